@@ -93,7 +93,11 @@ func main() {
 	w.RegisterWorkflow(workflows.ProcessingStepWorkflow) // For pipeline execution
 
 	// Register activities - agent needs execution and observability activities
-	localExecActivities := activities.NewLocalExecutionActivities()
+	defaultEnv, err := activities.AgentDefaultEnvFromEnviron()
+	if err != nil {
+		agentLog.Fatal().Err(err).Msg("Failed to read agent default env")
+	}
+	localExecActivities := activities.NewLocalExecutionActivities().WithDefaultEnv(defaultEnv)
 	w.RegisterActivity(localExecActivities.LocalExecuteActivity)
 
 	// Register PrepareAgentCommand function directly as PrepareAgentCommandActivity
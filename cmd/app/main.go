@@ -12,11 +12,16 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/noldarim/noldarim/internal/aiobs/adapters"
 	"github.com/noldarim/noldarim/internal/config"
 	"github.com/noldarim/noldarim/internal/logger"
 	"github.com/noldarim/noldarim/internal/orchestrator"
 	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/noldarim/noldarim/internal/rpc"
 	"github.com/noldarim/noldarim/internal/tui"
+	"github.com/noldarim/noldarim/internal/tui/editor"
+	"github.com/noldarim/noldarim/internal/tui/keys"
+	"github.com/noldarim/noldarim/internal/tui/toolstyle"
 )
 
 func main() {
@@ -40,6 +45,26 @@ func main() {
 	mainLog := logger.GetLogger("main")
 	mainLog.Info().Msg("Starting noldarim application")
 
+	// Apply keybinding overrides before any screen builds its key map
+	if err := keys.Configure(cfg.Keys); err != nil {
+		mainLog.Error().Err(err).Msg("Invalid keys configuration")
+		fmt.Fprintf(os.Stderr, "Error in keys configuration: %v\n", err)
+		os.Exit(1)
+	}
+	editor.Configure(cfg.Editor)
+	toolStyles := make(map[string]toolstyle.Style, len(cfg.TUI.ToolStyles))
+	for name, style := range cfg.TUI.ToolStyles {
+		toolStyles[name] = toolstyle.Style(style)
+	}
+	if err := toolstyle.Configure(toolStyles); err != nil {
+		mainLog.Error().Err(err).Msg("Invalid tui.tool_styles configuration")
+		fmt.Fprintf(os.Stderr, "Error in tui.tool_styles configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Register AI adapters; the task details screen reads prompts back from raw transcript lines
+	adapters.RegisterAll()
+
 	// Start pprof HTTP server for memory profiling
 	go func() {
 		mainLog.Info().Msg("Starting pprof server on localhost:6060")
@@ -87,11 +112,27 @@ func main() {
 		mainLog.Info().Msg("Orchestrator stopped")
 	}()
 
+	// Fan events out through a bus so other consumers can subscribe alongside the TUI
+	bus := orchestrator.NewEventBus(100)
+	go bus.Run(ctx, eventChan)
+	tuiEvents, unsubscribe := bus.Subscribe()
+	defer unsubscribe()
+
+	// Optional control socket for editors and scripts, alongside the TUI
+	if cfg.RPC.Enabled {
+		rpcServer := rpc.NewServer(cfg.RPC.SocketPath, cmdChan, bus)
+		go func() {
+			if err := rpcServer.Run(ctx); err != nil {
+				mainLog.Error().Err(err).Msg("RPC server failed")
+			}
+		}()
+	}
+
 	// Start TUI in background
 	tuiErrChan := make(chan error, 1)
 	go func() {
 		mainLog.Info().Msg("Starting TUI")
-		tuiErrChan <- tui.StartTUI(cmdChan, eventChan)
+		tuiErrChan <- tui.StartTUI(cmdChan, tuiEvents)
 	}()
 
 	// Wait for either signal or TUI to exit
@@ -1,14 +1,18 @@
 // Copyright (C) 2025-2026 Noldarim
 // SPDX-License-Identifier: AGPL-3.0-or-later
 
-// Command adapter parses Claude transcript.jsonl files through the adapter.
+// Command adapter parses transcript.jsonl files through an adapter (Claude by default).
+// Gzip-compressed transcripts (.jsonl.gz) are decompressed on the fly.
 // Usage:
 //
 //	go run cmd/dev/adapter/main.go <transcript.jsonl>
+//	go run cmd/dev/adapter/main.go --source gemini <transcript.jsonl>
+//	go run cmd/dev/adapter/main.go --source auto <transcript.jsonl>
 //	go run cmd/dev/adapter/main.go --raw <transcript.jsonl>
 //	go run cmd/dev/adapter/main.go --line 164 <transcript.jsonl>
 //	go run cmd/dev/adapter/main.go --type tool_use <transcript.jsonl>
 //	go run cmd/dev/adapter/main.go --stats <transcript.jsonl>
+//	go run cmd/dev/adapter/main.go <transcript.jsonl.gz>
 package main
 
 import (
@@ -20,7 +24,9 @@ import (
 	"strings"
 
 	"github.com/noldarim/noldarim/internal/aiobs/adapters"
+	"github.com/noldarim/noldarim/internal/aiobs/pricing"
 	"github.com/noldarim/noldarim/internal/aiobs/types"
+	"github.com/noldarim/noldarim/internal/aiobs/watcher"
 )
 
 var (
@@ -30,6 +36,7 @@ var (
 	startLine  int
 	endLine    int
 	showStats  bool
+	source     string
 )
 
 func init() {
@@ -44,11 +51,12 @@ func main() {
 	flag.IntVar(&startLine, "from", 0, "Start from line number")
 	flag.IntVar(&endLine, "to", 0, "End at line number")
 	flag.BoolVar(&showStats, "stats", false, "Show token usage statistics")
+	flag.StringVar(&source, "source", "claude", "Adapter to parse with (claude, gemini, codex, or auto to detect from the first line)")
 	flag.Parse()
 
 	args := flag.Args()
 	if len(args) < 1 {
-		fmt.Fprintf(os.Stderr, "Usage: %s [flags] <transcript.jsonl>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags] <transcript.jsonl|transcript.jsonl.gz>\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "\nFlags:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
@@ -57,21 +65,41 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s --type tool_use transcript.jsonl    # Show only tool_use events\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s --from 100 --to 110 transcript.jsonl # Show lines 100-110\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s --stats transcript.jsonl            # Show token statistics\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --source gemini transcript.jsonl    # Parse a Gemini CLI transcript\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --source auto transcript.jsonl      # Detect the adapter from the first line\n", os.Args[0])
 		os.Exit(1)
 	}
 
 	filename := args[0]
-	file, err := os.Open(filename)
+	file, err := watcher.OpenTranscript(filename)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
 		os.Exit(1)
 	}
-	defer file.Close()
-
-	adapter, ok := adapters.Get("claude")
-	if !ok {
-		fmt.Fprintf(os.Stderr, "Claude adapter not registered\n")
-		os.Exit(1)
+	defer func() { file.Close() }()
+
+	var adapter adapters.Adapter
+	if source == "auto" {
+		detected, ok := adapters.DetectReader(file)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Could not detect the transcript source, pass --source (registered: %s)\n", strings.Join(adapters.RegisteredAdapters(), ", "))
+			os.Exit(1)
+		}
+		// Detection consumed the start of the file, and a gzip stream cannot seek back
+		file.Close()
+		if file, err = watcher.OpenTranscript(filename); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reopening file: %v\n", err)
+			os.Exit(1)
+		}
+		adapter = detected
+		fmt.Fprintf(os.Stderr, "Detected source: %s\n", adapter.Name())
+	} else {
+		var ok bool
+		adapter, ok = adapters.Get(source)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Unknown source %q (registered: %s)\n", source, strings.Join(adapters.RegisteredAdapters(), ", "))
+			os.Exit(1)
+		}
 	}
 
 	scanner := bufio.NewScanner(file)
@@ -154,6 +182,9 @@ type Stats struct {
 	TotalOutputTokens      int
 	TotalCacheReadTokens   int
 	TotalCacheCreateTokens int
+	EstimatedCost          float64 // USD, over events whose model has known pricing
+	PricedEvents           int
+	UnpricedModels         map[string]bool
 	EventCounts            map[string]int
 	ToolCounts             map[string]int
 	Models                 map[string]int
@@ -164,6 +195,7 @@ func (s *Stats) Update(event types.ParsedEvent) {
 		s.EventCounts = make(map[string]int)
 		s.ToolCounts = make(map[string]int)
 		s.Models = make(map[string]int)
+		s.UnpricedModels = make(map[string]bool)
 	}
 
 	s.EventCounts[event.EventType]++
@@ -172,6 +204,15 @@ func (s *Stats) Update(event types.ParsedEvent) {
 	s.TotalCacheReadTokens += event.CacheReadTokens
 	s.TotalCacheCreateTokens += event.CacheCreateTokens
 
+	if event.InputTokens > 0 || event.OutputTokens > 0 || event.CacheReadTokens > 0 || event.CacheCreateTokens > 0 {
+		if cost, ok := pricing.EstimateCost(event.Model, event.InputTokens, event.OutputTokens, event.CacheReadTokens, event.CacheCreateTokens); ok {
+			s.EstimatedCost += cost
+			s.PricedEvents++
+		} else {
+			s.UnpricedModels[event.Model] = true
+		}
+	}
+
 	if event.ToolName != "" {
 		s.ToolCounts[event.ToolName]++
 	}
@@ -186,6 +227,15 @@ func (s *Stats) Print() {
 	fmt.Printf("  Output Tokens:       %d\n", s.TotalOutputTokens)
 	fmt.Printf("  Cache Read Tokens:   %d\n", s.TotalCacheReadTokens)
 	fmt.Printf("  Cache Create Tokens: %d\n", s.TotalCacheCreateTokens)
+	if s.PricedEvents > 0 {
+		fmt.Printf("  Estimated Cost:      $%.4f\n", s.EstimatedCost)
+	}
+	for model := range s.UnpricedModels {
+		if model == "" {
+			model = "(no model)"
+		}
+		fmt.Printf("  No pricing for:      %s\n", model)
+	}
 
 	fmt.Println("\n=== Event Type Counts ===")
 	for eventType, count := range s.EventCounts {
@@ -246,6 +296,9 @@ func printEvent(lineNum int, event types.ParsedEvent, rawLine []byte) {
 	if event.ToolName != "" {
 		fmt.Printf("  ToolName:     %s\n", event.ToolName)
 	}
+	if event.ToolNamespace != "" {
+		fmt.Printf("  MCP Server:   %s\n", event.ToolNamespace)
+	}
 	if event.ToolInputSummary != "" {
 		fmt.Printf("  ToolInput:    %s\n", event.ToolInputSummary)
 	}
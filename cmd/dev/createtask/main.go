@@ -14,6 +14,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/noldarim/noldarim/internal/aiobs/adapters"
 	"github.com/noldarim/noldarim/internal/config"
 	"github.com/noldarim/noldarim/internal/orchestrator"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
@@ -30,9 +31,12 @@ func main() {
 	prompt := flag.String("prompt", "", "Custom prompt template (default: read task file and implement)")
 	toolName := flag.String("tool", "claude", "Agent tool to use (claude, test)")
 	timeout := flag.Duration("timeout", 10*time.Minute, "Timeout for task completion")
+	subdir := flag.String("subdir", "", "Repository subdirectory to run the agent in (default: repo root)")
 
 	flag.Parse()
 
+	adapters.RegisterAll()
+
 	// Load config
 	cfg, err := config.NewConfig("test-config.yaml")
 	if err != nil {
@@ -131,10 +135,11 @@ func main() {
 			TaskID:  taskID,
 			Version: protocol.CurrentProtocolVersion,
 		},
-		ProjectID:   resolvedProjectID,
-		Title:       *title,
-		Description: *description,
-		AgentConfig: agentConfig,
+		ProjectID:     resolvedProjectID,
+		Title:         *title,
+		Description:   *description,
+		AgentConfig:   agentConfig,
+		WorkingSubdir: *subdir,
 	}
 
 	fmt.Println("\n========================================")
@@ -198,44 +203,47 @@ func printEvent(elapsed time.Duration, event protocol.Event) {
 
 	case *models.AIActivityRecord:
 		// Print AI record with meaningful content using flat fields
-		switch evt.EventType {
-		case models.AIEventToolUse:
+		icon := evt.EventType.DisplayIcon()
+		switch {
+		case evt.EventType == models.AIEventToolUse:
 			if evt.ToolInputSummary != "" {
-				fmt.Printf("%s > %s: %s\n", timestamp, evt.ToolName, truncateStr(evt.ToolInputSummary, 80))
+				fmt.Printf("%s %s %s: %s\n", timestamp, icon, evt.ToolName, truncateStr(evt.ToolInputSummary, 80))
 			} else {
-				fmt.Printf("%s > %s\n", timestamp, evt.ToolName)
+				fmt.Printf("%s %s %s\n", timestamp, icon, evt.ToolName)
 			}
-		case models.AIEventToolResult:
+		case evt.EventType == models.AIEventToolResult:
 			status := "OK"
 			if evt.ToolSuccess != nil && !*evt.ToolSuccess {
 				status = "ERR"
 			}
-			fmt.Printf("%s < %s [%s]\n", timestamp, evt.ToolName, status)
-		case models.AIEventThinking:
+			fmt.Printf("%s %s %s [%s]\n", timestamp, icon, evt.ToolName, status)
+		case evt.EventType == models.AIEventUserPrompt:
+			prompt := extractUserPromptContent(evt.RawPayload)
+			if prompt != "" {
+				fmt.Printf("%s User: %s\n", timestamp, truncateStr(prompt, 80))
+			} else {
+				fmt.Printf("%s User prompt submitted\n", timestamp)
+			}
+		case evt.EventType == models.AIEventError:
+			fmt.Printf("%s %s Error: %s\n", timestamp, icon, truncateStr(evt.ContentPreview, 100))
+		case evt.EventType.IsTerminal():
+			reason := "completed"
+			if evt.StopReason != "" {
+				reason = evt.StopReason
+			}
+			fmt.Printf("%s %s Session ended: %s\n", timestamp, icon, reason)
+		case evt.EventType == models.AIEventThinking:
 			content := evt.ContentPreview
 			if len(content) > 100 {
 				content = content[:100] + "..."
 			}
-			fmt.Printf("%s ~ %s\n", timestamp, content)
-		case models.AIEventAIOutput:
+			fmt.Printf("%s %s %s\n", timestamp, icon, content)
+		case evt.EventType.IsContentBearing():
 			content := evt.ContentPreview
 			if len(content) > 200 {
 				content = content[:200] + "..."
 			}
-			fmt.Printf("%s   %s\n", timestamp, content)
-		case models.AIEventSessionEnd, models.AIEventStop:
-			reason := "completed"
-			if evt.StopReason != "" {
-				reason = evt.StopReason
-			}
-			fmt.Printf("%s X Session ended: %s\n", timestamp, reason)
-		case models.AIEventUserPrompt:
-			prompt := extractUserPromptContent(evt.RawPayload)
-			if prompt != "" {
-				fmt.Printf("%s User: %s\n", timestamp, truncateStr(prompt, 80))
-			} else {
-				fmt.Printf("%s User prompt submitted\n", timestamp)
-			}
+			fmt.Printf("%s %s %s\n", timestamp, icon, content)
 		default:
 			fmt.Printf("%s [%s]\n", timestamp, evt.EventType)
 		}
@@ -276,24 +284,18 @@ func getLifecycleIcon(eventType protocol.TaskLifecycleType) string {
 	}
 }
 
-// extractUserPromptContent extracts the prompt from raw payload
+// extractUserPromptContent extracts the prompt from a user prompt record's raw payload
 func extractUserPromptContent(raw string) string {
 	if raw == "" {
 		return ""
 	}
 
-	var data map[string]interface{}
-	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+	adapter, ok := adapters.Get("claude")
+	if !ok {
 		return ""
 	}
-
-	for _, key := range []string{"prompt", "message", "content", "user_message"} {
-		if v, ok := data[key].(string); ok && v != "" {
-			return v
-		}
-	}
-
-	return ""
+	prompt, _ := adapter.ExtractTaskPrompt([]adapters.RawEntry{{Data: json.RawMessage(raw)}})
+	return prompt
 }
 
 // truncateStr shortens a string to max length with ellipsis
@@ -7,6 +7,7 @@
 //	go run cmd/dev/dbexplorer/main.go --task-id <id>
 //	go run cmd/dev/dbexplorer/main.go --latest
 //	go run cmd/dev/dbexplorer/main.go --list-tasks
+//	go run cmd/dev/dbexplorer/main.go --latest --search "tool:Bash go test"
 package main
 
 import (
@@ -29,6 +30,7 @@ func main() {
 	showRaw := flag.Bool("raw", false, "Show full raw payload")
 	limit := flag.Int("limit", 50, "Maximum number of events to show")
 	eventType := flag.String("type", "", "Filter by event type (tool_use, tool_result, thinking, output, etc.)")
+	search := flag.String("search", "", "Only show events whose content, tool name or tool input contains this text (prefix with tool:<name> to restrict to a tool)")
 	configFile := flag.String("config", "test-config.yaml", "Config file path")
 
 	flag.Parse()
@@ -70,8 +72,20 @@ func main() {
 		fmt.Printf("Using latest task: %s (%s)\n", task.Title, resolvedTaskID)
 	}
 
-	// Load events for task
-	events, err := ds.GetAIActivityByTask(ctx, resolvedTaskID)
+	// Load events for task. Without a type filter, a limit is applied by the query
+	// instead of loading the task's whole history.
+	var events []*models.AIActivityRecord
+	total := 0
+	switch {
+	case *search != "":
+		events, err = ds.SearchAIActivity(ctx, resolvedTaskID, *search, 0)
+		total = len(events)
+	case *limit > 0 && *eventType == "":
+		events, total, err = ds.GetAIActivityByTaskPaged(ctx, resolvedTaskID, 0, *limit)
+	default:
+		events, err = ds.GetAIActivityByTask(ctx, resolvedTaskID)
+		total = len(events)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load events: %v\n", err)
 		os.Exit(1)
@@ -86,16 +100,23 @@ func main() {
 			}
 		}
 		events = filtered
+		total = len(events)
 	}
 
-	fmt.Printf("\nFound %d events for task %s\n", len(events), resolvedTaskID)
+	if *search != "" {
+		fmt.Printf("\nFound %d events matching %q for task %s\n", total, *search, resolvedTaskID)
+	} else {
+		fmt.Printf("\nFound %d events for task %s\n", total, resolvedTaskID)
+	}
 	fmt.Println(strings.Repeat("=", 60))
 
 	// Apply limit
 	if *limit > 0 && len(events) > *limit {
-		fmt.Printf("(showing first %d of %d events)\n", *limit, len(events))
 		events = events[:*limit]
 	}
+	if len(events) < total {
+		fmt.Printf("(showing first %d of %d events)\n", len(events), total)
+	}
 
 	for i, event := range events {
 		printEvent(i+1, event, *showRaw)
@@ -145,17 +166,23 @@ func printEvent(num int, record *models.AIActivityRecord, showRaw bool) {
 	fmt.Printf("  Type:      %s\n", record.EventType)
 	fmt.Printf("  Timestamp: %s\n", record.Timestamp.Format("2006-01-02 15:04:05.000"))
 	fmt.Printf("  SessionID: %s\n", record.SessionID)
+	if origin := record.Origin(); origin != "" {
+		fmt.Printf("  Origin:    %s\n", origin)
+	}
 
 	// Print type-specific data based on flat fields
-	switch record.EventType {
-	case "tool_use":
+	switch {
+	case record.EventType == models.AIEventToolUse:
 		fmt.Printf("  ToolCall:\n")
 		fmt.Printf("    Name:  %s\n", record.ToolName)
+		if record.ToolNamespace != "" {
+			fmt.Printf("    MCP:   %s\n", record.ToolNamespace)
+		}
 		fmt.Printf("    Input: %s\n", truncate(record.ToolInputSummary, 200))
 		if record.FilePath != "" {
 			fmt.Printf("    Path:  %s\n", record.FilePath)
 		}
-	case "tool_result":
+	case record.EventType == models.AIEventToolResult:
 		fmt.Printf("  ToolResult:\n")
 		fmt.Printf("    Tool:    %s\n", record.ToolName)
 		if record.ToolSuccess != nil {
@@ -165,14 +192,14 @@ func printEvent(num int, record *models.AIActivityRecord, showRaw bool) {
 		if record.ToolError != "" {
 			fmt.Printf("    Error:   %s\n", record.ToolError)
 		}
-	case "ai_output", "user_prompt":
-		fmt.Printf("  Content: %s\n", truncate(record.ContentPreview, 300))
-	case "thinking":
-		fmt.Printf("  Thinking: %s\n", truncate(record.ContentPreview, 300))
-	case "error":
+	case record.EventType == models.AIEventError:
 		fmt.Printf("  Error: %s\n", record.ContentPreview)
-	case "session_end":
+	case record.EventType.IsTerminal():
 		fmt.Printf("  Stop: %s\n", record.StopReason)
+	case record.EventType == models.AIEventThinking:
+		fmt.Printf("  Thinking: %s\n", truncate(record.ContentPreview, 300))
+	case record.EventType.IsContentBearing():
+		fmt.Printf("  Content: %s\n", truncate(record.ContentPreview, 300))
 	}
 
 	// Show token usage if available
@@ -201,12 +228,16 @@ func printSummary(events []*models.AIActivityRecord) {
 	// Count by type
 	typeCounts := make(map[models.AIEventType]int)
 	toolCounts := make(map[string]int)
+	namespaceCounts := make(map[string]int)
 	totalTokens := 0
 
 	for _, e := range events {
 		typeCounts[e.EventType]++
 		if e.EventType == models.AIEventToolUse && e.ToolName != "" {
 			toolCounts[e.ToolName]++
+			if e.ToolNamespace != "" {
+				namespaceCounts[e.ToolNamespace]++
+			}
 		}
 		totalTokens += e.InputTokens + e.OutputTokens
 	}
@@ -226,6 +257,13 @@ func printSummary(events []*models.AIActivityRecord) {
 		}
 	}
 
+	if len(namespaceCounts) > 0 {
+		fmt.Println("\nMCP Tool Calls by Server:")
+		for namespace, count := range namespaceCounts {
+			fmt.Printf("  %-20s: %d\n", namespace, count)
+		}
+	}
+
 	if totalTokens > 0 {
 		fmt.Printf("\nTotal Tokens: %d\n", totalTokens)
 	}
@@ -13,6 +13,8 @@
 //	go run cmd/dev/obsharness/main.go --watch /path/to/transcript/dir --task-id my-test-task
 //	go run cmd/dev/obsharness/main.go --file /path/to/transcript.jsonl --no-save
 //	go run cmd/dev/obsharness/main.go --file /path/to/transcript.jsonl --tui
+//	go run cmd/dev/obsharness/main.go --file /path/to/gemini.jsonl --source gemini --no-save
+//	go run cmd/dev/obsharness/main.go --file /path/to/unknown.jsonl --source auto --no-save
 //
 // You can then write test events to the watched directory:
 //
@@ -52,6 +54,7 @@ func main() {
 	configFile := flag.String("config", "test-config.yaml", "Config file path")
 	verbose := flag.Bool("verbose", false, "Show verbose output including parse details")
 	useTUI := flag.Bool("tui", false, "Use real TUI component for display")
+	source := flag.String("source", "claude", "Transcript source adapter (claude, gemini, codex, or auto to detect from the file)")
 
 	flag.Parse()
 
@@ -61,6 +64,7 @@ func main() {
 		fmt.Fprintf(os.Stderr, "File mode:  processes a single transcript file\n")
 		fmt.Fprintf(os.Stderr, "\nFlags:\n")
 		fmt.Fprintf(os.Stderr, "  --tui     Use real Bubble Tea TUI component\n")
+		fmt.Fprintf(os.Stderr, "  --source  Transcript source adapter (default claude, auto detects it in file mode)\n")
 		os.Exit(1)
 	}
 
@@ -89,10 +93,26 @@ func main() {
 	}
 
 	// Get adapter
-	adapter, ok := adapters.Get("claude")
-	if !ok {
-		fmt.Fprintf(os.Stderr, "Claude adapter not registered\n")
-		os.Exit(1)
+	var adapter adapters.Adapter
+	if *source == "auto" {
+		if *inputFile == "" {
+			fmt.Fprintf(os.Stderr, "--source auto needs --file; pass the source explicitly in watch mode\n")
+			os.Exit(1)
+		}
+		detected, err := detectSource(*inputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		adapter = detected
+		fmt.Printf("Detected source: %s\n", adapter.Name())
+	} else {
+		var ok bool
+		adapter, ok = adapters.Get(*source)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Unknown source %q (registered: %s)\n", *source, strings.Join(adapters.RegisteredAdapters(), ", "))
+			os.Exit(1)
+		}
 	}
 
 	processor := &eventProcessor{
@@ -120,6 +140,21 @@ func main() {
 	runWatchMode(ctx, *watchDir, processor)
 }
 
+// detectSource picks the adapter from the first non-empty line of the file
+func detectSource(filePath string) (adapters.Adapter, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	adapter, ok := adapters.DetectReader(file)
+	if !ok {
+		return nil, fmt.Errorf("could not detect the transcript source, pass --source (registered: %s)", strings.Join(adapters.RegisteredAdapters(), ", "))
+	}
+	return adapter, nil
+}
+
 type eventProcessor struct {
 	adapter   adapters.Adapter
 	ds        *services.DataService
@@ -129,6 +164,11 @@ type eventProcessor struct {
 	showRaw   bool
 	verbose   bool
 	count     int
+
+	// In batch mode (file mode), records are collected in pending and saved by flush
+	// in one bulk insert instead of one INSERT per event
+	batch   bool
+	pending []*models.AIActivityRecord
 }
 
 func (p *eventProcessor) process(ctx context.Context, rawLine []byte, timestamp time.Time) {
@@ -183,7 +223,9 @@ func (p *eventProcessor) process(ctx context.Context, rawLine []byte, timestamp
 			event.EventID = models.GenerateEventID()
 			event.RawPayload = rawLine
 			record := models.NewAIActivityRecordFromParsed(event, p.taskID, "", "") // Empty RunID/StepID for dev harness
-			if err := p.ds.SaveAIActivityRecord(ctx, record); err != nil {
+			if p.batch {
+				p.pending = append(p.pending, record)
+			} else if err := p.ds.SaveAIActivityRecord(ctx, record); err != nil {
 				fmt.Printf("  DB SAVE ERROR: %v\n", err)
 			} else if p.verbose {
 				fmt.Printf("  Saved: %s\n", record.EventID)
@@ -196,6 +238,19 @@ func (p *eventProcessor) process(ctx context.Context, rawLine []byte, timestamp
 	}
 }
 
+// flush saves the records collected in batch mode
+func (p *eventProcessor) flush(ctx context.Context) {
+	if len(p.pending) == 0 {
+		return
+	}
+	if err := p.ds.SaveAIActivityRecords(ctx, p.pending); err != nil {
+		fmt.Printf("DB SAVE ERROR: %v (%d records not saved)\n", err, len(p.pending))
+	} else {
+		fmt.Printf("Saved %d records\n", len(p.pending))
+	}
+	p.pending = nil
+}
+
 func processFile(filePath string, processor *eventProcessor) {
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -208,6 +263,7 @@ func processFile(filePath string, processor *eventProcessor) {
 	fmt.Println(strings.Repeat("=", 60))
 
 	ctx := context.Background()
+	processor.batch = true
 	scanner := bufio.NewScanner(file)
 	scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
 
@@ -228,6 +284,7 @@ func processFile(filePath string, processor *eventProcessor) {
 
 	fmt.Printf("\n%s\n", strings.Repeat("=", 60))
 	fmt.Printf("Processed %d events\n", processor.count)
+	processor.flush(ctx)
 }
 
 // ============================================================================
@@ -405,14 +462,14 @@ func runWatchMode(ctx context.Context, watchDir string, processor *eventProcesso
 	fmt.Printf("Task ID: %s\n", processor.taskID)
 	fmt.Printf("Save to DB: %v\n", !processor.noSave)
 	fmt.Println(strings.Repeat("=", 60))
-	fmt.Println("Waiting for events... (Ctrl+C to stop)")
+	fmt.Println("Waiting for events... (Enter to pause/resume, Ctrl+C to stop)")
 	fmt.Println("Write test events to a .jsonl file in the watched directory")
 	fmt.Println()
 
 	// Create watcher
 	cfg := watcher.Config{
 		FilePath:        watchDir,
-		Source:          "claude",
+		Source:          processor.adapter.Name(),
 		EventBufferSize: 100,
 		PollInterval:    100 * time.Millisecond,
 		DiscoverUUID:    true,
@@ -429,33 +486,56 @@ func runWatchMode(ctx context.Context, watchDir string, processor *eventProcesso
 		fmt.Fprintf(os.Stderr, "Failed to start watcher: %v\n", err)
 		os.Exit(1)
 	}
-	defer w.Stop()
+	defer func() {
+		w.Stop()
+		if final, ok := w.FinalStats(); ok {
+			fmt.Printf("Watcher summary: %s\n", final)
+		}
+	}()
 
 	// Handle signals
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
+	// Toggle pause on each line read from stdin
+	toggleChan := make(chan struct{})
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			toggleChan <- struct{}{}
+		}
+	}()
+
 	rawEvents := w.RawEvents()
 	errors := w.Errors()
 	done := w.Done()
 
 	for {
 		select {
+		case <-toggleChan:
+			if w.IsPaused() {
+				w.Resume()
+				fmt.Printf("Resumed (%d events pending)\n", w.Stats().PendingEvents)
+			} else {
+				w.Pause()
+				fmt.Println("Paused - events are buffered until resumed")
+			}
+
 		case <-ctx.Done():
 			fmt.Println("\nContext cancelled, stopping...")
 			return
 
 		case <-sigChan:
-			fmt.Printf("\n\nStopping... Processed %d events total\n", processor.count)
+			fmt.Println("\n\nStopping...")
 			return
 
 		case <-done:
-			fmt.Printf("\nWatcher done. Processed %d events total\n", processor.count)
+			fmt.Println("\nWatcher done.")
 			return
 
 		case rawLine, ok := <-rawEvents:
 			if !ok {
-				fmt.Printf("\nEvent channel closed. Processed %d events total\n", processor.count)
+				fmt.Println("\nEvent channel closed.")
 				return
 			}
 			processor.process(ctx, rawLine.Line, rawLine.Timestamp)
@@ -482,19 +562,21 @@ func printTUIRepresentation(event *types.ParsedEvent, rawLine []byte) {
 		return
 	}
 
-	switch event.EventType {
-	case types.EventTypeToolUse:
+	eventType := models.AIEventType(event.EventType)
+	icon := eventType.DisplayIcon()
+	switch {
+	case eventType == models.AIEventToolUse:
 		if event.ToolName != "" {
 			if event.ToolInputSummary != "" {
-				fmt.Printf("  > %s: %s\n", event.ToolName, truncate(event.ToolInputSummary, 80))
+				fmt.Printf("  %s %s: %s\n", icon, event.ToolName, truncate(event.ToolInputSummary, 80))
 			} else {
-				fmt.Printf("  > %s\n", event.ToolName)
+				fmt.Printf("  %s %s\n", icon, event.ToolName)
 			}
 		} else {
-			fmt.Printf("  > [tool_use]\n")
+			fmt.Printf("  %s [tool_use]\n", icon)
 		}
 
-	case types.EventTypeToolResult:
+	case eventType == models.AIEventToolResult:
 		status := "OK"
 		if event.ToolSuccess != nil && !*event.ToolSuccess {
 			status = "ERR"
@@ -504,40 +586,40 @@ func printTUIRepresentation(event *types.ParsedEvent, rawLine []byte) {
 			toolName = "tool"
 		}
 		if event.ContentPreview != "" {
-			fmt.Printf("  < %s [%s]: %s\n", toolName, status, truncate(event.ContentPreview, 60))
+			fmt.Printf("  %s %s [%s]: %s\n", icon, toolName, status, truncate(event.ContentPreview, 60))
 		} else {
-			fmt.Printf("  < %s [%s]\n", toolName, status)
+			fmt.Printf("  %s %s [%s]\n", icon, toolName, status)
 		}
 
-	case types.EventTypeThinking:
+	case eventType == models.AIEventError:
 		if event.ContentPreview != "" {
-			fmt.Printf("  ~ %s\n", truncate(event.ContentPreview, 100))
+			fmt.Printf("  %s Error: %s\n", icon, truncate(event.ContentPreview, 100))
 		} else {
-			fmt.Printf("  ~ [thinking]\n")
+			fmt.Printf("  %s Error\n", icon)
 		}
 
-	case types.EventTypeAIOutput:
+	case eventType.IsTerminal():
+		fmt.Printf("  %s Session ended\n", icon)
+
+	case eventType == models.AIEventUserPrompt:
 		if event.ContentPreview != "" {
-			fmt.Printf("    %s\n", truncate(event.ContentPreview, 120))
+			fmt.Printf("  %s User: %s\n", icon, truncate(event.ContentPreview, 100))
 		} else {
-			fmt.Printf("    [output]\n")
+			fmt.Printf("  %s User prompt\n", icon)
 		}
 
-	case types.EventTypeSessionEnd:
-		fmt.Printf("  X Session ended\n")
-
-	case types.EventTypeError:
+	case eventType == models.AIEventThinking:
 		if event.ContentPreview != "" {
-			fmt.Printf("  ! Error: %s\n", truncate(event.ContentPreview, 100))
+			fmt.Printf("  %s %s\n", icon, truncate(event.ContentPreview, 100))
 		} else {
-			fmt.Printf("  ! Error\n")
+			fmt.Printf("  %s [thinking]\n", icon)
 		}
 
-	case types.EventTypeUserPrompt:
+	case eventType.IsContentBearing():
 		if event.ContentPreview != "" {
-			fmt.Printf("  @ User: %s\n", truncate(event.ContentPreview, 100))
+			fmt.Printf("  %s %s\n", icon, truncate(event.ContentPreview, 120))
 		} else {
-			fmt.Printf("  @ User prompt\n")
+			fmt.Printf("  %s [%s]\n", icon, event.EventType)
 		}
 
 	default:
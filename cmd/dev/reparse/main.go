@@ -12,9 +12,11 @@
 //	go run cmd/dev/reparse/main.go --diff            # Show only records where parsing changed
 //	go run cmd/dev/reparse/main.go --bench           # Run parsing benchmark
 //	go run cmd/dev/reparse/main.go --update          # Re-parse and update records in DB
+//	go run cmd/dev/reparse/main.go --file t.jsonl.gz # Re-parse the lines of a transcript instead of the DB
 package main
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"flag"
@@ -25,6 +27,7 @@ import (
 
 	"github.com/noldarim/noldarim/internal/aiobs/adapters"
 	"github.com/noldarim/noldarim/internal/aiobs/types"
+	"github.com/noldarim/noldarim/internal/aiobs/watcher"
 	"github.com/noldarim/noldarim/internal/config"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 	"github.com/noldarim/noldarim/internal/orchestrator/services"
@@ -37,6 +40,7 @@ var (
 	runBench    bool
 	updateDB    bool
 	verbose     bool
+	file        string
 )
 
 func init() {
@@ -50,8 +54,34 @@ func main() {
 	flag.BoolVar(&runBench, "bench", false, "Run parsing benchmark")
 	flag.BoolVar(&updateDB, "update", false, "Update records in database with new parsed values")
 	flag.BoolVar(&verbose, "v", false, "Verbose output")
+	flag.StringVar(&file, "file", "", "Re-parse the lines of a transcript (.jsonl or .jsonl.gz) instead of stored records")
 	flag.Parse()
 
+	adapter, ok := adapters.Get("claude")
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Claude adapter not registered\n")
+		os.Exit(1)
+	}
+
+	if file != "" {
+		if updateDB {
+			fmt.Fprintf(os.Stderr, "--update cannot be used with --file\n")
+			os.Exit(1)
+		}
+		records, err := loadTranscript(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading transcript: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Read %d lines from %s\n\n", len(records), file)
+		if runBench {
+			runBenchmark(records, adapter)
+			return
+		}
+		reparse(records, adapter, nil)
+		return
+	}
+
 	cfg, err := config.NewConfig("config.yaml")
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
@@ -65,12 +95,6 @@ func main() {
 	}
 	defer dataService.Close()
 
-	adapter, ok := adapters.Get("claude")
-	if !ok {
-		fmt.Fprintf(os.Stderr, "Claude adapter not registered\n")
-		os.Exit(1)
-	}
-
 	ctx := context.Background()
 	records, err := dataService.GetAIActivityByEventType(ctx, eventType, limit)
 	if err != nil {
@@ -85,11 +109,20 @@ func main() {
 		return
 	}
 
+	reparse(records, adapter, func(toUpdate []*models.AIActivityRecord) error {
+		return dataService.SaveAIActivityRecords(ctx, toUpdate)
+	})
+}
+
+// reparse re-parses records and prints what changed. save, when set, stores the
+// changed records (--update).
+func reparse(records []*models.AIActivityRecord, adapter types.Adapter, save func([]*models.AIActivityRecord) error) {
 	// Re-parse and compare
 	changed := 0
-	updated := 0
+	var toUpdate []*models.AIActivityRecord
 	for _, rec := range records {
 		rawEntry := types.RawEntry{
+			Line:      rec.SourceLine,
 			Data:      json.RawMessage(rec.RawPayload),
 			SessionID: types.ExtractSessionID(json.RawMessage(rec.RawPayload)),
 		}
@@ -133,13 +166,12 @@ func main() {
 			if updateDB {
 				rec.ContentPreview = newEvent.ContentPreview
 				rec.ToolName = newEvent.ToolName
+				rec.ToolNamespace = newEvent.ToolNamespace
 				rec.FilePath = newEvent.FilePath
 				rec.ContentLength = newEvent.ContentLength
-				if err := dataService.UpdateAIActivityRecord(ctx, rec); err != nil {
-					fmt.Printf("❌ %s: update error: %v\n", rec.EventID, err)
-				} else {
-					updated++
-				}
+				rec.ResultContentType = string(newEvent.ResultContentType)
+				rec.Attachments = models.Attachments(newEvent.Attachments)
+				toUpdate = append(toUpdate, rec)
 			}
 		}
 
@@ -149,6 +181,9 @@ func main() {
 
 		// Print result
 		fmt.Printf("─── %s ───\n", rec.EventID)
+		if origin := rec.Origin(); origin != "" {
+			fmt.Printf("  Origin:    %s\n", origin)
+		}
 
 		if toolChanged {
 			fmt.Printf("  ToolName:  %q → %q\n", oldToolName, newEvent.ToolName)
@@ -172,6 +207,16 @@ func main() {
 		fmt.Println()
 	}
 
+	// Write all changed records back in one bulk upsert
+	updated := 0
+	if updateDB && save != nil {
+		if err := save(toUpdate); err != nil {
+			fmt.Printf("❌ update error, no records updated: %v\n", err)
+		} else {
+			updated = len(toUpdate)
+		}
+	}
+
 	fmt.Printf("─── Summary ───\n")
 	fmt.Printf("  Total:   %d records\n", len(records))
 	fmt.Printf("  Changed: %d records\n", changed)
@@ -180,6 +225,36 @@ func main() {
 	}
 }
 
+// loadTranscript reads the lines of a transcript file as records with nothing parsed
+// yet, so every event the adapter produces shows up as a change
+func loadTranscript(path string) ([]*models.AIActivityRecord, error) {
+	r, err := watcher.OpenTranscript(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var records []*models.AIActivityRecord
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		records = append(records, &models.AIActivityRecord{
+			EventID:    fmt.Sprintf("line %d", lineNum),
+			SourceLine: lineNum,
+			RawPayload: string(scanner.Bytes()),
+		})
+		if limit > 0 && len(records) == limit {
+			break
+		}
+	}
+	return records, scanner.Err()
+}
+
 func runBenchmark(records []*models.AIActivityRecord, adapter types.Adapter) {
 	if len(records) == 0 {
 		fmt.Println("No records to benchmark")
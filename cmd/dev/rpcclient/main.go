@@ -0,0 +1,158 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// rpcclient is a smoke-test client for the JSON-RPC control socket.
+//
+// Usage:
+//
+//	go run ./cmd/dev/rpcclient projects
+//	go run ./cmd/dev/rpcclient tasks <project-id> [label...]
+//	go run ./cmd/dev/rpcclient create <project-id> <title> [description]
+//	go run ./cmd/dev/rpcclient retry <project-id> <base-commit> <title> [description]
+//	go run ./cmd/dev/rpcclient cancel <run-id>
+//	go run ./cmd/dev/rpcclient unqueue <project-id> <queue-id>
+//	go run ./cmd/dev/rpcclient rebase <project-id> <task-id> [onto]
+//	go run ./cmd/dev/rpcclient assemble <project-id> <target-branch> <task-id>...
+//	go run ./cmd/dev/rpcclient watch
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/noldarim/noldarim/internal/rpc"
+)
+
+func main() {
+	configPath := flag.String("config", "config.yaml", "Config file to read rpc.socket_path from")
+	socket := flag.String("socket", "", "Socket path (overrides the config)")
+	timeout := flag.Duration("timeout", 2*time.Minute, "Timeout for a single call")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	socketPath := *socket
+	if socketPath == "" {
+		cfg, err := config.NewConfig(*configPath)
+		if err != nil {
+			log.Fatalf("Failed to load config: %v", err)
+		}
+		socketPath = cfg.RPC.SocketPath
+	}
+
+	client, err := rpc.Dial(socketPath)
+	if err != nil {
+		log.Fatalf("Is the RPC server enabled (rpc.enabled: true)? %v", err)
+	}
+	defer client.Close()
+
+	if args[0] == "watch" {
+		watch(client)
+		return
+	}
+
+	method, params := request(args)
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	var result rpc.EventMessage
+	if err := client.Call(ctx, method, params, &result); err != nil {
+		log.Fatalf("%s failed: %v", method, err)
+	}
+	printEvent(result)
+}
+
+// request maps a subcommand to its RPC method and params
+func request(args []string) (string, any) {
+	need := func(n int) {
+		if len(args) < n {
+			log.Fatalf("%s: expected %d arguments, got %d", args[0], n-1, len(args)-1)
+		}
+	}
+
+	switch args[0] {
+	case "projects":
+		return rpc.MethodListProjects, nil
+	case "tasks":
+		need(2)
+		return rpc.MethodListTasks, protocol.LoadTasksCommand{ProjectID: args[1], Labels: args[2:]}
+	case "create":
+		need(3)
+		cmd := protocol.CreateTaskCommand{ProjectID: args[1], Title: args[2]}
+		if len(args) > 3 {
+			cmd.Description = args[3]
+		}
+		return rpc.MethodCreateTask, cmd
+	case "retry":
+		need(4)
+		cmd := protocol.CreateTaskCommand{ProjectID: args[1], BaseCommitSHA: args[2], Title: args[3]}
+		if len(args) > 4 {
+			cmd.Description = args[4]
+		}
+		return rpc.MethodRetryTask, cmd
+	case "cancel":
+		need(2)
+		return rpc.MethodCancelTask, protocol.CancelPipelineCommand{RunID: args[1], Reason: "Cancelled via rpcclient"}
+	case "unqueue":
+		need(3)
+		return rpc.MethodCancelQueuedTask, protocol.CancelQueuedTaskCommand{ProjectID: args[1], QueueID: args[2]}
+	case "rebase":
+		need(3)
+		cmd := protocol.RebaseTaskCommand{ProjectID: args[1], TaskID: args[2]}
+		if len(args) > 3 {
+			cmd.Onto = args[3]
+		}
+		return rpc.MethodRebaseTask, cmd
+	case "assemble":
+		need(4)
+		return rpc.MethodAssembleBranch, protocol.AssembleBranchCommand{ProjectID: args[1], TargetBranch: args[2], SourceTaskIDs: args[3:]}
+	default:
+		log.Fatalf("Unknown command %q", args[0])
+		return "", nil
+	}
+}
+
+func watch(client *rpc.Client) {
+	if err := client.Subscribe(context.Background()); err != nil {
+		log.Fatalf("Subscribe failed: %v", err)
+	}
+	fmt.Println("Watching events (Ctrl+C to stop)...")
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	for {
+		select {
+		case msg, ok := <-client.Events():
+			if !ok {
+				fmt.Println("Connection closed")
+				return
+			}
+			printEvent(msg)
+		case <-sigChan:
+			return
+		}
+	}
+}
+
+func printEvent(msg rpc.EventMessage) {
+	var pretty any
+	if err := json.Unmarshal(msg.Event, &pretty); err != nil {
+		fmt.Printf("%s %s\n", msg.Type, msg.Event)
+		return
+	}
+	out, _ := json.MarshalIndent(pretty, "", "  ")
+	fmt.Printf("%s %s\n", msg.Type, out)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/noldarim/noldarim/internal/aiobs/types"
 	"github.com/noldarim/noldarim/internal/config"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 	"github.com/noldarim/noldarim/internal/orchestrator/services"
@@ -50,10 +51,14 @@ func loadActivities() []activityfeed.Activity {
 		activities[i] = activityfeed.Activity{
 			EventType:      convertEventType(r.EventType),
 			ToolName:       r.ToolName,
+			ToolNamespace:  r.ToolNamespace,
 			ContentPreview: r.ContentPreview,
 			FilePath:       r.FilePath,
 			ToolSuccess:    r.ToolSuccess,
 			ToolError:      r.ToolError,
+			ContentType:    types.ContentType(r.ResultContentType),
+			ContentLength:  r.ContentLength,
+			Attachments:    r.Attachments,
 		}
 	}
 
@@ -145,65 +145,15 @@ func (m model) View() string {
 	// Show main commit graph
 	selectedHash := m.commits[m.selectedIndex].HashPtr()
 
-	// Create a color palette for different branches
-	branchColors := []string{
-		"1",  // Red
-		"2",  // Green
-		"3",  // Yellow
-		"4",  // Blue
-		"5",  // Magenta
-		"6",  // Cyan
-		"9",  // Bright Red
-		"10", // Bright Green
-		"11", // Bright Yellow
-		"12", // Bright Blue
-		"13", // Bright Magenta
-		"14", // Bright Cyan
-	}
-
-	// Build a map of commit hash to color based on graph position
-	colorMap := make(map[*string]string)
-
-	// First pass: assign colors to commits based on their graph position
-	tempGetStyle := func(c *commitgraph.Commit) *lipgloss.Style {
-		s := lipgloss.NewStyle().Foreground(lipgloss.Color("7")) // Default gray
-		return &s
-	}
-
-	// Get pipe sets to determine positions
-	pipeSets := commitgraph.GetPipeSets(m.commits, tempGetStyle)
-
-	// Track which color is used for each position
-	positionColors := make(map[int16]string)
-	nextColorIndex := 0
-
-	for i, pipes := range pipeSets {
-		commit := m.commits[i]
-		var commitPos int16 = 0
-
-		// Find the position of this commit
-		for _, pipe := range pipes {
-			if pipe.Kind() == commitgraph.PipeKindStarts {
-				commitPos = pipe.FromPos()
-				break
-			}
-		}
-
-		// Assign color to position if not already assigned
-		if _, exists := positionColors[commitPos]; !exists {
-			positionColors[commitPos] = branchColors[nextColorIndex%len(branchColors)]
-			nextColorIndex++
-		}
-
-		colorMap[commit.HashPtr()] = positionColors[commitPos]
-	}
+	// Color by branch lineage, so colors stay put as commits are added
+	colorMap := commitgraph.AssignBranchColors(m.commits)
 
 	getStyle := func(c *commitgraph.Commit) *lipgloss.Style {
-		color := colorMap[c.HashPtr()]
-		if color == "" {
+		color, ok := colorMap[c.HashPtr()]
+		if !ok {
 			color = "7" // Default gray
 		}
-		s := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+		s := lipgloss.NewStyle().Foreground(color)
 		return &s
 	}
 
@@ -8,9 +8,9 @@ import (
 	"log"
 	"strings"
 
-	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/noldarim/noldarim/internal/tui/components/pager"
 	"github.com/noldarim/noldarim/internal/tui/layout"
 )
 
@@ -42,7 +42,7 @@ type Model struct {
 	terminalWidth  int
 	terminalHeight int
 	showHelp       bool
-	viewport       viewport.Model
+	pager          pager.Model
 	contentCache   string // Cache content to avoid regeneration
 	contentDirty   bool   // Track when content needs regeneration
 }
@@ -55,7 +55,7 @@ func main() {
 }
 
 func initialModel() Model {
-	vp := viewport.New(80, 10) // Initial size, will be updated on resize
+	p := pager.New(80, 10) // Initial size, will be updated on resize
 
 	return Model{
 		components: []FlexComponent{
@@ -85,7 +85,7 @@ func initialModel() Model {
 		terminalWidth:  80,
 		terminalHeight: 24,
 		showHelp:       true,
-		viewport:       vp,
+		pager:          p,
 		contentDirty:   true, // Initial content generation needed
 	}
 }
@@ -100,14 +100,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.terminalWidth = msg.Width
 		m.terminalHeight = msg.Height
 
-		// Update viewport size based on available content area
+		// Update pager size based on available content area
 		layoutInfo := m.getLayoutInfo()
 		dims := layout.GetContentArea(layoutInfo, m.terminalWidth, m.terminalHeight)
-		m.viewport.Width = m.terminalWidth
-		m.viewport.Height = dims.Height
+		m.pager.SetSize(m.terminalWidth, dims.Height)
 		m.contentDirty = true // Window resize affects layout
 
 	case tea.KeyMsg:
+		if m.pager.Prompting() {
+			// The jump-to-line prompt takes every key while it is open
+			break
+		}
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
@@ -157,37 +160,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.components[2] = FlexComponent{"Details", Short, 1, 20, false}
 			m = m.withFocusedIndex(0)
 			m.contentDirty = true
-
-		case "up", "k":
-			m.viewport.LineUp(1)
-
-		case "down", "j":
-			m.viewport.LineDown(1)
-
-		case "pgup":
-			m.viewport.HalfViewUp()
-
-		case "pgdown":
-			m.viewport.HalfViewDown()
-
-		case "home":
-			m.viewport.GotoTop()
-
-		case "end":
-			m.viewport.GotoBottom()
 		}
 	}
 
 	// Regenerate content if dirty
 	if m.contentDirty {
 		content := m.renderFlexLayout()
-		m.viewport.SetContent(content)
+		m.pager.SetContent(content)
 		m.contentDirty = false
 	}
 
-	// Handle viewport updates
+	// Scrolling is handled by the pager
 	var cmd tea.Cmd
-	m.viewport, cmd = m.viewport.Update(msg)
+	m.pager, cmd = m.pager.Update(msg)
 
 	return m, cmd
 }
@@ -212,13 +197,15 @@ func (m Model) getLayoutInfo() layout.LayoutInfo {
 	return layout.LayoutInfo{
 		Title:       "Flexbox-Style Layout Demo",
 		Breadcrumbs: []string{"Dev Tools", "TUI", "Lipgloss Flexbox"},
-		Status:      fmt.Sprintf("Terminal: %dx%d | Focused: %s (flex: %d) | Scroll: %d%%", m.terminalWidth, m.terminalHeight, m.components[m.focusedIndex].name, m.components[m.focusedIndex].flexGrow, int(m.viewport.ScrollPercent()*100)),
+		Orientation: layout.OrientationAuto,
+		Status:      fmt.Sprintf("Terminal: %dx%d | Focused: %s (flex: %d) | Scroll: %d%%", m.terminalWidth, m.terminalHeight, m.components[m.focusedIndex].name, m.components[m.focusedIndex].flexGrow, int(m.pager.ScrollPercent()*100)),
 		HelpItems: []layout.HelpItem{
 			{Key: "tab/shift+tab", Description: "change focus"},
 			{Key: "1-6", Description: "content type"},
 			{Key: "+/-", Description: "flex grow"},
 			{Key: "↑↓/j/k", Description: "scroll"},
 			{Key: "pgup/pgdn", Description: "page scroll"},
+			{Key: ":N", Description: "go to line"},
 			{Key: "r", Description: "reset"},
 			{Key: "h/?", Description: "toggle help"},
 			{Key: "q", Description: "quit"},
@@ -230,10 +217,10 @@ func (m Model) View() string {
 	// Create layout info
 	layoutInfo := m.getLayoutInfo()
 
-	// Get viewport content (which is scrollable)
-	viewportContent := m.viewport.View()
+	// Get pager content (which is scrollable)
+	viewportContent := m.pager.View()
 
-	// Add help if enabled (outside viewport so it doesn't scroll)
+	// Add help if enabled (outside the pager so it doesn't scroll)
 	var finalContent string
 	if m.showHelp {
 		finalContent = viewportContent + "\n\n" + m.renderHelpText()
@@ -254,6 +241,7 @@ func (m Model) renderFlexLayout() string {
 		Breadcrumbs: []string{"Dev Tools", "TUI", "Lipgloss Flexbox"},
 		Status:      "Status",
 		HelpItems:   []layout.HelpItem{{Key: "test", Description: "test"}},
+		Orientation: layout.OrientationAuto,
 	}
 
 	dims := layout.GetContentArea(layoutInfo, m.terminalWidth, m.terminalHeight)
@@ -270,15 +258,19 @@ func (m Model) renderFlexLayout() string {
 	infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
 	rows = append(rows, infoStyle.Render(fmt.Sprintf("Available width: %d | Total flex-grow: %d", dims.Width, m.getTotalFlexGrow())))
 
-	// Calculate flex widths
+	// Calculate flex widths; narrow terminals stack components at full width instead
+	vertical := dims.Orientation == layout.OrientationVertical
 	widths := m.calculateFlexWidths(dims.Width)
+	if vertical {
+		widths = m.stackedWidths(dims.Width)
+	}
 	totalCalculatedWidth := 0
 	for _, w := range widths {
 		totalCalculatedWidth += w
 	}
 
 	// Show overflow warning if needed
-	if totalCalculatedWidth > dims.Width {
+	if !vertical && totalCalculatedWidth > dims.Width {
 		warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
 		rows = append(rows, warningStyle.Render("⚠ Content overflow detected - components will wrap"))
 	}
@@ -289,7 +281,14 @@ func (m Model) renderFlexLayout() string {
 	componentBoxes := m.renderComponents(widths)
 
 	// Check if we need to wrap (overflow handling)
-	if totalCalculatedWidth <= dims.Width {
+	if vertical {
+		rows = append(rows, infoStyle.Render("↳ Stacked vertically on a narrow terminal:"))
+		rows = append(rows, "")
+		for _, box := range componentBoxes {
+			rows = append(rows, box)
+			rows = append(rows, "")
+		}
+	} else if totalCalculatedWidth <= dims.Width {
 		// Horizontal layout
 		componentsRow := lipgloss.JoinHorizontal(lipgloss.Top, componentBoxes...)
 		rows = append(rows, componentsRow)
@@ -346,6 +345,15 @@ func (m Model) calculateFlexWidths(availableWidth int) []int {
 	return widths
 }
 
+// stackedWidths gives every component the full width when stacked vertically
+func (m Model) stackedWidths(availableWidth int) []int {
+	widths := make([]int, len(m.components))
+	for i := range widths {
+		widths[i] = availableWidth - 2 // Border
+	}
+	return widths
+}
+
 func (m Model) getTotalFlexGrow() int {
 	total := 0
 	for _, comp := range m.components {
@@ -473,8 +481,9 @@ Flex Controls (affects space distribution):
 Navigation:
 • Tab/Shift+Tab: Switch focus between components
 • ↑↓ or j/k: Scroll content up/down
-• PgUp/PgDn: Page scroll up/down
-• Home/End: Jump to top/bottom
+• PgUp/PgDn, Ctrl+U/Ctrl+D: Page and half-page scroll
+• Home/End or g/G: Jump to top/bottom
+• :N then Enter: Jump to line N
 • r: Reset all components to defaults
 • h/?: Toggle this help text
 • q: Quit
@@ -0,0 +1,122 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/noldarim/noldarim/internal/tui/components/pager"
+	"github.com/noldarim/noldarim/internal/tui/layout"
+)
+
+// Model pages through a file given as the first argument, or generated long content
+type Model struct {
+	pager       pager.Model
+	source      string
+	lineNumbers bool
+	width       int
+	height      int
+}
+
+func main() {
+	content, source := loadContent()
+	p := tea.NewProgram(initialModel(content, source), tea.WithAltScreen(), tea.WithMouseCellMotion())
+	if _, err := p.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func loadContent() (content, source string) {
+	if len(os.Args) > 1 {
+		data, err := os.ReadFile(os.Args[1])
+		if err == nil {
+			return strings.TrimRight(string(data), "\n"), os.Args[1]
+		}
+		log.Printf("Failed to read %s, using generated content: %v", os.Args[1], err)
+	}
+	return mockContent(), "generated"
+}
+
+func mockContent() string {
+	var lines []string
+	for i := 1; i <= 1000; i++ {
+		switch {
+		case i%100 == 0:
+			lines = append(lines, fmt.Sprintf("── section %d ──", i/100))
+		case i%7 == 0:
+			lines = append(lines, fmt.Sprintf("line %d: %s", i, strings.Repeat("a longer line to show horizontal overflow ", 4)))
+		default:
+			lines = append(lines, fmt.Sprintf("line %d", i))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func initialModel(content, source string) Model {
+	p := pager.New(80, 20) // Resized on the first WindowSizeMsg
+	p.SetContent(content)
+	p.SetHorizontalStep(8)
+
+	return Model{
+		pager:  p,
+		source: source,
+		width:  80,
+		height: 24,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		dims := layout.GetContentArea(m.layoutInfo(), m.width, m.height)
+		m.pager.SetSize(dims.Width, dims.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		// The jump-to-line prompt takes every key while it is open
+		if !m.pager.Prompting() {
+			switch msg.String() {
+			case "ctrl+c", "q":
+				return m, tea.Quit
+			case "n":
+				m.lineNumbers = !m.lineNumbers
+				m.pager.SetLineNumbers(m.lineNumbers)
+				return m, nil
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.pager, cmd = m.pager.Update(msg)
+	return m, cmd
+}
+
+func (m Model) layoutInfo() layout.LayoutInfo {
+	help := append(m.pager.KeyMap.HelpItems(),
+		layout.HelpItem{Key: "←/→", Description: "scroll sideways"},
+		layout.HelpItem{Key: "n", Description: "line numbers"},
+		layout.HelpItem{Key: "q", Description: "quit"},
+	)
+	return layout.LayoutInfo{
+		Title:       "Pager Demo",
+		Breadcrumbs: []string{"Dev Tools", "TUI", "Pager"},
+		Status:      fmt.Sprintf("%s | %d lines", m.source, m.pager.LineCount()),
+		HelpItems:   help,
+	}
+}
+
+func (m Model) View() string {
+	return layout.RenderLayout(m.pager.View(), m.layoutInfo(), m.width, m.height)
+}
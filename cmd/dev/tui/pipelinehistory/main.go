@@ -0,0 +1,177 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/noldarim/noldarim/internal/tui/components/toast"
+	"github.com/noldarim/noldarim/internal/tui/screens/pipelinehistory"
+)
+
+const projectID = "demo-project"
+
+type demoModel struct {
+	screen  pipelinehistory.Model
+	toasts  toast.Model
+	cmdChan chan protocol.Command
+	evtChan chan protocol.Event
+}
+
+func (m demoModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.screen.Init(),
+		listenForCommands(m.cmdChan),
+		listenForEvents(m.evtChan),
+	)
+}
+
+func (m demoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.screen.SetSize(msg.Width, msg.Height)
+		m.toasts.SetWidth(msg.Width)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "x":
+			// Simulate a project with no runs
+			m.evtChan <- protocol.PipelineRunsLoadedEvent{ProjectID: projectID, ProjectName: "Demo", Runs: map[string]*models.PipelineRun{}}
+			return m, nil
+		}
+
+	case commandMsg:
+		// Answer the screen's commands as the orchestrator would
+		switch cmd := msg.cmd.(type) {
+		case protocol.LoadPipelineRunsCommand:
+			m.evtChan <- protocol.PipelineRunsLoadedEvent{ProjectID: cmd.ProjectID, ProjectName: "Demo", Runs: mockRuns()}
+		case protocol.StartPipelineCommand:
+			m.evtChan <- protocol.PipelineRunStartedEvent{ProjectID: cmd.ProjectID, Name: cmd.Name}
+		}
+		return m, listenForCommands(m.cmdChan)
+
+	case toast.ShowMsg:
+		var cmd tea.Cmd
+		m.toasts, cmd = m.toasts.Update(msg)
+		return m, cmd
+
+	case protocol.Event:
+		screenModel, cmd := m.screen.Update(msg)
+		m.screen = screenModel.(pipelinehistory.Model)
+		cmds = append(cmds, cmd, listenForEvents(m.evtChan))
+		return m, tea.Batch(cmds...)
+	}
+
+	// Toast dismissals arrive here as well
+	var toastCmd tea.Cmd
+	m.toasts, toastCmd = m.toasts.Update(msg)
+	cmds = append(cmds, toastCmd)
+
+	screenModel, cmd := m.screen.Update(msg)
+	m.screen = screenModel.(pipelinehistory.Model)
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m demoModel) View() string {
+	return m.toasts.Overlay(m.screen.View())
+}
+
+type commandMsg struct {
+	cmd protocol.Command
+}
+
+func listenForCommands(cmdChan chan protocol.Command) tea.Cmd {
+	return func() tea.Msg {
+		return commandMsg{cmd: <-cmdChan}
+	}
+}
+
+func listenForEvents(evtChan chan protocol.Event) tea.Cmd {
+	return func() tea.Msg {
+		return <-evtChan
+	}
+}
+
+func mockRuns() map[string]*models.PipelineRun {
+	now := time.Now()
+	at := func(ago time.Duration) *time.Time {
+		t := now.Add(-ago)
+		return &t
+	}
+	snapshot := func(id, name string, index int) models.RunStepSnapshot {
+		return models.RunStepSnapshot{StepID: id, StepName: name, StepIndex: index, AgentConfigJSON: `{"tool_name":"test","prompt_template":"demo"}`}
+	}
+
+	return map[string]*models.PipelineRun{
+		"run-1": {
+			ID: "run-1", ProjectID: projectID, Name: "Add authentication", CreatedAt: *at(3 * time.Hour),
+			Status: models.PipelineRunStatusCompleted, StartedAt: at(3 * time.Hour), CompletedAt: at(3*time.Hour - 4*time.Minute),
+			BranchName: "task/add-auth", BaseCommitSHA: "abc1234def5678", HeadCommitSHA: "fed8765cba4321",
+			StepSnapshots: []models.RunStepSnapshot{snapshot("plan", "Plan", 0), snapshot("implement", "Implement", 1)},
+			StepResults: []models.StepResult{
+				{StepID: "plan", StepName: "Plan", Status: models.StepStatusCompleted, InputTokens: 8200, OutputTokens: 1900},
+				{StepID: "implement", StepName: "Implement", Status: models.StepStatusCompleted, InputTokens: 31000, OutputTokens: 7400, FilesChanged: 6, Insertions: 210, Deletions: 32},
+			},
+		},
+		"run-2": {
+			ID: "run-2", ProjectID: projectID, Name: "Fix flaky parser test", CreatedAt: *at(40 * time.Minute),
+			Status: models.PipelineRunStatusFailed, StartedAt: at(40 * time.Minute), CompletedAt: at(38 * time.Minute),
+			BaseCommitSHA: "0a1b2c3d4e5f", ErrorMessage: "step implement failed: agent exited with status 1",
+			StepSnapshots: []models.RunStepSnapshot{snapshot("implement", "Implement", 0), snapshot("review", "Review", 1)},
+			StepResults: []models.StepResult{
+				{StepID: "implement", StepName: "Implement", Status: models.StepStatusFailed, InputTokens: 5400, OutputTokens: 800},
+			},
+		},
+		"run-3": {
+			ID: "run-3", ProjectID: projectID, Name: "Refactor config loading", CreatedAt: *at(2 * time.Minute),
+			Status: models.PipelineRunStatusRunning, StartedAt: at(2 * time.Minute),
+			StepSnapshots: []models.RunStepSnapshot{snapshot("implement", "Implement", 0)},
+		},
+	}
+}
+
+func main() {
+	cmdChan := make(chan protocol.Command, 10)
+	evtChan := make(chan protocol.Event, 10)
+
+	screen := pipelinehistory.NewModel(projectID, cmdChan)
+	screen.SetSize(80, 24)
+
+	toasts := toast.New()
+	toasts.SetWidth(80)
+
+	model := demoModel{
+		screen:  screen,
+		toasts:  toasts,
+		cmdChan: cmdChan,
+		evtChan: evtChan,
+	}
+
+	fmt.Println("Pipeline History Screen Demo")
+	fmt.Println("Commands:")
+	fmt.Println("  ↑/↓ - Select run")
+	fmt.Println("  Enter - Toggle run summary")
+	fmt.Println("  r - Retry failed run")
+	fmt.Println("  x - Show empty history")
+	fmt.Println("  Esc - Close summary")
+	fmt.Println("  Ctrl+C - Quit")
+	fmt.Println("")
+	time.Sleep(2 * time.Second)
+
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+}
@@ -5,6 +5,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"time"
 
@@ -15,10 +16,17 @@ import (
 )
 
 func main() {
+	markdown := flag.Bool("markdown", false, "Print the summary as Markdown")
+	flag.Parse()
+
 	// Show mock data for demo (comment out to use DB data)
 	data := mockData()
 	// data := loadSummaryData()
 	component := pipelinesummary.New().SetData(data)
+	if *markdown {
+		fmt.Println(component.Markdown())
+		return
+	}
 	fmt.Println(component.View())
 }
 
@@ -109,5 +117,7 @@ func mockData() pipelinesummary.SummaryData {
 		BranchName:     "feature/add-auth",
 		BaseCommitSHA:  "abc1234def5678",
 		HeadCommitSHA:  "fed8765cba4321",
+
+		SignificantFilesChanged: 5,
 	}
 }
@@ -9,11 +9,13 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/noldarim/noldarim/internal/tui/components/toast"
 	"github.com/noldarim/noldarim/internal/tui/screens/settings"
 )
 
 type demoModel struct {
 	screen  settings.Model
+	toasts  toast.Model
 	width   int
 	height  int
 	cmdChan chan protocol.Command
@@ -35,6 +37,7 @@ func (m demoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.screen.SetSize(m.width, m.height)
+		m.toasts.SetWidth(m.width)
 		return m, nil
 
 	case tea.KeyMsg:
@@ -43,23 +46,17 @@ func (m demoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case "s":
 			// Simulate settings saved
-			// For demo purposes, just create a generic success message
-			event := protocol.ErrorEvent{
-				Metadata: protocol.Metadata{},
-				Message:  "Settings saved successfully",
-				Context:  "Settings",
-			}
-			return m, func() tea.Msg { return event }
+			return m, toast.Success("Settings saved successfully")
 		case "e":
 			// Simulate error
-			event := protocol.ErrorEvent{
-				Metadata: protocol.Metadata{},
-				Message:  "Failed to save settings: Permission denied",
-				Context:  "Saving settings",
-			}
-			return m, func() tea.Msg { return event }
+			return m, toast.Error("Failed to save settings: Permission denied")
 		}
 
+	case toast.ShowMsg, protocol.NotificationEvent:
+		var cmd tea.Cmd
+		m.toasts, cmd = m.toasts.Update(msg)
+		cmds = append(cmds, cmd)
+
 	case protocol.Event:
 		screenModel, cmd := m.screen.Update(msg)
 		if updatedScreen, ok := screenModel.(settings.Model); ok {
@@ -71,6 +68,11 @@ func (m demoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		fmt.Printf("Command sent: %T\n", msg)
 
 	default:
+		// Toast dismissals arrive here as well
+		var toastCmd tea.Cmd
+		m.toasts, toastCmd = m.toasts.Update(msg)
+		cmds = append(cmds, toastCmd)
+
 		// Forward other messages to screen (avoid double processing)
 		screenModel, cmd := m.screen.Update(msg)
 		if updatedScreen, ok := screenModel.(settings.Model); ok {
@@ -86,7 +88,7 @@ func (m demoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m demoModel) View() string {
-	return m.screen.View()
+	return m.toasts.Overlay(m.screen.View())
 }
 
 func listenForEvents(evtChan chan protocol.Event) tea.Cmd {
@@ -108,8 +110,12 @@ func main() {
 	screen := settings.NewModel()
 	screen.SetSize(80, 24)
 
+	toasts := toast.New()
+	toasts.SetWidth(80)
+
 	model := demoModel{
 		screen:  screen,
+		toasts:  toasts,
 		width:   80,
 		height:  24,
 		cmdChan: cmdChan,
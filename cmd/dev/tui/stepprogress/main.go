@@ -6,6 +6,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/noldarim/noldarim/internal/config"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
@@ -51,8 +52,10 @@ func loadSteps() []stepprogress.Step {
 			}
 		}
 		steps[i] = stepprogress.Step{
-			Name:   name,
-			Status: convertStatus(result.Status),
+			Name:        name,
+			Status:      convertStatus(result.Status),
+			StartedAt:   result.StartedAt,
+			CompletedAt: result.CompletedAt,
 		}
 	}
 
@@ -75,10 +78,14 @@ func convertStatus(s models.StepStatus) stepprogress.StepStatus {
 }
 
 func mockSteps() []stepprogress.Step {
+	at := func(ago time.Duration) *time.Time {
+		t := time.Now().Add(-ago)
+		return &t
+	}
 	return []stepprogress.Step{
-		{Name: "Setup", Status: stepprogress.StatusCompleted},
-		{Name: "Code Review", Status: stepprogress.StatusCompleted},
-		{Name: "Implementation", Status: stepprogress.StatusRunning},
+		{Name: "Setup", Status: stepprogress.StatusCompleted, StartedAt: at(5 * time.Minute), CompletedAt: at(4 * time.Minute)},
+		{Name: "Code Review", Status: stepprogress.StatusCompleted, StartedAt: at(4 * time.Minute), CompletedAt: at(2 * time.Minute)},
+		{Name: "Implementation", Status: stepprogress.StatusRunning, StartedAt: at(30 * time.Second)},
 		{Name: "Testing", Status: stepprogress.StatusPending},
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/noldarim/noldarim/internal/demodata"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 	"github.com/noldarim/noldarim/internal/protocol"
 	"github.com/noldarim/noldarim/internal/tui/screens/taskdetails"
@@ -139,7 +140,7 @@ func main() {
 	screen.SetSize(100, 40)
 
 	// Create mock AI activity records
-	mockRecords := createMockAIRecords(task.ID)
+	mockRecords := demodata.AIActivityRecords(task.ID, time.Now())
 
 	// Wrap only for dev scenario switching
 	model := devModel{
@@ -163,75 +164,6 @@ func main() {
 	}
 }
 
-// createMockAIRecords creates a sequence of mock AI activity records
-func createMockAIRecords(taskID string) []*models.AIActivityRecord {
-	baseTime := time.Now()
-	records := make([]*models.AIActivityRecord, 0)
-	trueVal := true
-
-	// Session start
-	records = append(records, &models.AIActivityRecord{
-		EventID:   "evt-001",
-		TaskID:    taskID,
-		SessionID: "session-abc123",
-		Timestamp: baseTime,
-		EventType: models.AIEventSessionStart,
-	})
-
-	// Tool calls sequence
-	toolCalls := []struct {
-		name  string
-		input string
-	}{
-		{"Bash", "ls -la /workspace"},
-		{"Read", "/workspace/main.go"},
-		{"Grep", "func main"},
-		{"Read", "/workspace/config.yaml"},
-		{"Edit", "/workspace/main.go"},
-		{"Bash", "go build ./..."},
-		{"Bash", "go test ./..."},
-	}
-
-	for i, tc := range toolCalls {
-		// Tool use
-		records = append(records, &models.AIActivityRecord{
-			EventID:          fmt.Sprintf("evt-%03d", i*2+2),
-			TaskID:           taskID,
-			SessionID:        "session-abc123",
-			Timestamp:        baseTime.Add(time.Duration(i*2+1) * time.Second),
-			EventType:        models.AIEventToolUse,
-			ToolName:         tc.name,
-			ToolInputSummary: tc.input,
-		})
-
-		// Tool result
-		records = append(records, &models.AIActivityRecord{
-			EventID:        fmt.Sprintf("evt-%03d", i*2+3),
-			TaskID:         taskID,
-			SessionID:      "session-abc123",
-			Timestamp:      baseTime.Add(time.Duration(i*2+2) * time.Second),
-			EventType:      models.AIEventToolResult,
-			ToolName:       tc.name,
-			ToolSuccess:    &trueVal,
-			ContentPreview: "Command executed successfully",
-		})
-	}
-
-	// Session end
-	records = append(records, &models.AIActivityRecord{
-		EventID:      "evt-final",
-		TaskID:       taskID,
-		SessionID:    "session-abc123",
-		Timestamp:    baseTime.Add(20 * time.Second),
-		EventType:    models.AIEventSessionEnd,
-		StopReason:   "completed",
-		InputTokens:  5420,
-		OutputTokens: 10000,
-	})
-
-	return records
-}
-
 // Mock data scenarios for testing different views
 
 func createTaskWithSmallDiff() models.Task {
@@ -249,16 +181,7 @@ func createTaskWithSmallDiff() models.Task {
 		Status:        models.TaskStatusInProgress,
 		CreatedAt:     now.Add(-2 * time.Hour),
 		LastUpdatedAt: now.Add(-15 * time.Minute),
-		GitDiff: `diff --git a/styles/login.css b/styles/login.css
-index 1234567..abcdefg 100644
---- a/styles/login.css
-+++ b/styles/login.css
-@@ -10,3 +10,4 @@
- .login-btn {
--  padding: 8px;
-+  padding: 12px 24px;
-+  border-radius: 4px;
- }`,
+		GitDiff:       demodata.SmallDiff,
 	}
 }
 
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/noldarim/noldarim/internal/demodata"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 	"github.com/noldarim/noldarim/internal/protocol"
 	"github.com/noldarim/noldarim/internal/tui/screens/taskview"
@@ -128,52 +129,6 @@ func listenForEvents(evtChan chan protocol.Event) tea.Cmd {
 	}
 }
 
-func createMockTasks() []models.Task {
-	now := time.Now()
-	return []models.Task{
-		{
-			ID:          "task-1",
-			ProjectID:   "proj-1",
-			Title:       "Implement user authentication",
-			Description: "Add JWT-based authentication to the API",
-			Status:      models.TaskStatusInProgress,
-			CreatedAt:   now.Add(-48 * time.Hour),
-		},
-		{
-			ID:          "task-2",
-			ProjectID:   "proj-1",
-			Title:       "Add database migrations",
-			Description: "Set up migration system for database schema changes",
-			Status:      models.TaskStatusCompleted,
-			CreatedAt:   now.Add(-72 * time.Hour),
-		},
-		{
-			ID:          "task-3",
-			ProjectID:   "proj-1",
-			Title:       "Write API documentation",
-			Description: "Document all API endpoints with OpenAPI spec",
-			Status:      models.TaskStatusPending,
-			CreatedAt:   now.Add(-24 * time.Hour),
-		},
-		{
-			ID:          "task-4",
-			ProjectID:   "proj-1",
-			Title:       "Fix memory leak in worker process",
-			Description: "Investigate and fix memory leak reported in production",
-			Status:      models.TaskStatusInProgress,
-			CreatedAt:   now.Add(-12 * time.Hour),
-		},
-		{
-			ID:          "task-5",
-			ProjectID:   "proj-1",
-			Title:       "Upgrade dependencies",
-			Description: "Update all npm packages to latest stable versions",
-			Status:      models.TaskStatusPending,
-			CreatedAt:   now.Add(-36 * time.Hour),
-		},
-	}
-}
-
 func createMockCommits() []protocol.CommitInfo {
 	return []protocol.CommitInfo{
 		{
@@ -226,7 +181,7 @@ func main() {
 	screen := taskview.NewModel(project.ID, cmdChan)
 	screen.SetSize(100, 30)
 
-	tasks := createMockTasks()
+	tasks := demodata.Tasks(project.ID, time.Now())
 	commits := createMockCommits()
 
 	// Send initial data via proper commands
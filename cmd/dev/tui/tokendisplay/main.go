@@ -57,5 +57,6 @@ func mockData() tokendisplay.TokenData {
 		OutputTokens:      8120,
 		CacheReadTokens:   12340,
 		CacheCreateTokens: 5200,
+		Model:             "claude-sonnet-4-20250514",
 	}
 }
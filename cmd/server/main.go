@@ -16,6 +16,7 @@ import (
 	"github.com/noldarim/noldarim/internal/observability"
 	"github.com/noldarim/noldarim/internal/orchestrator"
 	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/noldarim/noldarim/internal/rpc"
 	"github.com/noldarim/noldarim/internal/server"
 )
 
@@ -77,10 +78,27 @@ func main() {
 		mainLog.Info().Msg("Orchestrator stopped")
 	}()
 
+	// The server subscribes to the event bus rather than owning eventChan, so
+	// further consumers can be attached without competing for events.
+	bus := orchestrator.NewEventBus(100)
+	go bus.Run(ctx, eventChan)
+	serverEvents, unsubscribe := bus.Subscribe()
+	defer unsubscribe()
+
+	// Optional control socket for editors and scripts, alongside the HTTP API
+	if cfg.RPC.Enabled {
+		rpcServer := rpc.NewServer(cfg.RPC.SocketPath, cmdChan, bus)
+		go func() {
+			if err := rpcServer.Run(ctx); err != nil {
+				mainLog.Error().Err(err).Msg("RPC server failed")
+			}
+		}()
+	}
+
 	// Start API server
 	srv := server.New(
 		&cfg.Server,
-		eventChan,
+		serverEvents,
 		orch.DataService(),
 		orch.GitServiceManager(),
 		orch.PipelineService(),
@@ -5,11 +5,17 @@
 package adapters
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"sync"
 
+	"github.com/noldarim/noldarim/internal/aiobs/adapters/aider"
 	"github.com/noldarim/noldarim/internal/aiobs/adapters/claude"
+	"github.com/noldarim/noldarim/internal/aiobs/adapters/codex"
+	"github.com/noldarim/noldarim/internal/aiobs/adapters/gemini"
 )
 
 // registry holds registered adapters by name.
@@ -48,9 +54,14 @@ func RegisterAll() {
 	// Register Claude adapter
 	registry["claude"] = claude.New()
 
-	// Future adapters go here:
-	// registry["gemini"] = gemini.New()
-	// registry["aider"] = aider.New()
+	// Register Aider adapter (parses history blocks, see aider.Splitter)
+	registry["aider"] = aider.New()
+
+	// Register Gemini CLI adapter
+	registry["gemini"] = gemini.New()
+
+	// Register Codex CLI adapter
+	registry["codex"] = codex.New()
 
 	initialized = true
 }
@@ -73,22 +84,48 @@ func RegisteredAdapters() []string {
 	return names
 }
 
+// Detect returns the registered adapter whose Matches accepts entry, one line of a
+// transcript. Each adapter sniffs the line's shape (Claude's type/sessionId envelope,
+// Gemini's role/parts, Codex's typed payload). It returns false when no adapter or
+// more than one accepts the line, so callers can fall back to an explicit source.
+func Detect(entry RawEntry) (Adapter, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	var match Adapter
+	for _, adapter := range registry {
+		m, ok := adapter.(Matcher)
+		if !ok || !m.Matches(entry.Data) {
+			continue
+		}
+		if match != nil {
+			return nil, false // Ambiguous
+		}
+		match = adapter
+	}
+	return match, match != nil
+}
+
+// DetectReader runs Detect on the first non-empty line read from r.
+func DetectReader(r io.Reader) (Adapter, bool) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		return Detect(RawEntry{Line: 1, Data: json.RawMessage(line)})
+	}
+	return nil, false
+}
+
 // DetectAndParse attempts to detect the adapter and parse the entry.
 // Returns the parsed events and the adapter name used.
 func DetectAndParse(raw json.RawMessage) ([]ParsedEvent, string, error) {
-	// Try to detect based on common fields
-	var probe struct {
-		Type      string `json:"type"`
-		SessionID string `json:"sessionId"`
-	}
-	if err := json.Unmarshal(raw, &probe); err == nil {
-		// Claude Code transcripts have type field with specific values
-		if probe.Type == "user" || probe.Type == "assistant" || probe.Type == "summary" || probe.Type == "system" {
-			if a, ok := Get("claude"); ok {
-				events, err := a.ParseEntry(RawEntry{Data: raw, SessionID: probe.SessionID})
-				return events, "claude", err
-			}
-		}
+	entry := RawEntry{Data: raw, SessionID: ExtractSessionID(raw)}
+	if a, ok := Detect(entry); ok {
+		events, err := a.ParseEntry(entry)
+		return events, a.Name(), err
 	}
 
 	// Default to claude if we can't detect
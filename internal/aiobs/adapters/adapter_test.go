@@ -0,0 +1,88 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package adapters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	ResetForTesting()
+	RegisterAll()
+	t.Cleanup(ResetForTesting)
+
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{
+			name:     "claude",
+			line:     `{"type":"assistant","sessionId":"s1","uuid":"u1","message":{"role":"assistant","content":[{"type":"text","text":"hi"}],"usage":{"input_tokens":3}}}`,
+			expected: "claude",
+		},
+		{
+			name:     "gemini",
+			line:     `{"sessionId":"g1","role":"model","parts":[{"text":"hi"}],"usageMetadata":{"promptTokenCount":3}}`,
+			expected: "gemini",
+		},
+		{
+			name:     "codex",
+			line:     `{"timestamp":"2025-09-10T09:00:00Z","type":"response_item","payload":{"type":"message","role":"user","content":[]}}`,
+			expected: "codex",
+		},
+		{
+			name:     "aider",
+			line:     "# aider chat started at 2025-01-15 10:30:00",
+			expected: "aider",
+		},
+		{name: "unknown shape", line: `{"event":"something"}`},
+		{name: "not json", line: "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, ok := Detect(RawEntry{Data: []byte(tt.line)})
+			if tt.expected == "" {
+				assert.False(t, ok)
+				assert.Nil(t, adapter)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, adapter.Name())
+		})
+	}
+}
+
+// everything matches any line, making detection ambiguous
+type everything struct{ Adapter }
+
+func (everything) Matches([]byte) bool { return true }
+
+func TestDetect_Ambiguous(t *testing.T) {
+	ResetForTesting()
+	RegisterAll()
+	t.Cleanup(ResetForTesting)
+	register("everything", everything{})
+
+	_, ok := Detect(RawEntry{Data: []byte(`{"role":"model","parts":[{"text":"hi"}]}`)})
+	assert.False(t, ok)
+}
+
+func TestDetectReader(t *testing.T) {
+	ResetForTesting()
+	RegisterAll()
+	t.Cleanup(ResetForTesting)
+
+	adapter, ok := DetectReader(strings.NewReader("\n  \n" + `{"role":"user","parts":[{"text":"hi"}]}` + "\n{}\n"))
+	require.True(t, ok)
+	assert.Equal(t, "gemini", adapter.Name())
+
+	_, ok = DetectReader(strings.NewReader("\n\n"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,257 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package aider provides an adapter for Aider's .aider.chat.history.md files.
+//
+// The history is markdown rather than JSONL, so the unit of parsing is a block of
+// lines instead of a single line: RawEntry.Data holds one block as produced by
+// Splitter (or SplitBlocks for a whole file) and RawEntry.Line its first line.
+// Line-oriented consumers such as the transcript watcher feed lines through Parser,
+// which splits them into blocks and emits a block's events once the next one starts.
+package aider
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/aiobs/types"
+)
+
+// headerTimeLayout is the timestamp format of aider's session header
+const headerTimeLayout = "2006-01-02 15:04:05"
+
+// maxPreviewLength matches the ContentPreview size of the other adapters
+const maxPreviewLength = 500
+
+// Adapter implements the types.Adapter interface for Aider chat history.
+type Adapter struct{}
+
+// New creates a new Aider adapter instance.
+func New() *Adapter {
+	return &Adapter{}
+}
+
+func (a *Adapter) Name() string {
+	return "aider"
+}
+
+// Matches reports whether sample, the start of a file, looks like aider chat history
+func (a *Adapter) Matches(sample []byte) bool {
+	for _, line := range strings.SplitN(string(sample), "\n", 20) {
+		if strings.HasPrefix(line, headerPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseEntry converts one history block to ParsedEvents. Header blocks start a
+// session; blocks after it carry raw.SessionID when the caller tracked it.
+func (a *Adapter) ParseEntry(raw types.RawEntry) ([]types.ParsedEvent, error) {
+	text := string(raw.Data)
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	lines := strings.Split(text, "\n")
+
+	base := types.ParsedEvent{
+		SessionID:  raw.SessionID,
+		SourceLine: raw.Line,
+	}
+
+	var events []types.ParsedEvent
+	switch lineKind(lines[0]) {
+	case kindHeader:
+		started, err := time.ParseInLocation(headerTimeLayout, strings.TrimSpace(strings.TrimPrefix(lines[0], headerPrefix)), time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid aider session header %q: %w", lines[0], err)
+		}
+		if base.SessionID == "" {
+			base.SessionID = SessionIDFromHeader(started)
+		}
+		event := base
+		event.EventType = types.EventTypeSessionStart
+		event.Timestamp = started
+		events = append(events, event)
+	case kindUser:
+		events = append(events, userEvent(base, lines))
+	case kindInfo:
+		events = append(events, infoEvents(base, lines)...)
+	default:
+		events = append(events, assistantEvents(base, text, lines)...)
+	}
+
+	for i := range events {
+		events[i].EventID = generateEventID()
+		events[i].Kind = types.KindForEvent(events[i].EventType)
+		events[i].Level = types.LevelForEvent(events[i].EventType)
+	}
+	return events, nil
+}
+
+// ExtractTaskPrompt returns the first user message that is not an aider command
+// (such as "/add file.py"). Records are history blocks.
+func (a *Adapter) ExtractTaskPrompt(records []types.RawEntry) (string, bool) {
+	for _, raw := range records {
+		lines := strings.Split(string(raw.Data), "\n")
+		if lineKind(lines[0]) != kindUser {
+			continue
+		}
+		prompt := userText(lines)
+		if prompt == "" || strings.HasPrefix(prompt, "/") {
+			continue
+		}
+		return prompt, true
+	}
+	return "", false
+}
+
+// NewLineParser returns a Parser that groups history lines into blocks, so the
+// transcript watcher can tail a history file (see types.LineParserFactory)
+func (a *Adapter) NewLineParser() types.Parser {
+	return NewParser(types.RunContext{})
+}
+
+// SessionIDFromHeader derives a stable session ID from the session start time
+func SessionIDFromHeader(started time.Time) string {
+	return "aider-" + started.Format("20060102-150405")
+}
+
+func userEvent(base types.ParsedEvent, lines []string) types.ParsedEvent {
+	text := userText(lines)
+	event := base
+	event.EventType = types.EventTypeUserPrompt
+	event.IsHumanInput = true
+	event.ContentPreview = truncateString(text, maxPreviewLength)
+	event.ContentLength = len(text)
+	return event
+}
+
+func userText(lines []string) string {
+	parts := make([]string, 0, len(lines))
+	for _, line := range lines {
+		parts = append(parts, strings.TrimPrefix(strings.TrimPrefix(line, "####"), " "))
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n"))
+}
+
+// infoEvents turns aider's own output into tool results for applied edits; the
+// rest (command echoes, token usage, warnings) carries no event
+func infoEvents(base types.ParsedEvent, lines []string) []types.ParsedEvent {
+	var events []types.ParsedEvent
+	success := true
+	for _, line := range lines {
+		msg := strings.TrimSpace(strings.TrimPrefix(line, ">"))
+		path, ok := strings.CutPrefix(msg, "Applied edit to ")
+		if !ok {
+			continue
+		}
+		event := base
+		event.EventType = types.EventTypeToolResult
+		event.ToolName = "Edit"
+		event.ToolSuccess = &success
+		event.FilePath = strings.TrimSpace(path)
+		event.ContentPreview = msg
+		event.ContentLength = len(msg)
+		events = append(events, event)
+	}
+	return events
+}
+
+// assistantEvents returns the reply as ai_output followed by a tool_use per
+// SEARCH/REPLACE edit block
+func assistantEvents(base types.ParsedEvent, text string, lines []string) []types.ParsedEvent {
+	output := base
+	output.EventType = types.EventTypeAIOutput
+	output.ContentPreview = truncateString(text, maxPreviewLength)
+	output.ContentLength = len(text)
+	events := []types.ParsedEvent{output}
+
+	for _, edit := range findEdits(lines) {
+		event := base
+		event.EventType = types.EventTypeToolUse
+		event.ToolName = "Edit"
+		event.FilePath = edit.path
+		event.ToolInputSummary = fmt.Sprintf("%s (-%d +%d lines)", edit.path, edit.removed, edit.added)
+		events = append(events, event)
+	}
+	return events
+}
+
+// edit is one SEARCH/REPLACE block of an assistant reply
+type edit struct {
+	path           string
+	removed, added int
+}
+
+const (
+	searchMarker  = "<<<<<<< SEARCH"
+	dividerMarker = "======="
+	replaceMarker = ">>>>>>> REPLACE"
+)
+
+// findEdits locates SEARCH/REPLACE blocks. The file path is the closest non-blank
+// line before the SEARCH marker that is not a code fence, which covers both the
+// path-above-fence and path-inside-fence layouts aider uses.
+func findEdits(lines []string) []edit {
+	var edits []edit
+	lastPath := ""
+	for i := 0; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) != searchMarker {
+			continue
+		}
+		e := edit{path: editPath(lines[:i])}
+		if e.path == "" {
+			e.path = lastPath
+		}
+		lastPath = e.path
+		section := &e.removed
+		for i++; i < len(lines); i++ {
+			marker := strings.TrimSpace(lines[i])
+			if marker == dividerMarker {
+				section = &e.added
+				continue
+			}
+			if marker == replaceMarker {
+				break
+			}
+			*section++
+		}
+		if e.path != "" {
+			edits = append(edits, e)
+		}
+	}
+	return edits
+}
+
+func editPath(before []string) string {
+	for i := len(before) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(before[i])
+		if line == "" || strings.HasPrefix(line, "```") {
+			continue
+		}
+		if line == replaceMarker {
+			// Consecutive edits to the same file share its path line
+			return ""
+		}
+		return strings.Trim(line, "`*: ")
+	}
+	return ""
+}
+
+func truncateString(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
+
+// eventCounter provides uniqueness within the same nanosecond (thread-safe)
+var eventCounter atomic.Uint32
+
+func generateEventID() string {
+	count := eventCounter.Add(1)
+	return fmt.Sprintf("aider-%s-%04x", time.Now().Format("20060102150405.000000000"), count&0xFFFF)
+}
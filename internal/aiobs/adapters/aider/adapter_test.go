@@ -0,0 +1,154 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package aider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/aiobs/types"
+)
+
+const sampleHistory = "\n" +
+	"# aider chat started at 2025-01-15 10:30:00\n" +
+	"\n" +
+	"> /usr/local/bin/aider --model gpt-4o\n" +
+	"> Aider v0.70.0\n" +
+	"\n" +
+	"#### /add greeting.py\n" +
+	"\n" +
+	"> Added greeting.py to the chat\n" +
+	"\n" +
+	"#### Make the greeting friendlier\n" +
+	"#### and mention the user's name\n" +
+	"\n" +
+	"Here is the change:\n" +
+	"\n" +
+	"greeting.py\n" +
+	"```python\n" +
+	"<<<<<<< SEARCH\n" +
+	"def greet():\n" +
+	"    print(\"hi\")\n" +
+	"=======\n" +
+	"def greet(name):\n" +
+	"    print(f\"Hello there, {name}!\")\n" +
+	"\n" +
+	"#### not a user line inside a fence\n" +
+	">>>>>>> REPLACE\n" +
+	"```\n" +
+	"\n" +
+	"> Applied edit to greeting.py\n" +
+	"> Tokens: 1.2k sent, 150 received.\n"
+
+func TestAdapter_Matches(t *testing.T) {
+	a := New()
+	assert.True(t, a.Matches([]byte(sampleHistory)))
+	assert.False(t, a.Matches([]byte(`{"type":"user","sessionId":"s1"}`)))
+}
+
+func TestSplitBlocks(t *testing.T) {
+	blocks := SplitBlocks([]byte(sampleHistory))
+	require.Len(t, blocks, 7)
+
+	assert.Equal(t, 2, blocks[0].Line)
+	assert.Equal(t, "# aider chat started at 2025-01-15 10:30:00", string(blocks[0].Data))
+	assert.Equal(t, "#### /add greeting.py", string(blocks[2].Data))
+	assert.Equal(t, "#### Make the greeting friendlier\n#### and mention the user's name", string(blocks[4].Data))
+	assert.Equal(t, 14, blocks[5].Line)
+	assert.Contains(t, string(blocks[5].Data), "#### not a user line inside a fence")
+	assert.True(t, strings.HasSuffix(string(blocks[5].Data), "```"))
+}
+
+func TestAdapter_ParseEntry(t *testing.T) {
+	a := New()
+	var events []types.ParsedEvent
+	for _, block := range SplitBlocks([]byte(sampleHistory)) {
+		blockEvents, err := a.ParseEntry(block)
+		require.NoError(t, err)
+		events = append(events, blockEvents...)
+	}
+
+	var eventTypes []string
+	for _, e := range events {
+		eventTypes = append(eventTypes, e.EventType)
+	}
+	assert.Equal(t, []string{
+		types.EventTypeSessionStart,
+		types.EventTypeUserPrompt,
+		types.EventTypeUserPrompt,
+		types.EventTypeAIOutput,
+		types.EventTypeToolUse,
+		types.EventTypeToolResult,
+	}, eventTypes)
+
+	start := events[0]
+	assert.Equal(t, "aider-20250115-103000", start.SessionID)
+	assert.Equal(t, time.Date(2025, 1, 15, 10, 30, 0, 0, time.Local), start.Timestamp)
+	assert.Equal(t, types.KindLifecycle, start.Kind)
+
+	prompt := events[2]
+	assert.True(t, prompt.IsHumanInput)
+	assert.Equal(t, "Make the greeting friendlier\nand mention the user's name", prompt.ContentPreview)
+
+	toolUse := events[4]
+	assert.Equal(t, "Edit", toolUse.ToolName)
+	assert.Equal(t, "greeting.py", toolUse.FilePath)
+	assert.Equal(t, "greeting.py (-2 +4 lines)", toolUse.ToolInputSummary)
+	assert.Equal(t, types.KindTool, toolUse.Kind)
+
+	result := events[5]
+	assert.Equal(t, "greeting.py", result.FilePath)
+	require.NotNil(t, result.ToolSuccess)
+	assert.True(t, *result.ToolSuccess)
+
+	ids := map[string]bool{}
+	for _, e := range events {
+		assert.False(t, ids[e.EventID], "event IDs are unique")
+		ids[e.EventID] = true
+	}
+}
+
+func TestAdapter_ExtractTaskPrompt(t *testing.T) {
+	a := New()
+	blocks := SplitBlocks([]byte(sampleHistory))
+
+	prompt, ok := a.ExtractTaskPrompt(blocks)
+	require.True(t, ok)
+	assert.Equal(t, "Make the greeting friendlier\nand mention the user's name", prompt)
+
+	_, ok = a.ExtractTaskPrompt(blocks[:4])
+	assert.False(t, ok, "aider commands are not prompts")
+}
+
+func TestParser_OnLineAndFlush(t *testing.T) {
+	ctx := context.Background()
+	p := NewParser(types.RunContext{TaskID: "task-1"})
+	stream := types.StreamID{Name: HistoryFileName, StreamType: "fs-md"}
+
+	var events []types.ParsedEvent
+	for _, line := range strings.SplitAfter(sampleHistory, "\n") {
+		lineEvents, err := p.OnLine(ctx, stream, []byte(line))
+		require.NoError(t, err)
+		events = append(events, lineEvents...)
+	}
+	// The trailing info block is only complete once the stream ends
+	require.Len(t, events, 5)
+	flushed, err := p.Flush(ctx)
+	require.NoError(t, err)
+	events = append(events, flushed...)
+	require.Len(t, events, 6)
+
+	for i, e := range events {
+		assert.Equal(t, "aider-20250115-103000", e.SessionID)
+		assert.Equal(t, int64(i+1), e.Sequence)
+		assert.Equal(t, HistoryFileName, e.SourceFile)
+		assert.False(t, e.Timestamp.IsZero())
+	}
+	assert.Equal(t, 14, events[3].SourceLine)
+}
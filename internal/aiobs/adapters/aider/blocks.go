@@ -0,0 +1,118 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package aider
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"github.com/noldarim/noldarim/internal/aiobs/types"
+)
+
+// blockKind classifies a run of lines in .aider.chat.history.md
+type blockKind int
+
+const (
+	kindNone      blockKind = iota
+	kindHeader              // "# aider chat started at ..."
+	kindUser                // "#### ..." lines typed by the user
+	kindInfo                // "> ..." lines printed by aider (commands, applied edits, token usage)
+	kindAssistant           // Everything else: the model's markdown reply
+)
+
+const headerPrefix = "# aider chat started at "
+
+// lineKind returns the kind a line starts, or kindNone for blank lines which
+// belong to whatever block they are in
+func lineKind(line string) blockKind {
+	switch {
+	case strings.TrimSpace(line) == "":
+		return kindNone
+	case strings.HasPrefix(line, headerPrefix):
+		return kindHeader
+	case line == "####" || strings.HasPrefix(line, "#### "):
+		return kindUser
+	case line == ">" || strings.HasPrefix(line, "> "):
+		return kindInfo
+	default:
+		return kindAssistant
+	}
+}
+
+// Splitter groups history lines into blocks, the unit the adapter parses. A block
+// ends where a line of a different kind starts; inside a fenced code block of an
+// assistant reply every line is part of the reply.
+type Splitter struct {
+	kind    blockKind
+	start   int // Line number of the block's first line
+	lines   []string
+	inFence bool
+}
+
+// Push adds the next line (1-based lineNo) and returns the block it completed, if any
+func (s *Splitter) Push(line string, lineNo int) (types.RawEntry, bool) {
+	line = strings.TrimRight(line, "\r\n")
+
+	kind := lineKind(line)
+	if s.kind == kindAssistant && (s.inFence || kind == kindNone) {
+		kind = kindAssistant
+	}
+	if kind == kindNone || (kind == s.kind && kind != kindHeader) {
+		s.appendLine(line)
+		return types.RawEntry{}, false
+	}
+
+	block, ok := s.Flush()
+	s.kind = kind
+	s.start = lineNo
+	s.appendLine(line)
+	return block, ok
+}
+
+// Flush returns the pending block, if any, and resets the splitter
+func (s *Splitter) Flush() (types.RawEntry, bool) {
+	if s.kind == kindNone {
+		s.lines = s.lines[:0]
+		return types.RawEntry{}, false
+	}
+	text := strings.TrimRight(strings.Join(s.lines, "\n"), "\n ")
+	block := types.RawEntry{Line: s.start, Data: []byte(text)}
+	s.kind = kindNone
+	s.lines = s.lines[:0]
+	s.inFence = false
+	return block, true
+}
+
+func (s *Splitter) appendLine(line string) {
+	if s.kind == kindNone {
+		// Blank lines between blocks
+		return
+	}
+	s.lines = append(s.lines, line)
+	if s.kind == kindAssistant && strings.HasPrefix(strings.TrimSpace(line), "```") {
+		s.inFence = !s.inFence
+	}
+}
+
+// SplitBlocks splits a whole history file into blocks
+func SplitBlocks(data []byte) []types.RawEntry {
+	var (
+		s      Splitter
+		blocks []types.RawEntry
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		if block, ok := s.Push(scanner.Text(), lineNo); ok {
+			blocks = append(blocks, block)
+		}
+	}
+	if block, ok := s.Flush(); ok {
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
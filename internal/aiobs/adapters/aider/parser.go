@@ -0,0 +1,121 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package aider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/aiobs/types"
+)
+
+// HistoryFileName is the file aider appends its chat history to, in the repository root
+const HistoryFileName = ".aider.chat.history.md"
+
+// Parser implements types.Parser for line-by-line tailing of aider history. A block's
+// events are emitted when the first line of the next block arrives, or on Flush.
+type Parser struct {
+	run     types.RunContext
+	adapter *Adapter
+	mu      sync.Mutex
+	streams map[string]*streamState
+}
+
+// streamState is the per-file splitting and session state
+type streamState struct {
+	splitter  Splitter
+	line      int
+	sessionID string
+	sequence  int64
+}
+
+// NewParser creates a parser for one run
+func NewParser(run types.RunContext) *Parser {
+	return &Parser{
+		run:     run,
+		adapter: New(),
+		streams: make(map[string]*streamState),
+	}
+}
+
+// OnLine processes one line of a history file
+func (p *Parser) OnLine(ctx context.Context, stream types.StreamID, line []byte) ([]types.ParsedEvent, error) {
+	_ = ctx
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state, ok := p.streams[stream.Name]
+	if !ok {
+		state = &streamState{}
+		p.streams[stream.Name] = state
+	}
+	state.line++
+	block, ok := state.splitter.Push(string(line), state.line)
+	if !ok {
+		return nil, nil
+	}
+	return p.parseBlock(stream, state, block)
+}
+
+// Flush emits the last block of every stream
+func (p *Parser) Flush(ctx context.Context) ([]types.ParsedEvent, error) {
+	_ = ctx
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var events []types.ParsedEvent
+	for name, state := range p.streams {
+		block, ok := state.splitter.Flush()
+		if !ok {
+			continue
+		}
+		blockEvents, err := p.parseBlock(types.StreamID{Name: name}, state, block)
+		if err != nil {
+			return events, err
+		}
+		events = append(events, blockEvents...)
+	}
+	return events, nil
+}
+
+func (p *Parser) parseBlock(stream types.StreamID, state *streamState, block types.RawEntry) ([]types.ParsedEvent, error) {
+	block.SessionID = state.sessionID
+	if lineKind(firstLine(block.Data)) == kindHeader {
+		// A new header starts a new session
+		block.SessionID = ""
+	}
+
+	events, err := p.adapter.ParseEntry(block)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range events {
+		if events[i].EventType == types.EventTypeSessionStart {
+			state.sessionID = events[i].SessionID
+			state.sequence = 0
+		}
+		state.sequence++
+		events[i].Sequence = state.sequence
+		// Only the session header carries a time; other blocks are stamped as read
+		if events[i].Timestamp.IsZero() {
+			events[i].Timestamp = now
+		}
+		events[i].SourceFile = stream.Name
+	}
+	return events, nil
+}
+
+func firstLine(data []byte) string {
+	for i, b := range data {
+		if b == '\n' {
+			return string(data[:i])
+		}
+	}
+	return string(data)
+}
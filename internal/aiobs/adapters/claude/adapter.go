@@ -6,10 +6,13 @@ package claude
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/noldarim/noldarim/internal/aiobs/types"
 	"github.com/noldarim/noldarim/internal/logger"
@@ -29,6 +32,12 @@ func getLog() *zerolog.Logger {
 	return log
 }
 
+// ErrMalformedEntry is wrapped by every error ParseEntry returns for input it cannot parse
+var ErrMalformedEntry = errors.New("malformed transcript entry")
+
+// maxPreviewLen caps ContentPreview, except for tool_result_delta which carries all output so far
+const maxPreviewLen = 500
+
 // Adapter implements the types.Adapter interface for Claude Code transcripts.
 type Adapter struct{}
 
@@ -43,10 +52,19 @@ func (a *Adapter) Name() string {
 
 // ParseEntry converts a raw Claude transcript entry to ParsedEvents.
 // One entry can produce multiple events (e.g., thinking + tool_use in same message).
-func (a *Adapter) ParseEntry(raw types.RawEntry) ([]types.ParsedEvent, error) {
+// Input that cannot be parsed yields an error wrapping ErrMalformedEntry; ParseEntry never panics.
+func (a *Adapter) ParseEntry(raw types.RawEntry) (events []types.ParsedEvent, err error) {
+	// Transcripts are written by another program, so a parser bug must not take down the watcher
+	defer func() {
+		if r := recover(); r != nil {
+			getLog().Error().Interface("panic", r).Int("line", raw.Line).Msg("recovered from panic while parsing transcript entry")
+			events, err = nil, fmt.Errorf("%w: parser panic: %v", ErrMalformedEntry, r)
+		}
+	}()
+
 	var entry TranscriptEntry
 	if err := json.Unmarshal(raw.Data, &entry); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal transcript entry: %w", err)
+		return nil, fmt.Errorf("%w: failed to unmarshal transcript entry: %w", ErrMalformedEntry, err)
 	}
 
 	// Parse timestamp once
@@ -85,10 +103,6 @@ func (a *Adapter) ParseEntry(raw types.RawEntry) ([]types.ParsedEvent, error) {
 	}
 
 	// Route based on entry type
-	var (
-		events []types.ParsedEvent
-		err    error
-	)
 	switch entry.Type {
 	case "user":
 		events, err = a.parseUserEntry(entry, base)
@@ -98,6 +112,8 @@ func (a *Adapter) ParseEntry(raw types.RawEntry) ([]types.ParsedEvent, error) {
 		events, err = a.parseSummaryEntry(entry, base)
 	case "system":
 		events, err = a.parseSystemEntry(entry, base)
+	case "progress":
+		events, err = a.parseProgressEntry(entry, base)
 	default:
 		// Skip unknown entry types (e.g., "queue-operation", "file-history-snapshot")
 		return nil, nil
@@ -115,12 +131,118 @@ func (a *Adapter) ParseEntry(raw types.RawEntry) ([]types.ParsedEvent, error) {
 	return events, nil
 }
 
+// ExtractTaskPrompt returns the full text of the first human prompt in the transcript.
+// Tool results, sidechain (sub-agent) prompts, meta entries and slash-command
+// bookkeeping are skipped; lines that fail to parse are ignored.
+func (a *Adapter) ExtractTaskPrompt(records []types.RawEntry) (string, bool) {
+	for _, raw := range records {
+		var entry TranscriptEntry
+		if err := json.Unmarshal(raw.Data, &entry); err != nil {
+			continue
+		}
+		if entry.Type != "user" || entry.IsMeta || entry.IsSidechain || hasToolUseResult(entry) || entry.Message == nil {
+			continue
+		}
+
+		var parts []string
+		isToolResult := false
+		for _, item := range entry.Message.Content {
+			switch item.Type {
+			case "tool_result":
+				isToolResult = true
+			case "text":
+				if text := strings.TrimSpace(item.Text); text != "" {
+					parts = append(parts, text)
+				}
+			}
+		}
+		if isToolResult || len(parts) == 0 || isInjectedUserText(parts[0]) {
+			continue
+		}
+		return strings.Join(parts, "\n\n"), true
+	}
+	return "", false
+}
+
+// Matches reports whether sample starts with a Claude Code transcript entry
+func (a *Adapter) Matches(sample []byte) bool {
+	line, _, _ := strings.Cut(string(sample), "\n")
+	var probe struct {
+		Type      string `json:"type"`
+		SessionID string `json:"sessionId"`
+		UUID      string `json:"uuid"`
+		LeafUUID  string `json:"leafUuid"`
+	}
+	if err := json.Unmarshal([]byte(line), &probe); err != nil {
+		return false
+	}
+	switch probe.Type {
+	case "user", "assistant", "system", "progress":
+		return probe.SessionID != "" || probe.UUID != ""
+	case "summary":
+		return probe.LeafUUID != ""
+	}
+	return false
+}
+
+// EntryLinks returns the uuid/parentUuid pair of a transcript entry. Entries without a
+// uuid (summaries, file-history snapshots) return empty strings.
+func (a *Adapter) EntryLinks(raw types.RawEntry) (uuid, parentUUID string) {
+	var probe struct {
+		UUID       string `json:"uuid"`
+		ParentUUID string `json:"parentUuid"`
+	}
+	if err := json.Unmarshal(raw.Data, &probe); err != nil {
+		return "", ""
+	}
+	return probe.UUID, probe.ParentUUID
+}
+
+// hasToolUseResult reports whether the entry carries a toolUseResult; an explicit null counts as absent
+func hasToolUseResult(entry TranscriptEntry) bool {
+	return len(entry.ToolUseResult) > 0 && string(entry.ToolUseResult) != "null"
+}
+
+// injectedUserPrefixes start user-role text that Claude Code writes itself
+var injectedUserPrefixes = []string{
+	"<command-name>",
+	"<command-message>",
+	"<local-command-stdout>",
+	"<local-command-stderr>",
+	"<system-reminder>",
+	"Caveat: The messages below were generated by the user while running local commands",
+	"[Request interrupted by user",
+}
+
+func isInjectedUserText(text string) bool {
+	for _, prefix := range injectedUserPrefixes {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // parseUserEntry handles user entries - either human prompts or tool results.
 func (a *Adapter) parseUserEntry(entry TranscriptEntry, base types.ParsedEvent) ([]types.ParsedEvent, error) {
 	// Check if this is a tool result wrapped in user message.
 	// Claude sends tool outputs back as user messages with toolUseResult field.
-	if entry.ToolUseResult != nil {
-		return a.parseToolUseResultField(entry.ToolUseResult, base)
+	if hasToolUseResult(entry) {
+		events, err := a.parseToolUseResultField(entry.ToolUseResult, base)
+		if err != nil {
+			return nil, err
+		}
+		if entry.Message != nil {
+			for _, item := range entry.Message.Content {
+				if item.Type == "tool_result" {
+					for i := range events {
+						events[i].ToolUseID = item.ToolUseID
+					}
+					break
+				}
+			}
+		}
+		return events, nil
 	}
 
 	// Check message content for tool_result type items
@@ -139,8 +261,12 @@ func (a *Adapter) parseUserEntry(entry TranscriptEntry, base types.ParsedEvent)
 
 	// Extract content preview
 	content := extractTextContent(entry.Message)
-	base.ContentPreview = truncateString(content, 500)
+	base.ContentPreview = truncateString(content, maxPreviewLen)
 	base.ContentLength = len(content)
+	base.Attachments = extractAttachments(entry.Message)
+	if content == "" && len(base.Attachments) > 0 {
+		base.ContentPreview = attachmentPreview(base.Attachments)
+	}
 
 	return []types.ParsedEvent{base}, nil
 }
@@ -162,25 +288,26 @@ func (a *Adapter) parseAssistantEntry(entry TranscriptEntry, base types.ParsedEv
 		switch item.Type {
 		case "thinking":
 			event.EventType = types.EventTypeThinking
-			event.ContentPreview = truncateString(item.Thinking, 500)
+			event.ContentPreview = truncateString(item.Thinking, maxPreviewLen)
 			event.ContentLength = len(item.Thinking)
 			events = append(events, event)
 
 		case "text":
 			event.EventType = types.EventTypeAIOutput
-			event.ContentPreview = truncateString(item.Text, 500)
+			event.ContentPreview = truncateString(item.Text, maxPreviewLen)
 			event.ContentLength = len(item.Text)
 			events = append(events, event)
 
 		case "tool_use":
 			event.EventType = types.EventTypeToolUse
-			event.ToolName = item.Name
+			event.ToolNamespace, event.ToolName = types.SplitMCPToolName(item.Name)
+			event.ToolUseID = item.ID
 			event.ToolInputSummary = extractToolInputSummary(item.Name, item.Input)
 			event.FilePath = extractFilePath(item.Name, item.Input)
 
 			// For tool_use, content preview shows the input summary
 			inputJSON, _ := json.Marshal(item.Input)
-			event.ContentPreview = truncateString(string(inputJSON), 500)
+			event.ContentPreview = truncateString(string(inputJSON), maxPreviewLen)
 			event.ContentLength = len(inputJSON)
 			events = append(events, event)
 
@@ -190,12 +317,11 @@ func (a *Adapter) parseAssistantEntry(entry TranscriptEntry, base types.ParsedEv
 				subagentEvent.EventType = types.EventTypeSubagentStart
 				subagentEvent.IsHumanInput = false
 				subagentEvent.ToolName = "Task"
+				subagentEvent.ToolUseID = item.ID
 
-				if agentType, ok := item.Input["subagent_type"].(string); ok {
-					subagentEvent.ContentPreview = fmt.Sprintf("Spawning sub-agent [%s]", agentType)
-				} else {
-					subagentEvent.ContentPreview = "Spawning sub-agent"
-				}
+				agentType, _ := item.Input["subagent_type"].(string)
+				description, _ := item.Input["description"].(string)
+				subagentEvent.ContentPreview = truncateString(subagentPreview("Spawning sub-agent", agentType, description), maxPreviewLen)
 				if prompt, ok := item.Input["prompt"].(string); ok {
 					subagentEvent.ToolInputSummary = truncateString(prompt, 200)
 				}
@@ -220,8 +346,12 @@ func (a *Adapter) parseAssistantEntry(entry TranscriptEntry, base types.ParsedEv
 		base.EventType = types.EventTypeAIOutput
 		base.IsHumanInput = false
 		content := extractTextContent(entry.Message)
-		base.ContentPreview = truncateString(content, 500)
+		base.ContentPreview = truncateString(content, maxPreviewLen)
 		base.ContentLength = len(content)
+		base.Attachments = extractAttachments(entry.Message)
+		if content == "" && len(base.Attachments) > 0 {
+			base.ContentPreview = attachmentPreview(base.Attachments)
+		}
 		return []types.ParsedEvent{base}, nil
 	}
 
@@ -238,31 +368,53 @@ func (a *Adapter) parseToolResultContent(item ContentItem, base types.ParsedEven
 	event.EventID = generateEventID()
 	event.EventType = types.EventTypeToolResult
 	event.IsHumanInput = false
+	event.ToolUseID = item.ToolUseID
+
+	// Extract content preview
+	contentStr := toolResultText(item.Content)
 
 	// Tool success/error
 	success := !item.IsError
 	event.ToolSuccess = &success
 	if item.IsError {
-		if content, ok := item.Content.(string); ok {
-			event.ToolError = content
-		}
+		event.ToolError = contentStr
 	}
 
-	// Extract content preview
-	contentStr := ""
-	if content, ok := item.Content.(string); ok {
-		contentStr = content
-	} else if item.Content != nil {
-		if contentJSON, err := json.Marshal(item.Content); err == nil {
-			contentStr = string(contentJSON)
-		}
-	}
-	event.ContentPreview = truncateString(contentStr, 500)
+	event.ContentPreview = truncateString(contentStr, maxPreviewLen)
 	event.ContentLength = len(contentStr)
+	event.ResultContentType = types.DetectContentType(contentStr)
 
 	return []types.ParsedEvent{event}, nil
 }
 
+// toolResultText returns a tool_result's content as text. Content given as an array of
+// blocks yields its text blocks joined, as in taskResultEvents; an array without text
+// blocks, such as a lone image, falls back to its JSON.
+func toolResultText(content interface{}) string {
+	switch c := content.(type) {
+	case nil:
+		return ""
+	case string:
+		return c
+	case []interface{}:
+		var texts []string
+		for _, block := range c {
+			if m, ok := block.(map[string]interface{}); ok && m["type"] == "text" {
+				if text, ok := m["text"].(string); ok && text != "" {
+					texts = append(texts, text)
+				}
+			}
+		}
+		if len(texts) > 0 {
+			return strings.Join(texts, "\n")
+		}
+	}
+	if contentJSON, err := json.Marshal(content); err == nil {
+		return string(contentJSON)
+	}
+	return ""
+}
+
 // parseToolUseResultField handles the toolUseResult convenience field.
 // Claude Code adds this to user entries with pre-parsed tool output metadata.
 // The format varies by tool type - we detect the format by examining which fields are present.
@@ -278,8 +430,9 @@ func (a *Adapter) parseToolUseResultField(raw json.RawMessage, base types.Parsed
 	// Try plain string first (error messages come as plain strings)
 	var textContent string
 	if json.Unmarshal(raw, &textContent) == nil {
-		event.ContentPreview = truncateString(textContent, 500)
+		event.ContentPreview = truncateString(textContent, maxPreviewLen)
 		event.ContentLength = len(textContent)
+		event.ResultContentType = types.DetectContentType(textContent)
 		return []types.ParsedEvent{event}, nil
 	}
 
@@ -291,6 +444,10 @@ func (a *Adapter) parseToolUseResultField(raw json.RawMessage, base types.Parsed
 		event.AgentID = agentProbe.AgentID
 	}
 
+	if task, ok := parseTaskResult(raw); ok {
+		return taskResultEvents(task, event), nil
+	}
+
 	var result ToolUseResult
 	if err := json.Unmarshal(raw, &result); err != nil {
 		// Failed to parse - show raw snippet as last resort
@@ -315,34 +472,35 @@ func (a *Adapter) parseToolUseResultField(raw json.RawMessage, base types.Parsed
 		if output == "" {
 			output = "(no output)"
 		}
-		event.ContentPreview = truncateString(output, 500)
+		event.ContentPreview = truncateString(output, maxPreviewLen)
 		event.ContentLength = len(output)
+		event.ResultContentType = types.DetectContentType(output)
 
 	case result.Type == "text" && result.File != nil:
 		// Read result
 		event.ToolName = "Read"
 		event.FilePath = result.File.FilePath
-		event.ContentPreview = fmt.Sprintf("[%s] %d lines", result.File.FilePath, result.File.NumLines)
+		event.ContentPreview = truncateString(fmt.Sprintf("[%s] %d lines", result.File.FilePath, result.File.NumLines), maxPreviewLen)
 		event.ContentLength = len(result.File.Content)
 
 	case result.Type == "create":
 		// Write result (new file)
 		event.ToolName = "Write"
 		event.FilePath = result.FilePath
-		event.ContentPreview = fmt.Sprintf("Created %s", result.FilePath)
+		event.ContentPreview = truncateString(fmt.Sprintf("Created %s", result.FilePath), maxPreviewLen)
 		event.ContentLength = len(result.Content)
 
 	case result.Type == "update":
 		// Edit result
 		event.ToolName = "Edit"
 		event.FilePath = result.FilePath
-		event.ContentPreview = fmt.Sprintf("Updated %s", result.FilePath)
+		event.ContentPreview = truncateString(fmt.Sprintf("Updated %s", result.FilePath), maxPreviewLen)
 		event.ContentLength = len(result.Content)
 
 	case result.Type == "delete":
 		// Delete result
 		event.FilePath = result.FilePath
-		event.ContentPreview = fmt.Sprintf("Deleted %s", result.FilePath)
+		event.ContentPreview = truncateString(fmt.Sprintf("Deleted %s", result.FilePath), maxPreviewLen)
 
 	case result.NewTodos != nil || result.OldTodos != nil:
 		// TodoWrite result
@@ -360,8 +518,9 @@ func (a *Adapter) parseToolUseResultField(raw json.RawMessage, base types.Parsed
 
 	case result.Content != "":
 		// Generic content fallback
-		event.ContentPreview = truncateString(result.Content, 500)
+		event.ContentPreview = truncateString(result.Content, maxPreviewLen)
 		event.ContentLength = len(result.Content)
+		event.ResultContentType = types.DetectContentType(result.Content)
 
 	default:
 		// Last resort: show raw snippet
@@ -377,7 +536,7 @@ func (a *Adapter) parseSummaryEntry(entry TranscriptEntry, base types.ParsedEven
 	event := base
 	event.EventID = generateEventID()
 	event.IsHumanInput = false
-	event.ContentPreview = truncateString(entry.Summary, 500)
+	event.ContentPreview = truncateString(entry.Summary, maxPreviewLen)
 	event.ContentLength = len(entry.Summary)
 
 	if entry.IsSidechain {
@@ -389,6 +548,39 @@ func (a *Adapter) parseSummaryEntry(entry TranscriptEntry, base types.ParsedEven
 	return []types.ParsedEvent{event}, nil
 }
 
+// parseProgressEntry handles progress entries. Only Bash progress is parsed: it becomes
+// a tool_result_delta whose ContentPreview holds all output so far (not truncated), which
+// types.DeltaTracker turns into increments. Other progress kinds are skipped.
+func (a *Adapter) parseProgressEntry(entry TranscriptEntry, base types.ParsedEvent) ([]types.ParsedEvent, error) {
+	if len(entry.Data) == 0 || entry.ParentToolUseID == "" {
+		return nil, nil
+	}
+
+	var progress BashProgress
+	if err := json.Unmarshal(entry.Data, &progress); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal progress data: %w", ErrMalformedEntry, err)
+	}
+	if progress.Type != "bash_progress" {
+		return nil, nil
+	}
+
+	output := progress.FullOutput
+	if output == "" {
+		output = progress.Output
+	}
+
+	event := base
+	event.EventID = generateEventID()
+	event.EventType = types.EventTypeToolResultDelta
+	event.IsHumanInput = false
+	event.ToolName = "Bash"
+	event.ToolUseID = entry.ParentToolUseID
+	event.ContentPreview = output
+	event.ContentLength = len(output)
+
+	return []types.ParsedEvent{event}, nil
+}
+
 // parseSystemEntry handles system/error entries.
 func (a *Adapter) parseSystemEntry(entry TranscriptEntry, base types.ParsedEvent) ([]types.ParsedEvent, error) {
 	event := base
@@ -397,7 +589,7 @@ func (a *Adapter) parseSystemEntry(entry TranscriptEntry, base types.ParsedEvent
 	event.IsHumanInput = false
 
 	content := extractTextContent(entry.Message)
-	event.ContentPreview = truncateString(content, 500)
+	event.ContentPreview = truncateString(content, maxPreviewLen)
 	event.ContentLength = len(content)
 
 	return []types.ParsedEvent{event}, nil
@@ -432,6 +624,105 @@ func extractTextContent(msg *Message) string {
 	return ""
 }
 
+// subagentPreview renders a subagent event's preview, e.g.
+// "Spawning sub-agent [explore]: Find parser edge cases"
+func subagentPreview(prefix, agentType, description string) string {
+	if agentType != "" {
+		prefix += " [" + agentType + "]"
+	}
+	if description != "" {
+		prefix += ": " + description
+	}
+	return prefix
+}
+
+// parseTaskResult decodes the toolUseResult of a Task call, which names the sub-agent
+// and reports its status; other results return false
+func parseTaskResult(raw json.RawMessage) (TaskResult, bool) {
+	var task TaskResult
+	if err := json.Unmarshal(raw, &task); err != nil {
+		return TaskResult{}, false
+	}
+	return task, task.AgentID != "" && (task.Status != "" || task.TotalDurationMs > 0)
+}
+
+// taskResultEvents returns the tool_result of a Task call followed by the
+// subagent_stop that ends the sub-agent started by its tool_use
+func taskResultEvents(task TaskResult, event types.ParsedEvent) []types.ParsedEvent {
+	event.ToolName = "Task"
+	var texts []string
+	for _, item := range task.Content {
+		if item.Type == "text" && item.Text != "" {
+			texts = append(texts, item.Text)
+		}
+	}
+	output := strings.Join(texts, "\n")
+	event.ContentPreview = truncateString(output, maxPreviewLen)
+	event.ContentLength = len(output)
+	event.ResultContentType = types.DetectContentType(output)
+
+	stop := event
+	stop.EventID = generateEventID()
+	stop.EventType = types.EventTypeSubagentStop
+	stop.ToolSuccess = nil
+	stop.ResultContentType = ""
+	stop.ContentLength = 0
+	prefix := "Sub-agent finished"
+	if task.Status != "" && task.Status != "completed" {
+		prefix = "Sub-agent " + task.Status
+	}
+	stop.ContentPreview = prefix
+	return []types.ParsedEvent{event, stop}
+}
+
+// extractAttachments describes the image and document blocks of a message
+func extractAttachments(msg *Message) []types.Attachment {
+	if msg == nil {
+		return nil
+	}
+	var attachments []types.Attachment
+	for _, item := range msg.Content {
+		if item.Type != "image" && item.Type != "document" {
+			continue
+		}
+		attachment := types.Attachment{Type: item.Type, Descriptor: item.Title}
+		if src := item.Source; src != nil {
+			attachment.MediaType = src.MediaType
+			if attachment.Descriptor == "" {
+				switch {
+				case src.URL != "":
+					attachment.Descriptor = truncateString(src.URL, 80)
+				case src.Type == "base64" && src.Data != "":
+					attachment.Descriptor = formatSize(len(src.Data) * 3 / 4)
+				}
+			}
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments
+}
+
+// attachmentPreview stands in for the text of a message made only of attachments,
+// e.g. "[image attachment]" or "[3 attachments]"
+func attachmentPreview(attachments []types.Attachment) string {
+	if len(attachments) == 1 {
+		return fmt.Sprintf("[%s attachment]", attachments[0].Type)
+	}
+	return fmt.Sprintf("[%d attachments]", len(attachments))
+}
+
+// formatSize renders a byte count as B, KB or MB
+func formatSize(n int) string {
+	switch {
+	case n >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	case n >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
 func extractToolInputSummary(toolName string, input map[string]interface{}) string {
 	if input == nil {
 		return ""
@@ -519,11 +810,20 @@ func extractFilePath(toolName string, input map[string]interface{}) string {
 	return ""
 }
 
+// truncateString shortens s to at most maxLen bytes without splitting a rune. Invalid
+// UTF-8 (raw snippets keep the input bytes as-is) is replaced, since Postgres rejects it.
 func truncateString(s string, maxLen int) string {
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "\uFFFD")
+	}
 	if len(s) <= maxLen {
 		return s
 	}
-	return s[:maxLen-3] + "..."
+	cut := maxLen - 3
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
 }
 
 // isBashResult checks if the raw JSON represents a Bash tool result.
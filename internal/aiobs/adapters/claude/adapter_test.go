@@ -117,6 +117,40 @@ func TestAdapter_ParseToolUse(t *testing.T) {
 	assert.False(t, event.IsHumanInput)
 	assert.Equal(t, "Bash", event.ToolName)
 	assert.Equal(t, "ls -la", event.ToolInputSummary)
+	assert.Equal(t, "tool-123", event.ToolUseID)
+}
+
+func TestAdapter_ParseToolUse_MCP(t *testing.T) {
+	adapter := &Adapter{}
+
+	rawJSON := []byte(`{
+		"type": "assistant",
+		"uuid": "test-uuid",
+		"timestamp": "2025-01-15T10:30:00.000Z",
+		"sessionId": "session-123",
+		"message": {
+			"role": "assistant",
+			"content": [
+				{
+					"type": "tool_use",
+					"id": "tool-123",
+					"name": "mcp__filesystem__read_file",
+					"input": {
+						"path": "/tmp/notes.txt"
+					}
+				}
+			]
+		}
+	}`)
+
+	events := parseEntry(t, adapter, rawJSON)
+	require.Len(t, events, 1)
+
+	event := events[0]
+	assert.Equal(t, types.EventTypeToolUse, event.EventType)
+	assert.Equal(t, "filesystem", event.ToolNamespace)
+	assert.Equal(t, "read_file", event.ToolName)
+	assert.Equal(t, "tool-123", event.ToolUseID)
 }
 
 func TestAdapter_ParseToolUse_TaskEmitsSubagentStart(t *testing.T) {
@@ -136,6 +170,7 @@ func TestAdapter_ParseToolUse_TaskEmitsSubagentStart(t *testing.T) {
 					"name": "Task",
 					"input": {
 						"subagent_type": "explore",
+						"description": "Find parser edge cases",
 						"prompt": "Inspect the codebase for parsing edge cases"
 					}
 				}
@@ -153,8 +188,52 @@ func TestAdapter_ParseToolUse_TaskEmitsSubagentStart(t *testing.T) {
 	subagentStartEvent := events[1]
 	assert.Equal(t, types.EventTypeSubagentStart, subagentStartEvent.EventType)
 	assert.Equal(t, "Task", subagentStartEvent.ToolName)
-	assert.Contains(t, subagentStartEvent.ContentPreview, "Spawning sub-agent [explore]")
+	assert.Equal(t, "Spawning sub-agent [explore]: Find parser edge cases", subagentStartEvent.ContentPreview)
 	assert.Contains(t, subagentStartEvent.ToolInputSummary, "Inspect the codebase")
+	assert.Equal(t, "tool-task-123", subagentStartEvent.ToolUseID)
+}
+
+func TestAdapter_ParseTaskResultEmitsSubagentStop(t *testing.T) {
+	adapter := &Adapter{}
+
+	rawJSON := []byte(`{
+		"type": "user",
+		"uuid": "task-result-uuid",
+		"timestamp": "2025-01-15T10:31:00.000Z",
+		"sessionId": "session-123",
+		"message": {
+			"role": "user",
+			"content": [
+				{"type": "tool_result", "tool_use_id": "tool-task-123", "content": [{"type": "text", "text": "Found 3 edge cases"}]}
+			]
+		},
+		"toolUseResult": {
+			"status": "completed",
+			"prompt": "Inspect the codebase for parsing edge cases",
+			"agentId": "a1b2c3",
+			"content": [{"type": "text", "text": "Found 3 edge cases"}],
+			"totalDurationMs": 5400,
+			"totalTokens": 1200,
+			"totalToolUseCount": 4
+		}
+	}`)
+
+	events := parseEntry(t, adapter, rawJSON)
+	require.Len(t, events, 2)
+
+	result := events[0]
+	assert.Equal(t, types.EventTypeToolResult, result.EventType)
+	assert.Equal(t, "Task", result.ToolName)
+	assert.Equal(t, "Found 3 edge cases", result.ContentPreview)
+	assert.Equal(t, "tool-task-123", result.ToolUseID)
+
+	stop := events[1]
+	assert.Equal(t, types.EventTypeSubagentStop, stop.EventType)
+	assert.Equal(t, types.KindLifecycle, stop.Kind)
+	assert.Equal(t, "Sub-agent finished", stop.ContentPreview)
+	assert.Equal(t, "tool-task-123", stop.ToolUseID)
+	assert.Equal(t, "a1b2c3", stop.AgentID)
+	assert.Nil(t, stop.ToolSuccess)
 }
 
 func TestAdapter_ParseEntry_PropagatesSidechainAndAgentID(t *testing.T) {
@@ -292,6 +371,8 @@ func TestAdapter_ParseToolResult(t *testing.T) {
 	require.NotNil(t, event.ToolSuccess)
 	assert.True(t, *event.ToolSuccess)
 	assert.Contains(t, event.ContentPreview, "file1.txt")
+	assert.Equal(t, "tool-123", event.ToolUseID)
+	assert.Equal(t, types.ContentTypeText, event.ResultContentType)
 }
 
 func TestAdapter_ParseToolResult_Error(t *testing.T) {
@@ -325,6 +406,75 @@ func TestAdapter_ParseToolResult_Error(t *testing.T) {
 	assert.Equal(t, "command not found: foo", event.ToolError)
 }
 
+func TestAdapter_ParseToolResult_ContentType(t *testing.T) {
+	adapter := &Adapter{}
+
+	tests := []struct {
+		name     string
+		content  string // JSON-encoded tool_result content
+		expected types.ContentType
+	}{
+		{name: "json", content: `"{\"ok\": true, \"files\": [\"a.go\"]}"`, expected: types.ContentTypeJSON},
+		{name: "structured content blocks", content: `[{"type": "text", "text": "hello"}]`, expected: types.ContentTypeText},
+		{name: "json in a text block", content: `[{"type": "text", "text": "{\"ok\": true}"}]`, expected: types.ContentTypeJSON},
+		{name: "blocks without text", content: `[{"type": "image", "source": {"type": "base64", "media_type": "image/png", "data": "AA=="}}]`, expected: types.ContentTypeJSON},
+		{name: "diff", content: `"diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1 +1 @@\n-x\n+y\n"`, expected: types.ContentTypeDiff},
+		{name: "binary", content: `"GIF89a\u0000\u0001"`, expected: types.ContentTypeBinary},
+		{name: "text", content: `"ok"`, expected: types.ContentTypeText},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawJSON := []byte(`{
+				"type": "user",
+				"uuid": "test-uuid",
+				"timestamp": "2025-01-15T10:30:00.000Z",
+				"sessionId": "session-123",
+				"message": {
+					"role": "user",
+					"content": [{"type": "tool_result", "tool_use_id": "tool-123", "content": ` + tt.content + `}]
+				}
+			}`)
+
+			events := parseEntry(t, adapter, rawJSON)
+			require.Len(t, events, 1)
+			assert.Equal(t, tt.expected, events[0].ResultContentType)
+		})
+	}
+}
+
+func TestAdapter_ParseBashProgress(t *testing.T) {
+	adapter := &Adapter{}
+
+	rawJSON := []byte(`{
+		"type": "progress",
+		"uuid": "progress-uuid",
+		"timestamp": "2025-01-15T10:30:00.000Z",
+		"sessionId": "session-123",
+		"toolUseID": "bash-progress-1",
+		"parentToolUseID": "toolu_123",
+		"data": {"type": "bash_progress", "output": "ok pkg/b", "fullOutput": "ok pkg/a\nok pkg/b", "elapsedTimeSeconds": 4, "totalLines": 2}
+	}`)
+
+	events := parseEntry(t, adapter, rawJSON)
+	require.Len(t, events, 1)
+
+	event := events[0]
+	assert.Equal(t, types.EventTypeToolResultDelta, event.EventType)
+	assert.Equal(t, types.KindTool, event.Kind)
+	assert.Equal(t, "toolu_123", event.ToolUseID)
+	assert.Equal(t, "Bash", event.ToolName)
+	assert.Equal(t, "ok pkg/a\nok pkg/b", event.ContentPreview, "delta carries the full output so far")
+}
+
+func TestAdapter_ParseOtherProgressSkipped(t *testing.T) {
+	adapter := &Adapter{}
+
+	rawJSON := []byte(`{"type": "progress", "uuid": "p", "parentToolUseID": "toolu_1", "data": {"type": "hook_progress"}}`)
+
+	assert.Empty(t, parseEntry(t, adapter, rawJSON))
+}
+
 func TestAdapter_ParseThinking(t *testing.T) {
 	adapter := &Adapter{}
 
@@ -513,6 +663,64 @@ func TestAdapter_ParseMultipleContentBlocks(t *testing.T) {
 	assert.Equal(t, types.EventTypeAIOutput, events[1].EventType)
 }
 
+func TestAdapter_ParseImageAttachment(t *testing.T) {
+	adapter := &Adapter{}
+
+	// 8 base64 characters decode to 6 bytes
+	rawJSON := []byte(`{
+		"type": "user",
+		"uuid": "test-uuid",
+		"timestamp": "2025-01-15T10:30:00.000Z",
+		"sessionId": "session-123",
+		"message": {
+			"role": "user",
+			"content": [
+				{"type": "image", "source": {"type": "base64", "media_type": "image/png", "data": "iVBORw0K"}}
+			]
+		}
+	}`)
+
+	events := parseEntry(t, adapter, rawJSON)
+	require.Len(t, events, 1)
+
+	event := events[0]
+	assert.Equal(t, types.EventTypeUserPrompt, event.EventType)
+	assert.Equal(t, "[image attachment]", event.ContentPreview)
+	require.Len(t, event.Attachments, 1)
+	assert.Equal(t, types.Attachment{Type: "image", MediaType: "image/png", Descriptor: "6 B"}, event.Attachments[0])
+	assert.Equal(t, "📎 image/png (6 B)", event.Attachments[0].Label())
+}
+
+func TestAdapter_ParseTextWithDocumentAttachment(t *testing.T) {
+	adapter := &Adapter{}
+
+	rawJSON := []byte(`{
+		"type": "user",
+		"uuid": "test-uuid",
+		"timestamp": "2025-01-15T10:30:00.000Z",
+		"sessionId": "session-123",
+		"message": {
+			"role": "user",
+			"content": [
+				{"type": "text", "text": "Summarize this spec"},
+				{"type": "document", "title": "spec.pdf", "source": {"type": "url", "media_type": "application/pdf", "url": "https://example.com/spec.pdf"}},
+				{"type": "image", "source": {"type": "url", "url": "https://example.com/diagram.png"}}
+			]
+		}
+	}`)
+
+	events := parseEntry(t, adapter, rawJSON)
+	require.Len(t, events, 1)
+
+	event := events[0]
+	assert.Equal(t, "Summarize this spec", event.ContentPreview)
+	assert.Equal(t, []types.Attachment{
+		{Type: "document", MediaType: "application/pdf", Descriptor: "spec.pdf"},
+		{Type: "image", Descriptor: "https://example.com/diagram.png"},
+	}, event.Attachments)
+	assert.Equal(t, "📎 image (https://example.com/diagram.png)", event.Attachments[1].Label())
+}
+
 func TestAdapter_TimestampParsing(t *testing.T) {
 	adapter := &Adapter{}
 
@@ -567,3 +775,53 @@ func TestAdapter_ContentLength(t *testing.T) {
 	event := events[0]
 	assert.Equal(t, len(longContent), event.ContentLength)
 }
+
+func TestAdapter_ExtractTaskPrompt(t *testing.T) {
+	adapter := &Adapter{}
+	lines := []string{
+		`not json`,
+		`{"type":"summary","summary":"Earlier session"}`,
+		`{"type":"user","isMeta":true,"message":{"role":"user","content":"Caveat: injected"}}`,
+		`{"type":"user","message":{"role":"user","content":"<command-name>/clear</command-name>"}}`,
+		`{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"ok"}]}}`,
+		`{"type":"user","isSidechain":true,"message":{"role":"user","content":"Sub-agent prompt"}}`,
+		`{"type":"user","message":{"role":"user","content":[{"type":"text","text":"Add a logout button"},{"type":"text","text":"Put it in the header."}]}}`,
+		`{"type":"user","message":{"role":"user","content":"A follow-up"}}`,
+	}
+	records := make([]types.RawEntry, len(lines))
+	for i, line := range lines {
+		records[i] = types.RawEntry{Line: i + 1, Data: json.RawMessage(line)}
+	}
+
+	prompt, ok := adapter.ExtractTaskPrompt(records)
+	require.True(t, ok)
+	assert.Equal(t, "Add a logout button\n\nPut it in the header.", prompt)
+
+	_, ok = adapter.ExtractTaskPrompt(records[:6])
+	assert.False(t, ok)
+}
+
+func TestAdapter_EntryLinks(t *testing.T) {
+	adapter := &Adapter{}
+	var _ types.EntryLinker = adapter
+
+	uuid, parent := adapter.EntryLinks(types.RawEntry{Data: json.RawMessage(`{"type":"user","uuid":"u2","parentUuid":"u1"}`)})
+	assert.Equal(t, "u2", uuid)
+	assert.Equal(t, "u1", parent)
+
+	uuid, parent = adapter.EntryLinks(types.RawEntry{Data: json.RawMessage(`{"type":"user","uuid":"u1","parentUuid":null}`)})
+	assert.Equal(t, "u1", uuid)
+	assert.Empty(t, parent)
+
+	uuid, parent = adapter.EntryLinks(types.RawEntry{Data: json.RawMessage(`not json`)})
+	assert.Empty(t, uuid)
+	assert.Empty(t, parent)
+}
+
+func TestAdapter_Matches(t *testing.T) {
+	adapter := &Adapter{}
+	assert.True(t, adapter.Matches([]byte(`{"type":"user","uuid":"u1","sessionId":"s1"}`+"\n"+`{"type":"assistant"}`)))
+	assert.True(t, adapter.Matches([]byte(`{"type":"summary","summary":"Earlier","leafUuid":"u9"}`)))
+	assert.False(t, adapter.Matches([]byte("# aider chat started at 2025-01-15 10:30:00\n")))
+	assert.False(t, adapter.Matches([]byte(`{"type":"user"}`)))
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package claude
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/aiobs/types"
+)
+
+// fuzzSeeds are transcript lines in the shapes Claude Code writes them
+var fuzzSeeds = []string{
+	`{"parentUuid":null,"isSidechain":false,"userType":"external","cwd":"/workspace","sessionId":"5f0c","version":"2.0.14","type":"user","message":{"role":"user","content":"Fix the failing test in pkg/foo"},"uuid":"u1","timestamp":"2025-01-15T10:30:00.000Z"}`,
+	`{"parentUuid":"u1","isSidechain":false,"sessionId":"5f0c","type":"assistant","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-5","content":[{"type":"thinking","thinking":"Let me look at the test","signature":"abc"}],"stop_reason":null,"usage":{"input_tokens":10,"output_tokens":3,"cache_creation_input_tokens":100,"cache_read_input_tokens":2000}},"requestId":"req_1","uuid":"a1","timestamp":"2025-01-15T10:30:01.123Z"}`,
+	`{"parentUuid":"a1","sessionId":"5f0c","type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"toolu_1","name":"Bash","input":{"command":"go test ./pkg/foo","description":"Run tests"}}]},"uuid":"a2","timestamp":"2025-01-15T10:30:02Z"}`,
+	`{"parentUuid":"a2","sessionId":"5f0c","type":"user","message":{"role":"user","content":[{"tool_use_id":"toolu_1","type":"tool_result","content":"ok  pkg/foo 0.01s","is_error":false}]},"toolUseResult":{"stdout":"ok  pkg/foo 0.01s","stderr":"","interrupted":false,"isImage":false},"uuid":"u2","timestamp":"2025-01-15T10:30:03Z"}`,
+	`{"type":"user","sessionId":"5f0c","message":{"role":"user","content":[{"tool_use_id":"toolu_2","type":"tool_result","content":[{"type":"text","text":"file contents"}]}]},"toolUseResult":{"type":"text","file":{"filePath":"/workspace/main.go","content":"package main","numLines":1,"startLine":1,"totalLines":1}},"uuid":"u3"}`,
+	`{"type":"user","sessionId":"5f0c","message":{"role":"user","content":[{"tool_use_id":"toolu_3","type":"tool_result","content":"Error: command not found","is_error":true}]},"toolUseResult":"Error: command not found","uuid":"u4"}`,
+	`{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_4","content":"done"}]},"toolUseResult":{"oldTodos":[],"newTodos":[{"content":"a","status":"pending"}]},"uuid":"u5"}`,
+	`{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_5","content":"ok"}]},"toolUseResult":{"filenames":["a.go","b.go"],"numFiles":2,"durationMs":3},"uuid":"u6"}`,
+	`{"type":"assistant","isSidechain":true,"agentId":"agent-1","message":{"role":"assistant","content":[{"type":"tool_use","id":"toolu_6","name":"Task","input":{"subagent_type":"Explore","prompt":"Find the config loader"}},{"type":"text","text":"Spawning"}]},"uuid":"a3"}`,
+	`{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"toolu_7","name":"Edit","input":{"file_path":"/workspace/a.go","old_string":"x","new_string":"y"}}]},"uuid":"a4"}`,
+	`{"type":"progress","uuid":"p1","sessionId":"5f0c","toolUseID":"bash-progress-1","parentToolUseID":"toolu_1","data":{"type":"bash_progress","output":"ok","fullOutput":"ok pkg/a\nok","elapsedTimeSeconds":4,"totalLines":2},"timestamp":"2025-01-15T10:30:02.500Z"}`,
+	`{"type":"summary","summary":"Fixed the failing test","leafUuid":"a4"}`,
+	`{"type":"system","subtype":"compact_boundary","content":"Conversation compacted","isMeta":false,"uuid":"s1","level":"info"}`,
+	`{"type":"file-history-snapshot","messageId":"m1","snapshot":{"trackedFileBackups":{}}}`,
+	`{"type":"queue-operation","operation":"enqueue","timestamp":"2025-01-15T10:30:00Z","content":"next"}`,
+}
+
+func FuzzParseEntry(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+
+	adapter := New()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		events, err := adapter.ParseEntry(types.RawEntry{Line: 1, Data: json.RawMessage(data)})
+		if err != nil {
+			if !errors.Is(err, ErrMalformedEntry) {
+				t.Fatalf("error is not ErrMalformedEntry: %v", err)
+			}
+			if events != nil {
+				t.Fatalf("events returned alongside error: %v", err)
+			}
+			return
+		}
+
+		for _, event := range events {
+			if event.EventID == "" || event.EventType == "" {
+				t.Fatalf("event without ID or type: %+v", event)
+			}
+			if !utf8.ValidString(event.ContentPreview) || !utf8.ValidString(event.ToolInputSummary) {
+				t.Fatalf("invalid UTF-8 in preview %q / summary %q", event.ContentPreview, event.ToolInputSummary)
+			}
+			if event.EventType != types.EventTypeToolResultDelta && len(event.ContentPreview) > maxPreviewLen {
+				t.Fatalf("preview of %d bytes exceeds %d", len(event.ContentPreview), maxPreviewLen)
+			}
+		}
+	})
+}
+
+// Regression cases for inputs found by FuzzParseEntry; the minimized originals live in
+// testdata/fuzz/FuzzParseEntry
+func TestAdapter_ParseEntry_MalformedInput(t *testing.T) {
+	adapter := New()
+
+	for name, data := range map[string]string{
+		"empty":               ``,
+		"not json":            `{"type": "user"`,
+		"wrong field type":    `{"type": "user", "uuid": 42}`,
+		"content not a list":  `{"type": "assistant", "message": {"role": "assistant", "content": 7}}`,
+		"progress data array": `{"type": "progress", "parentToolUseID": "toolu_1", "data": [1]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			events, err := adapter.ParseEntry(types.RawEntry{Data: json.RawMessage(data)})
+			assert.ErrorIs(t, err, ErrMalformedEntry)
+			assert.Nil(t, events)
+		})
+	}
+}
+
+func TestAdapter_ParseEntry_InvalidUTF8InRawSnippet(t *testing.T) {
+	adapter := New()
+
+	events := parseEntry(t, adapter, []byte("{\"type\":\"user\",\"toolUseResult\":{\"\":\"\xda\"}}"))
+	require.Len(t, events, 1)
+	assert.True(t, utf8.ValidString(events[0].ContentPreview))
+}
+
+func TestAdapter_ParseEntry_TruncatesOnRuneBoundary(t *testing.T) {
+	adapter := New()
+
+	text := strings.Repeat("日本", 200)
+	rawJSON, err := json.Marshal(map[string]any{
+		"type":    "assistant",
+		"message": map[string]any{"role": "assistant", "content": []any{map[string]any{"type": "text", "text": text}}},
+	})
+	require.NoError(t, err)
+
+	events := parseEntry(t, adapter, rawJSON)
+	require.Len(t, events, 1)
+	assert.True(t, utf8.ValidString(events[0].ContentPreview))
+	assert.LessOrEqual(t, len(events[0].ContentPreview), maxPreviewLen)
+	assert.Equal(t, len(text), events[0].ContentLength)
+}
+
+func TestAdapter_ParseEntry_LongFilePathPreview(t *testing.T) {
+	adapter := New()
+
+	path := "/" + strings.Repeat("a", 2000)
+	rawJSON, err := json.Marshal(map[string]any{
+		"type":          "user",
+		"toolUseResult": map[string]any{"type": "create", "filePath": path, "content": "x"},
+	})
+	require.NoError(t, err)
+
+	events := parseEntry(t, adapter, rawJSON)
+	require.Len(t, events, 1)
+	assert.Equal(t, path, events[0].FilePath)
+	assert.LessOrEqual(t, len(events[0].ContentPreview), maxPreviewLen)
+}
+
+func TestAdapter_ParseEntry_NullToolUseResult(t *testing.T) {
+	adapter := New()
+
+	events := parseEntry(t, adapter, []byte(`{"type": "user", "toolUseResult": null, "message": {"role": "user", "content": "hello"}}`))
+	require.Len(t, events, 1)
+	assert.Equal(t, types.EventTypeUserPrompt, events[0].EventType)
+	assert.Equal(t, "hello", events[0].ContentPreview)
+}
+
+func TestAdapter_ParseEntry_DeeplyNestedContent(t *testing.T) {
+	adapter := New()
+
+	// Within encoding/json's nesting limit the content still parses, with a bounded preview
+	nested := strings.Repeat("[", 5000) + strings.Repeat("]", 5000)
+	events := parseEntry(t, adapter, []byte(`{"type": "user", "message": {"role": "user", "content": [{"type": "tool_result", "tool_use_id": "toolu_1", "content": `+nested+`}]}}`))
+	require.Len(t, events, 1)
+	assert.LessOrEqual(t, len(events[0].ContentPreview), maxPreviewLen)
+	assert.Equal(t, len(nested), events[0].ContentLength)
+
+	// Beyond it the entry is rejected rather than exhausting the stack
+	tooDeep := strings.Repeat("[", 20000) + strings.Repeat("]", 20000)
+	_, err := adapter.ParseEntry(types.RawEntry{Data: json.RawMessage(`{"type": "user", "message": {"role": "user", "content": [{"type": "tool_result", "content": ` + tooDeep + `}]}}`)})
+	assert.ErrorIs(t, err, ErrMalformedEntry)
+}
@@ -43,13 +43,24 @@ func (o *ClaudeObserver) Discover(ctx context.Context, run types.RunContext) (ty
 }
 
 func (o *ClaudeObserver) NewParser(run types.RunContext) types.Parser {
-	return &ClaudeParser{
+	p := &ClaudeParser{
 		run:               run,
 		adapter:           &Adapter{},
 		toolUseNames:      make(map[string]string),
+		subagentTasks:     make(map[string]subagentTask),
 		seenSessions:      make(map[string]bool),
 		sequenceBySession: make(map[string]int64),
 	}
+	if run.ToolResultDeltas {
+		p.deltas = types.NewDeltaTracker()
+	}
+	return p
+}
+
+// subagentTask is the input of a Task call, kept to describe its sub-agent when it stops
+type subagentTask struct {
+	agentType   string
+	description string
 }
 
 type ClaudeParser struct {
@@ -57,9 +68,11 @@ type ClaudeParser struct {
 	adapter           *Adapter
 	mu                sync.Mutex
 	toolUseNames      map[string]string
+	subagentTasks     map[string]subagentTask // Task calls whose sub-agent has not stopped, by tool_use ID
 	seenSessions      map[string]bool
 	sequenceBySession map[string]int64
-	mainSessionID     string // First session ID seen from a non-agent file
+	mainSessionID     string              // First session ID seen from a non-agent file
+	deltas            *types.DeltaTracker // nil unless delta mode is enabled
 }
 
 func (p *ClaudeParser) OnLine(ctx context.Context, stream types.StreamID, line []byte) ([]types.ParsedEvent, error) {
@@ -88,10 +101,16 @@ func (p *ClaudeParser) OnLine(ctx context.Context, stream types.StreamID, line [
 		for _, item := range entry.Message.Content {
 			if item.Type == "tool_use" && item.ID != "" && item.Name != "" {
 				p.toolUseNames[item.ID] = item.Name
+				if item.Name == "Task" {
+					agentType, _ := item.Input["subagent_type"].(string)
+					description, _ := item.Input["description"].(string)
+					p.subagentTasks[item.ID] = subagentTask{agentType: agentType, description: description}
+				}
 			}
 		}
 
 	}
+	events = p.stopSubagents(events)
 
 	var toolResultIDs []string
 	if entry.Message != nil {
@@ -111,6 +130,9 @@ func (p *ClaudeParser) OnLine(ctx context.Context, stream types.StreamID, line [
 
 	for i := range events {
 		event := events[i]
+		if !p.keepDelta(&event) {
+			continue
+		}
 		event.SourceFile = stream.Name
 
 		if event.SessionID != "" {
@@ -151,7 +173,7 @@ func (p *ClaudeParser) OnLine(ctx context.Context, stream types.StreamID, line [
 		if event.EventType == types.EventTypeToolResult && event.ToolName == "" {
 			if toolResultIndex < len(toolResultIDs) {
 				if name, ok := p.toolUseNames[toolResultIDs[toolResultIndex]]; ok {
-					event.ToolName = name
+					event.ToolNamespace, event.ToolName = types.SplitMCPToolName(name)
 				}
 				toolResultIndex++
 			} else if entry.ToolUseResult != nil {
@@ -160,7 +182,7 @@ func (p *ClaudeParser) OnLine(ctx context.Context, stream types.StreamID, line [
 				}
 				if json.Unmarshal(entry.ToolUseResult, &probe) == nil {
 					if name, ok := p.toolUseNames[probe.ToolUseID]; ok {
-						event.ToolName = name
+						event.ToolNamespace, event.ToolName = types.SplitMCPToolName(name)
 					}
 				}
 			}
@@ -172,6 +194,61 @@ func (p *ClaudeParser) OnLine(ctx context.Context, stream types.StreamID, line [
 	return result, nil
 }
 
+// stopSubagents pairs the results of Task calls with a subagent_stop describing the
+// sub-agent. The adapter only emits one when the result carries the sub-agent's
+// report, so one is added after other results of a Task call (errors, interrupts).
+func (p *ClaudeParser) stopSubagents(events []types.ParsedEvent) []types.ParsedEvent {
+	out := make([]types.ParsedEvent, 0, len(events))
+	for i, event := range events {
+		task, ok := p.subagentTasks[event.ToolUseID]
+		if !ok || event.ToolUseID == "" {
+			out = append(out, event)
+			continue
+		}
+
+		switch event.EventType {
+		case types.EventTypeSubagentStop:
+			event.ContentPreview = truncateString(subagentPreview(event.ContentPreview, task.agentType, task.description), maxPreviewLen)
+			delete(p.subagentTasks, event.ToolUseID)
+			out = append(out, event)
+		case types.EventTypeToolResult:
+			out = append(out, event)
+			if i+1 < len(events) && events[i+1].EventType == types.EventTypeSubagentStop && events[i+1].ToolUseID == event.ToolUseID {
+				continue
+			}
+			prefix := "Sub-agent finished"
+			if event.ToolSuccess != nil && !*event.ToolSuccess {
+				prefix = "Sub-agent failed"
+			}
+			stop := event
+			stop.EventID = generateEventID()
+			stop.EventType = types.EventTypeSubagentStop
+			stop.Kind = types.KindForEvent(stop.EventType)
+			stop.Level = types.LevelForEvent(stop.EventType)
+			stop.ToolName = "Task"
+			stop.ToolSuccess = nil
+			stop.ToolError = ""
+			stop.ResultContentType = ""
+			stop.ContentLength = 0
+			stop.ContentPreview = truncateString(subagentPreview(prefix, task.agentType, task.description), maxPreviewLen)
+			delete(p.subagentTasks, event.ToolUseID)
+			out = append(out, stop)
+		default:
+			out = append(out, event)
+		}
+	}
+	return out
+}
+
+// keepDelta reports whether event should be emitted: tool_result_delta events are
+// dropped outside delta mode and reduced to their new output inside it.
+func (p *ClaudeParser) keepDelta(event *types.ParsedEvent) bool {
+	if p.deltas == nil {
+		return event.EventType != types.EventTypeToolResultDelta
+	}
+	return p.deltas.Apply(event)
+}
+
 func (p *ClaudeParser) Flush(ctx context.Context) ([]types.ParsedEvent, error) {
 	_ = ctx
 
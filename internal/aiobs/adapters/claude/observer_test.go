@@ -265,3 +265,97 @@ func TestClaudeParser_MainSessionDoesNotGetParentSessionID(t *testing.T) {
 		assert.False(t, e.IsSidechain, "Regular file events should not be sidechain")
 	}
 }
+
+func TestClaudeParser_ToolResultDeltas(t *testing.T) {
+	stream := types.StreamID{Name: "session.jsonl", StreamType: "fs-jsonl"}
+	progress := func(fullOutput string) []byte {
+		return []byte(`{"type": "progress", "uuid": "p", "sessionId": "session-123", "timestamp": "2025-01-15T10:30:00.000Z",
+			"parentToolUseID": "toolu_1", "data": {"type": "bash_progress", "fullOutput": "` + fullOutput + `"}}`)
+	}
+
+	t.Run("delta mode emits increments", func(t *testing.T) {
+		parser := NewObserver().NewParser(types.RunContext{ToolResultDeltas: true})
+
+		first, err := parser.OnLine(context.Background(), stream, progress("a\\n"))
+		require.NoError(t, err)
+		require.Len(t, first, 2) // session_start + delta
+		assert.Equal(t, types.EventTypeToolResultDelta, first[1].EventType)
+		assert.Equal(t, "a\n", first[1].ContentPreview)
+
+		second, err := parser.OnLine(context.Background(), stream, progress("a\\nb\\n"))
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+		assert.Equal(t, "b\n", second[0].ContentPreview)
+		assert.Greater(t, second[0].Sequence, first[1].Sequence)
+	})
+
+	t.Run("deltas dropped by default", func(t *testing.T) {
+		parser := NewObserver().NewParser(types.RunContext{})
+
+		events, err := parser.OnLine(context.Background(), stream, progress("a\\n"))
+		require.NoError(t, err)
+		assert.Empty(t, events, "no session_start for a line that emits nothing")
+	})
+}
+
+func TestClaudeParser_TaskEmitsSubagentStartAndStop(t *testing.T) {
+	parser := NewObserver().NewParser(types.RunContext{})
+	stream := types.StreamID{Name: "session.jsonl", StreamType: "fs-jsonl"}
+
+	taskLine := []byte(`{
+		"type": "assistant",
+		"uuid": "assistant-1",
+		"timestamp": "2025-01-15T10:30:00.000Z",
+		"sessionId": "session-123",
+		"message": {
+			"role": "assistant",
+			"content": [
+				{"type": "tool_use", "id": "toolu_task", "name": "Task", "input": {"subagent_type": "explore", "description": "Find parser edge cases", "prompt": "Inspect the parser"}},
+				{"type": "tool_use", "id": "toolu_failed", "name": "Task", "input": {"description": "Run the linter", "prompt": "Run golangci-lint"}}
+			]
+		}
+	}`)
+	events, err := parser.OnLine(context.Background(), stream, taskLine)
+	require.NoError(t, err)
+	require.Len(t, events, 5) // session_start, then tool_use and subagent_start per call
+	assert.Equal(t, types.EventTypeSubagentStart, events[2].EventType)
+	assert.Equal(t, "Spawning sub-agent [explore]: Find parser edge cases", events[2].ContentPreview)
+	assert.Equal(t, "Spawning sub-agent: Run the linter", events[4].ContentPreview)
+
+	// The sub-agent's report: the adapter emits the stop, the parser describes it
+	reportLine := []byte(`{
+		"type": "user",
+		"uuid": "user-1",
+		"timestamp": "2025-01-15T10:31:00.000Z",
+		"sessionId": "session-123",
+		"message": {"role": "user", "content": [{"type": "tool_result", "tool_use_id": "toolu_task", "content": [{"type": "text", "text": "Found 3"}]}]},
+		"toolUseResult": {"status": "completed", "agentId": "a1", "content": [{"type": "text", "text": "Found 3"}], "totalDurationMs": 5400}
+	}`)
+	events, err = parser.OnLine(context.Background(), stream, reportLine)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, types.EventTypeToolResult, events[0].EventType)
+	assert.Equal(t, types.EventTypeSubagentStop, events[1].EventType)
+	assert.Equal(t, "Sub-agent finished [explore]: Find parser edge cases", events[1].ContentPreview)
+	assert.Greater(t, events[1].Sequence, events[0].Sequence)
+
+	// A failed call has no report; the parser adds the stop
+	errorLine := []byte(`{
+		"type": "user",
+		"uuid": "user-2",
+		"timestamp": "2025-01-15T10:31:05.000Z",
+		"sessionId": "session-123",
+		"message": {"role": "user", "content": [{"type": "tool_result", "tool_use_id": "toolu_failed", "content": "Agent crashed", "is_error": true}]}
+	}`)
+	events, err = parser.OnLine(context.Background(), stream, errorLine)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, types.EventTypeToolResult, events[0].EventType)
+	assert.Equal(t, "Task", events[0].ToolName)
+	stop := events[1]
+	assert.Equal(t, types.EventTypeSubagentStop, stop.EventType)
+	assert.Equal(t, types.KindLifecycle, stop.Kind)
+	assert.Equal(t, "Sub-agent failed: Run the linter", stop.ContentPreview)
+	assert.Equal(t, "toolu_failed", stop.ToolUseID)
+	assert.NotEqual(t, events[0].EventID, stop.EventID)
+}
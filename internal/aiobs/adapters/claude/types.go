@@ -11,7 +11,7 @@ import (
 // TranscriptEntry represents a single entry in Claude Code's transcript.jsonl file.
 type TranscriptEntry struct {
 	// Common fields for all entry types
-	Type        string `json:"type"`                 // "user", "assistant", "summary", "system", "queue-operation"
+	Type        string `json:"type"`                 // "user", "assistant", "summary", "system", "progress", "queue-operation"
 	UUID        string `json:"uuid"`                 // Unique message ID
 	ParentUUID  string `json:"parentUuid,omitempty"` // Links messages in conversation
 	SessionID   string `json:"sessionId,omitempty"`  // Session identifier
@@ -21,6 +21,7 @@ type TranscriptEntry struct {
 	Version     string `json:"version,omitempty"`    // Claude Code version
 	UserType    string `json:"userType,omitempty"`   // "external", "internal"
 	IsSidechain bool   `json:"isSidechain,omitempty"`
+	IsMeta      bool   `json:"isMeta,omitempty"` // Injected by Claude Code, not typed by the user
 	AgentID     string `json:"agentId,omitempty"`
 
 	// Message content (for user/assistant types)
@@ -29,6 +30,10 @@ type TranscriptEntry struct {
 	// Tool use result (for user messages that are tool results)
 	ToolUseResult json.RawMessage `json:"toolUseResult,omitempty"`
 
+	// Progress fields (for progress type): partial output of a running tool call
+	Data            json.RawMessage `json:"data,omitempty"`
+	ParentToolUseID string          `json:"parentToolUseID,omitempty"` // tool_use id the progress belongs to
+
 	// Summary fields (for summary type)
 	Summary  string `json:"summary,omitempty"`
 	LeafUUID string `json:"leafUuid,omitempty"`
@@ -94,7 +99,7 @@ func (m *Message) UnmarshalJSON(data []byte) error {
 
 // ContentItem represents a single content block in a message.
 type ContentItem struct {
-	Type string `json:"type"` // "text", "tool_use", "tool_result", "thinking", "image"
+	Type string `json:"type"` // "text", "tool_use", "tool_result", "thinking", "image", "document"
 
 	// For text content
 	Text string `json:"text,omitempty"`
@@ -112,15 +117,17 @@ type ContentItem struct {
 	// For thinking
 	Thinking string `json:"thinking,omitempty"`
 
-	// For image
+	// For image and document
 	Source *ImageSource `json:"source,omitempty"`
+	Title  string       `json:"title,omitempty"` // Document title
 }
 
-// ImageSource represents image data in a message.
+// ImageSource represents image or document data in a message.
 type ImageSource struct {
-	Type      string `json:"type"`       // "base64"
-	MediaType string `json:"media_type"` // e.g., "image/png"
-	Data      string `json:"data"`       // Base64 encoded data
+	Type      string `json:"type"`          // "base64", "url", "text"
+	MediaType string `json:"media_type"`    // e.g., "image/png", "application/pdf"
+	Data      string `json:"data"`          // Base64 encoded data (or plain text)
+	URL       string `json:"url,omitempty"` // For url sources
 }
 
 // UsageInfo contains token usage information.
@@ -160,6 +167,17 @@ type ToolUseResult struct {
 	NumFiles  int      `json:"numFiles,omitempty"`
 }
 
+// TaskResult is the toolUseResult of a Task call: the sub-agent's final report.
+type TaskResult struct {
+	Status            string        `json:"status,omitempty"` // "completed"
+	Prompt            string        `json:"prompt,omitempty"`
+	AgentID           string        `json:"agentId,omitempty"`
+	Content           []ContentItem `json:"content,omitempty"`
+	TotalDurationMs   int64         `json:"totalDurationMs,omitempty"`
+	TotalTokens       int           `json:"totalTokens,omitempty"`
+	TotalToolUseCount int           `json:"totalToolUseCount,omitempty"`
+}
+
 // FileResult contains file content and metadata from Read tool.
 type FileResult struct {
 	Content    string `json:"content"`
@@ -168,3 +186,13 @@ type FileResult struct {
 	StartLine  int    `json:"startLine"`
 	TotalLines int    `json:"totalLines"`
 }
+
+// BashProgress is the data of a "bash_progress" progress entry, written periodically
+// while a Bash command runs.
+type BashProgress struct {
+	Type               string  `json:"type"`       // "bash_progress"
+	Output             string  `json:"output"`     // Most recent output
+	FullOutput         string  `json:"fullOutput"` // All output so far
+	ElapsedTimeSeconds float64 `json:"elapsedTimeSeconds,omitempty"`
+	TotalLines         int     `json:"totalLines,omitempty"`
+}
@@ -0,0 +1,329 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package codex provides an adapter for OpenAI Codex CLI transcripts.
+//
+// Codex CLI records a session as a rollout JSONL file. Each line is an envelope,
+// {"timestamp":...,"type":...,"payload":{...}}: session_meta opens the file,
+// response_item lines hold the Responses API items (message, reasoning,
+// function_call, function_call_output) and event_msg lines mirror them for the UI,
+// plus the token_count reported after every model response.
+package codex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/noldarim/noldarim/internal/aiobs/types"
+)
+
+// ErrMalformedEntry is wrapped by every error ParseEntry returns for input it cannot parse
+var ErrMalformedEntry = errors.New("malformed codex transcript entry")
+
+// maxPreviewLen matches the ContentPreview size of the other adapters
+const maxPreviewLen = 500
+
+// toolNames maps Codex CLI tools to the Claude names the TUI knows how to render.
+// Other tools (MCP servers, update_plan) keep their own name.
+var toolNames = map[string]string{
+	"shell":       "Bash",
+	"local_shell": "Bash",
+	"apply_patch": "Edit",
+	"view_image":  "Read",
+}
+
+// contextPrefixes start the user messages the CLI injects ahead of the task
+var contextPrefixes = []string{"<environment_context>", "<user_instructions>"}
+
+// Adapter implements the types.Adapter interface for Codex CLI transcripts.
+type Adapter struct{}
+
+// New creates a new Codex adapter instance.
+func New() *Adapter {
+	return &Adapter{}
+}
+
+func (a *Adapter) Name() string {
+	return "codex"
+}
+
+// Matches reports whether sample starts with a rollout envelope
+func (a *Adapter) Matches(sample []byte) bool {
+	line, _, _ := strings.Cut(string(sample), "\n")
+	var entry TranscriptEntry
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		return false
+	}
+	switch entry.Type {
+	case entrySessionMeta, entryResponseItem, entryEventMsg, "turn_context":
+		return len(entry.Payload) > 0
+	}
+	return false
+}
+
+// ParseEntry converts one rollout line to ParsedEvents. session_meta becomes
+// session_start and response items become prompt, thinking, output and tool events.
+// event_msg lines only repeat the items, except token_count, which becomes a stop
+// event carrying the usage of the response that just ended.
+func (a *Adapter) ParseEntry(raw types.RawEntry) ([]types.ParsedEvent, error) {
+	var entry TranscriptEntry
+	if err := json.Unmarshal(raw.Data, &entry); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal transcript entry: %w", ErrMalformedEntry, err)
+	}
+
+	// Lines after session_meta carry no session ID; the watcher routes them by the
+	// rollout file name
+	base := types.ParsedEvent{
+		SessionID:  raw.SessionID,
+		Timestamp:  parseTimestamp(entry.Timestamp),
+		SourceLine: raw.Line,
+		RawPayload: raw.Data,
+	}
+
+	var events []types.ParsedEvent
+	switch entry.Type {
+	case entrySessionMeta:
+		var meta SessionMeta
+		if err := json.Unmarshal(entry.Payload, &meta); err != nil {
+			return nil, fmt.Errorf("%w: failed to unmarshal session_meta: %w", ErrMalformedEntry, err)
+		}
+		event := base
+		event.EventType = types.EventTypeSessionStart
+		if meta.ID != "" {
+			event.SessionID = meta.ID
+		}
+		event.ContentPreview = meta.Cwd
+		events = append(events, event)
+	case entryResponseItem:
+		var item ResponseItem
+		if err := json.Unmarshal(entry.Payload, &item); err != nil {
+			return nil, fmt.Errorf("%w: failed to unmarshal response_item: %w", ErrMalformedEntry, err)
+		}
+		base.MessageUUID = item.ID
+		events = parseResponseItem(item, base)
+	case entryEventMsg:
+		var msg EventMsg
+		if err := json.Unmarshal(entry.Payload, &msg); err != nil {
+			return nil, fmt.Errorf("%w: failed to unmarshal event_msg: %w", ErrMalformedEntry, err)
+		}
+		if msg.Type != "token_count" || msg.Info == nil || msg.Info.LastTokenUsage == nil {
+			return nil, nil
+		}
+		// Cached tokens are part of input_tokens; split them out as the Claude
+		// adapter does so cost estimation does not count them twice
+		usage := msg.Info.LastTokenUsage
+		event := base
+		event.EventType = types.EventTypeStop
+		event.InputTokens = usage.InputTokens - usage.CachedInputTokens
+		event.CacheReadTokens = usage.CachedInputTokens
+		event.OutputTokens = usage.OutputTokens
+		events = append(events, event)
+	default:
+		// Skip turn_context and unknown envelopes
+		return nil, nil
+	}
+
+	for i := range events {
+		events[i].EventID = generateEventID()
+		events[i].Kind = types.KindForEvent(events[i].EventType)
+		events[i].Level = types.LevelForEvent(events[i].EventType)
+	}
+	return events, nil
+}
+
+// ExtractTaskPrompt returns the text of the first user message that is not context
+// injected by the CLI. Lines that fail to parse are ignored.
+func (a *Adapter) ExtractTaskPrompt(records []types.RawEntry) (string, bool) {
+	for _, raw := range records {
+		var entry TranscriptEntry
+		if err := json.Unmarshal(raw.Data, &entry); err != nil || entry.Type != entryResponseItem {
+			continue
+		}
+		var item ResponseItem
+		if err := json.Unmarshal(entry.Payload, &item); err != nil {
+			continue
+		}
+		if item.Type != itemMessage || item.Role != "user" {
+			continue
+		}
+		text := strings.TrimSpace(joinText(item.Content))
+		if text == "" || isInjectedContext(text) {
+			continue
+		}
+		return text, true
+	}
+	return "", false
+}
+
+func parseResponseItem(item ResponseItem, base types.ParsedEvent) []types.ParsedEvent {
+	event := base
+	switch item.Type {
+	case itemMessage:
+		text := joinText(item.Content)
+		if text == "" {
+			return nil
+		}
+		switch item.Role {
+		case "user":
+			event.EventType = types.EventTypeUserPrompt
+			event.IsHumanInput = !isInjectedContext(strings.TrimSpace(text))
+		case "assistant":
+			event.EventType = types.EventTypeAIOutput
+		default:
+			// Developer and system messages are instructions, not conversation
+			return nil
+		}
+		event.ContentPreview = truncateString(text, maxPreviewLen)
+		event.ContentLength = len(text)
+	case itemReasoning:
+		text := joinText(item.Summary)
+		if text == "" {
+			return nil
+		}
+		event.EventType = types.EventTypeThinking
+		event.ContentPreview = truncateString(text, maxPreviewLen)
+		event.ContentLength = len(text)
+	case itemFunctionCall:
+		var args map[string]interface{}
+		_ = json.Unmarshal([]byte(item.Arguments), &args)
+		event.EventType = types.EventTypeToolUse
+		event.ToolName = toolName(item.Name)
+		event.ToolUseID = item.CallID
+		event.ToolInputSummary = extractToolInputSummary(args)
+		event.FilePath = extractFilePath(args)
+		event.ContentPreview = event.ToolInputSummary
+	case itemFunctionCallOutput:
+		return []types.ParsedEvent{toolResultEvent(item, base)}
+	default:
+		return nil
+	}
+	return []types.ParsedEvent{event}
+}
+
+// toolResultEvent builds the tool_result for a function_call_output. Shell calls
+// encode their output and exit code as JSON inside the output string; a non-zero
+// exit code marks the call failed.
+func toolResultEvent(item ResponseItem, base types.ParsedEvent) types.ParsedEvent {
+	event := base
+	event.EventType = types.EventTypeToolResult
+	event.ToolUseID = item.CallID
+
+	content := rawText(item.Output)
+	success := true
+	var shell ShellOutput
+	if err := json.Unmarshal([]byte(content), &shell); err == nil && shell.Metadata != nil {
+		content = shell.Output
+		success = shell.Metadata.ExitCode == 0
+	}
+	event.ToolSuccess = &success
+	if !success {
+		event.ToolError = truncateString(content, maxPreviewLen)
+	}
+	event.ContentPreview = truncateString(content, maxPreviewLen)
+	event.ContentLength = len(content)
+	event.ResultContentType = types.DetectContentType(content)
+	return event
+}
+
+func joinText(items []ContentItem) string {
+	var texts []string
+	for _, item := range items {
+		if item.Text != "" {
+			texts = append(texts, item.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func isInjectedContext(text string) bool {
+	for _, prefix := range contextPrefixes {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func toolName(name string) string {
+	if mapped, ok := toolNames[name]; ok {
+		return mapped
+	}
+	return name
+}
+
+// rawText returns a JSON string's value, or other JSON as written
+func rawText(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// extractToolInputSummary prefers the shell command, which Codex passes as argv
+func extractToolInputSummary(args map[string]interface{}) string {
+	if argv, ok := args["command"].([]interface{}); ok && len(argv) > 0 {
+		parts := make([]string, 0, len(argv))
+		for _, arg := range argv {
+			parts = append(parts, fmt.Sprint(arg))
+		}
+		// Drop the "bash -lc" wrapper the CLI puts around scripts
+		if len(parts) == 3 && (parts[1] == "-lc" || parts[1] == "-c") {
+			parts = parts[2:]
+		}
+		return truncateString(strings.Join(parts, " "), 100)
+	}
+	for _, key := range []string{"command", "path", "file_path", "query", "input"} {
+		if val, ok := args[key].(string); ok && val != "" {
+			return truncateString(val, 100)
+		}
+	}
+	return ""
+}
+
+func extractFilePath(args map[string]interface{}) string {
+	for _, key := range []string{"path", "file_path"} {
+		if val, ok := args[key].(string); ok {
+			return val
+		}
+	}
+	return ""
+}
+
+func parseTimestamp(ts string) time.Time {
+	if ts == "" {
+		return time.Now()
+	}
+	if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+		return t
+	}
+	return time.Now()
+}
+
+// truncateString shortens s to at most maxLen bytes without splitting a rune
+func truncateString(s string, maxLen int) string {
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "\uFFFD")
+	}
+	if len(s) <= maxLen {
+		return s
+	}
+	cut := maxLen - 3
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
+
+// eventCounter provides uniqueness within the same nanosecond (thread-safe)
+var eventCounter atomic.Uint32
+
+func generateEventID() string {
+	count := eventCounter.Add(1)
+	return fmt.Sprintf("codex-%s-%04x", time.Now().Format("20060102150405.000000000"), count&0xFFFF)
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package codex
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/aiobs/types"
+)
+
+const (
+	sessionID   = "0199a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b"
+	rolloutFile = "testdata/rollout-2025-09-10T09-00-00-" + sessionID + ".jsonl"
+)
+
+// records reads the rollout and routes its lines as the watcher does: by the session
+// ID a line carries, which only session_meta has, or else by the file name
+func records(t *testing.T) []types.RawEntry {
+	data, err := os.ReadFile(rolloutFile)
+	require.NoError(t, err)
+	var entries []types.RawEntry
+	for i, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		raw := json.RawMessage(line)
+		id := types.ExtractSessionID(raw)
+		if id == "" {
+			id = types.SessionIDFromPath(rolloutFile)
+		}
+		entries = append(entries, types.RawEntry{Line: i + 1, Data: raw, SessionID: id})
+	}
+	return entries
+}
+
+func TestAdapter_Name(t *testing.T) {
+	assert.Equal(t, "codex", New().Name())
+}
+
+func TestAdapter_Matches(t *testing.T) {
+	a := New()
+	data, err := os.ReadFile(rolloutFile)
+	require.NoError(t, err)
+	assert.True(t, a.Matches(data))
+	assert.False(t, a.Matches([]byte(`{"type":"user","sessionId":"s1","uuid":"u1"}`)))
+	assert.False(t, a.Matches([]byte(`{"role":"user","parts":[{"text":"hi"}]}`)))
+	assert.False(t, a.Matches([]byte("# aider chat started at 2025-01-15 10:30:00")))
+}
+
+func TestAdapter_ParseEntry(t *testing.T) {
+	entries := records(t)
+
+	tests := []struct {
+		line            int
+		eventTypes      []string
+		inputTokens     int
+		outputTokens    int
+		cacheReadTokens int
+	}{
+		{line: 1, eventTypes: []string{types.EventTypeSessionStart}},
+		{line: 2, eventTypes: []string{types.EventTypeUserPrompt}},
+		{line: 3}, // turn_context
+		{line: 4, eventTypes: []string{types.EventTypeUserPrompt}},
+		{line: 5}, // event_msg mirroring the user message
+		{line: 6, eventTypes: []string{types.EventTypeThinking}},
+		{line: 7, eventTypes: []string{types.EventTypeToolUse}},
+		{line: 8, eventTypes: []string{types.EventTypeToolResult}},
+		{line: 9, eventTypes: []string{types.EventTypeStop}, inputTokens: 1128, outputTokens: 180, cacheReadTokens: 3072},
+		{line: 10, eventTypes: []string{types.EventTypeToolUse}},
+		{line: 11, eventTypes: []string{types.EventTypeToolResult}},
+		{line: 12, eventTypes: []string{types.EventTypeAIOutput}},
+		{line: 13},
+		{line: 14, eventTypes: []string{types.EventTypeStop}, inputTokens: 704, outputTokens: 80, cacheReadTokens: 4096},
+	}
+	require.Len(t, entries, len(tests))
+
+	for _, tt := range tests {
+		events, err := New().ParseEntry(entries[tt.line-1])
+		require.NoError(t, err, "line %d", tt.line)
+
+		var eventTypes []string
+		var input, output, cacheRead int
+		for _, e := range events {
+			eventTypes = append(eventTypes, e.EventType)
+			input += e.InputTokens
+			output += e.OutputTokens
+			cacheRead += e.CacheReadTokens
+			assert.Equal(t, sessionID, e.SessionID)
+			assert.Equal(t, tt.line, e.SourceLine)
+			assert.NotEmpty(t, e.EventID)
+			assert.Equal(t, types.KindForEvent(e.EventType), e.Kind)
+		}
+		assert.Equal(t, tt.eventTypes, eventTypes, "line %d", tt.line)
+		assert.Equal(t, tt.inputTokens, input, "line %d input tokens", tt.line)
+		assert.Equal(t, tt.outputTokens, output, "line %d output tokens", tt.line)
+		assert.Equal(t, tt.cacheReadTokens, cacheRead, "line %d cache read tokens", tt.line)
+	}
+}
+
+func TestAdapter_ParseEntry_Content(t *testing.T) {
+	entries := records(t)
+	parse := func(line int) types.ParsedEvent {
+		events, err := New().ParseEntry(entries[line-1])
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		return events[0]
+	}
+
+	assert.False(t, parse(2).IsHumanInput, "injected context is not human input")
+
+	prompt := parse(4)
+	assert.True(t, prompt.IsHumanInput)
+	assert.Equal(t, "Fix the failing test", prompt.ContentPreview)
+	assert.Equal(t, "2025-09-10T09:00:01.2Z", prompt.Timestamp.UTC().Format("2006-01-02T15:04:05.9Z"))
+
+	assert.Equal(t, "**Running the tests first**", parse(6).ContentPreview)
+
+	toolUse := parse(7)
+	assert.Equal(t, "Bash", toolUse.ToolName)
+	assert.Equal(t, "call_1", toolUse.ToolUseID)
+	assert.Equal(t, "go test ./...", toolUse.ToolInputSummary)
+
+	failed := parse(8)
+	assert.Equal(t, "call_1", failed.ToolUseID)
+	require.NotNil(t, failed.ToolSuccess)
+	assert.False(t, *failed.ToolSuccess)
+	assert.Equal(t, "--- FAIL: TestAdd\nFAIL", failed.ToolError)
+	assert.Equal(t, "--- FAIL: TestAdd\nFAIL", failed.ContentPreview)
+
+	assert.Equal(t, "Edit", parse(10).ToolName)
+
+	patched := parse(11)
+	require.NotNil(t, patched.ToolSuccess)
+	assert.True(t, *patched.ToolSuccess)
+	assert.Contains(t, patched.ContentPreview, "M add.go")
+
+	assert.Equal(t, "Fixed the off-by-one in Add.", parse(12).ContentPreview)
+}
+
+func TestAdapter_ParseEntry_SessionIDFromSessionMeta(t *testing.T) {
+	data, err := os.ReadFile(rolloutFile)
+	require.NoError(t, err)
+	meta, _, _ := strings.Cut(string(data), "\n")
+
+	// Only the session_meta line names the session
+	assert.Equal(t, sessionID, types.ExtractSessionID(json.RawMessage(meta)))
+	events, err := New().ParseEntry(types.RawEntry{Line: 1, Data: json.RawMessage(meta)})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, sessionID, events[0].SessionID)
+}
+
+func TestAdapter_ParseEntry_Malformed(t *testing.T) {
+	_, err := New().ParseEntry(types.RawEntry{Data: []byte(`{"type":`)})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrMalformedEntry))
+
+	_, err = New().ParseEntry(types.RawEntry{Data: []byte(`{"type":"response_item","payload":"oops"}`)})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrMalformedEntry))
+}
+
+func TestAdapter_ExtractTaskPrompt(t *testing.T) {
+	entries := records(t)
+
+	prompt, ok := New().ExtractTaskPrompt(entries)
+	require.True(t, ok)
+	assert.Equal(t, "Fix the failing test", prompt)
+
+	_, ok = New().ExtractTaskPrompt(entries[:3])
+	assert.False(t, ok, "environment context is not the task")
+}
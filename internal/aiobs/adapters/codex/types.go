@@ -0,0 +1,91 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package codex
+
+import "encoding/json"
+
+// TranscriptEntry is one line of a Codex CLI rollout file: a typed envelope
+// ({"type":"response_item","payload":{...}}) with the time it was recorded.
+// Only the session_meta payload names the session.
+type TranscriptEntry struct {
+	Timestamp string          `json:"timestamp,omitempty"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+}
+
+// Envelope types of a rollout line
+const (
+	entrySessionMeta  = "session_meta"
+	entryResponseItem = "response_item"
+	entryEventMsg     = "event_msg"
+)
+
+// SessionMeta is the payload of the session_meta line that opens a rollout
+type SessionMeta struct {
+	ID         string `json:"id"`
+	Timestamp  string `json:"timestamp,omitempty"`
+	Cwd        string `json:"cwd,omitempty"`
+	CLIVersion string `json:"cli_version,omitempty"`
+}
+
+// ResponseItem is a Responses API item: a message, a reasoning summary, a function
+// call from the model or the output the CLI sent back for it
+type ResponseItem struct {
+	Type      string          `json:"type"`
+	ID        string          `json:"id,omitempty"`
+	Role      string          `json:"role,omitempty"`
+	Content   []ContentItem   `json:"content,omitempty"`
+	Summary   []ContentItem   `json:"summary,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Arguments string          `json:"arguments,omitempty"`
+	CallID    string          `json:"call_id,omitempty"`
+	Output    json.RawMessage `json:"output,omitempty"`
+}
+
+// Response item types
+const (
+	itemMessage            = "message"
+	itemReasoning          = "reasoning"
+	itemFunctionCall       = "function_call"
+	itemFunctionCallOutput = "function_call_output"
+)
+
+// ContentItem is one text piece of a message (input_text, output_text) or of a
+// reasoning summary (summary_text)
+type ContentItem struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+}
+
+// ShellOutput is the JSON the CLI encodes into a shell call's output string
+type ShellOutput struct {
+	Output   string `json:"output"`
+	Metadata *struct {
+		ExitCode        int     `json:"exit_code"`
+		DurationSeconds float64 `json:"duration_seconds"`
+	} `json:"metadata,omitempty"`
+}
+
+// EventMsg is the payload of an event_msg line. Only token_count carries data the
+// response items do not already hold.
+type EventMsg struct {
+	Type string          `json:"type"`
+	Info *TokenCountInfo `json:"info,omitempty"`
+}
+
+// TokenCountInfo holds the usage of the last model response and the session total
+type TokenCountInfo struct {
+	TotalTokenUsage *TokenUsage `json:"total_token_usage,omitempty"`
+	LastTokenUsage  *TokenUsage `json:"last_token_usage,omitempty"`
+}
+
+// TokenUsage is OpenAI token accounting. InputTokens includes CachedInputTokens and
+// OutputTokens includes ReasoningOutputTokens.
+type TokenUsage struct {
+	InputTokens           int `json:"input_tokens"`
+	CachedInputTokens     int `json:"cached_input_tokens"`
+	OutputTokens          int `json:"output_tokens"`
+	ReasoningOutputTokens int `json:"reasoning_output_tokens"`
+	TotalTokens           int `json:"total_tokens"`
+}
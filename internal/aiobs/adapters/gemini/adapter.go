@@ -0,0 +1,315 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package gemini provides an adapter for Gemini CLI transcripts.
+//
+// Each JSONL line is a Content message, {"role":"user"|"model","parts":[...]}, with
+// the CLI's sessionId, timestamp and the response's usageMetadata alongside. Lines
+// logged as a raw GenerateContent response carry the message in candidates instead.
+package gemini
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/noldarim/noldarim/internal/aiobs/types"
+)
+
+// ErrMalformedEntry is wrapped by every error ParseEntry returns for input it cannot parse
+var ErrMalformedEntry = errors.New("malformed gemini transcript entry")
+
+// maxPreviewLen matches the ContentPreview size of the other adapters
+const maxPreviewLen = 500
+
+// toolNames maps Gemini CLI tools to the Claude names the TUI knows how to render.
+// Other tools (MCP servers, memory) keep their own name.
+var toolNames = map[string]string{
+	"read_file":           "Read",
+	"read_many_files":     "Read",
+	"write_file":          "Write",
+	"replace":             "Edit",
+	"run_shell_command":   "Bash",
+	"glob":                "Glob",
+	"search_file_content": "Grep",
+	"list_directory":      "LS",
+	"web_fetch":           "WebFetch",
+	"google_web_search":   "WebSearch",
+}
+
+// Adapter implements the types.Adapter interface for Gemini CLI transcripts.
+type Adapter struct{}
+
+// New creates a new Gemini adapter instance.
+func New() *Adapter {
+	return &Adapter{}
+}
+
+func (a *Adapter) Name() string {
+	return "gemini"
+}
+
+// Matches reports whether sample starts with a Gemini role/parts message
+func (a *Adapter) Matches(sample []byte) bool {
+	line, _, _ := strings.Cut(string(sample), "\n")
+	var probe struct {
+		Role       string            `json:"role"`
+		Parts      []json.RawMessage `json:"parts"`
+		Candidates []json.RawMessage `json:"candidates"`
+	}
+	if err := json.Unmarshal([]byte(line), &probe); err != nil {
+		return false
+	}
+	if len(probe.Candidates) > 0 {
+		return true
+	}
+	return (probe.Role == "user" || probe.Role == "model") && probe.Parts != nil
+}
+
+// ParseEntry converts one transcript line to ParsedEvents, one per part. Usage
+// metadata goes on the line's first event only, since token totals are summed over
+// events: promptTokenCount becomes InputTokens and candidatesTokenCount OutputTokens.
+func (a *Adapter) ParseEntry(raw types.RawEntry) ([]types.ParsedEvent, error) {
+	var entry TranscriptEntry
+	if err := json.Unmarshal(raw.Data, &entry); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal transcript entry: %w", ErrMalformedEntry, err)
+	}
+	entry.normalize()
+
+	base := types.ParsedEvent{
+		SessionID:   entry.SessionID,
+		MessageUUID: entry.ID,
+		Timestamp:   parseTimestamp(entry.Timestamp),
+		Model:       entry.Model,
+		StopReason:  entry.FinishReason,
+		SourceLine:  raw.Line,
+		RawPayload:  raw.Data,
+	}
+	if base.SessionID == "" {
+		base.SessionID = raw.SessionID
+	}
+	var events []types.ParsedEvent
+	switch entry.Role {
+	case "user":
+		events = parseUserParts(entry.Parts, base)
+	case "model":
+		events = parseModelParts(entry.Parts, base)
+	default:
+		// Skip entries that are not messages (session metadata and the like)
+		return nil, nil
+	}
+
+	if usage := entry.UsageMetadata; usage != nil && len(events) > 0 {
+		events[0].InputTokens = usage.PromptTokenCount
+		events[0].OutputTokens = usage.CandidatesTokenCount
+		events[0].CacheReadTokens = usage.CachedContentTokenCount
+	}
+	for i := range events {
+		events[i].EventID = generateEventID()
+		events[i].Kind = types.KindForEvent(events[i].EventType)
+		events[i].Level = types.LevelForEvent(events[i].EventType)
+	}
+	return events, nil
+}
+
+// ExtractTaskPrompt returns the text of the first user message that is not a
+// function response. Lines that fail to parse are ignored.
+func (a *Adapter) ExtractTaskPrompt(records []types.RawEntry) (string, bool) {
+	for _, raw := range records {
+		var entry TranscriptEntry
+		if err := json.Unmarshal(raw.Data, &entry); err != nil {
+			continue
+		}
+		entry.normalize()
+		if entry.Role != "user" {
+			continue
+		}
+
+		var parts []string
+		isToolResult := false
+		for _, part := range entry.Parts {
+			if part.FunctionResponse != nil {
+				isToolResult = true
+			}
+			if text := strings.TrimSpace(part.Text); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		if isToolResult || len(parts) == 0 {
+			continue
+		}
+		return strings.Join(parts, "\n\n"), true
+	}
+	return "", false
+}
+
+// normalize lifts the first candidate of a raw response into Role and Parts, and
+// prefers modelVersion, the model that actually answered, over the requested model
+func (e *TranscriptEntry) normalize() {
+	if len(e.Parts) == 0 && len(e.Candidates) > 0 && e.Candidates[0].Content != nil {
+		e.Role = e.Candidates[0].Content.Role
+		e.Parts = e.Candidates[0].Content.Parts
+		if e.FinishReason == "" {
+			e.FinishReason = e.Candidates[0].FinishReason
+		}
+	}
+	if e.ModelVersion != "" {
+		e.Model = e.ModelVersion
+	}
+}
+
+// parseUserParts returns a user_prompt for the typed text and a tool_result per
+// function response
+func parseUserParts(parts []Part, base types.ParsedEvent) []types.ParsedEvent {
+	var events []types.ParsedEvent
+	var texts []string
+	for _, part := range parts {
+		if part.FunctionResponse != nil {
+			events = append(events, toolResultEvent(*part.FunctionResponse, base))
+			continue
+		}
+		if part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	if len(texts) > 0 {
+		text := strings.Join(texts, "\n")
+		event := base
+		event.EventType = types.EventTypeUserPrompt
+		event.IsHumanInput = true
+		event.ContentPreview = truncateString(text, maxPreviewLen)
+		event.ContentLength = len(text)
+		events = append([]types.ParsedEvent{event}, events...)
+	}
+	return events
+}
+
+// parseModelParts returns thinking, ai_output and tool_use events in part order
+func parseModelParts(parts []Part, base types.ParsedEvent) []types.ParsedEvent {
+	var events []types.ParsedEvent
+	for _, part := range parts {
+		event := base
+		switch {
+		case part.FunctionCall != nil:
+			call := part.FunctionCall
+			event.EventType = types.EventTypeToolUse
+			event.ToolName = toolName(call.Name)
+			event.ToolUseID = call.ID
+			event.ToolInputSummary = extractToolInputSummary(call.Args)
+			event.FilePath = extractFilePath(call.Args)
+			event.ContentPreview = event.ToolInputSummary
+		case part.Text != "":
+			event.EventType = types.EventTypeAIOutput
+			if part.Thought {
+				event.EventType = types.EventTypeThinking
+			}
+			event.ContentPreview = truncateString(part.Text, maxPreviewLen)
+			event.ContentLength = len(part.Text)
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func toolResultEvent(resp FunctionResponse, base types.ParsedEvent) types.ParsedEvent {
+	event := base
+	event.EventType = types.EventTypeToolResult
+	event.ToolName = toolName(resp.Name)
+	event.ToolUseID = resp.ID
+
+	var body struct {
+		Output json.RawMessage `json:"output"`
+		Error  json.RawMessage `json:"error"`
+	}
+	_ = json.Unmarshal(resp.Response, &body)
+
+	success := len(body.Error) == 0 || string(body.Error) == "null"
+	event.ToolSuccess = &success
+	content := rawText(body.Output)
+	if !success {
+		event.ToolError = truncateString(rawText(body.Error), maxPreviewLen)
+		content = event.ToolError
+	} else if len(body.Output) == 0 {
+		content = string(resp.Response)
+	}
+	event.ContentPreview = truncateString(content, maxPreviewLen)
+	event.ContentLength = len(content)
+	event.ResultContentType = types.DetectContentType(content)
+	return event
+}
+
+func toolName(name string) string {
+	if mapped, ok := toolNames[name]; ok {
+		return mapped
+	}
+	return name
+}
+
+// rawText returns a JSON string's value, or other JSON as written
+func rawText(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+func extractToolInputSummary(args map[string]interface{}) string {
+	for _, key := range []string{"command", "file_path", "absolute_path", "path", "pattern", "query", "url", "prompt"} {
+		if val, ok := args[key].(string); ok && val != "" {
+			return truncateString(val, 100)
+		}
+	}
+	if paths, ok := args["paths"].([]interface{}); ok && len(paths) > 0 {
+		return fmt.Sprintf("[%d paths]", len(paths))
+	}
+	return ""
+}
+
+func extractFilePath(args map[string]interface{}) string {
+	for _, key := range []string{"file_path", "absolute_path", "path"} {
+		if val, ok := args[key].(string); ok {
+			return val
+		}
+	}
+	return ""
+}
+
+func parseTimestamp(ts string) time.Time {
+	if ts == "" {
+		return time.Now()
+	}
+	if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+		return t
+	}
+	return time.Now()
+}
+
+// truncateString shortens s to at most maxLen bytes without splitting a rune
+func truncateString(s string, maxLen int) string {
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "\uFFFD")
+	}
+	if len(s) <= maxLen {
+		return s
+	}
+	cut := maxLen - 3
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
+
+// eventCounter provides uniqueness within the same nanosecond (thread-safe)
+var eventCounter atomic.Uint32
+
+func generateEventID() string {
+	count := eventCounter.Add(1)
+	return fmt.Sprintf("gemini-%s-%04x", time.Now().Format("20060102150405.000000000"), count&0xFFFF)
+}
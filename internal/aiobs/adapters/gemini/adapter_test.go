@@ -0,0 +1,140 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package gemini
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/aiobs/types"
+)
+
+const sampleTranscript = `{"sessionId":"g1","timestamp":"2025-06-01T10:00:00Z","role":"user","parts":[{"text":"Fix the failing test"}]}
+{"sessionId":"g1","timestamp":"2025-06-01T10:00:02Z","role":"model","modelVersion":"gemini-2.5-pro","parts":[{"text":"Looking at the test first.","thought":true},{"text":"Let me read it."},{"functionCall":{"id":"call-1","name":"read_file","args":{"absolute_path":"/repo/main_test.go"}}}],"usageMetadata":{"promptTokenCount":1200,"candidatesTokenCount":85,"cachedContentTokenCount":1000,"totalTokenCount":1285}}
+{"sessionId":"g1","timestamp":"2025-06-01T10:00:03Z","role":"user","parts":[{"functionResponse":{"id":"call-1","name":"read_file","response":{"output":"package main"}}}]}
+{"sessionId":"g1","timestamp":"2025-06-01T10:00:05Z","role":"user","parts":[{"functionResponse":{"id":"call-2","name":"run_shell_command","response":{"error":"exit status 1"}}}]}`
+
+func records() []types.RawEntry {
+	var entries []types.RawEntry
+	for i, line := range strings.Split(sampleTranscript, "\n") {
+		entries = append(entries, types.RawEntry{Line: i + 1, Data: []byte(line)})
+	}
+	return entries
+}
+
+func TestAdapter_Matches(t *testing.T) {
+	a := New()
+	assert.True(t, a.Matches([]byte(sampleTranscript)))
+	assert.True(t, a.Matches([]byte(`{"candidates":[{"content":{"role":"model","parts":[{"text":"hi"}]}}]}`)))
+	assert.False(t, a.Matches([]byte(`{"type":"user","sessionId":"s1"}`)))
+	assert.False(t, a.Matches([]byte("# aider chat started at 2025-01-15 10:30:00")))
+}
+
+func TestAdapter_ParseEntry(t *testing.T) {
+	a := New()
+	var events []types.ParsedEvent
+	for _, raw := range records() {
+		entryEvents, err := a.ParseEntry(raw)
+		require.NoError(t, err)
+		events = append(events, entryEvents...)
+	}
+
+	var eventTypes []string
+	for _, e := range events {
+		eventTypes = append(eventTypes, e.EventType)
+		assert.Equal(t, "g1", e.SessionID)
+		assert.NotEmpty(t, e.EventID)
+		assert.Equal(t, types.KindForEvent(e.EventType), e.Kind)
+	}
+	assert.Equal(t, []string{
+		types.EventTypeUserPrompt,
+		types.EventTypeThinking,
+		types.EventTypeAIOutput,
+		types.EventTypeToolUse,
+		types.EventTypeToolResult,
+		types.EventTypeToolResult,
+	}, eventTypes)
+
+	prompt := events[0]
+	assert.True(t, prompt.IsHumanInput)
+	assert.Equal(t, "Fix the failing test", prompt.ContentPreview)
+	assert.Equal(t, 1, prompt.SourceLine)
+
+	// Usage is counted once per response, on its first event
+	thinking := events[1]
+	assert.Equal(t, "gemini-2.5-pro", thinking.Model)
+	assert.Equal(t, 1200, thinking.InputTokens)
+	assert.Equal(t, 85, thinking.OutputTokens)
+	assert.Equal(t, 1000, thinking.CacheReadTokens)
+	assert.Equal(t, "2025-06-01T10:00:02Z", thinking.Timestamp.UTC().Format("2006-01-02T15:04:05Z"))
+	assert.Equal(t, "Let me read it.", events[2].ContentPreview)
+	assert.Equal(t, "gemini-2.5-pro", events[2].Model)
+	assert.Zero(t, events[2].InputTokens)
+	assert.Zero(t, events[3].OutputTokens)
+
+	toolUse := events[3]
+	assert.Equal(t, "Read", toolUse.ToolName)
+	assert.Equal(t, "call-1", toolUse.ToolUseID)
+	assert.Equal(t, "/repo/main_test.go", toolUse.FilePath)
+	assert.Equal(t, "/repo/main_test.go", toolUse.ToolInputSummary)
+
+	result := events[4]
+	assert.Equal(t, "Read", result.ToolName)
+	assert.Equal(t, "call-1", result.ToolUseID)
+	require.NotNil(t, result.ToolSuccess)
+	assert.True(t, *result.ToolSuccess)
+	assert.Equal(t, "package main", result.ContentPreview)
+	assert.Zero(t, result.InputTokens)
+
+	failed := events[5]
+	assert.Equal(t, "Bash", failed.ToolName)
+	require.NotNil(t, failed.ToolSuccess)
+	assert.False(t, *failed.ToolSuccess)
+	assert.Equal(t, "exit status 1", failed.ToolError)
+}
+
+func TestAdapter_ParseEntry_Candidates(t *testing.T) {
+	raw := types.RawEntry{
+		SessionID: "from-watcher",
+		Data: []byte(`{"candidates":[{"content":{"role":"model","parts":[{"functionCall":{"name":"mcp_lookup","args":{"query":"docs"}}}]},"finishReason":"STOP"}],` +
+			`"usageMetadata":{"promptTokenCount":10,"candidatesTokenCount":3}}`),
+	}
+	events, err := New().ParseEntry(raw)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, types.EventTypeToolUse, events[0].EventType)
+	assert.Equal(t, "mcp_lookup", events[0].ToolName, "unknown tools keep their name")
+	assert.Equal(t, "docs", events[0].ToolInputSummary)
+	assert.Equal(t, "STOP", events[0].StopReason)
+	assert.Equal(t, "from-watcher", events[0].SessionID)
+	assert.Equal(t, 10, events[0].InputTokens)
+	assert.Equal(t, 3, events[0].OutputTokens)
+}
+
+func TestAdapter_ParseEntry_Malformed(t *testing.T) {
+	_, err := New().ParseEntry(types.RawEntry{Data: []byte(`{"role":`)})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrMalformedEntry))
+
+	events, err := New().ParseEntry(types.RawEntry{Data: []byte(`{"kind":"session_metadata"}`)})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestAdapter_ExtractTaskPrompt(t *testing.T) {
+	entries := records()
+	// A function response first is not the task
+	entries = append([]types.RawEntry{entries[2]}, entries...)
+
+	prompt, ok := New().ExtractTaskPrompt(entries)
+	require.True(t, ok)
+	assert.Equal(t, "Fix the failing test", prompt)
+
+	_, ok = New().ExtractTaskPrompt(entries[:1])
+	assert.False(t, ok)
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package gemini
+
+import "encoding/json"
+
+// TranscriptEntry is one line of a Gemini CLI transcript: a Content message
+// ({"role":"model","parts":[...]}) with the session and usage fields the CLI adds.
+// Lines logged as a raw API response carry the message in Candidates instead.
+type TranscriptEntry struct {
+	Role          string         `json:"role"`
+	Parts         []Part         `json:"parts"`
+	Candidates    []Candidate    `json:"candidates,omitempty"`
+	SessionID     string         `json:"sessionId,omitempty"`
+	Timestamp     string         `json:"timestamp,omitempty"`
+	ID            string         `json:"id,omitempty"`
+	ModelVersion  string         `json:"modelVersion,omitempty"`
+	Model         string         `json:"model,omitempty"`
+	FinishReason  string         `json:"finishReason,omitempty"`
+	UsageMetadata *UsageMetadata `json:"usageMetadata,omitempty"`
+}
+
+// Candidate is one response candidate of a GenerateContent response
+type Candidate struct {
+	Content      *Content `json:"content"`
+	FinishReason string   `json:"finishReason,omitempty"`
+}
+
+// Content is a role and its parts
+type Content struct {
+	Role  string `json:"role"`
+	Parts []Part `json:"parts"`
+}
+
+// Part is one piece of a message: text (a thought when Thought is set), a function
+// call from the model, or the function response sent back by the CLI
+type Part struct {
+	Text             string            `json:"text,omitempty"`
+	Thought          bool              `json:"thought,omitempty"`
+	FunctionCall     *FunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *FunctionResponse `json:"functionResponse,omitempty"`
+}
+
+// FunctionCall is a tool call requested by the model
+type FunctionCall struct {
+	ID   string                 `json:"id,omitempty"`
+	Name string                 `json:"name"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// FunctionResponse is the result of a tool call. The CLI puts the tool output under
+// "output", or the failure under "error".
+type FunctionResponse struct {
+	ID       string          `json:"id,omitempty"`
+	Name     string          `json:"name"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// UsageMetadata is the token accounting of a model response
+type UsageMetadata struct {
+	PromptTokenCount        int `json:"promptTokenCount"`
+	CandidatesTokenCount    int `json:"candidatesTokenCount"`
+	CachedContentTokenCount int `json:"cachedContentTokenCount"`
+	ThoughtsTokenCount      int `json:"thoughtsTokenCount"`
+	TotalTokenCount         int `json:"totalTokenCount"`
+}
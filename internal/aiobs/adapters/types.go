@@ -14,23 +14,26 @@ type (
 	RawEntry    = types.RawEntry
 	ParsedEvent = types.ParsedEvent
 	Adapter     = types.Adapter
+	EntryLinker = types.EntryLinker
+	Matcher     = types.Matcher
 )
 
 // Re-export event type constants
 const (
-	EventTypeSessionStart  = types.EventTypeSessionStart
-	EventTypeSessionEnd    = types.EventTypeSessionEnd
-	EventTypeUserPrompt    = types.EventTypeUserPrompt
-	EventTypeThinking      = types.EventTypeThinking
-	EventTypeAIOutput      = types.EventTypeAIOutput
-	EventTypeStreaming     = types.EventTypeStreaming
-	EventTypeToolUse       = types.EventTypeToolUse
-	EventTypeToolResult    = types.EventTypeToolResult
-	EventTypeToolBlocked   = types.EventTypeToolBlocked
-	EventTypeError         = types.EventTypeError
-	EventTypeStop          = types.EventTypeStop
-	EventTypeSubagentStart = types.EventTypeSubagentStart
-	EventTypeSubagentStop  = types.EventTypeSubagentStop
+	EventTypeSessionStart    = types.EventTypeSessionStart
+	EventTypeSessionEnd      = types.EventTypeSessionEnd
+	EventTypeUserPrompt      = types.EventTypeUserPrompt
+	EventTypeThinking        = types.EventTypeThinking
+	EventTypeAIOutput        = types.EventTypeAIOutput
+	EventTypeStreaming       = types.EventTypeStreaming
+	EventTypeToolUse         = types.EventTypeToolUse
+	EventTypeToolResult      = types.EventTypeToolResult
+	EventTypeToolBlocked     = types.EventTypeToolBlocked
+	EventTypeToolResultDelta = types.EventTypeToolResultDelta
+	EventTypeError           = types.EventTypeError
+	EventTypeStop            = types.EventTypeStop
+	EventTypeSubagentStart   = types.EventTypeSubagentStart
+	EventTypeSubagentStop    = types.EventTypeSubagentStop
 )
 
 // ExtractSessionID re-exports the helper for extracting sessionId from raw JSON.
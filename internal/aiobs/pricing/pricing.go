@@ -0,0 +1,67 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package pricing estimates the USD cost of AI token usage from published per-model rates.
+package pricing
+
+import "strings"
+
+// Rates are USD per million tokens
+type Rates struct {
+	Input       float64
+	Output      float64
+	CacheRead   float64
+	CacheCreate float64 // 5-minute cache writes
+}
+
+// modelRate pairs a model ID fragment with its rates
+type modelRate struct {
+	model string
+	rates Rates
+}
+
+// rateTable holds the known models. A model matches the longest fragment its ID
+// contains, so dated IDs ("claude-sonnet-4-20250514") and provider-prefixed ones
+// ("anthropic.claude-3-5-haiku-20241022-v1:0") resolve to their family.
+var rateTable = []modelRate{
+	{"claude-opus-4-5", Rates{Input: 5, Output: 25, CacheRead: 0.50, CacheCreate: 6.25}},
+	{"claude-opus-4-1", Rates{Input: 15, Output: 75, CacheRead: 1.50, CacheCreate: 18.75}},
+	{"claude-opus-4", Rates{Input: 15, Output: 75, CacheRead: 1.50, CacheCreate: 18.75}},
+	{"claude-3-opus", Rates{Input: 15, Output: 75, CacheRead: 1.50, CacheCreate: 18.75}},
+	{"claude-sonnet-4-5", Rates{Input: 3, Output: 15, CacheRead: 0.30, CacheCreate: 3.75}},
+	{"claude-sonnet-4", Rates{Input: 3, Output: 15, CacheRead: 0.30, CacheCreate: 3.75}},
+	{"claude-3-7-sonnet", Rates{Input: 3, Output: 15, CacheRead: 0.30, CacheCreate: 3.75}},
+	{"claude-3-5-sonnet", Rates{Input: 3, Output: 15, CacheRead: 0.30, CacheCreate: 3.75}},
+	{"claude-haiku-4-5", Rates{Input: 1, Output: 5, CacheRead: 0.10, CacheCreate: 1.25}},
+	{"claude-3-5-haiku", Rates{Input: 0.80, Output: 4, CacheRead: 0.08, CacheCreate: 1}},
+	{"claude-3-haiku", Rates{Input: 0.25, Output: 1.25, CacheRead: 0.03, CacheCreate: 0.30}},
+}
+
+// Lookup returns the rates for a model ID, false when the model is unknown
+func Lookup(model string) (Rates, bool) {
+	model = strings.ToLower(model)
+	best := -1
+	for i, entry := range rateTable {
+		if strings.Contains(model, entry.model) && (best < 0 || len(entry.model) > len(rateTable[best].model)) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return Rates{}, false
+	}
+	return rateTable[best].rates, true
+}
+
+// EstimateCost returns the estimated USD cost of the given token counts for a model.
+// It returns false for unknown models, so callers can hide the cost rather than show $0.
+func EstimateCost(model string, in, out, cacheRead, cacheCreate int) (float64, bool) {
+	rates, ok := Lookup(model)
+	if !ok {
+		return 0, false
+	}
+	cost := float64(in)*rates.Input +
+		float64(out)*rates.Output +
+		float64(cacheRead)*rates.CacheRead +
+		float64(cacheCreate)*rates.CacheCreate
+	return cost / 1_000_000, true
+}
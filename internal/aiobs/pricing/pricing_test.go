@@ -0,0 +1,50 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pricing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		model     string
+		wantInput float64
+		known     bool
+	}{
+		{"claude-sonnet-4-20250514", 3, true},
+		{"claude-opus-4-5-20251101", 5, true},
+		{"claude-opus-4-1-20250805", 15, true},
+		{"claude-3-5-haiku-20241022", 0.80, true},
+		{"anthropic.claude-3-5-haiku-20241022-v1:0", 0.80, true},
+		{"Claude-Haiku-4-5", 1, true},
+		{"gemini-2.5-pro", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			rates, ok := Lookup(tt.model)
+			assert.Equal(t, tt.known, ok)
+			assert.Equal(t, tt.wantInput, rates.Input)
+		})
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	// 1M each of input, output, cache read and cache write on Sonnet: 3 + 15 + 0.30 + 3.75
+	cost, ok := EstimateCost("claude-sonnet-4-5-20250929", 1_000_000, 1_000_000, 1_000_000, 1_000_000)
+	assert.True(t, ok)
+	assert.InDelta(t, 22.05, cost, 1e-9)
+
+	cost, ok = EstimateCost("claude-3-haiku-20240307", 40_000, 2_000, 0, 0)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.0125, cost, 1e-9)
+
+	cost, ok = EstimateCost("gpt-4o", 1000, 1000, 0, 0)
+	assert.False(t, ok)
+	assert.Zero(t, cost)
+}
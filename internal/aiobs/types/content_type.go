@@ -0,0 +1,82 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ContentType classifies tool result content so the UI can pick a renderer.
+type ContentType string
+
+const (
+	ContentTypeText   ContentType = "text"   // Plain text; the fallback
+	ContentTypeJSON   ContentType = "json"   // A JSON object or array
+	ContentTypeDiff   ContentType = "diff"   // Unified diff
+	ContentTypeBinary ContentType = "binary" // Contains NUL bytes
+)
+
+// contentSniffLen bounds how much of the content DetectContentType inspects
+const contentSniffLen = 1024
+
+// hunkHeaderRegex matches a unified diff hunk header such as "@@ -1,3 +1,4 @@"
+var hunkHeaderRegex = regexp.MustCompile(`(?m)^@@ -\d+(,\d+)? \+\d+(,\d+)? @@`)
+
+// DetectContentType sniffs the first KB of content: NUL bytes mean binary, a
+// well-formed JSON object or array prefix means JSON, and git or unified diff
+// markers mean diff. Anything else, including empty content, is text.
+func DetectContentType(content string) ContentType {
+	sample := content
+	if len(sample) > contentSniffLen {
+		sample = sample[:contentSniffLen]
+	}
+
+	switch {
+	case strings.IndexByte(sample, 0) >= 0:
+		return ContentTypeBinary
+	case looksLikeJSON(sample, len(sample) < len(content)):
+		return ContentTypeJSON
+	case looksLikeDiff(sample):
+		return ContentTypeDiff
+	default:
+		return ContentTypeText
+	}
+}
+
+// looksLikeJSON reports whether sample is a JSON object or array. When the
+// sample is a truncated prefix, running out of input is not held against it.
+func looksLikeJSON(sample string, truncated bool) bool {
+	trimmed := strings.TrimSpace(sample)
+	if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return false
+	}
+	if !truncated {
+		return json.Valid([]byte(trimmed))
+	}
+
+	dec := json.NewDecoder(strings.NewReader(trimmed))
+	for {
+		if _, err := dec.Token(); err != nil {
+			return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+// looksLikeDiff reports whether sample holds a git diff header, or a hunk header
+// under unified diff file headers or at the very start
+func looksLikeDiff(sample string) bool {
+	if strings.HasPrefix(sample, "diff --git ") || strings.Contains(sample, "\ndiff --git ") {
+		return true
+	}
+	if !hunkHeaderRegex.MatchString(sample) {
+		return false
+	}
+	hasFileHeaders := (strings.HasPrefix(sample, "--- ") || strings.Contains(sample, "\n--- ")) &&
+		strings.Contains(sample, "\n+++ ")
+	return hasFileHeaders || strings.HasPrefix(sample, "@@ -")
+}
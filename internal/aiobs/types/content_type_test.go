@@ -0,0 +1,52 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectContentType(t *testing.T) {
+	longJSON := `{"items": [` + strings.Repeat(`{"name": "entry", "value": 42},`, 100) + `{"name": "last"}]}`
+	truncatedJSON := longJSON[:len(longJSON)/2]
+
+	tests := []struct {
+		name     string
+		content  string
+		expected ContentType
+	}{
+		{name: "empty is text", content: "", expected: ContentTypeText},
+		{name: "plain text", content: "total 8\ndrwxr-xr-x  2 user user 4096 main.go\n", expected: ContentTypeText},
+		{name: "json object", content: `{"status": "ok", "count": 3}`, expected: ContentTypeJSON},
+		{name: "json array with whitespace", content: "\n  [1, 2, 3]\n", expected: ContentTypeJSON},
+		{name: "long json sniffed by prefix", content: longJSON, expected: ContentTypeJSON},
+		{name: "json cut past the sniff window", content: truncatedJSON + strings.Repeat(" ", 2048), expected: ContentTypeJSON},
+		{name: "invalid json falls back to text", content: `{"status": ok}`, expected: ContentTypeText},
+		{name: "bracketed log line is text", content: "[INFO] build finished", expected: ContentTypeText},
+		{name: "scalar json is text", content: `"just a string"`, expected: ContentTypeText},
+		{
+			name:     "git diff",
+			content:  "diff --git a/main.go b/main.go\nindex 1a2b3c4..5d6e7f8 100644\n--- a/main.go\n+++ b/main.go\n@@ -1,3 +1,4 @@\n package main\n+import \"fmt\"\n",
+			expected: ContentTypeDiff,
+		},
+		{
+			name:     "unified diff after a preamble",
+			content:  "Applying patch\n--- a/README.md\n+++ b/README.md\n@@ -10 +10,2 @@\n-old\n+new\n",
+			expected: ContentTypeDiff,
+		},
+		{name: "bare hunk", content: "@@ -1,2 +1,2 @@\n-a\n+b\n", expected: ContentTypeDiff},
+		{name: "dashes without a hunk are text", content: "--- Summary ---\n+++ added +++\n", expected: ContentTypeText},
+		{name: "nul byte is binary", content: "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR", expected: ContentTypeBinary},
+		{name: "nul byte past the sniff window is not seen", content: strings.Repeat("a", 2048) + "\x00", expected: ContentTypeText},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DetectContentType(tt.content))
+		})
+	}
+}
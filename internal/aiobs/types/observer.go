@@ -11,6 +11,10 @@ type RunContext struct {
 	RunID     string
 	ProjectID string
 	WorkDir   string // Working directory inside the container
+
+	// ToolResultDeltas enables delta mode: partial tool output is emitted as
+	// tool_result_delta events ahead of the final tool_result
+	ToolResultDeltas bool
 }
 
 // StreamID identifies a specific transcript stream (e.g., a file).
@@ -0,0 +1,42 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package types
+
+// DeltaTracker turns tool_result_delta events, which adapters emit with the full
+// output so far in ContentPreview, into increments per ToolUseID. The final
+// tool_result carries the complete output, so it ends tracking for its ID: the
+// deltas coalesce into that record.
+type DeltaTracker struct {
+	emitted map[string]int // Bytes of output already emitted, by tool_use id
+}
+
+// NewDeltaTracker creates an empty tracker.
+func NewDeltaTracker() *DeltaTracker {
+	return &DeltaTracker{emitted: make(map[string]int)}
+}
+
+// Apply rewrites a delta event's ContentPreview to only the output not emitted
+// before; ContentLength keeps the total length so far. Returns false for deltas
+// that add nothing. Other event types pass through unchanged.
+func (t *DeltaTracker) Apply(event *ParsedEvent) bool {
+	switch event.EventType {
+	case EventTypeToolResultDelta:
+		snapshot := event.ContentPreview
+		prev := t.emitted[event.ToolUseID]
+		if prev > len(snapshot) {
+			// Output shrank (reset or truncated at the source): resend it whole
+			prev = 0
+		}
+		t.emitted[event.ToolUseID] = len(snapshot)
+		if prev == len(snapshot) {
+			return false
+		}
+		event.ContentPreview = snapshot[prev:]
+		return true
+
+	case EventTypeToolResult:
+		delete(t.emitted, event.ToolUseID)
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func delta(toolUseID, snapshot string) ParsedEvent {
+	return ParsedEvent{EventType: EventTypeToolResultDelta, ToolUseID: toolUseID, ContentPreview: snapshot, ContentLength: len(snapshot)}
+}
+
+func TestDeltaTracker_EmitsIncrements(t *testing.T) {
+	tracker := NewDeltaTracker()
+
+	first := delta("toolu_1", "building\n")
+	assert.True(t, tracker.Apply(&first))
+	assert.Equal(t, "building\n", first.ContentPreview)
+
+	second := delta("toolu_1", "building\nok pkg/a\n")
+	assert.True(t, tracker.Apply(&second))
+	assert.Equal(t, "ok pkg/a\n", second.ContentPreview)
+	assert.Equal(t, len("building\nok pkg/a\n"), second.ContentLength)
+
+	unchanged := delta("toolu_1", "building\nok pkg/a\n")
+	assert.False(t, tracker.Apply(&unchanged), "a delta without new output is suppressed")
+
+	// Other tool calls are tracked independently
+	other := delta("toolu_2", "hello")
+	assert.True(t, tracker.Apply(&other))
+	assert.Equal(t, "hello", other.ContentPreview)
+}
+
+func TestDeltaTracker_ResetAndResult(t *testing.T) {
+	tracker := NewDeltaTracker()
+
+	long := delta("toolu_1", "0123456789")
+	tracker.Apply(&long)
+
+	shrunk := delta("toolu_1", "abc")
+	assert.True(t, tracker.Apply(&shrunk))
+	assert.Equal(t, "abc", shrunk.ContentPreview, "shrunk output is resent whole")
+
+	result := ParsedEvent{EventType: EventTypeToolResult, ToolUseID: "toolu_1", ContentPreview: "abc"}
+	assert.True(t, tracker.Apply(&result))
+	assert.Equal(t, "abc", result.ContentPreview, "results pass through unchanged")
+
+	again := delta("toolu_1", "abc")
+	assert.True(t, tracker.Apply(&again), "a result ends tracking for its tool call")
+}
@@ -7,12 +7,15 @@ package types
 
 import (
 	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"time"
 )
 
 // RawEntry is the unparsed transcript line with minimal extraction for routing.
 type RawEntry struct {
-	Line      int             // Line number in the file
+	Line      int             // 1-based line number in the file (0 = unknown)
 	Data      json.RawMessage // Raw JSON data
 	SessionID string          // Extracted session ID for routing
 }
@@ -72,24 +75,50 @@ type ParsedEvent struct {
 
 	// Tool info (for tool_use and tool_result events)
 	ToolName         string `json:"tool_name,omitempty"`
+	ToolNamespace    string `json:"tool_namespace,omitempty"`     // MCP server of an mcp__<server>__<tool> call; ToolName is then the bare tool
 	ToolInputSummary string `json:"tool_input_summary,omitempty"` // Human-readable truncated
 	ToolSuccess      *bool  `json:"tool_success,omitempty"`       // nil if not applicable
 	ToolError        string `json:"tool_error,omitempty"`
-	FilePath         string `json:"file_path,omitempty"` // Extracted for file operations
+	ToolUseID        string `json:"tool_use_id,omitempty"` // Tool call id; pairs a tool_use with its tool_result and deltas
+	FilePath         string `json:"file_path,omitempty"`   // Extracted for file operations
+
+	ResultContentType ContentType `json:"result_content_type,omitempty"` // Sniffed from tool_result content; empty means text
 
 	IsSidechain     bool   `json:"is_sidechain,omitempty"`
 	AgentID         string `json:"agent_id,omitempty"`
 	ParentSessionID string `json:"parent_session_id,omitempty"`
-	SourceFile      string `json:"source_file,omitempty"`
+	SourceFile      string `json:"source_file,omitempty"` // Transcript file (or stream) the event was read from
+	SourceLine      int    `json:"source_line,omitempty"` // 1-based line in SourceFile (0 = unknown)
 
 	// Content
-	ContentPreview string `json:"content_preview,omitempty"` // First 500 chars
-	ContentLength  int    `json:"content_length,omitempty"`  // Full content length
+	ContentPreview string       `json:"content_preview,omitempty"` // First 500 chars
+	ContentLength  int          `json:"content_length,omitempty"`  // Full content length
+	Attachments    []Attachment `json:"attachments,omitempty"`     // Image and document blocks of the message
 
 	// Raw data for debugging
 	RawPayload json.RawMessage `json:"raw_payload,omitempty"`
 }
 
+// Attachment is a non-text content block of a message, such as a pasted image or
+// an attached PDF. Only its description is kept, never the data.
+type Attachment struct {
+	Type       string `json:"type"`                 // "image", "document"
+	MediaType  string `json:"media_type,omitempty"` // e.g. "image/png"
+	Descriptor string `json:"descriptor,omitempty"` // Short description: title, URL or size
+}
+
+// Label returns how feeds show the attachment, e.g. "📎 image/png"
+func (a Attachment) Label() string {
+	name := a.MediaType
+	if name == "" {
+		name = a.Type
+	}
+	if a.Descriptor != "" {
+		return "📎 " + name + " (" + a.Descriptor + ")"
+	}
+	return "📎 " + name
+}
+
 // Event type constants for ParsedEvent.EventType
 // These mirror models.AIEventType values for consistency.
 const (
@@ -110,6 +139,10 @@ const (
 	EventTypeToolResult  = "tool_result"
 	EventTypeToolBlocked = "tool_blocked"
 
+	// Partial output of a still-running tool (e.g. a long Bash command), keyed by
+	// ToolUseID. Only emitted in delta mode.
+	EventTypeToolResultDelta = "tool_result_delta"
+
 	// Status events
 	EventTypeError = "error"
 	EventTypeStop  = "stop"
@@ -121,36 +154,38 @@ const (
 
 // EventKindMap maps event types to their ObsKind for classification.
 var EventKindMap = map[string]ObsKind{
-	EventTypeSessionStart:  KindLifecycle,
-	EventTypeSessionEnd:    KindLifecycle,
-	EventTypeUserPrompt:    KindMessage,
-	EventTypeThinking:      KindMessage,
-	EventTypeAIOutput:      KindMessage,
-	EventTypeStreaming:     KindMessage,
-	EventTypeToolUse:       KindTool,
-	EventTypeToolResult:    KindTool,
-	EventTypeToolBlocked:   KindTool,
-	EventTypeError:         KindError,
-	EventTypeStop:          KindLifecycle,
-	EventTypeSubagentStart: KindLifecycle,
-	EventTypeSubagentStop:  KindLifecycle,
+	EventTypeSessionStart:    KindLifecycle,
+	EventTypeSessionEnd:      KindLifecycle,
+	EventTypeUserPrompt:      KindMessage,
+	EventTypeThinking:        KindMessage,
+	EventTypeAIOutput:        KindMessage,
+	EventTypeStreaming:       KindMessage,
+	EventTypeToolUse:         KindTool,
+	EventTypeToolResult:      KindTool,
+	EventTypeToolBlocked:     KindTool,
+	EventTypeToolResultDelta: KindTool,
+	EventTypeError:           KindError,
+	EventTypeStop:            KindLifecycle,
+	EventTypeSubagentStart:   KindLifecycle,
+	EventTypeSubagentStop:    KindLifecycle,
 }
 
 // EventLevelMap maps event types to their ObsLevel for filtering.
 var EventLevelMap = map[string]ObsLevel{
-	EventTypeSessionStart:  LevelInfo,
-	EventTypeSessionEnd:    LevelInfo,
-	EventTypeUserPrompt:    LevelInfo,
-	EventTypeThinking:      LevelInfo,
-	EventTypeAIOutput:      LevelInfo,
-	EventTypeStreaming:     LevelDebug,
-	EventTypeToolUse:       LevelInfo,
-	EventTypeToolResult:    LevelInfo,
-	EventTypeToolBlocked:   LevelWarn,
-	EventTypeError:         LevelError,
-	EventTypeStop:          LevelInfo,
-	EventTypeSubagentStart: LevelInfo,
-	EventTypeSubagentStop:  LevelInfo,
+	EventTypeSessionStart:    LevelInfo,
+	EventTypeSessionEnd:      LevelInfo,
+	EventTypeUserPrompt:      LevelInfo,
+	EventTypeThinking:        LevelInfo,
+	EventTypeAIOutput:        LevelInfo,
+	EventTypeStreaming:       LevelDebug,
+	EventTypeToolUse:         LevelInfo,
+	EventTypeToolResult:      LevelInfo,
+	EventTypeToolBlocked:     LevelWarn,
+	EventTypeToolResultDelta: LevelDebug,
+	EventTypeError:           LevelError,
+	EventTypeStop:            LevelInfo,
+	EventTypeSubagentStart:   LevelInfo,
+	EventTypeSubagentStop:    LevelInfo,
 }
 
 // KindForEvent returns the ObsKind for a given event type, defaulting to KindMessage.
@@ -178,17 +213,94 @@ type Adapter interface {
 	// ParseEntry converts one transcript line to events.
 	// Returns multiple events because one entry can have multiple content blocks.
 	ParseEntry(raw RawEntry) ([]ParsedEvent, error)
+
+	// ExtractTaskPrompt returns the first human-written prompt in a transcript,
+	// skipping tool results and system/meta messages. False when there is none.
+	ExtractTaskPrompt(records []RawEntry) (string, bool)
+}
+
+// Matcher is implemented by adapters that can recognise their transcript format
+// from a sample of the start of a file.
+type Matcher interface {
+	Matches(sample []byte) bool
+}
+
+// LineParserFactory is implemented by adapters whose entries span several lines, such as
+// aider's markdown history. The watcher feeds their files line by line through the
+// returned Parser instead of calling ParseEntry on each line.
+type LineParserFactory interface {
+	NewLineParser() Parser
+}
+
+// EntryLinker is implemented by adapters whose transcript entries form a UUID chain,
+// each entry naming its parent. The watcher uses it to detect skipped entries.
+type EntryLinker interface {
+	// EntryLinks returns the entry's UUID and its parent's UUID; either may be empty.
+	EntryLinks(raw RawEntry) (uuid, parentUUID string)
 }
 
-// ExtractSessionID extracts the sessionId field from a raw JSON payload.
+// mcpToolPrefix starts the names Claude Code gives MCP tools: mcp__<server>__<tool>
+const mcpToolPrefix = "mcp__"
+
+// SplitMCPToolName splits an MCP tool name like "mcp__github__create_issue" into its
+// server namespace ("github") and tool ("create_issue"). Other names are returned
+// unchanged with an empty namespace.
+func SplitMCPToolName(name string) (namespace, tool string) {
+	rest, ok := strings.CutPrefix(name, mcpToolPrefix)
+	if !ok {
+		return "", name
+	}
+	namespace, tool, ok = strings.Cut(rest, "__")
+	if !ok || namespace == "" || tool == "" {
+		return "", name
+	}
+	return namespace, tool
+}
+
+// ExtractSessionID extracts the session ID from a raw JSON payload: the sessionId
+// field (Claude, Gemini), session_id, or the id of a Codex session_meta payload.
+// Other Codex rollout lines carry no session ID; see SessionIDFromPath.
 // This is a common operation used across adapters and watchers for routing.
-// Returns empty string if the field is not present or extraction fails.
+// Returns empty string if no ID is present or extraction fails.
 func ExtractSessionID(raw json.RawMessage) string {
 	var probe struct {
-		SessionID string `json:"sessionId"`
+		SessionID      string `json:"sessionId"`
+		SnakeSessionID string `json:"session_id"`
 	}
 	if err := json.Unmarshal(raw, &probe); err != nil {
 		return ""
 	}
-	return probe.SessionID
+	if probe.SessionID != "" {
+		return probe.SessionID
+	}
+	if probe.SnakeSessionID != "" {
+		return probe.SnakeSessionID
+	}
+
+	var meta struct {
+		Type    string `json:"type"`
+		Payload struct {
+			ID string `json:"id"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(raw, &meta); err != nil || meta.Type != "session_meta" {
+		return ""
+	}
+	return meta.Payload.ID
+}
+
+// sessionFileRegex matches a file name, extensions removed, that ends in a UUID:
+// "<uuid>" (Claude) or "rollout-2025-09-10T09-00-00-<uuid>" (Codex)
+var sessionFileRegex = regexp.MustCompile(`(?:^|-)([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$`)
+
+// SessionIDFromPath returns the session UUID a transcript's file name ends with,
+// or empty string if it has none. Watchers use it for lines that do not carry the
+// session ID themselves.
+func SessionIDFromPath(path string) string {
+	name, _, _ := strings.Cut(filepath.Base(path), ".")
+	match := sessionFileRegex.FindStringSubmatch(name)
+	if match == nil {
+		return ""
+	}
+	return match[1]
 }
@@ -46,3 +46,69 @@ func TestLevelForEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractSessionID(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected string
+	}{
+		{name: "camel case", raw: `{"sessionId":"s1"}`, expected: "s1"},
+		{name: "snake case", raw: `{"session_id":"s2"}`, expected: "s2"},
+		{name: "camel case wins", raw: `{"sessionId":"s1","session_id":"s2"}`, expected: "s1"},
+		{name: "codex session_meta", raw: `{"type":"session_meta","payload":{"id":"s3","cwd":"/repo"}}`, expected: "s3"},
+		{name: "codex response item", raw: `{"type":"response_item","payload":{"type":"message","id":"msg_1"}}`, expected: ""},
+		{name: "missing", raw: `{"type":"user"}`, expected: ""},
+		{name: "invalid json", raw: `{"sessionId":`, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractSessionID([]byte(tt.raw)))
+		})
+	}
+}
+
+func TestSessionIDFromPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{name: "claude", path: "/p/88ad3a71-4c86-4b19-b41d-71a7b027ee63.jsonl", expected: "88ad3a71-4c86-4b19-b41d-71a7b027ee63"},
+		{name: "codex rollout", path: "/s/2025/09/10/rollout-2025-09-10T09-00-00-0199a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b.jsonl", expected: "0199a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b"},
+		{name: "compressed", path: "88ad3a71-4c86-4b19-b41d-71a7b027ee63.jsonl.gz", expected: "88ad3a71-4c86-4b19-b41d-71a7b027ee63"},
+		{name: "agent file", path: "/p/agent-a1b2c3.jsonl", expected: ""},
+		{name: "uuid glued to a word", path: "x88ad3a71-4c86-4b19-b41d-71a7b027ee63.jsonl", expected: ""},
+		{name: "aider history", path: ".aider.chat.history.md", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SessionIDFromPath(tt.path))
+		})
+	}
+}
+
+func TestSplitMCPToolName(t *testing.T) {
+	tests := []struct {
+		name      string
+		toolName  string
+		namespace string
+		tool      string
+	}{
+		{name: "mcp tool", toolName: "mcp__filesystem__read_file", namespace: "filesystem", tool: "read_file"},
+		{name: "tool with underscores", toolName: "mcp__github__create__issue", namespace: "github", tool: "create__issue"},
+		{name: "built-in tool", toolName: "Bash", namespace: "", tool: "Bash"},
+		{name: "missing tool", toolName: "mcp__filesystem", namespace: "", tool: "mcp__filesystem"},
+		{name: "empty server", toolName: "mcp____read_file", namespace: "", tool: "mcp____read_file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace, tool := SplitMCPToolName(tt.toolName)
+			assert.Equal(t, tt.namespace, namespace)
+			assert.Equal(t, tt.tool, tool)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sync"
 	"time"
 
@@ -19,15 +20,21 @@ import (
 // ErrDirectoryWatcherClosed is returned when operations are attempted on a closed directory watcher.
 var ErrDirectoryWatcherClosed = errors.New("directory watcher is closed")
 
-// DirectoryWatcher watches a directory for UUID-named transcript files and manages
-// individual TranscriptWatchers for each discovered file. It merges events from all
-// active watchers into a single channel.
+// DirectoryWatcher watches a directory for UUID-named transcript files, or those matching
+// DirectoryWatcherConfig.FilePattern, and manages individual TranscriptWatchers for each
+// discovered file. It merges events from all active watchers into a single channel.
 type DirectoryWatcher struct {
 	dir          string
 	source       string
 	pollInterval time.Duration
 	bufferSize   int
-	watchers     map[string]*TranscriptWatcher // UUID filename -> watcher
+	maxLineBytes int
+	useFSNotify  bool
+	offsets      OffsetStore
+	filePattern  *regexp.Regexp                // Names of the transcript files to watch
+	parseErrors  bool                          // Config.EmitParseErrors for the per-session watchers
+	maxRate      int                           // Config.MaxEventsPerSecond for the per-session watchers
+	watchers     map[string]*TranscriptWatcher // File name -> watcher
 	eventChan    chan types.ParsedEvent
 	errorChan    chan error
 	doneChan     chan struct{}
@@ -37,18 +44,45 @@ type DirectoryWatcher struct {
 	initialized  bool
 	closed       bool
 	lastError    error
+
+	// Timestamp ordering (OrderByTimestamp): forwarders push into reorder, the
+	// watch loop releases events once they have been held for the window
+	reorder   *reorderBuffer
+	reorderMu sync.Mutex
 }
 
 // DirectoryWatcherConfig holds configuration for a DirectoryWatcher.
 type DirectoryWatcherConfig struct {
 	// Directory is the path to watch for UUID-named .jsonl files.
 	Directory string
+	// FilePattern is a regular expression matched against file names in Directory to
+	// pick the transcripts to watch, e.g. `^log-.*\.jsonl$`. It is not anchored
+	// implicitly. Empty watches UUID-named and agent-*.jsonl files.
+	FilePattern string
 	// Source identifies the AI tool (e.g., "claude", "gemini").
 	Source string
 	// EventBufferSize is the size of the merged event channel buffer.
 	EventBufferSize int
 	// PollInterval is how often to check for new files and content (default: 100ms).
 	PollInterval time.Duration
+	// OrderByTimestamp holds merged events for ReorderWindow and emits them in
+	// timestamp order, so events of concurrent sessions arrive roughly chronologically.
+	// Every event is delayed by the window; events arriving later than that relative
+	// to newer events already emitted still come out of order.
+	OrderByTimestamp bool
+	// ReorderWindow is how long events are held in OrderByTimestamp mode (default: 500ms).
+	ReorderWindow time.Duration
+	// MaxLineBytes caps the length of a transcript line (see Config.MaxLineBytes).
+	MaxLineBytes int
+	// UseFSNotify makes the per-session watchers read on fsnotify events (see Config.UseFSNotify).
+	// New session files are still discovered every PollInterval.
+	UseFSNotify bool
+	// ResumeFrom persists and resumes the per-session read offsets (see Config.ResumeFrom).
+	ResumeFrom OffsetStore
+	// EmitParseErrors emits unparseable lines as error events (see Config.EmitParseErrors).
+	EmitParseErrors bool
+	// MaxEventsPerSecond paces each session's events separately (see Config.MaxEventsPerSecond).
+	MaxEventsPerSecond int
 }
 
 // DefaultDirectoryWatcherConfig returns a DirectoryWatcherConfig with sensible defaults.
@@ -70,6 +104,13 @@ func NewDirectoryWatcher(ctx context.Context, cfg DirectoryWatcherConfig) (*Dire
 	if cfg.Source == "" {
 		return nil, fmt.Errorf("%w: source is required", ErrInitFailed)
 	}
+	filePattern := transcriptFileRegex
+	if cfg.FilePattern != "" {
+		var err error
+		if filePattern, err = regexp.Compile(cfg.FilePattern); err != nil {
+			return nil, fmt.Errorf("%w: invalid file pattern: %w", ErrInitFailed, err)
+		}
+	}
 
 	if cfg.EventBufferSize == 0 {
 		cfg.EventBufferSize = 1000
@@ -77,6 +118,9 @@ func NewDirectoryWatcher(ctx context.Context, cfg DirectoryWatcherConfig) (*Dire
 	if cfg.PollInterval == 0 {
 		cfg.PollInterval = 100 * time.Millisecond
 	}
+	if cfg.ReorderWindow == 0 {
+		cfg.ReorderWindow = 500 * time.Millisecond
+	}
 
 	watchCtx, cancel := context.WithCancel(ctx)
 
@@ -85,6 +129,12 @@ func NewDirectoryWatcher(ctx context.Context, cfg DirectoryWatcherConfig) (*Dire
 		source:       cfg.Source,
 		pollInterval: cfg.PollInterval,
 		bufferSize:   cfg.EventBufferSize,
+		maxLineBytes: cfg.MaxLineBytes,
+		useFSNotify:  cfg.UseFSNotify,
+		offsets:      cfg.ResumeFrom,
+		filePattern:  filePattern,
+		parseErrors:  cfg.EmitParseErrors,
+		maxRate:      cfg.MaxEventsPerSecond,
 		watchers:     make(map[string]*TranscriptWatcher),
 		eventChan:    make(chan types.ParsedEvent, cfg.EventBufferSize),
 		errorChan:    make(chan error, 10),
@@ -92,6 +142,9 @@ func NewDirectoryWatcher(ctx context.Context, cfg DirectoryWatcherConfig) (*Dire
 		ctx:          watchCtx,
 		cancel:       cancel,
 	}
+	if cfg.OrderByTimestamp {
+		dw.reorder = newReorderBuffer(cfg.ReorderWindow)
+	}
 
 	return dw, nil
 }
@@ -164,8 +217,10 @@ func (dw *DirectoryWatcher) Stats() DirectoryWatcherStats {
 	defer dw.mu.RUnlock()
 
 	watcherStats := make(map[string]WatcherStats)
+	perFile := make(map[string]WatcherStats, len(dw.watchers))
 	for uuid, w := range dw.watchers {
 		watcherStats[uuid] = w.Stats()
+		perFile[uuid] = watcherStats[uuid]
 	}
 
 	return DirectoryWatcherStats{
@@ -173,12 +228,23 @@ func (dw *DirectoryWatcher) Stats() DirectoryWatcherStats {
 		Source:       dw.source,
 		WatcherCount: len(dw.watchers),
 		Watchers:     watcherStats,
+		PerFile:      perFile,
 		Initialized:  dw.initialized,
 		Closed:       dw.closed,
+		Reordering:   dw.reorderingCount(),
 		LastError:    dw.lastError,
 	}
 }
 
+func (dw *DirectoryWatcher) reorderingCount() int {
+	if dw.reorder == nil {
+		return 0
+	}
+	dw.reorderMu.Lock()
+	defer dw.reorderMu.Unlock()
+	return dw.reorder.len()
+}
+
 // ActiveSessions returns the UUIDs of all active sessions being watched.
 func (dw *DirectoryWatcher) ActiveSessions() []string {
 	dw.mu.RLock()
@@ -197,8 +263,10 @@ type DirectoryWatcherStats struct {
 	Source       string
 	WatcherCount int
 	Watchers     map[string]WatcherStats // UUID -> stats
+	PerFile      map[string]WatcherStats // Transcript file name -> stats of its watcher, with bytes read and last activity
 	Initialized  bool
 	Closed       bool
+	Reordering   int // Events held in the reorder buffer (OrderByTimestamp)
 	LastError    error
 }
 
@@ -211,33 +279,78 @@ func (dw *DirectoryWatcher) watch() {
 	ticker := time.NewTicker(dw.pollInterval)
 	defer ticker.Stop()
 
+	// Release reordered events at a finer grain than the window
+	var releaseC <-chan time.Time
+	if dw.reorder != nil {
+		releaseTicker := time.NewTicker(max(dw.reorder.window/10, 5*time.Millisecond))
+		defer releaseTicker.Stop()
+		releaseC = releaseTicker.C
+	}
+
 	for {
 		select {
 		case <-dw.ctx.Done():
+			dw.releaseReordered(true)
 			return
 		case <-ticker.C:
 			dw.scanForNewFiles()
+		case <-releaseC:
+			dw.releaseReordered(false)
 		}
 	}
 }
 
+// releaseReordered emits the events whose window has passed, or all of them
+func (dw *DirectoryWatcher) releaseReordered(all bool) {
+	if dw.reorder == nil {
+		return
+	}
+	dw.reorderMu.Lock()
+	var events []types.ParsedEvent
+	if all {
+		events = dw.reorder.drain()
+	} else {
+		events = dw.reorder.ready(time.Now())
+	}
+	dw.reorderMu.Unlock()
+
+	for _, event := range events {
+		dw.emit(event, event.SourceFile)
+	}
+}
+
+// emit sends a merged event without blocking
+func (dw *DirectoryWatcher) emit(event types.ParsedEvent, filename string) {
+	select {
+	case dw.eventChan <- event:
+	default:
+		dw.reportError(fmt.Errorf("event channel full, dropping event from %s", filename))
+	}
+}
+
+// scanForNewFiles spawns a watcher for each new session file and stops the watchers
+// of session files that were deleted
 func (dw *DirectoryWatcher) scanForNewFiles() {
 	entries, err := os.ReadDir(dw.dir)
 	if err != nil {
 		if !os.IsNotExist(err) {
 			dw.reportError(fmt.Errorf("failed to read directory: %w", err))
+			return
 		}
-		// Directory doesn't exist yet, keep polling
+		// Directory doesn't exist (yet, or anymore), keep polling
+		dw.stopRemovedWatchers(nil)
 		return
 	}
 
+	present := make(map[string]bool, len(entries))
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
 		}
-		if !transcriptFileRegex.MatchString(entry.Name()) {
+		if !dw.filePattern.MatchString(entry.Name()) {
 			continue
 		}
+		present[entry.Name()] = true
 
 		// Check if we already have a watcher for this file
 		dw.mu.RLock()
@@ -248,17 +361,40 @@ func (dw *DirectoryWatcher) scanForNewFiles() {
 			dw.spawnWatcher(entry.Name())
 		}
 	}
+	dw.stopRemovedWatchers(present)
+}
+
+// stopRemovedWatchers stops and forgets the watchers whose file is no longer present.
+// Their forwarders still pass on what the watchers read before stopping.
+func (dw *DirectoryWatcher) stopRemovedWatchers(present map[string]bool) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	for filename, watcher := range dw.watchers {
+		if present[filename] {
+			continue
+		}
+		log.Info().Str("file", filename).Msg("Session file removed, stopping transcript watcher")
+		// Use a goroutine to avoid blocking the scan if Stop() is slow
+		go watcher.Stop()
+		delete(dw.watchers, filename)
+	}
 }
 
 func (dw *DirectoryWatcher) spawnWatcher(filename string) {
 	filePath := filepath.Join(dw.dir, filename)
 
 	cfg := Config{
-		FilePath:        filePath,
-		Source:          dw.source,
-		EventBufferSize: dw.bufferSize / 10, // Smaller buffer per watcher
-		PollInterval:    dw.pollInterval,
-		DiscoverUUID:    false, // Direct file mode since we know the path
+		FilePath:           filePath,
+		Source:             dw.source,
+		EventBufferSize:    dw.bufferSize / 10, // Smaller buffer per watcher
+		PollInterval:       dw.pollInterval,
+		MaxLineBytes:       dw.maxLineBytes,
+		UseFSNotify:        dw.useFSNotify,
+		ResumeFrom:         dw.offsets,
+		EmitParseErrors:    dw.parseErrors,
+		MaxEventsPerSecond: dw.maxRate,
+		DiscoverUUID:       false, // Direct file mode since we know the path
 	}
 
 	watcher, err := NewTranscriptWatcher(dw.ctx, cfg)
@@ -293,12 +429,14 @@ func (dw *DirectoryWatcher) forwardEvents(filename string, watcher *TranscriptWa
 				log.Info().Str("file", filename).Msg("Watcher event channel closed")
 				return
 			}
-			// Forward event to merged channel
-			select {
-			case dw.eventChan <- event:
-			default:
-				dw.reportError(fmt.Errorf("event channel full, dropping event from %s", filename))
+			// Forward event to merged channel, through the reorder buffer if enabled
+			if dw.reorder != nil {
+				dw.reorderMu.Lock()
+				dw.reorder.push(event, time.Now())
+				dw.reorderMu.Unlock()
+				continue
 			}
+			dw.emit(event, filename)
 		case err, ok := <-watcher.Errors():
 			if !ok {
 				return
@@ -0,0 +1,84 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package watcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/noldarim/noldarim/internal/aiobs/types"
+)
+
+// GapDetectedError is reported on Errors() when an entry's parent UUID was never read
+// from its file: earlier entries were skipped (e.g. the file was rotated or compacted
+// under the watcher), so the stream read so far is not contiguous.
+type GapDetectedError struct {
+	SourceFile string
+	SourceLine int    // 1-based line of the entry whose parent is missing
+	UUID       string // The entry read
+	ParentUUID string // The parent that was never seen
+}
+
+func (e *GapDetectedError) Error() string {
+	return fmt.Sprintf("transcript gap in %s at line %d: parent %s of entry %s was never read",
+		e.SourceFile, e.SourceLine, e.ParentUUID, e.UUID)
+}
+
+// checkChain records the entry's UUID and reports a gap if its parent is unknown
+func (w *TranscriptWatcher) checkChain(af *activeFile, line []byte) {
+	if w.linker == nil || af.seen == nil {
+		return
+	}
+	uuid, parentUUID := w.linker.EntryLinks(types.RawEntry{Line: af.line, Data: json.RawMessage(line)})
+	if uuid == "" {
+		return
+	}
+
+	if parentUUID != "" && !af.seen.contains(parentUUID) {
+		w.mu.Lock()
+		w.gapsDetected++
+		w.mu.Unlock()
+		w.reportError(&GapDetectedError{
+			SourceFile: filepath.Base(af.path),
+			SourceLine: af.line,
+			UUID:       uuid,
+			ParentUUID: parentUUID,
+		})
+	}
+	af.seen.add(uuid)
+}
+
+// uuidSet is a set of UUIDs that forgets the oldest entries beyond its capacity
+type uuidSet struct {
+	members map[string]struct{}
+	ring    []string // Insertion order; next is the slot to overwrite once full
+	next    int
+}
+
+func newUUIDSet(capacity int) *uuidSet {
+	if capacity <= 0 {
+		return nil
+	}
+	return &uuidSet{members: make(map[string]struct{}, capacity), ring: make([]string, 0, capacity)}
+}
+
+func (s *uuidSet) contains(uuid string) bool {
+	_, ok := s.members[uuid]
+	return ok
+}
+
+func (s *uuidSet) add(uuid string) {
+	if s.contains(uuid) {
+		return
+	}
+	if len(s.ring) < cap(s.ring) {
+		s.ring = append(s.ring, uuid)
+	} else {
+		delete(s.members, s.ring[s.next])
+		s.ring[s.next] = uuid
+		s.next = (s.next + 1) % len(s.ring)
+	}
+	s.members[uuid] = struct{}{}
+}
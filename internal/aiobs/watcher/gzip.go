@@ -0,0 +1,50 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package watcher
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// isCompressed reports whether path names a gzip-compressed transcript (.jsonl.gz).
+// Compressed transcripts are archives: they are read once from the start, never tailed.
+func isCompressed(path string) bool {
+	return strings.HasSuffix(path, ".gz")
+}
+
+// OpenTranscript opens a transcript for reading from the start. Files ending in .gz
+// are decompressed as they are read.
+func OpenTranscript(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !isCompressed(path) {
+		return file, nil
+	}
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read gzip header of %s: %w", path, err)
+	}
+	return &gzipFile{Reader: gz, file: file}, nil
+}
+
+// gzipFile closes the file under its gzip reader
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipFile) Close() error {
+	err := g.Reader.Close()
+	if closeErr := g.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
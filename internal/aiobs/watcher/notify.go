@@ -0,0 +1,67 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package watcher
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// notifier wakes the watch loop when files in the watched directory change (Config.UseFSNotify).
+// It stays unarmed while the directory does not exist yet, and is disabled when fsnotify
+// cannot watch it at all (e.g. on network filesystems), leaving the loop to poll.
+type notifier struct {
+	fs    *fsnotify.Watcher
+	dir   string
+	armed bool
+}
+
+// newNotifier returns nil when fsnotify is unavailable
+func newNotifier(dir string) *notifier {
+	fs, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Warn().Err(err).Str("dir", dir).Msg("fsnotify unavailable, polling for transcript changes")
+		return nil
+	}
+	return &notifier{fs: fs, dir: filepath.Clean(dir)}
+}
+
+// arm starts watching the directory. It returns false when the directory does not
+// exist yet, and an error when fsnotify cannot watch it.
+func (n *notifier) arm() (bool, error) {
+	if n.armed {
+		return true, nil
+	}
+	if err := n.fs.Add(n.dir); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	n.armed = true
+	return true, nil
+}
+
+// disarm forgets the watch after the directory was removed or renamed, so that arm
+// re-adds it once the directory is back
+func (n *notifier) disarm() {
+	if n.armed {
+		_ = n.fs.Remove(n.dir) // Already gone with the directory in most cases
+		n.armed = false
+	}
+}
+
+// isDir reports whether the event is about the watched directory itself
+func (n *notifier) isDir(event fsnotify.Event) bool {
+	return filepath.Clean(event.Name) == n.dir
+}
+
+func (n *notifier) close() {
+	if err := n.fs.Close(); err != nil {
+		log.Debug().Err(err).Str("dir", n.dir).Msg("Failed to close fsnotify watcher")
+	}
+}
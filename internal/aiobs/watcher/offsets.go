@@ -0,0 +1,150 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package watcher
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/noldarim/noldarim/internal/aiobs/types"
+)
+
+// FileOffset is how far a transcript file has been read: the byte just past the last
+// complete line, and the number of lines up to there
+type FileOffset struct {
+	Offset int64 `json:"offset"`
+	Line   int   `json:"line"`
+}
+
+// OffsetStore persists read offsets keyed by transcript file path, so a restarted
+// watcher resumes where the previous one left off (Config.ResumeFrom)
+type OffsetStore interface {
+	// Load returns the stored offset of a file; ok is false when none was stored
+	Load(path string) (offset FileOffset, ok bool, err error)
+	// Save stores the offset of a file
+	Save(path string, offset FileOffset) error
+}
+
+// offsetFileSuffix is appended to a transcript path to name its offset file
+const offsetFileSuffix = ".offset"
+
+// FileOffsetStore stores each transcript's offset as JSON in a file next to it,
+// named after the transcript with an ".offset" suffix
+type FileOffsetStore struct{}
+
+// NewFileOffsetStore creates a store that writes offsets next to the transcripts
+func NewFileOffsetStore() *FileOffsetStore {
+	return &FileOffsetStore{}
+}
+
+// Load reads the offset file of a transcript
+func (s *FileOffsetStore) Load(path string) (FileOffset, bool, error) {
+	data, err := os.ReadFile(path + offsetFileSuffix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return FileOffset{}, false, nil
+		}
+		return FileOffset{}, false, fmt.Errorf("failed to read offset file: %w", err)
+	}
+	var offset FileOffset
+	if err := json.Unmarshal(data, &offset); err != nil {
+		return FileOffset{}, false, fmt.Errorf("invalid offset file %s: %w", path+offsetFileSuffix, err)
+	}
+	return offset, true, nil
+}
+
+// Save writes the offset file of a transcript, replacing it atomically
+func (s *FileOffsetStore) Save(path string, offset FileOffset) error {
+	data, err := json.Marshal(offset)
+	if err != nil {
+		return fmt.Errorf("failed to encode offset: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+offsetFileSuffix+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create offset file: %w", err)
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write offset file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path+offsetFileSuffix); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to replace offset file: %w", err)
+	}
+	return nil
+}
+
+// resumeOffset positions a newly opened file at its stored offset. An offset past the end
+// of the file means it was truncated or replaced since, so reading starts over instead.
+func (w *TranscriptWatcher) resumeOffset(af *activeFile) {
+	if w.offsets == nil {
+		return
+	}
+	stored, ok, err := w.offsets.Load(af.path)
+	if err != nil {
+		w.reportError(fmt.Errorf("failed to load read offset of %s: %w", af.path, err))
+		return
+	}
+	if !ok || stored.Offset <= 0 {
+		return
+	}
+
+	info, err := af.file.Stat()
+	if err != nil {
+		w.reportError(fmt.Errorf("failed to stat transcript %s: %w", af.path, err))
+		return
+	}
+	if stored.Offset > info.Size() {
+		log.Warn().Str("file", filepath.Base(af.path)).Int64("offset", stored.Offset).Int64("size", info.Size()).
+			Msg("Stored read offset is past the end of the transcript, reading from the beginning")
+		return
+	}
+
+	w.seedSeen(af, stored.Offset)
+	if _, err := af.file.Seek(stored.Offset, io.SeekStart); err != nil {
+		w.reportError(fmt.Errorf("failed to seek transcript %s: %w", af.path, err))
+		return
+	}
+	af.reader.Reset(af.file)
+	af.offset = stored.Offset
+	af.committed = stored.Offset
+	af.saved = stored.Offset
+	af.line = stored.Line
+	log.Info().Str("file", filepath.Base(af.path)).Int64("offset", stored.Offset).Int("line", stored.Line).Msg("Resuming transcript from stored offset")
+}
+
+// saveOffset stores how far af has been read, if that moved since the last save
+func (w *TranscriptWatcher) saveOffset(af *activeFile) {
+	if w.offsets == nil || af.compressed || af.committed == af.saved {
+		return
+	}
+	if err := w.offsets.Save(af.path, FileOffset{Offset: af.committed, Line: af.line}); err != nil {
+		w.reportError(fmt.Errorf("failed to save read offset of %s: %w", af.path, err))
+		return
+	}
+	af.saved = af.committed
+}
+
+// seedSeen records the entry UUIDs before a resumed offset, so gap detection does not
+// report the resumed entries' parents as missing
+func (w *TranscriptWatcher) seedSeen(af *activeFile, offset int64) {
+	if w.linker == nil || af.seen == nil {
+		return
+	}
+	scanner := bufio.NewScanner(io.NewSectionReader(af.file, 0, offset))
+	scanner.Buffer(make([]byte, 0, 64*1024), w.maxLineBytes+1)
+	for scanner.Scan() {
+		if uuid, _ := w.linker.EntryLinks(types.RawEntry{Data: scanner.Bytes()}); uuid != "" {
+			af.seen.add(uuid)
+		}
+	}
+	// An oversized line stops the scan; entries after it may then be reported as gaps
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package watcher
+
+import "time"
+
+// tokenBucket paces event delivery (Config.MaxEventsPerSecond). It refills at rate
+// tokens per second up to a burst of one second's worth, so a quiet watcher can
+// deliver a short burst at once. Not safe for concurrent use.
+type tokenBucket struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(perSecond int, now time.Time) *tokenBucket {
+	return &tokenBucket{rate: float64(perSecond), tokens: float64(perSecond), last: now}
+}
+
+// take spends a token, reporting false when none is available at now
+func (b *tokenBucket) take(now time.Time) bool {
+	if !b.ready(now) {
+		return false
+	}
+	b.spend()
+	return true
+}
+
+// ready reports whether a token is available at now, without spending it
+func (b *tokenBucket) ready(now time.Time) bool {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.rate, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+	return b.tokens >= 1
+}
+
+// spend takes the token ready reported
+func (b *tokenBucket) spend() {
+	b.tokens--
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package watcher
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/aiobs/types"
+)
+
+// reorderBuffer holds events for a fixed window and releases them in timestamp
+// order. An event that arrives more than a window after a later-stamped event was
+// released is still emitted, just out of order. Not safe for concurrent use.
+type reorderBuffer struct {
+	window time.Duration
+	items  reorderHeap
+	seq    uint64
+}
+
+func newReorderBuffer(window time.Duration) *reorderBuffer {
+	return &reorderBuffer{window: window}
+}
+
+// push buffers an event that arrived at now
+func (b *reorderBuffer) push(event types.ParsedEvent, now time.Time) {
+	key := event.Timestamp
+	if key.IsZero() {
+		key = now
+	}
+	b.seq++
+	heap.Push(&b.items, reorderItem{event: event, key: key, arrived: now, seq: b.seq})
+}
+
+// ready pops, in timestamp order, the events whose oldest contender has been held
+// for the full window
+func (b *reorderBuffer) ready(now time.Time) []types.ParsedEvent {
+	var out []types.ParsedEvent
+	for len(b.items) > 0 && now.Sub(b.items[0].arrived) >= b.window {
+		out = append(out, heap.Pop(&b.items).(reorderItem).event)
+	}
+	return out
+}
+
+// drain pops every buffered event in timestamp order
+func (b *reorderBuffer) drain() []types.ParsedEvent {
+	out := make([]types.ParsedEvent, 0, len(b.items))
+	for len(b.items) > 0 {
+		out = append(out, heap.Pop(&b.items).(reorderItem).event)
+	}
+	return out
+}
+
+func (b *reorderBuffer) len() int {
+	return len(b.items)
+}
+
+type reorderItem struct {
+	event   types.ParsedEvent
+	key     time.Time // Event timestamp, or arrival time for unstamped events
+	arrived time.Time
+	seq     uint64 // Arrival order, to keep equal timestamps stable
+}
+
+// reorderHeap is a min-heap on (key, seq)
+type reorderHeap []reorderItem
+
+func (h reorderHeap) Len() int { return len(h) }
+func (h reorderHeap) Less(i, j int) bool {
+	if !h[i].key.Equal(h[j].key) {
+		return h[i].key.Before(h[j].key)
+	}
+	return h[i].seq < h[j].seq
+}
+func (h reorderHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *reorderHeap) Push(x any)   { *h = append(*h, x.(reorderItem)) }
+func (h *reorderHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
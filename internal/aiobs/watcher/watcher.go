@@ -6,6 +6,7 @@ package watcher
 
 import (
 	"bufio"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
@@ -14,16 +15,20 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
+
 	"github.com/noldarim/noldarim/internal/aiobs/adapters"
 	"github.com/noldarim/noldarim/internal/aiobs/types"
 	"github.com/noldarim/noldarim/internal/logger"
 )
 
-// transcriptFileRegex matches UUID-named .jsonl files (main sessions) AND agent-*.jsonl files (sub-agent sessions)
-var transcriptFileRegex = regexp.MustCompile(`^([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|agent-[a-zA-Z0-9]+)\.jsonl$`)
+// transcriptFileRegex matches UUID-named .jsonl files (main sessions), agent-*.jsonl files
+// (sub-agent sessions) and aider's .aider.chat.history.md
+var transcriptFileRegex = regexp.MustCompile(`^(([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|agent-[a-zA-Z0-9]+)\.jsonl|\.aider\.chat\.history\.md)$`)
 
 var log = logger.GetLogger("aiobs.watcher")
 
@@ -33,6 +38,48 @@ var ErrWatcherClosed = errors.New("watcher is closed")
 // ErrInitFailed is returned when the watcher fails to initialize.
 var ErrInitFailed = errors.New("watcher initialization failed")
 
+// DefaultMaxLineBytes is the line length cap used when Config.MaxLineBytes is unset
+const DefaultMaxLineBytes = 10 * 1024 * 1024
+
+// OversizedLineError is reported on Errors() when a line exceeds Config.MaxLineBytes.
+// The line is skipped and reading continues with the next one.
+type OversizedLineError struct {
+	SourceFile string
+	SourceLine int   // 1-based line that was skipped
+	Size       int64 // Length of the line, newline included
+	Max        int
+}
+
+func (e *OversizedLineError) Error() string {
+	return fmt.Sprintf("transcript line %d in %s is %d bytes, over the %d byte limit; skipped",
+		e.SourceLine, e.SourceFile, e.Size, e.Max)
+}
+
+// OverflowPolicy decides what happens to an event when the event channel is full.
+type OverflowPolicy int
+
+const (
+	// OverflowDropNewest drops the event that did not fit (the default).
+	OverflowDropNewest OverflowPolicy = iota
+	// OverflowDropOldest evicts the oldest buffered event to make room, keeping the latest.
+	OverflowDropOldest
+	// OverflowBlock waits for the consumer to make room; reading stalls meanwhile.
+	OverflowBlock
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowDropNewest:
+		return "drop-newest"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowBlock:
+		return "block"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
 // RawLine represents an unparsed line from a transcript file.
 // Used in raw mode where parsing is deferred to the orchestrator.
 type RawLine struct {
@@ -43,6 +90,9 @@ type RawLine struct {
 	Timestamp time.Time `json:"timestamp"`
 
 	SourceFile string `json:"source_file"`
+
+	// SourceLine is the 1-based line number within SourceFile
+	SourceLine int `json:"source_line"`
 }
 
 // activeFile tracks the state of a single file being watched
@@ -51,6 +101,18 @@ type activeFile struct {
 	file   *os.File
 	reader *bufio.Reader
 	offset int64
+	line   int      // Number of complete lines read so far
+	seen   *uuidSet // Entry UUIDs read from this file, for gap detection
+
+	partial  []byte // Start of a line whose newline has not been written yet
+	skipping bool   // Discarding the rest of an oversized line
+	skipped  int64  // Bytes of the oversized line discarded so far
+
+	committed int64 // Offset just past the last complete line
+	saved     int64 // Offset last written to the OffsetStore
+
+	compressed bool // Decompressed with gzip: read once, never reopened or resumed
+	finished   bool // A compressed file was read to its end
 }
 
 // TranscriptWatcher watches transcript JSONL files and emits ParsedEvent.
@@ -63,7 +125,10 @@ type TranscriptWatcher struct {
 	discoverDir  string // Directory to search for UUID files (if discovery mode)
 	source       string
 	pollInterval time.Duration
+	useFSNotify  bool
+	notifying    bool // fsnotify is watching, so ticks only flush held events
 	adapter      types.Adapter
+	parser       types.Parser // Set when the adapter's entries span lines (types.LineParserFactory)
 	eventChan    chan types.ParsedEvent
 	rawEventChan chan RawLine // Raw line channel (used when RawMode is enabled)
 	rawMode      bool         // When true, emit raw lines instead of parsed events
@@ -75,15 +140,55 @@ type TranscriptWatcher struct {
 	initialized  bool
 	closed       bool
 	linesRead    int64
-	lineNumber   int64 // Current line number for RawEntry
+	bytesRead    int64     // Bytes of the complete lines in linesRead
+	lastActivity time.Time // When the last line was read
 	lastError    error
 	activeFiles  map[string]*activeFile // All files currently being watched
+
+	// Last gzip header error of the single file, reported once rather than on every poll
+	headerErr string
+
+	// Pause support: while paused, reading continues but events are held back
+	paused          bool
+	pauseBufferSize int
+	pendingEvents   []types.ParsedEvent // Held events in parsed mode, oldest first
+	pendingRaw      []RawLine           // Held lines in raw mode, oldest first
+	droppedEvents   int64
+	overflow        OverflowPolicy
+
+	// Rate limiting (Config.MaxEventsPerSecond): events over the rate wait in pendingEvents.
+	// Reading stops while they fill the buffer, and readStalled asks for it to resume.
+	limiter     *tokenBucket
+	throttled   bool
+	readStalled bool
+
+	deltas *types.DeltaTracker // Set in delta mode (Config.ToolResultDeltas)
+
+	// Gap detection: set when the source adapter chains entries by UUID
+	linker          types.EntryLinker
+	maxTrackedUUIDs int
+	gapsDetected    int64
+
+	maxLineBytes   int
+	oversizedLines int64
+
+	offsets OffsetStore // Set when resuming across restarts (Config.ResumeFrom)
+
+	emitParseErrors bool // Unparseable lines become error events (Config.EmitParseErrors)
+
+	// Run summary: counted while watching, frozen into final just before Done closes
+	startedAt     time.Time
+	eventsEmitted int64
+	parseErrors   int64
+	final         *FinalStats
 }
 
 // Config holds configuration for a TranscriptWatcher.
 type Config struct {
 	// FilePath is the path to the transcript JSONL file.
 	// If DiscoverUUID is true, this should be a directory path instead.
+	// A path ending in .gz is read as a gzip-compressed archive: decompressed from the
+	// start once, without tailing, restart detection or ResumeFrom.
 	FilePath string
 	// Source identifies the AI tool (e.g., "claude", "gemini").
 	Source string
@@ -91,6 +196,10 @@ type Config struct {
 	EventBufferSize int
 	// PollInterval is how often to check for new content (default: 100ms).
 	PollInterval time.Duration
+	// UseFSNotify reads on fsnotify write events instead of every PollInterval. The
+	// watcher polls while the directory does not exist yet, and falls back to polling
+	// for good when fsnotify cannot watch it (e.g. on network filesystems).
+	UseFSNotify bool
 	// DiscoverUUID enables UUID file discovery mode.
 	// When true, FilePath is treated as a directory and the watcher will
 	// search for UUID-named .jsonl files (e.g., "88ad3a71-4c86-4b19-b41d-71a7b027ee63.jsonl").
@@ -100,6 +209,37 @@ type Config struct {
 	// When true, the watcher emits raw bytes via RawEvents() instead of parsed events via Events().
 	// This is used when parsing should be done on the orchestrator side rather than in the agent.
 	RawMode bool
+	// PauseBufferSize caps the events held while paused (default: 10000).
+	// Events beyond the cap are dropped and counted in Stats().DroppedEvents.
+	PauseBufferSize int
+	// OverflowPolicy decides which event is lost when the event channel is full
+	// (default: OverflowDropNewest). With OverflowDropOldest a full pause buffer also
+	// evicts its oldest event; OverflowBlock never drops from the channel, but the pause
+	// buffer still drops new events once full. Losses are counted in Stats().DroppedEvents.
+	OverflowPolicy OverflowPolicy
+	// MaxEventsPerSecond paces delivery of parsed events (parsed mode only; 0 = unlimited).
+	// Events over the rate are held back like paused ones and delivered in order as the
+	// rate allows. Once PauseBufferSize events are held, reading stops until they drain,
+	// so none are dropped.
+	MaxEventsPerSecond int
+	// ToolResultDeltas enables delta mode (parsed mode only): output of running tools,
+	// such as long Bash commands, is emitted incrementally as tool_result_delta events
+	// keyed by ToolUseID before the final tool_result. When false they are dropped.
+	ToolResultDeltas bool
+	// MaxTrackedUUIDs bounds the entry UUIDs remembered per file for gap detection
+	// (default: 10000; negative disables). A parent older than the window may be reported as a gap.
+	MaxTrackedUUIDs int
+	// MaxLineBytes caps the length of a transcript line (default: 10MB). Longer lines are
+	// skipped up to their newline and reported as OversizedLineError.
+	MaxLineBytes int
+	// ResumeFrom, when set, persists each file's read offset after every read and resumes
+	// from it when the file is opened again, so a restarted watcher does not re-emit lines.
+	// Events still held back by Pause when the process died are not read again.
+	ResumeFrom OffsetStore
+	// EmitParseErrors (parsed mode only) emits a line the adapter cannot parse as an
+	// EventTypeError event carrying the line in RawPayload, so consumers keep a record
+	// of what was skipped. The error is reported on Errors() either way.
+	EmitParseErrors bool
 }
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -116,13 +256,18 @@ func DefaultConfig(filePath, source string) Config {
 // The watcher must be started with Start() before events are emitted.
 func NewTranscriptWatcher(ctx context.Context, cfg Config) (*TranscriptWatcher, error) {
 	// In raw mode, we don't need the adapter (parsing happens on orchestrator)
-	var adapter types.Adapter
-	if !cfg.RawMode {
-		var ok bool
-		adapter, ok = adapters.Get(cfg.Source)
-		if !ok {
-			return nil, fmt.Errorf("%w: unknown source %q", ErrInitFailed, cfg.Source)
-		}
+	adapter, ok := adapters.Get(cfg.Source)
+	if !ok && !cfg.RawMode {
+		return nil, fmt.Errorf("%w: unknown source %q", ErrInitFailed, cfg.Source)
+	}
+	// Gap detection only needs the UUID chain, so it works in raw mode too
+	linker, _ := adapter.(types.EntryLinker)
+	if cfg.RawMode {
+		adapter = nil
+	}
+	var parser types.Parser
+	if factory, ok := adapter.(types.LineParserFactory); ok {
+		parser = factory.NewLineParser()
 	}
 
 	if cfg.EventBufferSize == 0 {
@@ -131,19 +276,46 @@ func NewTranscriptWatcher(ctx context.Context, cfg Config) (*TranscriptWatcher,
 	if cfg.PollInterval == 0 {
 		cfg.PollInterval = 100 * time.Millisecond
 	}
+	if cfg.PauseBufferSize == 0 {
+		cfg.PauseBufferSize = 10000
+	}
+	if cfg.MaxTrackedUUIDs == 0 {
+		cfg.MaxTrackedUUIDs = 10000
+	}
+	if cfg.MaxLineBytes <= 0 {
+		cfg.MaxLineBytes = DefaultMaxLineBytes
+	}
 
 	watchCtx, cancel := context.WithCancel(ctx)
 
 	w := &TranscriptWatcher{
 		source:       cfg.Source,
 		pollInterval: cfg.PollInterval,
+		useFSNotify:  cfg.UseFSNotify,
 		adapter:      adapter,
+		parser:       parser,
 		rawMode:      cfg.RawMode,
 		errorChan:    make(chan error, 10),
 		doneChan:     make(chan struct{}),
 		ctx:          watchCtx,
 		cancel:       cancel,
 		activeFiles:  make(map[string]*activeFile),
+
+		pauseBufferSize: cfg.PauseBufferSize,
+		overflow:        cfg.OverflowPolicy,
+		linker:          linker,
+		maxTrackedUUIDs: cfg.MaxTrackedUUIDs,
+		maxLineBytes:    cfg.MaxLineBytes,
+		offsets:         cfg.ResumeFrom,
+		emitParseErrors: cfg.EmitParseErrors,
+	}
+
+	if cfg.MaxEventsPerSecond > 0 && !cfg.RawMode {
+		w.limiter = newTokenBucket(cfg.MaxEventsPerSecond, time.Now())
+	}
+
+	if cfg.ToolResultDeltas && !cfg.RawMode {
+		w.deltas = types.NewDeltaTracker()
 	}
 
 	// Initialize the appropriate event channel based on mode
@@ -198,6 +370,7 @@ func (w *TranscriptWatcher) Start() error {
 
 	w.mu.Lock()
 	w.initialized = true
+	w.startedAt = time.Now()
 	w.mu.Unlock()
 
 	go w.watch()
@@ -247,7 +420,38 @@ func (w *TranscriptWatcher) Stop() {
 	w.cancel()
 	// Wait for the watch goroutine to finish
 	<-w.doneChan
-	log.Info().Int("activeFiles", len(w.activeFiles)).Int64("linesRead", w.linesRead).Msg("Transcript watcher stopped")
+	if final, ok := w.FinalStats(); ok {
+		log.Info().Int("activeFiles", len(w.activeFiles)).Str("summary", final.String()).Msg("Transcript watcher stopped")
+	}
+}
+
+// Pause stops emitting events without tearing down the watcher. Files keep being
+// read and events are held internally (up to PauseBufferSize) until Resume.
+func (w *TranscriptWatcher) Pause() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.paused {
+		w.paused = true
+		log.Info().Msg("Transcript watcher paused")
+	}
+}
+
+// Resume restarts event emission. Events held while paused are emitted first, in
+// order, as the event channel has room.
+func (w *TranscriptWatcher) Resume() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.paused {
+		w.paused = false
+		log.Info().Int("pending", len(w.pendingEvents)+len(w.pendingRaw)).Msg("Transcript watcher resumed")
+	}
+}
+
+// IsPaused returns whether event emission is paused.
+func (w *TranscriptWatcher) IsPaused() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.paused
 }
 
 // Stats returns watcher statistics.
@@ -268,12 +472,85 @@ func (w *TranscriptWatcher) Stats() WatcherStats {
 		ActiveFileCount: len(w.activeFiles),
 		Source:          w.source,
 		LinesRead:       w.linesRead,
+		BytesRead:       w.bytesRead,
+		LastActivity:    w.lastActivity,
 		Initialized:     w.initialized,
 		Closed:          w.closed,
+		Paused:          w.paused,
+		FSNotify:        w.notifying,
+		PendingEvents:   len(w.pendingEvents) + len(w.pendingRaw),
+		Throttled:       w.throttled,
+		DroppedEvents:   w.droppedEvents,
+		GapsDetected:    w.gapsDetected,
+		OversizedLines:  w.oversizedLines,
 		LastError:       w.lastError,
 	}
 }
 
+// FinalStats returns the summary of a finished watcher. It is only available once Done()
+// is closed, including after Stop() interrupted it mid-stream; ok is false before that.
+func (w *TranscriptWatcher) FinalStats() (stats FinalStats, ok bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.final == nil {
+		return FinalStats{}, false
+	}
+	return *w.final, true
+}
+
+// FinalStats summarizes a watcher's whole run
+type FinalStats struct {
+	LinesRead      int64
+	EventsEmitted  int64 // Events (raw lines in raw mode) delivered to the event channel
+	EventsDropped  int64 // Events lost to a full channel or pause buffer
+	EventsPending  int   // Events still held back by Pause when the watcher stopped; never delivered
+	ParseErrors    int64 // Lines the adapter could not parse (parsed mode only)
+	GapsDetected   int64
+	OversizedLines int64         // Lines skipped for exceeding MaxLineBytes
+	Duration       time.Duration // From Start to the watcher stopping
+}
+
+// String renders the summary as a single line for logs and tools
+func (s FinalStats) String() string {
+	summary := fmt.Sprintf("read %d lines, emitted %d events in %s", s.LinesRead, s.EventsEmitted, s.Duration.Round(time.Millisecond))
+	var extra []string
+	if s.EventsDropped > 0 {
+		extra = append(extra, fmt.Sprintf("%d dropped", s.EventsDropped))
+	}
+	if s.EventsPending > 0 {
+		extra = append(extra, fmt.Sprintf("%d undelivered", s.EventsPending))
+	}
+	if s.ParseErrors > 0 {
+		extra = append(extra, fmt.Sprintf("%d parse errors", s.ParseErrors))
+	}
+	if s.GapsDetected > 0 {
+		extra = append(extra, fmt.Sprintf("%d gaps", s.GapsDetected))
+	}
+	if s.OversizedLines > 0 {
+		extra = append(extra, fmt.Sprintf("%d oversized lines", s.OversizedLines))
+	}
+	if len(extra) > 0 {
+		summary += " (" + strings.Join(extra, ", ") + ")"
+	}
+	return summary
+}
+
+// recordFinalStats freezes the run summary; called by watch() as it exits
+func (w *TranscriptWatcher) recordFinalStats() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.final = &FinalStats{
+		LinesRead:      w.linesRead,
+		EventsEmitted:  w.eventsEmitted,
+		EventsDropped:  w.droppedEvents,
+		EventsPending:  len(w.pendingEvents) + len(w.pendingRaw),
+		ParseErrors:    w.parseErrors,
+		GapsDetected:   w.gapsDetected,
+		OversizedLines: w.oversizedLines,
+		Duration:       time.Since(w.startedAt),
+	}
+}
+
 // WatcherStats contains watcher statistics.
 type WatcherStats struct {
 	FilePath        string   // Single file path (non-discovery mode)
@@ -282,13 +559,25 @@ type WatcherStats struct {
 	ActiveFileCount int      // Number of files currently being watched
 	Source          string
 	LinesRead       int64
+	BytesRead       int64     // Bytes of the lines read, newlines included (decompressed for .gz)
+	LastActivity    time.Time // When the last line was read; zero until one is
 	Initialized     bool
 	Closed          bool
+	Paused          bool  // Emission paused via Pause()
+	FSNotify        bool  // Changes are picked up through fsnotify rather than polling
+	PendingEvents   int   // Events held back while paused or draining after Resume
+	Throttled       bool  // Events are held back by MaxEventsPerSecond
+	DroppedEvents   int64 // Events dropped because a buffer was full
+	GapsDetected    int64 // Entries whose parent was never read (see GapDetectedError)
+	OversizedLines  int64 // Lines skipped for exceeding MaxLineBytes (see OversizedLineError)
 	LastError       error
 }
 
 func (w *TranscriptWatcher) watch() {
-	defer close(w.doneChan)
+	defer func() {
+		w.recordFinalStats()
+		close(w.doneChan)
+	}()
 	defer func() {
 		// Close all active files
 		for _, af := range w.activeFiles {
@@ -296,6 +585,8 @@ func (w *TranscriptWatcher) watch() {
 				af.file.Close()
 			}
 		}
+		// Emit what a line parser still holds, such as aider's last block
+		w.flushParser()
 		// Close the appropriate event channel based on mode
 		if w.rawMode {
 			close(w.rawEventChan)
@@ -305,28 +596,196 @@ func (w *TranscriptWatcher) watch() {
 	}()
 	defer close(w.errorChan)
 
+	var notify *notifier
+	var notifyEvents <-chan fsnotify.Event
+	var notifyErrors <-chan error
+	if w.useFSNotify {
+		dir := w.discoverDir
+		if dir == "" {
+			dir = filepath.Dir(w.filePath)
+		}
+		if notify = newNotifier(dir); notify != nil {
+			defer notify.close()
+			notifyEvents, notifyErrors = notify.fs.Events, notify.fs.Errors
+		}
+	}
+	// Falls back to polling: the directory cannot be watched after all
+	stopNotifying := func(reason error) {
+		log.Warn().Err(reason).Str("dir", notify.dir).Msg("fsnotify cannot watch transcripts, polling instead")
+		notify.close()
+		notify, notifyEvents, notifyErrors = nil, nil, nil
+		w.setNotifying(false)
+	}
+	if notify != nil {
+		if err := w.armNotifier(notify); err != nil {
+			stopNotifying(err)
+		}
+	}
+
 	ticker := time.NewTicker(w.pollInterval)
 	defer ticker.Stop()
 
+	if w.isNotifying() {
+		// fsnotify only reports changes; read what is already there
+		w.poll()
+	}
+
 	for {
 		select {
 		case <-w.ctx.Done():
 			return
 		case <-ticker.C:
-			// Discovery mode: scan for new UUID files
-			if w.discoverDir != "" {
-				w.discoverAndAddNewFiles()
-			} else if w.filePath != "" && len(w.activeFiles) == 0 {
-				// Single file mode: try to open the file if not yet tracked
-				w.tryOpenSingleFile()
+			if notify != nil && !w.isNotifying() {
+				if err := w.armNotifier(notify); err != nil {
+					stopNotifying(err)
+				} else if w.isNotifying() {
+					// The directory appeared: pick up anything written before the watch was added
+					w.poll()
+					continue
+				}
 			}
-
-			// Read from ALL active files
-			for _, af := range w.activeFiles {
-				w.readAvailableLines(af)
+			if w.isNotifying() {
+				// Reading waits for fsnotify; only events held back by Pause or the rate
+				// limit need the ticker, and reading that stopped on a full buffer
+				w.flushPending()
+				if w.isReadStalled() {
+					w.poll()
+				}
+				continue
+			}
+			w.poll()
+		case event, ok := <-notifyEvents:
+			if !ok {
+				stopNotifying(errors.New("fsnotify event channel closed"))
+				continue
+			}
+			if notify.isDir(event) && (event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename)) {
+				// Re-armed by the ticker once the directory is back
+				notify.disarm()
+				w.setNotifying(false)
+				continue
+			}
+			if w.isWatchedPath(event.Name) {
+				w.poll()
 			}
+		case err, ok := <-notifyErrors:
+			if !ok {
+				stopNotifying(errors.New("fsnotify error channel closed"))
+				continue
+			}
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				// Events were lost, so read everything
+				w.poll()
+				continue
+			}
+			w.reportError(fmt.Errorf("fsnotify: %w", err))
+		}
+	}
+}
+
+// poll discovers or opens files and reads everything available from them
+func (w *TranscriptWatcher) poll() {
+	// Emit events held back while paused before any new ones
+	w.flushPending()
+	w.mu.Lock()
+	w.readStalled = false
+	w.mu.Unlock()
+
+	// Discovery mode: scan for new UUID files
+	if w.discoverDir != "" {
+		w.discoverAndAddNewFiles()
+	} else if w.filePath != "" && len(w.activeFiles) == 0 {
+		// Single file mode: try to open the file if not yet tracked
+		w.tryOpenSingleFile()
+	}
+
+	// Read from ALL active files
+	for _, af := range w.activeFiles {
+		if !af.compressed {
+			w.reopenIfReplaced(af)
+		}
+		w.readAvailableLines(af)
+	}
+}
+
+// armNotifier starts fsnotify watching, returning the error when it cannot
+func (w *TranscriptWatcher) armNotifier(notify *notifier) error {
+	armed, err := notify.arm()
+	if err != nil {
+		return err
+	}
+	if armed {
+		log.Debug().Str("dir", notify.dir).Msg("Watching transcripts with fsnotify")
+	}
+	w.setNotifying(armed)
+	return nil
+}
+
+func (w *TranscriptWatcher) setNotifying(on bool) {
+	w.mu.Lock()
+	w.notifying = on
+	w.mu.Unlock()
+}
+
+func (w *TranscriptWatcher) isNotifying() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.notifying
+}
+
+// isWatchedPath reports whether an fsnotify event concerns a transcript this watcher reads
+func (w *TranscriptWatcher) isWatchedPath(path string) bool {
+	if w.discoverDir != "" {
+		return transcriptFileRegex.MatchString(filepath.Base(path))
+	}
+	return filepath.Clean(path) == filepath.Clean(w.filePath)
+}
+
+// reopenIfReplaced starts reading af from the beginning again when its file was truncated,
+// or replaced by a new one at the same path (rotation). The rest of a replaced file is read
+// first. A deleted file keeps its handle until a new file appears at the path.
+func (w *TranscriptWatcher) reopenIfReplaced(af *activeFile) {
+	info, err := os.Stat(af.path)
+	if err != nil {
+		return
+	}
+	current, err := af.file.Stat()
+	if err != nil {
+		return
+	}
+
+	var reason string
+	switch {
+	case !os.SameFile(info, current):
+		w.readAvailableLines(af)
+		file, err := os.Open(af.path)
+		if err != nil {
+			w.reportError(fmt.Errorf("failed to reopen replaced transcript %s: %w", af.path, err))
+			return
+		}
+		af.file.Close()
+		af.file = file
+		reason = "replaced"
+	case info.Size() < af.offset:
+		if _, err := af.file.Seek(0, io.SeekStart); err != nil {
+			w.reportError(fmt.Errorf("failed to rewind truncated transcript %s: %w", af.path, err))
+			return
 		}
+		reason = "truncated"
+	default:
+		return
 	}
+
+	log.Info().Str("file", filepath.Base(af.path)).Str("reason", reason).Int("linesBefore", af.line).Msg("Transcript file restarted, reading from the beginning")
+	af.reader.Reset(af.file)
+	af.offset = 0
+	af.committed = 0
+	af.saved = -1 // Store the restart even when it ends up at the old offset
+	af.line = 0
+	af.partial = nil
+	af.skipping = false
+	af.skipped = 0
+	af.seen = newUUIDSet(w.maxTrackedUUIDs)
 }
 
 // discoverAndAddNewFiles scans for new UUID files and adds them to activeFiles
@@ -368,7 +827,9 @@ func (w *TranscriptWatcher) discoverAndAddNewFiles() {
 			file:   file,
 			reader: bufio.NewReader(file),
 			offset: 0,
+			seen:   newUUIDSet(w.maxTrackedUUIDs),
 		}
+		w.resumeOffset(af)
 		w.activeFiles[fullPath] = af
 		log.Info().Str("file", entry.Name()).Int("totalFiles", len(w.activeFiles)).Msg("Now watching new transcript file")
 	}
@@ -389,21 +850,51 @@ func (w *TranscriptWatcher) tryOpenSingleFile() {
 		file:   file,
 		reader: bufio.NewReader(file),
 		offset: 0,
+		seen:   newUUIDSet(w.maxTrackedUUIDs),
+	}
+	if isCompressed(w.filePath) {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			// An empty file may still be getting its header; retry on the next poll, but
+			// only report a corrupt header the first time it is seen
+			if err != io.EOF && err.Error() != w.headerErr {
+				w.headerErr = err.Error()
+				w.reportError(fmt.Errorf("failed to open compressed transcript: %w", err))
+			}
+			return
+		}
+		w.headerErr = ""
+		af.reader = bufio.NewReader(gz)
+		af.compressed = true
+	} else {
+		w.resumeOffset(af)
 	}
 	w.activeFiles[w.filePath] = af
 	log.Info().Str("file", w.filePath).Msg("Now watching transcript file")
 }
 
 func (w *TranscriptWatcher) readAvailableLines(af *activeFile) {
+	if af.finished {
+		return
+	}
+	defer w.saveOffset(af)
+
 	for {
 		select {
 		case <-w.ctx.Done():
 			return
 		default:
 		}
+		if w.backlogFull() {
+			// Rate limited: leave the rest unread until the held events drain
+			return
+		}
 
-		line, err := af.reader.ReadBytes('\n')
+		line, oversized, err := w.readLine(af)
 		if err != nil {
+			// A compressed archive is complete; its end, or a corrupt stream, is final
+			af.finished = af.compressed
 			if err == io.EOF {
 				// No more data available right now
 				return
@@ -412,68 +903,419 @@ func (w *TranscriptWatcher) readAvailableLines(af *activeFile) {
 			return
 		}
 
-		af.offset += int64(len(line))
+		af.line++
 		w.mu.Lock()
 		w.linesRead++
+		w.bytesRead += af.offset - af.committed
+		w.lastActivity = time.Now()
+		if oversized {
+			w.oversizedLines++
+		}
 		w.mu.Unlock()
+		af.committed = af.offset
 
-		// Skip empty lines
-		if len(line) <= 1 {
+		if oversized {
+			w.reportError(&OversizedLineError{
+				SourceFile: filepath.Base(af.path),
+				SourceLine: af.line,
+				Size:       af.skipped,
+				Max:        w.maxLineBytes,
+			})
 			continue
 		}
 
+		// Skip empty lines, except in multi-line entries where they belong to the entry
+		if len(line) <= 1 && w.parser == nil {
+			continue
+		}
+
+		w.checkChain(af, line)
+
 		// Parse and emit event
-		w.processLine(line, filepath.Base(af.path))
+		w.processLine(line, filepath.Base(af.path), af.line)
 	}
 }
 
-func (w *TranscriptWatcher) processLine(line []byte, sourceFile string) {
+// readLine returns the next complete line, newline included. A line longer than
+// maxLineBytes is discarded up to its newline instead, without holding it in memory,
+// and reported as oversized. A line still being written is kept until it completes,
+// except at the end of a compressed archive, where nothing more is coming.
+func (w *TranscriptWatcher) readLine(af *activeFile) (line []byte, oversized bool, err error) {
+	for {
+		chunk, err := af.reader.ReadSlice('\n')
+		af.offset += int64(len(chunk))
+
+		if !af.skipping {
+			length := len(af.partial) + len(chunk)
+			if err == nil {
+				length-- // The newline does not count
+			}
+			if length > w.maxLineBytes {
+				af.skipping = true
+				af.skipped = int64(len(af.partial))
+				af.partial = nil
+			} else {
+				af.partial = append(af.partial, chunk...)
+			}
+		}
+		if af.skipping {
+			af.skipped += int64(len(chunk))
+		}
+
+		switch err {
+		case nil:
+			if af.skipping {
+				af.skipping = false
+				return nil, true, nil
+			}
+			line, af.partial = af.partial, nil
+			return line, false, nil
+		case bufio.ErrBufferFull:
+			continue
+		case io.EOF:
+			if !af.compressed || (len(af.partial) == 0 && !af.skipping) {
+				return nil, false, err
+			}
+			// The archive's last line has no newline; the next read reports EOF
+			if af.skipping {
+				af.skipping = false
+				return nil, true, nil
+			}
+			line, af.partial = af.partial, nil
+			return line, false, nil
+		default:
+			return nil, false, err
+		}
+	}
+}
+
+func (w *TranscriptWatcher) processLine(line []byte, sourceFile string, sourceLine int) {
 	if w.rawMode {
 		// Raw mode: emit the line as-is without parsing
 		rawLine := RawLine{
 			Line:       line,
 			Timestamp:  time.Now(),
 			SourceFile: sourceFile,
+			SourceLine: sourceLine,
 		}
 
-		// Non-blocking send to raw event channel
-		select {
-		case w.rawEventChan <- rawLine:
-		default:
-			// Channel full, drop event and report
-			w.reportError(fmt.Errorf("raw event channel full, dropping event"))
-		}
+		w.emitRaw(rawLine)
 		return
 	}
 
 	// Parsed mode: parse and emit structured events
-	w.mu.Lock()
-	w.lineNumber++
-	lineNum := int(w.lineNumber)
-	w.mu.Unlock()
-
 	rawEntry := types.RawEntry{
-		Line:      lineNum,
+		Line:      sourceLine,
 		Data:      json.RawMessage(line),
 		SessionID: types.ExtractSessionID(json.RawMessage(line)),
 	}
+	if rawEntry.SessionID == "" {
+		rawEntry.SessionID = types.SessionIDFromPath(sourceFile)
+	}
 
-	events, err := w.adapter.ParseEntry(rawEntry)
+	var events []types.ParsedEvent
+	var err error
+	if w.parser != nil {
+		// Events come per entry, once its last line has been read, and carry its first line
+		events, err = w.parser.OnLine(w.ctx, types.StreamID{Name: sourceFile, StreamType: "fs"}, line)
+	} else {
+		events, err = w.adapter.ParseEntry(rawEntry)
+	}
 	if err != nil {
-		w.reportError(fmt.Errorf("failed to parse transcript line %d: %w", lineNum, err))
+		w.mu.Lock()
+		w.parseErrors++
+		w.mu.Unlock()
+		err = fmt.Errorf("failed to parse %s line %d: %w", sourceFile, sourceLine, err)
+		w.reportError(err)
+		if w.emitParseErrors {
+			w.emitEvent(parseErrorEvent(rawEntry, sourceFile, err))
+		}
 		return
 	}
 
 	// Emit all parsed events (one entry can produce multiple events)
 	for _, event := range events {
+		if w.deltas == nil && event.EventType == types.EventTypeToolResultDelta {
+			continue
+		}
+		if w.deltas != nil && !w.deltas.Apply(&event) {
+			continue
+		}
+		event.SourceFile = sourceFile
+		if w.parser == nil {
+			event.SourceLine = sourceLine
+		}
+		w.emitEvent(event)
+	}
+}
+
+// flushParser emits the entries a line parser holds at the end of its files
+func (w *TranscriptWatcher) flushParser() {
+	if w.parser == nil {
+		return
+	}
+	events, err := w.parser.Flush(context.Background())
+	if err != nil {
+		w.mu.Lock()
+		w.parseErrors++
+		w.mu.Unlock()
+		log.Warn().Err(err).Msg("Failed to parse the last transcript entry")
+	}
+	for _, event := range events {
+		w.emitEvent(event)
+	}
+}
+
+// parseErrorEvent records a line the adapter could not parse. Its ID is derived from
+// the line's position, so re-reading the line does not store it twice.
+func parseErrorEvent(raw types.RawEntry, sourceFile string, err error) types.ParsedEvent {
+	return types.ParsedEvent{
+		EventID:        fmt.Sprintf("parse-error-%s-%d", sourceFile, raw.Line),
+		SessionID:      raw.SessionID,
+		EventType:      types.EventTypeError,
+		Kind:           types.KindForEvent(types.EventTypeError),
+		Level:          types.LevelForEvent(types.EventTypeError),
+		Timestamp:      time.Now(),
+		SourceFile:     sourceFile,
+		SourceLine:     raw.Line,
+		ContentPreview: err.Error(),
+		ContentLength:  len(raw.Data),
+		RawPayload:     raw.Data,
+	}
+}
+
+// emitEvent sends a parsed event without blocking. While paused, over the rate limit,
+// or while earlier held events are still draining, it is held back instead to
+// preserve ordering. A rate limit token is only spent once the event is sent.
+func (w *TranscriptWatcher) emitEvent(event types.ParsedEvent) {
+	w.mu.Lock()
+	if w.paused || len(w.pendingEvents) > 0 || !w.readyLocked() {
+		// With a rate limit reading stops on a full buffer instead (see backlogFull); the
+		// events of the last line read may still go over
+		if len(w.pendingEvents) < w.pauseBufferSize || w.limiter != nil {
+			w.pendingEvents = append(w.pendingEvents, event)
+			w.mu.Unlock()
+			return
+		}
+		if w.overflow == OverflowDropOldest {
+			w.pendingEvents = append(w.pendingEvents[1:], event)
+			w.mu.Unlock()
+			w.dropEvent(fmt.Errorf("pause buffer full, dropping oldest event"))
+			return
+		}
+		w.mu.Unlock()
+		w.dropEvent(fmt.Errorf("pause buffer full, dropping event"))
+		return
+	}
+	w.mu.Unlock()
+
+	switch w.overflow {
+	case OverflowBlock:
+		select {
+		case w.eventChan <- event:
+			w.countSent()
+		case <-w.ctx.Done():
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case w.eventChan <- event:
+				w.countSent()
+				return
+			default:
+			}
+			// Channel full: evict the oldest event and try again
+			select {
+			case <-w.eventChan:
+				w.uncountEmitted()
+				w.dropEvent(fmt.Errorf("event channel full, dropping oldest event"))
+			default:
+			}
+		}
+	default:
 		// Non-blocking send to event channel
 		select {
 		case w.eventChan <- event:
+			w.countSent()
+		default:
+			// Channel full, drop event and report
+			w.dropEvent(fmt.Errorf("event channel full, dropping event"))
+		}
+	}
+}
+
+// emitRaw is emitEvent for raw mode.
+func (w *TranscriptWatcher) emitRaw(rawLine RawLine) {
+	w.mu.Lock()
+	if w.paused || len(w.pendingRaw) > 0 {
+		if len(w.pendingRaw) < w.pauseBufferSize {
+			w.pendingRaw = append(w.pendingRaw, rawLine)
+			w.mu.Unlock()
+			return
+		}
+		if w.overflow == OverflowDropOldest {
+			w.pendingRaw = append(w.pendingRaw[1:], rawLine)
+			w.mu.Unlock()
+			w.dropEvent(fmt.Errorf("pause buffer full, dropping oldest event"))
+			return
+		}
+		w.mu.Unlock()
+		w.dropEvent(fmt.Errorf("pause buffer full, dropping event"))
+		return
+	}
+	w.mu.Unlock()
+
+	switch w.overflow {
+	case OverflowBlock:
+		select {
+		case w.rawEventChan <- rawLine:
+			w.countEmitted(1)
+		case <-w.ctx.Done():
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case w.rawEventChan <- rawLine:
+				w.countEmitted(1)
+				return
+			default:
+			}
+			// Channel full: evict the oldest line and try again
+			select {
+			case <-w.rawEventChan:
+				w.uncountEmitted()
+				w.dropEvent(fmt.Errorf("raw event channel full, dropping oldest event"))
+			default:
+			}
+		}
+	default:
+		// Non-blocking send to raw event channel
+		select {
+		case w.rawEventChan <- rawLine:
+			w.countEmitted(1)
 		default:
 			// Channel full, drop event and report
-			w.reportError(fmt.Errorf("event channel full, dropping event"))
+			w.dropEvent(fmt.Errorf("raw event channel full, dropping event"))
+		}
+	}
+}
+
+// flushPending emits held events, oldest first, until the channel is full.
+// Nothing is emitted while paused.
+func (w *TranscriptWatcher) flushPending() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.paused {
+		return
+	}
+
+	sent := 0
+	if w.rawMode {
+	rawLoop:
+		for _, rawLine := range w.pendingRaw {
+			select {
+			case w.rawEventChan <- rawLine:
+				sent++
+			default:
+				break rawLoop
+			}
+		}
+		w.pendingRaw = w.pendingRaw[sent:]
+		if len(w.pendingRaw) == 0 {
+			w.pendingRaw = nil
+		}
+		w.eventsEmitted += int64(sent)
+		return
+	}
+
+eventLoop:
+	for _, event := range w.pendingEvents {
+		if !w.readyLocked() {
+			break
+		}
+		select {
+		case w.eventChan <- event:
+			sent++
+			if w.limiter != nil {
+				w.limiter.spend()
+			}
+		default:
+			break eventLoop
 		}
 	}
+	w.pendingEvents = w.pendingEvents[sent:]
+	if len(w.pendingEvents) == 0 {
+		w.pendingEvents = nil
+		w.throttled = false
+	}
+	w.eventsEmitted += int64(sent)
+}
+
+// readyLocked reports whether the rate limiter, if there is one, has a token for the
+// next event, and marks the watcher throttled when it has none. The token is spent
+// once the event is sent. Must be called with mu held.
+func (w *TranscriptWatcher) readyLocked() bool {
+	if w.limiter == nil {
+		return true
+	}
+	if !w.limiter.ready(time.Now()) {
+		w.throttled = true
+		return false
+	}
+	return true
+}
+
+// backlogFull reports whether rate-limited events fill the buffer, in which case
+// reading stops and is marked stalled so the next tick resumes it
+func (w *TranscriptWatcher) backlogFull() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.limiter == nil || len(w.pendingEvents) < w.pauseBufferSize {
+		return false
+	}
+	w.readStalled = true
+	return true
+}
+
+// isReadStalled reports whether reading stopped on a full buffer of rate-limited events
+func (w *TranscriptWatcher) isReadStalled() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.readStalled
+}
+
+// countEmitted counts events delivered to the event channel
+func (w *TranscriptWatcher) countEmitted(n int64) {
+	w.mu.Lock()
+	w.eventsEmitted += n
+	w.mu.Unlock()
+}
+
+// countSent counts a parsed event delivered to the event channel and spends its rate
+// limit token
+func (w *TranscriptWatcher) countSent() {
+	w.mu.Lock()
+	w.eventsEmitted++
+	if w.limiter != nil {
+		w.limiter.spend()
+	}
+	w.mu.Unlock()
+}
+
+// uncountEmitted takes back an emitted event that was evicted from the channel
+// before the consumer received it
+func (w *TranscriptWatcher) uncountEmitted() {
+	w.mu.Lock()
+	w.eventsEmitted--
+	w.mu.Unlock()
+}
+
+// dropEvent counts a dropped event and reports why
+func (w *TranscriptWatcher) dropEvent(err error) {
+	w.mu.Lock()
+	w.droppedEvents++
+	w.mu.Unlock()
+	w.reportError(err)
 }
 
 func (w *TranscriptWatcher) reportError(err error) {
@@ -4,11 +4,15 @@
 package watcher
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sync"
 	"testing"
 	"time"
@@ -141,6 +145,681 @@ func TestTranscriptWatcher_BasicReading(t *testing.T) {
 	}
 }
 
+func TestTranscriptWatcher_ReadsGzipTranscript(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl.gz")
+
+	var plain bytes.Buffer
+	for i := 0; i < 5; i++ {
+		plain.Write(generateClaudeTranscriptLine(i, "user"))
+	}
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write(plain.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, os.WriteFile(transcriptPath, compressed.Bytes(), 0644))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:        transcriptPath,
+		Source:          "claude",
+		EventBufferSize: 100,
+		PollInterval:    10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+	defer watcher.Stop()
+
+	received := receiveEvents(t, watcher, 5, 2*time.Second)
+	for i, event := range received {
+		assert.Equal(t, types.EventTypeUserPrompt, event.EventType)
+		assert.Equal(t, i+1, event.SourceLine)
+		assert.Equal(t, "transcript.jsonl.gz", event.SourceFile)
+	}
+
+	// The archive is read once: later polls neither re-read nor report errors
+	time.Sleep(50 * time.Millisecond)
+	stats := watcher.Stats()
+	assert.Equal(t, int64(5), stats.LinesRead)
+	assert.NoError(t, stats.LastError)
+}
+
+func TestTranscriptWatcher_GzipTranscriptWithoutFinalNewline(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl.gz")
+
+	var plain bytes.Buffer
+	for i := 0; i < 3; i++ {
+		plain.Write(generateClaudeTranscriptLine(i, "user"))
+	}
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write(bytes.TrimSuffix(plain.Bytes(), []byte("\n")))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, os.WriteFile(transcriptPath, compressed.Bytes(), 0644))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:        transcriptPath,
+		Source:          "claude",
+		EventBufferSize: 100,
+		PollInterval:    10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+	defer watcher.Stop()
+
+	// The archive is complete, so its unterminated last line is read too
+	received := receiveEvents(t, watcher, 3, 2*time.Second)
+	assert.Equal(t, 3, received[2].SourceLine)
+	assert.Equal(t, int64(3), watcher.Stats().LinesRead)
+}
+
+func TestTranscriptWatcher_CorruptGzipHeaderReportedOnce(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl.gz")
+	require.NoError(t, os.WriteFile(transcriptPath, generateClaudeTranscriptLine(0, "user"), 0644))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:        transcriptPath,
+		Source:          "claude",
+		EventBufferSize: 100,
+		PollInterval:    10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+	defer watcher.Stop()
+
+	select {
+	case err := <-watcher.Errors():
+		assert.Contains(t, err.Error(), "failed to open compressed transcript")
+	case <-time.After(2 * time.Second):
+		t.Fatal("corrupt gzip header was not reported")
+	}
+	// Later polls retry the file without repeating the error
+	select {
+	case err := <-watcher.Errors():
+		t.Fatalf("error reported again: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestOpenTranscript(t *testing.T) {
+	tmpDir := t.TempDir()
+	line := generateClaudeTranscriptLine(0, "user")
+
+	plainPath := filepath.Join(tmpDir, "transcript.jsonl")
+	require.NoError(t, os.WriteFile(plainPath, line, 0644))
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write(line)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	gzPath := filepath.Join(tmpDir, "transcript.jsonl.gz")
+	require.NoError(t, os.WriteFile(gzPath, compressed.Bytes(), 0644))
+
+	for _, path := range []string{plainPath, gzPath} {
+		r, err := OpenTranscript(path)
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		assert.Equal(t, line, data, path)
+	}
+
+	badPath := filepath.Join(tmpDir, "bad.jsonl.gz")
+	require.NoError(t, os.WriteFile(badPath, line, 0644))
+	_, err = OpenTranscript(badPath)
+	assert.Error(t, err)
+}
+
+func TestTranscriptWatcher_StampsSourceOrigin(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+
+	f, err := os.Create(transcriptPath)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err := f.Write(generateClaudeTranscriptLine(i, "user"))
+		require.NoError(t, err)
+	}
+	f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:        transcriptPath,
+		Source:          "claude",
+		EventBufferSize: 100,
+		PollInterval:    10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+	defer watcher.Stop()
+
+	var received []types.ParsedEvent
+	timeout := time.After(2 * time.Second)
+	for len(received) < 3 {
+		select {
+		case event := <-watcher.Events():
+			received = append(received, event)
+		case <-timeout:
+			t.Fatalf("Timeout waiting for events, got %d", len(received))
+		}
+	}
+
+	for i, event := range received {
+		assert.Equal(t, "transcript.jsonl", event.SourceFile)
+		assert.Equal(t, i+1, event.SourceLine, "line numbers are 1-based per file")
+	}
+}
+
+func TestTranscriptWatcher_AiderHistory(t *testing.T) {
+	tmpDir := t.TempDir()
+	history := "# aider chat started at 2025-01-15 10:30:00\n" +
+		"\n" +
+		"#### Make the greeting friendlier\n" +
+		"\n" +
+		"Here is the change.\n" +
+		"\n" +
+		"It keeps the old name.\n" +
+		"\n" +
+		"> Applied edit to greeting.py\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".aider.chat.history.md"), []byte(history), 0644))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:        tmpDir,
+		Source:          "aider",
+		EventBufferSize: 100,
+		PollInterval:    10 * time.Millisecond,
+		DiscoverUUID:    true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+
+	// Each block is emitted once the next one starts
+	var received []types.ParsedEvent
+	timeout := time.After(2 * time.Second)
+	for len(received) < 3 {
+		select {
+		case event := <-watcher.Events():
+			received = append(received, event)
+		case <-timeout:
+			t.Fatalf("Timeout waiting for events, got %d", len(received))
+		}
+	}
+
+	// and the last block when the watcher stops
+	watcher.Stop()
+	for event := range watcher.Events() {
+		received = append(received, event)
+	}
+
+	require.Len(t, received, 4)
+	assert.Equal(t, types.EventTypeSessionStart, received[0].EventType)
+	assert.Equal(t, types.EventTypeUserPrompt, received[1].EventType)
+	assert.Equal(t, types.EventTypeAIOutput, received[2].EventType)
+	assert.Equal(t, "Here is the change.\n\nIt keeps the old name.", received[2].ContentPreview, "blank lines stay inside the reply")
+	assert.Equal(t, 5, received[2].SourceLine, "events carry the block's first line")
+	assert.Equal(t, types.EventTypeToolResult, received[3].EventType)
+	assert.Equal(t, "greeting.py", received[3].FilePath)
+	for _, event := range received {
+		assert.Equal(t, "aider-20250115-103000", event.SessionID)
+		assert.Equal(t, ".aider.chat.history.md", event.SourceFile)
+	}
+}
+
+func TestTranscriptWatcher_PauseResume(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+
+	f, err := os.Create(transcriptPath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:        transcriptPath,
+		Source:          "claude",
+		EventBufferSize: 100,
+		PollInterval:    10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+	defer watcher.Stop()
+
+	watcher.Pause()
+	assert.True(t, watcher.Stats().Paused)
+
+	for i := 0; i < 5; i++ {
+		_, err := f.Write(generateClaudeTranscriptLine(i, "user"))
+		require.NoError(t, err)
+	}
+
+	// Lines are still read while paused, but nothing is emitted
+	require.Eventually(t, func() bool {
+		return watcher.Stats().PendingEvents == 5
+	}, 2*time.Second, 10*time.Millisecond)
+	select {
+	case event := <-watcher.Events():
+		t.Fatalf("Received event while paused: %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Equal(t, int64(5), watcher.Stats().LinesRead)
+
+	watcher.Resume()
+	assert.False(t, watcher.IsPaused())
+
+	var received []types.ParsedEvent
+	timeout := time.After(2 * time.Second)
+	for len(received) < 5 {
+		select {
+		case event := <-watcher.Events():
+			received = append(received, event)
+		case <-timeout:
+			t.Fatalf("Timeout waiting for events, got %d", len(received))
+		}
+	}
+
+	for i, event := range received {
+		assert.Equal(t, i+1, event.SourceLine, "held events are emitted in order")
+	}
+	stats := watcher.Stats()
+	assert.Equal(t, 0, stats.PendingEvents)
+	assert.Equal(t, int64(0), stats.DroppedEvents)
+}
+
+func bashProgressLine(toolUseID, fullOutput string) []byte {
+	entry := map[string]interface{}{
+		"type":            "progress",
+		"uuid":            fmt.Sprintf("progress-%d", len(fullOutput)),
+		"sessionId":       "test-session-123",
+		"timestamp":       time.Now().Format(time.RFC3339Nano),
+		"parentToolUseID": toolUseID,
+		"data":            map[string]interface{}{"type": "bash_progress", "fullOutput": fullOutput},
+	}
+	data, _ := json.Marshal(entry)
+	return append(data, '\n')
+}
+
+func TestTranscriptWatcher_ToolResultDeltas(t *testing.T) {
+	lines := [][]byte{
+		bashProgressLine("toolu_1", "step 1\n"),
+		bashProgressLine("toolu_1", "step 1\nstep 2\n"),
+		bashProgressLine("toolu_1", "step 1\nstep 2\n"), // no new output
+		[]byte(`{"type":"user","uuid":"r1","sessionId":"test-session-123","timestamp":"2025-01-15T10:30:00Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"step 1\nstep 2\n"}]}}` + "\n"),
+	}
+
+	run := func(t *testing.T, deltas bool) []types.ParsedEvent {
+		tmpDir := t.TempDir()
+		transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+		var content []byte
+		for _, line := range lines {
+			content = append(content, line...)
+		}
+		require.NoError(t, os.WriteFile(transcriptPath, content, 0644))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		watcher, err := NewTranscriptWatcher(ctx, Config{
+			FilePath:         transcriptPath,
+			Source:           "claude",
+			EventBufferSize:  100,
+			PollInterval:     10 * time.Millisecond,
+			ToolResultDeltas: deltas,
+		})
+		require.NoError(t, err)
+		require.NoError(t, watcher.Start())
+		defer watcher.Stop()
+
+		var received []types.ParsedEvent
+		timeout := time.After(2 * time.Second)
+		for {
+			select {
+			case event := <-watcher.Events():
+				received = append(received, event)
+				if event.EventType == types.EventTypeToolResult {
+					return received
+				}
+			case <-timeout:
+				t.Fatalf("Timeout waiting for tool result, got %d events", len(received))
+			}
+		}
+	}
+
+	t.Run("delta mode", func(t *testing.T) {
+		received := run(t, true)
+		require.Len(t, received, 3)
+		assert.Equal(t, types.EventTypeToolResultDelta, received[0].EventType)
+		assert.Equal(t, "toolu_1", received[0].ToolUseID)
+		assert.Equal(t, "step 1\n", received[0].ContentPreview)
+		assert.Equal(t, "step 2\n", received[1].ContentPreview)
+		assert.Equal(t, types.EventTypeToolResult, received[2].EventType)
+		assert.Equal(t, "toolu_1", received[2].ToolUseID)
+	})
+
+	t.Run("deltas dropped by default", func(t *testing.T) {
+		received := run(t, false)
+		require.Len(t, received, 1)
+		assert.Equal(t, types.EventTypeToolResult, received[0].EventType)
+	})
+}
+
+func chainedLine(uuid, parentUUID string) []byte {
+	entry := map[string]interface{}{
+		"type":       "user",
+		"uuid":       uuid,
+		"parentUuid": parentUUID,
+		"sessionId":  "test-session-123",
+		"timestamp":  time.Now().Format(time.RFC3339Nano),
+		"message":    map[string]interface{}{"role": "user", "content": "hello " + uuid},
+	}
+	if parentUUID == "" {
+		entry["parentUuid"] = nil
+	}
+	data, _ := json.Marshal(entry)
+	return append(data, '\n')
+}
+
+func TestTranscriptWatcher_GapDetection(t *testing.T) {
+	run := func(t *testing.T, rawMode bool, lines ...[]byte) *TranscriptWatcher {
+		tmpDir := t.TempDir()
+		transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+		var content []byte
+		for _, line := range lines {
+			content = append(content, line...)
+		}
+		require.NoError(t, os.WriteFile(transcriptPath, content, 0644))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		t.Cleanup(cancel)
+
+		watcher, err := NewTranscriptWatcher(ctx, Config{
+			FilePath:        transcriptPath,
+			Source:          "claude",
+			EventBufferSize: 100,
+			PollInterval:    10 * time.Millisecond,
+			RawMode:         rawMode,
+		})
+		require.NoError(t, err)
+		require.NoError(t, watcher.Start())
+		t.Cleanup(watcher.Stop)
+
+		require.Eventually(t, func() bool {
+			return watcher.Stats().LinesRead == int64(len(lines))
+		}, 2*time.Second, 10*time.Millisecond)
+		return watcher
+	}
+
+	t.Run("contiguous chain", func(t *testing.T) {
+		watcher := run(t, false, chainedLine("u1", ""), chainedLine("u2", "u1"), chainedLine("u3", "u2"))
+		assert.Equal(t, int64(0), watcher.Stats().GapsDetected)
+		select {
+		case err := <-watcher.Errors():
+			t.Fatalf("Unexpected error: %v", err)
+		default:
+		}
+	})
+
+	for _, rawMode := range []bool{false, true} {
+		t.Run(fmt.Sprintf("missing parent raw=%v", rawMode), func(t *testing.T) {
+			watcher := run(t, rawMode, chainedLine("u1", ""), chainedLine("u3", "u2"), chainedLine("u4", "u3"))
+			assert.Equal(t, int64(1), watcher.Stats().GapsDetected)
+
+			var gap *GapDetectedError
+			require.ErrorAs(t, <-watcher.Errors(), &gap)
+			assert.Equal(t, "transcript.jsonl", gap.SourceFile)
+			assert.Equal(t, 2, gap.SourceLine)
+			assert.Equal(t, "u3", gap.UUID)
+			assert.Equal(t, "u2", gap.ParentUUID)
+		})
+	}
+}
+
+func TestTranscriptWatcher_SkipsOversizedLine(t *testing.T) {
+	for _, rawMode := range []bool{false, true} {
+		t.Run(fmt.Sprintf("raw=%v", rawMode), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+
+			giant := make([]byte, 12*1024*1024)
+			for i := range giant {
+				giant[i] = 'x'
+			}
+			content := append([]byte(nil), generateClaudeTranscriptLine(0, "user")...)
+			content = append(content, giant...)
+			content = append(content, '\n')
+			content = append(content, generateClaudeTranscriptLine(1, "user")...)
+			content = append(content, generateClaudeTranscriptLine(2, "user")...)
+			require.NoError(t, os.WriteFile(transcriptPath, content, 0644))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			watcher, err := NewTranscriptWatcher(ctx, Config{
+				FilePath:        transcriptPath,
+				Source:          "claude",
+				EventBufferSize: 100,
+				PollInterval:    10 * time.Millisecond,
+				RawMode:         rawMode,
+			})
+			require.NoError(t, err)
+			require.NoError(t, watcher.Start())
+			defer watcher.Stop()
+
+			var lines []int
+			for len(lines) < 3 {
+				select {
+				case event := <-watcher.Events():
+					if event.EventType == types.EventTypeUserPrompt {
+						lines = append(lines, event.SourceLine)
+					}
+				case raw := <-watcher.RawEvents():
+					lines = append(lines, raw.SourceLine)
+				case <-time.After(5 * time.Second):
+					t.Fatalf("Timeout waiting for lines, got %v", lines)
+				}
+			}
+			assert.Equal(t, []int{1, 3, 4}, lines)
+
+			var oversized *OversizedLineError
+			require.ErrorAs(t, <-watcher.Errors(), &oversized)
+			assert.Equal(t, "transcript.jsonl", oversized.SourceFile)
+			assert.Equal(t, 2, oversized.SourceLine)
+			assert.Equal(t, int64(len(giant)+1), oversized.Size)
+			assert.Equal(t, DefaultMaxLineBytes, oversized.Max)
+
+			stats := watcher.Stats()
+			assert.Equal(t, int64(1), stats.OversizedLines)
+			assert.Equal(t, int64(4), stats.LinesRead)
+		})
+	}
+}
+
+func TestTranscriptWatcher_EmitParseErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+	content := append(generateClaudeTranscriptLine(0, "user"), []byte("{\"type\": not json\n")...)
+	content = append(content, generateClaudeTranscriptLine(2, "user")...)
+	require.NoError(t, os.WriteFile(transcriptPath, content, 0644))
+
+	start := func(emit bool) *TranscriptWatcher {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		t.Cleanup(cancel)
+		watcher, err := NewTranscriptWatcher(ctx, Config{
+			FilePath:        transcriptPath,
+			Source:          "claude",
+			EventBufferSize: 100,
+			PollInterval:    10 * time.Millisecond,
+			EmitParseErrors: emit,
+		})
+		require.NoError(t, err)
+		require.NoError(t, watcher.Start())
+		t.Cleanup(watcher.Stop)
+		return watcher
+	}
+
+	watcher := start(true)
+	received := receiveEvents(t, watcher, 3, 2*time.Second)
+	assert.Equal(t, types.EventTypeUserPrompt, received[0].EventType)
+	assert.Equal(t, types.EventTypeUserPrompt, received[2].EventType)
+
+	parseErr := received[1]
+	assert.Equal(t, types.EventTypeError, parseErr.EventType)
+	assert.Equal(t, types.KindError, parseErr.Kind)
+	assert.Equal(t, types.LevelError, parseErr.Level)
+	assert.Equal(t, 2, parseErr.SourceLine)
+	assert.Equal(t, "transcript.jsonl", parseErr.SourceFile)
+	assert.Equal(t, "{\"type\": not json\n", string(parseErr.RawPayload))
+	assert.Contains(t, parseErr.ContentPreview, "line 2")
+	assert.NotEmpty(t, parseErr.EventID)
+
+	select {
+	case err := <-watcher.Errors():
+		assert.Contains(t, err.Error(), "line 2")
+	case <-time.After(time.Second):
+		t.Fatal("parse error not reported on Errors()")
+	}
+
+	// Off by default: the line is only reported
+	watcher = start(false)
+	received = receiveEvents(t, watcher, 2, 2*time.Second)
+	assert.Equal(t, 3, received[1].SourceLine)
+}
+
+func TestUUIDSet_EvictsOldest(t *testing.T) {
+	s := newUUIDSet(2)
+	s.add("a")
+	s.add("b")
+	s.add("a") // already present, does not take a slot
+	s.add("c")
+
+	assert.False(t, s.contains("a"))
+	assert.True(t, s.contains("b"))
+	assert.True(t, s.contains("c"))
+}
+
+func TestTranscriptWatcher_FinalStatsAfterStop(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+
+	f, err := os.Create(transcriptPath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:        transcriptPath,
+		Source:          "claude",
+		EventBufferSize: 100,
+		PollInterval:    10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+
+	for i := 0; i < 3; i++ {
+		_, err := f.Write(generateClaudeTranscriptLine(i, "user"))
+		require.NoError(t, err)
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case <-watcher.Events():
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timeout waiting for event %d", i)
+		}
+	}
+
+	_, err = f.WriteString("not json\n")
+	require.NoError(t, err)
+
+	// Stop mid-stream with events still held back by Pause
+	watcher.Pause()
+	for i := 3; i < 5; i++ {
+		_, err := f.Write(generateClaudeTranscriptLine(i, "user"))
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool {
+		return watcher.Stats().LinesRead == 6
+	}, 2*time.Second, 10*time.Millisecond)
+
+	_, ok := watcher.FinalStats()
+	assert.False(t, ok, "final stats are not available before Done")
+
+	watcher.Stop()
+
+	final, ok := watcher.FinalStats()
+	require.True(t, ok)
+	assert.Equal(t, int64(6), final.LinesRead)
+	assert.Equal(t, int64(3), final.EventsEmitted)
+	assert.Equal(t, 2, final.EventsPending)
+	assert.Equal(t, int64(1), final.ParseErrors)
+	assert.Equal(t, int64(0), final.EventsDropped)
+	assert.Positive(t, final.Duration)
+	assert.Contains(t, final.String(), "read 6 lines, emitted 3 events")
+	assert.Contains(t, final.String(), "2 undelivered, 1 parse errors")
+}
+
+func TestTranscriptWatcher_PauseBufferOverflowDrops(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+
+	f, err := os.Create(transcriptPath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:        transcriptPath,
+		Source:          "claude",
+		EventBufferSize: 100,
+		PollInterval:    10 * time.Millisecond,
+		PauseBufferSize: 3,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+	defer watcher.Stop()
+
+	watcher.Pause()
+	for i := 0; i < 5; i++ {
+		_, err := f.Write(generateClaudeTranscriptLine(i, "user"))
+		require.NoError(t, err)
+	}
+
+	require.Eventually(t, func() bool {
+		return watcher.Stats().DroppedEvents == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 3, watcher.Stats().PendingEvents)
+
+	// The oldest events are kept
+	watcher.Resume()
+	for i := 0; i < 3; i++ {
+		select {
+		case event := <-watcher.Events():
+			assert.Equal(t, i+1, event.SourceLine)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timeout waiting for event %d", i)
+		}
+	}
+}
+
 func TestTranscriptWatcher_NonBlocking_HundredsOfLines(t *testing.T) {
 	// This test verifies that writing hundreds of lines doesn't block the writer
 	// and all events are read correctly
@@ -333,61 +1012,321 @@ func TestTranscriptWatcher_ConcurrentWriteAndRead(t *testing.T) {
 	assert.Equal(t, numLines, finalCount)
 }
 
-func TestTranscriptWatcher_FileCreatedAfterStart(t *testing.T) {
-	// Test that watcher handles file being created after Start() is called
-
-	tmpDir := t.TempDir()
-	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+func TestTranscriptWatcher_FileCreatedAfterStart(t *testing.T) {
+	// Test that watcher handles file being created after Start() is called
+
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+
+	// DON'T create the file yet
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cfg := Config{
+		FilePath:        transcriptPath,
+		Source:          "claude",
+		EventBufferSize: 100,
+		PollInterval:    20 * time.Millisecond,
+	}
+
+	watcher, err := NewTranscriptWatcher(ctx, cfg)
+	require.NoError(t, err)
+
+	err = watcher.Start()
+	require.NoError(t, err)
+	defer watcher.Stop()
+
+	// Wait a bit, then create and write to the file
+	time.Sleep(100 * time.Millisecond)
+
+	f, err := os.Create(transcriptPath)
+	require.NoError(t, err)
+
+	numLines := 10
+	for i := 0; i < numLines; i++ {
+		_, err := f.Write(generateClaudeTranscriptLine(i, "user"))
+		require.NoError(t, err)
+	}
+	f.Sync()
+	f.Close()
+
+	// Wait for events
+	var receivedEvents []types.ParsedEvent
+	timeout := time.After(5 * time.Second)
+
+	for len(receivedEvents) < numLines {
+		select {
+		case event, ok := <-watcher.Events():
+			if !ok {
+				t.Fatal("Event channel closed")
+			}
+			receivedEvents = append(receivedEvents, event)
+		case <-timeout:
+			t.Fatalf("Timeout waiting for events, got %d, expected %d", len(receivedEvents), numLines)
+		}
+	}
+
+	assert.Equal(t, numLines, len(receivedEvents))
+}
+
+// receiveEvents waits for n events from w, failing the test after timeout
+func receiveEvents(t *testing.T, w *TranscriptWatcher, n int, timeout time.Duration) []types.ParsedEvent {
+	t.Helper()
+	var received []types.ParsedEvent
+	deadline := time.After(timeout)
+	for len(received) < n {
+		select {
+		case event, ok := <-w.Events():
+			if !ok {
+				t.Fatalf("Event channel closed after %d of %d events", len(received), n)
+			}
+			received = append(received, event)
+		case <-deadline:
+			t.Fatalf("Timeout waiting for events, got %d, expected %d", len(received), n)
+		}
+	}
+	return received
+}
+
+func appendLines(t *testing.T, path string, from, to int) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	defer f.Close()
+	for i := from; i < to; i++ {
+		_, err := f.Write(generateClaudeTranscriptLine(i, "user"))
+		require.NoError(t, err)
+	}
+}
+
+func TestTranscriptWatcher_FSNotify(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+	appendLines(t, transcriptPath, 0, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// A poll interval this long means only fsnotify can deliver the lines
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:        transcriptPath,
+		Source:          "claude",
+		EventBufferSize: 100,
+		PollInterval:    time.Hour,
+		UseFSNotify:     true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+	defer watcher.Stop()
+
+	// Content written before starting is read straight away
+	received := receiveEvents(t, watcher, 3, 2*time.Second)
+	assert.Equal(t, 3, received[2].SourceLine)
+	assert.True(t, watcher.Stats().FSNotify)
+
+	appendLines(t, transcriptPath, 3, 5)
+	received = receiveEvents(t, watcher, 2, 2*time.Second)
+	assert.Equal(t, 5, received[1].SourceLine)
+
+	// Other files in the directory do not wake the watcher up
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "other.jsonl"), generateClaudeTranscriptLine(99, "user"), 0644))
+	select {
+	case event := <-watcher.Events():
+		t.Fatalf("Unexpected event from another file: %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestTranscriptWatcher_FSNotify_DirectoryCreatedLater(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "later")
+	transcriptPath := filepath.Join(dir, "transcript.jsonl")
 
-	// DON'T create the file yet
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	cfg := Config{
+	watcher, err := NewTranscriptWatcher(ctx, Config{
 		FilePath:        transcriptPath,
 		Source:          "claude",
 		EventBufferSize: 100,
 		PollInterval:    20 * time.Millisecond,
+		UseFSNotify:     true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+	defer watcher.Stop()
+
+	// Polls until the directory exists, then switches to fsnotify
+	assert.False(t, watcher.Stats().FSNotify)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	appendLines(t, transcriptPath, 0, 2)
+	receiveEvents(t, watcher, 2, 2*time.Second)
+	assert.Eventually(t, func() bool { return watcher.Stats().FSNotify }, 2*time.Second, 10*time.Millisecond)
+
+	appendLines(t, transcriptPath, 2, 3)
+	receiveEvents(t, watcher, 1, 2*time.Second)
+}
+
+func TestTranscriptWatcher_RestartsTruncatedAndReplacedFiles(t *testing.T) {
+	for _, useFSNotify := range []bool{false, true} {
+		t.Run(fmt.Sprintf("fsnotify=%v", useFSNotify), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+			appendLines(t, transcriptPath, 0, 3)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			watcher, err := NewTranscriptWatcher(ctx, Config{
+				FilePath:        transcriptPath,
+				Source:          "claude",
+				EventBufferSize: 100,
+				PollInterval:    10 * time.Millisecond,
+				UseFSNotify:     useFSNotify,
+			})
+			require.NoError(t, err)
+			require.NoError(t, watcher.Start())
+			defer watcher.Stop()
+			receiveEvents(t, watcher, 3, 2*time.Second)
+
+			// Truncated in place: the new content is read from line 1
+			require.NoError(t, os.Truncate(transcriptPath, 0))
+			appendLines(t, transcriptPath, 10, 11)
+			received := receiveEvents(t, watcher, 1, 2*time.Second)
+			assert.Equal(t, 1, received[0].SourceLine)
+			assert.Equal(t, "User message 10", received[0].ContentPreview)
+
+			// Rotated: moved away and replaced by a new file
+			require.NoError(t, os.Rename(transcriptPath, transcriptPath+".1"))
+			appendLines(t, transcriptPath, 20, 22)
+			received = receiveEvents(t, watcher, 2, 2*time.Second)
+			assert.Equal(t, "User message 21", received[1].ContentPreview)
+			assert.Equal(t, 2, received[1].SourceLine)
+		})
 	}
+}
 
-	watcher, err := NewTranscriptWatcher(ctx, cfg)
-	require.NoError(t, err)
+func TestTranscriptWatcher_FSNotify_StopReleasesGoroutines(t *testing.T) {
+	tmpDir := t.TempDir()
+	before := runtime.NumGoroutine()
 
-	err = watcher.Start()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:    filepath.Join(tmpDir, "transcript.jsonl"),
+		Source:      "claude",
+		UseFSNotify: true,
+	})
 	require.NoError(t, err)
-	defer watcher.Stop()
+	require.NoError(t, watcher.Start())
+	assert.Eventually(t, func() bool { return watcher.Stats().FSNotify }, time.Second, 10*time.Millisecond)
 
-	// Wait a bit, then create and write to the file
-	time.Sleep(100 * time.Millisecond)
+	watcher.Stop()
+	_, open := <-watcher.Events()
+	assert.False(t, open)
+	// Not assert.Eventually, which runs the condition on a goroutine of its own
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before, "the watch loop and fsnotify's reader exit on Stop")
+}
 
-	f, err := os.Create(transcriptPath)
+func TestFileOffsetStore_SaveLoad(t *testing.T) {
+	store := NewFileOffsetStore()
+	transcriptPath := filepath.Join(t.TempDir(), "transcript.jsonl")
+
+	_, ok, err := store.Load(transcriptPath)
 	require.NoError(t, err)
+	assert.False(t, ok, "nothing stored yet")
 
-	numLines := 10
-	for i := 0; i < numLines; i++ {
-		_, err := f.Write(generateClaudeTranscriptLine(i, "user"))
+	require.NoError(t, store.Save(transcriptPath, FileOffset{Offset: 120, Line: 3}))
+	require.NoError(t, store.Save(transcriptPath, FileOffset{Offset: 240, Line: 6}))
+	offset, ok, err := store.Load(transcriptPath)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, FileOffset{Offset: 240, Line: 6}, offset)
+	assert.FileExists(t, transcriptPath+".offset")
+
+	require.NoError(t, os.WriteFile(transcriptPath+".offset", []byte("not json"), 0644))
+	_, _, err = store.Load(transcriptPath)
+	assert.Error(t, err)
+}
+
+func TestTranscriptWatcher_ResumeFrom(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+	require.NoError(t, os.WriteFile(transcriptPath, append(chainedLine("u1", ""), chainedLine("u2", "u1")...), 0644))
+	store := NewFileOffsetStore()
+
+	start := func() *TranscriptWatcher {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		t.Cleanup(cancel)
+		watcher, err := NewTranscriptWatcher(ctx, Config{
+			FilePath:        transcriptPath,
+			Source:          "claude",
+			EventBufferSize: 100,
+			PollInterval:    10 * time.Millisecond,
+			ResumeFrom:      store,
+		})
 		require.NoError(t, err)
+		require.NoError(t, watcher.Start())
+		return watcher
 	}
-	f.Sync()
-	f.Close()
 
-	// Wait for events
-	var receivedEvents []types.ParsedEvent
-	timeout := time.After(5 * time.Second)
+	first := start()
+	receiveEvents(t, first, 2, 2*time.Second)
+	first.Stop()
 
-	for len(receivedEvents) < numLines {
-		select {
-		case event, ok := <-watcher.Events():
-			if !ok {
-				t.Fatal("Event channel closed")
-			}
-			receivedEvents = append(receivedEvents, event)
-		case <-timeout:
-			t.Fatalf("Timeout waiting for events, got %d, expected %d", len(receivedEvents), numLines)
-		}
-	}
+	f, err := os.OpenFile(transcriptPath, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.Write(append(chainedLine("u3", "u2"), chainedLine("u4", "u3")...))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
 
-	assert.Equal(t, numLines, len(receivedEvents))
+	// The restarted watcher only reads what was appended meanwhile
+	second := start()
+	defer second.Stop()
+	received := receiveEvents(t, second, 2, 2*time.Second)
+	assert.Equal(t, "u3", received[0].MessageUUID)
+	assert.Equal(t, 3, received[0].SourceLine)
+	assert.Equal(t, 4, received[1].SourceLine)
+	assert.Eventually(t, func() bool { return second.Stats().LinesRead == 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(0), second.Stats().GapsDetected, "the parent before the resumed offset is known")
+
+	offset, ok, err := store.Load(transcriptPath)
+	require.NoError(t, err)
+	require.True(t, ok)
+	info, err := os.Stat(transcriptPath)
+	require.NoError(t, err)
+	assert.Equal(t, FileOffset{Offset: info.Size(), Line: 4}, offset)
+}
+
+func TestTranscriptWatcher_ResumeFrom_OffsetPastEnd(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+	appendLines(t, transcriptPath, 0, 2)
+	store := NewFileOffsetStore()
+	// Stored for an earlier, longer transcript at the same path
+	require.NoError(t, store.Save(transcriptPath, FileOffset{Offset: 1 << 20, Line: 500}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:        transcriptPath,
+		Source:          "claude",
+		EventBufferSize: 100,
+		PollInterval:    10 * time.Millisecond,
+		ResumeFrom:      store,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+	defer watcher.Stop()
+
+	received := receiveEvents(t, watcher, 2, 2*time.Second)
+	assert.Equal(t, 1, received[0].SourceLine)
+	assert.Equal(t, "User message 0", received[0].ContentPreview)
+	assert.Equal(t, 2, received[1].SourceLine)
 }
 
 func TestTranscriptWatcher_ToolUseEvents(t *testing.T) {
@@ -570,6 +1509,190 @@ done:
 	assert.Greater(t, stats.LinesRead, int64(0))
 }
 
+func TestTranscriptWatcher_OverflowPolicy(t *testing.T) {
+	tests := []struct {
+		policy     OverflowPolicy
+		firstLine  int
+		dropped    int64
+		linesStall bool
+	}{
+		{policy: OverflowDropNewest, firstLine: 1, dropped: 15},
+		{policy: OverflowDropOldest, firstLine: 16, dropped: 15},
+		{policy: OverflowBlock, firstLine: 1, linesStall: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+			var content []byte
+			for i := 0; i < 20; i++ {
+				content = append(content, generateClaudeTranscriptLine(i, "user")...)
+			}
+			require.NoError(t, os.WriteFile(transcriptPath, content, 0644))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			watcher, err := NewTranscriptWatcher(ctx, Config{
+				FilePath:        transcriptPath,
+				Source:          "claude",
+				EventBufferSize: 5,
+				PollInterval:    10 * time.Millisecond,
+				OverflowPolicy:  tt.policy,
+			})
+			require.NoError(t, err)
+			require.NoError(t, watcher.Start())
+			defer watcher.Stop()
+
+			if tt.linesStall {
+				// The reader waits for room after filling the channel
+				time.Sleep(100 * time.Millisecond)
+				assert.Less(t, watcher.Stats().LinesRead, int64(20))
+				received := receiveEvents(t, watcher, 20, 2*time.Second)
+				for i, event := range received {
+					assert.Equal(t, i+1, event.SourceLine)
+				}
+				assert.Equal(t, int64(0), watcher.Stats().DroppedEvents)
+				return
+			}
+
+			require.Eventually(t, func() bool { return watcher.Stats().LinesRead == 20 }, 2*time.Second, 10*time.Millisecond)
+			received := receiveEvents(t, watcher, 5, time.Second)
+			for i, event := range received {
+				assert.Equal(t, tt.firstLine+i, event.SourceLine)
+			}
+			assert.Equal(t, tt.dropped, watcher.Stats().DroppedEvents)
+		})
+	}
+}
+
+func TestTranscriptWatcher_MaxEventsPerSecond(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+	var content []byte
+	for i := 0; i < 30; i++ {
+		content = append(content, generateClaudeTranscriptLine(i, "user")...)
+	}
+	require.NoError(t, os.WriteFile(transcriptPath, content, 0644))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:           transcriptPath,
+		Source:             "claude",
+		EventBufferSize:    100,
+		PollInterval:       10 * time.Millisecond,
+		MaxEventsPerSecond: 20,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+	defer watcher.Stop()
+
+	// The whole file is read at once, but only a burst of events is delivered
+	require.Eventually(t, func() bool { return watcher.Stats().LinesRead == 30 }, time.Second, 10*time.Millisecond)
+	stats := watcher.Stats()
+	assert.True(t, stats.Throttled)
+	assert.Greater(t, stats.PendingEvents, 0)
+
+	start := time.Now()
+	received := receiveEvents(t, watcher, 30, 3*time.Second)
+	assert.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond, "events over the burst are paced")
+	for i, event := range received {
+		assert.Equal(t, i+1, event.SourceLine)
+	}
+	assert.Eventually(t, func() bool { return !watcher.Stats().Throttled }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(0), watcher.Stats().DroppedEvents)
+}
+
+func TestTranscriptWatcher_MaxEventsPerSecond_StopsReadingOnFullBuffer(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+	var content []byte
+	for i := 0; i < 30; i++ {
+		content = append(content, generateClaudeTranscriptLine(i, "user")...)
+	}
+	require.NoError(t, os.WriteFile(transcriptPath, content, 0644))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:           transcriptPath,
+		Source:             "claude",
+		EventBufferSize:    100,
+		PollInterval:       10 * time.Millisecond,
+		MaxEventsPerSecond: 20,
+		PauseBufferSize:    5,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+	defer watcher.Stop()
+
+	// After the burst, reading waits for the held events instead of dropping new ones
+	require.Eventually(t, func() bool { return watcher.Stats().PendingEvents >= 5 }, time.Second, 5*time.Millisecond)
+	assert.Less(t, watcher.Stats().LinesRead, int64(30))
+
+	received := receiveEvents(t, watcher, 30, 3*time.Second)
+	for i, event := range received {
+		assert.Equal(t, i+1, event.SourceLine)
+	}
+	assert.Equal(t, int64(30), watcher.Stats().LinesRead)
+	assert.Equal(t, int64(0), watcher.Stats().DroppedEvents)
+}
+
+func TestTranscriptWatcher_MaxEventsPerSecond_DroppedEventsKeepTheirToken(t *testing.T) {
+	tmpDir := t.TempDir()
+	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
+	var content []byte
+	for i := 0; i < 20; i++ {
+		content = append(content, generateClaudeTranscriptLine(i, "user")...)
+	}
+	require.NoError(t, os.WriteFile(transcriptPath, content, 0644))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	watcher, err := NewTranscriptWatcher(ctx, Config{
+		FilePath:           transcriptPath,
+		Source:             "claude",
+		EventBufferSize:    1,
+		PollInterval:       10 * time.Millisecond,
+		MaxEventsPerSecond: 20,
+	})
+	require.NoError(t, err)
+	require.NoError(t, watcher.Start())
+	defer watcher.Stop()
+
+	// Nothing is received: one event fills the channel and the rest are dropped
+	require.Eventually(t, func() bool { return watcher.Stats().DroppedEvents == 19 }, time.Second, 5*time.Millisecond)
+
+	// Only the delivered event took a token
+	watcher.mu.Lock()
+	watcher.limiter.ready(time.Now())
+	tokens := watcher.limiter.tokens
+	watcher.mu.Unlock()
+	assert.GreaterOrEqual(t, tokens, float64(18))
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(2, now)
+	assert.True(t, b.take(now))
+	assert.True(t, b.take(now))
+	assert.False(t, b.take(now), "burst is one second's worth")
+	assert.False(t, b.take(now.Add(400*time.Millisecond)))
+	assert.True(t, b.take(now.Add(500*time.Millisecond)))
+	assert.True(t, b.take(now.Add(10*time.Second)))
+	assert.True(t, b.take(now.Add(10*time.Second)))
+	assert.False(t, b.take(now.Add(10*time.Second)), "idle time does not grow the burst")
+
+	// ready does not spend the token
+	later := now.Add(20 * time.Second)
+	assert.True(t, b.ready(later))
+	assert.True(t, b.ready(later))
+	b.spend()
+	b.spend()
+	assert.False(t, b.ready(later))
+}
+
 func TestTranscriptWatcher_UnknownAdapter(t *testing.T) {
 	tmpDir := t.TempDir()
 	transcriptPath := filepath.Join(tmpDir, "transcript.jsonl")
@@ -1060,6 +2183,55 @@ func TestDirectoryWatcher_MultipleSessions(t *testing.T) {
 	assert.Equal(t, len(sessions), stats.WatcherCount)
 }
 
+func TestDirectoryWatcher_PerFileStats(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tmpDir := t.TempDir()
+	lines := map[string]int{
+		"aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee.jsonl": 3,
+		"11111111-2222-3333-4444-555555555555.jsonl": 1,
+	}
+	sizes := make(map[string]int64)
+	for name, n := range lines {
+		var content []byte
+		for i := 0; i < n; i++ {
+			content = append(content, generateClaudeTranscriptLine(i, "user")...)
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), content, 0644))
+		sizes[name] = int64(len(content))
+	}
+
+	dw, err := NewDirectoryWatcher(ctx, DirectoryWatcherConfig{
+		Directory:       tmpDir,
+		Source:          "claude",
+		EventBufferSize: 100,
+		PollInterval:    20 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, dw.Start())
+	defer dw.Stop()
+
+	timeout := time.After(3 * time.Second)
+	for received := 0; received < 4; received++ {
+		select {
+		case <-dw.Events():
+		case <-timeout:
+			t.Fatalf("Timeout waiting for events, got %d, expected 4", received)
+		}
+	}
+
+	stats := dw.Stats()
+	require.Len(t, stats.PerFile, len(lines))
+	for name, n := range lines {
+		fileStats, ok := stats.PerFile[name]
+		require.True(t, ok, name)
+		assert.Equal(t, int64(n), fileStats.LinesRead, name)
+		assert.Equal(t, sizes[name], fileStats.BytesRead, name)
+		assert.False(t, fileStats.LastActivity.IsZero(), name)
+	}
+}
+
 func TestDirectoryWatcher_SessionCreatedLater(t *testing.T) {
 	// Test that DirectoryWatcher picks up new session files created after start
 	ctx, cancel := context.WithCancel(context.Background())
@@ -1122,6 +2294,50 @@ func TestDirectoryWatcher_SessionCreatedLater(t *testing.T) {
 	assert.Contains(t, stats.Watchers, session2+".jsonl")
 }
 
+func TestDirectoryWatcher_SessionDeleted(t *testing.T) {
+	// Test that DirectoryWatcher stops watching session files that were deleted
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tmpDir := t.TempDir()
+	cfg := DirectoryWatcherConfig{
+		Directory:       tmpDir,
+		Source:          "claude",
+		EventBufferSize: 100,
+		PollInterval:    50 * time.Millisecond,
+	}
+
+	dw, err := NewDirectoryWatcher(ctx, cfg)
+	require.NoError(t, err)
+
+	err = dw.Start()
+	require.NoError(t, err)
+	defer dw.Stop()
+
+	// Create a session file once the watcher runs
+	session := "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"
+	path := filepath.Join(tmpDir, session+".jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"type":"user","timestamp":"2025-01-15T10:30:00.000Z","message":{"role":"user","content":[{"type":"text","text":"Doomed session"}]}}`+"\n"), 0644))
+
+	select {
+	case event := <-dw.Events():
+		assert.Equal(t, "Doomed session", event.ContentPreview)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for session event")
+	}
+	assert.Equal(t, 1, dw.Stats().WatcherCount)
+	assert.Equal(t, []string{session + ".jsonl"}, dw.ActiveSessions())
+
+	// Delete it, as a cleanup job would
+	require.NoError(t, os.Remove(path))
+
+	assert.Eventually(t, func() bool {
+		return dw.Stats().WatcherCount == 0
+	}, 4*cfg.PollInterval, 10*time.Millisecond, "watcher of the deleted session should be torn down")
+	assert.Empty(t, dw.ActiveSessions())
+	assert.NotContains(t, dw.Stats().Watchers, session+".jsonl")
+}
+
 func TestDirectoryWatcher_ConcurrentWrites(t *testing.T) {
 	// Test that DirectoryWatcher handles concurrent writes to multiple session files
 	ctx, cancel := context.WithCancel(context.Background())
@@ -1259,6 +2475,60 @@ func TestDirectoryWatcher_IgnoresNonUUID(t *testing.T) {
 	assert.Equal(t, 1, stats.WatcherCount)
 }
 
+func TestDirectoryWatcher_FilePattern(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"log-2024-01-02.jsonl":                       "Matching log",
+		"log-worker.jsonl":                           "Second log",
+		"aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee.jsonl": "UUID session",
+		"session-2024-01-02.jsonl":                   "Other session",
+		"log-2024-01-02.txt":                         "Not a transcript",
+	}
+	for name, content := range files {
+		entry := fmt.Sprintf(`{"type":"user","timestamp":"2025-01-15T10:30:00.000Z","message":{"role":"user","content":[{"type":"text","text":"%s"}]}}`, content)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(entry+"\n"), 0644))
+	}
+
+	dw, err := NewDirectoryWatcher(ctx, DirectoryWatcherConfig{
+		Directory:       tmpDir,
+		Source:          "claude",
+		EventBufferSize: 100,
+		PollInterval:    20 * time.Millisecond,
+		FilePattern:     `^log-.*\.jsonl$`,
+	})
+	require.NoError(t, err)
+	require.NoError(t, dw.Start())
+	defer dw.Stop()
+
+	received := make(map[string]bool)
+	timeout := time.After(3 * time.Second)
+	for len(received) < 2 {
+		select {
+		case event := <-dw.Events():
+			received[event.ContentPreview] = true
+		case <-timeout:
+			t.Fatalf("Timeout waiting for events, got %v", received)
+		}
+	}
+	assert.True(t, received["Matching log"])
+	assert.True(t, received["Second log"])
+
+	// Give the other files time to be (wrongly) picked up
+	time.Sleep(100 * time.Millisecond)
+	select {
+	case event := <-dw.Events():
+		t.Fatalf("Unexpected event from a file outside the pattern: %q", event.ContentPreview)
+	default:
+	}
+	stats := dw.Stats()
+	assert.Equal(t, 2, stats.WatcherCount)
+	assert.Contains(t, stats.PerFile, "log-2024-01-02.jsonl")
+	assert.Contains(t, stats.PerFile, "log-worker.jsonl")
+}
+
 func TestDirectoryWatcher_DirectoryNotExist(t *testing.T) {
 	// Test that DirectoryWatcher handles non-existent directory gracefully
 	ctx, cancel := context.WithCancel(context.Background())
@@ -1422,6 +2692,16 @@ func TestDirectoryWatcher_ConfigValidation(t *testing.T) {
 	})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "source is required")
+
+	// Test invalid file pattern
+	_, err = NewDirectoryWatcher(ctx, DirectoryWatcherConfig{
+		Directory:   "/tmp/test",
+		Source:      "claude",
+		FilePattern: `log-(.jsonl`,
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInitFailed)
+	assert.Contains(t, err.Error(), "invalid file pattern")
 }
 
 func TestDirectoryWatcher_DefaultConfig(t *testing.T) {
@@ -1432,3 +2712,88 @@ func TestDirectoryWatcher_DefaultConfig(t *testing.T) {
 	assert.Equal(t, 1000, cfg.EventBufferSize)
 	assert.Equal(t, 100*time.Millisecond, cfg.PollInterval)
 }
+
+func TestReorderBuffer_ReleasesInTimestampOrderAfterWindow(t *testing.T) {
+	base := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	now := base
+	b := newReorderBuffer(100 * time.Millisecond)
+
+	b.push(types.ParsedEvent{EventID: "late", Timestamp: base.Add(2 * time.Second)}, now)
+	b.push(types.ParsedEvent{EventID: "early", Timestamp: base.Add(time.Second)}, now.Add(10*time.Millisecond))
+	assert.Empty(t, b.ready(now.Add(50*time.Millisecond)), "events are held for the window")
+
+	// "early" sorts first but has not been held long enough, so nothing is released yet
+	assert.Empty(t, b.ready(now.Add(105*time.Millisecond)))
+
+	released := b.ready(now.Add(110 * time.Millisecond))
+	require.Len(t, released, 2)
+	assert.Equal(t, "early", released[0].EventID)
+	assert.Equal(t, "late", released[1].EventID)
+	assert.Equal(t, 0, b.len())
+}
+
+func TestDirectoryWatcher_OrderByTimestamp(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tmpDir := t.TempDir()
+	sessions := []string{
+		"aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+		"11111111-2222-3333-4444-555555555555",
+	}
+	files := make([]*os.File, len(sessions))
+	for i, sessionID := range sessions {
+		f, err := os.Create(filepath.Join(tmpDir, sessionID+".jsonl"))
+		require.NoError(t, err)
+		defer f.Close()
+		files[i] = f
+	}
+
+	dw, err := NewDirectoryWatcher(ctx, DirectoryWatcherConfig{
+		Directory:        tmpDir,
+		Source:           "claude",
+		EventBufferSize:  100,
+		PollInterval:     10 * time.Millisecond,
+		OrderByTimestamp: true,
+		ReorderWindow:    500 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, dw.Start())
+	defer dw.Stop()
+
+	require.Eventually(t, func() bool {
+		return len(dw.ActiveSessions()) == len(sessions)
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// Each session writes all its lines in turn, but their timestamps interleave:
+	// session 0 has the odd seconds and session 1 the even ones
+	base := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	const perSession = 5
+	for s, f := range files {
+		for i := 0; i < perSession; i++ {
+			ts := base.Add(time.Duration(2*i+s) * time.Second)
+			line := fmt.Sprintf(`{"type":"user","timestamp":%q,"sessionId":%q,"message":{"role":"user","content":[{"type":"text","text":"s%d-%d"}]}}`+"\n",
+				ts.Format(time.RFC3339Nano), sessions[s], s, i)
+			_, err := f.WriteString(line)
+			require.NoError(t, err)
+		}
+		time.Sleep(30 * time.Millisecond)
+	}
+
+	var received []types.ParsedEvent
+	timeout := time.After(3 * time.Second)
+	for len(received) < 2*perSession {
+		select {
+		case event := <-dw.Events():
+			received = append(received, event)
+		case <-timeout:
+			t.Fatalf("Timeout waiting for events, got %d", len(received))
+		}
+	}
+
+	for i := 1; i < len(received); i++ {
+		assert.False(t, received[i].Timestamp.Before(received[i-1].Timestamp),
+			"event %d (%s) emitted after a later one (%s)", i, received[i].Timestamp, received[i-1].Timestamp)
+	}
+	assert.Equal(t, 0, dw.Stats().Reordering)
+}
@@ -29,8 +29,20 @@ func Execute() error {
 		return taskCommand(args)
 	case "diff":
 		return diffCommand(args)
+	case "compare-runs":
+		return compareRunsCommand(args)
 	case "projects":
 		return projectsCommand(args)
+	case "project":
+		return projectCommand(args)
+	case "compact":
+		return compactCommand(args)
+	case "db":
+		return dbCommand(args)
+	case "seed":
+		return seedCommand(args)
+	case "logs":
+		return logsCommand(args)
 	case "version":
 		fmt.Printf("%s version %s\n", appName, appVersion)
 		return nil
@@ -52,7 +64,13 @@ Commands:
   run <task>     Run an AI task on a project
   task           Show task details (tokens, commands, diff)
   diff [run_id]  Show git diff for a pipeline run (grouped by steps and files)
+  compare-runs   Compare two runs of a task (tokens, files, step statuses, branch diff)
   projects       List available projects
+  project        Export or import a project's configuration (export, import)
+  compact        Delete old AI activity records of finished tasks
+  db             Database maintenance (prune old AI activity)
+  seed           Create or remove a demo project with sample data (--demo, --clear)
+  logs           Show orchestrator logs, filtered by task, level or field (--follow to tail)
   version        Print version information
   help           Show this help message
 
@@ -63,8 +81,14 @@ Examples:
   %s task show --diff
   %s diff                    # Show diff for latest run
   %s diff abc123             # Show diff for specific run
+  %s compare-runs --run-a abc123 --run-b def456
   %s projects
+  %s project export --id abc123 > project.json
+  %s compact --older-than 30d --dry-run
+  %s db prune --older-than 30d
+  %s seed --demo
+  %s logs --task-id abc123 --level warn --follow
 
-`, appName, appName, appName, appName, appName, appName, appName, appName, appName)
+`, appName, appName, appName, appName, appName, appName, appName, appName, appName, appName, appName, appName, appName, appName, appName)
 	return nil
 }
@@ -0,0 +1,136 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/noldarim/noldarim/internal/orchestrator/services"
+)
+
+type compactOptions struct {
+	configPath  string
+	olderThan   string
+	dryRun      bool
+	keepSummary bool
+}
+
+func compactCommand(args []string) error {
+	opts := &compactOptions{}
+	fs := flag.NewFlagSet("compact", flag.ExitOnError)
+	fs.StringVar(&opts.configPath, "config", "config.yaml", "Path to config file")
+	fs.StringVar(&opts.olderThan, "older-than", "30d", "Compact activity of tasks finished longer ago than this (e.g. 30d, 12h)")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "Report what would be deleted without deleting anything")
+	fs.BoolVar(&opts.keepSummary, "keep-summary", true, "Keep one summary record per task with token totals and counts")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	age, err := parseAge(opts.olderThan)
+	if err != nil {
+		return fmt.Errorf("invalid --older-than: %w", err)
+	}
+
+	return compactActivity(opts, time.Now().Add(-age))
+}
+
+func compactActivity(opts *compactOptions, cutoff time.Time) error {
+	// Load configuration
+	cfg, err := config.NewConfig(opts.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create data service (just DB access, no orchestrator)
+	dataService, err := services.NewDataService(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer dataService.Close()
+
+	ctx := context.Background()
+
+	groups, err := dataService.PreviewActivityCompaction(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to find compactable activity: %w", err)
+	}
+
+	if len(groups) == 0 {
+		fmt.Printf("No activity of tasks finished before %s.\n", cutoff.Format("2006-01-02 15:04"))
+		return nil
+	}
+
+	// Print table
+	fmt.Println()
+	fmt.Printf("%-40s  %-40s  %10s  %12s\n", "TASK", "RUN", "RECORDS", "RAW PAYLOAD")
+	fmt.Println("────────────────────────────────────────  ────────────────────────────────────────  ──────────  ────────────")
+	var totalRecords int
+	var totalBytes int64
+	for _, g := range groups {
+		fmt.Printf("%-40s  %-40s  %10s  %12s\n", truncate(g.TaskID, 40), truncate(g.RunID, 40), formatNumber(g.Records), formatBytes(g.RawPayloadBytes))
+		totalRecords += g.Records
+		totalBytes += g.RawPayloadBytes
+	}
+	fmt.Println()
+
+	if opts.dryRun {
+		fmt.Printf("Dry run: would delete %s records (%s of raw payloads) from %d tasks finished before %s.\n",
+			formatNumber(totalRecords), formatBytes(totalBytes), len(groups), cutoff.Format("2006-01-02 15:04"))
+		return nil
+	}
+
+	deleted, err := dataService.CompactActivity(ctx, cutoff, opts.keepSummary)
+	if err != nil {
+		return fmt.Errorf("compaction stopped after deleting %d records: %w", deleted, err)
+	}
+
+	fmt.Printf("Deleted %s records from %d tasks.\n", formatNumber(deleted), len(groups))
+	if opts.keepSummary {
+		fmt.Println("Token totals were kept as one summary record per task.")
+	}
+	return nil
+}
+
+// parseAge parses a duration that additionally accepts a day suffix (e.g. "30d")
+func parseAge(s string) (time.Duration, error) {
+	var age time.Duration
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid number of days: %s", s)
+		}
+		age = time.Duration(days) * 24 * time.Hour
+	} else {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, err
+		}
+		age = d
+	}
+
+	if age <= 0 {
+		return 0, fmt.Errorf("must be positive, got: %s", s)
+	}
+	return age, nil
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/noldarim/noldarim/internal/orchestrator/services"
+)
+
+type compareOptions struct {
+	configPath string
+	runA       string
+	runB       string
+	summary    bool // Skip the branch diff
+}
+
+// compareRunsCommand handles the compare-runs subcommand
+func compareRunsCommand(args []string) error {
+	opts := &compareOptions{}
+	fs := flag.NewFlagSet("compare-runs", flag.ExitOnError)
+	fs.StringVar(&opts.configPath, "config", "config.yaml", "Path to config file")
+	fs.StringVar(&opts.runA, "run-a", "", "Baseline pipeline run ID")
+	fs.StringVar(&opts.runB, "run-b", "", "Pipeline run ID to compare against the baseline")
+	fs.BoolVar(&opts.summary, "summary", false, "Show summary only, no branch diff")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if opts.runA == "" || opts.runB == "" {
+		return fmt.Errorf("both --run-a and --run-b are required")
+	}
+
+	cfg, err := config.NewConfig(opts.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dataService, err := services.NewDataService(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer dataService.Close()
+
+	gitManager := services.NewGitServiceManager(cfg)
+	defer gitManager.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	comparison, err := dataService.CompareRuns(ctx, gitManager, opts.runA, opts.runB, !opts.summary)
+	if err != nil {
+		return fmt.Errorf("failed to compare runs: %w", err)
+	}
+
+	displayRunComparison(comparison, opts)
+	return nil
+}
+
+func displayRunComparison(c *services.RunComparison, opts *compareOptions) {
+	const (
+		cyan   = "\033[36m"
+		green  = "\033[32m"
+		red    = "\033[31m"
+		yellow = "\033[33m"
+		bold   = "\033[1m"
+		dim    = "\033[2m"
+		reset  = "\033[0m"
+	)
+
+	// signed right-aligns a delta with its sign, green when it shrank and red when it grew
+	signed := func(delta int) string {
+		text := fmt.Sprintf("%12s", fmt.Sprintf("%+d", delta))
+		switch {
+		case delta > 0:
+			return red + text + reset
+		case delta < 0:
+			return green + text + reset
+		default:
+			return fmt.Sprintf("%12s", "0")
+		}
+	}
+
+	fmt.Printf("%s%s# ══════════════════════════════════════════════════════════════%s\n", bold, cyan, reset)
+	fmt.Printf("%s%s# Run A:%s %s %s(%s, head %s)%s\n", bold, cyan, reset, c.RunA.ID, dim, c.RunA.Status.String(), truncateSHA(c.RunA.HeadCommitSHA), reset)
+	fmt.Printf("%s%s# Run B:%s %s %s(%s, head %s)%s\n", bold, cyan, reset, c.RunB.ID, dim, c.RunB.Status.String(), truncateSHA(c.RunB.HeadCommitSHA), reset)
+	if c.RunA.PipelineID != c.RunB.PipelineID {
+		fmt.Printf("%s# Note: runs belong to different pipelines%s\n", yellow, reset)
+	}
+	fmt.Printf("%s%s# ══════════════════════════════════════════════════════════════%s\n", bold, cyan, reset)
+	fmt.Println()
+
+	tokensA := c.TotalsA.InputTokens + c.TotalsA.OutputTokens
+	tokensB := c.TotalsB.InputTokens + c.TotalsB.OutputTokens
+	fmt.Printf("%-16s %12s %12s %12s\n", "", "RUN A", "RUN B", "DELTA")
+	fmt.Printf("%-16s %12s %12s %s\n", "Tokens", formatNumber(tokensA), formatNumber(tokensB), signed(c.TokenDelta()))
+	fmt.Printf("%-16s %12d %12d %s\n", "Files changed", c.TotalsA.FilesChanged, c.TotalsB.FilesChanged, signed(c.FilesChangedDelta()))
+	fmt.Printf("%-16s %12d %12d %s\n", "Insertions", c.TotalsA.Insertions, c.TotalsB.Insertions, signed(c.TotalsB.Insertions-c.TotalsA.Insertions))
+	fmt.Printf("%-16s %12d %12d %s\n", "Deletions", c.TotalsA.Deletions, c.TotalsB.Deletions, signed(c.TotalsB.Deletions-c.TotalsA.Deletions))
+	fmt.Println()
+
+	if len(c.StepChanges) == 0 {
+		fmt.Printf("%s# Step statuses are identical%s\n", dim, reset)
+	} else {
+		fmt.Printf("%s%s# Step status changes%s\n", bold, yellow, reset)
+		for _, change := range c.StepChanges {
+			before, after := "-", "-"
+			if change.InA {
+				before = change.StatusA.String()
+			}
+			if change.InB {
+				after = change.StatusB.String()
+			}
+			fmt.Printf("  %-30s %s → %s\n", truncate(change.StepID, 30), before, after)
+		}
+	}
+
+	if opts.summary {
+		return
+	}
+
+	fmt.Println()
+	if c.BranchDiff == "" {
+		fmt.Printf("%s# No branch diff between head commits%s\n", dim, reset)
+		return
+	}
+	fmt.Printf("%s%s# ──────────────────────────────────────────────────────────────%s\n", dim, cyan, reset)
+	fmt.Printf("%s%s# Branch diff %s..%s%s\n", bold, yellow, truncateSHA(c.RunA.HeadCommitSHA), truncateSHA(c.RunB.HeadCommitSHA), reset)
+	fmt.Printf("%s%s# ──────────────────────────────────────────────────────────────%s\n", dim, cyan, reset)
+	fmt.Println(strings.TrimRight(c.BranchDiff, "\n"))
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/noldarim/noldarim/internal/orchestrator/services"
+)
+
+type dbPruneOptions struct {
+	configPath string
+	olderThan  string
+	dryRun     bool
+}
+
+// dbCommand dispatches db subcommands
+func dbCommand(args []string) error {
+	if len(args) == 0 {
+		return dbUsage()
+	}
+
+	subcommand := args[0]
+	subargs := args[1:]
+
+	switch subcommand {
+	case "prune":
+		return dbPruneCommand(subargs)
+	case "help", "-h", "--help":
+		return dbUsage()
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown db subcommand: %s\n\n", subcommand)
+		return dbUsage()
+	}
+}
+
+func dbUsage() error {
+	fmt.Printf(`Usage: %s db <subcommand> [arguments]
+
+Subcommands:
+  prune --older-than <age>   Delete all AI activity records older than age, of any task (see also compact)
+  help                       Show this help message
+
+Examples:
+  %s db prune --older-than 30d --dry-run
+  %s db prune --older-than 12h
+
+`, appName, appName, appName)
+	return nil
+}
+
+func dbPruneCommand(args []string) error {
+	opts := &dbPruneOptions{}
+	fs := flag.NewFlagSet("db prune", flag.ExitOnError)
+	fs.StringVar(&opts.configPath, "config", "config.yaml", "Path to config file")
+	fs.StringVar(&opts.olderThan, "older-than", "", "Delete AI activity recorded longer ago than this (e.g. 30d, 12h)")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "Report how many records would be deleted without deleting anything")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if opts.olderThan == "" {
+		return fmt.Errorf("--older-than is required")
+	}
+
+	age, err := parseAge(opts.olderThan)
+	if err != nil {
+		return fmt.Errorf("invalid --older-than: %w", err)
+	}
+	cutoff := time.Now().Add(-age)
+
+	cfg, err := config.NewConfig(opts.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dataService, err := services.NewDataService(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer dataService.Close()
+
+	ctx := context.Background()
+
+	if opts.dryRun {
+		count, err := dataService.CountAIActivityOlderThan(ctx, cutoff)
+		if err != nil {
+			return fmt.Errorf("failed to count AI activity: %w", err)
+		}
+		fmt.Printf("Dry run: would delete %s records from before %s.\n", formatNumber(int(count)), cutoff.Format("2006-01-02 15:04"))
+		return nil
+	}
+
+	deleted, err := dataService.DeleteAIActivityOlderThan(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("pruning stopped after deleting %d records: %w", deleted, err)
+	}
+	fmt.Printf("Deleted %s records from before %s.\n", formatNumber(int(deleted)), cutoff.Format("2006-01-02 15:04"))
+	return nil
+}
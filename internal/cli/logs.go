@@ -0,0 +1,270 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/noldarim/noldarim/internal/logger"
+)
+
+// logsPollInterval is how often --follow checks the log files for new lines
+const logsPollInterval = 250 * time.Millisecond
+
+type logsOptions struct {
+	configPath string
+	taskID     string
+	level      string
+	fields     fieldFilters
+	follow     bool
+	lines      int
+	rotated    bool
+	noColor    bool
+}
+
+// fieldFilters collects repeated --field key=value flags
+type fieldFilters map[string]string
+
+func (f fieldFilters) String() string {
+	pairs := make([]string, 0, len(f))
+	for key, value := range f {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (f fieldFilters) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("expected key=value, got %q", s)
+	}
+	f[key] = value
+	return nil
+}
+
+func logsCommand(args []string) error {
+	opts := &logsOptions{fields: fieldFilters{}}
+	fs := flag.NewFlagSet("logs", flag.ExitOnError)
+	fs.StringVar(&opts.configPath, "config", "config.yaml", "Path to config file")
+	fs.StringVar(&opts.taskID, "task-id", "", "Only show entries logged for this task (or run)")
+	fs.StringVar(&opts.level, "level", "", "Minimum level to show: trace, debug, info, warn, error")
+	fs.Var(opts.fields, "field", "Only show entries with this field value, as key=value (repeatable)")
+	fs.BoolVar(&opts.follow, "follow", false, "Keep printing new entries as they are written")
+	fs.BoolVar(&opts.follow, "f", false, "Shorthand for --follow")
+	fs.IntVar(&opts.lines, "lines", 0, "Only print the last N matching entries before following (0 = all)")
+	fs.BoolVar(&opts.rotated, "rotated", false, "Also read rotated log files")
+	fs.BoolVar(&opts.noColor, "no-color", false, "Disable colored output")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	filter := logger.Filter{TaskID: opts.taskID, MinLevel: zerolog.TraceLevel, Fields: opts.fields}
+	if opts.level != "" {
+		level, err := parseLogLevel(opts.level)
+		if err != nil {
+			return err
+		}
+		filter.MinLevel = level
+	}
+
+	cfg, err := config.NewConfig(opts.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	paths := logger.FileOutputs(&cfg.Log)
+	if len(paths) == 0 {
+		return fmt.Errorf("no file log output is enabled in log.output; console output cannot be read back")
+	}
+
+	return showLogs(paths, filter, opts)
+}
+
+// parseLogLevel parses a --level value, accepting "warning" like the config does
+func parseLogLevel(s string) (zerolog.Level, error) {
+	s = strings.ToLower(s)
+	if s == "warning" {
+		s = "warn"
+	}
+	level, err := zerolog.ParseLevel(s)
+	if err != nil || level == zerolog.NoLevel {
+		return zerolog.NoLevel, fmt.Errorf("invalid --level %q: use trace, debug, info, warn or error", s)
+	}
+	return level, nil
+}
+
+func showLogs(paths []string, filter logger.Filter, opts *logsOptions) error {
+	color := !opts.noColor && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout)
+
+	tails := make([]*logger.Tail, 0, len(paths))
+	defer func() {
+		for _, tail := range tails {
+			tail.Close()
+		}
+	}()
+
+	// Existing entries of every output, merged in time order
+	var history []logger.Entry
+	for _, path := range paths {
+		if _, err := os.Stat(path); os.IsNotExist(err) && !opts.follow {
+			fmt.Fprintf(os.Stderr, "Log file %s does not exist yet\n", path)
+			continue
+		}
+
+		if opts.rotated {
+			backups, err := logger.RotatedFiles(path)
+			if err != nil {
+				return fmt.Errorf("failed to list rotated logs of %s: %w", path, err)
+			}
+			for _, backup := range backups {
+				lines, err := logger.ReadLines(backup)
+				if err != nil {
+					return fmt.Errorf("failed to read %s: %w", backup, err)
+				}
+				history = append(history, matchingEntries(lines, filter)...)
+			}
+		}
+
+		tail := logger.NewTail(path)
+		tails = append(tails, tail)
+		lines, err := tail.Lines()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		history = append(history, matchingEntries(lines, filter)...)
+	}
+
+	if len(paths) > 1 || opts.rotated {
+		sort.SliceStable(history, func(i, j int) bool { return history[i].Time.Before(history[j].Time) })
+	}
+	if opts.lines > 0 && len(history) > opts.lines {
+		history = history[len(history)-opts.lines:]
+	}
+	for _, entry := range history {
+		fmt.Println(formatLogEntry(entry, color))
+	}
+
+	if !opts.follow {
+		return nil
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	ticker := time.NewTicker(logsPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sigChan:
+			return nil
+		case <-ticker.C:
+		}
+
+		for _, tail := range tails {
+			lines, err := tail.Lines()
+			if err != nil {
+				return err
+			}
+			for _, entry := range matchingEntries(lines, filter) {
+				fmt.Println(formatLogEntry(entry, color))
+			}
+		}
+	}
+}
+
+// matchingEntries parses lines and keeps the entries passing filter; lines that are
+// not log entries (stack trace continuations) are dropped
+func matchingEntries(lines []string, filter logger.Filter) []logger.Entry {
+	var entries []logger.Entry
+	for _, line := range lines {
+		entry, ok := logger.ParseLine(line)
+		if ok && filter.Match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// formatLogEntry renders an entry as "time LEVEL [pkg] message key=value ..."
+func formatLogEntry(e logger.Entry, color bool) string {
+	const (
+		cyan   = "\033[36m"
+		green  = "\033[32m"
+		red    = "\033[31m"
+		yellow = "\033[33m"
+		bold   = "\033[1m"
+		dim    = "\033[2m"
+		reset  = "\033[0m"
+	)
+	paint := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return code + s + reset
+	}
+
+	var b strings.Builder
+	if !e.Time.IsZero() {
+		b.WriteString(paint(dim, e.Time.Local().Format("2006-01-02 15:04:05.000")) + " ")
+	}
+
+	levelText := "-----"
+	if e.Level != zerolog.NoLevel {
+		levelText = strings.ToUpper(e.Level.String())
+	}
+	levelText = fmt.Sprintf("%-5s", levelText)
+	switch {
+	case e.Level == zerolog.NoLevel || e.Level <= zerolog.DebugLevel:
+		levelText = paint(dim, levelText)
+	case e.Level == zerolog.InfoLevel:
+		levelText = paint(green, levelText)
+	case e.Level == zerolog.WarnLevel:
+		levelText = paint(yellow, levelText)
+	default:
+		levelText = paint(bold+red, levelText)
+	}
+	b.WriteString(levelText)
+
+	if pkg := e.Fields["pkg"]; pkg != "" {
+		b.WriteString(" " + paint(cyan, "["+pkg+"]"))
+	}
+	if e.Message != "" {
+		b.WriteString(" " + e.Message)
+	}
+
+	keys := make([]string, 0, len(e.Fields))
+	for key := range e.Fields {
+		if key != "pkg" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := e.Fields[key]
+		if value == "" || strings.ContainsAny(value, " \t\n\"") {
+			value = strconv.Quote(value)
+		}
+		b.WriteString(" " + paint(cyan, key+"=") + value)
+	}
+	return b.String()
+}
+
+// isTerminal reports whether f is a terminal rather than a pipe or file
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
@@ -43,9 +43,10 @@ type StepConfig struct {
 
 // AgentOverride allows overriding agent settings per-pipeline
 type AgentOverride struct {
-	Tool    string `yaml:"tool"`
-	Model   string `yaml:"model"`
-	Version string `yaml:"version"`
+	Tool    string            `yaml:"tool"`
+	Model   string            `yaml:"model"`
+	Version string            `yaml:"version"`
+	Env     map[string]string `yaml:"env"` // Extra agent environment, layered over agent.default_env
 }
 
 // LoadPipelineFile loads and validates a pipeline YAML file
@@ -150,6 +151,9 @@ func (p *PipelineFileConfig) ToStepInputs(appCfg *config.AppConfig, cliVars map[
 			ToolOptions:    toolOptions,
 			FlagFormat:     appCfg.Agent.FlagFormat,
 		}
+		if p.Agent != nil {
+			agentConfig.Env = p.Agent.Env
+		}
 
 		inputs[i] = protocol.StepInput{
 			StepID:      step.ID,
@@ -0,0 +1,214 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/noldarim/noldarim/internal/orchestrator/services"
+)
+
+type projectExportOptions struct {
+	configPath string
+	projectID  string
+	output     string
+}
+
+type projectImportOptions struct {
+	configPath string
+	repoPath   string
+}
+
+// projectCommand dispatches project subcommands
+func projectCommand(args []string) error {
+	if len(args) == 0 {
+		return projectUsage()
+	}
+
+	subcommand := args[0]
+	subargs := args[1:]
+
+	switch subcommand {
+	case "export":
+		return projectExportCommand(subargs)
+	case "import":
+		return projectImportCommand(subargs)
+	case "help", "-h", "--help":
+		return projectUsage()
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown project subcommand: %s\n\n", subcommand)
+		return projectUsage()
+	}
+}
+
+func projectUsage() error {
+	fmt.Printf(`Usage: %s project <subcommand> [arguments]
+
+Subcommands:
+  export --id <project-id>   Write the project's settings and pipelines as JSON (secrets are left out)
+  import <file>              Recreate a project from an export (use --repo-path if the repository moved)
+  help                       Show this help message
+
+Examples:
+  %s project export --id project-123 > project.json
+  %s project import project.json
+  %s project import project.json --repo-path ~/src/myrepo
+
+`, appName, appName, appName, appName)
+	return nil
+}
+
+func projectExportCommand(args []string) error {
+	opts := &projectExportOptions{}
+	fs := flag.NewFlagSet("project export", flag.ExitOnError)
+	fs.StringVar(&opts.configPath, "config", "config.yaml", "Path to config file")
+	fs.StringVar(&opts.projectID, "id", "", "ID of the project to export")
+	fs.StringVar(&opts.output, "o", "", "Write the export to a file instead of stdout")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if opts.projectID == "" {
+		return fmt.Errorf("--id is required (see '%s projects')", appName)
+	}
+
+	cfg, err := config.NewConfig(opts.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dataService, err := services.NewDataService(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer dataService.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	export, err := dataService.ExportProject(ctx, opts.projectID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode export: %w", err)
+	}
+	data = append(data, '\n')
+
+	if opts.output != "" {
+		if err := os.WriteFile(opts.output, data, 0644); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+	} else if _, err := os.Stdout.Write(data); err != nil {
+		return err
+	}
+
+	// Status goes to stderr so stdout stays valid JSON
+	if len(export.OmittedSecrets) > 0 {
+		fmt.Fprintf(os.Stderr, "Left out secret env vars: %s\n", strings.Join(export.OmittedSecrets, ", "))
+	}
+	return nil
+}
+
+func projectImportCommand(args []string) error {
+	opts := &projectImportOptions{}
+	fs := flag.NewFlagSet("project import", flag.ExitOnError)
+	fs.StringVar(&opts.configPath, "config", "config.yaml", "Path to config file")
+	fs.StringVar(&opts.repoPath, "repo-path", "", "Repository path on this machine (defaults to the exported path)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	remaining := fs.Args()
+	if len(remaining) == 0 {
+		return fmt.Errorf("usage: %s project import <file> [--repo-path <path>]", appName)
+	}
+	file := remaining[0]
+	// Allow flags after the file name too
+	if err := fs.Parse(remaining[1:]); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read export: %w", err)
+	}
+	var export services.ProjectExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("failed to parse export %s: %w", file, err)
+	}
+	if err := services.ValidateProjectExport(&export); err != nil {
+		return fmt.Errorf("invalid export %s: %w", file, err)
+	}
+
+	repoPath := opts.repoPath
+	if repoPath == "" {
+		repoPath, err = resolveImportRepoPath(export.RepositoryPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.NewConfig(opts.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dataService, err := services.NewDataService(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer dataService.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	project, err := dataService.ImportProject(ctx, &export, repoPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported project %q as %s (%d pipelines)\n", project.Name, project.ID, len(export.Pipelines))
+	fmt.Printf("  Repository: %s\n", project.RepositoryPath)
+	if len(export.OmittedSecrets) > 0 {
+		fmt.Printf("  Set these env vars again, they were not exported: %s\n", strings.Join(export.OmittedSecrets, ", "))
+	}
+	return nil
+}
+
+// resolveImportRepoPath keeps the exported repository path when it exists on this machine,
+// otherwise asks for one when stdin is a terminal
+func resolveImportRepoPath(exportedPath string) (string, error) {
+	if exportedPath != "" {
+		if info, err := os.Stat(exportedPath); err == nil && info.IsDir() {
+			return exportedPath, nil
+		}
+	}
+
+	stat, err := os.Stdin.Stat()
+	if err != nil || stat.Mode()&os.ModeCharDevice == 0 {
+		return "", fmt.Errorf("repository path %q does not exist here; pass --repo-path", exportedPath)
+	}
+
+	fmt.Printf("Repository path %q does not exist on this machine.\nRepository path: ", exportedPath)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("no repository path given")
+	}
+	repoPath := strings.TrimSpace(line)
+	if repoPath == "" {
+		return "", fmt.Errorf("no repository path given")
+	}
+	return repoPath, nil
+}
@@ -223,16 +223,18 @@ waitForPipeline:
 	}
 
 	// Run the pipeline view TUI - pass channels for cancellation support
-	return runPipelineView(ctx, dataService, runID, len(steps), cmdChan, eventChan)
+	return runPipelineView(ctx, dataService, runID, len(steps), cfg.Agent.TokenBudget, cmdChan, eventChan)
 }
 
 // runPipelineView starts the Bubble Tea program for pipeline execution display
-func runPipelineView(ctx context.Context, dataService *services.DataService, runID string, stepCount int, cmdChan chan<- protocol.Command, eventChan <-chan protocol.Event) error {
+func runPipelineView(ctx context.Context, dataService *services.DataService, runID string, stepCount, tokenBudget int, cmdChan chan<- protocol.Command, eventChan <-chan protocol.Event) error {
 	// Use default size - Bubble Tea will send WindowSizeMsg with actual dimensions
 	width, height := 80, 24
 
 	// Track activity count for incremental updates
 	lastActivityCount := 0
+	// Model of the run's first AI output, priced in the token display
+	runModel := ""
 
 	// Create data fetcher that polls the database
 	fetcher := func(fetchCtx context.Context) (*pipelineview.DataMsg, error) {
@@ -249,8 +251,10 @@ func runPipelineView(ctx context.Context, dataService *services.DataService, run
 		steps := make([]stepprogress.Step, len(run.StepResults))
 		for i, step := range run.StepResults {
 			steps[i] = stepprogress.Step{
-				Name:   step.StepID,
-				Status: convertStepStatus(step.Status),
+				Name:        step.StepID,
+				Status:      convertStepStatus(step.Status),
+				StartedAt:   step.StartedAt,
+				CompletedAt: step.CompletedAt,
 			}
 		}
 		if len(steps) == 0 {
@@ -258,6 +262,22 @@ func runPipelineView(ctx context.Context, dataService *services.DataService, run
 		}
 		data.Steps = steps
 
+		// Fetch activities for all steps in this run
+		dbActivities, err := dataService.GetAIActivityByRunID(fetchCtx, runID)
+		if err == nil && len(dbActivities) > lastActivityCount {
+			// Parse records into collapsible activity groups
+			data.Groups = collapsiblefeed.ParseRecords(dbActivities)
+			lastActivityCount = len(dbActivities)
+		}
+		if runModel == "" {
+			for _, r := range dbActivities {
+				if r.EventType == models.AIEventAIOutput && r.Model != "" {
+					runModel = r.Model
+					break
+				}
+			}
+		}
+
 		// Build tokens from step results
 		var totalIn, totalOut, cacheRead, cacheCreate int
 		for _, step := range run.StepResults {
@@ -271,14 +291,7 @@ func runPipelineView(ctx context.Context, dataService *services.DataService, run
 			OutputTokens:      totalOut,
 			CacheReadTokens:   cacheRead,
 			CacheCreateTokens: cacheCreate,
-		}
-
-		// Fetch activities for all steps in this run
-		dbActivities, err := dataService.GetAIActivityByRunID(fetchCtx, runID)
-		if err == nil && len(dbActivities) > lastActivityCount {
-			// Parse records into collapsible activity groups
-			data.Groups = collapsiblefeed.ParseRecords(dbActivities)
-			lastActivityCount = len(dbActivities)
+			Model:             runModel,
 		}
 
 		// Check final status
@@ -302,7 +315,7 @@ func runPipelineView(ctx context.Context, dataService *services.DataService, run
 	}
 
 	// Create the pipeline view model
-	model := pipelineview.New(width, height, fetcher)
+	model := pipelineview.New(width, height, fetcher).SetTokenBudget(tokenBudget)
 
 	// Initialize with step count
 	initialSteps := make([]stepprogress.Step, stepCount)
@@ -433,6 +446,7 @@ func buildPipelineSummary(run *models.PipelineRun, steps []stepprogress.Step, to
 	// Aggregate diff stats from step results
 	for _, step := range run.StepResults {
 		data.FilesChanged += step.FilesChanged
+		data.SignificantFilesChanged += step.SignificantFilesChanged
 		data.Insertions += step.Insertions
 		data.Deletions += step.Deletions
 	}
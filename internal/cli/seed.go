@@ -0,0 +1,91 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/noldarim/noldarim/internal/demodata"
+	"github.com/noldarim/noldarim/internal/orchestrator/services"
+)
+
+type seedOptions struct {
+	configPath string
+	demo       bool
+	clear      bool
+	repoPath   string
+}
+
+func seedCommand(args []string) error {
+	opts := &seedOptions{}
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	fs.StringVar(&opts.configPath, "config", "config.yaml", "Path to config file")
+	fs.BoolVar(&opts.demo, "demo", false, "Create (or recreate) the demo project with sample tasks and activity")
+	fs.BoolVar(&opts.clear, "clear", false, "Remove the demo data")
+	fs.StringVar(&opts.repoPath, "repo", "", "Repository path of the demo project (default: current directory)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if opts.demo == opts.clear {
+		return fmt.Errorf("specify exactly one of --demo or --clear")
+	}
+
+	return seedDemo(opts)
+}
+
+func seedDemo(opts *seedOptions) error {
+	// Load configuration
+	cfg, err := config.NewConfig(opts.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Create data service (just DB access, no orchestrator)
+	dataService, err := services.NewDataService(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer dataService.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if opts.clear {
+		if err := demodata.Clear(ctx, dataService); err != nil {
+			return err
+		}
+		fmt.Println("Demo data removed.")
+		return nil
+	}
+
+	repoPath := opts.repoPath
+	if repoPath == "" {
+		if repoPath, err = os.Getwd(); err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+	}
+	if repoPath, err = filepath.Abs(repoPath); err != nil {
+		return fmt.Errorf("invalid --repo: %w", err)
+	}
+
+	summary, err := demodata.Seed(ctx, dataService, repoPath, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Seeded project %s (%s): %d tasks, %d pipeline run, %d activity records.\n",
+		demodata.ProjectID, repoPath, summary.Tasks, summary.PipelineRuns, summary.Activities)
+	fmt.Println("\nOpen it in the TUI:")
+	fmt.Println("  make run")
+	fmt.Printf("\nRemove it again with:\n  %s seed --clear\n", appName)
+	return nil
+}
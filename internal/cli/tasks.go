@@ -8,7 +8,6 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"sort"
 	"strings"
 	"time"
 
@@ -37,6 +36,8 @@ func taskCommand(args []string) error {
 		return taskShowCommand(subargs)
 	case "list":
 		return taskListCommand(subargs)
+	case "label":
+		return taskLabelCommand(subargs)
 	case "help", "-h", "--help":
 		return taskUsage()
 	default:
@@ -50,15 +51,19 @@ func taskUsage() error {
 
 Subcommands:
   show <task-id>   Show detailed task information including tokens, commands, and diff
-  list             List all tasks (use --project to filter)
+  list             List all tasks (use --project and --label to filter)
+  label <task-id> [+label|-label ...]
+                   Add (+) or remove (-) task labels, then print the task's labels
   help             Show this help message
 
 Examples:
   %s task show abc123
   %s task show abc123 --diff
   %s task list --project myproject
+  %s task list --label bug,ui          # tasks labelled bug AND ui
+  %s task label abc123 +bug -triage
 
-`, appName, appName, appName, appName)
+`, appName, appName, appName, appName, appName, appName)
 	return nil
 }
 
@@ -136,15 +141,16 @@ func showTask(taskID string, opts *taskShowOptions) error {
 }
 
 func showTaskDetails(ctx context.Context, dataService *services.DataService, task *models.Task, opts *taskShowOptions) error {
-	// Get AI activity records for token aggregation
-	records, err := dataService.GetAIActivityByTask(ctx, task.ID)
+	// Aggregate token usage, streaming the records so long tasks are not loaded at once
+	stats := &TokenStats{ToolCalls: make([]ToolCallInfo, 0)}
+	err := dataService.StreamAIActivityByTask(ctx, task.ID, func(r *models.AIActivityRecord) error {
+		stats.add(r)
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to get activity records: %w", err)
 	}
 
-	// Aggregate token usage
-	stats := aggregateTokenStats(records)
-
 	// Print task header
 	fmt.Println(strings.Repeat("=", 60))
 	fmt.Printf("TASK: %s\n", task.Title)
@@ -261,43 +267,31 @@ func (ts *TokenStats) TotalTokens() int {
 	return ts.InputTokens + ts.OutputTokens
 }
 
-func aggregateTokenStats(records []*models.AIActivityRecord) *TokenStats {
-	stats := &TokenStats{
-		ToolCalls: make([]ToolCallInfo, 0),
-	}
-
-	// Sort records by timestamp
-	sort.Slice(records, func(i, j int) bool {
-		return records[i].Timestamp.Before(records[j].Timestamp)
-	})
-
-	for _, r := range records {
-		// Aggregate tokens (only count AI output messages to avoid double-counting)
-		if r.EventType == models.AIEventAIOutput {
-			stats.InputTokens += r.InputTokens
-			stats.OutputTokens += r.OutputTokens
-			stats.CacheReadTokens += r.CacheReadTokens
-			stats.CacheCreateTokens += r.CacheCreateTokens
-
-			// Capture model from first record that has it
-			if stats.Model == "" && r.Model != "" {
-				stats.Model = r.Model
-			}
-		}
-
-		// Track tool calls
-		if r.EventType == models.AIEventToolUse && r.ToolName != "" {
-			stats.ToolCalls = append(stats.ToolCalls, ToolCallInfo{
-				ToolName:  r.ToolName,
-				FilePath:  r.FilePath,
-				Summary:   r.ToolInputSummary,
-				Success:   r.ToolSuccess,
-				Timestamp: r.Timestamp,
-			})
+// add folds one record into the stats; records are expected in timestamp order
+func (ts *TokenStats) add(r *models.AIActivityRecord) {
+	// Aggregate tokens (only count AI output messages to avoid double-counting)
+	if r.EventType == models.AIEventAIOutput {
+		ts.InputTokens += r.InputTokens
+		ts.OutputTokens += r.OutputTokens
+		ts.CacheReadTokens += r.CacheReadTokens
+		ts.CacheCreateTokens += r.CacheCreateTokens
+
+		// Capture model from first record that has it
+		if ts.Model == "" && r.Model != "" {
+			ts.Model = r.Model
 		}
 	}
 
-	return stats
+	// Track tool calls
+	if r.EventType == models.AIEventToolUse && r.ToolName != "" {
+		ts.ToolCalls = append(ts.ToolCalls, ToolCallInfo{
+			ToolName:  r.ToolName,
+			FilePath:  r.FilePath,
+			Summary:   r.ToolInputSummary,
+			Success:   r.ToolSuccess,
+			Timestamp: r.Timestamp,
+		})
+	}
 }
 
 // DiffStats holds parsed git diff statistics
@@ -395,10 +389,12 @@ func taskListCommand(args []string) error {
 	fs := flag.NewFlagSet("task list", flag.ExitOnError)
 	fs.StringVar(&configPath, "config", "config.yaml", "Path to config file")
 	fs.StringVar(&projectID, "project", "", "Filter by project ID")
+	labelFilter := fs.String("label", "", "Comma-separated labels; only tasks carrying all of them are listed")
 
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
+	labels := strings.Split(*labelFilter, ",")
 
 	cfg, err := config.NewConfig(configPath)
 	if err != nil {
@@ -431,18 +427,19 @@ func taskListCommand(args []string) error {
 			continue
 		}
 
-		tasks, err := dataService.LoadTasks(ctx, project.ID)
+		tasks, err := dataService.LoadTasksByLabel(ctx, project.ID, labels...)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to load tasks for project %s: %v\n", project.ID, err)
 			continue
 		}
 
 		for _, task := range tasks {
-			fmt.Printf("%-12s %-12s %-30s %s\n",
+			fmt.Printf("%-12s %-12s %-30s %s%s\n",
 				formatStatus(task.Status),
 				truncate(task.ID, 12),
 				truncate(task.Title, 30),
 				task.CreatedAt.Format("2006-01-02 15:04"),
+				formatLabels(task.Labels),
 			)
 			count++
 		}
@@ -453,3 +450,96 @@ func taskListCommand(args []string) error {
 
 	return nil
 }
+
+func taskLabelCommand(args []string) error {
+	var configPath string
+	fs := flag.NewFlagSet("task label", flag.ExitOnError)
+	fs.StringVar(&configPath, "config", "config.yaml", "Path to config file")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	remaining := fs.Args()
+	if len(remaining) == 0 {
+		return fmt.Errorf("usage: %s task label <task-id> [+label|-label ...]", appName)
+	}
+	taskID := remaining[0]
+
+	var add, remove []string
+	for _, arg := range remaining[1:] {
+		switch {
+		case strings.HasPrefix(arg, "+"):
+			add = append(add, arg[1:])
+		case strings.HasPrefix(arg, "-"):
+			remove = append(remove, arg[1:])
+		default:
+			return fmt.Errorf("label %q must start with + (add) or - (remove)", arg)
+		}
+	}
+
+	cfg, err := config.NewConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dataService, err := services.NewDataService(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer dataService.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Pipeline runs are tasks too (see PipelineService.CreateTask) and carry their own labels
+	removeLabels, addLabels := dataService.RemoveTaskLabels, dataService.AddTaskLabels
+	reload := func() ([]string, error) {
+		task, err := dataService.GetTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		return task.Labels, nil
+	}
+	if _, err := dataService.GetTask(ctx, taskID); err != nil {
+		run, runErr := dataService.GetPipelineRun(ctx, taskID)
+		if runErr != nil || run == nil {
+			return fmt.Errorf("task not found: %w", err)
+		}
+		removeLabels, addLabels = dataService.RemovePipelineRunLabels, dataService.AddPipelineRunLabels
+		reload = func() ([]string, error) {
+			run, err := dataService.GetPipelineRun(ctx, taskID)
+			if err != nil {
+				return nil, err
+			}
+			if run == nil {
+				return nil, fmt.Errorf("pipeline run %s not found", taskID)
+			}
+			return run.Labels, nil
+		}
+	}
+	if err := removeLabels(ctx, taskID, remove...); err != nil {
+		return fmt.Errorf("failed to remove labels: %w", err)
+	}
+	if err := addLabels(ctx, taskID, add...); err != nil {
+		return fmt.Errorf("failed to add labels: %w", err)
+	}
+
+	labels, err := reload()
+	if err != nil {
+		return fmt.Errorf("failed to reload task: %w", err)
+	}
+	if len(labels) == 0 {
+		fmt.Printf("%s has no labels\n", taskID)
+		return nil
+	}
+	fmt.Printf("%s:%s\n", taskID, formatLabels(labels))
+	return nil
+}
+
+// formatLabels renders labels as " #a #b", or "" when there are none
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	return " #" + strings.Join(labels, " #")
+}
@@ -7,12 +7,16 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
+
+	"github.com/noldarim/noldarim/internal/rotate"
 )
 
 // AppConfig holds all application configuration.
@@ -24,10 +28,29 @@ type AppConfig struct {
 	Container   ContainerConfig   `mapstructure:"container"`
 	Git         GitConfig         `mapstructure:"git"`
 	Server      ServerConfig      `mapstructure:"server"`
+	RPC         RPCConfig         `mapstructure:"rpc"`
 	Claude      ClaudeConfig      `mapstructure:"claude"`
 	Agent       AgentConfig       `mapstructure:"agent"`
 	Hooks       HooksConfig       `mapstructure:"hooks"`
 	Pipeline    PipelineConfig    `mapstructure:"pipeline"`
+	Retention   RetentionConfig   `mapstructure:"retention"`
+	TUI         TUIConfig         `mapstructure:"tui"`
+	Keys        map[string]string `mapstructure:"keys"`   // TUI keybinding overrides: action → comma-separated keys
+	Editor      string            `mapstructure:"editor"` // Command used to open files from the TUI; overrides $VISUAL/$EDITOR
+}
+
+// TUIConfig holds TUI appearance settings
+type TUIConfig struct {
+	// ToolStyles overrides how tool names render in the activity feeds, keyed by tool name
+	// (case-insensitive); "default" styles tools without an entry
+	ToolStyles map[string]ToolStyle `mapstructure:"tool_styles"`
+}
+
+// ToolStyle is the icon and color shown next to a tool's name. Color is an ANSI color
+// number ("214") or a hex color ("#ff8800"); empty fields keep the built-in style.
+type ToolStyle struct {
+	Icon  string `mapstructure:"icon"`
+	Color string `mapstructure:"color"`
 }
 
 // DatabaseConfig holds PostgreSQL database configuration.
@@ -38,6 +61,8 @@ type DatabaseConfig struct {
 	Password string `mapstructure:"password"`
 	Database string `mapstructure:"database"`
 	SSLMode  string `mapstructure:"ssl_mode"`
+	// InsertBatchSize is how many rows one INSERT of a bulk save writes (default: 500)
+	InsertBatchSize int `mapstructure:"insert_batch_size"`
 }
 
 // LogConfig holds comprehensive logging configuration
@@ -163,6 +188,28 @@ type GitConfig struct {
 	WorktreeBasePath                  string `mapstructure:"worktree_base_path"`
 	DefaultBranch                     string `mapstructure:"default_branch"`
 	CreateGitRepoForProjectIfNotExist bool   `mapstructure:"create_git_repo_for_project_if_not_exist"`
+	MaxWorktrees                      int    `mapstructure:"max_worktrees"` // Max task worktrees per repository before eviction (0 = unlimited)
+
+	AuthorName  string `mapstructure:"author_name"`  // Author and committer name of commits noldarim makes; empty = from git config
+	AuthorEmail string `mapstructure:"author_email"` // Author and committer email of commits noldarim makes; empty = from git config
+
+	CommitTemplate string `mapstructure:"commit_template"` // Task commit message template ({{.TaskTitle}}, {{.TaskID}}, {{.FilesChanged}}, {{.Tokens}}); empty = default
+
+	ProtectedBranches []string `mapstructure:"protected_branches"` // Glob patterns ("main", "release/*") of branches never created, checked out in a worktree or committed to; task-<id> branches are exempt
+
+	CleanupOnDelete GitCleanupConfig `mapstructure:"cleanup_on_delete"`
+
+	DiffStreamInterval time.Duration `mapstructure:"diff_stream_interval"`  // How often the diff of a running task is re-captured (0 = only when the step finishes)
+	DiffStreamMaxBytes int           `mapstructure:"diff_stream_max_bytes"` // Streamed diffs larger than this are sent as stats only
+}
+
+// GitCleanupConfig controls what is removed along with a deleted task. Without Force the
+// worktree is kept when it has uncommitted changes and the branch when it is not merged
+// into the repository's current branch; the checked-out branch is never deleted.
+type GitCleanupConfig struct {
+	Worktree bool `mapstructure:"worktree"` // Remove the task's worktree
+	Branch   bool `mapstructure:"branch"`   // Delete the task's branch
+	Force    bool `mapstructure:"force"`    // Also discard uncommitted changes and unmerged commits
 }
 
 // ServerConfig holds server configuration.
@@ -172,6 +219,12 @@ type ServerConfig struct {
 	AllowedOrigins []string `mapstructure:"allowed_origins"` // Empty = allow all (development); set for production
 }
 
+// RPCConfig holds configuration for the JSON-RPC control socket.
+type RPCConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	SocketPath string `mapstructure:"socket_path"` // Unix socket the RPC server listens on
+}
+
 // ClaudeConfig holds Claude-related configuration.
 type ClaudeConfig struct {
 	ClaudeJSONHostPath string `mapstructure:"claude_json_host_path"`
@@ -180,12 +233,19 @@ type ClaudeConfig struct {
 // AgentConfig holds default AI agent configuration for task processing.
 // This defines the default behavior when a task is created without explicit agent configuration.
 type AgentConfig struct {
-	DefaultTool    string                 `mapstructure:"default_tool"`    // Tool name: "claude", "gemini", etc.
-	DefaultVersion string                 `mapstructure:"default_version"` // Tool version: "4.5"
-	PromptTemplate string                 `mapstructure:"prompt_template"` // Template with {{.variable}} placeholders
-	Variables      map[string]string      `mapstructure:"variables"`       // Default values for template variables
-	ToolOptions    map[string]interface{} `mapstructure:"tool_options"`    // CLI flags and options (e.g., model, custom flags)
-	FlagFormat     string                 `mapstructure:"flag_format"`     // Format for CLI flags: "space" (--flag value) or "equals" (--flag=value)
+	DefaultTool    string                 `mapstructure:"default_tool"`     // Tool name: "claude", "gemini", etc.
+	DefaultVersion string                 `mapstructure:"default_version"`  // Tool version: "4.5"
+	PromptTemplate string                 `mapstructure:"prompt_template"`  // Template with {{.variable}} placeholders
+	Variables      map[string]string      `mapstructure:"variables"`        // Default values for template variables
+	ToolOptions    map[string]interface{} `mapstructure:"tool_options"`     // CLI flags and options (e.g., model, custom flags)
+	FlagFormat     string                 `mapstructure:"flag_format"`      // Format for CLI flags: "space" (--flag value) or "equals" (--flag=value)
+	TokenBudget    int                    `mapstructure:"token_budget"`     // Default input+output token budget per task (0 = unlimited)
+	HardBudget     bool                   `mapstructure:"hard_budget"`      // Cancel a task once it exceeds its token budget
+	IdleTimeout    time.Duration          `mapstructure:"idle_timeout"`     // Report an agent that writes no transcript lines for this long (0 = never)
+	FinalizeOnIdle bool                   `mapstructure:"finalize_on_idle"` // Stop an idle agent and commit what it has done so far
+	DefaultEnv     map[string]string      `mapstructure:"default_env"`      // Environment for every agent process; task env wins per variable
+
+	MaxConcurrentTasks int `mapstructure:"max_concurrent_tasks"` // Running tasks per project before new ones are queued (0 = unlimited)
 }
 
 // HooksConfig holds configuration for Claude Code hooks.
@@ -200,6 +260,32 @@ type PipelineConfig struct {
 	PromptSuffix string `mapstructure:"prompt_suffix"` // Default suffix appended to all step prompts (e.g., summary instruction)
 }
 
+// RetentionConfig holds rotation and pruning settings for files written by the orchestrator.
+type RetentionConfig struct {
+	JanitorInterval time.Duration  `mapstructure:"janitor_interval"` // How often rotated files are pruned (0 = disabled)
+	EventLog        RotationConfig `mapstructure:"event_log"`
+	Transcripts     RotationConfig `mapstructure:"transcripts"`
+}
+
+// RotationConfig describes when a file is rotated and how long rotated files are kept.
+type RotationConfig struct {
+	Path        string        `mapstructure:"path"`         // Active file, or a directory of files (empty = not managed)
+	MaxSizeMB   int           `mapstructure:"max_size_mb"`  // Rotate when the file reaches this size (0 = unlimited)
+	RotateEvery time.Duration `mapstructure:"rotate_every"` // Rotate when the file is older than this (0 = never)
+	MaxAgeDays  int           `mapstructure:"max_age_days"` // Delete rotated files older than this (0 = keep)
+	MaxBackups  int           `mapstructure:"max_backups"`  // Keep at most this many rotated files (0 = unlimited)
+}
+
+// Policy converts the configuration into a rotation policy
+func (r RotationConfig) Policy() rotate.Policy {
+	return rotate.Policy{
+		MaxSize:     int64(r.MaxSizeMB) * 1024 * 1024,
+		RotateEvery: r.RotateEvery,
+		MaxAge:      time.Duration(r.MaxAgeDays) * 24 * time.Hour,
+		MaxBackups:  r.MaxBackups,
+	}
+}
+
 // NewConfig creates a new AppConfig by reading from a file, environment variables,
 // and applying defaults. This function replaces the global Init().
 func NewConfig(configPath string) (*AppConfig, error) {
@@ -244,6 +330,7 @@ func NewConfig(configPath string) (*AppConfig, error) {
 
 	// Expand paths that may contain ~ or environment variables
 	cfg.expandPaths()
+	cfg.normalizeAgentEnv()
 
 	// Validate the final configuration
 	if err := cfg.validate(); err != nil {
@@ -264,6 +351,8 @@ func defaultConfig() AppConfig {
 			Password: "noldarim",
 			Database: "noldarim",
 			SSLMode:  "disable",
+
+			InsertBatchSize: 500,
 		},
 		Log: LogConfig{
 			Level:  "INFO",
@@ -294,6 +383,7 @@ func defaultConfig() AppConfig {
 				"git":          "INFO",
 				"container":    "INFO",
 				"api":          "INFO",
+				"rpc":          "INFO",
 			},
 			Context: LogContextConfig{
 				IncludeCaller:     true,
@@ -353,11 +443,17 @@ func defaultConfig() AppConfig {
 			WorktreeBasePath:                  "./worktrees",
 			DefaultBranch:                     "main",
 			CreateGitRepoForProjectIfNotExist: true,
+			DiffStreamInterval:                10 * time.Second,
+			DiffStreamMaxBytes:                256 * 1024,
 		},
 		Server: ServerConfig{
 			Host: "127.0.0.1",
 			Port: 8080,
 		},
+		RPC: RPCConfig{
+			Enabled:    false,
+			SocketPath: "~/.noldarim/noldarim.sock",
+		},
 		Claude: ClaudeConfig{
 			ClaudeJSONHostPath: "$HOME/.claude.json",
 		},
@@ -396,6 +492,18 @@ When you complete this task, end your response with a summary in this exact form
 ---END SUMMARY---
 `,
 		},
+		Retention: RetentionConfig{
+			JanitorInterval: time.Hour,
+			EventLog: RotationConfig{
+				MaxSizeMB:  100,
+				MaxAgeDays: 30,
+				MaxBackups: 10,
+			},
+			Transcripts: RotationConfig{
+				MaxSizeMB:  50,
+				MaxAgeDays: 30,
+			},
+		},
 	}
 }
 
@@ -411,12 +519,38 @@ func (c *AppConfig) expandPaths() {
 		c.Git.WorktreeBasePath = expandPath(c.Git.WorktreeBasePath)
 	}
 
+	// Expand retention paths
+	if c.Retention.EventLog.Path != "" {
+		c.Retention.EventLog.Path = expandPath(c.Retention.EventLog.Path)
+	}
+	if c.Retention.Transcripts.Path != "" {
+		c.Retention.Transcripts.Path = expandPath(c.Retention.Transcripts.Path)
+	}
+
+	// Expand RPC socket path
+	if c.RPC.SocketPath != "" {
+		c.RPC.SocketPath = expandPath(c.RPC.SocketPath)
+	}
+
 	// Expand Docker host path
 	if c.Container.DockerHost != "" {
 		c.Container.DockerHost = expandPath(c.Container.DockerHost)
 	}
 }
 
+// normalizeAgentEnv upper-cases agent.default_env names, which viper lowercases, and
+// expands $VAR references in values so secrets can stay out of the config file
+func (c *AppConfig) normalizeAgentEnv() {
+	if len(c.Agent.DefaultEnv) == 0 {
+		return
+	}
+	env := make(map[string]string, len(c.Agent.DefaultEnv))
+	for name, value := range c.Agent.DefaultEnv {
+		env[strings.ToUpper(name)] = os.ExpandEnv(value)
+	}
+	c.Agent.DefaultEnv = env
+}
+
 // expandPath expands ~ to home directory and environment variables
 func expandPath(path string) string {
 	if path == "" {
@@ -445,6 +579,9 @@ func (c *AppConfig) validate() error {
 	if c.Database.Database == "" {
 		return errors.New("database name is required")
 	}
+	if c.Database.InsertBatchSize < 0 {
+		return fmt.Errorf("database.insert_batch_size must be >= 0, got: %d", c.Database.InsertBatchSize)
+	}
 
 	validLogLevels := map[string]bool{
 		"DEBUG": true, "INFO": true, "WARN": true, "ERROR": true, "FATAL": true, "PANIC": true,
@@ -457,6 +594,33 @@ func (c *AppConfig) validate() error {
 		return errors.New("container default_image is required")
 	}
 
+	if c.Git.MaxWorktrees < 0 {
+		return fmt.Errorf("git.max_worktrees must be >= 0, got: %d", c.Git.MaxWorktrees)
+	}
+	if c.Git.DiffStreamInterval < 0 {
+		return fmt.Errorf("git.diff_stream_interval must be >= 0, got: %s", c.Git.DiffStreamInterval)
+	}
+	if c.Git.DiffStreamMaxBytes < 0 {
+		return fmt.Errorf("git.diff_stream_max_bytes must be >= 0, got: %d", c.Git.DiffStreamMaxBytes)
+	}
+	if err := ValidateGitAuthor(c.Git.AuthorName, c.Git.AuthorEmail); err != nil {
+		return fmt.Errorf("invalid git.author_name or git.author_email: %w", err)
+	}
+	for _, pattern := range c.Git.ProtectedBranches {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid git.protected_branches pattern %q: %w", pattern, err)
+		}
+	}
+
+	if c.Retention.JanitorInterval < 0 {
+		return fmt.Errorf("retention.janitor_interval must be >= 0, got: %s", c.Retention.JanitorInterval)
+	}
+	for name, r := range map[string]RotationConfig{"event_log": c.Retention.EventLog, "transcripts": c.Retention.Transcripts} {
+		if r.MaxSizeMB < 0 || r.MaxAgeDays < 0 || r.MaxBackups < 0 || r.RotateEvery < 0 {
+			return fmt.Errorf("retention.%s limits must be >= 0", name)
+		}
+	}
+
 	if c.Server.Port <= 0 || c.Server.Port > 65535 {
 		return fmt.Errorf("invalid server port: %d", c.Server.Port)
 	}
@@ -471,6 +635,48 @@ func (c *AppConfig) validate() error {
 	if c.Agent.FlagFormat != "" && c.Agent.FlagFormat != "space" && c.Agent.FlagFormat != "equals" {
 		return fmt.Errorf("agent.flag_format must be 'space' or 'equals', got: %s", c.Agent.FlagFormat)
 	}
+	if c.Agent.TokenBudget < 0 {
+		return fmt.Errorf("agent.token_budget must be >= 0, got: %d", c.Agent.TokenBudget)
+	}
+	if c.Agent.IdleTimeout < 0 {
+		return fmt.Errorf("agent.idle_timeout must be >= 0, got: %s", c.Agent.IdleTimeout)
+	}
+	if c.RPC.Enabled && c.RPC.SocketPath == "" {
+		return errors.New("rpc.socket_path is required when rpc.enabled is true")
+	}
+	if c.Agent.MaxConcurrentTasks < 0 {
+		return fmt.Errorf("agent.max_concurrent_tasks must be >= 0, got: %d", c.Agent.MaxConcurrentTasks)
+	}
+
+	return nil
+}
+
+// maxGitAuthorLength caps the length of git.author_name and git.author_email
+const maxGitAuthorLength = 256
+
+// Safe commit identity patterns: letters, digits, spaces and a little punctuation in names;
+// a plain local-part@domain address. Both end up in the environment of git commands.
+var (
+	gitAuthorNameRegex  = regexp.MustCompile(`^[\p{L}\p{N} ._'-]+$`)
+	gitAuthorEmailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+$`)
+)
+
+// ValidateGitAuthor checks a commit author name and email; empty values (keep the user's git
+// config) are valid
+func ValidateGitAuthor(name, email string) error {
+	if len(name) > maxGitAuthorLength {
+		return fmt.Errorf("author name too long: %d characters (max: %d)", len(name), maxGitAuthorLength)
+	}
+	if name != "" && (!gitAuthorNameRegex.MatchString(name) || strings.TrimSpace(name) == "") {
+		return fmt.Errorf("author name contains invalid characters: %q", name)
+	}
+
+	if len(email) > maxGitAuthorLength {
+		return fmt.Errorf("author email too long: %d characters (max: %d)", len(email), maxGitAuthorLength)
+	}
+	if email != "" && !gitAuthorEmailRegex.MatchString(email) {
+		return fmt.Errorf("invalid author email: %q", email)
+	}
 
 	return nil
 }
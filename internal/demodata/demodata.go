@@ -0,0 +1,333 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package demodata generates synthetic projects, tasks, pipeline runs and AI activity.
+// The dev TUI tools use the generators directly; Seed writes the same data to the
+// database so a fresh install has something to show. Every seeded ID starts with
+// IDPrefix, which is what makes seeding idempotent and Clear safe.
+package demodata
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+)
+
+const (
+	// IDPrefix tags every ID written by Seed
+	IDPrefix = "demo-"
+
+	// ProjectID is the ID of the seeded demo project
+	ProjectID = IDPrefix + "project"
+
+	// RunID is the ID of the seeded demo pipeline run
+	RunID = IDPrefix + "run-1"
+)
+
+// SmallDiff is a short single-file diff
+const SmallDiff = `diff --git a/styles/login.css b/styles/login.css
+index 1234567..abcdefg 100644
+--- a/styles/login.css
++++ b/styles/login.css
+@@ -10,3 +10,4 @@
+ .login-btn {
+-  padding: 8px;
++  padding: 12px 24px;
++  border-radius: 4px;
+ }`
+
+// rateLimitDiff is the change made by the demo pipeline run
+const rateLimitDiff = `diff --git a/api/middleware.go b/api/middleware.go
+index 3f4e2a1..9b8c7d6 100644
+--- a/api/middleware.go
++++ b/api/middleware.go
+@@ -1,8 +1,23 @@
+ package api
+ 
+-import "net/http"
++import (
++	"net/http"
++
++	"golang.org/x/time/rate"
++)
++
++// RateLimit rejects requests beyond rps per second with 429 Too Many Requests
++func RateLimit(rps int, next http.Handler) http.Handler {
++	limiter := rate.NewLimiter(rate.Limit(rps), rps)
++	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
++		if !limiter.Allow() {
++			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
++			return
++		}
++		next.ServeHTTP(w, r)
++	})
++}
+ 
+ func Logging(next http.Handler) http.Handler {
+ 	return next
+ }`
+
+// Project returns the demo project for the repository at repoPath
+func Project(repoPath string) models.Project {
+	return models.Project{
+		ID:             ProjectID,
+		Name:           "Demo Project",
+		Description:    "Sample project with synthetic tasks and activity (remove with: noldarim seed --clear)",
+		RepositoryPath: repoPath,
+	}
+}
+
+// Tasks returns a mix of pending, in-progress and completed tasks for projectID.
+// Task IDs are derived from projectID.
+func Tasks(projectID string, now time.Time) []models.Task {
+	tasks := []models.Task{
+		{
+			Title:       "Implement user authentication",
+			Description: "Add JWT-based authentication to the API",
+			Status:      models.TaskStatusInProgress,
+			CreatedAt:   now.Add(-48 * time.Hour),
+		},
+		{
+			Title:       "Fix login button styling",
+			Description: "Quick CSS fix for the login button on the homepage",
+			Status:      models.TaskStatusCompleted,
+			CreatedAt:   now.Add(-72 * time.Hour),
+			GitDiff:     SmallDiff,
+		},
+		{
+			Title:       "Write API documentation",
+			Description: "Document all API endpoints with OpenAPI spec",
+			Status:      models.TaskStatusPending,
+			CreatedAt:   now.Add(-24 * time.Hour),
+		},
+		{
+			Title:       "Fix memory leak in worker process",
+			Description: "Investigate and fix memory leak reported in production",
+			Status:      models.TaskStatusInProgress,
+			CreatedAt:   now.Add(-12 * time.Hour),
+		},
+		{
+			Title:       "Upgrade dependencies",
+			Description: "Update all npm packages to latest stable versions",
+			Status:      models.TaskStatusPending,
+			CreatedAt:   now.Add(-36 * time.Hour),
+		},
+	}
+	for i := range tasks {
+		tasks[i].ID = fmt.Sprintf("%s-task-%d", projectID, i+1)
+		tasks[i].ProjectID = projectID
+		tasks[i].LastUpdatedAt = tasks[i].CreatedAt
+	}
+	return tasks
+}
+
+// AIActivityRecords returns a session of tool calls for taskID starting at base.
+// Event IDs are derived from taskID so several tasks can be generated side by side.
+func AIActivityRecords(taskID string, base time.Time) []*models.AIActivityRecord {
+	records := make([]*models.AIActivityRecord, 0)
+	trueVal := true
+	sessionID := taskID + "-session"
+
+	// Session start
+	records = append(records, &models.AIActivityRecord{
+		EventID:   taskID + "-evt-001",
+		TaskID:    taskID,
+		SessionID: sessionID,
+		Timestamp: base,
+		EventType: models.AIEventSessionStart,
+	})
+
+	// Tool calls sequence
+	toolCalls := []struct {
+		name  string
+		input string
+		file  string
+	}{
+		{"Bash", "ls -la /workspace", ""},
+		{"Read", "/workspace/main.go", "/workspace/main.go"},
+		{"Grep", "func main", ""},
+		{"Read", "/workspace/config.yaml", "/workspace/config.yaml"},
+		{"Edit", "/workspace/main.go", "/workspace/main.go"},
+		{"Bash", "go build ./...", ""},
+		{"Bash", "go test ./...", ""},
+	}
+
+	for i, tc := range toolCalls {
+		// Tool use
+		records = append(records, &models.AIActivityRecord{
+			EventID:          fmt.Sprintf("%s-evt-%03d", taskID, i*2+2),
+			TaskID:           taskID,
+			SessionID:        sessionID,
+			Timestamp:        base.Add(time.Duration(i*2+1) * time.Second),
+			EventType:        models.AIEventToolUse,
+			ToolName:         tc.name,
+			ToolInputSummary: tc.input,
+			FilePath:         tc.file,
+		})
+
+		// Tool result
+		records = append(records, &models.AIActivityRecord{
+			EventID:        fmt.Sprintf("%s-evt-%03d", taskID, i*2+3),
+			TaskID:         taskID,
+			SessionID:      sessionID,
+			Timestamp:      base.Add(time.Duration(i*2+2) * time.Second),
+			EventType:      models.AIEventToolResult,
+			ToolName:       tc.name,
+			ToolSuccess:    &trueVal,
+			FilePath:       tc.file,
+			ContentPreview: "Command executed successfully",
+		})
+	}
+
+	// Session end
+	records = append(records, &models.AIActivityRecord{
+		EventID:      taskID + "-evt-final",
+		TaskID:       taskID,
+		SessionID:    sessionID,
+		Timestamp:    base.Add(20 * time.Second),
+		EventType:    models.AIEventSessionEnd,
+		StopReason:   "completed",
+		InputTokens:  5420,
+		OutputTokens: 10000,
+	})
+
+	return records
+}
+
+// PipelineRun returns a completed single-step run for projectID with its step result
+func PipelineRun(projectID, runID string, now time.Time) models.PipelineRun {
+	started := now.Add(-3 * time.Hour)
+	completed := started.Add(4 * time.Minute)
+	return models.PipelineRun{
+		ID:            runID,
+		ProjectID:     projectID,
+		Name:          "Add rate limiting to the API",
+		Status:        models.PipelineRunStatusCompleted,
+		RunType:       models.PipelineRunTypeStandard,
+		BranchName:    "task/" + runID,
+		BaseCommitSHA: "3f4e2a1c0d9b8e7f6a5b4c3d2e1f0a9b8c7d6e5f",
+		HeadCommitSHA: "9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a1b0c",
+		CreatedAt:     started,
+		StartedAt:     &started,
+		CompletedAt:   &completed,
+		StepResults: []models.StepResult{{
+			ID:            runID + "-step-1",
+			PipelineRunID: runID,
+			StepID:        "implement",
+			StepName:      "Implement",
+			StepIndex:     0,
+			Status:        models.StepStatusCompleted,
+			CommitSHA:     "9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a1b0c",
+			CommitMessage: "Add rate limiting middleware",
+			GitDiff:       rateLimitDiff,
+			FilesChanged:  1,
+			Insertions:    16,
+			Deletions:     1,
+			InputTokens:   5420,
+			OutputTokens:  10000,
+			Duration:      completed.Sub(started),
+			CreatedAt:     started,
+			StartedAt:     &started,
+			CompletedAt:   &completed,
+		}},
+	}
+}
+
+// Store is the subset of services.DataService that Seed and Clear need
+type Store interface {
+	InsertProject(ctx context.Context, project *models.Project) error
+	InsertTask(ctx context.Context, task *models.Task) error
+	CreatePipelineRun(ctx context.Context, run *models.PipelineRun) error
+	SaveAIActivityRecord(ctx context.Context, record *models.AIActivityRecord) error
+	DeleteAIActivityByTask(ctx context.Context, taskID string) error
+	DeletePipelineRun(ctx context.Context, runID string) error
+	DeleteTask(ctx context.Context, taskID string) error
+	DeleteProject(ctx context.Context, projectID string) error
+}
+
+// Summary counts what Seed wrote
+type Summary struct {
+	Tasks        int
+	PipelineRuns int
+	Activities   int
+}
+
+// Seed writes the demo project, its tasks, a pipeline run and their activity.
+// Existing demo data is cleared first, so seeding twice leaves a single copy.
+func Seed(ctx context.Context, store Store, repoPath string, now time.Time) (Summary, error) {
+	var summary Summary
+	if err := Clear(ctx, store); err != nil {
+		return summary, err
+	}
+
+	project := Project(repoPath)
+	if err := store.InsertProject(ctx, &project); err != nil {
+		return summary, fmt.Errorf("failed to create demo project: %w", err)
+	}
+
+	for _, task := range Tasks(ProjectID, now) {
+		if err := store.InsertTask(ctx, &task); err != nil {
+			return summary, fmt.Errorf("failed to create demo task %s: %w", task.ID, err)
+		}
+		summary.Tasks++
+		if task.Status == models.TaskStatusPending {
+			continue
+		}
+		n, err := saveActivity(ctx, store, AIActivityRecords(task.ID, task.CreatedAt.Add(time.Minute)), "")
+		if err != nil {
+			return summary, err
+		}
+		summary.Activities += n
+	}
+
+	run := PipelineRun(ProjectID, RunID, now)
+	if err := store.CreatePipelineRun(ctx, &run); err != nil {
+		return summary, fmt.Errorf("failed to create demo pipeline run: %w", err)
+	}
+	summary.PipelineRuns++
+	// Pipeline activity is keyed by run ID, which doubles as the task ID in the TUI
+	n, err := saveActivity(ctx, store, AIActivityRecords(RunID, *run.StartedAt), run.StepResults[0].StepID)
+	if err != nil {
+		return summary, err
+	}
+	summary.Activities += n
+
+	return summary, nil
+}
+
+func saveActivity(ctx context.Context, store Store, records []*models.AIActivityRecord, stepID string) (int, error) {
+	for _, record := range records {
+		if stepID != "" {
+			record.RunID = record.TaskID
+			record.StepID = stepID
+		}
+		if err := store.SaveAIActivityRecord(ctx, record); err != nil {
+			return 0, fmt.Errorf("failed to save demo activity %s: %w", record.EventID, err)
+		}
+	}
+	return len(records), nil
+}
+
+// Clear removes everything Seed writes. Missing rows are not an error.
+func Clear(ctx context.Context, store Store) error {
+	for _, task := range Tasks(ProjectID, time.Time{}) {
+		if err := store.DeleteAIActivityByTask(ctx, task.ID); err != nil {
+			return fmt.Errorf("failed to delete demo activity of %s: %w", task.ID, err)
+		}
+		if err := store.DeleteTask(ctx, task.ID); err != nil {
+			return fmt.Errorf("failed to delete demo task %s: %w", task.ID, err)
+		}
+	}
+	if err := store.DeleteAIActivityByTask(ctx, RunID); err != nil {
+		return fmt.Errorf("failed to delete demo activity of %s: %w", RunID, err)
+	}
+	if err := store.DeletePipelineRun(ctx, RunID); err != nil {
+		return fmt.Errorf("failed to delete demo pipeline run: %w", err)
+	}
+	if err := store.DeleteProject(ctx, ProjectID); err != nil {
+		return fmt.Errorf("failed to delete demo project: %w", err)
+	}
+	return nil
+}
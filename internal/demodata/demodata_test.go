@@ -0,0 +1,124 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package demodata
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+)
+
+// memStore keeps rows by ID, like the database would
+type memStore struct {
+	projects   map[string]*models.Project
+	tasks      map[string]*models.Task
+	runs       map[string]*models.PipelineRun
+	activities map[string]*models.AIActivityRecord
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		projects:   map[string]*models.Project{},
+		tasks:      map[string]*models.Task{},
+		runs:       map[string]*models.PipelineRun{},
+		activities: map[string]*models.AIActivityRecord{},
+	}
+}
+
+func (s *memStore) InsertProject(_ context.Context, p *models.Project) error {
+	s.projects[p.ID] = p
+	return nil
+}
+
+func (s *memStore) InsertTask(_ context.Context, t *models.Task) error {
+	s.tasks[t.ID] = t
+	return nil
+}
+
+func (s *memStore) CreatePipelineRun(_ context.Context, r *models.PipelineRun) error {
+	s.runs[r.ID] = r
+	return nil
+}
+
+func (s *memStore) SaveAIActivityRecord(_ context.Context, r *models.AIActivityRecord) error {
+	s.activities[r.EventID] = r
+	return nil
+}
+
+func (s *memStore) DeleteAIActivityByTask(_ context.Context, taskID string) error {
+	for id, r := range s.activities {
+		if r.TaskID == taskID {
+			delete(s.activities, id)
+		}
+	}
+	return nil
+}
+
+func (s *memStore) DeletePipelineRun(_ context.Context, runID string) error {
+	delete(s.runs, runID)
+	return nil
+}
+
+func (s *memStore) DeleteTask(_ context.Context, taskID string) error {
+	delete(s.tasks, taskID)
+	return nil
+}
+
+func (s *memStore) DeleteProject(_ context.Context, projectID string) error {
+	delete(s.projects, projectID)
+	return nil
+}
+
+func TestSeed_IdempotentAndClearable(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	// Unrelated data must survive Clear
+	store.projects["project-1"] = &models.Project{ID: "project-1"}
+	store.activities["evt-1"] = &models.AIActivityRecord{EventID: "evt-1", TaskID: "task-1"}
+
+	summary, err := Seed(ctx, store, "/tmp/repo", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 5, summary.Tasks)
+	assert.Equal(t, 1, summary.PipelineRuns)
+	assert.Equal(t, len(store.activities)-1, summary.Activities)
+
+	again, err := Seed(ctx, store, "/tmp/repo", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, summary, again)
+	assert.Len(t, store.tasks, 5)
+	assert.Len(t, store.projects, 2)
+
+	for id := range store.tasks {
+		assert.True(t, strings.HasPrefix(id, IDPrefix), id)
+	}
+	for id, r := range store.activities {
+		if id != "evt-1" {
+			assert.True(t, strings.HasPrefix(id, IDPrefix), id)
+			assert.True(t, strings.HasPrefix(r.TaskID, IDPrefix), r.TaskID)
+		}
+	}
+	assert.Equal(t, "/tmp/repo", store.projects[ProjectID].RepositoryPath)
+	require.Contains(t, store.runs, RunID)
+	assert.Equal(t, RunID, store.activities[RunID+"-evt-001"].RunID)
+
+	require.NoError(t, Clear(ctx, store))
+	assert.Empty(t, store.tasks)
+	assert.Empty(t, store.runs)
+	assert.Equal(t, []string{"project-1"}, keys(store.projects))
+	assert.Equal(t, []string{"evt-1"}, keys(store.activities))
+}
+
+func keys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,414 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package logger
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/rs/zerolog"
+)
+
+// ansiRegex matches the color escapes ConsoleWriter writes into log files
+var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// fieldKeyRegex matches the key of a key=value field in a console-format line
+var fieldKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.\-]*=`)
+
+// consoleLevels maps ConsoleWriter's default three-letter levels
+var consoleLevels = map[string]zerolog.Level{
+	"TRC": zerolog.TraceLevel,
+	"DBG": zerolog.DebugLevel,
+	"INF": zerolog.InfoLevel,
+	"WRN": zerolog.WarnLevel,
+	"ERR": zerolog.ErrorLevel,
+	"FTL": zerolog.FatalLevel,
+	"PNC": zerolog.PanicLevel,
+}
+
+// Entry is one structured log line read back from a log output
+type Entry struct {
+	Time    time.Time     // Zero when the line has no timestamp
+	Level   zerolog.Level // zerolog.NoLevel when the line has none
+	Message string
+	Caller  string
+	Fields  map[string]string // Remaining fields, values as written (JSON objects stay encoded)
+}
+
+// ParseLine parses a line written by a Manager, either zerolog JSON or the console
+// format used for file outputs when log.format is "console". ok is false for lines
+// in neither format, such as the continuation lines of a stack trace.
+func ParseLine(line string) (entry Entry, ok bool) {
+	line = strings.TrimSpace(ansiRegex.ReplaceAllString(line, ""))
+	if strings.HasPrefix(line, "{") {
+		return parseJSONLine(line)
+	}
+	return parseConsoleLine(line)
+}
+
+func parseJSONLine(line string) (Entry, bool) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(line), &raw); err != nil {
+		return Entry{}, false
+	}
+
+	entry := Entry{Level: zerolog.NoLevel, Fields: make(map[string]string, len(raw))}
+	for key, value := range raw {
+		text := string(value)
+		var s string
+		if json.Unmarshal(value, &s) == nil {
+			text = s
+		}
+
+		switch key {
+		case zerolog.LevelFieldName:
+			if level, err := zerolog.ParseLevel(text); err == nil {
+				entry.Level = level
+			}
+		case zerolog.TimestampFieldName:
+			if t, err := time.Parse(time.RFC3339Nano, text); err == nil {
+				entry.Time = t
+			}
+		case zerolog.MessageFieldName:
+			entry.Message = text
+		case zerolog.CallerFieldName:
+			entry.Caller = text
+		default:
+			entry.Fields[key] = text
+		}
+	}
+	return entry, true
+}
+
+// parseConsoleLine parses "<time> | LEVEL | [caller >] message key=value ...", as
+// written by the file ConsoleWriter, or ConsoleWriter's default "<time> LVL ..." form
+func parseConsoleLine(line string) (Entry, bool) {
+	entry := Entry{Level: zerolog.NoLevel, Fields: make(map[string]string)}
+
+	rest := line
+	switch {
+	case strings.HasPrefix(rest, "<nil> "):
+		rest = rest[len("<nil> "):]
+	case len(rest) >= len(fileTimeFormat):
+		t, err := time.ParseInLocation(fileTimeFormat, rest[:len(fileTimeFormat)], time.Local)
+		if err != nil {
+			return Entry{}, false
+		}
+		entry.Time = t
+		rest = rest[len(fileTimeFormat):]
+	default:
+		return Entry{}, false
+	}
+	rest = strings.TrimSpace(rest)
+
+	if strings.HasPrefix(rest, "|") {
+		end := strings.Index(rest[1:], "|")
+		if end < 0 {
+			return Entry{}, false
+		}
+		level, err := zerolog.ParseLevel(strings.ToLower(strings.TrimSpace(rest[1 : end+1])))
+		if err != nil {
+			return Entry{}, false
+		}
+		entry.Level = level
+		rest = rest[end+2:]
+	} else {
+		abbrev, after, _ := strings.Cut(rest, " ")
+		level, known := consoleLevels[abbrev]
+		if !known {
+			return Entry{}, false
+		}
+		entry.Level = level
+		rest = after
+	}
+	rest = strings.TrimSpace(rest)
+
+	if caller, after, found := strings.Cut(rest, " > "); found && !strings.Contains(caller, " ") && strings.Contains(caller, ":") {
+		entry.Caller = caller
+		rest = after
+	}
+
+	tokens := splitConsoleTokens(rest)
+	first := len(tokens)
+	for first > 0 && fieldKeyRegex.MatchString(tokens[first-1].text) {
+		first--
+	}
+	messageEnd := len(rest)
+	if first < len(tokens) {
+		messageEnd = tokens[first].start
+	}
+	entry.Message = strings.TrimSpace(rest[:messageEnd])
+	for _, token := range tokens[first:] {
+		key, value, _ := strings.Cut(token.text, "=")
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		entry.Fields[key] = value
+	}
+	return entry, true
+}
+
+type consoleToken struct {
+	text  string
+	start int
+}
+
+// splitConsoleTokens splits on spaces outside of double-quoted values
+func splitConsoleTokens(s string) []consoleToken {
+	var tokens []consoleToken
+	start := -1
+	inQuote := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case inQuote && c == '\\':
+			i++
+		case c == '"':
+			inQuote = !inQuote
+		case c == ' ' && !inQuote:
+			if start >= 0 {
+				tokens = append(tokens, consoleToken{text: s[start:i], start: start})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, consoleToken{text: s[start:], start: start})
+	}
+	return tokens
+}
+
+// taskIDFields are the field names task and run IDs are logged under; a task's ID
+// is its run ID
+var taskIDFields = []string{"taskID", "task_id", "TaskID", "runID", "run_id", "RunID"}
+
+// workflowIDFields are the field names Temporal workflow IDs are logged under; a
+// task's workflow IDs start with its run ID
+var workflowIDFields = []string{"WorkflowID", "workflowID", "workflow_id"}
+
+// Filter selects log entries
+type Filter struct {
+	TaskID   string            // Entries logged for this task or run, including its workflows
+	MinLevel zerolog.Level     // Entries without a level always pass
+	Fields   map[string]string // Exact field matches, all required
+}
+
+// Match reports whether the entry passes every condition of the filter
+func (f Filter) Match(e Entry) bool {
+	if e.Level != zerolog.NoLevel && e.Level < f.MinLevel {
+		return false
+	}
+	for key, want := range f.Fields {
+		if e.Fields[key] != want {
+			return false
+		}
+	}
+	if f.TaskID == "" {
+		return true
+	}
+	for _, key := range taskIDFields {
+		if e.Fields[key] == f.TaskID {
+			return true
+		}
+	}
+	for _, key := range workflowIDFields {
+		if id := e.Fields[key]; id == f.TaskID || strings.HasPrefix(id, f.TaskID+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// FileOutputs returns the paths of the enabled file outputs, or the fallback file
+// NewManager writes to when no output is enabled
+func FileOutputs(cfg *config.LogConfig) []string {
+	var paths []string
+	enabled := 0
+	for _, output := range cfg.Output {
+		if !output.Enabled {
+			continue
+		}
+		enabled++
+		if output.Type == "file" && output.Path != "" {
+			paths = append(paths, output.Path)
+		}
+	}
+	if enabled == 0 {
+		return []string{fallbackLogPath}
+	}
+	return paths
+}
+
+// RotatedFiles returns the backups lumberjack rotated out of path, oldest first,
+// including compressed ones
+func RotatedFiles(path string) ([]string, error) {
+	ext := filepath.Ext(path)
+	prefix := strings.TrimSuffix(path, ext) + "-"
+	matches, err := filepath.Glob(prefix + "*")
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, match := range matches {
+		stamp := strings.TrimSuffix(strings.TrimSuffix(match, ".gz"), ext)
+		stamp = strings.TrimPrefix(stamp, prefix)
+		// lumberjack names backups <name>-<timestamp><ext>, so they sort by name
+		if _, err := time.Parse("2006-01-02T15-04-05.000", stamp); err == nil {
+			backups = append(backups, match)
+		}
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// ReadLines returns all lines of a log file, decompressing rotated .gz backups
+func ReadLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress %s: %w", path, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// Tail follows a log file by path. It reopens the file when it is rotated away or
+// truncated, and waits for it to appear when it does not exist yet.
+type Tail struct {
+	path    string
+	file    *os.File
+	reader  *bufio.Reader
+	offset  int64
+	partial string
+}
+
+// NewTail starts following path from its beginning
+func NewTail(path string) *Tail {
+	return &Tail{path: path}
+}
+
+// Lines returns the complete lines written since the last call
+func (t *Tail) Lines() ([]string, error) {
+	if t.file == nil {
+		if err := t.open(); err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+
+	lines, err := t.drain()
+	if err != nil {
+		return lines, err
+	}
+
+	info, err := os.Stat(t.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Rotated away and not recreated yet; keep the old file until it is
+			return lines, nil
+		}
+		return lines, err
+	}
+	current, err := t.file.Stat()
+	if err != nil {
+		return lines, err
+	}
+
+	switch {
+	case !os.SameFile(info, current):
+		// Rotated: the old file is drained, continue with the new one
+		t.file.Close()
+		t.file = nil
+		if err := t.open(); err != nil {
+			return lines, err
+		}
+		more, err := t.drain()
+		return append(lines, more...), err
+	case info.Size() < t.offset:
+		// Truncated in place
+		if _, err := t.file.Seek(0, io.SeekStart); err != nil {
+			return lines, err
+		}
+		t.reader.Reset(t.file)
+		t.offset = 0
+		t.partial = ""
+		more, err := t.drain()
+		return append(lines, more...), err
+	}
+	return lines, nil
+}
+
+// Close closes the followed file
+func (t *Tail) Close() error {
+	if t.file == nil {
+		return nil
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
+
+func (t *Tail) open() error {
+	file, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+	t.file = file
+	t.reader = bufio.NewReader(file)
+	t.offset = 0
+	t.partial = ""
+	return nil
+}
+
+// drain reads up to the current end of the file, holding back a trailing partial line
+func (t *Tail) drain() ([]string, error) {
+	var lines []string
+	for {
+		chunk, err := t.reader.ReadString('\n')
+		t.offset += int64(len(chunk))
+		if err != nil {
+			t.partial += chunk
+			if err == io.EOF {
+				return lines, nil
+			}
+			return lines, err
+		}
+		lines = append(lines, strings.TrimRight(t.partial+chunk, "\r\n"))
+		t.partial = ""
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/rs/zerolog"
+)
+
+func TestParseLine(t *testing.T) {
+	var console bytes.Buffer
+	fileWriter := zerolog.ConsoleWriter{
+		Out:        &console,
+		TimeFormat: fileTimeFormat,
+		FormatLevel: func(i interface{}) string {
+			return strings.ToUpper(fmt.Sprintf("| %-6s|", i))
+		},
+	}
+	fileLogger := zerolog.New(fileWriter).With().Timestamp().Str("pkg", "orchestrator").Logger()
+	fileLogger.Warn().Str("taskID", "run-1").Str("note", "has space").Msg("Step failed twice")
+
+	tests := []struct {
+		name    string
+		line    string
+		ok      bool
+		level   zerolog.Level
+		message string
+		fields  map[string]string
+	}{
+		{
+			name:    "json",
+			line:    `{"level":"error","pkg":"temporal","taskID":"run-1","attempt":2,"time":"2026-01-02T10:00:00Z","message":"Activity failed"}`,
+			ok:      true,
+			level:   zerolog.ErrorLevel,
+			message: "Activity failed",
+			fields:  map[string]string{"pkg": "temporal", "taskID": "run-1", "attempt": "2"},
+		},
+		{
+			name:    "colored console file line",
+			line:    strings.TrimSpace(console.String()),
+			ok:      true,
+			level:   zerolog.WarnLevel,
+			message: "Step failed twice",
+			fields:  map[string]string{"pkg": "orchestrator", "taskID": "run-1", "note": "has space"},
+		},
+		{
+			name:    "default console line with caller",
+			line:    `<nil> DBG orchestrator/task.go:42 > Queued task pkg=orchestrator`,
+			ok:      true,
+			level:   zerolog.DebugLevel,
+			message: "Queued task",
+			fields:  map[string]string{"pkg": "orchestrator"},
+		},
+		{name: "stack trace continuation", line: "\tgoroutine 1 [running]:", ok: false},
+		{name: "broken json", line: `{"level":`, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, ok := ParseLine(tt.line)
+			if ok != tt.ok {
+				t.Fatalf("ParseLine(%q) ok = %v, want %v", tt.line, ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if entry.Level != tt.level {
+				t.Errorf("level = %v, want %v", entry.Level, tt.level)
+			}
+			if entry.Message != tt.message {
+				t.Errorf("message = %q, want %q", entry.Message, tt.message)
+			}
+			if !reflect.DeepEqual(entry.Fields, tt.fields) {
+				t.Errorf("fields = %v, want %v", entry.Fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	entry := func(level zerolog.Level, fields map[string]string) Entry {
+		return Entry{Level: level, Fields: fields}
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		entry  Entry
+		want   bool
+	}{
+		{name: "empty filter", filter: Filter{}, entry: entry(zerolog.DebugLevel, nil), want: true},
+		{name: "below min level", filter: Filter{MinLevel: zerolog.WarnLevel}, entry: entry(zerolog.InfoLevel, nil), want: false},
+		{name: "at min level", filter: Filter{MinLevel: zerolog.WarnLevel}, entry: entry(zerolog.WarnLevel, nil), want: true},
+		{name: "no level passes", filter: Filter{MinLevel: zerolog.WarnLevel}, entry: entry(zerolog.NoLevel, nil), want: true},
+		{name: "task id field", filter: Filter{TaskID: "run-1"}, entry: entry(zerolog.InfoLevel, map[string]string{"task_id": "run-1"}), want: true},
+		{name: "run id field", filter: Filter{TaskID: "run-1"}, entry: entry(zerolog.InfoLevel, map[string]string{"runID": "run-1"}), want: true},
+		{name: "task workflow", filter: Filter{TaskID: "run-1"}, entry: entry(zerolog.InfoLevel, map[string]string{"WorkflowID": "run-1-pipeline"}), want: true},
+		{name: "other task", filter: Filter{TaskID: "run-1"}, entry: entry(zerolog.InfoLevel, map[string]string{"taskID": "run-10"}), want: false},
+		{name: "other task workflow", filter: Filter{TaskID: "run-1"}, entry: entry(zerolog.InfoLevel, map[string]string{"WorkflowID": "run-10-pipeline"}), want: false},
+		{name: "field match", filter: Filter{Fields: map[string]string{"pkg": "git"}}, entry: entry(zerolog.InfoLevel, map[string]string{"pkg": "git"}), want: true},
+		{name: "field mismatch", filter: Filter{Fields: map[string]string{"pkg": "git"}}, entry: entry(zerolog.InfoLevel, map[string]string{"pkg": "rpc"}), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.entry); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileOutputs(t *testing.T) {
+	cfg := &config.LogConfig{Output: []config.LogOutputConfig{
+		{Type: "file", Enabled: true, Path: "a.log"},
+		{Type: "file", Enabled: false, Path: "b.log"},
+		{Type: "console", Enabled: true},
+		{Type: "file", Enabled: true, Path: "c.log"},
+	}}
+	if got := FileOutputs(cfg); !reflect.DeepEqual(got, []string{"a.log", "c.log"}) {
+		t.Errorf("FileOutputs() = %v", got)
+	}
+
+	if got := FileOutputs(&config.LogConfig{}); !reflect.DeepEqual(got, []string{fallbackLogPath}) {
+		t.Errorf("FileOutputs() without outputs = %v, want the fallback file", got)
+	}
+}
+
+func TestRotatedFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "noldarim.log")
+	for _, name := range []string{
+		"noldarim.log",
+		"noldarim-2026-01-02T10-00-00.000.log.gz",
+		"noldarim-2026-01-01T10-00-00.000.log",
+		"noldarim-fallback.log",
+		"other-2026-01-01T10-00-00.000.log",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backups, err := RotatedFiles(path)
+	if err != nil {
+		t.Fatalf("RotatedFiles() error: %v", err)
+	}
+	want := []string{
+		filepath.Join(dir, "noldarim-2026-01-01T10-00-00.000.log"),
+		filepath.Join(dir, "noldarim-2026-01-02T10-00-00.000.log.gz"),
+	}
+	if !reflect.DeepEqual(backups, want) {
+		t.Errorf("RotatedFiles() = %v, want %v", backups, want)
+	}
+}
+
+func TestTail_FollowsRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "noldarim.log")
+
+	tail := NewTail(path)
+	defer tail.Close()
+
+	// A missing file is waited for
+	if lines, err := tail.Lines(); err != nil || len(lines) != 0 {
+		t.Fatalf("Lines() before the file exists = %v, %v", lines, err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprint(f, "one\ntwo\nthr")
+	assertLines(t, tail, "one", "two")
+
+	// The partial line completes, then the file is rotated away and recreated
+	fmt.Fprint(f, "ee\n")
+	f.Close()
+	if err := os.Rename(path, filepath.Join(dir, "noldarim-2026-01-01T10-00-00.000.log")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("four\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assertLines(t, tail, "three", "four")
+
+	// Truncation starts over
+	if err := os.WriteFile(path, []byte("5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assertLines(t, tail, "5")
+}
+
+func assertLines(t *testing.T, tail *Tail, want ...string) {
+	t.Helper()
+	lines, err := tail.Lines()
+	if err != nil {
+		t.Fatalf("Lines() error: %v", err)
+	}
+	if len(lines) != len(want) || (len(want) > 0 && !reflect.DeepEqual(lines, want)) {
+		t.Errorf("Lines() = %q, want %q", lines, want)
+	}
+}
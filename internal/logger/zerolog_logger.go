@@ -17,6 +17,13 @@ import (
 	"github.com/noldarim/noldarim/internal/config"
 )
 
+const (
+	// fallbackLogPath is written to when no output is configured
+	fallbackLogPath = "./logs/noldarim-fallback.log"
+	// fileTimeFormat is the timestamp layout of console-format log files
+	fileTimeFormat = "2006-01-02 15:04:05.000"
+)
+
 // Manager manages multiple loggers for different packages
 type Manager struct {
 	config         *config.LogConfig
@@ -56,7 +63,7 @@ func NewManager(cfg *config.LogConfig) (*Manager, error) {
 		multiWriter = io.MultiWriter(writers...)
 	} else {
 		// If no writers configured, fall back to a default file writer to ensure logs aren't lost
-		defaultPath := "./logs/noldarim-fallback.log"
+		defaultPath := fallbackLogPath
 		if err := os.MkdirAll(filepath.Dir(defaultPath), 0755); err != nil {
 			return nil, fmt.Errorf("failed to create fallback log directory: %w", err)
 		}
@@ -150,7 +157,7 @@ func (m *Manager) createWriters(cfg *config.LogConfig) ([]io.Writer, error) {
 			if i < len(cfg.Output) && cfg.Output[i].Type == "file" {
 				enhancedWriters = append(enhancedWriters, zerolog.ConsoleWriter{
 					Out:        w,
-					TimeFormat: "2006-01-02 15:04:05.000",
+					TimeFormat: fileTimeFormat,
 					NoColor:    false, // Keep colors in file for readability
 					FormatLevel: func(i interface{}) string {
 						return strings.ToUpper(fmt.Sprintf("| %-6s|", i))
@@ -6,7 +6,6 @@ package agents
 import (
 	"fmt"
 	"sort"
-	"strings"
 )
 
 // ClaudeAdapter handles execution configuration for Claude AI agent
@@ -95,15 +94,13 @@ func (ca *ClaudeAdapter) PrepareCommand(config AgentConfig) ([]string, error) {
 	return command, nil
 }
 
-// RenderPrompt renders a prompt template with variables using simple string
-// replacement. Avoids text/template to prevent template injection from
-// user-controlled variable values.
+// RenderPrompt renders a prompt template with variables. Substitution is a single
+// pass of plain string replacement rather than text/template, which prevents
+// template injection from user-controlled variable values.
 func (ca *ClaudeAdapter) RenderPrompt(promptTemplate string, variables map[string]string) (string, error) {
-	result := promptTemplate
-	for key, value := range variables {
-		// Replace both {{.Key}} and {{ .Key }} forms
-		result = strings.ReplaceAll(result, "{{."+key+"}}", value)
-		result = strings.ReplaceAll(result, "{{ ."+key+" }}", value)
+	result, err := ResolvePrompt(promptTemplate, variables)
+	if err != nil {
+		return "", err
 	}
-	return result, nil
+	return result.Prompt, nil
 }
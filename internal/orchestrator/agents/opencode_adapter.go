@@ -6,7 +6,6 @@ package agents
 import (
 	"fmt"
 	"sort"
-	"strings"
 )
 
 type OpenCodeAdapter struct{}
@@ -67,10 +66,9 @@ func (oa *OpenCodeAdapter) PrepareCommand(config AgentConfig) ([]string, error)
 }
 
 func (oa *OpenCodeAdapter) renderPrompt(template string, vars map[string]string) (string, error) {
-	result := template
-	for key, value := range vars {
-		result = strings.ReplaceAll(result, "{{."+key+"}}", value)
-		result = strings.ReplaceAll(result, "{{ ."+key+" }}", value)
+	result, err := ResolvePrompt(template, vars)
+	if err != nil {
+		return "", err
 	}
-	return result, nil
+	return result.Prompt, nil
 }
@@ -0,0 +1,128 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package agents
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Built-in prompt variables. They are filled from the task and project; the legacy
+// lowercase keys (title, description, task_file) are accepted as their sources.
+const (
+	VarTaskTitle       = "TaskTitle"
+	VarTaskDescription = "TaskDescription"
+	VarTaskFilePath    = "TaskFilePath"
+	VarProjectID       = "ProjectID"
+)
+
+// builtinSources maps a built-in variable to the legacy key it is derived from
+var builtinSources = map[string]string{
+	VarTaskTitle:       "title",
+	VarTaskDescription: "description",
+	VarTaskFilePath:    "task_file",
+}
+
+// placeholderPattern matches {{.Name}} and {{ .Name }}, optionally escaped as \{{.Name}}
+var placeholderPattern = regexp.MustCompile(`\\?\{\{\s*\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// ErrUndefinedVariable is returned when a template references a variable that has no value
+var ErrUndefinedVariable = errors.New("undefined template variable")
+
+// PromptResult is a rendered prompt plus the supplied variables it never referenced
+type PromptResult struct {
+	Prompt string
+	Unused []string
+}
+
+// ResolvePrompt substitutes variables into promptTemplate in a single pass, so values
+// are never re-expanded. \{{.Name}} renders as a literal {{.Name}}. Every referenced
+// variable must be defined; user-supplied variables that are never referenced are
+// reported in Unused.
+func ResolvePrompt(promptTemplate string, variables map[string]string) (PromptResult, error) {
+	vars := WithBuiltinVariables(variables)
+
+	referenced := make(map[string]bool)
+	var undefined []string
+	rendered := placeholderPattern.ReplaceAllStringFunc(promptTemplate, func(match string) string {
+		if strings.HasPrefix(match, `\`) {
+			return match[1:]
+		}
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		referenced[name] = true
+		value, ok := vars[name]
+		if !ok {
+			undefined = append(undefined, name)
+			return match
+		}
+		return value
+	})
+
+	if len(undefined) > 0 {
+		return PromptResult{}, fmt.Errorf("%w: %s", ErrUndefinedVariable, strings.Join(dedupe(undefined), ", "))
+	}
+
+	var unused []string
+	for name := range variables {
+		if referenced[name] || isBuiltin(name) || referencedViaBuiltin(name, referenced) {
+			continue
+		}
+		unused = append(unused, name)
+	}
+	sort.Strings(unused)
+
+	return PromptResult{Prompt: rendered, Unused: unused}, nil
+}
+
+// WithBuiltinVariables returns a copy of variables with the built-in variables
+// filled from their legacy keys where they are not set explicitly
+func WithBuiltinVariables(variables map[string]string) map[string]string {
+	vars := make(map[string]string, len(variables)+len(builtinSources))
+	for k, v := range variables {
+		vars[k] = v
+	}
+	for builtin, source := range builtinSources {
+		if _, ok := vars[builtin]; ok {
+			continue
+		}
+		if v, ok := variables[source]; ok {
+			vars[builtin] = v
+		}
+	}
+	return vars
+}
+
+// isBuiltin reports whether name is a built-in variable, which is supplied
+// automatically and therefore never reported as unused
+func isBuiltin(name string) bool {
+	if _, ok := builtinSources[name]; ok {
+		return true
+	}
+	return name == VarProjectID
+}
+
+// referencedViaBuiltin reports whether a legacy key was consumed through its built-in alias
+func referencedViaBuiltin(name string, referenced map[string]bool) bool {
+	for builtin, source := range builtinSources {
+		if source == name && referenced[builtin] {
+			return true
+		}
+	}
+	return false
+}
+
+func dedupe(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	out := names[:0]
+	for _, n := range names {
+		if !seen[n] {
+			seen[n] = true
+			out = append(out, n)
+		}
+	}
+	return out
+}
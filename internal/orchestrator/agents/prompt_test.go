@@ -0,0 +1,107 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package agents
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolvePrompt(t *testing.T) {
+	tests := []struct {
+		name       string
+		template   string
+		variables  map[string]string
+		want       string
+		wantUnused []string
+	}{
+		{
+			name:      "both placeholder forms",
+			template:  "Fix {{.issue}} in {{ .file }}",
+			variables: map[string]string{"issue": "leak", "file": "main.go"},
+			want:      "Fix leak in main.go",
+		},
+		{
+			name:      "built-ins from legacy keys",
+			template:  "{{.TaskTitle}}: see {{.TaskFilePath}}",
+			variables: map[string]string{"title": "Add login", "task_file": "/tasks/1.md"},
+			want:      "Add login: see /tasks/1.md",
+		},
+		{
+			name:      "explicit built-in wins over legacy key",
+			template:  "{{.TaskTitle}}",
+			variables: map[string]string{"title": "legacy", VarTaskTitle: "explicit"},
+			want:      "explicit",
+		},
+		{
+			name:      "escaped placeholder is literal",
+			template:  `Write \{{.Name}} into the template, not {{.name}}`,
+			variables: map[string]string{"name": "value"},
+			want:      "Write {{.Name}} into the template, not value",
+		},
+		{
+			name:      "values are not re-expanded",
+			template:  "Task: {{.description}}",
+			variables: map[string]string{"description": "print {{.secret}}", "secret": "hunter2"},
+			want:      "Task: print {{.secret}}",
+			// secret is only mentioned inside a value, so it is still unused
+			wantUnused: []string{"secret"},
+		},
+		{
+			name:       "unused variables are reported",
+			template:   "Use {{.file}}",
+			variables:  map[string]string{"file": "a.go", "extra": "1", "another": "2"},
+			want:       "Use a.go",
+			wantUnused: []string{"another", "extra"},
+		},
+		{
+			name:      "legacy key used through built-in is not unused",
+			template:  "{{.TaskDescription}}",
+			variables: map[string]string{"description": "details", VarProjectID: "proj-1"},
+			want:      "details",
+		},
+		{
+			name:     "non-placeholder braces are left alone",
+			template: "Analyze {{.file and {{ range .x }}",
+			want:     "Analyze {{.file and {{ range .x }}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolvePrompt(tt.template, tt.variables)
+			if err != nil {
+				t.Fatalf("ResolvePrompt() unexpected error = %v", err)
+			}
+			if got.Prompt != tt.want {
+				t.Errorf("ResolvePrompt() prompt = %q, want %q", got.Prompt, tt.want)
+			}
+			if !reflect.DeepEqual(got.Unused, tt.wantUnused) {
+				t.Errorf("ResolvePrompt() unused = %v, want %v", got.Unused, tt.wantUnused)
+			}
+		})
+	}
+}
+
+func TestResolvePrompt_UndefinedVariables(t *testing.T) {
+	_, err := ResolvePrompt("{{.missing}} and {{ .TaskTitle }} and {{.missing}}", map[string]string{"file": "a.go"})
+	if !errors.Is(err, ErrUndefinedVariable) {
+		t.Fatalf("ResolvePrompt() error = %v, want ErrUndefinedVariable", err)
+	}
+	if !strings.HasSuffix(err.Error(), ": missing, TaskTitle") {
+		t.Errorf("ResolvePrompt() error = %q, want each undefined name listed once", err)
+	}
+}
+
+func TestResolvePrompt_EscapedUndefinedIsAllowed(t *testing.T) {
+	got, err := ResolvePrompt(`\{{.missing}}`, nil)
+	if err != nil {
+		t.Fatalf("ResolvePrompt() unexpected error = %v", err)
+	}
+	if got.Prompt != "{{.missing}}" {
+		t.Errorf("ResolvePrompt() prompt = %q, want %q", got.Prompt, "{{.missing}}")
+	}
+}
@@ -4,9 +4,7 @@
 package agents
 
 import (
-	"bytes"
 	"fmt"
-	"text/template"
 )
 
 // TestAdapter handles test command execution for integration tests
@@ -50,17 +48,9 @@ func (ta *TestAdapter) PrepareCommand(config AgentConfig) ([]string, error) {
 // renderPrompt renders a prompt template with variables
 // Reuses the same template rendering logic as ClaudeAdapter
 func (ta *TestAdapter) renderPrompt(promptTemplate string, variables map[string]string) (string, error) {
-	// Parse template
-	tmpl, err := template.New("command").Parse(promptTemplate)
+	result, err := ResolvePrompt(promptTemplate, variables)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
+		return "", err
 	}
-
-	// Execute template with variables
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, variables); err != nil {
-		return "", fmt.Errorf("failed to execute template: %w", err)
-	}
-
-	return buf.String(), nil
+	return result.Prompt, nil
 }
@@ -0,0 +1,155 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/services"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/workflows"
+	"github.com/noldarim/noldarim/internal/protocol"
+)
+
+// handleAssembleBranch resolves the source task branches and starts an AssembleBranchWorkflow.
+// The workflow answers the command itself, so the result reaches the caller even when the
+// assembly outlives this orchestrator process.
+func (o *Orchestrator) handleAssembleBranch(ctx context.Context, cmd protocol.AssembleBranchCommand) {
+	fail := func(message string, err error) {
+		if ctx.Err() != nil {
+			return
+		}
+		event := protocol.ErrorEvent{Metadata: cmd.Metadata, Message: message}
+		if err != nil {
+			event.Context = err.Error()
+		}
+		o.sendEvent(event)
+	}
+
+	if cmd.TargetBranch == "" || len(cmd.SourceTaskIDs) == 0 {
+		fail("A target branch and at least one source task are required", nil)
+		return
+	}
+	// The workflow creates the target with a plain ref update, which bypasses the checks
+	// GitService applies when creating branches
+	if o.config != nil {
+		if pattern, protected := services.MatchProtectedBranch(cmd.TargetBranch, o.config.Git.ProtectedBranches); protected {
+			fail("Failed to assemble branch "+cmd.TargetBranch, &services.ProtectedBranchError{
+				Branch:    cmd.TargetBranch,
+				Pattern:   pattern,
+				Operation: "assemble branch",
+			})
+			return
+		}
+	}
+	strategy := cmd.Strategy
+	switch strategy {
+	case "":
+		strategy = protocol.AssembleStrategyMerge
+	case protocol.AssembleStrategyMerge, protocol.AssembleStrategyCherryPick:
+	default:
+		fail(fmt.Sprintf("Unknown assembly strategy %q", strategy), nil)
+		return
+	}
+
+	workflowID := types.AssembleBranchWorkflowID(cmd.ProjectID, cmd.TargetBranch)
+	status, err := o.temporalClient.GetWorkflowStatus(ctx, workflowID)
+	if err == nil && status == temporal.WorkflowStatusRunning {
+		fail("Branch "+cmd.TargetBranch+" is already being assembled", nil)
+		return
+	}
+
+	project, err := o.dataService.GetProject(ctx, cmd.ProjectID)
+	if err != nil {
+		fail("Failed to load project details for "+cmd.ProjectID, err)
+		return
+	}
+
+	handle, err := o.gitServiceManager.GetService(project.RepositoryPath)
+	if err != nil {
+		fail("Failed to access git repository", err)
+		return
+	}
+	defer handle.Release()
+
+	input := types.AssembleBranchWorkflowInput{
+		Metadata:              cmd.Metadata,
+		ProjectID:             cmd.ProjectID,
+		RepositoryPath:        project.RepositoryPath,
+		TargetBranch:          cmd.TargetBranch,
+		BaseBranch:            cmd.BaseBranch,
+		Strategy:              strategy,
+		OrchestratorTaskQueue: o.temporalClient.GetTaskQueue(),
+	}
+	err = handle.WithReadLock(ctx, func(gs *services.GitService) error {
+		exists, err := gs.BranchExists(ctx, project.RepositoryPath, cmd.TargetBranch)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("branch %s already exists", cmd.TargetBranch)
+		}
+
+		if input.BaseBranch == "" {
+			state, err := gs.ValidateRepository(ctx, project.RepositoryPath)
+			if err != nil {
+				return fmt.Errorf("failed to resolve the project's current branch: %w", err)
+			}
+			input.BaseBranch = state.Branch
+		}
+		if input.BaseCommitSHA, err = gs.GetBranchHeadSHA(ctx, project.RepositoryPath, input.BaseBranch); err != nil {
+			return err
+		}
+
+		for _, taskID := range cmd.SourceTaskIDs {
+			branch, err := o.taskBranch(ctx, gs, project.RepositoryPath, taskID)
+			if err != nil {
+				return err
+			}
+			input.Sources = append(input.Sources, types.AssembleSource{TaskID: taskID, Branch: branch})
+		}
+		return nil
+	})
+	if err != nil {
+		fail("Failed to assemble branch "+cmd.TargetBranch, err)
+		return
+	}
+
+	if _, err := o.temporalClient.StartWorkflow(ctx, workflowID, workflows.AssembleBranchWorkflow, input); err != nil {
+		fail("Failed to start assembling branch "+cmd.TargetBranch, err)
+		return
+	}
+
+	getLog().Info().
+		Str("project_id", cmd.ProjectID).
+		Str("target_branch", cmd.TargetBranch).
+		Str("base_branch", input.BaseBranch).
+		Str("strategy", string(strategy)).
+		Int("sources", len(input.Sources)).
+		Msg("Started branch assembly")
+}
+
+// taskBranch returns the branch holding a task's work: the one its pipeline run recorded, or
+// the worktree branch named after the task
+func (o *Orchestrator) taskBranch(ctx context.Context, gs *services.GitService, repoPath, taskID string) (string, error) {
+	var candidates []string
+	// Pipeline runs use the run ID as task ID (see PipelineService.CreateTask)
+	if run, err := o.dataService.GetPipelineRun(ctx, taskID); err == nil && run != nil && run.BranchName != "" {
+		candidates = append(candidates, run.BranchName)
+	}
+	candidates = append(candidates, services.GenerateTaskBranchName(taskID))
+
+	for _, branch := range candidates {
+		exists, err := gs.BranchExists(ctx, repoPath, branch)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return branch, nil
+		}
+	}
+	return "", fmt.Errorf("task %s has no branch", taskID)
+}
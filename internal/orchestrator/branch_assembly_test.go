@@ -0,0 +1,35 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/noldarim/noldarim/internal/protocol"
+)
+
+func TestHandleAssembleBranch_RejectsProtectedTarget(t *testing.T) {
+	eventChan := make(chan protocol.Event, 1)
+	orch := &Orchestrator{
+		eventChan: eventChan,
+		config:    &config.AppConfig{Git: config.GitConfig{ProtectedBranches: []string{"main", "release/*"}}},
+	}
+
+	orch.handleAssembleBranch(context.Background(), protocol.AssembleBranchCommand{
+		Metadata:      protocol.Metadata{IdempotencyKey: "assemble-1"},
+		ProjectID:     "p1",
+		TargetBranch:  "release/1.2",
+		SourceTaskIDs: []string{"run-1"},
+	})
+
+	errEvent, ok := (<-eventChan).(protocol.ErrorEvent)
+	require.True(t, ok)
+	assert.Equal(t, "assemble-1", errEvent.Metadata.IdempotencyKey)
+	assert.Contains(t, errEvent.Context, "release/1.2 is protected")
+}
@@ -5,8 +5,10 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 
@@ -303,6 +305,8 @@ func TestModelTableNames(t *testing.T) {
 	}{
 		{&models.Project{}, "projects"},
 		{&models.Task{}, "tasks"},
+		{&models.TaskLabel{}, "task_labels"},
+		{&models.PipelineRunLabel{}, "pipeline_run_labels"},
 	}
 
 	for _, tc := range testCases {
@@ -439,13 +443,21 @@ func TestTaskCRUD(t *testing.T) {
 	})
 
 	t.Run("UpdateTask", func(t *testing.T) {
-		err := fixture.DB.UpdateTask(ctx, TestTaskID1, "Updated Title", "Updated Description")
+		before, err := fixture.DB.GetTask(ctx, TestTaskID1)
+		require.NoError(t, err)
+
+		err = fixture.DB.UpdateTask(ctx, TestTaskID1, "Updated Title", "Updated Description", before.Version)
 		assert.NoError(t, err)
 
 		task, err := fixture.DB.GetTask(ctx, TestTaskID1)
 		require.NoError(t, err)
 		assert.Equal(t, "Updated Title", task.Title)
 		assert.Equal(t, "Updated Description", task.Description)
+		assert.Equal(t, before.Version+1, task.Version)
+
+		// An update based on the old version is rejected
+		err = fixture.DB.UpdateTask(ctx, TestTaskID1, "Stale Title", "", before.Version)
+		assert.ErrorIs(t, err, ErrStaleWrite)
 	})
 
 	t.Run("DeleteTask", func(t *testing.T) {
@@ -640,6 +652,371 @@ func TestAIActivityCRUD(t *testing.T) {
 	})
 }
 
+// TestSaveAIActivityRecords tests batched saving, overwriting existing event IDs and rolling back on failure
+func TestSaveAIActivityRecords(t *testing.T) {
+	fixture := UseFreshTestDatabase(t)
+	ctx := context.Background()
+
+	NewProjectBuilder().Create(t, fixture.DB, ctx)
+	NewTaskBuilder().Create(t, fixture.DB, ctx)
+
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	newRecords := func(ids ...string) []*models.AIActivityRecord {
+		records := make([]*models.AIActivityRecord, len(ids))
+		for i, id := range ids {
+			records[i] = &models.AIActivityRecord{
+				EventID:        id,
+				TaskID:         TestTaskID1,
+				EventType:      models.AIEventToolUse,
+				ContentPreview: "original",
+				Timestamp:      base.Add(time.Duration(i) * time.Second),
+			}
+		}
+		return records
+	}
+
+	t.Run("SavesAcrossBatches", func(t *testing.T) {
+		require.NoError(t, fixture.DB.SaveAIActivityRecords(ctx, newRecords("evt-1", "evt-2", "evt-3", "evt-4", "evt-5"), 2))
+		records, err := fixture.DB.GetAIActivityByTask(ctx, TestTaskID1)
+		require.NoError(t, err)
+		assert.Len(t, records, 5)
+	})
+
+	t.Run("OverwritesExistingEventIDs", func(t *testing.T) {
+		updated := newRecords("evt-1")
+		updated[0].ContentPreview = "reparsed"
+		require.NoError(t, fixture.DB.SaveAIActivityRecords(ctx, updated, 2))
+
+		records, err := fixture.DB.GetAIActivityByTask(ctx, TestTaskID1)
+		require.NoError(t, err)
+		assert.Len(t, records, 5)
+		for _, r := range records {
+			if r.EventID == "evt-1" {
+				assert.Equal(t, "reparsed", r.ContentPreview)
+			}
+		}
+	})
+
+	t.Run("RollsBackOnFailure", func(t *testing.T) {
+		// The second batch updates evt-8 twice, which Postgres rejects
+		err := fixture.DB.SaveAIActivityRecords(ctx, newRecords("evt-6", "evt-7", "evt-8", "evt-8"), 2)
+		require.Error(t, err)
+
+		records, err := fixture.DB.GetAIActivityByTask(ctx, TestTaskID1)
+		require.NoError(t, err)
+		assert.Len(t, records, 5, "the first batch was rolled back too")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.NoError(t, fixture.DB.SaveAIActivityRecords(ctx, nil, 2))
+	})
+}
+
+// TestStreamAIActivityByTask tests that streaming visits each record once, in timestamp order
+func TestStreamAIActivityByTask(t *testing.T) {
+	fixture := UseFreshTestDatabase(t)
+	ctx := context.Background()
+
+	NewProjectBuilder().Create(t, fixture.DB, ctx)
+	NewTaskBuilder().Create(t, fixture.DB, ctx)
+	NewTaskBuilder().WithID(TestTaskID2).Create(t, fixture.DB, ctx)
+
+	// Saved out of timestamp order, so insertion order does not give the answer away
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	offsets := []int{3, 0, 4, 1, 2}
+	for i, offset := range offsets {
+		record := &models.AIActivityRecord{
+			EventID:   fmt.Sprintf("evt-%d", offset),
+			TaskID:    TestTaskID1,
+			SessionID: "session-123",
+			EventType: models.AIEventToolUse,
+			Timestamp: base.Add(time.Duration(offset) * time.Second),
+		}
+		require.NoError(t, fixture.DB.SaveAIActivityRecord(ctx, record), "record %d", i)
+	}
+	require.NoError(t, fixture.DB.SaveAIActivityRecord(ctx, &models.AIActivityRecord{
+		EventID:   "evt-other",
+		TaskID:    TestTaskID2,
+		EventType: models.AIEventToolUse,
+		Timestamp: base,
+	}))
+
+	t.Run("VisitsEveryRecordOnceInOrder", func(t *testing.T) {
+		var visited []string
+		err := fixture.DB.StreamAIActivityByTask(ctx, TestTaskID1, func(r *models.AIActivityRecord) error {
+			visited = append(visited, r.EventID)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"evt-0", "evt-1", "evt-2", "evt-3", "evt-4"}, visited)
+	})
+
+	t.Run("CallbackErrorStopsIteration", func(t *testing.T) {
+		stop := errors.New("stop")
+		visits := 0
+		err := fixture.DB.StreamAIActivityByTask(ctx, TestTaskID1, func(r *models.AIActivityRecord) error {
+			visits++
+			if visits == 2 {
+				return stop
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 2, visits)
+
+		// The cursor was closed, so the connection is usable again
+		records, err := fixture.DB.GetAIActivityByTask(ctx, TestTaskID1)
+		require.NoError(t, err)
+		assert.Len(t, records, 5)
+	})
+
+	t.Run("NoRecords", func(t *testing.T) {
+		err := fixture.DB.StreamAIActivityByTask(ctx, "non-existent-task", func(r *models.AIActivityRecord) error {
+			t.Fatalf("unexpected record %s", r.EventID)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
+
+// TestGetAIActivityByTaskPaged tests that pages follow timestamp order and report the task's total
+func TestGetAIActivityByTaskPaged(t *testing.T) {
+	fixture := UseFreshTestDatabase(t)
+	ctx := context.Background()
+
+	NewProjectBuilder().Create(t, fixture.DB, ctx)
+	NewTaskBuilder().Create(t, fixture.DB, ctx)
+	NewTaskBuilder().WithID(TestTaskID2).Create(t, fixture.DB, ctx)
+
+	// Saved out of timestamp order, so insertion order does not give the answer away
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	for _, offset := range []int{3, 0, 4, 1, 2} {
+		require.NoError(t, fixture.DB.SaveAIActivityRecord(ctx, &models.AIActivityRecord{
+			EventID:   fmt.Sprintf("evt-%d", offset),
+			TaskID:    TestTaskID1,
+			EventType: models.AIEventToolUse,
+			Timestamp: base.Add(time.Duration(offset) * time.Second),
+		}))
+	}
+	require.NoError(t, fixture.DB.SaveAIActivityRecord(ctx, &models.AIActivityRecord{
+		EventID:   "evt-other",
+		TaskID:    TestTaskID2,
+		EventType: models.AIEventToolUse,
+		Timestamp: base,
+	}))
+
+	eventIDs := func(records []*models.AIActivityRecord) []string {
+		ids := make([]string, len(records))
+		for i, r := range records {
+			ids[i] = r.EventID
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name          string
+		offset, limit int
+		want          []string
+	}{
+		{"FirstPage", 0, 2, []string{"evt-0", "evt-1"}},
+		{"MiddlePage", 2, 2, []string{"evt-2", "evt-3"}},
+		{"LastPartialPage", 4, 2, []string{"evt-4"}},
+		{"PastTheEnd", 10, 2, []string{}},
+		{"NoLimit", 1, 0, []string{"evt-1", "evt-2", "evt-3", "evt-4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, total, err := fixture.DB.GetAIActivityByTaskPaged(ctx, TestTaskID1, tt.offset, tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, 5, total)
+			assert.Equal(t, tt.want, eventIDs(records))
+		})
+	}
+
+	t.Run("NoRecords", func(t *testing.T) {
+		records, total, err := fixture.DB.GetAIActivityByTaskPaged(ctx, "non-existent-task", 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+		assert.Empty(t, records)
+	})
+}
+
+// TestGetAIActivityByTimeRange tests that only a task's records inside the range are returned, in order
+func TestGetAIActivityByTimeRange(t *testing.T) {
+	fixture := UseFreshTestDatabase(t)
+	ctx := context.Background()
+
+	NewProjectBuilder().Create(t, fixture.DB, ctx)
+	NewTaskBuilder().Create(t, fixture.DB, ctx)
+	NewTaskBuilder().WithID(TestTaskID2).Create(t, fixture.DB, ctx)
+
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	for _, offset := range []int{3, 0, 4, 1, 2} {
+		require.NoError(t, fixture.DB.SaveAIActivityRecord(ctx, &models.AIActivityRecord{
+			EventID:   fmt.Sprintf("evt-%d", offset),
+			TaskID:    TestTaskID1,
+			EventType: models.AIEventToolUse,
+			Timestamp: base.Add(time.Duration(offset) * time.Second),
+		}))
+	}
+	require.NoError(t, fixture.DB.SaveAIActivityRecord(ctx, &models.AIActivityRecord{
+		EventID:   "evt-other",
+		TaskID:    TestTaskID2,
+		EventType: models.AIEventToolUse,
+		Timestamp: base.Add(2 * time.Second),
+	}))
+
+	t.Run("InclusiveBoundsInOrder", func(t *testing.T) {
+		records, err := fixture.DB.GetAIActivityByTimeRange(ctx, TestTaskID1, base.Add(time.Second), base.Add(3*time.Second))
+		require.NoError(t, err)
+		var ids []string
+		for _, r := range records {
+			ids = append(ids, r.EventID)
+		}
+		assert.Equal(t, []string{"evt-1", "evt-2", "evt-3"}, ids)
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		records, err := fixture.DB.GetAIActivityByTimeRange(ctx, TestTaskID1, base.Add(time.Hour), base.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.NotNil(t, records)
+		assert.Empty(t, records)
+	})
+}
+
+// TestSearchAIActivity tests case-insensitive matching across the searched columns and the tool qualifier
+func TestSearchAIActivity(t *testing.T) {
+	fixture := UseFreshTestDatabase(t)
+	ctx := context.Background()
+
+	NewProjectBuilder().Create(t, fixture.DB, ctx)
+	NewTaskBuilder().Create(t, fixture.DB, ctx)
+	NewTaskBuilder().WithID(TestTaskID2).Create(t, fixture.DB, ctx)
+
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	records := []*models.AIActivityRecord{
+		{EventID: "evt-bash", ToolName: "Bash", ToolInputSummary: "go test ./..."},
+		{EventID: "evt-read", ToolName: "Read", ToolInputSummary: "/workspace/main_test.go"},
+		{EventID: "evt-output", ContentPreview: "All TESTS pass"},
+		{EventID: "evt-percent", ContentPreview: "coverage 100%"},
+		{EventID: "evt-grep", ToolName: "Grep", ContentPreview: "no matches"},
+	}
+	for i, record := range records {
+		record.TaskID = TestTaskID1
+		record.EventType = models.AIEventToolUse
+		record.Timestamp = base.Add(time.Duration(i) * time.Second)
+		require.NoError(t, fixture.DB.SaveAIActivityRecord(ctx, record))
+	}
+	require.NoError(t, fixture.DB.SaveAIActivityRecord(ctx, &models.AIActivityRecord{
+		EventID:          "evt-other",
+		TaskID:           TestTaskID2,
+		EventType:        models.AIEventToolUse,
+		ToolName:         "Bash",
+		ToolInputSummary: "go test ./...",
+		Timestamp:        base,
+	}))
+
+	tests := []struct {
+		name   string
+		taskID string
+		query  string
+		limit  int
+		want   []string
+	}{
+		{"AcrossColumnsIgnoringCase", TestTaskID1, "test", 0, []string{"evt-bash", "evt-read", "evt-output"}},
+		{"ToolName", TestTaskID1, "grep", 0, []string{"evt-grep"}},
+		{"ToolQualifier", TestTaskID1, "tool:bash test", 0, []string{"evt-bash"}},
+		{"ToolQualifierOnly", TestTaskID1, "tool:Read", 0, []string{"evt-read"}},
+		{"WildcardsMatchLiterally", TestTaskID1, "100%", 0, []string{"evt-percent"}},
+		{"Limit", TestTaskID1, "test", 2, []string{"evt-bash", "evt-read"}},
+		{"AllTasks", "", "tool:Bash", 0, []string{"evt-other", "evt-bash"}},
+		{"NoMatch", TestTaskID1, "docker", 0, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := fixture.DB.SearchAIActivity(ctx, tt.taskID, tt.query, tt.limit)
+			require.NoError(t, err)
+			ids := make([]string, len(found))
+			for i, r := range found {
+				ids[i] = r.EventID
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestParseActivitySearch(t *testing.T) {
+	tests := []struct {
+		query, tool, text string
+	}{
+		{"go test", "", "go test"},
+		{"tool:Bash go test", "Bash", "go test"},
+		{"  TOOL:Read  ", "Read", ""},
+		{"not tool:Bash", "", "not tool:Bash"},
+		{"tool:", "", ""},
+	}
+	for _, tt := range tests {
+		tool, text := parseActivitySearch(tt.query)
+		assert.Equal(t, tt.tool, tool, tt.query)
+		assert.Equal(t, tt.text, text, tt.query)
+	}
+}
+
+// TestDeleteAIActivityOlderThan tests that pruning removes only records before the cutoff, across tasks
+func TestDeleteAIActivityOlderThan(t *testing.T) {
+	fixture := UseFreshTestDatabase(t)
+	ctx := context.Background()
+
+	NewProjectBuilder().Create(t, fixture.DB, ctx)
+	NewTaskBuilder().Create(t, fixture.DB, ctx)
+	NewTaskBuilder().WithID(TestTaskID2).Create(t, fixture.DB, ctx)
+
+	cutoff := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	for _, r := range []struct {
+		id, taskID string
+		at         time.Time
+	}{
+		{"evt-old-1", TestTaskID1, cutoff.Add(-48 * time.Hour)},
+		{"evt-old-2", TestTaskID2, cutoff.Add(-time.Second)},
+		{"evt-at-cutoff", TestTaskID1, cutoff},
+		{"evt-new", TestTaskID2, cutoff.Add(time.Hour)},
+	} {
+		require.NoError(t, fixture.DB.SaveAIActivityRecord(ctx, &models.AIActivityRecord{
+			EventID:   r.id,
+			TaskID:    r.taskID,
+			EventType: models.AIEventToolUse,
+			Timestamp: r.at,
+		}))
+	}
+
+	count, err := fixture.DB.CountAIActivityOlderThan(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	// Batches take the oldest records first
+	deleted, err := fixture.DB.DeleteAIActivityOlderThanBatch(ctx, cutoff, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	records1, err := fixture.DB.GetAIActivityByTask(ctx, TestTaskID1)
+	require.NoError(t, err)
+	require.Len(t, records1, 1)
+	assert.Equal(t, "evt-at-cutoff", records1[0].EventID)
+
+	deleted, err = fixture.DB.DeleteAIActivityOlderThanBatch(ctx, cutoff, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	records2, err := fixture.DB.GetAIActivityByTask(ctx, TestTaskID2)
+	require.NoError(t, err)
+	require.Len(t, records2, 1)
+	assert.Equal(t, "evt-new", records2[0].EventID)
+
+	// Nothing left to prune
+	deleted, err = fixture.DB.DeleteAIActivityOlderThanBatch(ctx, cutoff, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+}
+
 // TestAIActivityIsolation tests that AI activity records are properly isolated between tasks
 func TestAIActivityIsolation(t *testing.T) {
 	fixture := UseFreshTestDatabase(t)
@@ -706,6 +1083,15 @@ func TestAIActivityIsolation(t *testing.T) {
 		require.NoError(t, err)
 		assert.Len(t, recordsB, 2)
 	})
+
+	t.Run("DeleteTaskDeletesItsActivity", func(t *testing.T) {
+		err := fixture.DB.DeleteTask(ctx, "task-b")
+		require.NoError(t, err)
+
+		recordsB, err := fixture.DB.GetAIActivityByTask(ctx, "task-b")
+		require.NoError(t, err)
+		assert.Empty(t, recordsB, "no orphaned records are left behind")
+	})
 }
 
 // TestConcurrentOperations tests database operations under concurrent access
@@ -735,3 +1121,161 @@ func TestConcurrentOperations(t *testing.T) {
 		assert.Len(t, tasks, numTasks)
 	})
 }
+
+// TestAIActivityCompaction tests batched compaction of finished tasks' activity
+func TestAIActivityCompaction(t *testing.T) {
+	fixture := UseFreshTestDatabase(t)
+	ctx := context.Background()
+
+	NewProjectBuilder().Create(t, fixture.DB, ctx)
+	NewTaskBuilder().WithID("task-done").WithTitle("Done").WithStatus(models.TaskStatusCompleted).Create(t, fixture.DB, ctx)
+	NewTaskBuilder().WithID("task-running").WithTitle("Running").WithStatus(models.TaskStatusInProgress).Create(t, fixture.DB, ctx)
+
+	now := time.Now()
+	require.NoError(t, fixture.DB.CreatePipelineRun(ctx, &models.PipelineRun{
+		ID: "run-done", ProjectID: TestProjectID1, Status: models.PipelineRunStatusCompleted, CompletedAt: &now,
+	}))
+	require.NoError(t, fixture.DB.CreatePipelineRun(ctx, &models.PipelineRun{
+		ID: "run-running", ProjectID: TestProjectID1, Status: models.PipelineRunStatusRunning,
+	}))
+
+	save := func(eventID, taskID, runID string, inputTokens int) {
+		require.NoError(t, fixture.DB.SaveAIActivityRecord(ctx, &models.AIActivityRecord{
+			EventID:     eventID,
+			TaskID:      taskID,
+			RunID:       runID,
+			EventType:   models.AIEventToolUse,
+			Timestamp:   now,
+			InputTokens: inputTokens,
+			RawPayload:  `{"payload":true}`,
+		}))
+	}
+	for i := 0; i < 5; i++ {
+		save(fmt.Sprintf("evt-done-%d", i), "task-done", "", 10)
+		save(fmt.Sprintf("evt-running-%d", i), "task-running", "", 10)
+		save(fmt.Sprintf("evt-run-done-%d", i), "step-1", "run-done", 1)
+		save(fmt.Sprintf("evt-run-running-%d", i), "step-1", "run-running", 1)
+	}
+
+	// All finished owners were updated just now, so a cutoff in the future includes them
+	cutoff := now.Add(time.Hour)
+
+	t.Run("FindCompactableActivity", func(t *testing.T) {
+		groups, err := fixture.DB.FindCompactableActivity(ctx, cutoff)
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+		for _, g := range groups {
+			assert.Equal(t, 5, g.Records)
+			assert.NotEqual(t, "task-running", g.TaskID)
+			assert.NotEqual(t, "run-running", g.RunID)
+		}
+
+		groups, err = fixture.DB.FindCompactableActivity(ctx, now.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, groups, "owners finished after the cutoff are not compactable")
+	})
+
+	t.Run("CompactActivityBatch keeps exact summary across batches", func(t *testing.T) {
+		for {
+			n, err := fixture.DB.CompactActivityBatch(ctx, "task-done", "", cutoff, true, 2)
+			require.NoError(t, err)
+			if n == 0 {
+				break
+			}
+		}
+
+		records, err := fixture.DB.GetAIActivityByTask(ctx, "task-done")
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, models.AIEventCompactionSummary, records[0].EventType)
+		assert.Equal(t, 5, records[0].CompactedCount)
+		assert.Equal(t, 50, records[0].InputTokens)
+
+		totals, err := fixture.DB.GetTokenTotalsByTask(ctx, "task-done")
+		require.NoError(t, err)
+		assert.Equal(t, 50, totals.InputTokens)
+	})
+
+	t.Run("CompactActivityBatch without summary", func(t *testing.T) {
+		n, err := fixture.DB.CompactActivityBatch(ctx, "step-1", "run-done", cutoff, false, 100)
+		require.NoError(t, err)
+		assert.Equal(t, 5, n)
+
+		records, err := fixture.DB.GetAIActivityByRunID(ctx, "run-done")
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("running owners are untouched", func(t *testing.T) {
+		n, err := fixture.DB.CompactActivityBatch(ctx, "task-running", "", cutoff, true, 100)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+
+		records, err := fixture.DB.GetAIActivityByTask(ctx, "task-running")
+		require.NoError(t, err)
+		assert.Len(t, records, 5)
+
+		records, err = fixture.DB.GetAIActivityByRunID(ctx, "run-running")
+		require.NoError(t, err)
+		assert.Len(t, records, 5)
+	})
+}
+
+func TestProjectDefaultAgentConfig(t *testing.T) {
+	fixture := UseFreshTestDatabase(t)
+	ctx := context.Background()
+
+	require.NoError(t, fixture.DB.CreateProject(ctx, &models.Project{
+		ID:   "project-with-default",
+		Name: "With default",
+		DefaultAgentConfig: &models.ProjectAgentConfig{
+			ToolName:    "claude",
+			ToolOptions: map[string]interface{}{"model": "claude-sonnet-4-5"},
+			FlagFormat:  "equals",
+		},
+	}))
+	require.NoError(t, fixture.DB.CreateProject(ctx, &models.Project{ID: "project-without-default", Name: "Without default"}))
+
+	withDefault, err := fixture.DB.GetProject(ctx, "project-with-default")
+	require.NoError(t, err)
+	require.NotNil(t, withDefault.DefaultAgentConfig)
+	assert.Equal(t, "claude", withDefault.DefaultAgentConfig.ToolName)
+	assert.Equal(t, "equals", withDefault.DefaultAgentConfig.FlagFormat)
+	assert.Equal(t, "claude-sonnet-4-5", withDefault.DefaultAgentConfig.ToolOptions["model"])
+
+	withoutDefault, err := fixture.DB.GetProject(ctx, "project-without-default")
+	require.NoError(t, err)
+	assert.True(t, withoutDefault.DefaultAgentConfig == nil || withoutDefault.DefaultAgentConfig.IsZero())
+}
+
+// TestCreateProjectWithPipelines tests that a project and its pipelines are created together or not at all
+func TestCreateProjectWithPipelines(t *testing.T) {
+	fixture := UseFreshTestDatabase(t)
+	ctx := context.Background()
+
+	project := &models.Project{ID: "project-imported", Name: "Imported"}
+	pipelines := []*models.Pipeline{
+		{ID: "project-imported-pipeline-1", Name: "review", ProjectID: project.ID},
+		{ID: "project-imported-pipeline-2", Name: "release", ProjectID: project.ID},
+	}
+	require.NoError(t, fixture.DB.CreateProjectWithPipelines(ctx, project, pipelines))
+
+	saved, err := fixture.DB.GetPipelinesByProject(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Len(t, saved, 2)
+
+	// A failing pipeline rolls the project back
+	failing := &models.Project{ID: "project-failing", Name: "Failing"}
+	err = fixture.DB.CreateProjectWithPipelines(ctx, failing, []*models.Pipeline{
+		{ID: "project-failing-pipeline-1", Name: "ok", ProjectID: failing.ID},
+		{ID: "project-imported-pipeline-1", Name: "duplicate", ProjectID: failing.ID},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"duplicate"`)
+
+	_, err = fixture.DB.GetProject(ctx, failing.ID)
+	assert.Error(t, err)
+	saved, err = fixture.DB.GetPipelinesByProject(ctx, failing.ID)
+	require.NoError(t, err)
+	assert.Empty(t, saved)
+}
@@ -5,7 +5,10 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/noldarim/noldarim/internal/config"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
@@ -16,6 +19,28 @@ import (
 	"gorm.io/gorm/logger"
 )
 
+// ErrStaleWrite matches a StaleWriteError with errors.Is
+var ErrStaleWrite = errors.New("stale write")
+
+// StaleWriteError is returned by versioned updates when the row was updated after the
+// caller read it. The caller should reload the row and retry.
+type StaleWriteError struct {
+	Table           string
+	ID              string
+	ExpectedVersion int
+	CurrentVersion  int
+}
+
+func (e *StaleWriteError) Error() string {
+	return fmt.Sprintf("stale write to %s %s: expected version %d, current version is %d",
+		e.Table, e.ID, e.ExpectedVersion, e.CurrentVersion)
+}
+
+// Is makes errors.Is(err, ErrStaleWrite) match
+func (e *StaleWriteError) Is(target error) bool {
+	return target == ErrStaleWrite
+}
+
 // GormDB wraps the GORM database connection
 type GormDB struct {
 	db *gorm.DB
@@ -38,9 +63,11 @@ func (db *GormDB) AutoMigrate() error {
 	if err := db.db.AutoMigrate(
 		&models.Project{},
 		&models.Task{},
+		&models.TaskLabel{},
 		&models.AIActivityRecord{},
 		&models.Pipeline{},
 		&models.PipelineRun{},
+		&models.PipelineRunLabel{},
 		&models.StepResult{},
 		&models.RunStepSnapshot{},
 		&models.ContainerLog{},
@@ -73,6 +100,14 @@ func (db *GormDB) ValidateSchema() error {
 		missingTables = append(missingTables, "tasks")
 	}
 
+	if !db.db.Migrator().HasTable(&models.TaskLabel{}) {
+		missingTables = append(missingTables, "task_labels")
+	}
+
+	if !db.db.Migrator().HasTable(&models.PipelineRunLabel{}) {
+		missingTables = append(missingTables, "pipeline_run_labels")
+	}
+
 	if !db.db.Migrator().HasTable(&models.AIActivityRecord{}) {
 		missingTables = append(missingTables, "ai_activity_records")
 	}
@@ -86,7 +121,7 @@ func (db *GormDB) ValidateSchema() error {
 	}
 
 	// Check for required columns in projects table
-	projectColumns := []string{"id", "name", "description", "last_updated_at", "agent_id", "created_at"}
+	projectColumns := []string{"id", "name", "description", "last_updated_at", "agent_id", "created_at", "default_agent_config", "version"}
 	for _, col := range projectColumns {
 		if !db.db.Migrator().HasColumn(&models.Project{}, col) {
 			missingColumns = append(missingColumns, fmt.Sprintf("projects.%s", col))
@@ -96,7 +131,7 @@ func (db *GormDB) ValidateSchema() error {
 	// Check for required columns in tasks table
 	taskColumns := []string{
 		"id", "title", "description", "status", "project_id", "exec_history",
-		"last_updated_at", "agent_id", "created_at", "task_file_path", "branch_name",
+		"last_updated_at", "agent_id", "created_at", "task_file_path", "branch_name", "version",
 	}
 	for _, col := range taskColumns {
 		if !db.db.Migrator().HasColumn(&models.Task{}, col) {
@@ -108,6 +143,7 @@ func (db *GormDB) ValidateSchema() error {
 	// run_id and step_id are required for real-time pipeline activity streaming.
 	aiActivityColumns := []string{
 		"event_id", "task_id", "run_id", "step_id", "event_type", "timestamp", "raw_payload",
+		"source_file", "source_line",
 	}
 	for _, col := range aiActivityColumns {
 		if !db.db.Migrator().HasColumn(&models.AIActivityRecord{}, col) {
@@ -183,23 +219,128 @@ func (db *GormDB) GetTasksByProject(ctx context.Context, projectID string) (map[
 	for _, task := range tasks {
 		result[task.ID] = &task
 	}
+	if err := db.attachLabels(ctx, result); err != nil {
+		return nil, err
+	}
 
 	return result, nil
 }
 
+// GetTasksByLabels retrieves the tasks of a project that carry every one of labels
+func (db *GormDB) GetTasksByLabels(ctx context.Context, projectID string, labels []string) (map[string]*models.Task, error) {
+	var tasks []models.Task
+
+	labelled := db.db.Model(&models.TaskLabel{}).
+		Select("task_id").
+		Where("label IN ?", labels).
+		Group("task_id").
+		Having("COUNT(DISTINCT label) = ?", len(labels))
+	err := db.db.WithContext(ctx).
+		Where("project_id = ? AND id IN (?)", projectID, labelled).
+		Order("last_updated_at DESC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*models.Task, len(tasks))
+	for _, task := range tasks {
+		result[task.ID] = &task
+	}
+	if err := db.attachLabels(ctx, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AddTaskLabels attaches labels to a task, skipping ones it already has
+func (db *GormDB) AddTaskLabels(ctx context.Context, taskID string, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	rows := make([]models.TaskLabel, 0, len(labels))
+	for _, label := range labels {
+		rows = append(rows, models.TaskLabel{TaskID: taskID, Label: label})
+	}
+	return db.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&rows).Error
+}
+
+// RemoveTaskLabels detaches labels from a task; labels it doesn't have are ignored
+func (db *GormDB) RemoveTaskLabels(ctx context.Context, taskID string, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	return db.db.WithContext(ctx).
+		Where("task_id = ? AND label IN ?", taskID, labels).
+		Delete(&models.TaskLabel{}).Error
+}
+
+// attachLabels fills in the Labels of tasks, keyed by task ID, with one query
+func (db *GormDB) attachLabels(ctx context.Context, tasks map[string]*models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(tasks))
+	for id := range tasks {
+		ids = append(ids, id)
+	}
+
+	var rows []models.TaskLabel
+	err := db.db.WithContext(ctx).
+		Where("task_id IN ?", ids).
+		Order("label").
+		Find(&rows).Error
+	if err != nil {
+		return fmt.Errorf("failed to load task labels: %w", err)
+	}
+	for _, row := range rows {
+		task := tasks[row.TaskID]
+		task.Labels = append(task.Labels, row.Label)
+	}
+	return nil
+}
+
 // CreateProject creates a new project
 func (db *GormDB) CreateProject(ctx context.Context, project *models.Project) error {
 	return db.db.WithContext(ctx).Create(project).Error
 }
 
-// UpdateProject updates project details
-func (db *GormDB) UpdateProject(ctx context.Context, projectID, name, description string) error {
-	return db.db.WithContext(ctx).Model(&models.Project{}).
-		Where("id = ?", projectID).
+// CreateProjectWithPipelines creates a project and its pipelines in one transaction, so a
+// failure leaves neither behind
+func (db *GormDB) CreateProjectWithPipelines(ctx context.Context, project *models.Project, pipelines []*models.Pipeline) error {
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(project).Error; err != nil {
+			return fmt.Errorf("failed to create project: %w", err)
+		}
+		for _, pipeline := range pipelines {
+			if err := tx.Create(pipeline).Error; err != nil {
+				return fmt.Errorf("failed to create pipeline %q: %w", pipeline.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateProject updates project details if the project is still at expectedVersion,
+// returning a StaleWriteError otherwise
+func (db *GormDB) UpdateProject(ctx context.Context, projectID, name, description string, expectedVersion int) error {
+	result := db.db.WithContext(ctx).Model(&models.Project{}).
+		Where("id = ? AND version = ?", projectID, expectedVersion).
 		Updates(map[string]any{
 			"name":        name,
 			"description": description,
-		}).Error
+			"version":     gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return db.versionConflict(ctx, &models.Project{}, "projects", projectID, expectedVersion)
+	}
+	return nil
 }
 
 // DeleteProject deletes a project
@@ -207,38 +348,86 @@ func (db *GormDB) DeleteProject(ctx context.Context, projectID string) error {
 	return db.db.WithContext(ctx).Delete(&models.Project{}, "id = ?", projectID).Error
 }
 
-// CreateTask creates a new task
+// CreateTask creates a new task along with its labels
 func (db *GormDB) CreateTask(ctx context.Context, task *models.Task) error {
-	return db.db.WithContext(ctx).Create(task).Error
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(task).Error; err != nil {
+			return err
+		}
+		return (&GormDB{db: tx}).AddTaskLabels(ctx, task.ID, task.Labels)
+	})
 }
 
-// UpdateTaskStatus updates a task's status
+// UpdateTaskStatus updates a task's status. It is not versioned, but still bumps the
+// version so edits based on the old state are detected as stale.
 func (db *GormDB) UpdateTaskStatus(ctx context.Context, taskID string, status models.TaskStatus) error {
 	return db.db.WithContext(ctx).Model(&models.Task{}).
 		Where("id = ?", taskID).
-		Update("status", status).Error
+		Updates(map[string]any{
+			"status":  status,
+			"version": gorm.Expr("version + 1"),
+		}).Error
 }
 
-// UpdateTask updates task details
-func (db *GormDB) UpdateTask(ctx context.Context, taskID, title, description string) error {
-	return db.db.WithContext(ctx).Model(&models.Task{}).
-		Where("id = ?", taskID).
+// UpdateTask updates task details if the task is still at expectedVersion, returning
+// a StaleWriteError otherwise
+func (db *GormDB) UpdateTask(ctx context.Context, taskID, title, description string, expectedVersion int) error {
+	result := db.db.WithContext(ctx).Model(&models.Task{}).
+		Where("id = ? AND version = ?", taskID, expectedVersion).
 		Updates(map[string]any{
 			"title":       title,
 			"description": description,
-		}).Error
+			"version":     gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return db.versionConflict(ctx, &models.Task{}, "tasks", taskID, expectedVersion)
+	}
+	return nil
 }
 
-// UpdateTaskGitDiff updates a task's git diff field
+// UpdateTaskGitDiff updates a task's git diff field; like UpdateTaskStatus it bumps the version
 func (db *GormDB) UpdateTaskGitDiff(ctx context.Context, taskID, gitDiff string) error {
 	return db.db.WithContext(ctx).Model(&models.Task{}).
 		Where("id = ?", taskID).
-		Update("git_diff", gitDiff).Error
+		Updates(map[string]any{
+			"git_diff": gitDiff,
+			"version":  gorm.Expr("version + 1"),
+		}).Error
 }
 
-// DeleteTask deletes a task
+// UpdateTaskProcessCheckpoint stores a task's processing checkpoint. It leaves the version
+// alone: the checkpoint is workflow state, and must not make user edits of the task stale.
+func (db *GormDB) UpdateTaskProcessCheckpoint(ctx context.Context, taskID, checkpoint string) error {
+	return db.db.WithContext(ctx).Model(&models.Task{}).
+		Where("id = ?", taskID).
+		UpdateColumn("process_checkpoint", checkpoint).Error
+}
+
+// versionConflict explains why a versioned update matched no row: the row is gone,
+// or it is at another version than expected
+func (db *GormDB) versionConflict(ctx context.Context, model any, table, id string, expectedVersion int) error {
+	var current struct{ Version int }
+	err := db.db.WithContext(ctx).Model(model).Select("version").Where("id = ?", id).Take(&current).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%s %s not found: %w", table, id, err)
+		}
+		return err
+	}
+	return &StaleWriteError{Table: table, ID: id, ExpectedVersion: expectedVersion, CurrentVersion: current.Version}
+}
+
+// DeleteTask deletes a task along with its AI activity records
 func (db *GormDB) DeleteTask(ctx context.Context, taskID string) error {
-	return db.db.WithContext(ctx).Delete(&models.Task{}, "id = ?", taskID).Error
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskID).Delete(&models.AIActivityRecord{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Task{}, "id = ?", taskID).Error
+	})
 }
 
 // GetTask retrieves a single task by ID
@@ -248,6 +437,9 @@ func (db *GormDB) GetTask(ctx context.Context, taskID string) (*models.Task, err
 	if err != nil {
 		return nil, err
 	}
+	if err := db.attachLabels(ctx, map[string]*models.Task{task.ID: &task}); err != nil {
+		return nil, err
+	}
 	return &task, nil
 }
 
@@ -271,6 +463,9 @@ func (db *GormDB) FindTaskByProjectAndTitle(ctx context.Context, projectID, titl
 		}
 		return nil, err
 	}
+	if err := db.attachLabels(ctx, map[string]*models.Task{task.ID: &task}); err != nil {
+		return nil, err
+	}
 	return &task, nil
 }
 
@@ -284,6 +479,9 @@ func (db *GormDB) GetLatestTask(ctx context.Context) (*models.Task, error) {
 		}
 		return nil, err
 	}
+	if err := db.attachLabels(ctx, map[string]*models.Task{task.ID: &task}); err != nil {
+		return nil, err
+	}
 	return &task, nil
 }
 
@@ -295,6 +493,24 @@ func (db *GormDB) SaveAIActivityRecord(ctx context.Context, record *models.AIAct
 		FirstOrCreate(record).Error
 }
 
+// SaveAIActivityRecords inserts records in batches of batchSize (all at once if 0) inside
+// one transaction, so either all of them are saved or none. Records whose event ID already exists are
+// overwritten with the given values.
+func (db *GormDB) SaveAIActivityRecords(ctx context.Context, records []*models.AIActivityRecord, batchSize int) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if batchSize <= 0 {
+		batchSize = len(records)
+	}
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "event_id"}},
+			UpdateAll: true,
+		}).CreateInBatches(records, batchSize).Error
+	})
+}
+
 // UpdateAIActivityRecord updates an existing AI activity record with parsed data
 func (db *GormDB) UpdateAIActivityRecord(ctx context.Context, record *models.AIActivityRecord) error {
 	result := db.db.WithContext(ctx).
@@ -323,13 +539,19 @@ func (db *GormDB) UpdateAIActivityRecord(ctx context.Context, record *models.AIA
 			"context_depth":  record.ContextDepth,
 			// Tool info
 			"tool_name":          record.ToolName,
+			"tool_namespace":     record.ToolNamespace,
 			"tool_input_summary": record.ToolInputSummary,
 			"tool_success":       record.ToolSuccess,
 			"tool_error":         record.ToolError,
 			"file_path":          record.FilePath,
+			// Origin
+			"source_file": record.SourceFile,
+			"source_line": record.SourceLine,
 			// Content
-			"content_preview": record.ContentPreview,
-			"content_length":  record.ContentLength,
+			"content_preview":     record.ContentPreview,
+			"content_length":      record.ContentLength,
+			"result_content_type": record.ResultContentType,
+			"attachments":         record.Attachments,
 			// Raw data (in case parsing enriches it)
 			"raw_payload": record.RawPayload,
 		})
@@ -356,6 +578,121 @@ func (db *GormDB) GetAIActivityByTask(ctx context.Context, taskID string) ([]*mo
 	return records, nil
 }
 
+// GetAIActivityByTaskPaged retrieves one page of a task's AI activity records in timestamp
+// order, with the total number of records of the task. If limit is 0, returns all records
+// from offset on.
+func (db *GormDB) GetAIActivityByTaskPaged(ctx context.Context, taskID string, offset, limit int) ([]*models.AIActivityRecord, int, error) {
+	var total int64
+	err := db.db.WithContext(ctx).
+		Model(&models.AIActivityRecord{}).
+		Where("task_id = ?", taskID).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var records []*models.AIActivityRecord
+	query := db.db.WithContext(ctx).
+		Where("task_id = ?", taskID).
+		Order("timestamp ASC, created_at ASC, event_id ASC")
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&records).Error; err != nil {
+		return nil, 0, err
+	}
+	return records, int(total), nil
+}
+
+// GetAIActivityByTimeRange retrieves the AI activity records of a task with a timestamp
+// between start and end (inclusive), in timestamp order. Returns an empty slice when none match.
+func (db *GormDB) GetAIActivityByTimeRange(ctx context.Context, taskID string, start, end time.Time) ([]*models.AIActivityRecord, error) {
+	records := make([]*models.AIActivityRecord, 0)
+	err := db.db.WithContext(ctx).
+		Where("task_id = ?", taskID).
+		Where("timestamp BETWEEN ? AND ?", start, end).
+		Order("timestamp ASC, created_at ASC, event_id ASC").
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// SearchAIActivity retrieves the AI activity records of a task whose content preview, tool
+// name or tool input summary contains query, ignoring case, in timestamp order. A leading
+// "tool:<name>" qualifier restricts the results to that tool; the query may be just the
+// qualifier. An empty taskID searches all tasks. If limit is 0, returns all matching records.
+func (db *GormDB) SearchAIActivity(ctx context.Context, taskID, query string, limit int) ([]*models.AIActivityRecord, error) {
+	tool, text := parseActivitySearch(query)
+
+	q := db.db.WithContext(ctx).Model(&models.AIActivityRecord{})
+	if taskID != "" {
+		q = q.Where("task_id = ?", taskID)
+	}
+	if tool != "" {
+		q = q.Where("LOWER(tool_name) = LOWER(?)", tool)
+	}
+	if text != "" {
+		pattern := "%" + escapeLike(text) + "%"
+		q = q.Where("(content_preview ILIKE ? OR tool_name ILIKE ? OR tool_input_summary ILIKE ?)", pattern, pattern, pattern)
+	}
+	q = q.Order("timestamp ASC, created_at ASC, event_id ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	records := make([]*models.AIActivityRecord, 0)
+	if err := q.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// parseActivitySearch splits a leading "tool:<name>" qualifier off a search query
+func parseActivitySearch(query string) (tool, text string) {
+	query = strings.TrimSpace(query)
+	if len(query) < len("tool:") || !strings.EqualFold(query[:len("tool:")], "tool:") {
+		return "", query
+	}
+	tool, text, _ = strings.Cut(query[len("tool:"):], " ")
+	return tool, strings.TrimSpace(text)
+}
+
+// escapeLike escapes the LIKE wildcards in s, so it matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// StreamAIActivityByTask calls fn with each AI activity record of a task in timestamp order,
+// scanning them one at a time from a cursor instead of loading them all. An error from fn
+// stops the iteration, closes the cursor and is returned as is.
+func (db *GormDB) StreamAIActivityByTask(ctx context.Context, taskID string, fn func(*models.AIActivityRecord) error) error {
+	rows, err := db.db.WithContext(ctx).
+		Model(&models.AIActivityRecord{}).
+		Where("task_id = ?", taskID).
+		Order("timestamp ASC, created_at ASC, event_id ASC").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record models.AIActivityRecord
+		if err := db.db.ScanRows(rows, &record); err != nil {
+			return err
+		}
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 // GetAIActivityByRunID retrieves all AI activity records for a pipeline run (all steps)
 func (db *GormDB) GetAIActivityByRunID(ctx context.Context, runID string) ([]*models.AIActivityRecord, error) {
 	var records []*models.AIActivityRecord
@@ -409,6 +746,31 @@ func (db *GormDB) DeleteAIActivityByTask(ctx context.Context, taskID string) err
 		Delete(&models.AIActivityRecord{}).Error
 }
 
+// DeleteAIActivityOlderThanBatch deletes up to batchSize of the oldest AI activity records with
+// a timestamp before cutoff and returns how many were deleted
+func (db *GormDB) DeleteAIActivityOlderThanBatch(ctx context.Context, cutoff time.Time, batchSize int) (int, error) {
+	tx := db.db.WithContext(ctx)
+	batch := tx.Session(&gorm.Session{NewDB: true}).
+		Model(&models.AIActivityRecord{}).
+		Select("event_id").
+		Where("timestamp < ?", cutoff).
+		Order("timestamp ASC").
+		Limit(batchSize)
+
+	result := tx.Where("event_id IN (?)", batch).Delete(&models.AIActivityRecord{})
+	return int(result.RowsAffected), result.Error
+}
+
+// CountAIActivityOlderThan counts the AI activity records with a timestamp before cutoff
+func (db *GormDB) CountAIActivityOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	var count int64
+	err := db.db.WithContext(ctx).
+		Model(&models.AIActivityRecord{}).
+		Where("timestamp < ?", cutoff).
+		Count(&count).Error
+	return count, err
+}
+
 // GetAIActivityByEventType retrieves AI activity records filtered by event type.
 // If limit is 0, returns all matching records.
 func (db *GormDB) GetAIActivityByEventType(ctx context.Context, eventType string, limit int) ([]*models.AIActivityRecord, error) {
@@ -450,6 +812,129 @@ func (db *GormDB) GetTokenTotalsByTask(ctx context.Context, taskID string) (*Tok
 	return &result, nil
 }
 
+// ============================================================================
+// Activity Compaction
+// ============================================================================
+
+// ActivityCompactionGroup describes the compactable AI activity of one task/run
+type ActivityCompactionGroup struct {
+	TaskID          string
+	RunID           string
+	Records         int
+	RawPayloadBytes int64
+	TokenTotals
+}
+
+// compactableActivity scopes AI activity records that may be compacted: records of pipeline
+// runs or (legacy, run-less) tasks that finished before olderThan. Records of pending or
+// running owners never match. Existing compaction summaries are excluded.
+func (db *GormDB) compactableActivity(tx *gorm.DB, olderThan time.Time) *gorm.DB {
+	finishedRuns := tx.Session(&gorm.Session{NewDB: true}).
+		Model(&models.PipelineRun{}).
+		Select("id").
+		Where("status IN ?", []models.PipelineRunStatus{models.PipelineRunStatusCompleted, models.PipelineRunStatusFailed}).
+		Where("COALESCE(completed_at, updated_at) < ?", olderThan)
+
+	finishedTasks := tx.Session(&gorm.Session{NewDB: true}).
+		Model(&models.Task{}).
+		Select("id").
+		Where("status IN ?", []models.TaskStatus{models.TaskStatusCompleted, models.TaskStatusFailed}).
+		Where("last_updated_at < ?", olderThan)
+
+	return tx.Model(&models.AIActivityRecord{}).
+		Where("event_type <> ?", models.AIEventCompactionSummary).
+		Where("((COALESCE(run_id, '') <> '' AND run_id IN (?)) OR (COALESCE(run_id, '') = '' AND task_id IN (?)))",
+			finishedRuns, finishedTasks)
+}
+
+// FindCompactableActivity lists, per task/run, the AI activity that CompactActivityBatch would remove
+func (db *GormDB) FindCompactableActivity(ctx context.Context, olderThan time.Time) ([]ActivityCompactionGroup, error) {
+	var groups []ActivityCompactionGroup
+	err := db.compactableActivity(db.db.WithContext(ctx), olderThan).
+		Select("task_id, COALESCE(run_id, '') as run_id, COUNT(*) as records, " +
+			"COALESCE(SUM(LENGTH(raw_payload)), 0) as raw_payload_bytes, " +
+			"COALESCE(SUM(input_tokens), 0) as input_tokens, COALESCE(SUM(output_tokens), 0) as output_tokens, " +
+			"COALESCE(SUM(cache_read_tokens), 0) as cache_read_tokens, COALESCE(SUM(cache_create_tokens), 0) as cache_create_tokens").
+		Group("task_id, COALESCE(run_id, '')").
+		Order("task_id, run_id").
+		Scan(&groups).Error
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// CompactActivityBatch deletes up to batchSize compactable records of one task/run in a single
+// transaction and returns how many were deleted. With keepSummary, the batch's token totals and
+// record count are folded into one AIEventCompactionSummary record per task/run, so repeated
+// batches (or an interrupted run resumed later) keep the totals exact.
+func (db *GormDB) CompactActivityBatch(ctx context.Context, taskID, runID string, olderThan time.Time, keepSummary bool, batchSize int) (int, error) {
+	deleted := 0
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var batch []models.AIActivityRecord
+		err := db.compactableActivity(tx, olderThan).
+			Where("task_id = ? AND COALESCE(run_id, '') = ?", taskID, runID).
+			Select("event_id, timestamp, input_tokens, output_tokens, cache_read_tokens, cache_create_tokens").
+			Order("created_at ASC").
+			Limit(batchSize).
+			Find(&batch).Error
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		eventIDs := make([]string, 0, len(batch))
+		summary := models.AIActivityRecord{
+			EventID:        fmt.Sprintf("compaction-summary-%s-%s", taskID, runID),
+			TaskID:         taskID,
+			RunID:          runID,
+			EventType:      models.AIEventCompactionSummary,
+			ContentPreview: "Compacted AI activity",
+			CompactedCount: len(batch),
+		}
+		for _, r := range batch {
+			eventIDs = append(eventIDs, r.EventID)
+			summary.InputTokens += r.InputTokens
+			summary.OutputTokens += r.OutputTokens
+			summary.CacheReadTokens += r.CacheReadTokens
+			summary.CacheCreateTokens += r.CacheCreateTokens
+			if r.Timestamp.After(summary.Timestamp) {
+				summary.Timestamp = r.Timestamp
+			}
+		}
+
+		if keepSummary {
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "event_id"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"input_tokens":        gorm.Expr("ai_activity_records.input_tokens + EXCLUDED.input_tokens"),
+					"output_tokens":       gorm.Expr("ai_activity_records.output_tokens + EXCLUDED.output_tokens"),
+					"cache_read_tokens":   gorm.Expr("ai_activity_records.cache_read_tokens + EXCLUDED.cache_read_tokens"),
+					"cache_create_tokens": gorm.Expr("ai_activity_records.cache_create_tokens + EXCLUDED.cache_create_tokens"),
+					"compacted_count":     gorm.Expr("ai_activity_records.compacted_count + EXCLUDED.compacted_count"),
+					"timestamp":           gorm.Expr("GREATEST(ai_activity_records.timestamp, EXCLUDED.timestamp)"),
+				}),
+			}).Create(&summary).Error
+			if err != nil {
+				return fmt.Errorf("failed to save compaction summary: %w", err)
+			}
+		}
+
+		result := tx.Where("event_id IN ?", eventIDs).Delete(&models.AIActivityRecord{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = int(result.RowsAffected)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
 // ============================================================================
 // Pipeline Operations
 // ============================================================================
@@ -499,9 +984,67 @@ func (db *GormDB) DeletePipeline(ctx context.Context, pipelineID string) error {
 // PipelineRun Operations
 // ============================================================================
 
-// CreatePipelineRun creates a new pipeline run
+// CreatePipelineRun creates a new pipeline run along with its labels
 func (db *GormDB) CreatePipelineRun(ctx context.Context, run *models.PipelineRun) error {
-	return db.db.WithContext(ctx).Create(run).Error
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(run).Error; err != nil {
+			return err
+		}
+		return (&GormDB{db: tx}).AddPipelineRunLabels(ctx, run.ID, run.Labels)
+	})
+}
+
+// AddPipelineRunLabels attaches labels to a pipeline run, skipping ones it already has
+func (db *GormDB) AddPipelineRunLabels(ctx context.Context, runID string, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	rows := make([]models.PipelineRunLabel, 0, len(labels))
+	for _, label := range labels {
+		rows = append(rows, models.PipelineRunLabel{RunID: runID, Label: label})
+	}
+	return db.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&rows).Error
+}
+
+// RemovePipelineRunLabels detaches labels from a pipeline run; labels it doesn't have are ignored
+func (db *GormDB) RemovePipelineRunLabels(ctx context.Context, runID string, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	return db.db.WithContext(ctx).
+		Where("run_id = ? AND label IN ?", runID, labels).
+		Delete(&models.PipelineRunLabel{}).Error
+}
+
+// attachRunLabels fills in the Labels of runs with one query
+func (db *GormDB) attachRunLabels(ctx context.Context, runs ...*models.PipelineRun) error {
+	if len(runs) == 0 {
+		return nil
+	}
+	byID := make(map[string]*models.PipelineRun, len(runs))
+	for _, run := range runs {
+		byID[run.ID] = run
+	}
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+
+	var rows []models.PipelineRunLabel
+	err := db.db.WithContext(ctx).
+		Where("run_id IN ?", ids).
+		Order("label").
+		Find(&rows).Error
+	if err != nil {
+		return fmt.Errorf("failed to load pipeline run labels: %w", err)
+	}
+	for _, row := range rows {
+		run := byID[row.RunID]
+		run.Labels = append(run.Labels, row.Label)
+	}
+	return nil
 }
 
 // GetPipelineRun retrieves a pipeline run by ID with step results
@@ -521,6 +1064,9 @@ func (db *GormDB) GetPipelineRun(ctx context.Context, runID string) (*models.Pip
 		}
 		return nil, err
 	}
+	if err := db.attachRunLabels(ctx, &run); err != nil {
+		return nil, err
+	}
 	return &run, nil
 }
 
@@ -540,6 +1086,9 @@ func (db *GormDB) GetPipelineRunsByProject(ctx context.Context, projectID string
 	if err != nil {
 		return nil, err
 	}
+	if err := db.attachRunLabels(ctx, runs...); err != nil {
+		return nil, err
+	}
 	return runs, nil
 }
 
@@ -0,0 +1,287 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package orchestrator
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/noldarim/noldarim/internal/protocol"
+)
+
+// DefaultSubscriberBuffer is the per-subscriber channel capacity used when
+// NewEventBus is given a non-positive buffer size.
+const DefaultSubscriberBuffer = 100
+
+// backlogWarnEvery is how many queued events, beyond the channel buffer, a
+// subscriber accumulates between "falling behind" warnings. It is also how
+// many dropped events pass between "dropping events" warnings.
+const backlogWarnEvery = 1000
+
+// MaxSubscriberBacklog caps the events queued for a subscriber beyond its
+// channel buffer. When a subscriber is this far behind, its oldest queued
+// event is dropped to make room for the new one.
+const MaxSubscriberBacklog = 10000
+
+// DedupWindow is how many recent idempotency keys Publish remembers; an event
+// whose key is among them is not published again.
+const DedupWindow = 1024
+
+// EventBus fans out every event from the orchestrator's event channel to all
+// subscribers, so the TUI and the API server can consume events side by side
+// instead of competing for a single channel.
+//
+// Publish never blocks: each subscriber has a buffered channel fed from its
+// own queue, so a slow subscriber builds a backlog instead of stalling the
+// others. The backlog is capped at MaxSubscriberBacklog; past it the oldest
+// queued event is dropped and counted (see Dropped). Events carrying an
+// idempotency key already seen among the last DedupWindow keys are published
+// only once. Every subscriber receives its own deep copy of each event (see
+// protocol.CloneEvent), so no subscriber can observe another's mutations.
+type EventBus struct {
+	mu         sync.Mutex
+	subs       map[int]*subscriber
+	nextID     int
+	buffer     int
+	maxBacklog int
+	closed     bool
+
+	recentKeys *list.List // Idempotency keys of recent events; front is most recent
+	keyIndex   map[string]*list.Element
+	dedupSize  int
+
+	dropped    atomic.Int64 // Events dropped from full subscriber queues
+	duplicates atomic.Int64 // Events not published because their key was seen
+}
+
+type subscriber struct {
+	ch   chan protocol.Event
+	wake chan struct{} // Signals the pump that events were queued or the bus closed
+	done chan struct{} // Closed on unsubscribe; queued events are discarded
+
+	mu      sync.Mutex
+	queue   []protocol.Event
+	dropped int  // Events dropped from this subscriber's full queue
+	closing bool // Set when the bus closes; queued events are delivered first
+}
+
+// NewEventBus creates an event bus whose subscribers each buffer up to
+// buffer events in their channel before queueing.
+func NewEventBus(buffer int) *EventBus {
+	if buffer <= 0 {
+		buffer = DefaultSubscriberBuffer
+	}
+	return &EventBus{
+		subs:       make(map[int]*subscriber),
+		buffer:     buffer,
+		maxBacklog: MaxSubscriberBacklog,
+		recentKeys: list.New(),
+		keyIndex:   make(map[string]*list.Element),
+		dedupSize:  DedupWindow,
+	}
+}
+
+// Dropped returns how many events were dropped from full subscriber queues
+func (b *EventBus) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+// Duplicates returns how many events Publish skipped as already published
+func (b *EventBus) Duplicates() int64 {
+	return b.duplicates.Load()
+}
+
+// Subscribe registers a new subscriber and returns its event channel together
+// with a function that unsubscribes and closes the channel. The unsubscribe
+// function is safe to call more than once. Subscribing to a closed bus returns
+// an already-closed channel.
+func (b *EventBus) Subscribe() (<-chan protocol.Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan protocol.Event, b.buffer)
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	id := b.nextID
+	b.nextID++
+	sub := &subscriber{
+		ch:   ch,
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+	b.subs[id] = sub
+	go sub.pump()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() { b.unsubscribe(id, sub) })
+	}
+}
+
+// unsubscribe stops sub's pump, which closes its channel; this also applies to
+// a subscriber still draining its queue after Close
+func (b *EventBus) unsubscribe(id int, sub *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subs, id)
+	close(sub.done)
+}
+
+// Publish queues a copy of event for every current subscriber. It never
+// blocks. An event whose idempotency key was published recently is skipped,
+// and a subscriber whose queue is full loses its oldest queued event.
+func (b *EventBus) Publish(event protocol.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	if b.seenLocked(protocol.GetIdempotencyKey(event)) {
+		b.duplicates.Add(1)
+		return
+	}
+	for id, sub := range b.subs {
+		backlog, dropped := sub.enqueue(protocol.CloneEvent(event), b.maxBacklog)
+		if dropped > 0 {
+			b.dropped.Add(1)
+			if dropped%backlogWarnEvery == 1 {
+				getLog().Warn().
+					Int("subscriber", id).
+					Int("dropped", dropped).
+					Str("event_type", fmt.Sprintf("%T", event)).
+					Msg("Subscriber queue is full, dropping its oldest events")
+			}
+		} else if backlog%backlogWarnEvery == 0 {
+			getLog().Warn().
+				Int("subscriber", id).
+				Int("backlog", backlog).
+				Str("event_type", fmt.Sprintf("%T", event)).
+				Msg("Subscriber is falling behind")
+		}
+	}
+}
+
+// seenLocked reports whether key is among the recently published keys, and
+// records it as the most recent one. Events without a key are never duplicates.
+func (b *EventBus) seenLocked(key string) bool {
+	if key == "" {
+		return false
+	}
+	if elem, ok := b.keyIndex[key]; ok {
+		b.recentKeys.MoveToFront(elem)
+		return true
+	}
+	b.keyIndex[key] = b.recentKeys.PushFront(key)
+	for b.recentKeys.Len() > b.dedupSize {
+		oldest := b.recentKeys.Back()
+		b.recentKeys.Remove(oldest)
+		delete(b.keyIndex, oldest.Value.(string))
+	}
+	return false
+}
+
+// enqueue adds event to the queue and wakes the pump. A queue already holding
+// maxBacklog events first drops its oldest one. It returns the queue length and,
+// when an event was dropped, the subscriber's total of dropped events.
+func (s *subscriber) enqueue(event protocol.Event, maxBacklog int) (backlog, dropped int) {
+	s.mu.Lock()
+	if len(s.queue) >= maxBacklog {
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+		s.dropped++
+		dropped = s.dropped
+	}
+	s.queue = append(s.queue, event)
+	backlog = len(s.queue)
+	s.mu.Unlock()
+	s.signal()
+	return backlog, dropped
+}
+
+func (s *subscriber) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default: // A wake-up is already pending
+	}
+}
+
+// close makes the pump deliver what is queued, then close the channel
+func (s *subscriber) close() {
+	s.mu.Lock()
+	s.closing = true
+	s.mu.Unlock()
+	s.signal()
+}
+
+// pump moves queued events into the subscriber's channel in order. It is the
+// only sender on the channel and closes it on exit.
+func (s *subscriber) pump() {
+	defer close(s.ch)
+	for {
+		s.mu.Lock()
+		queue, closing := s.queue, s.closing
+		s.queue = nil
+		s.mu.Unlock()
+
+		for _, event := range queue {
+			select {
+			case s.ch <- event:
+			case <-s.done:
+				return
+			}
+		}
+		if len(queue) > 0 {
+			continue // More may have been queued meanwhile
+		}
+		if closing {
+			return
+		}
+		select {
+		case <-s.wake:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Run publishes every event read from source until source is closed or ctx
+// is cancelled, then closes the bus.
+func (b *EventBus) Run(ctx context.Context, source <-chan protocol.Event) {
+	defer b.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-source:
+			if !ok {
+				return
+			}
+			b.Publish(event)
+		}
+	}
+}
+
+// Close closes every subscriber channel once the events already published to
+// it have been delivered. Later Publish calls are no-ops.
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for id, sub := range b.subs {
+		delete(b.subs, id)
+		sub.close()
+	}
+}
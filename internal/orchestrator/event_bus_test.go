@@ -0,0 +1,207 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/protocol"
+)
+
+func drain(t *testing.T, ch <-chan protocol.Event) []protocol.Event {
+	t.Helper()
+	var events []protocol.Event
+	for {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for subscriber channel to close")
+		}
+	}
+}
+
+func TestEventBus_FansOutToAllSubscribers(t *testing.T) {
+	bus := NewEventBus(100)
+	source := make(chan protocol.Event)
+
+	first, unsubFirst := bus.Subscribe()
+	defer unsubFirst()
+	second, unsubSecond := bus.Subscribe()
+	defer unsubSecond()
+
+	done := make(chan struct{})
+	go func() {
+		bus.Run(context.Background(), source)
+		close(done)
+	}()
+
+	const n = 50
+	for i := 0; i < n; i++ {
+		source <- protocol.ErrorEvent{Message: fmt.Sprintf("event-%d", i)}
+	}
+	close(source)
+	<-done
+
+	var wg sync.WaitGroup
+	results := make([][]protocol.Event, 2)
+	for i, ch := range []<-chan protocol.Event{first, second} {
+		wg.Add(1)
+		go func(i int, ch <-chan protocol.Event) {
+			defer wg.Done()
+			results[i] = drain(t, ch)
+		}(i, ch)
+	}
+	wg.Wait()
+
+	for _, events := range results {
+		require.Len(t, events, n)
+		for i, event := range events {
+			assert.Equal(t, fmt.Sprintf("event-%d", i), event.(protocol.ErrorEvent).Message)
+		}
+	}
+}
+
+func TestEventBus_Unsubscribe(t *testing.T) {
+	bus := NewEventBus(10)
+	ch, unsubscribe := bus.Subscribe()
+	other, unsubOther := bus.Subscribe()
+	defer unsubOther()
+
+	unsubscribe()
+	unsubscribe() // idempotent
+
+	_, ok := <-ch
+	assert.False(t, ok, "unsubscribed channel should be closed")
+
+	bus.Publish(protocol.ErrorEvent{Message: "after"})
+	event := <-other
+	assert.Equal(t, "after", event.(protocol.ErrorEvent).Message)
+}
+
+func TestEventBus_SlowConsumerDoesNotBlockOthers(t *testing.T) {
+	bus := NewEventBus(2)
+	slow, unsubSlow := bus.Subscribe()
+	defer unsubSlow()
+	fast, unsubFast := bus.Subscribe()
+	defer unsubFast()
+
+	received := make(chan int, 10)
+	go func() {
+		for event := range fast {
+			received <- len(event.(protocol.ErrorEvent).Message)
+		}
+	}()
+
+	const n = 5
+	for i := 0; i < n; i++ {
+		bus.Publish(protocol.ErrorEvent{Message: fmt.Sprintf("event-%d", i)})
+		select {
+		case <-received:
+		case <-time.After(time.Second):
+			t.Fatal("fast subscriber was blocked by the slow one")
+		}
+	}
+	bus.Close()
+
+	events := drain(t, slow)
+	require.Len(t, events, n, "slow subscriber receives its whole backlog")
+	for i, event := range events {
+		assert.Equal(t, fmt.Sprintf("event-%d", i), event.(protocol.ErrorEvent).Message)
+	}
+}
+
+func TestEventBus_SubscribersGetTheirOwnCopy(t *testing.T) {
+	bus := NewEventBus(10)
+	first, unsubFirst := bus.Subscribe()
+	defer unsubFirst()
+	second, unsubSecond := bus.Subscribe()
+	defer unsubSecond()
+
+	published := protocol.ErrorEvent{Message: "boom", Context: "step"}
+	loaded := protocol.TasksLoadedEvent{Labels: []string{"bug"}}
+	bus.Publish(loaded)
+	bus.Publish(published)
+
+	mine := (<-first).(protocol.TasksLoadedEvent)
+	mine.Labels[0] = "changed"
+
+	theirs := (<-second).(protocol.TasksLoadedEvent)
+	assert.Equal(t, []string{"bug"}, theirs.Labels)
+	assert.Equal(t, []string{"bug"}, loaded.Labels, "the publisher's event is untouched")
+	assert.Equal(t, published, <-first)
+}
+
+func TestEventBus_SubscribeAfterClose(t *testing.T) {
+	bus := NewEventBus(0)
+	bus.Close()
+	bus.Publish(protocol.ErrorEvent{Message: "ignored"})
+
+	ch, unsubscribe := bus.Subscribe()
+	defer unsubscribe()
+	_, ok := <-ch
+	assert.False(t, ok)
+}
+
+func TestEventBus_SkipsRecentlyPublishedKeys(t *testing.T) {
+	bus := NewEventBus(10)
+	bus.dedupSize = 2
+	ch, unsubscribe := bus.Subscribe()
+	defer unsubscribe()
+
+	keyed := func(key, message string) protocol.ErrorEvent {
+		return protocol.ErrorEvent{Metadata: protocol.Metadata{IdempotencyKey: key}, Message: message}
+	}
+	bus.Publish(keyed("a", "first a"))
+	bus.Publish(keyed("a", "retried a")) // duplicate
+	bus.Publish(keyed("b", "first b"))
+	bus.Publish(keyed("", "no key"))
+	bus.Publish(keyed("", "no key again")) // events without a key are never duplicates
+	bus.Publish(keyed("c", "first c"))     // evicts a, the least recently seen key
+	bus.Publish(keyed("a", "a after eviction"))
+	bus.Close()
+
+	var messages []string
+	for _, event := range drain(t, ch) {
+		messages = append(messages, event.(protocol.ErrorEvent).Message)
+	}
+	assert.Equal(t, []string{"first a", "first b", "no key", "no key again", "first c", "a after eviction"}, messages)
+	assert.Equal(t, int64(1), bus.Duplicates())
+}
+
+func TestEventBus_FullQueueDropsOldest(t *testing.T) {
+	bus := NewEventBus(1)
+	bus.maxBacklog = 3
+	slow, unsubSlow := bus.Subscribe()
+	defer unsubSlow()
+
+	// The pump holds at most one event in the channel and one in hand, so
+	// publishing ten events into a three-event queue must drop some
+	const n = 10
+	for i := 0; i < n; i++ {
+		bus.Publish(protocol.ErrorEvent{Message: fmt.Sprintf("event-%d", i)})
+	}
+	bus.Close()
+
+	events := drain(t, slow)
+	assert.Equal(t, int64(n-len(events)), bus.Dropped())
+	assert.Positive(t, bus.Dropped())
+	require.GreaterOrEqual(t, len(events), 3)
+
+	// Whatever was dropped, the newest events survive, in order
+	tail := events[len(events)-3:]
+	for i, event := range tail {
+		assert.Equal(t, fmt.Sprintf("event-%d", n-3+i), event.(protocol.ErrorEvent).Message)
+	}
+}
@@ -48,6 +48,14 @@ func (m *MockTemporalClient) QueryWorkflow(ctx context.Context, workflowID, quer
 	return callArgs.Error(0)
 }
 
+func (m *MockTemporalClient) ListRunningWorkflows(ctx context.Context, workflowType string) ([]temporal.WorkflowExecution, error) {
+	args := m.Called(ctx, workflowType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]temporal.WorkflowExecution), args.Error(1)
+}
+
 func (m *MockTemporalClient) CancelWorkflow(ctx context.Context, workflowID string) error {
 	args := m.Called(ctx, workflowID)
 	return args.Error(0)
@@ -16,9 +16,10 @@ const (
 	AIEventSessionEnd   AIEventType = "session_end"
 
 	// Tool events
-	AIEventToolUse     AIEventType = "tool_use"     // Before tool execution
-	AIEventToolResult  AIEventType = "tool_result"  // After tool execution
-	AIEventToolBlocked AIEventType = "tool_blocked" // Tool was blocked by user/policy
+	AIEventToolUse         AIEventType = "tool_use"          // Before tool execution
+	AIEventToolResult      AIEventType = "tool_result"       // After tool execution
+	AIEventToolBlocked     AIEventType = "tool_blocked"      // Tool was blocked by user/policy
+	AIEventToolResultDelta AIEventType = "tool_result_delta" // Partial output of a still-running tool
 
 	// Processing events
 	AIEventThinking  AIEventType = "thinking"  // AI thinking/reasoning
@@ -35,9 +36,73 @@ const (
 
 	// User events
 	AIEventUserPrompt AIEventType = "user_prompt"
+
+	// Maintenance events
+	AIEventCompactionSummary AIEventType = "compaction_summary" // Aggregate left behind when old records are compacted
 )
 
 // GenerateEventID creates a unique event ID
 func GenerateEventID() string {
 	return time.Now().Format("20060102150405.000000000")
 }
+
+// IsTerminal reports whether the event ends an AI session
+func (t AIEventType) IsTerminal() bool {
+	switch t {
+	case AIEventSessionEnd, AIEventStop, AIEventError:
+		return true
+	}
+	return false
+}
+
+// IsToolRelated reports whether the event belongs to a tool call
+func (t AIEventType) IsToolRelated() bool {
+	switch t {
+	case AIEventToolUse, AIEventToolResult, AIEventToolBlocked, AIEventToolResultDelta:
+		return true
+	}
+	return false
+}
+
+// IsContentBearing reports whether the event's ContentPreview carries text worth showing
+func (t AIEventType) IsContentBearing() bool {
+	switch t {
+	case AIEventUserPrompt, AIEventThinking, AIEventAIOutput, AIEventStreaming,
+		AIEventToolResult, AIEventToolResultDelta, AIEventError:
+		return true
+	}
+	return false
+}
+
+// DisplayIcon returns the single-glyph marker used when printing the event as a log line
+func (t AIEventType) DisplayIcon() string {
+	switch t {
+	case AIEventSessionStart:
+		return "*"
+	case AIEventSessionEnd, AIEventStop:
+		return "X"
+	case AIEventToolUse:
+		return ">"
+	case AIEventToolResult:
+		return "<"
+	case AIEventToolResultDelta:
+		return "."
+	case AIEventToolBlocked:
+		return "#"
+	case AIEventThinking:
+		return "~"
+	case AIEventAIOutput, AIEventStreaming:
+		return " "
+	case AIEventError:
+		return "!"
+	case AIEventUserPrompt:
+		return "@"
+	case AIEventSubagentStart:
+		return "+"
+	case AIEventSubagentStop:
+		return "-"
+	case AIEventCompactionSummary:
+		return "="
+	}
+	return "?"
+}
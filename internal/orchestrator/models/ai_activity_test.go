@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/noldarim/noldarim/internal/aiobs/types"
 )
@@ -25,6 +26,52 @@ func TestGenerateEventID(t *testing.T) {
 	assert.Len(t, id1, 24) // "20060102150405.000000000"
 }
 
+func TestAIEventType_Classification(t *testing.T) {
+	tests := []struct {
+		eventType      AIEventType
+		terminal       bool
+		toolRelated    bool
+		contentBearing bool
+		icon           string
+	}{
+		{AIEventSessionStart, false, false, false, "*"},
+		{AIEventSessionEnd, true, false, false, "X"},
+		{AIEventToolUse, false, true, false, ">"},
+		{AIEventToolResult, false, true, true, "<"},
+		{AIEventToolBlocked, false, true, false, "#"},
+		{AIEventToolResultDelta, false, true, true, "."},
+		{AIEventThinking, false, false, true, "~"},
+		{AIEventAIOutput, false, false, true, " "},
+		{AIEventStreaming, false, false, true, " "},
+		{AIEventError, true, false, true, "!"},
+		{AIEventStop, true, false, false, "X"},
+		{AIEventSubagentStart, false, false, false, "+"},
+		{AIEventSubagentStop, false, false, false, "-"},
+		{AIEventUserPrompt, false, false, true, "@"},
+		{AIEventCompactionSummary, false, false, false, "="},
+	}
+
+	// Every event type known to the observability layer must be classified here
+	knownTypes := map[AIEventType]bool{AIEventCompactionSummary: true}
+	for eventType := range types.EventKindMap {
+		knownTypes[AIEventType(eventType)] = true
+	}
+	require.Len(t, tests, len(knownTypes))
+
+	for _, tt := range tests {
+		t.Run(string(tt.eventType), func(t *testing.T) {
+			assert.True(t, knownTypes[tt.eventType], "unknown event type")
+			assert.Equal(t, tt.terminal, tt.eventType.IsTerminal())
+			assert.Equal(t, tt.toolRelated, tt.eventType.IsToolRelated())
+			assert.Equal(t, tt.contentBearing, tt.eventType.IsContentBearing())
+			assert.Equal(t, tt.icon, tt.eventType.DisplayIcon())
+		})
+	}
+
+	assert.Equal(t, "?", AIEventType("unknown").DisplayIcon())
+	assert.False(t, AIEventType("unknown").IsTerminal())
+}
+
 func TestAIActivityRecord_Basic(t *testing.T) {
 	trueVal := true
 	record := &AIActivityRecord{
@@ -151,9 +198,11 @@ func TestNewAIActivityRecordFromParsed(t *testing.T) {
 		ToolInputSummary:  "ls -la /tmp",
 		ToolSuccess:       &trueVal,
 		ToolError:         "",
+		ToolUseID:         "toolu_01",
 		FilePath:          "/tmp",
 		ContentPreview:    "command output preview",
 		ContentLength:     1500,
+		ResultContentType: types.ContentTypeJSON,
 		RawPayload:        json.RawMessage(`{"type":"tool_use"}`),
 	}
 
@@ -191,11 +240,13 @@ func TestNewAIActivityRecordFromParsed(t *testing.T) {
 	assert.Equal(t, "Bash", record.ToolName)
 	assert.Equal(t, "ls -la /tmp", record.ToolInputSummary)
 	assert.True(t, *record.ToolSuccess)
+	assert.Equal(t, "toolu_01", record.ToolUseID)
 	assert.Equal(t, "/tmp", record.FilePath)
 
 	// Content
 	assert.Equal(t, "command output preview", record.ContentPreview)
 	assert.Equal(t, 1500, record.ContentLength)
+	assert.Equal(t, "json", record.ResultContentType)
 
 	// Raw payload stored as string
 	assert.Equal(t, `{"type":"tool_use"}`, record.RawPayload)
@@ -7,6 +7,9 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/noldarim/noldarim/internal/aiobs/types"
@@ -69,15 +72,101 @@ func (h ExecHistory) Value() (driver.Value, error) {
 	return json.Marshal(h)
 }
 
+// Attachments represents a JSON array of the attachments of an AI activity record
+type Attachments []types.Attachment
+
+// Scan implements the sql.Scanner interface
+func (a *Attachments) Scan(value any) error {
+	*a = nil
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	default:
+		return errors.New("cannot scan Attachments from non-string/[]byte value")
+	}
+}
+
+// Value implements the driver.Valuer interface
+func (a Attachments) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal([]types.Attachment(a))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// ProjectAgentConfig is a project's default agent configuration, applied to tasks
+// that don't set their own. Empty fields fall back to the application config.
+type ProjectAgentConfig struct {
+	ToolName       string                 `json:"tool_name,omitempty"`
+	ToolVersion    string                 `json:"tool_version,omitempty"`
+	PromptTemplate string                 `json:"prompt_template,omitempty"`
+	Variables      map[string]string      `json:"variables,omitempty"`
+	ToolOptions    map[string]interface{} `json:"tool_options,omitempty"`
+	FlagFormat     string                 `json:"flag_format,omitempty"`
+	Env            map[string]string      `json:"env,omitempty"`
+
+	// MaxConcurrentTasks overrides agent.max_concurrent_tasks for this project (0 = use the global setting)
+	MaxConcurrentTasks int `json:"max_concurrent_tasks,omitempty"`
+}
+
+// IsZero reports whether no default is set
+func (c ProjectAgentConfig) IsZero() bool {
+	return c.ToolName == "" && c.ToolVersion == "" && c.PromptTemplate == "" &&
+		len(c.Variables) == 0 && len(c.ToolOptions) == 0 && c.FlagFormat == "" && len(c.Env) == 0 &&
+		c.MaxConcurrentTasks == 0
+}
+
+// Scan implements the sql.Scanner interface
+func (c *ProjectAgentConfig) Scan(value any) error {
+	*c = ProjectAgentConfig{}
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return errors.New("cannot scan ProjectAgentConfig from non-string/[]byte value")
+	}
+}
+
+// Value implements the driver.Valuer interface
+func (c ProjectAgentConfig) Value() (driver.Value, error) {
+	if c.IsZero() {
+		return nil, nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
 // Project represents the GORM model for projects
 type Project struct {
-	ID             string    `gorm:"primaryKey;type:text" json:"id"`
-	Name           string    `gorm:"not null;type:text" json:"name"`
-	Description    string    `gorm:"type:text" json:"description"`
-	RepositoryPath string    `gorm:"type:text" json:"repository_path"`
-	LastUpdatedAt  time.Time `gorm:"autoUpdateTime" json:"last_updated_at"`
-	AgentID        string    `gorm:"type:text" json:"agent_id"`
-	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ID                 string              `gorm:"primaryKey;type:text" json:"id"`
+	Name               string              `gorm:"not null;type:text" json:"name"`
+	Description        string              `gorm:"type:text" json:"description"`
+	RepositoryPath     string              `gorm:"type:text" json:"repository_path"`
+	LastUpdatedAt      time.Time           `gorm:"autoUpdateTime" json:"last_updated_at"`
+	AgentID            string              `gorm:"type:text" json:"agent_id"`
+	CreatedAt          time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	DefaultAgentConfig *ProjectAgentConfig `gorm:"type:text" json:"default_agent_config,omitempty"`
+	CommitTemplate     string              `gorm:"type:text" json:"commit_template,omitempty"` // Overrides git.commit_template
+	Version            int                 `gorm:"not null;default:1" json:"version"`          // Incremented by every update, for optimistic concurrency
 
 	// Relations
 	Tasks []Task `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"tasks,omitempty"`
@@ -100,9 +189,17 @@ type Task struct {
 	AgentID       string      `gorm:"type:text;index" json:"agent_id"`
 	CreatedAt     time.Time   `gorm:"autoCreateTime" json:"created_at"`
 	TaskFilePath  string      `gorm:"type:text" json:"task_file_path"`
+	Version       int         `gorm:"not null;default:1" json:"version"` // Incremented by every update, for optimistic concurrency
 
 	BranchName string `gorm:"type:text" json:"branch_name"`
 	GitDiff    string `gorm:"type:text" json:"git_diff"`
+
+	// ProcessCheckpoint is the JSON progress of the task's processing workflow, which a
+	// retried attempt resumes from; internal state, not part of the API
+	ProcessCheckpoint string `gorm:"type:text" json:"-"`
+
+	// Labels are stored in task_labels; the database layer fills them in when loading tasks
+	Labels []string `gorm:"-" json:"labels,omitempty"`
 }
 
 // TableName returns the table name for Task
@@ -110,6 +207,55 @@ func (Task) TableName() string {
 	return "tasks"
 }
 
+// HasLabels reports whether the task carries every one of labels
+func (t *Task) HasLabels(labels ...string) bool {
+	for _, label := range NormalizeLabels(labels) {
+		found := false
+		for _, own := range t.Labels {
+			if own == label {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// TaskLabel attaches one label to a task. The composite primary key makes adding a
+// label twice a no-op.
+type TaskLabel struct {
+	TaskID    string    `gorm:"primaryKey;type:text" json:"task_id"`
+	Label     string    `gorm:"primaryKey;type:text;index" json:"label"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	Task *Task `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName returns the table name for TaskLabel
+func (TaskLabel) TableName() string {
+	return "task_labels"
+}
+
+// NormalizeLabels trims and lowercases labels, dropping empty ones and duplicates.
+// The result is sorted.
+func NormalizeLabels(labels []string) []string {
+	seen := make(map[string]bool, len(labels))
+	result := make([]string, 0, len(labels))
+	for _, label := range labels {
+		label = strings.ToLower(strings.TrimSpace(label))
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		result = append(result, label)
+	}
+	sort.Strings(result)
+	return result
+}
+
 // BeforeCreate is a GORM hook that runs before creating a record
 func (p *Project) BeforeCreate(tx *gorm.DB) error {
 	now := time.Now()
@@ -188,18 +334,26 @@ type AIActivityRecord struct {
 
 	// Tool info
 	ToolName         string `gorm:"type:text;index" json:"tool_name"`
-	ToolInputSummary string `gorm:"type:text" json:"tool_input_summary"` // Truncated human-readable
+	ToolNamespace    string `gorm:"type:text;index" json:"tool_namespace"` // MCP server of an MCP tool call
+	ToolInputSummary string `gorm:"type:text" json:"tool_input_summary"`   // Truncated human-readable
 	ToolSuccess      *bool  `gorm:"type:boolean" json:"tool_success"`
 	ToolError        string `gorm:"type:text" json:"tool_error"`
-	FilePath         string `gorm:"type:text;index" json:"file_path"` // Extracted for file ops
+	ToolUseID        string `gorm:"type:text;index" json:"tool_use_id"` // Pairs a tool_use with its tool_result
+	FilePath         string `gorm:"type:text;index" json:"file_path"`   // Extracted for file ops
 	IsSidechain      *bool  `gorm:"type:boolean" json:"is_sidechain"`
 	AgentID          string `gorm:"type:text;index" json:"agent_id"`
 	ParentSessionID  string `gorm:"type:text;index" json:"parent_session_id"`
 	SourceFile       string `gorm:"type:text" json:"source_file"`
+	SourceLine       int    `gorm:"type:integer" json:"source_line"`
 
 	// Content
-	ContentPreview string `gorm:"type:text" json:"content_preview"` // First 500 chars
-	ContentLength  int    `gorm:"type:integer" json:"content_length"`
+	ContentPreview    string      `gorm:"type:text" json:"content_preview"` // First 500 chars
+	ContentLength     int         `gorm:"type:integer" json:"content_length"`
+	ResultContentType string      `gorm:"type:text" json:"result_content_type"`   // json, diff, binary or text (tool_result only)
+	Attachments       Attachments `gorm:"type:text" json:"attachments,omitempty"` // Image and document blocks of the message
+
+	// Compaction (set only on AIEventCompactionSummary records)
+	CompactedCount int `gorm:"type:integer;default:0" json:"compacted_count,omitempty"` // Number of records folded into this summary
 
 	// Raw data
 	RawPayload string    `gorm:"type:text" json:"raw_payload"`
@@ -236,16 +390,21 @@ func (r *AIActivityRecord) ToParsedEventFields() map[string]interface{} {
 		"context_tokens":      r.ContextTokens,
 		"context_depth":       r.ContextDepth,
 		"tool_name":           r.ToolName,
+		"tool_namespace":      r.ToolNamespace,
 		"tool_input_summary":  r.ToolInputSummary,
 		"tool_success":        r.ToolSuccess,
 		"tool_error":          r.ToolError,
+		"tool_use_id":         r.ToolUseID,
 		"file_path":           r.FilePath,
 		"is_sidechain":        r.IsSidechain,
 		"agent_id":            r.AgentID,
 		"parent_session_id":   r.ParentSessionID,
 		"source_file":         r.SourceFile,
+		"source_line":         r.SourceLine,
 		"content_preview":     r.ContentPreview,
 		"content_length":      r.ContentLength,
+		"result_content_type": r.ResultContentType,
+		"attachments":         r.Attachments,
 	}
 }
 
@@ -285,20 +444,37 @@ func NewAIActivityRecordFromParsed(parsed types.ParsedEvent, taskID, runID, step
 		CacheCreateTokens: parsed.CacheCreateTokens,
 		ContextTokens:     parsed.InputTokens, // input_tokens represents context size
 		ToolName:          parsed.ToolName,
+		ToolNamespace:     parsed.ToolNamespace,
 		ToolInputSummary:  parsed.ToolInputSummary,
 		ToolSuccess:       parsed.ToolSuccess,
 		ToolError:         parsed.ToolError,
+		ToolUseID:         parsed.ToolUseID,
 		FilePath:          parsed.FilePath,
 		IsSidechain:       isSidechain,
 		AgentID:           parsed.AgentID,
 		ParentSessionID:   parsed.ParentSessionID,
 		SourceFile:        parsed.SourceFile,
+		SourceLine:        parsed.SourceLine,
 		ContentPreview:    parsed.ContentPreview,
 		ContentLength:     parsed.ContentLength,
+		ResultContentType: string(parsed.ResultContentType),
+		Attachments:       Attachments(parsed.Attachments),
 		RawPayload:        string(parsed.RawPayload),
 	}
 }
 
+// Origin returns where the record was read from as "file:line", "file", or "" if unknown
+func (r *AIActivityRecord) Origin() string {
+	switch {
+	case r.SourceFile == "":
+		return ""
+	case r.SourceLine > 0:
+		return fmt.Sprintf("%s:%d", r.SourceFile, r.SourceLine)
+	default:
+		return r.SourceFile
+	}
+}
+
 // GetMetadata implements common.Event interface.
 // This allows AIActivityRecord to be sent directly through the protocol event channel.
 func (r *AIActivityRecord) GetMetadata() common.Metadata {
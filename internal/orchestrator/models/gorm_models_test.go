@@ -0,0 +1,79 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectAgentConfig_ValueScan(t *testing.T) {
+	cfg := ProjectAgentConfig{
+		ToolName:    "claude",
+		ToolOptions: map[string]interface{}{"model": "claude-sonnet-4-5"},
+		FlagFormat:  "equals",
+	}
+
+	value, err := cfg.Value()
+	require.NoError(t, err)
+
+	var got ProjectAgentConfig
+	require.NoError(t, got.Scan(value))
+	assert.Equal(t, cfg, got)
+
+	require.NoError(t, got.Scan([]byte(`{"tool_name":"test"}`)))
+	assert.Equal(t, ProjectAgentConfig{ToolName: "test"}, got)
+}
+
+func TestProjectAgentConfig_ZeroIsNull(t *testing.T) {
+	value, err := ProjectAgentConfig{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	got := ProjectAgentConfig{ToolName: "stale"}
+	require.NoError(t, got.Scan(nil))
+	assert.True(t, got.IsZero())
+
+	assert.Error(t, got.Scan(42))
+}
+
+func TestAttachments_ValueScan(t *testing.T) {
+	attachments := Attachments{{Type: "image", MediaType: "image/png", Descriptor: "12.0 KB"}}
+
+	value, err := attachments.Value()
+	require.NoError(t, err)
+
+	var got Attachments
+	require.NoError(t, got.Scan(value))
+	assert.Equal(t, attachments, got)
+
+	value, err = Attachments{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, got.Scan(nil))
+	assert.Empty(t, got)
+	assert.Error(t, got.Scan(42))
+}
+
+func TestAIActivityRecord_Origin(t *testing.T) {
+	assert.Equal(t, "", (&AIActivityRecord{}).Origin())
+	assert.Equal(t, "a.jsonl", (&AIActivityRecord{SourceFile: "a.jsonl"}).Origin())
+	assert.Equal(t, "a.jsonl:12", (&AIActivityRecord{SourceFile: "a.jsonl", SourceLine: 12}).Origin())
+}
+
+func TestNormalizeLabels(t *testing.T) {
+	assert.Equal(t, []string{"bug", "ui"}, NormalizeLabels([]string{"UI", " bug", "", "ui", "Bug "}))
+	assert.Empty(t, NormalizeLabels(nil))
+}
+
+func TestTask_HasLabels(t *testing.T) {
+	task := &Task{Labels: []string{"bug", "ui"}}
+	assert.True(t, task.HasLabels())
+	assert.True(t, task.HasLabels("Bug"))
+	assert.True(t, task.HasLabels("bug", "ui"))
+	assert.False(t, task.HasLabels("bug", "backend"))
+}
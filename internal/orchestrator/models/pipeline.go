@@ -113,6 +113,7 @@ type StepAgentConfig struct {
 	Variables      map[string]string      `json:"variables,omitempty"`
 	ToolOptions    map[string]interface{} `json:"tool_options,omitempty"`
 	FlagFormat     string                 `json:"flag_format,omitempty"`
+	Env            map[string]string      `json:"env,omitempty"`
 }
 
 // StepDefinitions is a JSON-serializable slice of StepDefinition
@@ -169,8 +170,9 @@ type PipelineRun struct {
 	IdentityHash string `gorm:"type:text;index" json:"identity_hash"` // Hash of all inputs affecting output
 
 	// Execution metadata
-	WorktreePath string `gorm:"type:text" json:"worktree_path"`
-	ContainerID  string `gorm:"type:text" json:"container_id"`
+	WorktreePath  string `gorm:"type:text" json:"worktree_path"`
+	ContainerID   string `gorm:"type:text" json:"container_id"`
+	WorkingSubdir string `gorm:"type:text" json:"working_subdir,omitempty"` // Repository-relative directory the agent worked in (empty = repo root)
 
 	// Temporal workflow tracking
 	TemporalWorkflowID string `gorm:"type:text" json:"temporal_workflow_id"`
@@ -184,6 +186,9 @@ type PipelineRun struct {
 	// Error tracking
 	ErrorMessage string `gorm:"type:text" json:"error_message,omitempty"`
 
+	// Labels are stored in pipeline_run_labels; the database layer fills them in when loading runs
+	Labels []string `gorm:"-" json:"labels,omitempty"`
+
 	// Relations
 	StepResults   []StepResult      `gorm:"foreignKey:PipelineRunID;constraint:OnDelete:CASCADE" json:"step_results,omitempty"`
 	StepSnapshots []RunStepSnapshot `gorm:"foreignKey:RunID;references:ID;constraint:OnDelete:CASCADE" json:"step_snapshots,omitempty"`
@@ -193,6 +198,20 @@ func (PipelineRun) TableName() string {
 	return "pipeline_runs"
 }
 
+// PipelineRunLabel attaches one label to a pipeline run, as TaskLabel does for a task
+type PipelineRunLabel struct {
+	RunID     string    `gorm:"primaryKey;type:text" json:"run_id"`
+	Label     string    `gorm:"primaryKey;type:text;index" json:"label"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	Run *PipelineRun `gorm:"foreignKey:RunID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName returns the table name for PipelineRunLabel
+func (PipelineRunLabel) TableName() string {
+	return "pipeline_run_labels"
+}
+
 // StepResult represents the result of executing a single step
 type StepResult struct {
 	ID            string     `gorm:"primaryKey;type:text" json:"id"`
@@ -208,9 +227,10 @@ type StepResult struct {
 	GitDiff       string `gorm:"type:text" json:"git_diff"`
 
 	// Diff statistics
-	FilesChanged int `gorm:"type:integer" json:"files_changed"`
-	Insertions   int `gorm:"type:integer" json:"insertions"`
-	Deletions    int `gorm:"type:integer" json:"deletions"`
+	FilesChanged            int `gorm:"type:integer" json:"files_changed"`
+	SignificantFilesChanged int `gorm:"type:integer" json:"significant_files_changed"` // Excluding whitespace-only changes
+	Insertions              int `gorm:"type:integer" json:"insertions"`
+	Deletions               int `gorm:"type:integer" json:"deletions"`
 
 	// Token usage
 	InputTokens       int `gorm:"type:integer" json:"input_tokens"`
@@ -222,6 +242,7 @@ type StepResult struct {
 	AgentOutput  string        `gorm:"type:text" json:"agent_output"`
 	Duration     time.Duration `gorm:"type:integer" json:"duration"` // Stored as nanoseconds
 	ErrorMessage string        `gorm:"type:text" json:"error_message,omitempty"`
+	Warning      string        `gorm:"type:text" json:"warning,omitempty"` // Completed, but not normally (e.g. finalized after the agent went idle)
 
 	// Step definition hash for fork comparison - allows detecting unchanged steps
 	DefinitionHash string `gorm:"type:text;index" json:"definition_hash"`
@@ -358,6 +379,19 @@ func ComputeStepDefinitionHash(step StepDefinition) string {
 				}
 			}
 		}
+
+		// Sort env for determinism
+		if len(step.AgentConfig.Env) > 0 {
+			envKeys := make([]string, 0, len(step.AgentConfig.Env))
+			for k := range step.AgentConfig.Env {
+				envKeys = append(envKeys, k)
+			}
+			sort.Strings(envKeys)
+			for _, k := range envKeys {
+				h.Write([]byte(k))
+				h.Write([]byte(step.AgentConfig.Env[k]))
+			}
+		}
 	}
 
 	// Include step-level Options for completeness
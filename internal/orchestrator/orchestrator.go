@@ -53,6 +53,7 @@ type Orchestrator struct {
 	pipelineService   *services.PipelineService
 	runtimeProvider   runtime.Provider
 	runtimeEnv        runtime.Environment
+	taskQueue         *taskQueue
 	config            *config.AppConfig
 }
 
@@ -143,6 +144,7 @@ func New(cmdChan <-chan protocol.Command, eventChan chan<- protocol.Event, cfg *
 		pipelineService:   pipelineService,
 		runtimeProvider:   runtimeProvider,
 		runtimeEnv:        env,
+		taskQueue:         newTaskQueue(),
 		config:            cfg,
 	}, nil
 }
@@ -170,6 +172,7 @@ func (o *Orchestrator) GitServiceManager() *services.GitServiceManager {
 // Run starts the orchestrator's main loop
 func (o *Orchestrator) Run(ctx context.Context) {
 	getLog().Info().Msg("Orchestrator started")
+	go o.runRetentionJanitor(ctx)
 	for {
 		select {
 		case <-ctx.Done():
@@ -203,25 +206,39 @@ func (o *Orchestrator) handleCommand(ctx context.Context, cmd protocol.Command)
 	case protocol.LoadProjectsCommand:
 		o.handleLoadProjects(ctx, c.Metadata)
 	case protocol.LoadTasksCommand:
-		o.handleLoadTasks(ctx, c.Metadata, c.ProjectID)
+		o.handleLoadTasks(ctx, c.Metadata, c.ProjectID, c.Labels...)
 	case protocol.LoadCommitsCommand:
 		o.handleLoadCommits(ctx, c.Metadata, c.ProjectID, c.Limit)
 	case protocol.ToggleTaskCommand:
 		o.handleToggleTask(ctx, c.Metadata, c.ProjectID, c.TaskID)
 	case protocol.DeleteTaskCommand:
 		o.handleDeleteTask(ctx, c.Metadata, c.ProjectID, c.TaskID)
+	case protocol.UpdateTaskCommand:
+		o.handleUpdateTask(ctx, c)
+	case protocol.UpdateTaskLabelsCommand:
+		o.handleUpdateTaskLabels(ctx, c)
 	case protocol.CreateTaskCommand:
 		go o.handleCreateTask(ctx, c)
 	case protocol.CreateProjectCommand:
-		o.handleCreateProject(ctx, c.Metadata, c.Name, c.Description, c.RepositoryPath)
+		o.handleCreateProject(ctx, c)
 	case protocol.LoadAIActivityCommand:
 		o.handleLoadAIActivity(ctx, c.Metadata, c.ProjectID, c.TaskID)
+	case protocol.ListRunningTasksCommand:
+		go o.handleListRunningTasks(ctx, c.Metadata)
+	case protocol.ResolveWorktreeCommand:
+		o.handleResolveWorktree(ctx, c.Metadata, c.ProjectID, c.TaskID)
 	case protocol.StartPipelineCommand:
 		go o.handleStartPipeline(ctx, c)
 	case protocol.LoadPipelineRunsCommand:
 		o.handleLoadPipelineRuns(ctx, c.Metadata, c.ProjectID)
 	case protocol.CancelPipelineCommand:
 		go o.handleCancelPipeline(c)
+	case protocol.CancelQueuedTaskCommand:
+		o.handleCancelQueuedTask(c)
+	case protocol.RebaseTaskCommand:
+		go o.handleRebaseTask(ctx, c)
+	case protocol.AssembleBranchCommand:
+		go o.handleAssembleBranch(ctx, c)
 	default:
 		getLog().Warn().Str("command_type", fmt.Sprintf("%T", cmd)).Msg("Unknown command type")
 	}
@@ -241,7 +258,7 @@ func (o *Orchestrator) handleLoadProjects(ctx context.Context, metadata protocol
 	o.sendEvent(protocol.ProjectsLoadedEvent{Metadata: metadata, Projects: projects})
 }
 
-func (o *Orchestrator) handleLoadTasks(ctx context.Context, metadata protocol.Metadata, projectID string) {
+func (o *Orchestrator) handleLoadTasks(ctx context.Context, metadata protocol.Metadata, projectID string, labels ...string) {
 	project, err := o.dataService.GetProject(ctx, projectID)
 	if err != nil {
 		if ctx.Err() != nil {
@@ -251,7 +268,7 @@ func (o *Orchestrator) handleLoadTasks(ctx context.Context, metadata protocol.Me
 		return
 	}
 
-	tasks, err := o.dataService.LoadTasks(ctx, projectID)
+	tasks, err := o.dataService.LoadTasksByLabel(ctx, projectID, labels...)
 	if err != nil {
 		if ctx.Err() != nil {
 			return
@@ -265,6 +282,7 @@ func (o *Orchestrator) handleLoadTasks(ctx context.Context, metadata protocol.Me
 		ProjectName:    project.Name,
 		RepositoryPath: project.RepositoryPath,
 		Tasks:          tasks,
+		Labels:         labels,
 	})
 }
 
@@ -313,6 +331,56 @@ func (o *Orchestrator) handleLoadAIActivity(ctx context.Context, metadata protoc
 	o.sendEvent(protocol.AIActivityBatchEvent{Metadata: metadata, TaskID: taskID, ProjectID: projectID, Activities: events})
 }
 
+// handleListRunningTasks reports tasks still running in Temporal. An unreachable Temporal
+// server is reported on the event rather than as an error, so a client probing at startup
+// can carry on without reattaching.
+func (o *Orchestrator) handleListRunningTasks(ctx context.Context, metadata protocol.Metadata) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tasks, err := o.pipelineService.ListRunningTasks(ctx)
+	if err != nil {
+		getLog().Warn().Err(err).Msg("Could not list running tasks")
+		o.sendEvent(protocol.RunningTasksEvent{Metadata: metadata, Unavailable: true, Error: err.Error()})
+		return
+	}
+	o.sendEvent(protocol.RunningTasksEvent{Metadata: metadata, Tasks: tasks})
+}
+
+func (o *Orchestrator) handleResolveWorktree(ctx context.Context, metadata protocol.Metadata, projectID, taskID string) {
+	project, err := o.dataService.GetProject(ctx, projectID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		o.sendEvent(protocol.ErrorEvent{Metadata: metadata, Message: "Failed to load project details for " + projectID, Context: err.Error()})
+		return
+	}
+
+	gitServiceHandle, err := o.gitServiceManager.GetService(project.RepositoryPath)
+	if err != nil {
+		o.sendEvent(protocol.ErrorEvent{Metadata: metadata, Message: "Failed to access git repository", Context: err.Error()})
+		return
+	}
+	defer gitServiceHandle.Release()
+
+	// Task worktrees (pipeline runs included) are named after the task ID
+	event := protocol.WorktreeResolvedEvent{Metadata: metadata, ProjectID: projectID, TaskID: taskID}
+	err = gitServiceHandle.WithReadLock(ctx, func(gs *services.GitService) error {
+		event.WorktreePath = gs.GetWorktreePath(taskID)
+		event.Exists = event.WorktreePath != "" && gs.WorktreeExists(event.WorktreePath)
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		o.sendEvent(protocol.ErrorEvent{Metadata: metadata, Message: "Failed to resolve worktree for task " + taskID, Context: err.Error()})
+		return
+	}
+	o.sendEvent(event)
+}
+
 func (o *Orchestrator) handleLoadCommits(ctx context.Context, metadata protocol.Metadata, projectID string, limit int) {
 	project, err := o.dataService.GetProject(ctx, projectID)
 	if err != nil {
@@ -354,16 +422,16 @@ func (o *Orchestrator) handleLoadCommits(ctx context.Context, metadata protocol.
 
 // --- Mutation handlers (thin wrappers around PipelineService) ---
 
-func (o *Orchestrator) handleCreateProject(ctx context.Context, metadata protocol.Metadata, name, description, repositoryPath string) {
-	project, err := o.pipelineService.CreateProject(ctx, name, description, repositoryPath)
+func (o *Orchestrator) handleCreateProject(ctx context.Context, cmd protocol.CreateProjectCommand) {
+	project, err := o.pipelineService.CreateProject(ctx, cmd.Name, cmd.Description, cmd.RepositoryPath, cmd.DefaultAgentConfig)
 	if err != nil {
 		if ctx.Err() != nil {
 			return
 		}
-		o.sendEvent(protocol.ErrorEvent{Metadata: metadata, Message: "Failed to create project", Context: err.Error()})
+		o.sendEvent(protocol.ErrorEvent{Metadata: cmd.Metadata, Message: "Failed to create project", Context: err.Error()})
 		return
 	}
-	o.sendEvent(protocol.ProjectCreatedEvent{Metadata: metadata, Project: project})
+	o.sendEvent(protocol.ProjectCreatedEvent{Metadata: cmd.Metadata, Project: project})
 }
 
 func (o *Orchestrator) handleToggleTask(ctx context.Context, metadata protocol.Metadata, projectID, taskID string) {
@@ -381,18 +449,89 @@ func (o *Orchestrator) handleToggleTask(ctx context.Context, metadata protocol.M
 }
 
 func (o *Orchestrator) handleDeleteTask(ctx context.Context, metadata protocol.Metadata, projectID, taskID string) {
-	if err := o.pipelineService.DeleteTask(ctx, projectID, taskID); err != nil {
-		if ctx.Err() != nil {
-			return
-		}
+	result, err := o.pipelineService.DeleteTask(ctx, projectID, taskID)
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+	if err != nil && !errors.Is(err, services.ErrTaskCleanup) {
 		o.sendEvent(protocol.ErrorEvent{Metadata: metadata, Message: "Failed to delete task", Context: err.Error()})
 		return
 	}
+	if err != nil {
+		o.sendEvent(protocol.ErrorEvent{Metadata: metadata, TaskID: taskID, Message: "Failed to clean up after task " + taskID, Context: err.Error()})
+	}
+	if result != nil {
+		o.sendEvent(protocol.TaskCleanedUpEvent{
+			Metadata:        metadata,
+			ProjectID:       projectID,
+			TaskID:          taskID,
+			WorktreePath:    result.WorktreePath,
+			WorktreeRemoved: result.WorktreeRemoved,
+			WorktreeSkipped: result.WorktreeSkipped,
+			Branch:          result.Branch,
+			BranchDeleted:   result.BranchDeleted,
+			BranchSkipped:   result.BranchSkipped,
+		})
+	}
+
 	// Reload tasks to reflect the deletion
 	o.handleLoadTasks(ctx, metadata, projectID)
 }
 
+func (o *Orchestrator) handleUpdateTask(ctx context.Context, cmd protocol.UpdateTaskCommand) {
+	_, err := o.pipelineService.UpdateTask(ctx, cmd.ProjectID, cmd.TaskID, cmd.Title, cmd.Description, cmd.ExpectedVersion)
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+	if errors.Is(err, services.ErrStaleWrite) {
+		// Someone else edited the task first; the reload below hands the client the
+		// current version to edit again
+		o.sendEvent(protocol.ErrorEvent{Metadata: cmd.Metadata, Message: "Task was changed elsewhere, reloaded the latest version", Context: err.Error()})
+	} else if err != nil {
+		o.sendEvent(protocol.ErrorEvent{Metadata: cmd.Metadata, Message: "Failed to update task", Context: err.Error()})
+		return
+	}
+	// Reload tasks to reflect the edit
+	o.handleLoadTasks(ctx, cmd.Metadata, cmd.ProjectID)
+}
+
+func (o *Orchestrator) handleUpdateTaskLabels(ctx context.Context, cmd protocol.UpdateTaskLabelsCommand) {
+	if err := o.pipelineService.UpdateTaskLabels(ctx, cmd.ProjectID, cmd.TaskID, cmd.Add, cmd.Remove); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		o.sendEvent(protocol.ErrorEvent{Metadata: cmd.Metadata, Message: "Failed to update task labels", Context: err.Error()})
+		return
+	}
+	// Reload tasks to reflect the new labels
+	o.handleLoadTasks(ctx, cmd.Metadata, cmd.ProjectID)
+}
+
+// handleCreateTask starts the task, or queues it when the project is at its concurrent task limit
 func (o *Orchestrator) handleCreateTask(ctx context.Context, cmd protocol.CreateTaskCommand) {
+	limit := o.maxConcurrentTasks(ctx, cmd.ProjectID)
+	if limit > 0 {
+		started, queueID, position := o.taskQueue.acquire(cmd.ProjectID, limit, cmd)
+		if !started {
+			getLog().Info().Str("project_id", cmd.ProjectID).Str("queue_id", queueID).Int("position", position).
+				Msg("Project at concurrent task limit, queueing task")
+			o.sendEvent(protocol.TaskQueuedEvent{
+				Metadata:  cmd.Metadata,
+				ProjectID: cmd.ProjectID,
+				QueueID:   queueID,
+				Title:     cmd.Title,
+				Position:  position,
+			})
+			return
+		}
+	}
+	o.startTask(ctx, cmd, limit > 0)
+}
+
+// startTask creates the task's pipeline. When the task holds a queue slot, the slot is
+// released once the workflow stops running, or straight away if it could not be started.
+func (o *Orchestrator) startTask(ctx context.Context, cmd protocol.CreateTaskCommand, holdsSlot bool) {
+	parentCtx := ctx
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
@@ -402,14 +541,23 @@ func (o *Orchestrator) handleCreateTask(ctx context.Context, cmd protocol.Create
 		Description:   cmd.Description,
 		BaseCommitSHA: cmd.BaseCommitSHA,
 		AgentConfig:   cmd.AgentConfig,
+		TokenBudget:   cmd.TokenBudget,
+		WorkingSubdir: cmd.WorkingSubdir,
+		Labels:        cmd.Labels,
 	})
 	if err != nil {
+		if holdsSlot {
+			o.releaseTaskSlot(parentCtx, cmd.ProjectID)
+		}
 		if ctx.Err() != nil {
 			return
 		}
 		o.sendEvent(protocol.ErrorEvent{Metadata: cmd.Metadata, Message: "Failed to create task", Context: err.Error()})
 		return
 	}
+	if holdsSlot {
+		go o.watchTaskSlot(parentCtx, cmd.ProjectID, result.WorkflowID)
+	}
 	o.sendEvent(protocol.PipelineRunStartedEvent{
 		Metadata:      cmd.Metadata,
 		RunID:         result.RunID,
@@ -433,6 +581,8 @@ func (o *Orchestrator) handleStartPipeline(ctx context.Context, cmd protocol.Sta
 		ForkFromRunID:   cmd.ForkFromRunID,
 		ForkAfterStepID: cmd.ForkAfterStepID,
 		NoAutoFork:      cmd.NoAutoFork,
+		Labels:          cmd.Labels,
+		WorkingSubdir:   cmd.WorkingSubdir,
 	})
 	if err != nil {
 		if ctx.Err() != nil {
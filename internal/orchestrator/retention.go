@@ -0,0 +1,55 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/noldarim/noldarim/internal/rotate"
+)
+
+// runRetentionJanitor prunes rotated event log and transcript files every
+// retention.janitor_interval until ctx is done
+func (o *Orchestrator) runRetentionJanitor(ctx context.Context) {
+	retention := o.config.Retention
+	if retention.JanitorInterval <= 0 || (retention.EventLog.Path == "" && retention.Transcripts.Path == "") {
+		return
+	}
+
+	ticker := time.NewTicker(retention.JanitorInterval)
+	defer ticker.Stop()
+
+	pruneRotatedFiles(retention, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			pruneRotatedFiles(retention, now)
+		}
+	}
+}
+
+// pruneRotatedFiles applies each configured retention policy once; errors are logged
+// so one unreadable location does not stop the others from being pruned
+func pruneRotatedFiles(retention config.RetentionConfig, now time.Time) {
+	targets := map[string]config.RotationConfig{
+		"event_log":   retention.EventLog,
+		"transcripts": retention.Transcripts,
+	}
+	for name, target := range targets {
+		if target.Path == "" {
+			continue
+		}
+		removed, err := rotate.Prune(target.Path, target.Policy(), now)
+		if err != nil {
+			getLog().Warn().Err(err).Str("target", name).Str("path", target.Path).Msg("Failed to prune rotated files")
+		}
+		if len(removed) > 0 {
+			getLog().Info().Str("target", name).Int("removed", len(removed)).Msg("Pruned rotated files")
+		}
+	}
+}
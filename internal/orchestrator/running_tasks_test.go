@@ -0,0 +1,75 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/workflows"
+	"github.com/noldarim/noldarim/internal/protocol"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func receiveRunningTasksEvent(t *testing.T, eventChan chan protocol.Event) protocol.RunningTasksEvent {
+	t.Helper()
+	select {
+	case event := <-eventChan:
+		runningEvent, ok := event.(protocol.RunningTasksEvent)
+		require.True(t, ok, "Expected RunningTasksEvent, got %T", event)
+		return runningEvent
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected event but none received")
+		return protocol.RunningTasksEvent{}
+	}
+}
+
+func TestHandleListRunningTasks(t *testing.T) {
+	mockClient := new(MockTemporalClient)
+	orch, eventChan, _ := setupTestOrchestrator(t, mockClient)
+
+	started := time.Now().Add(-time.Minute).UTC()
+	mockClient.On("ListRunningWorkflows", mock.Anything, workflows.ProcessTaskWorkflowName).Return([]temporal.WorkflowExecution{
+		{WorkflowID: workflows.ProcessTaskWorkflowIDPrefix + "task-1", RunID: "run-1", StartTime: started},
+	}, nil)
+	mockClient.On("ListRunningWorkflows", mock.Anything, workflows.PipelineWorkflowName).Return([]temporal.WorkflowExecution{}, nil)
+	mockClient.On("QueryWorkflow", mock.Anything, workflows.ProcessTaskWorkflowIDPrefix+"task-1", workflows.ProcessingMetadataQuery, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(3).(*types.ProcessingMetadata).Step = workflows.ProcessTaskStepRunningAgent
+		}).
+		Return(nil)
+
+	orch.handleListRunningTasks(context.Background(), protocol.Metadata{})
+
+	event := receiveRunningTasksEvent(t, eventChan)
+	assert.False(t, event.Unavailable)
+	require.Len(t, event.Tasks, 1)
+	assert.Equal(t, "task-1", event.Tasks[0].TaskID)
+	assert.Equal(t, workflows.ProcessTaskWorkflowIDPrefix+"task-1", event.Tasks[0].WorkflowID)
+	assert.Equal(t, workflows.ProcessTaskStepRunningAgent, event.Tasks[0].Step)
+	assert.Equal(t, started, event.Tasks[0].StartedAt)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestHandleListRunningTasks_TemporalUnreachable(t *testing.T) {
+	mockClient := new(MockTemporalClient)
+	orch, eventChan, _ := setupTestOrchestrator(t, mockClient)
+
+	mockClient.On("ListRunningWorkflows", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	orch.handleListRunningTasks(context.Background(), protocol.Metadata{})
+
+	event := receiveRunningTasksEvent(t, eventChan)
+	assert.True(t, event.Unavailable)
+	assert.Contains(t, event.Error, "connection refused")
+	assert.Empty(t, event.Tasks)
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/config"
+)
+
+// TaskCleanupResult reports what CleanupTaskBranch removed and, for what it kept, why
+type TaskCleanupResult struct {
+	WorktreePath    string
+	WorktreeRemoved bool
+	WorktreeSkipped string // Reason the worktree was kept, empty when removed or absent
+	Branch          string
+	BranchDeleted   bool
+	BranchSkipped   string // Reason the branch was kept, empty when deleted or absent
+}
+
+// CleanupTaskBranch removes the worktree and deletes the branch of a deleted task, as far
+// as opts allow. Unless opts.Force is set, a worktree with uncommitted changes and a branch
+// with commits not merged into the repository's current branch are kept. The current branch,
+// protected branches and branches still checked out in a worktree are never deleted.
+// The branch is the one checked out in the task's worktree, else branch, else the task
+// branch name.
+func (gs *GitService) CleanupTaskBranch(ctx context.Context, repoPath, taskID, branch string, opts config.GitCleanupConfig) (*TaskCleanupResult, error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository path: %w", err)
+	}
+
+	result := &TaskCleanupResult{WorktreePath: gs.GetWorktreePath(taskID), Branch: branch}
+	worktreeExists := result.WorktreePath != "" && gs.WorktreeExists(result.WorktreePath)
+	if worktreeExists {
+		// The worktree knows best which branch the task worked on
+		if current, err := gs.getCurrentBranch(ctx, result.WorktreePath); err == nil && current != "HEAD" {
+			result.Branch = current
+		}
+	}
+	if result.Branch == "" {
+		result.Branch = GenerateTaskBranchName(taskID)
+	}
+
+	if opts.Worktree && worktreeExists {
+		clean, err := gs.IsWorkingDirectoryClean(ctx, result.WorktreePath)
+		switch {
+		case err != nil:
+			return result, fmt.Errorf("failed to check worktree for changes: %w", err)
+		case !clean && !opts.Force:
+			result.WorktreeSkipped = "uncommitted changes"
+		default:
+			if err := gs.RemoveWorktree(ctx, result.WorktreePath, opts.Force); err != nil {
+				return result, err
+			}
+			result.WorktreeRemoved = true
+			worktreeExists = false
+		}
+	} else if !worktreeExists {
+		result.WorktreePath = ""
+	}
+
+	if !opts.Branch {
+		return result, nil
+	}
+	exists, err := gs.branchExists(ctx, validatedPath, result.Branch)
+	if err != nil {
+		return result, fmt.Errorf("failed to check if branch exists: %w", err)
+	}
+	if !exists {
+		return result, nil
+	}
+
+	current, err := gs.getCurrentBranch(ctx, validatedPath)
+	if err != nil {
+		return result, fmt.Errorf("failed to get current branch: %w", err)
+	}
+	switch {
+	case current == result.Branch:
+		result.BranchSkipped = "current branch"
+		return result, nil
+	case worktreeExists:
+		result.BranchSkipped = "checked out in the worktree"
+		return result, nil
+	}
+	if pattern, protected := MatchProtectedBranch(result.Branch, gs.protectedBranches()); protected {
+		result.BranchSkipped = fmt.Sprintf("protected (matches %q)", pattern)
+		return result, nil
+	}
+	if !opts.Force {
+		merged, err := gs.IsBranchMerged(ctx, validatedPath, result.Branch)
+		if err != nil {
+			return result, err
+		}
+		if !merged {
+			result.BranchSkipped = "unmerged commits"
+			return result, nil
+		}
+	}
+
+	if err := gs.DeleteBranch(ctx, validatedPath, result.Branch); err != nil {
+		return result, err
+	}
+	result.BranchDeleted = true
+	return result, nil
+}
+
+// IsBranchMerged reports whether every commit of branch is reachable from HEAD
+func (gs *GitService) IsBranchMerged(ctx context.Context, repoPath, branch string) (bool, error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return false, fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateBranchName(branch); err != nil {
+		return false, fmt.Errorf("invalid branch name: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cmd, err := gs.buildSafeGitCommand(ctx, validatedPath, "merge-base", "--is-ancestor", branch, "HEAD")
+	if err != nil {
+		return false, fmt.Errorf("failed to build git command: %w", err)
+	}
+	if err := cmd.Run(); err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) && exitError.ExitCode() == 1 {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check if branch is merged: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/noldarim/noldarim/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var cleanupAll = config.GitCleanupConfig{Worktree: true, Branch: true}
+
+// cleanupFixture creates a repository with a task worktree on branch task-<taskID>,
+// holding one commit that is not merged into the main branch
+func cleanupFixture(t *testing.T, taskID string) (*GitService, string, string) {
+	gitService, repoPath, cleanup := createTestGitService(t)
+	t.Cleanup(cleanup)
+
+	ctx := context.Background()
+	createTestRepoWithCommit(t, gitService, repoPath)
+	gitService.workDir = repoPath
+
+	worktreePath := gitService.GetWorktreePath(taskID)
+	require.NoError(t, gitService.AddWorktree(ctx, worktreePath, GenerateTaskBranchName(taskID), ""))
+	require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "work.txt"), []byte("work"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, worktreePath, "Task work"))
+	return gitService, repoPath, worktreePath
+}
+
+func TestGitService_CleanupTaskBranch_SkipsUnmergedBranch(t *testing.T) {
+	gitService, repoPath, worktreePath := cleanupFixture(t, "unmerged")
+	ctx := context.Background()
+
+	result, err := gitService.CleanupTaskBranch(ctx, repoPath, "unmerged", "", cleanupAll)
+	require.NoError(t, err)
+	assert.True(t, result.WorktreeRemoved)
+	assert.NoDirExists(t, worktreePath)
+	assert.Equal(t, "task-unmerged", result.Branch)
+	assert.False(t, result.BranchDeleted)
+	assert.Equal(t, "unmerged commits", result.BranchSkipped)
+
+	exists, err := gitService.BranchExists(ctx, repoPath, "task-unmerged")
+	require.NoError(t, err)
+	assert.True(t, exists, "unmerged work is kept")
+
+	// Forcing deletes it anyway
+	result, err = gitService.CleanupTaskBranch(ctx, repoPath, "unmerged", "", config.GitCleanupConfig{Branch: true, Force: true})
+	require.NoError(t, err)
+	assert.True(t, result.BranchDeleted)
+	exists, err = gitService.BranchExists(ctx, repoPath, "task-unmerged")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestGitService_CleanupTaskBranch_DeletesMergedBranch(t *testing.T) {
+	gitService, repoPath, _ := cleanupFixture(t, "merged")
+	ctx := context.Background()
+	require.NoError(t, gitService.runSafeGitCommand(ctx, repoPath, "merge", "--ff-only", "task-merged"))
+
+	result, err := gitService.CleanupTaskBranch(ctx, repoPath, "merged", "", cleanupAll)
+	require.NoError(t, err)
+	assert.True(t, result.WorktreeRemoved)
+	assert.True(t, result.BranchDeleted)
+	assert.Empty(t, result.BranchSkipped)
+}
+
+func TestGitService_CleanupTaskBranch_SkipsCurrentBranch(t *testing.T) {
+	gitService, repoPath, _ := cleanupFixture(t, "current")
+	ctx := context.Background()
+	require.NoError(t, gitService.RemoveWorktree(ctx, gitService.GetWorktreePath("current"), false))
+	require.NoError(t, gitService.SwitchBranch(ctx, repoPath, "task-current"))
+
+	// Not even forcing deletes the checked-out branch
+	result, err := gitService.CleanupTaskBranch(ctx, repoPath, "current", "", config.GitCleanupConfig{Worktree: true, Branch: true, Force: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.WorktreePath, "the worktree was already gone")
+	assert.False(t, result.BranchDeleted)
+	assert.Equal(t, "current branch", result.BranchSkipped)
+
+	branch, err := gitService.getCurrentBranch(ctx, repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, "task-current", branch)
+}
+
+func TestGitService_CleanupTaskBranch_KeepsDirtyWorktree(t *testing.T) {
+	gitService, repoPath, worktreePath := cleanupFixture(t, "dirty")
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "uncommitted.txt"), []byte("wip"), 0644))
+
+	result, err := gitService.CleanupTaskBranch(ctx, repoPath, "dirty", "", cleanupAll)
+	require.NoError(t, err)
+	assert.False(t, result.WorktreeRemoved)
+	assert.Equal(t, "uncommitted changes", result.WorktreeSkipped)
+	assert.DirExists(t, worktreePath)
+	assert.Equal(t, "checked out in the worktree", result.BranchSkipped)
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ErrProtectedBranch is returned when an operation would create, check out or commit to a
+// branch matching git.protected_branches
+var ErrProtectedBranch = errors.New("branch is protected")
+
+// ProtectedBranchError describes a refused operation; it matches ErrProtectedBranch
+type ProtectedBranchError struct {
+	Branch    string
+	Pattern   string // The git.protected_branches pattern the branch matched
+	Operation string
+}
+
+func (e *ProtectedBranchError) Error() string {
+	return fmt.Sprintf("refusing to %s: branch %s is protected (matches %q)", e.Operation, e.Branch, e.Pattern)
+}
+
+func (e *ProtectedBranchError) Unwrap() error {
+	return ErrProtectedBranch
+}
+
+// MatchProtectedBranch returns the first pattern protecting branch. Patterns are globs as
+// understood by path.Match, so "release/*" covers "release/1.2" but not "release/1/hotfix".
+// Task branches are never protected, so tasks keep working whatever the patterns say.
+func MatchProtectedBranch(branch string, patterns []string) (pattern string, protected bool) {
+	if isTaskBranch(branch) {
+		return "", false
+	}
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, branch); err == nil && matched {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// isTaskBranch reports whether branch is named like GenerateTaskBranchName's output
+func isTaskBranch(branch string) bool {
+	return strings.HasPrefix(branch, "task-") && len(branch) > len("task-")
+}
+
+// protectedBranches returns the configured patterns, none without a config
+func (gs *GitService) protectedBranches() []string {
+	if gs.config == nil {
+		return nil
+	}
+	return gs.config.Git.ProtectedBranches
+}
+
+// checkBranchWritable returns a *ProtectedBranchError when branch is protected
+func (gs *GitService) checkBranchWritable(branch, operation string) error {
+	if pattern, protected := MatchProtectedBranch(branch, gs.protectedBranches()); protected {
+		getLog().Warn().Str("branch", branch).Str("pattern", pattern).Str("operation", operation).Msg("Refused operation on protected branch")
+		return &ProtectedBranchError{Branch: branch, Pattern: pattern, Operation: operation}
+	}
+	return nil
+}
+
+// checkCurrentBranchWritable is checkBranchWritable for the branch checked out at repoPath.
+// A detached HEAD is not a branch and passes.
+func (gs *GitService) checkCurrentBranchWritable(ctx context.Context, repoPath, operation string) error {
+	if len(gs.protectedBranches()) == 0 {
+		return nil
+	}
+	branch, err := gs.getCurrentBranch(ctx, repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to check branch protection: %w", err)
+	}
+	if branch == "HEAD" {
+		return nil
+	}
+	return gs.checkBranchWritable(branch, operation)
+}
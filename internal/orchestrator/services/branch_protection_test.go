@@ -0,0 +1,93 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/noldarim/noldarim/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchProtectedBranch(t *testing.T) {
+	patterns := []string{"main", "release/*", "task-*"}
+
+	tests := []struct {
+		branch      string
+		wantPattern string
+		protected   bool
+	}{
+		{"main", "main", true},
+		{"release/1.2", "release/*", true},
+		{"release/1/hotfix", "", false},
+		{"releases", "", false},
+		{"feature/login", "", false},
+		// Task branches stay writable even when a pattern covers them
+		{"task-123", "", false},
+		{"task-", "task-*", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			pattern, protected := MatchProtectedBranch(tt.branch, patterns)
+			assert.Equal(t, tt.protected, protected)
+			assert.Equal(t, tt.wantPattern, pattern)
+		})
+	}
+
+	_, protected := MatchProtectedBranch("task-abc", []string{"*"})
+	assert.False(t, protected, "task branches are exempt from catch-all patterns")
+}
+
+func TestGitService_ProtectedBranches(t *testing.T) {
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	createTestRepoWithCommit(t, gitService, repoPath)
+	gitService.workDir = repoPath
+
+	defaultBranch, err := gitService.getCurrentBranch(ctx, repoPath)
+	require.NoError(t, err)
+
+	gitService.config = &config.AppConfig{
+		Git: config.GitConfig{ProtectedBranches: []string{defaultBranch, "release/*"}},
+	}
+
+	// Committing on the checked-out protected branch is refused
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "change.txt"), []byte("change"), 0644))
+	err = gitService.CreateCommit(ctx, repoPath, "Direct commit")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrProtectedBranch))
+	var protectedErr *ProtectedBranchError
+	require.True(t, errors.As(err, &protectedErr))
+	assert.Equal(t, defaultBranch, protectedErr.Branch)
+	assert.Equal(t, "commit", protectedErr.Operation)
+
+	err = gitService.CommitSpecificFiles(ctx, repoPath, []string{"change.txt"}, "Direct commit")
+	assert.True(t, errors.Is(err, ErrProtectedBranch))
+
+	// Creating a branch matching a glob is refused
+	err = gitService.CreateBranch(ctx, repoPath, "release/v1")
+	assert.True(t, errors.Is(err, ErrProtectedBranch))
+	err = gitService.CreateBranchFromRef(ctx, repoPath, "release/v1", "HEAD")
+	assert.True(t, errors.Is(err, ErrProtectedBranch))
+
+	worktreePath := filepath.Join(t.TempDir(), "release")
+	err = gitService.AddWorktree(ctx, worktreePath, "release/v2", "")
+	assert.True(t, errors.Is(err, ErrProtectedBranch))
+	assert.NoDirExists(t, worktreePath)
+
+	// Task branches are not protected, so task worktrees work as before
+	taskPath := filepath.Join(t.TempDir(), "task")
+	require.NoError(t, gitService.AddWorktree(ctx, taskPath, "task-42", ""))
+	require.NoError(t, os.WriteFile(filepath.Join(taskPath, "work.txt"), []byte("work"), 0644))
+	assert.NoError(t, gitService.CreateCommit(ctx, taskPath, "Task work"))
+}
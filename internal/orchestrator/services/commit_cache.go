@@ -0,0 +1,184 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package services
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	// defaultCommitCacheSize is the number of commits kept in the metadata cache
+	defaultCommitCacheSize = 4096
+
+	// maxCommitsPerLog bounds the hashes passed to a single git log invocation
+	maxCommitsPerLog = 256
+)
+
+// commitCacheKey identifies a commit in a specific repository
+type commitCacheKey struct {
+	repoPath string
+	hash     string
+}
+
+type commitCacheEntry struct {
+	key    commitCacheKey
+	commit GitCommit
+}
+
+// commitCache is a concurrency-safe LRU of commit metadata. Commits are immutable,
+// so entries never need invalidating; they are only evicted for space.
+type commitCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // Front is most recently used
+	entries  map[commitCacheKey]*list.Element
+}
+
+func newCommitCache(capacity int) *commitCache {
+	return &commitCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[commitCacheKey]*list.Element),
+	}
+}
+
+// get returns the cached commit and marks it as recently used
+func (c *commitCache) get(key commitCacheKey) (GitCommit, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return GitCommit{}, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*commitCacheEntry).commit, true
+}
+
+// put stores a commit, evicting the least recently used entry when full
+func (c *commitCache) put(key commitCacheKey, commit GitCommit) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*commitCacheEntry).commit = commit
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&commitCacheEntry{key: key, commit: commit})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*commitCacheEntry).key)
+	}
+}
+
+// len returns the number of cached commits
+func (c *commitCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// commitMetadataCache is shared by all GitService instances, keyed by repository path
+var commitMetadataCache = newCommitCache(defaultCommitCacheSize)
+
+// commits returns the metadata cache the service uses: its own when set, else the shared one
+func (gs *GitService) commits() *commitCache {
+	if gs.commitCache != nil {
+		return gs.commitCache
+	}
+	return commitMetadataCache
+}
+
+// GetCommitsMetadata returns subject, author, parents and commit date for each of the
+// given full commit hashes, keyed by lowercase hash. Cached commits are served without running git;
+// the rest are fetched with a single git log --no-walk call per batch.
+func (gs *GitService) GetCommitsMetadata(ctx context.Context, repoPath string, hashes []string) (map[string]GitCommit, error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository path: %w", err)
+	}
+
+	result := make(map[string]GitCommit, len(hashes))
+	var missing []string
+	for _, hash := range hashes {
+		hash = strings.ToLower(hash) // git prints lowercase hashes
+		if err := validateCommitHash(hash); err != nil {
+			return nil, fmt.Errorf("invalid commit hash: %w", err)
+		}
+		if _, done := result[hash]; done {
+			continue
+		}
+		if commit, ok := gs.commits().get(commitCacheKey{repoPath: validatedPath, hash: hash}); ok {
+			result[hash] = commit
+			continue
+		}
+		result[hash] = GitCommit{} // Placeholder so duplicates are fetched once
+		missing = append(missing, hash)
+	}
+
+	for start := 0; start < len(missing); start += maxCommitsPerLog {
+		end := min(start+maxCommitsPerLog, len(missing))
+		commits, err := gs.logCommits(ctx, validatedPath, missing[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			gs.commits().put(commitCacheKey{repoPath: validatedPath, hash: commit.Hash}, commit)
+			result[commit.Hash] = commit
+		}
+	}
+
+	for _, hash := range missing {
+		if result[hash].Hash == "" {
+			return nil, fmt.Errorf("commit not found: %s", hash)
+		}
+	}
+
+	return result, nil
+}
+
+// logCommits reads the metadata of exactly the given commits in one git invocation
+func (gs *GitService) logCommits(ctx context.Context, repoPath string, hashes []string) ([]GitCommit, error) {
+	// Format: hash\x00message\x00author\x00parent_hashes\x00ISO8601_date (null-byte delimited)
+	args := append([]string{"log", "--no-walk=unsorted", "--format=%H%x00%s%x00%an%x00%P%x00%cI"}, hashes...)
+	cmd, err := gs.buildSafeGitCommand(ctx, repoPath, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build git command: %w", err)
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit metadata: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	commits := make([]GitCommit, 0, len(lines))
+	for _, line := range lines {
+		parts := strings.SplitN(line, "\x00", 5)
+		if len(parts) < 5 {
+			continue
+		}
+
+		commit := GitCommit{
+			Hash:      parts[0],
+			Message:   parts[1],
+			Author:    parts[2],
+			Timestamp: parts[4],
+			Parents:   []string{},
+		}
+		if parts[3] != "" {
+			commit.Parents = strings.Split(parts[3], " ")
+		}
+		commits = append(commits, commit)
+	}
+
+	return commits, nil
+}
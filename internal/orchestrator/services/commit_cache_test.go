@@ -0,0 +1,157 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createCommits adds n empty commits and returns their hashes, oldest first
+func createCommits(tb testing.TB, gitService *GitService, repoPath string, n int) []string {
+	ctx := context.Background()
+	hashes := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		require.NoError(tb, gitService.runSafeGitCommand(ctx, repoPath, "commit", "--allow-empty", "-m", fmt.Sprintf("Commit %d", i)))
+		hash, err := gitService.getCurrentCommit(ctx, repoPath)
+		require.NoError(tb, err)
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+func TestCommitCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newCommitCache(2)
+	a := commitCacheKey{repoPath: "/repo", hash: "a"}
+	b := commitCacheKey{repoPath: "/repo", hash: "b"}
+	c := commitCacheKey{repoPath: "/repo", hash: "c"}
+
+	cache.put(a, GitCommit{Hash: "a"})
+	cache.put(b, GitCommit{Hash: "b"})
+	_, ok := cache.get(a) // a is now more recent than b
+	require.True(t, ok)
+	cache.put(c, GitCommit{Hash: "c"})
+
+	assert.Equal(t, 2, cache.len())
+	_, ok = cache.get(b)
+	assert.False(t, ok, "least recently used entry is evicted")
+	_, ok = cache.get(a)
+	assert.True(t, ok)
+
+	// Same hash in another repository is a different entry
+	_, ok = cache.get(commitCacheKey{repoPath: "/other", hash: "a"})
+	assert.False(t, ok)
+}
+
+func TestGitService_GetCommitsMetadata(t *testing.T) {
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+	createTestRepoWithCommit(t, gitService, repoPath)
+
+	hashes := createCommits(t, gitService, repoPath, 3)
+	ctx := context.Background()
+
+	// Duplicates and uppercase input are accepted
+	commits, err := gitService.GetCommitsMetadata(ctx, repoPath, []string{hashes[2], hashes[0], strings.ToUpper(hashes[0])})
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+
+	newest := commits[hashes[2]]
+	assert.Equal(t, "Commit 2", newest.Message)
+	assert.Equal(t, "Test User", newest.Author)
+	assert.Equal(t, []string{hashes[1]}, newest.Parents)
+	assert.NotEmpty(t, newest.Timestamp)
+	assert.Equal(t, "Commit 0", commits[hashes[0]].Message)
+
+	validatedPath, err := gitService.validateRepoPath(repoPath)
+	require.NoError(t, err)
+	cached, ok := gitService.commits().get(commitCacheKey{repoPath: validatedPath, hash: hashes[2]})
+	require.True(t, ok)
+	assert.Equal(t, newest, cached)
+
+	_, err = gitService.GetCommitsMetadata(ctx, repoPath, []string{"not-a-hash"})
+	assert.Error(t, err)
+	_, err = gitService.GetCommitsMetadata(ctx, repoPath, []string{strings.Repeat("0", 40)})
+	assert.Error(t, err)
+}
+
+func TestGitService_GetCommitHistory_FillsCache(t *testing.T) {
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+	createTestRepoWithCommit(t, gitService, repoPath)
+	gitService.commitCache = newCommitCache(defaultCommitCacheSize)
+
+	hashes := createCommits(t, gitService, repoPath, 2)
+	commits, err := gitService.GetCommitHistory(context.Background(), repoPath, 2)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+
+	// Newest first, with the metadata of the commits view
+	assert.Equal(t, hashes[1], commits[0].Hash)
+	assert.Equal(t, "Commit 1", commits[0].Message)
+	assert.Equal(t, []string{hashes[0]}, commits[0].Parents)
+	assert.NotEmpty(t, commits[0].Timestamp)
+	assert.Equal(t, hashes[0], commits[1].Hash)
+	assert.Equal(t, 2, gitService.commitCache.len())
+}
+
+// BenchmarkCommitMetadata compares per-commit subprocesses with the batched, cached lookup over 100 commits
+func BenchmarkCommitMetadata(b *testing.B) {
+	repoPath := filepath.Join(b.TempDir(), "bench_repo")
+	gitService, err := NewGitService(repoPath, true)
+	require.NoError(b, err)
+	defer gitService.Close()
+	ctx := context.Background()
+
+	require.NoError(b, gitService.InitRepository(ctx, repoPath))
+	require.NoError(b, gitService.runSafeGitCommand(ctx, repoPath, "config", "user.name", "Bench User"))
+	require.NoError(b, gitService.runSafeGitCommand(ctx, repoPath, "config", "user.email", "bench@example.com"))
+	hashes := createCommits(b, gitService, repoPath, 100)
+
+	// The benchmark resets its own cache, leaving the shared one alone
+	gitService.commitCache = newCommitCache(defaultCommitCacheSize)
+
+	b.Run("PerCommit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, hash := range hashes {
+				if _, err := gitService.GetCommitMessage(ctx, repoPath, hash); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := gitService.GetCommitAuthor(ctx, repoPath, hash); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := gitService.GetCommitTimestamp(ctx, repoPath, hash); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("BatchedCold", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			gitService.commitCache = newCommitCache(defaultCommitCacheSize)
+			if _, err := gitService.GetCommitsMetadata(ctx, repoPath, hashes); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("BatchedCached", func(b *testing.B) {
+		if _, err := gitService.GetCommitsMetadata(ctx, repoPath, hashes); err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := gitService.GetCommitsMetadata(ctx, repoPath, hashes); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -30,9 +30,14 @@ func getDataLog() *zerolog.Logger {
 	return dataLog
 }
 
+// ErrStaleWrite is returned (as a *database.StaleWriteError) by UpdateTask and UpdateProject
+// when another update got there first; reload and retry
+var ErrStaleWrite = database.ErrStaleWrite
+
 // DataService handles loading and managing data from various sources
 type DataService struct {
-	db *database.GormDB
+	db              *database.GormDB
+	insertBatchSize int
 }
 
 // NewDataService creates a new data service
@@ -53,7 +58,8 @@ func NewDataService(cfg *config.AppConfig) (*DataService, error) {
 
 	getDataLog().Info().Msg("Data service initialized successfully")
 	return &DataService{
-		db: db,
+		db:              db,
+		insertBatchSize: cfg.Database.InsertBatchSize,
 	}, nil
 }
 
@@ -69,6 +75,11 @@ func (ds *DataService) GetProject(ctx context.Context, projectID string) (*model
 
 // CreateProject creates a new project in the database
 func (ds *DataService) CreateProject(ctx context.Context, name, description, repositoryPath string) (*models.Project, error) {
+	return ds.CreateProjectWithAgentConfig(ctx, name, description, repositoryPath, nil)
+}
+
+// CreateProjectWithAgentConfig creates a new project with a default agent configuration (may be nil)
+func (ds *DataService) CreateProjectWithAgentConfig(ctx context.Context, name, description, repositoryPath string, agentConfig *models.ProjectAgentConfig) (*models.Project, error) {
 	// Generate new project ID using timestamp
 	projectID := fmt.Sprintf("project-%d", time.Now().UnixNano())
 
@@ -80,6 +91,9 @@ func (ds *DataService) CreateProject(ctx context.Context, name, description, rep
 		RepositoryPath: repositoryPath,
 		AgentID:        "", // Placeholder - will be set when agent assignment is implemented
 	}
+	if agentConfig != nil && !agentConfig.IsZero() {
+		dbProject.DefaultAgentConfig = agentConfig
+	}
 
 	if err := ds.db.CreateProject(ctx, dbProject); err != nil {
 		return nil, err
@@ -88,6 +102,16 @@ func (ds *DataService) CreateProject(ctx context.Context, name, description, rep
 	return dbProject, nil
 }
 
+// InsertProject stores a fully populated project, keeping its ID (used for seeding)
+func (ds *DataService) InsertProject(ctx context.Context, project *models.Project) error {
+	return ds.db.CreateProject(ctx, project)
+}
+
+// InsertTask stores a fully populated task, keeping its ID, status and diff (used for seeding)
+func (ds *DataService) InsertTask(ctx context.Context, task *models.Task) error {
+	return ds.db.CreateTask(ctx, task)
+}
+
 // LoadTasks loads tasks for a specific project from the database
 func (ds *DataService) LoadTasks(ctx context.Context, projectID string) (map[string]*models.Task, error) {
 	return ds.db.GetTasksByProject(ctx, projectID)
@@ -103,13 +127,23 @@ func (ds *DataService) UpdateTaskGitDiff(ctx context.Context, taskID, gitDiff st
 	return ds.db.UpdateTaskGitDiff(ctx, taskID, gitDiff)
 }
 
-// DeleteTask deletes a task from the database
+// UpdateTaskProcessCheckpoint stores a task's processing checkpoint in the database
+func (ds *DataService) UpdateTaskProcessCheckpoint(ctx context.Context, taskID, checkpoint string) error {
+	return ds.db.UpdateTaskProcessCheckpoint(ctx, taskID, checkpoint)
+}
+
+// DeleteTask deletes a task and its AI activity records from the database
 func (ds *DataService) DeleteTask(ctx context.Context, taskID string) error {
 	return ds.db.DeleteTask(ctx, taskID)
 }
 
 // CreateTask creates a new task in the database
 func (ds *DataService) CreateTask(ctx context.Context, projectID, taskID, title, description, taskFilePath string) (*models.Task, error) {
+	return ds.CreateTaskWithLabels(ctx, projectID, taskID, title, description, taskFilePath, nil)
+}
+
+// CreateTaskWithLabels creates a new task carrying initial labels (may be nil)
+func (ds *DataService) CreateTaskWithLabels(ctx context.Context, projectID, taskID, title, description, taskFilePath string, labels []string) (*models.Task, error) {
 	// Validate inputs
 	if strings.TrimSpace(title) == "" {
 		return nil, fmt.Errorf("task title cannot be empty")
@@ -140,6 +174,9 @@ func (ds *DataService) CreateTask(ctx context.Context, projectID, taskID, title,
 		BranchName:   "",
 		TaskFilePath: taskFilePath,
 	}
+	if labels = models.NormalizeLabels(labels); len(labels) > 0 {
+		dbTask.Labels = labels
+	}
 
 	if err := ds.db.CreateTask(ctx, dbTask); err != nil {
 		return nil, err
@@ -148,9 +185,41 @@ func (ds *DataService) CreateTask(ctx context.Context, projectID, taskID, title,
 	return dbTask, nil
 }
 
-// UpdateTask updates task details in the database
-func (ds *DataService) UpdateTask(ctx context.Context, projectID, taskID, title, description string) (*models.Task, error) {
-	if err := ds.db.UpdateTask(ctx, taskID, title, description); err != nil {
+// AddTaskLabels attaches labels to a task. Labels are normalized (see models.NormalizeLabels)
+// and adding one the task already has is a no-op.
+func (ds *DataService) AddTaskLabels(ctx context.Context, taskID string, labels ...string) error {
+	return ds.db.AddTaskLabels(ctx, taskID, models.NormalizeLabels(labels))
+}
+
+// RemoveTaskLabels detaches labels from a task; removing one it doesn't have is a no-op
+func (ds *DataService) RemoveTaskLabels(ctx context.Context, taskID string, labels ...string) error {
+	return ds.db.RemoveTaskLabels(ctx, taskID, models.NormalizeLabels(labels))
+}
+
+// AddPipelineRunLabels attaches labels to a pipeline run, normalized as for tasks
+func (ds *DataService) AddPipelineRunLabels(ctx context.Context, runID string, labels ...string) error {
+	return ds.db.AddPipelineRunLabels(ctx, runID, models.NormalizeLabels(labels))
+}
+
+// RemovePipelineRunLabels detaches labels from a pipeline run; removing one it doesn't have is a no-op
+func (ds *DataService) RemovePipelineRunLabels(ctx context.Context, runID string, labels ...string) error {
+	return ds.db.RemovePipelineRunLabels(ctx, runID, models.NormalizeLabels(labels))
+}
+
+// LoadTasksByLabel loads the tasks of a project that carry ALL of labels. With no labels
+// it is the same as LoadTasks.
+func (ds *DataService) LoadTasksByLabel(ctx context.Context, projectID string, labels ...string) (map[string]*models.Task, error) {
+	labels = models.NormalizeLabels(labels)
+	if len(labels) == 0 {
+		return ds.db.GetTasksByProject(ctx, projectID)
+	}
+	return ds.db.GetTasksByLabels(ctx, projectID, labels)
+}
+
+// UpdateTask updates task details in the database. expectedVersion is the Version of the
+// task the edit is based on; if the task was updated since, ErrStaleWrite is returned.
+func (ds *DataService) UpdateTask(ctx context.Context, projectID, taskID, title, description string, expectedVersion int) (*models.Task, error) {
+	if err := ds.db.UpdateTask(ctx, taskID, title, description, expectedVersion); err != nil {
 		return nil, err
 	}
 
@@ -168,9 +237,10 @@ func (ds *DataService) UpdateTask(ctx context.Context, projectID, taskID, title,
 	return task, nil
 }
 
-// UpdateProject updates project details in the database
-func (ds *DataService) UpdateProject(ctx context.Context, projectID, name, description string) (*models.Project, error) {
-	if err := ds.db.UpdateProject(ctx, projectID, name, description); err != nil {
+// UpdateProject updates project details in the database. Like UpdateTask it returns
+// ErrStaleWrite when the project is no longer at expectedVersion.
+func (ds *DataService) UpdateProject(ctx context.Context, projectID, name, description string, expectedVersion int) (*models.Project, error) {
+	if err := ds.db.UpdateProject(ctx, projectID, name, description, expectedVersion); err != nil {
 		return nil, err
 	}
 
@@ -227,6 +297,17 @@ func (ds *DataService) SaveAIActivityRecord(ctx context.Context, record *models.
 	return ds.db.SaveAIActivityRecord(ctx, record)
 }
 
+// SaveAIActivityRecords saves many AI activity records at once, in batched INSERTs of
+// database.insert_batch_size rows within one transaction that is rolled back on any failure.
+// Records whose event ID already exists are overwritten.
+func (ds *DataService) SaveAIActivityRecords(ctx context.Context, records []*models.AIActivityRecord) error {
+	batchSize := ds.insertBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultInsertBatchSize
+	}
+	return ds.db.SaveAIActivityRecords(ctx, records, batchSize)
+}
+
 // UpdateAIActivityRecord updates an existing AI activity record with parsed data
 func (ds *DataService) UpdateAIActivityRecord(ctx context.Context, record *models.AIActivityRecord) error {
 	return ds.db.UpdateAIActivityRecord(ctx, record)
@@ -237,6 +318,31 @@ func (ds *DataService) GetAIActivityByTask(ctx context.Context, taskID string) (
 	return ds.db.GetAIActivityByTask(ctx, taskID)
 }
 
+// GetAIActivityByTaskPaged retrieves one page of a task's AI activity records in timestamp
+// order, plus the task's total record count. If limit is 0, returns all records from offset on.
+func (ds *DataService) GetAIActivityByTaskPaged(ctx context.Context, taskID string, offset, limit int) ([]*models.AIActivityRecord, int, error) {
+	return ds.db.GetAIActivityByTaskPaged(ctx, taskID, offset, limit)
+}
+
+// GetAIActivityByTimeRange retrieves a task's AI activity records with a timestamp between
+// start and end (inclusive), in timestamp order
+func (ds *DataService) GetAIActivityByTimeRange(ctx context.Context, taskID string, start, end time.Time) ([]*models.AIActivityRecord, error) {
+	return ds.db.GetAIActivityByTimeRange(ctx, taskID, start, end)
+}
+
+// SearchAIActivity retrieves a task's AI activity records whose content preview, tool name
+// or tool input summary contains query, ignoring case, in timestamp order. A leading
+// "tool:Bash" qualifier restricts the search to one tool. If limit is 0, returns all matches.
+func (ds *DataService) SearchAIActivity(ctx context.Context, taskID, query string, limit int) ([]*models.AIActivityRecord, error) {
+	return ds.db.SearchAIActivity(ctx, taskID, query, limit)
+}
+
+// StreamAIActivityByTask calls fn with each AI activity record of a task in timestamp order
+// without holding them all in memory; an error from fn stops the iteration and is returned
+func (ds *DataService) StreamAIActivityByTask(ctx context.Context, taskID string, fn func(*models.AIActivityRecord) error) error {
+	return ds.db.StreamAIActivityByTask(ctx, taskID, fn)
+}
+
 // GetAIActivityByRunID retrieves all AI activity records for a pipeline run (all steps)
 func (ds *DataService) GetAIActivityByRunID(ctx context.Context, runID string) ([]*models.AIActivityRecord, error) {
 	return ds.db.GetAIActivityByRunID(ctx, runID)
@@ -247,6 +353,29 @@ func (ds *DataService) DeleteAIActivityByTask(ctx context.Context, taskID string
 	return ds.db.DeleteAIActivityByTask(ctx, taskID)
 }
 
+// DeleteAIActivityOlderThan deletes the AI activity records of all tasks with a timestamp
+// before cutoff, regardless of task status, and returns how many were deleted. Deletion
+// happens in batches; on error, deleted reports what was already removed.
+func (ds *DataService) DeleteAIActivityOlderThan(ctx context.Context, cutoff time.Time) (deleted int64, err error) {
+	for {
+		n, err := ds.db.DeleteAIActivityOlderThanBatch(ctx, cutoff, activityPruneBatchSize)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += int64(n)
+		if n < activityPruneBatchSize {
+			break
+		}
+	}
+	getDataLog().Info().Int64("deleted", deleted).Time("cutoff", cutoff).Msg("Pruned AI activity")
+	return deleted, nil
+}
+
+// CountAIActivityOlderThan counts the AI activity records with a timestamp before cutoff
+func (ds *DataService) CountAIActivityOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	return ds.db.CountAIActivityOlderThan(ctx, cutoff)
+}
+
 // GetAIActivityByEventType retrieves AI activity records filtered by event type.
 // If limit is 0, returns all matching records.
 func (ds *DataService) GetAIActivityByEventType(ctx context.Context, eventType string, limit int) ([]*models.AIActivityRecord, error) {
@@ -258,6 +387,52 @@ func (ds *DataService) GetTokenTotalsByTask(ctx context.Context, taskID string)
 	return ds.db.GetTokenTotalsByTask(ctx, taskID)
 }
 
+// defaultInsertBatchSize is the batch size of SaveAIActivityRecords when none is configured
+const defaultInsertBatchSize = 500
+
+// activityCompactionBatchSize bounds how many records one compaction transaction deletes
+const activityCompactionBatchSize = 500
+
+// activityPruneBatchSize bounds how many records one pruning statement deletes
+const activityPruneBatchSize = 500
+
+// PreviewActivityCompaction reports, per task/run, what CompactActivity would delete (dry run)
+func (ds *DataService) PreviewActivityCompaction(ctx context.Context, olderThan time.Time) ([]database.ActivityCompactionGroup, error) {
+	return ds.db.FindCompactableActivity(ctx, olderThan)
+}
+
+// CompactActivity deletes AI activity records of pipeline runs and tasks that finished before
+// olderThan. Records of pending or running tasks are never touched. With keepSummary, one
+// summary record per task/run retains token totals and the number of compacted records.
+// Deletion happens in batched transactions; on error, deleted reports what was already removed.
+func (ds *DataService) CompactActivity(ctx context.Context, olderThan time.Time, keepSummary bool) (deleted int, err error) {
+	groups, err := ds.db.FindCompactableActivity(ctx, olderThan)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find compactable activity: %w", err)
+	}
+
+	for _, group := range groups {
+		for {
+			n, err := ds.db.CompactActivityBatch(ctx, group.TaskID, group.RunID, olderThan, keepSummary, activityCompactionBatchSize)
+			if err != nil {
+				return deleted, fmt.Errorf("failed to compact activity for task %s: %w", group.TaskID, err)
+			}
+			deleted += n
+			if n < activityCompactionBatchSize {
+				break
+			}
+		}
+	}
+
+	getDataLog().Info().
+		Int("deleted", deleted).
+		Int("groups", len(groups)).
+		Time("olderThan", olderThan).
+		Bool("keepSummary", keepSummary).
+		Msg("Compacted AI activity")
+	return deleted, nil
+}
+
 // ============================================================================
 // Pipeline Operations
 // ============================================================================
@@ -5,6 +5,8 @@ package services
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 
 	"github.com/noldarim/noldarim/internal/orchestrator/database"
@@ -37,6 +39,16 @@ func TestDataServiceCRUD(t *testing.T) {
 	t.Run("TaskStatusUpdates", func(t *testing.T) {
 		testTaskStatusUpdates(t, db, ds)
 	})
+
+	// Test Task Labels
+	t.Run("TaskLabels", func(t *testing.T) {
+		testTaskLabels(t, db, ds)
+	})
+
+	// Test Pipeline Run Labels
+	t.Run("PipelineRunLabels", func(t *testing.T) {
+		testPipelineRunLabels(t, db, ds)
+	})
 }
 
 func testProjectsCRUD(t *testing.T, db *database.GormDB) {
@@ -112,10 +124,11 @@ func testTasksCRUD(t *testing.T, db *database.GormDB, ds *DataService) {
 	}
 
 	// Test Update Task
-	updatedTask, err := ds.UpdateTask(ctx, project.ID, taskView.ID, "Updated Task", "Updated description")
+	updatedTask, err := ds.UpdateTask(ctx, project.ID, taskView.ID, "Updated Task", "Updated description", taskView.Version)
 	require.NoError(t, err, "Failed to update task")
 	assert.Equal(t, "Updated Task", updatedTask.Title)
 	assert.Equal(t, "Updated description", updatedTask.Description)
+	assert.Equal(t, taskView.Version+1, updatedTask.Version)
 
 	// Test Delete Task
 	err = ds.DeleteTask(ctx, taskView.ID)
@@ -127,6 +140,67 @@ func testTasksCRUD(t *testing.T, db *database.GormDB, ds *DataService) {
 	assert.Len(t, tasks, 0, "Should have no tasks after deletion")
 }
 
+// TestDataService_ConcurrentUpdatesOneStale checks that of two updates based on the same
+// version exactly one wins; the other gets ErrStaleWrite and succeeds after reloading
+func TestDataService_ConcurrentUpdatesOneStale(t *testing.T) {
+	ds := WithDataService(t).Service
+	ctx := context.Background()
+
+	project, err := ds.CreateProject(ctx, "Concurrent", "Project edited twice", "")
+	require.NoError(t, err)
+	task, err := ds.CreateTask(ctx, project.ID, "concurrent-task", "Original", "", "")
+	require.NoError(t, err)
+
+	type update struct {
+		task *models.Task
+		err  error
+	}
+	results := make(chan update, 2)
+	var start sync.WaitGroup
+	start.Add(1)
+	for _, title := range []string{"From TUI", "From server"} {
+		go func(title string) {
+			start.Wait()
+			updated, err := ds.UpdateTask(ctx, project.ID, task.ID, title, "", task.Version)
+			results <- update{updated, err}
+		}(title)
+	}
+	start.Done()
+
+	var succeeded, stale int
+	for i := 0; i < 2; i++ {
+		result := <-results
+		switch {
+		case result.err == nil:
+			succeeded++
+			assert.Equal(t, task.Version+1, result.task.Version)
+		case errors.Is(result.err, ErrStaleWrite):
+			stale++
+			var staleErr *database.StaleWriteError
+			require.ErrorAs(t, result.err, &staleErr)
+			assert.Equal(t, task.Version, staleErr.ExpectedVersion)
+			assert.Equal(t, task.Version+1, staleErr.CurrentVersion)
+		default:
+			t.Fatalf("unexpected update error: %v", result.err)
+		}
+	}
+	assert.Equal(t, 1, succeeded, "exactly one update wins")
+	assert.Equal(t, 1, stale, "the other update is stale")
+
+	// The loser reloads and retries
+	current, err := ds.GetTask(ctx, task.ID)
+	require.NoError(t, err)
+	retried, err := ds.UpdateTask(ctx, project.ID, task.ID, "Retried", "", current.Version)
+	require.NoError(t, err)
+	assert.Equal(t, "Retried", retried.Title)
+
+	// Projects follow the same rule
+	_, err = ds.UpdateProject(ctx, project.ID, "Renamed", "", project.Version)
+	require.NoError(t, err)
+	_, err = ds.UpdateProject(ctx, project.ID, "Renamed again", "", project.Version)
+	assert.ErrorIs(t, err, ErrStaleWrite)
+}
+
 func testTaskStatusUpdates(t *testing.T, db *database.GormDB, ds *DataService) {
 	ctx := context.Background()
 
@@ -165,3 +239,84 @@ func testTaskStatusUpdates(t *testing.T, db *database.GormDB, ds *DataService) {
 	}
 }
 
+func testTaskLabels(t *testing.T, db *database.GormDB, ds *DataService) {
+	ctx := context.Background()
+
+	project := &models.Project{ID: "test-project-labels", Name: "Labels"}
+	require.NoError(t, db.CreateProject(ctx, project))
+
+	bug, err := ds.CreateTaskWithLabels(ctx, project.ID, "task-bug", "Fix crash", "", "", []string{" Bug ", "ui", "bug"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bug", "ui"}, bug.Labels, "labels are normalized on creation")
+	_, err = ds.CreateTaskWithLabels(ctx, project.ID, "task-feature", "Add export", "", "", []string{"feature", "ui"})
+	require.NoError(t, err)
+	_, err = ds.CreateTask(ctx, project.ID, "task-plain", "Refactor", "", "")
+	require.NoError(t, err)
+
+	// Adding and removing are idempotent
+	require.NoError(t, ds.AddTaskLabels(ctx, "task-plain", "backend", "BACKEND"))
+	require.NoError(t, ds.AddTaskLabels(ctx, "task-plain", "backend"))
+	require.NoError(t, ds.RemoveTaskLabels(ctx, "task-bug", "ui"))
+	require.NoError(t, ds.RemoveTaskLabels(ctx, "task-bug", "ui", "never-added"))
+
+	plain, err := ds.GetTask(ctx, "task-plain")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backend"}, plain.Labels)
+
+	// Several labels match tasks carrying all of them
+	tasks, err := ds.LoadTasksByLabel(ctx, project.ID, "ui")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"task-feature"}, taskIDs(tasks))
+
+	tasks, err = ds.LoadTasksByLabel(ctx, project.ID, "feature", "ui")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"task-feature"}, taskIDs(tasks))
+
+	tasks, err = ds.LoadTasksByLabel(ctx, project.ID, "bug", "ui")
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	tasks, err = ds.LoadTasksByLabel(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 3, "no labels loads every task")
+
+	// Deleting a task drops its labels
+	require.NoError(t, ds.DeleteTask(ctx, "task-feature"))
+	tasks, err = ds.LoadTasksByLabel(ctx, project.ID, "ui")
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func testPipelineRunLabels(t *testing.T, db *database.GormDB, ds *DataService) {
+	ctx := context.Background()
+
+	project := &models.Project{ID: "test-project-run-labels", Name: "Run labels"}
+	require.NoError(t, db.CreateProject(ctx, project))
+
+	run := &models.PipelineRun{ID: "run-labelled", ProjectID: project.ID, Name: "Fix crash", Labels: []string{"bug", "ui"}}
+	require.NoError(t, ds.CreatePipelineRun(ctx, run), "labels are stored with the run")
+	require.NoError(t, ds.CreatePipelineRun(ctx, &models.PipelineRun{ID: "run-plain", ProjectID: project.ID}))
+
+	require.NoError(t, ds.AddPipelineRunLabels(ctx, "run-plain", "Backend", "backend"))
+	require.NoError(t, ds.RemovePipelineRunLabels(ctx, "run-labelled", "ui", "never-added"))
+
+	loaded, err := ds.GetPipelineRun(ctx, "run-labelled")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bug"}, loaded.Labels)
+
+	runs, err := ds.GetPipelineRunsByProject(ctx, project.ID)
+	require.NoError(t, err)
+	labels := make(map[string][]string, len(runs))
+	for _, run := range runs {
+		labels[run.ID] = run.Labels
+	}
+	assert.Equal(t, map[string][]string{"run-labelled": {"bug"}, "run-plain": {"backend"}}, labels)
+}
+
+func taskIDs(tasks map[string]*models.Task) []string {
+	result := make([]string, 0, len(tasks))
+	for id := range tasks {
+		result = append(result, id)
+	}
+	return result
+}
@@ -96,6 +96,88 @@ func TestGitService_SecurityValidations(t *testing.T) {
 		}
 	})
 
+	t.Run("Tag Name Validation", func(t *testing.T) {
+		invalidTags := map[string]string{
+			"":                        "cannot be empty",
+			string(make([]byte, 300)): "too long",
+			"-tag":                    "cannot start with",
+			".tag":                    "cannot start with",
+			"tag;rm -rf /":            "invalid characters",
+			"v1..2":                   "not a valid ref name",
+			"release/.hidden":         "not a valid ref name",
+			"v1.":                     "not a valid ref name",
+			"tag.lock":                "not a valid ref name",
+		}
+		for tag, reason := range invalidTags {
+			err := validateTagName(tag)
+			if assert.Error(t, err, "Tag name should be invalid: %q", tag) {
+				assert.Contains(t, err.Error(), reason)
+			}
+		}
+
+		for _, tag := range []string{"v1.2.0", "noldarim/run-abc123", "release_2026"} {
+			assert.NoError(t, validateTagName(tag), "Tag name should be valid: %s", tag)
+		}
+	})
+
+	t.Run("Commit Identity Validation", func(t *testing.T) {
+		invalidIdentities := map[CommitIdentity]string{
+			{Name: string(make([]byte, 300))}:                  "too long",
+			{Name: "agent\nSigned-off-by: someone"}:            "invalid characters",
+			{Name: "agent <evil@example.com>"}:                 "invalid characters",
+			{Name: "   "}:                                      "invalid characters",
+			{Email: "agent@example.com>"}:                      "invalid author email",
+			{Email: "agent"}:                                   "invalid author email",
+			{Email: "a@b.c" + string(make([]byte, 300))}:       "too long",
+			{Name: "noldarim-agent", Email: "$(whoami)@x.com"}: "invalid author email",
+		}
+		for identity, reason := range invalidIdentities {
+			err := validateCommitIdentity(identity)
+			if assert.Error(t, err, "Identity should be invalid: %+v", identity) {
+				assert.Contains(t, err.Error(), reason)
+			}
+		}
+
+		for _, identity := range []CommitIdentity{
+			{},
+			{Name: "noldarim-agent", Email: "agent@noldarim.local"},
+			{Name: "Zoë O'Brien-Smith Jr."},
+			{Email: "first.last+tag@example.co.uk"},
+		} {
+			assert.NoError(t, validateCommitIdentity(identity), "Identity should be valid: %+v", identity)
+		}
+	})
+
+	t.Run("Commit Trailer Validation", func(t *testing.T) {
+		invalidTrailers := map[[2]string]string{
+			{"", "x"}:               "invalid characters",
+			{"Co authored by", "x"}: "invalid characters",
+			{"-Key", "x"}:           "invalid characters",
+			{"Co-authored-by", ""}:  "cannot be empty",
+			{"Co-authored-by", string(make([]byte, 600))}:       "too long",
+			{"Co-authored-by", "A <a@x.com>\nSigned-off-by: B"}: "control characters",
+			{"Co-authored-by", "A <$(whoami)@x.com>"}:           "invalid email",
+			{"Co-authored-by", "A > /etc/passwd <a@x.com>"}:     "dangerous pattern",
+			{"Co-authored-by", "A <b> <a@x.com>"}:               "dangerous pattern",
+			{"Co-authored-by", "A; rm -rf / <a@x.com>"}:         "dangerous pattern",
+			{"Reviewed-by", "A | cat"}:                          "dangerous pattern",
+		}
+		for trailer, reason := range invalidTrailers {
+			err := validateTrailer(trailer[0], trailer[1])
+			if assert.Error(t, err, "Trailer should be invalid: %q", trailer) {
+				assert.Contains(t, err.Error(), reason)
+			}
+		}
+
+		for _, trailer := range [][2]string{
+			{"Co-authored-by", "noldarim-agent <agent@noldarim.local>"},
+			{"Co-authored-by", "<agent@noldarim.local>"},
+			{"Noldarim-Run", "run-abc123"},
+		} {
+			assert.NoError(t, validateTrailer(trailer[0], trailer[1]), "Trailer should be valid: %q", trailer)
+		}
+	})
+
 	t.Run("Commit Message Validation", func(t *testing.T) {
 		// Test empty commit message
 		err := validateCommitMessage("")
@@ -7,13 +7,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/rs/zerolog"
 	"github.com/noldarim/noldarim/internal/config"
@@ -35,19 +39,34 @@ func getLog() *zerolog.Logger {
 
 // GitService handles git operations for projects and tasks
 type GitService struct {
-	workDir string
-	config  *config.AppConfig
+	workDir  string
+	config   *config.AppConfig
+	identity CommitIdentity
+
+	commitCache *commitCache // Commit metadata cache; nil shares commitMetadataCache
+}
+
+// CommitIdentity is the author and committer recorded on commits made by a GitService.
+// An empty field keeps the value from the user's git config.
+type CommitIdentity struct {
+	Name  string
+	Email string
 }
 
 // ErrBranchNotFound indicates the requested branch does not exist.
 var ErrBranchNotFound = fmt.Errorf("branch not found")
 
+// ErrCommitNotFound indicates the requested commit does not exist in the repository.
+var ErrCommitNotFound = errors.New("commit not found")
+
 // Security constants for validation
 const (
 	maxPathLength          = 4096
 	maxBranchNameLength    = 250
 	maxCommitMessageLength = 8192
 	maxAgentIDLength       = 100
+	maxTrailerKeyLength    = 100
+	maxTrailerValueLength  = 512
 )
 
 // Regular expressions for validation
@@ -55,6 +74,15 @@ var (
 	// Safe branch name pattern: alphanumeric, hyphens, underscores, forward slashes
 	branchNameRegex = regexp.MustCompile(`^[a-zA-Z0-9/_-]+$`)
 
+	// Safe tag name pattern: a branch name that may also contain dots (v1.2.0)
+	tagNameRegex = regexp.MustCompile(`^[a-zA-Z0-9/._-]+$`)
+
+	// Safe trailer key pattern: a token like Co-authored-by
+	trailerKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-]*$`)
+
+	// Trailer value ending in an email in angle brackets ("Name <email>")
+	trailerEmailRegex = regexp.MustCompile(` ?<([^<>]*)>$`)
+
 	// Safe agent ID pattern: alphanumeric, hyphens, underscores
 	agentIDRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
@@ -72,25 +100,32 @@ var (
 
 // Allowed git operations for security
 var allowedGitOperations = map[string]bool{
-	"init":       true,
-	"add":        true,
-	"commit":     true,
-	"checkout":   true,
-	"branch":     true,
-	"status":     true,
-	"rev-parse":  true,
-	"diff":       true,
-	"log":        true,
-	"show-ref":   true,
-	"worktree":   true,
-	"stash":      true,
-	"reset":      true,
-	"clean":      true,
-	"remote":     true,
-	"config":     true,
-	"merge":      true,
-	"merge-base": true,
-	"update-ref": true,
+	"init":        true,
+	"add":         true,
+	"commit":      true,
+	"checkout":    true,
+	"branch":      true,
+	"status":      true,
+	"rev-parse":   true,
+	"diff":        true,
+	"log":         true,
+	"show-ref":    true,
+	"worktree":    true,
+	"stash":       true,
+	"reset":       true,
+	"clean":       true,
+	"remote":      true,
+	"config":      true,
+	"merge":       true,
+	"merge-base":  true,
+	"update-ref":  true,
+	"apply":       true,
+	"rebase":      true,
+	"cherry-pick": true,
+	"tag":         true,
+	"blame":       true,
+	"rev-list":    true,
+	"revert":      true,
 }
 
 // NewGitService creates a new git service with the provided repository path
@@ -157,6 +192,11 @@ func NewGitServiceWithConfig(repoPath string, cfg *config.AppConfig, createIfNot
 		workDir: absPath,
 		config:  cfg,
 	}
+	if cfg != nil {
+		if err := gs.SetCommitIdentity(CommitIdentity{Name: cfg.Git.AuthorName, Email: cfg.Git.AuthorEmail}); err != nil {
+			return nil, fmt.Errorf("invalid git author: %w", err)
+		}
+	}
 
 	// Check if directory exists, create if needed
 	if _, err := os.Stat(absPath); os.IsNotExist(err) {
@@ -238,6 +278,33 @@ func validateBranchName(name string) error {
 	return nil
 }
 
+// validateTagName validates tag names for security, like validateBranchName but allowing
+// dots where git does
+func validateTagName(name string) error {
+	if name == "" {
+		return fmt.Errorf("tag name cannot be empty")
+	}
+
+	if len(name) > maxBranchNameLength {
+		return fmt.Errorf("tag name too long: %d characters (max: %d)", len(name), maxBranchNameLength)
+	}
+
+	if strings.HasPrefix(name, "-") || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("tag name cannot start with '-' or '.'")
+	}
+
+	if !tagNameRegex.MatchString(name) {
+		return fmt.Errorf("tag name contains invalid characters: %s", name)
+	}
+
+	// Dot sequences git refuses in ref names
+	if strings.Contains(name, "..") || strings.Contains(name, "/.") || strings.HasSuffix(name, ".") || strings.HasSuffix(name, ".lock") {
+		return fmt.Errorf("tag name is not a valid ref name: %s", name)
+	}
+
+	return nil
+}
+
 // validateCommitMessage validates commit messages for security
 func validateCommitMessage(message string) error {
 	if message == "" {
@@ -258,6 +325,32 @@ func validateCommitMessage(message string) error {
 	return nil
 }
 
+// SanitizeCommitText strips control and bidi formatting characters from free text such as
+// a task title before it is embedded in a commit message. Everything else, non-ASCII
+// letters included, is kept as written; tabs and newlines become spaces.
+func SanitizeCommitText(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			return ' '
+		case unicode.IsControl(r) || unicode.Is(unicode.Bidi_Control, r):
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// ValidateCommitText checks free text against the same dangerous patterns as commit
+// messages, so callers can reject it up front instead of failing the commit
+func ValidateCommitText(text string) error {
+	for _, pattern := range dangerousPatterns {
+		if pattern.MatchString(text) {
+			return fmt.Errorf("text contains dangerous pattern: %s", pattern.String())
+		}
+	}
+	return nil
+}
+
 // validateConfigKey validates git configuration keys
 func (gs *GitService) validateConfigKey(key string) error {
 	if len(key) == 0 {
@@ -294,6 +387,72 @@ func (gs *GitService) validateConfigValue(value string) error {
 	return nil
 }
 
+// validateFilePath validates a repository-relative file path for security
+func validateFilePath(path string) error {
+	if path == "" {
+		return fmt.Errorf("file path cannot be empty")
+	}
+
+	if len(path) > maxPathLength {
+		return fmt.Errorf("file path too long: %d characters (max: %d)", len(path), maxPathLength)
+	}
+
+	if filepath.IsAbs(path) {
+		return fmt.Errorf("file path must be relative to the repository: %s", path)
+	}
+
+	// Only whole ".." segments traverse; names like "v1..v2.txt" are fine
+	for _, segment := range strings.Split(filepath.ToSlash(path), "/") {
+		if segment == ".." {
+			return fmt.Errorf("file path contains invalid directory traversal")
+		}
+	}
+
+	return nil
+}
+
+// validateCommitIdentity validates a commit author name and email for security, with the
+// rules the configured git.author_name and git.author_email are checked against at load
+func validateCommitIdentity(identity CommitIdentity) error {
+	return config.ValidateGitAuthor(identity.Name, identity.Email)
+}
+
+// validateTrailer validates a commit trailer for security. Values get the commit message
+// checks, except that they may end in an email address in angle brackets.
+func validateTrailer(key, value string) error {
+	if len(key) > maxTrailerKeyLength {
+		return fmt.Errorf("trailer key too long: %d characters (max: %d)", len(key), maxTrailerKeyLength)
+	}
+	if !trailerKeyRegex.MatchString(key) {
+		return fmt.Errorf("trailer key contains invalid characters: %q", key)
+	}
+
+	if value == "" {
+		return fmt.Errorf("trailer %s value cannot be empty", key)
+	}
+	if len(value) > maxTrailerValueLength {
+		return fmt.Errorf("trailer %s value too long: %d characters (max: %d)", key, len(value), maxTrailerValueLength)
+	}
+	if strings.ContainsFunc(value, unicode.IsControl) {
+		return fmt.Errorf("trailer %s value contains control characters", key)
+	}
+
+	rest := value
+	if match := trailerEmailRegex.FindStringSubmatch(value); match != nil {
+		if config.ValidateGitAuthor("", match[1]) != nil {
+			return fmt.Errorf("trailer %s value contains invalid email: %q", key, match[1])
+		}
+		rest = strings.TrimSuffix(value, match[0])
+	}
+	for _, pattern := range dangerousPatterns {
+		if pattern.MatchString(rest) {
+			return fmt.Errorf("trailer %s value contains dangerous pattern: %s", key, pattern.String())
+		}
+	}
+
+	return nil
+}
+
 // validateAgentID validates agent IDs for security
 func validateAgentID(agentID string) error {
 	if agentID == "" {
@@ -334,7 +493,7 @@ func validateCommitHash(hash string) error {
 
 // getSafeEnvironment returns a minimal, safe environment for git commands
 func (gs *GitService) getSafeEnvironment() []string {
-	return []string{
+	env := []string{
 		"HOME=" + os.Getenv("HOME"),
 		"USER=" + os.Getenv("USER"),
 		"PATH=" + os.Getenv("PATH"),
@@ -344,6 +503,24 @@ func (gs *GitService) getSafeEnvironment() []string {
 		"GIT_TERMINAL_PROMPT=0", // Disable interactive prompts
 		"GIT_ASKPASS=",          // Disable password prompts
 	}
+	// The configured identity applies to every commit git creates: commits, merges,
+	// cherry-picks, rebases and annotated tags
+	if gs.identity.Name != "" {
+		env = append(env, "GIT_AUTHOR_NAME="+gs.identity.Name, "GIT_COMMITTER_NAME="+gs.identity.Name)
+	}
+	if gs.identity.Email != "" {
+		env = append(env, "GIT_AUTHOR_EMAIL="+gs.identity.Email, "GIT_COMMITTER_EMAIL="+gs.identity.Email)
+	}
+	return env
+}
+
+// SetCommitIdentity sets the author and committer of the commits this service creates
+func (gs *GitService) SetCommitIdentity(identity CommitIdentity) error {
+	if err := validateCommitIdentity(identity); err != nil {
+		return err
+	}
+	gs.identity = identity
+	return nil
 }
 
 // buildSafeGitCommand builds a git command with security validations
@@ -486,8 +663,9 @@ This is a noldarim project repository.
 		return fmt.Errorf("failed to create noldarim.md file: %w", err)
 	}
 
-	// Create initial commit (CreateCommit method handles adding files and committing)
-	if err := gs.CreateCommit(ctx, validatedPath, "noldarim project initialized"); err != nil {
+	// Create initial commit. The repository has no branch yet to protect, and setting up the
+	// project is not task work, so branch protection does not apply.
+	if err := gs.createCommit(ctx, validatedPath, "noldarim project initialized", nil); err != nil {
 		return fmt.Errorf("failed to create initial commit: %w", err)
 	}
 
@@ -584,8 +762,84 @@ func (gs *GitService) ValidateRepository(ctx context.Context, repoPath string) (
 	return state, nil
 }
 
-// CreateCommit creates a new commit with the given message
+// CreateCommit creates a new commit with the given message. It refuses to commit to a
+// protected branch with a *ProtectedBranchError.
 func (gs *GitService) CreateCommit(ctx context.Context, repoPath, message string) error {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := gs.checkCurrentBranchWritable(ctx, validatedPath, "commit"); err != nil {
+		return err
+	}
+	return gs.createCommit(ctx, validatedPath, message, nil)
+}
+
+// CreateCommitWithTrailers adds all changes and commits them to the current branch with
+// git trailers ("Co-authored-by": "Name <email>") appended to the message, in key order
+func (gs *GitService) CreateCommitWithTrailers(ctx context.Context, repoPath, message string, trailers map[string]string) error {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return fmt.Errorf("invalid repository path: %w", err)
+	}
+	for key, value := range trailers {
+		if err := validateTrailer(key, value); err != nil {
+			return fmt.Errorf("invalid commit trailer: %w", err)
+		}
+	}
+	if err := gs.checkCurrentBranchWritable(ctx, validatedPath, "commit"); err != nil {
+		return err
+	}
+	return gs.createCommit(ctx, validatedPath, message, trailers)
+}
+
+// ErrNoCommits is returned when an operation needs a commit but the repository has none yet
+var ErrNoCommits = errors.New("repository has no commits")
+
+// AmendCommit adds all changes to the last commit of the current branch and replaces its
+// message with newMessage
+func (gs *GitService) AmendCommit(ctx context.Context, repoPath, newMessage string) error {
+	if err := validateCommitMessage(newMessage); err != nil {
+		return fmt.Errorf("invalid commit message: %w", err)
+	}
+	return gs.amendCommit(ctx, repoPath, "-m", newMessage)
+}
+
+// AmendCommitNoEdit adds all changes to the last commit of the current branch, keeping its message
+func (gs *GitService) AmendCommitNoEdit(ctx context.Context, repoPath string) error {
+	return gs.amendCommit(ctx, repoPath, "--no-edit")
+}
+
+// amendCommit stages all changes and runs git commit --amend with messageArgs
+func (gs *GitService) amendCommit(ctx context.Context, repoPath string, messageArgs ...string) error {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return fmt.Errorf("invalid repository path: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if _, err := gs.gitOutput(ctx, validatedPath, nil, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return fmt.Errorf("cannot amend: %w", ErrNoCommits)
+	}
+	if err := gs.checkCurrentBranchWritable(ctx, validatedPath, "amend"); err != nil {
+		return err
+	}
+
+	if err := gs.runSafeGitCommand(ctx, validatedPath, "add", "."); err != nil {
+		return fmt.Errorf("failed to add changes: %w", err)
+	}
+	if _, err := gs.gitOutput(ctx, validatedPath, nil, append([]string{"commit", "--amend"}, messageArgs...)...); err != nil {
+		return fmt.Errorf("failed to amend commit: %w", err)
+	}
+
+	getLog().Info().Str("repo_path", validatedPath).Msg("Successfully amended commit in repository")
+	return nil
+}
+
+// createCommit adds all changes and commits them to the current branch
+func (gs *GitService) createCommit(ctx context.Context, repoPath, message string, trailers map[string]string) error {
 	getLog().Debug().Str("repo_path", repoPath).Msg("Creating commit in repository")
 
 	// Validate repository path
@@ -616,7 +870,16 @@ func (gs *GitService) CreateCommit(ctx context.Context, repoPath, message string
 	}
 
 	// Create commit
-	if err := gs.runSafeGitCommand(ctx, validatedPath, "commit", "-m", message); err != nil {
+	args := []string{"commit", "-m", message}
+	keys := make([]string, 0, len(trailers))
+	for key := range trailers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, "--trailer", key+": "+trailers[key])
+	}
+	if err := gs.runSafeGitCommand(ctx, validatedPath, args...); err != nil {
 		return fmt.Errorf("failed to create commit: %w", err)
 	}
 
@@ -644,6 +907,10 @@ func (gs *GitService) CommitSpecificFiles(ctx context.Context, repoPath string,
 		return fmt.Errorf("no files specified to commit")
 	}
 
+	if err := gs.checkCurrentBranchWritable(ctx, validatedPath, "commit"); err != nil {
+		return err
+	}
+
 	// Check if files exist before staging
 	for _, fileName := range fileNames {
 		fullPath := filepath.Join(validatedPath, fileName)
@@ -692,6 +959,9 @@ func (gs *GitService) CreateBranch(ctx context.Context, repoPath, branchName str
 	if err := validateBranchName(branchName); err != nil {
 		return fmt.Errorf("invalid branch name: %w", err)
 	}
+	if err := gs.checkBranchWritable(branchName, "create branch"); err != nil {
+		return err
+	}
 
 	// Check if branch already exists
 	exists, err := gs.branchExists(ctx, validatedPath, branchName)
@@ -732,6 +1002,9 @@ func (gs *GitService) CreateBranchFromRef(ctx context.Context, repoPath, branchN
 	if ref == "" {
 		return fmt.Errorf("ref cannot be empty")
 	}
+	if err := gs.checkBranchWritable(branchName, "create branch"); err != nil {
+		return err
+	}
 
 	// Check if branch already exists
 	exists, err := gs.branchExists(ctx, validatedPath, branchName)
@@ -910,6 +1183,67 @@ func (gs *GitService) branchExists(ctx context.Context, repoPath, branchName str
 	return true, nil
 }
 
+// BranchExists reports whether a local branch exists in repoPath
+func (gs *GitService) BranchExists(ctx context.Context, repoPath, branchName string) (bool, error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return false, fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateBranchName(branchName); err != nil {
+		return false, fmt.Errorf("invalid branch name: %w", err)
+	}
+	return gs.branchExists(ctx, validatedPath, branchName)
+}
+
+// AheadBehind counts the commits on branch that are not on base (ahead) and the commits on
+// base that are not on branch (behind). Both are local branches; a missing one is reported
+// as ErrBranchNotFound.
+func (gs *GitService) AheadBehind(ctx context.Context, repoPath, branch, base string) (ahead, behind int, err error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateBranchName(branch); err != nil {
+		return 0, 0, fmt.Errorf("invalid branch name: %w", err)
+	}
+	if err := validateBranchName(base); err != nil {
+		return 0, 0, fmt.Errorf("invalid base branch name: %w", err)
+	}
+	if branch == base {
+		return 0, 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	for _, name := range []string{branch, base} {
+		exists, err := gs.branchExists(ctx, validatedPath, name)
+		if err != nil {
+			return 0, 0, err
+		}
+		if !exists {
+			return 0, 0, fmt.Errorf("%w: %s", ErrBranchNotFound, name)
+		}
+	}
+
+	// Left of base...branch are the commits only on base, right the ones only on branch
+	output, err := gs.gitOutput(ctx, validatedPath, nil, "rev-list", "--left-right", "--count", "refs/heads/"+base+"...refs/heads/"+branch)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count commits between %s and %s: %w", base, branch, err)
+	}
+	counts := strings.Fields(output)
+	if len(counts) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", output)
+	}
+	if behind, err = strconv.Atoi(counts[0]); err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", output)
+	}
+	if ahead, err = strconv.Atoi(counts[1]); err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", output)
+	}
+	return ahead, behind, nil
+}
+
 // GitCommit represents a git commit with its metadata
 type GitCommit struct {
 	Hash      string
@@ -919,18 +1253,16 @@ type GitCommit struct {
 	Timestamp string // ISO 8601 commit date (optional, populated by some queries)
 }
 
-// GetCommitHistory retrieves the commit history for the repository
+// GetCommitHistory retrieves the commit history for the repository. Only the hashes are
+// listed with git; their metadata comes from GetCommitsMetadata and its cache.
 func (gs *GitService) GetCommitHistory(ctx context.Context, repoPath string, limit int) ([]GitCommit, error) {
 	if limit <= 0 {
 		limit = 100 // Default limit
 	}
 
-	// Build git log command with format to extract commit info
-	// Format: hash\x00message\x00author\x00parent_hashes (null-byte delimited to avoid corruption from | in commit messages)
 	// Use --topo-order to preserve branch topology for proper graph visualization
-	cmd, err := gs.buildSafeGitCommand(ctx, repoPath, "log",
+	cmd, err := gs.buildSafeGitCommand(ctx, repoPath, "rev-list",
 		fmt.Sprintf("--max-count=%d", limit),
-		"--format=%H%x00%s%x00%an%x00%P",
 		"--all",
 		"--topo-order")
 	if err != nil {
@@ -939,43 +1271,23 @@ func (gs *GitService) GetCommitHistory(ctx context.Context, repoPath string, lim
 
 	output, err := cmd.Output()
 	if err != nil {
-		// Check if it's just an empty repository
-		if strings.Contains(err.Error(), "does not have any commits") {
-			return []GitCommit{}, nil
-		}
 		return nil, fmt.Errorf("failed to get commit history: %w", err)
 	}
 
-	// Parse the output
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	commits := make([]GitCommit, 0, len(lines))
-
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-
-		parts := strings.Split(line, "\x00")
-		if len(parts) < 4 {
-			continue // Skip malformed lines
-		}
-
-		commit := GitCommit{
-			Hash:    parts[0],
-			Message: parts[1],
-			Author:  parts[2],
-		}
-
-		// Parse parent hashes (space-separated)
-		if parts[3] != "" {
-			commit.Parents = strings.Split(parts[3], " ")
-		} else {
-			commit.Parents = []string{}
-		}
+	hashes := strings.Fields(string(output))
+	if len(hashes) == 0 {
+		return []GitCommit{}, nil // Empty repository
+	}
 
-		commits = append(commits, commit)
+	metadata, err := gs.GetCommitsMetadata(ctx, repoPath, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit history: %w", err)
 	}
 
+	commits := make([]GitCommit, 0, len(hashes))
+	for _, hash := range hashes {
+		commits = append(commits, metadata[hash])
+	}
 	return commits, nil
 }
 
@@ -1403,6 +1715,58 @@ func (gs *GitService) GetWorktreeBranch(path string) (string, error) {
 	return branch, nil
 }
 
+// detachedHeadPrefix starts the GetWorktreeBranch result for a worktree with no branch checked out
+const detachedHeadPrefix = "(detached HEAD"
+
+// IsDetachedHead reports whether a GetWorktreeBranch result denotes a detached HEAD
+func IsDetachedHead(branch string) bool {
+	return strings.HasPrefix(branch, detachedHeadPrefix)
+}
+
+// EnsureWorktreeOnBranch makes sure branchName is checked out in a worktree before it is
+// committed to. A detached HEAD is repaired by pointing branchName at the current commit and
+// checking it out, keeping uncommitted changes. It fails rather than moving an existing
+// branch that HEAD does not descend from, or when another branch is checked out.
+func (gs *GitService) EnsureWorktreeOnBranch(ctx context.Context, worktreePath, branchName string) error {
+	if err := validateBranchName(branchName); err != nil {
+		return fmt.Errorf("invalid branch name: %w", err)
+	}
+	if err := gs.checkBranchWritable(branchName, "check out branch"); err != nil {
+		return err
+	}
+
+	current, err := gs.GetWorktreeBranch(worktreePath)
+	if err != nil {
+		return err
+	}
+	if current == branchName {
+		return nil
+	}
+	if !IsDetachedHead(current) {
+		return fmt.Errorf("worktree %s is on branch %s, expected %s", worktreePath, current, branchName)
+	}
+
+	exists, err := gs.branchExists(ctx, worktreePath, branchName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		descends, err := gs.IsFastForwardPossible(ctx, worktreePath, branchName, "HEAD")
+		if err != nil {
+			return err
+		}
+		if !descends {
+			return fmt.Errorf("worktree %s is %s, which has diverged from branch %s", worktreePath, current, branchName)
+		}
+	}
+
+	getLog().Warn().Str("worktree", worktreePath).Str("branch", branchName).Msgf("Worktree in %s, checking out task branch", current)
+	if err := gs.runSafeGitCommand(ctx, worktreePath, "checkout", "-B", branchName); err != nil {
+		return fmt.Errorf("failed to check out branch %s: %w", branchName, err)
+	}
+	return nil
+}
+
 // GenerateTaskBranchName generates a consistent branch name for a task
 func GenerateTaskBranchName(taskID string) string {
 	return fmt.Sprintf("task-%s", taskID)
@@ -1494,6 +1858,9 @@ func (gs *GitService) AddWorktree(ctx context.Context, worktreePath, branchName,
 	if err := validateBranchName(branchName); err != nil {
 		return fmt.Errorf("invalid branch name: %w", err)
 	}
+	if err := gs.checkBranchWritable(branchName, "add worktree"); err != nil {
+		return err
+	}
 
 	// Build command arguments
 	var args []string
@@ -1659,18 +2026,42 @@ func (gs *GitService) resolveToBranchName(ctx context.Context, ref string) (stri
 }
 
 // GetDiff returns the full git diff output for the repository
-// This captures ALL changes (tracked and untracked) by first staging them
-func (gs *GitService) GetDiff(ctx context.Context, repoPath string) (string, error) {
+// This captures ALL changes (tracked and untracked) by first staging them.
+// When paths are given, only changes under those repository-relative paths are included.
+func (gs *GitService) GetDiff(ctx context.Context, repoPath string, paths ...string) (string, error) {
+	return gs.getDiff(ctx, repoPath, nil, paths)
+}
+
+// GetWordDiff is GetDiff with word-level changes (git diff --word-diff=porcelain) instead
+// of a unified line diff. Each hunk line is a context (" "), removed ("-") or added ("+")
+// span of words, and a line of just "~" ends a line of the file:
+//
+//	 a { color: 
+//	-red;
+//	+blue;
+//	  }
+//	~
+func (gs *GitService) GetWordDiff(ctx context.Context, repoPath string, paths ...string) (string, error) {
+	return gs.getDiff(ctx, repoPath, []string{"--word-diff=porcelain"}, paths)
+}
+
+// getDiff stages intent-to-add for untracked files and diffs against HEAD with extra options
+func (gs *GitService) getDiff(ctx context.Context, repoPath string, options []string, paths []string) (string, error) {
 	// First, add all files to staging to capture untracked files in the diff
 	// This is safe since we're capturing diff BEFORE the commit in the workflow
-	addErr := gs.runSafeGitCommand(ctx, repoPath, "add", "-N", ".")
+	addArgs := []string{"add", "-N", "."}
+	if len(paths) > 0 {
+		addArgs = append([]string{"add", "-N", "--"}, paths...)
+	}
+	addErr := gs.runSafeGitCommand(ctx, repoPath, addArgs...)
 	if addErr != nil {
 		// Non-critical - continue even if add fails
 		getLog().Debug().Err(addErr).Msg("Failed to add files for diff capture")
 	}
 
 	// Now get the diff including staged and unstaged changes
-	cmd, err := gs.buildSafeGitCommand(ctx, repoPath, "diff", "HEAD")
+	args := append(append([]string{"diff"}, options...), "HEAD")
+	cmd, err := gs.buildSafeGitCommand(ctx, repoPath, withPathScope(args, paths)...)
 	if err != nil {
 		return "", fmt.Errorf("failed to build git command: %w", err)
 	}
@@ -1688,9 +2079,23 @@ func (gs *GitService) GetDiff(ctx context.Context, repoPath string) (string, err
 	return string(output), nil
 }
 
-// GetDiffStat returns the git diff --stat output for the repository
-func (gs *GitService) GetDiffStat(ctx context.Context, repoPath string) (string, error) {
-	cmd, err := gs.buildSafeGitCommand(ctx, repoPath, "diff", "--stat", "HEAD")
+// GetFileDiff returns the diff of a single file against HEAD, untracked files included.
+// A file without changes gives an empty diff.
+func (gs *GitService) GetFileDiff(ctx context.Context, repoPath, filePath string) (string, error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateFilePath(filePath); err != nil {
+		return "", fmt.Errorf("invalid file path: %w", err)
+	}
+
+	return gs.GetDiff(ctx, validatedPath, filepath.Clean(filePath))
+}
+
+// GetDiffStat returns the git diff --stat output for the repository, optionally limited to paths
+func (gs *GitService) GetDiffStat(ctx context.Context, repoPath string, paths ...string) (string, error) {
+	cmd, err := gs.buildSafeGitCommand(ctx, repoPath, withPathScope([]string{"diff", "--stat", "HEAD"}, paths)...)
 	if err != nil {
 		return "", fmt.Errorf("failed to build git command: %w", err)
 	}
@@ -1708,9 +2113,9 @@ func (gs *GitService) GetDiffStat(ctx context.Context, repoPath string) (string,
 	return string(output), nil
 }
 
-// GetChangedFiles returns a list of files that have been changed
-func (gs *GitService) GetChangedFiles(ctx context.Context, repoPath string) ([]string, error) {
-	cmd, err := gs.buildSafeGitCommand(ctx, repoPath, "diff", "--name-only", "HEAD")
+// GetChangedFiles returns a list of files that have been changed, optionally limited to paths
+func (gs *GitService) GetChangedFiles(ctx context.Context, repoPath string, paths ...string) ([]string, error) {
+	cmd, err := gs.buildSafeGitCommand(ctx, repoPath, withPathScope([]string{"diff", "--name-only", "HEAD"}, paths)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build git command: %w", err)
 	}
@@ -1737,37 +2142,374 @@ func (gs *GitService) GetChangedFiles(ctx context.Context, repoPath string) ([]s
 	return result, nil
 }
 
-// IsFastForwardPossible checks if mainBranch HEAD is an ancestor of taskBranch HEAD,
-// meaning a fast-forward merge is possible (main hasn't diverged).
-func (gs *GitService) IsFastForwardPossible(ctx context.Context, repoPath, mainBranch, taskBranch string) (bool, error) {
-	getLog().Debug().Str("main", mainBranch).Str("task", taskBranch).Msg("Checking fast-forward feasibility")
+// Change statuses of a ChangedFile
+const (
+	ChangedFileAdded    = "added"
+	ChangedFileModified = "modified"
+	ChangedFileDeleted  = "deleted"
+	ChangedFileRenamed  = "renamed"
+)
 
-	validatedPath, err := gs.validateRepoPath(repoPath)
-	if err != nil {
-		return false, fmt.Errorf("invalid repository path: %w", err)
-	}
-	if err := validateBranchName(mainBranch); err != nil {
-		return false, fmt.Errorf("invalid main branch name: %w", err)
-	}
-	if err := validateBranchName(taskBranch); err != nil {
-		return false, fmt.Errorf("invalid task branch name: %w", err)
-	}
+// ChangedFile is a file changed in the working tree against HEAD
+type ChangedFile struct {
+	Path     string
+	OldPath  string // Path before a rename; empty otherwise
+	Status   string // One of the ChangedFile* statuses
+	IsBinary bool   // Git shows no text diff for the file, only "Binary files ... differ"
+}
 
+// GetChangedFilesDetailed returns the tracked files changed in the working tree against
+// HEAD, with renames detected and binary files flagged so callers can skip their diffs
+func (gs *GitService) GetChangedFilesDetailed(ctx context.Context, repoPath string) ([]ChangedFile, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	cmd, err := gs.buildSafeGitCommand(ctx, validatedPath, "merge-base", "--is-ancestor", mainBranch, taskBranch)
+	nameStatus, err := gs.gitOutput(ctx, repoPath, nil, "diff", "--name-status", "-M", "-z", "HEAD")
 	if err != nil {
-		return false, fmt.Errorf("failed to build git command: %w", err)
+		if strings.Contains(err.Error(), "ambiguous argument 'HEAD'") {
+			// No commits yet, nothing to compare against
+			return []ChangedFile{}, nil
+		}
+		return nil, fmt.Errorf("failed to get changed files: %w", err)
+	}
+	numstat, err := gs.gitOutput(ctx, repoPath, nil, "diff", "--numstat", "-M", "-z", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff numstat: %w", err)
 	}
 
-	err = cmd.Run()
+	files, err := parseNameStatusZ(nameStatus)
 	if err != nil {
-		var exitError *exec.ExitError
-		if errors.As(err, &exitError) {
-			if exitError.ExitCode() == 1 {
-				// Exit 1 means main is NOT an ancestor of task — not FF-able
-				return false, nil
+		return nil, err
+	}
+	binary := parseNumstatBinaryZ(numstat)
+	for i := range files {
+		files[i].IsBinary = binary[files[i].Path]
+	}
+	return files, nil
+}
+
+// parseNameStatusZ parses git diff --name-status -z output: a status letter (followed by a
+// similarity score for renames and copies) and the path, or the old and new path, each
+// NUL-terminated
+func parseNameStatusZ(output string) ([]ChangedFile, error) {
+	fields := strings.Split(strings.TrimSuffix(output, "\x00"), "\x00")
+	files := []ChangedFile{}
+	for i := 0; i < len(fields) && fields[i] != ""; {
+		code := fields[i]
+		paths := 1
+		if code[0] == 'R' || code[0] == 'C' {
+			paths = 2
+		}
+		if i+paths >= len(fields) {
+			return nil, fmt.Errorf("truncated name-status output for status %q", code)
+		}
+
+		file := ChangedFile{Path: fields[i+paths]}
+		switch code[0] {
+		case 'A', 'C':
+			file.Status = ChangedFileAdded
+		case 'D':
+			file.Status = ChangedFileDeleted
+		case 'R':
+			file.Status = ChangedFileRenamed
+			file.OldPath = fields[i+1]
+		default: // M, T (type change), U (unmerged)
+			file.Status = ChangedFileModified
+		}
+		files = append(files, file)
+		i += paths + 1
+	}
+	return files, nil
+}
+
+// parseNumstatBinaryZ returns the paths git diff --numstat -z reports as binary ("-" counts).
+// Renames leave the path field empty and follow it with the old and new path.
+func parseNumstatBinaryZ(output string) map[string]bool {
+	binary := make(map[string]bool)
+	fields := strings.Split(output, "\x00")
+	for i := 0; i < len(fields); i++ {
+		counts := strings.SplitN(fields[i], "\t", 3)
+		if len(counts) != 3 {
+			continue
+		}
+		path := counts[2]
+		if path == "" && i+2 < len(fields) {
+			path = fields[i+2]
+			i += 2
+		}
+		if counts[0] == "-" && counts[1] == "-" {
+			binary[path] = true
+		}
+	}
+	return binary
+}
+
+// DiffOptions controls how diff statistics are computed
+type DiffOptions struct {
+	IgnoreWhitespace bool     // Ignore whitespace-only changes (git diff -w)
+	Paths            []string // Limit to these repository-relative paths (all when empty)
+}
+
+// DiffNumstat holds per-repository totals parsed from git diff --numstat
+type DiffNumstat struct {
+	Files      []string // Files with at least one counted change
+	Insertions int
+	Deletions  int
+}
+
+// GetDiffNumstat returns the changed files and line counts of the working tree against HEAD.
+// With IgnoreWhitespace, files whose only changes are whitespace are left out entirely.
+func (gs *GitService) GetDiffNumstat(ctx context.Context, repoPath string, opts DiffOptions) (*DiffNumstat, error) {
+	args := []string{"diff", "--numstat"}
+	if opts.IgnoreWhitespace {
+		args = append(args, "-w")
+	}
+	args = withPathScope(append(args, "HEAD"), opts.Paths)
+
+	cmd, err := gs.buildSafeGitCommand(ctx, repoPath, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build git command: %w", err)
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		if strings.Contains(err.Error(), "ambiguous argument 'HEAD'") {
+			return &DiffNumstat{Files: []string{}}, nil
+		}
+		return nil, fmt.Errorf("failed to get diff numstat: %w", err)
+	}
+
+	return parseDiffNumstat(string(output)), nil
+}
+
+// LiveDiff is a snapshot of the working tree's changes against HEAD
+type LiveDiff struct {
+	Diff     string
+	DiffStat string
+	Numstat  *DiffNumstat
+}
+
+// GetLiveDiff captures the working tree's changes against HEAD while an agent may still be
+// working in it. Untracked files are marked intent-to-add in a throwaway copy of the index
+// and GIT_OPTIONAL_LOCKS=0 stops git from refreshing the real one, so neither the agent's
+// staging nor its index.lock is ever touched.
+func (gs *GitService) GetLiveDiff(ctx context.Context, repoPath string, paths ...string) (*LiveDiff, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	live := &LiveDiff{Numstat: &DiffNumstat{Files: []string{}}}
+	if _, err := gs.gitOutput(ctx, repoPath, nil, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		// No commits yet: nothing to diff against
+		return live, nil
+	}
+
+	indexPath, err := gs.gitOutput(ctx, repoPath, nil, "rev-parse", "--git-path", "index")
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate index: %w", err)
+	}
+	indexPath = strings.TrimSpace(indexPath)
+	if !filepath.IsAbs(indexPath) {
+		indexPath = filepath.Join(repoPath, indexPath)
+	}
+
+	tmpIndex, err := copyIndex(indexPath)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmpIndex)
+	env := []string{"GIT_INDEX_FILE=" + tmpIndex, "GIT_OPTIONAL_LOCKS=0"}
+
+	addArgs := []string{"add", "-N", "."}
+	if len(paths) > 0 {
+		addArgs = append([]string{"add", "-N", "--"}, paths...)
+	}
+	if _, err := gs.gitOutput(ctx, repoPath, env, addArgs...); err != nil {
+		// Non-critical - untracked files are just missing from this snapshot
+		getLog().Debug().Err(err).Msg("Failed to add files for live diff")
+	}
+
+	if live.Diff, err = gs.gitOutput(ctx, repoPath, env, withPathScope([]string{"diff", "HEAD"}, paths)...); err != nil {
+		return nil, fmt.Errorf("failed to get diff: %w", err)
+	}
+	if live.DiffStat, err = gs.gitOutput(ctx, repoPath, env, withPathScope([]string{"diff", "--stat", "HEAD"}, paths)...); err != nil {
+		return nil, fmt.Errorf("failed to get diff stat: %w", err)
+	}
+	numstat, err := gs.gitOutput(ctx, repoPath, env, withPathScope([]string{"diff", "--numstat", "HEAD"}, paths)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff numstat: %w", err)
+	}
+	live.Numstat = parseDiffNumstat(numstat)
+
+	return live, nil
+}
+
+// gitOutput runs a git command with extra environment variables and returns its stdout
+func (gs *GitService) gitOutput(ctx context.Context, repoPath string, env []string, args ...string) (string, error) {
+	cmd, err := gs.buildSafeGitCommand(ctx, repoPath, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to build git command: %w", err)
+	}
+	cmd.Env = append(cmd.Env, env...)
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return string(output), nil
+}
+
+// copyIndex copies a git index to a temporary file and returns its path. When the
+// repository has no index yet the returned path does not exist, which git treats as empty.
+func copyIndex(indexPath string) (string, error) {
+	tmp, err := os.CreateTemp("", "noldarim-index-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary index: %w", err)
+	}
+	defer tmp.Close()
+
+	src, err := os.Open(indexPath)
+	if err != nil {
+		os.Remove(tmp.Name())
+		if os.IsNotExist(err) {
+			return tmp.Name(), nil
+		}
+		return "", fmt.Errorf("failed to open index: %w", err)
+	}
+	defer src.Close()
+
+	if _, err := io.Copy(tmp, src); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to copy index: %w", err)
+	}
+	return tmp.Name(), nil
+}
+
+// withPathScope appends a "--" pathspec for paths to git args, if there are any
+func withPathScope(args []string, paths []string) []string {
+	if len(paths) == 0 {
+		return args
+	}
+	return append(append(args, "--"), paths...)
+}
+
+// parseDiffNumstat parses "added<TAB>deleted<TAB>path" lines; binary files report "-" counts
+func parseDiffNumstat(output string) *DiffNumstat {
+	stat := &DiffNumstat{Files: []string{}}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		added, addErr := strconv.Atoi(fields[0])
+		deleted, delErr := strconv.Atoi(fields[1])
+		if addErr == nil && delErr == nil && added == 0 && deleted == 0 {
+			continue
+		}
+		stat.Files = append(stat.Files, fields[2])
+		if addErr == nil {
+			stat.Insertions += added
+		}
+		if delErr == nil {
+			stat.Deletions += deleted
+		}
+	}
+	return stat
+}
+
+// GetDiffBetween returns the diff between two commits (fromSHA..toSHA). Either commit
+// missing from the repository is reported as ErrCommitNotFound.
+func (gs *GitService) GetDiffBetween(ctx context.Context, repoPath, fromSHA, toSHA string) (string, error) {
+	return gs.diffBetween(ctx, repoPath, fromSHA, toSHA)
+}
+
+// GetDiffStatBetween returns the git diff --stat output between two commits (fromSHA..toSHA),
+// suitable for ParseDiffStat
+func (gs *GitService) GetDiffStatBetween(ctx context.Context, repoPath, fromSHA, toSHA string) (string, error) {
+	return gs.diffBetween(ctx, repoPath, fromSHA, toSHA, "--stat")
+}
+
+// diffBetween validates both commits and runs git diff fromSHA..toSHA with extra flags
+func (gs *GitService) diffBetween(ctx context.Context, repoPath, fromSHA, toSHA string, flags ...string) (string, error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateCommitHash(fromSHA); err != nil {
+		return "", fmt.Errorf("invalid from commit: %w", err)
+	}
+	if err := validateCommitHash(toSHA); err != nil {
+		return "", fmt.Errorf("invalid to commit: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	for _, sha := range []string{fromSHA, toSHA} {
+		if err := gs.requireCommit(ctx, validatedPath, sha); err != nil {
+			return "", err
+		}
+	}
+
+	args := append([]string{"diff"}, flags...)
+	args = append(args, fromSHA+".."+toSHA)
+	output, err := gs.gitOutput(ctx, validatedPath, nil, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff between commits: %w", err)
+	}
+	return output, nil
+}
+
+// requireCommit returns ErrCommitNotFound unless sha names a commit in the repository
+func (gs *GitService) requireCommit(ctx context.Context, repoPath, sha string) error {
+	cmd, err := gs.buildSafeGitCommand(ctx, repoPath, "rev-parse", "--verify", "--quiet", sha+"^{commit}")
+	if err != nil {
+		return fmt.Errorf("failed to build git command: %w", err)
+	}
+	if err := cmd.Run(); err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) {
+			return fmt.Errorf("%w: %s", ErrCommitNotFound, sha)
+		}
+		return fmt.Errorf("failed to verify commit %s: %w", sha, err)
+	}
+	return nil
+}
+
+// IsFastForwardPossible checks if mainBranch HEAD is an ancestor of taskBranch HEAD,
+// meaning a fast-forward merge is possible (main hasn't diverged).
+func (gs *GitService) IsFastForwardPossible(ctx context.Context, repoPath, mainBranch, taskBranch string) (bool, error) {
+	getLog().Debug().Str("main", mainBranch).Str("task", taskBranch).Msg("Checking fast-forward feasibility")
+
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return false, fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateBranchName(mainBranch); err != nil {
+		return false, fmt.Errorf("invalid main branch name: %w", err)
+	}
+	if err := validateBranchName(taskBranch); err != nil {
+		return false, fmt.Errorf("invalid task branch name: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cmd, err := gs.buildSafeGitCommand(ctx, validatedPath, "merge-base", "--is-ancestor", mainBranch, taskBranch)
+	if err != nil {
+		return false, fmt.Errorf("failed to build git command: %w", err)
+	}
+
+	err = cmd.Run()
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) {
+			if exitError.ExitCode() == 1 {
+				// Exit 1 means main is NOT an ancestor of task — not FF-able
+				return false, nil
 			}
 		}
 		return false, fmt.Errorf("failed to check fast-forward: %w", err)
@@ -1825,30 +2567,23 @@ func (gs *GitService) MergeInWorktree(ctx context.Context, worktreePath, branchT
 	if err := validateBranchName(branchToMerge); err != nil {
 		return "", false, fmt.Errorf("invalid branch name: %w", err)
 	}
+	if err := gs.checkCurrentBranchWritable(ctx, validatedPath, "merge"); err != nil {
+		return "", false, err
+	}
 
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
-	cmd, err := gs.buildSafeGitCommand(ctx, validatedPath, "merge", "--no-edit", branchToMerge)
+	conflicts, err := gs.merge(ctx, validatedPath, branchToMerge, "", true)
 	if err != nil {
-		return "", false, fmt.Errorf("failed to build git command: %w", err)
+		return "", false, err
 	}
-
-	output, mergeErr := cmd.CombinedOutput()
-	if mergeErr != nil {
-		var exitError *exec.ExitError
-		if errors.As(mergeErr, &exitError) && exitError.ExitCode() == 1 {
-			hasConflictMarker := strings.Contains(string(output), "CONFLICT")
-			if hasConflictMarker {
-				getLog().Info().
-					Str("worktree", validatedPath).
-					Msg("Merge has conflicts")
-				return "", true, nil
-			}
-			// Exit code 1 without CONFLICT marker — treat as generic error
-			return "", false, fmt.Errorf("merge failed (exit 1, no conflict marker): %s, output: %s", mergeErr, string(output))
-		}
-		return "", false, fmt.Errorf("merge failed: %s, output: %s", mergeErr, string(output))
+	if len(conflicts) > 0 {
+		getLog().Info().
+			Str("worktree", validatedPath).
+			Strs("conflicts", conflicts).
+			Msg("Merge has conflicts")
+		return "", true, nil
 	}
 
 	// Merge succeeded — get the resulting commit SHA
@@ -1861,12 +2596,771 @@ func (gs *GitService) MergeInWorktree(ctx context.Context, worktreePath, branchT
 	return sha, false, nil
 }
 
+// CreateTag creates an annotated tag on commitHash, or on HEAD when commitHash is empty.
+// The tag message defaults to the tag name.
+func (gs *GitService) CreateTag(ctx context.Context, repoPath, tagName, commitHash, message string) error {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateTagName(tagName); err != nil {
+		return fmt.Errorf("invalid tag name: %w", err)
+	}
+	if message == "" {
+		message = tagName
+	}
+	if err := validateCommitMessage(message); err != nil {
+		return fmt.Errorf("invalid tag message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	target := "HEAD"
+	if commitHash != "" {
+		if err := validateCommitHash(commitHash); err != nil {
+			return fmt.Errorf("invalid commit hash: %w", err)
+		}
+		if err := gs.requireCommit(ctx, validatedPath, commitHash); err != nil {
+			return err
+		}
+		target = commitHash
+	}
+
+	if _, err := gs.gitOutput(ctx, validatedPath, nil, "tag", "--annotate", "--message", message, tagName, target); err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", tagName, err)
+	}
+	getLog().Info().Str("repo_path", validatedPath).Str("tag", tagName).Str("target", target).Msg("Tag created")
+	return nil
+}
+
+// ListTags returns the names of all tags in the repository, sorted by name
+func (gs *GitService) ListTags(ctx context.Context, repoPath string) ([]string, error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository path: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	output, err := gs.gitOutput(ctx, validatedPath, nil, "tag", "--list")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	tags := strings.Fields(output)
+	if tags == nil {
+		tags = []string{}
+	}
+	return tags, nil
+}
+
+// ErrFileNotTracked is returned by Blame when the file is not tracked at HEAD
+var ErrFileNotTracked = errors.New("file not tracked")
+
+// BlameLine attributes one line of a file to the commit that last changed it
+type BlameLine struct {
+	CommitHash string
+	Author     string
+	LineNumber int
+}
+
+// Blame attributes lines startLine through endLine (1-based, inclusive) of filePath to the
+// commits that last changed them, as reported by git blame --porcelain
+func (gs *GitService) Blame(ctx context.Context, repoPath, filePath string, startLine, endLine int) ([]BlameLine, error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateFilePath(filePath); err != nil {
+		return nil, fmt.Errorf("invalid file path: %w", err)
+	}
+	if startLine < 1 || endLine < 1 {
+		return nil, fmt.Errorf("invalid line range %d,%d: line numbers must be positive", startLine, endLine)
+	}
+	if startLine > endLine {
+		return nil, fmt.Errorf("invalid line range %d,%d: start line is after end line", startLine, endLine)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	output, err := gs.gitOutput(ctx, validatedPath, nil, "blame", "-L", fmt.Sprintf("%d,%d", startLine, endLine), "--porcelain", "--", filepath.Clean(filePath))
+	if err != nil {
+		if strings.Contains(err.Error(), "no such path") {
+			return nil, fmt.Errorf("%w: %s", ErrFileNotTracked, filePath)
+		}
+		return nil, fmt.Errorf("failed to blame %s: %w", filePath, err)
+	}
+	return parseBlamePorcelain(output)
+}
+
+// parseBlamePorcelain parses git blame --porcelain output. Each line is a header
+// "<sha> <orig-line> <final-line> [<group-size>]", followed by commit details the first
+// time a commit appears (author among them), and ends with the tab-prefixed content.
+func parseBlamePorcelain(output string) ([]BlameLine, error) {
+	authors := make(map[string]string)
+	var lines []BlameLine
+	var current *BlameLine
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "\t"):
+			if current == nil {
+				return nil, fmt.Errorf("unexpected blame content line without header")
+			}
+			current.Author = authors[current.CommitHash]
+			lines = append(lines, *current)
+			current = nil
+		case current == nil:
+			if line == "" {
+				continue
+			}
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("malformed blame header: %q", line)
+			}
+			lineNumber, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return nil, fmt.Errorf("malformed blame header: %q", line)
+			}
+			current = &BlameLine{CommitHash: fields[0], LineNumber: lineNumber}
+		case strings.HasPrefix(line, "author "):
+			authors[current.CommitHash] = strings.TrimPrefix(line, "author ")
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("truncated blame output")
+	}
+	if lines == nil {
+		lines = []BlameLine{}
+	}
+	return lines, nil
+}
+
+// ErrMergeConflict is returned by MergeBranch when the merge stopped on conflicts
+var ErrMergeConflict = errors.New("merge conflict")
+
+// MergeBranch merges sourceBranch into the branch checked out in repoPath with a merge commit
+// (git merge --no-ff) and returns its SHA; message replaces git's default merge message when
+// set. On conflicts it returns the conflicted files and an error wrapping ErrMergeConflict,
+// leaving the merge in progress for resolution (or AbortMerge). When HEAD already contains
+// sourceBranch, nothing is committed and the current HEAD is returned.
+func (gs *GitService) MergeBranch(ctx context.Context, repoPath, sourceBranch, message string) (mergeSHA string, conflicts []string, err error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateBranchName(sourceBranch); err != nil {
+		return "", nil, fmt.Errorf("invalid branch name: %w", err)
+	}
+	if err := gs.checkCurrentBranchWritable(ctx, validatedPath, "merge "+sourceBranch); err != nil {
+		return "", nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	if conflicts, err = gs.merge(ctx, validatedPath, sourceBranch, message, false); err != nil {
+		return "", nil, err
+	}
+	if len(conflicts) > 0 {
+		getLog().Info().Str("repo_path", validatedPath).Str("branch", sourceBranch).Strs("conflicts", conflicts).Msg("Merge has conflicts, left in progress")
+		return "", conflicts, fmt.Errorf("%w: merging %s conflicts in %s", ErrMergeConflict, sourceBranch, strings.Join(conflicts, ", "))
+	}
+
+	mergeSHA, err = gs.getCurrentCommit(ctx, validatedPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get merge commit SHA: %w", err)
+	}
+	getLog().Info().Str("repo_path", validatedPath).Str("branch", sourceBranch).Str("merge_sha", mergeSHA).Msg("Branch merged")
+	return mergeSHA, nil, nil
+}
+
 // AbortMerge aborts a merge in progress in the given directory.
 // Returns an error if no merge is in progress (which callers can safely ignore).
 func (gs *GitService) AbortMerge(ctx context.Context, repoPath string) error {
 	return gs.runSafeGitCommand(ctx, repoPath, "merge", "--abort")
 }
 
+// RebaseOptions controls how RebaseWithOptions handles conflicts
+type RebaseOptions struct {
+	// KeepConflicts leaves a conflicted rebase in progress so it can be resolved by hand;
+	// by default it is aborted and the branch is left as it was
+	KeepConflicts bool
+}
+
+// RebaseResult reports the outcome of a rebase
+type RebaseResult struct {
+	Branch     string
+	Onto       string
+	OldHeadSHA string
+	NewHeadSHA string   // Equal to OldHeadSHA when the rebase was aborted
+	UpToDate   bool     // The branch already contained onto; nothing was rewritten
+	Conflicts  []string // Files that conflicted; empty when the rebase succeeded
+	Aborted    bool     // The conflicted rebase was aborted
+	InProgress bool     // The conflicted rebase was kept for resolution (RebaseOptions.KeepConflicts)
+}
+
+// Rebase rebases branch onto onto in repoPath (a worktree with branch checked out, or the
+// main repository), aborting on conflicts. See RebaseWithOptions.
+func (gs *GitService) Rebase(ctx context.Context, repoPath, branch, onto string) (*RebaseResult, error) {
+	return gs.RebaseWithOptions(ctx, repoPath, branch, onto, RebaseOptions{})
+}
+
+// RebaseWithOptions rebases branch onto onto. A rebase left in progress by an earlier
+// attempt is aborted first, so a retried call starts from the original branch. Conflicts
+// are reported in the result rather than as an error.
+func (gs *GitService) RebaseWithOptions(ctx context.Context, repoPath, branch, onto string, opts RebaseOptions) (*RebaseResult, error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateBranchName(branch); err != nil {
+		return nil, fmt.Errorf("invalid branch name: %w", err)
+	}
+	if err := validateBranchName(onto); err != nil {
+		return nil, fmt.Errorf("invalid rebase target: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	inProgress, err := gs.rebaseInProgress(ctx, validatedPath)
+	if err != nil {
+		return nil, err
+	}
+	if inProgress {
+		getLog().Warn().Str("repo_path", validatedPath).Msg("Aborting rebase left in progress by an earlier attempt")
+		if err := gs.AbortRebase(ctx, validatedPath); err != nil {
+			return nil, fmt.Errorf("failed to abort earlier rebase: %w", err)
+		}
+	}
+
+	result := &RebaseResult{Branch: branch, Onto: onto}
+	if result.OldHeadSHA, err = gs.GetBranchHeadSHA(ctx, validatedPath, branch); err != nil {
+		return nil, err
+	}
+
+	cmd, err := gs.buildSafeGitCommand(ctx, validatedPath, "rebase", onto, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build git command: %w", err)
+	}
+	cmd.Env = append(cmd.Env, "GIT_EDITOR=true") // Never wait on an editor
+
+	output, rebaseErr := cmd.CombinedOutput()
+	if rebaseErr != nil {
+		var exitError *exec.ExitError
+		if !errors.As(rebaseErr, &exitError) {
+			return nil, fmt.Errorf("rebase failed: %s, output: %s", rebaseErr, string(output))
+		}
+
+		result.Conflicts = gs.conflictedFiles(ctx, validatedPath)
+		stillInProgress, _ := gs.rebaseInProgress(ctx, validatedPath)
+
+		if len(result.Conflicts) > 0 && opts.KeepConflicts {
+			result.InProgress = true
+			result.NewHeadSHA = result.OldHeadSHA
+			getLog().Info().Str("repo_path", validatedPath).Strs("conflicts", result.Conflicts).Msg("Rebase has conflicts, left in progress")
+			return result, nil
+		}
+		if stillInProgress {
+			if err := gs.AbortRebase(ctx, validatedPath); err != nil {
+				return nil, fmt.Errorf("rebase failed and could not be aborted: %w (rebase output: %s)", err, string(output))
+			}
+		}
+		if len(result.Conflicts) == 0 {
+			return nil, fmt.Errorf("rebase failed: %s, output: %s", rebaseErr, string(output))
+		}
+		result.Aborted = true
+		result.NewHeadSHA = result.OldHeadSHA
+		getLog().Info().Str("repo_path", validatedPath).Strs("conflicts", result.Conflicts).Msg("Rebase has conflicts, aborted")
+		return result, nil
+	}
+
+	if result.NewHeadSHA, err = gs.GetBranchHeadSHA(ctx, validatedPath, branch); err != nil {
+		return nil, err
+	}
+	result.UpToDate = result.NewHeadSHA == result.OldHeadSHA
+
+	getLog().Info().
+		Str("repo_path", validatedPath).
+		Str("branch", branch).
+		Str("onto", onto).
+		Str("old_head", result.OldHeadSHA).
+		Str("new_head", result.NewHeadSHA).
+		Msg("Rebase completed")
+	return result, nil
+}
+
+// AbortRebase aborts a rebase in progress in the given directory.
+// Returns an error if no rebase is in progress.
+func (gs *GitService) AbortRebase(ctx context.Context, repoPath string) error {
+	return gs.runSafeGitCommand(ctx, repoPath, "rebase", "--abort")
+}
+
+// rebaseInProgress reports whether repoPath (which may be a worktree) has a rebase underway
+func (gs *GitService) rebaseInProgress(ctx context.Context, repoPath string) (bool, error) {
+	inProgress, err := gs.gitStateExists(ctx, repoPath, "rebase-merge", "rebase-apply")
+	if err != nil {
+		return false, fmt.Errorf("failed to check for rebase in progress: %w", err)
+	}
+	return inProgress, nil
+}
+
+// gitStateExists reports whether any of the named state files (such as MERGE_HEAD) exists
+// in the git directory of repoPath, which may be a worktree
+func (gs *GitService) gitStateExists(ctx context.Context, repoPath string, names ...string) (bool, error) {
+	for _, name := range names {
+		statePath, err := gs.gitOutput(ctx, repoPath, nil, "rev-parse", "--git-path", name)
+		if err != nil {
+			return false, err
+		}
+		statePath = strings.TrimSpace(statePath)
+		if !filepath.IsAbs(statePath) {
+			statePath = filepath.Join(repoPath, statePath)
+		}
+		if _, err := os.Stat(statePath); err == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// MergeResult reports the outcome of Merge or CherryPick
+type MergeResult struct {
+	Branch     string
+	OldHeadSHA string
+	NewHeadSHA string   // Equal to OldHeadSHA when nothing was applied
+	UpToDate   bool     // HEAD already contained the branch's changes
+	Picked     []string // Commits applied by CherryPick, oldest first
+	Conflicts  []string // Files that conflicted; the operation was aborted
+}
+
+// Merge merges branch into the branch checked out in repoPath (a worktree or the main
+// repository), always creating a merge commit. Conflicts abort the merge and are reported in
+// the result, leaving HEAD unchanged. Safe to retry: a merge left in progress by an earlier
+// attempt is aborted first, and merging an already merged branch is a no-op.
+func (gs *GitService) Merge(ctx context.Context, repoPath, branch string) (*MergeResult, error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateBranchName(branch); err != nil {
+		return nil, fmt.Errorf("invalid branch name: %w", err)
+	}
+	if err := gs.checkCurrentBranchWritable(ctx, validatedPath, "integrate "+branch); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	if err := gs.abortLeftoverOperation(ctx, validatedPath); err != nil {
+		return nil, err
+	}
+
+	result := &MergeResult{Branch: branch}
+	if result.OldHeadSHA, err = gs.getCurrentCommit(ctx, validatedPath); err != nil {
+		return nil, err
+	}
+
+	if conflicts, err := gs.merge(ctx, validatedPath, branch, "", false); err != nil || len(conflicts) > 0 {
+		return gs.abortConflicted(ctx, validatedPath, result, "merge", conflicts, err)
+	}
+
+	if result.NewHeadSHA, err = gs.getCurrentCommit(ctx, validatedPath); err != nil {
+		return nil, err
+	}
+	result.UpToDate = result.NewHeadSHA == result.OldHeadSHA
+
+	getLog().Info().
+		Str("repo_path", validatedPath).
+		Str("branch", branch).
+		Str("new_head", result.NewHeadSHA).
+		Bool("up_to_date", result.UpToDate).
+		Msg("Merge completed")
+	return result, nil
+}
+
+// CherryPick applies the commits of branch that HEAD does not have yet, oldest first, onto
+// the branch checked out in repoPath. Commits whose changes are already in HEAD (picked by an
+// earlier attempt) and merge commits are skipped. Conflicts abort the whole pick, leaving HEAD
+// unchanged, and are reported in the result.
+func (gs *GitService) CherryPick(ctx context.Context, repoPath, branch string) (*MergeResult, error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateBranchName(branch); err != nil {
+		return nil, fmt.Errorf("invalid branch name: %w", err)
+	}
+	if err := gs.checkCurrentBranchWritable(ctx, validatedPath, "integrate "+branch); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	if err := gs.abortLeftoverOperation(ctx, validatedPath); err != nil {
+		return nil, err
+	}
+
+	result := &MergeResult{Branch: branch}
+	if result.OldHeadSHA, err = gs.getCurrentCommit(ctx, validatedPath); err != nil {
+		return nil, err
+	}
+
+	commits, err := gs.gitOutput(ctx, validatedPath, nil, "log", "--format=%H", "--reverse", "--right-only", "--cherry-pick", "--no-merges", "HEAD..."+branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits to pick: %w", err)
+	}
+	picks := strings.Fields(commits)
+	if len(picks) == 0 {
+		result.NewHeadSHA = result.OldHeadSHA
+		result.UpToDate = true
+		return result, nil
+	}
+
+	if conflicts, err := gs.cherryPick(ctx, validatedPath, picks...); err != nil || len(conflicts) > 0 {
+		return gs.abortConflicted(ctx, validatedPath, result, "cherry-pick", conflicts, err)
+	}
+
+	if result.NewHeadSHA, err = gs.getCurrentCommit(ctx, validatedPath); err != nil {
+		return nil, err
+	}
+	result.Picked = picks
+
+	getLog().Info().
+		Str("repo_path", validatedPath).
+		Str("branch", branch).
+		Int("picked", len(picks)).
+		Str("new_head", result.NewHeadSHA).
+		Msg("Cherry-pick completed")
+	return result, nil
+}
+
+// ErrCherryPickConflict matches a CherryPickConflictError with errors.Is
+var ErrCherryPickConflict = errors.New("cherry-pick conflict")
+
+// CherryPickConflictError is returned by CherryPickCommit when the commit does not apply
+// cleanly. The cherry-pick is left in progress so the conflicts can be resolved, or undone
+// with CherryPickAbort.
+type CherryPickConflictError struct {
+	Commit    string
+	Conflicts []string // Files with conflict markers
+}
+
+func (e *CherryPickConflictError) Error() string {
+	return fmt.Sprintf("cherry-pick of %s conflicts in %s", e.Commit, strings.Join(e.Conflicts, ", "))
+}
+
+// Is makes errors.Is(err, ErrCherryPickConflict) match
+func (e *CherryPickConflictError) Is(target error) bool {
+	return target == ErrCherryPickConflict
+}
+
+// CherryPickCommit applies a single commit onto the branch checked out in repoPath. Unlike
+// CherryPick, a conflict is not aborted: it is returned as a *CherryPickConflictError with
+// the cherry-pick left in progress for the user to resolve (or CherryPickAbort).
+func (gs *GitService) CherryPickCommit(ctx context.Context, repoPath, commitHash string) error {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateCommitHash(commitHash); err != nil {
+		return fmt.Errorf("invalid commit hash: %w", err)
+	}
+	if err := gs.checkCurrentBranchWritable(ctx, validatedPath, "cherry-pick "+commitHash); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	if err := gs.requireCommit(ctx, validatedPath, commitHash); err != nil {
+		return err
+	}
+
+	conflicts, pickErr := gs.cherryPick(ctx, validatedPath, commitHash)
+	if pickErr != nil {
+		// Nothing to resolve (e.g. the commit is already applied), so do not leave it in progress
+		if picking, _ := gs.gitStateExists(ctx, validatedPath, "CHERRY_PICK_HEAD"); picking {
+			if err := gs.CherryPickAbort(ctx, validatedPath); err != nil {
+				return fmt.Errorf("%w, and could not be aborted: %w", pickErr, err)
+			}
+		}
+		return pickErr
+	}
+	if len(conflicts) > 0 {
+		getLog().Info().Str("repo_path", validatedPath).Str("commit", commitHash).Strs("conflicts", conflicts).Msg("Cherry-pick has conflicts, left in progress")
+		return &CherryPickConflictError{Commit: commitHash, Conflicts: conflicts}
+	}
+
+	getLog().Info().Str("repo_path", validatedPath).Str("commit", commitHash).Msg("Cherry-picked commit")
+	return nil
+}
+
+// CherryPickAbort aborts a cherry-pick in progress in the given directory, restoring the
+// branch as it was before. Returns an error if no cherry-pick is in progress.
+func (gs *GitService) CherryPickAbort(ctx context.Context, repoPath string) error {
+	return gs.runSafeGitCommand(ctx, repoPath, "cherry-pick", "--abort")
+}
+
+// ErrRevertConflict matches a RevertConflictError with errors.Is
+var ErrRevertConflict = errors.New("revert conflict")
+
+// RevertConflictError is returned by RevertCommit when undoing the commit conflicts with
+// later changes. The revert is left in progress so the conflicts can be resolved, or undone
+// with RevertAbort.
+type RevertConflictError struct {
+	Commit    string
+	Conflicts []string // Files with conflict markers
+}
+
+func (e *RevertConflictError) Error() string {
+	return fmt.Sprintf("revert of %s conflicts in %s", e.Commit, strings.Join(e.Conflicts, ", "))
+}
+
+// Is makes errors.Is(err, ErrRevertConflict) match
+func (e *RevertConflictError) Is(target error) bool {
+	return target == ErrRevertConflict
+}
+
+// RevertCommit undoes a commit on the branch checked out in repoPath with a new commit
+// (git revert --no-edit) and returns the SHA of that commit. A conflict is returned as a
+// *RevertConflictError with the revert left in progress for the user to resolve (or RevertAbort).
+func (gs *GitService) RevertCommit(ctx context.Context, repoPath, commitHash string) (revertSHA string, err error) {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository path: %w", err)
+	}
+	if err := validateCommitHash(commitHash); err != nil {
+		return "", fmt.Errorf("invalid commit hash: %w", err)
+	}
+	if err := gs.checkCurrentBranchWritable(ctx, validatedPath, "revert "+commitHash); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	if err := gs.requireCommit(ctx, validatedPath, commitHash); err != nil {
+		return "", err
+	}
+
+	conflicts, revertErr := gs.runConflicting(ctx, validatedPath, "revert", "revert", "--no-edit", commitHash)
+	if revertErr != nil {
+		// Nothing to resolve (e.g. the changes were already undone), so do not leave it in progress
+		if reverting, _ := gs.gitStateExists(ctx, validatedPath, "REVERT_HEAD"); reverting {
+			if err := gs.RevertAbort(ctx, validatedPath); err != nil {
+				return "", fmt.Errorf("%w, and could not be aborted: %w", revertErr, err)
+			}
+		}
+		return "", revertErr
+	}
+	if len(conflicts) > 0 {
+		getLog().Info().Str("repo_path", validatedPath).Str("commit", commitHash).Strs("conflicts", conflicts).Msg("Revert has conflicts, left in progress")
+		return "", &RevertConflictError{Commit: commitHash, Conflicts: conflicts}
+	}
+
+	revertSHA, err = gs.getCurrentCommit(ctx, validatedPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get revert commit: %w", err)
+	}
+	getLog().Info().Str("repo_path", validatedPath).Str("commit", commitHash).Str("revert_sha", revertSHA).Msg("Reverted commit")
+	return revertSHA, nil
+}
+
+// RevertAbort aborts a revert in progress in the given directory, restoring the branch as
+// it was before. Returns an error if no revert is in progress.
+func (gs *GitService) RevertAbort(ctx context.Context, repoPath string) error {
+	return gs.runSafeGitCommand(ctx, repoPath, "revert", "--abort")
+}
+
+// abortLeftoverOperation aborts a merge or cherry-pick an earlier attempt left in progress
+func (gs *GitService) abortLeftoverOperation(ctx context.Context, repoPath string) error {
+	merging, err := gs.gitStateExists(ctx, repoPath, "MERGE_HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to check for merge in progress: %w", err)
+	}
+	if merging {
+		getLog().Warn().Str("repo_path", repoPath).Msg("Aborting merge left in progress by an earlier attempt")
+		if err := gs.AbortMerge(ctx, repoPath); err != nil {
+			return fmt.Errorf("failed to abort earlier merge: %w", err)
+		}
+	}
+
+	picking, err := gs.gitStateExists(ctx, repoPath, "CHERRY_PICK_HEAD", "sequencer")
+	if err != nil {
+		return fmt.Errorf("failed to check for cherry-pick in progress: %w", err)
+	}
+	if picking {
+		getLog().Warn().Str("repo_path", repoPath).Msg("Aborting cherry-pick left in progress by an earlier attempt")
+		if err := gs.CherryPickAbort(ctx, repoPath); err != nil {
+			return fmt.Errorf("failed to abort earlier cherry-pick: %w", err)
+		}
+	}
+	return nil
+}
+
+// abortConflicted handles a merge or cherry-pick that stopped on conflicts or failed: the
+// operation is aborted and the conflicting files recorded. Failures are returned as errors.
+func (gs *GitService) abortConflicted(ctx context.Context, repoPath string, result *MergeResult, operation string, conflicts []string, opErr error) (*MergeResult, error) {
+	if err := gs.abortLeftoverOperation(ctx, repoPath); err != nil {
+		if opErr != nil {
+			return nil, fmt.Errorf("%w, and could not be aborted: %w", opErr, err)
+		}
+		return nil, fmt.Errorf("%s has conflicts and could not be aborted: %w", operation, err)
+	}
+	if opErr != nil {
+		return nil, opErr
+	}
+
+	result.Conflicts = conflicts
+	result.NewHeadSHA = result.OldHeadSHA
+	getLog().Info().Str("repo_path", repoPath).Str("branch", result.Branch).Strs("conflicts", result.Conflicts).Msgf("%s has conflicts, aborted", operation)
+	return result, nil
+}
+
+// merge runs git merge of branch into the branch checked out in repoPath, creating a merge
+// commit unless fastForward allows a fast-forward; message replaces git's default merge
+// message when set. Conflicts are returned with the merge left in progress.
+func (gs *GitService) merge(ctx context.Context, repoPath, branch, message string, fastForward bool) ([]string, error) {
+	args := []string{"merge"}
+	if !fastForward {
+		args = append(args, "--no-ff")
+	}
+	if message != "" {
+		args = append(args, "-m", message)
+	} else {
+		args = append(args, "--no-edit")
+	}
+	return gs.runConflicting(ctx, repoPath, "merge", append(args, branch)...)
+}
+
+// cherryPick runs git cherry-pick of commits, oldest first, onto the branch checked out in
+// repoPath. Conflicts are returned with the cherry-pick left in progress.
+func (gs *GitService) cherryPick(ctx context.Context, repoPath string, commits ...string) ([]string, error) {
+	return gs.runConflicting(ctx, repoPath, "cherry-pick", append([]string{"cherry-pick", "--allow-empty"}, commits...)...)
+}
+
+// runConflicting runs a git command that can stop on conflicts (merge, cherry-pick, revert).
+// When it does, the conflicted files are returned and the operation is left in progress; any
+// other failure is returned as an error.
+func (gs *GitService) runConflicting(ctx context.Context, repoPath, operation string, args ...string) ([]string, error) {
+	cmd, err := gs.buildSafeGitCommand(ctx, repoPath, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build git command: %w", err)
+	}
+	cmd.Env = append(cmd.Env, "GIT_EDITOR=true") // Never wait on an editor
+
+	output, runErr := cmd.CombinedOutput()
+	if runErr == nil {
+		return nil, nil
+	}
+	var exitError *exec.ExitError
+	if errors.As(runErr, &exitError) {
+		if conflicts := gs.conflictedFiles(ctx, repoPath); len(conflicts) > 0 {
+			return conflicts, nil
+		}
+	}
+	return nil, fmt.Errorf("%s failed: %s, output: %s", operation, runErr, string(output))
+}
+
+// conflictedFiles lists the files left unmerged in repoPath by a stopped merge, cherry-pick,
+// revert or rebase
+func (gs *GitService) conflictedFiles(ctx context.Context, repoPath string) []string {
+	unmerged, _ := gs.gitOutput(ctx, repoPath, nil, "diff", "--name-only", "-z", "--diff-filter=U")
+	var files []string
+	for _, file := range strings.Split(unmerged, "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// ApplyOptions controls how Apply applies a patch
+type ApplyOptions struct {
+	Check    bool // Only check that the patch applies (git apply --check); nothing is changed
+	ThreeWay bool // Fall back to a 3-way merge for hunks that do not apply (git apply --3way); also stages the result
+}
+
+// PatchRejectedError is returned by Apply when git refuses a patch, or when a 3-way
+// apply left conflicts behind
+type PatchRejectedError struct {
+	Rejected  []string // "path:line" of each hunk that did not apply
+	Conflicts []string // Files left with conflict markers by a 3-way apply
+	Output    string   // git apply's combined output
+}
+
+func (e *PatchRejectedError) Error() string {
+	switch {
+	case len(e.Conflicts) > 0:
+		return fmt.Sprintf("patch applied with conflicts in %s", strings.Join(e.Conflicts, ", "))
+	case len(e.Rejected) > 0:
+		return fmt.Sprintf("patch rejected: hunks at %s do not apply", strings.Join(e.Rejected, ", "))
+	default:
+		return fmt.Sprintf("patch rejected: %s", strings.TrimSpace(e.Output))
+	}
+}
+
+// Apply applies a unified diff to the working tree of repoPath with git apply. The patch
+// is fed through stdin. A patch git refuses is reported as a *PatchRejectedError.
+func (gs *GitService) Apply(ctx context.Context, repoPath, patch string, opts ApplyOptions) error {
+	validatedPath, err := gs.validateRepoPath(repoPath)
+	if err != nil {
+		return fmt.Errorf("invalid repository path: %w", err)
+	}
+	if strings.TrimSpace(patch) == "" {
+		return fmt.Errorf("patch is empty")
+	}
+
+	args := []string{"apply"}
+	if opts.Check {
+		args = append(args, "--check")
+	}
+	if opts.ThreeWay {
+		args = append(args, "--3way")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cmd, err := gs.buildSafeGitCommand(ctx, validatedPath, args...)
+	if err != nil {
+		return fmt.Errorf("failed to build git command: %w", err)
+	}
+	cmd.Stdin = strings.NewReader(patch)
+
+	output, applyErr := cmd.CombinedOutput()
+	if applyErr != nil {
+		var exitError *exec.ExitError
+		if errors.As(applyErr, &exitError) {
+			return parseApplyOutput(string(output))
+		}
+		return fmt.Errorf("git apply failed: %s, output: %s", applyErr, string(output))
+	}
+
+	getLog().Debug().Str("repo_path", validatedPath).Bool("check", opts.Check).Bool("three_way", opts.ThreeWay).Msg("Patch applied")
+	return nil
+}
+
+// parseApplyOutput collects rejected hunks ("error: patch failed: path:line") and
+// conflicted files ("U path") from git apply's output
+func parseApplyOutput(output string) *PatchRejectedError {
+	rejection := &PatchRejectedError{Output: output}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if hunk, ok := strings.CutPrefix(line, "error: patch failed: "); ok {
+			rejection.Rejected = append(rejection.Rejected, hunk)
+		} else if file, ok := strings.CutPrefix(line, "U "); ok {
+			rejection.Conflicts = append(rejection.Conflicts, file)
+		}
+	}
+	return rejection
+}
+
 // GetBranchHeadSHA returns the HEAD commit SHA of a specific branch.
 func (gs *GitService) GetBranchHeadSHA(ctx context.Context, repoPath, branch string) (string, error) {
 	validatedPath, err := gs.validateRepoPath(repoPath)
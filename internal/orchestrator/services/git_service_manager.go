@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -175,6 +176,25 @@ func (gsm *GitServiceManager) GetService(repoPath string) (*GitServiceHandle, er
 	}, nil
 }
 
+// WithRepo runs fn with the repository's write lock held, so a sequence of git
+// operations (create branch, add worktree, commit, ...) runs atomically. The handle
+// is acquired and released around the call. fn is not run if ctx ends while waiting
+// for the lock.
+func (gsm *GitServiceManager) WithRepo(ctx context.Context, repoPath string, fn func(*GitService) error) error {
+	handle, err := gsm.GetService(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to get git service handle: %w", err)
+	}
+	defer handle.Release()
+
+	return handle.WithWriteLock(ctx, func(gs *GitService) error {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("write operation timed out: %w", err)
+		}
+		return fn(gs)
+	})
+}
+
 // WithReadLock executes a function with read lock on the repository
 func (h *GitServiceHandle) WithReadLock(ctx context.Context, fn func(*GitService) error) error {
 	// Use context for timeout
@@ -530,3 +550,97 @@ func (h *GitServiceHandle) UpdateWorktreeAccess(taskID string) {
 		worktreeInfo.LastAccessed = time.Now()
 	}
 }
+
+// WorktreeEvictableFunc reports whether the task owning a worktree has finished,
+// so that its worktree may be evicted. ownerID is the task ID extracted from the worktree path.
+type WorktreeEvictableFunc func(ctx context.Context, ownerID string) (bool, error)
+
+// EvictWorktrees removes the oldest task worktrees until fewer than maxWorktrees remain,
+// making room for one more. Candidates are skipped (never removed) when isEvictable
+// reports false or fails, or when the worktree has uncommitted changes. Branches are kept
+// so that task history stays reachable. Returns the worktrees that were evicted.
+// A maxWorktrees of zero or less disables eviction.
+func (h *GitServiceHandle) EvictWorktrees(ctx context.Context, maxWorktrees int, isEvictable WorktreeEvictableFunc) ([]WorktreeInfo, error) {
+	if maxWorktrees <= 0 {
+		return nil, nil
+	}
+
+	var evicted []WorktreeInfo
+	err := h.WithWriteLock(ctx, func(gs *GitService) error {
+		worktrees, err := h.repo.worktreeManager.ListWorktreesDetailed(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list worktrees: %w", err)
+		}
+
+		excess := len(worktrees) - maxWorktrees + 1
+		if excess <= 0 {
+			return nil
+		}
+
+		sort.Slice(worktrees, func(i, j int) bool {
+			return worktrees[i].CreatedAt.Before(worktrees[j].CreatedAt)
+		})
+
+		for _, wt := range worktrees {
+			if len(evicted) >= excess {
+				break
+			}
+
+			evictable, err := isEvictable(ctx, wt.TaskID)
+			if err != nil {
+				getManagerLog().Warn().Err(err).
+					Str("taskID", wt.TaskID).
+					Str("worktreePath", wt.Path).
+					Msg("Failed to check worktree owner, skipping eviction candidate")
+				continue
+			}
+			if !evictable {
+				continue
+			}
+
+			clean, err := gs.IsWorkingDirectoryClean(ctx, wt.Path)
+			if err != nil || !clean {
+				getManagerLog().Info().
+					Str("taskID", wt.TaskID).
+					Str("worktreePath", wt.Path).
+					Msg("Worktree has uncommitted changes, skipping eviction candidate")
+				continue
+			}
+
+			if err := h.repo.worktreeManager.RemoveWorktree(ctx, wt.Path); err != nil {
+				getManagerLog().Warn().Err(err).
+					Str("worktreePath", wt.Path).
+					Msg("Failed to evict worktree")
+				continue
+			}
+
+			evicted = append(evicted, wt)
+		}
+
+		if len(evicted) < excess {
+			getManagerLog().Warn().
+				Str("repo", h.repoPath).
+				Int("maxWorktrees", maxWorktrees).
+				Int("worktrees", len(worktrees)-len(evicted)).
+				Msg("Worktree limit exceeded but no more worktrees are evictable")
+		}
+		return nil
+	})
+	if err != nil {
+		return evicted, err
+	}
+
+	active := h.GetActiveWorktrees()
+	for _, wt := range evicted {
+		if _, ok := active[wt.TaskID]; ok {
+			h.UnregisterWorktree(wt.TaskID)
+		}
+		getManagerLog().Info().
+			Str("taskID", wt.TaskID).
+			Str("worktreePath", wt.Path).
+			Str("repo", h.repoPath).
+			Msg("Evicted worktree")
+	}
+
+	return evicted, nil
+}
@@ -432,6 +432,112 @@ func TestGitServiceManager_WriteOperationsAreSerialized(t *testing.T) {
 	assert.GreaterOrEqual(t, len(branches), numWriters, "All branches should be created")
 }
 
+func initTestRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	gitService, err := NewGitService(dir, true)
+	require.NoError(t, err)
+	defer gitService.Close()
+	require.NoError(t, gitService.InitRepository(context.Background(), dir))
+	return dir
+}
+
+// TestGitServiceManager_WithRepo tests that WithRepo serializes work per repository only
+func TestGitServiceManager_WithRepo(t *testing.T) {
+	manager := NewGitServiceManager(nil)
+	defer manager.Close()
+	ctx := context.Background()
+
+	repoA := initTestRepo(t)
+	repoB := initTestRepo(t)
+
+	t.Run("same repository serializes", func(t *testing.T) {
+		var active, maxActive int32
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := manager.WithRepo(ctx, repoA, func(gs *GitService) error {
+					n := atomic.AddInt32(&active, 1)
+					for {
+						m := atomic.LoadInt32(&maxActive)
+						if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+							break
+						}
+					}
+					time.Sleep(50 * time.Millisecond)
+					atomic.AddInt32(&active, -1)
+					return nil
+				})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), maxActive, "calls on the same repository must not overlap")
+	})
+
+	t.Run("different repositories run in parallel", func(t *testing.T) {
+		entered := make(chan struct{}, 2)
+		both := make(chan struct{})
+		var wg sync.WaitGroup
+		for _, repo := range []string{repoA, repoB} {
+			wg.Add(1)
+			go func(repo string) {
+				defer wg.Done()
+				err := manager.WithRepo(ctx, repo, func(gs *GitService) error {
+					entered <- struct{}{}
+					select {
+					case <-both:
+						return nil
+					case <-time.After(2 * time.Second):
+						return fmt.Errorf("other repository never entered WithRepo")
+					}
+				})
+				assert.NoError(t, err)
+			}(repo)
+		}
+		<-entered
+		<-entered
+		close(both)
+		wg.Wait()
+	})
+
+	t.Run("fn is skipped when the context ends while waiting", func(t *testing.T) {
+		release := make(chan struct{})
+		holding := make(chan struct{})
+		go func() {
+			_ = manager.WithRepo(ctx, repoA, func(gs *GitService) error {
+				close(holding)
+				<-release
+				return nil
+			})
+		}()
+		<-holding
+
+		waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		var ran atomic.Bool
+		err := manager.WithRepo(waitCtx, repoA, func(gs *GitService) error {
+			ran.Store(true)
+			return nil
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		close(release)
+		// Let the abandoned call acquire the lock; it must not run fn
+		require.NoError(t, manager.WithRepo(ctx, repoA, func(gs *GitService) error { return nil }))
+		assert.False(t, ran.Load())
+	})
+
+	t.Run("fn error is returned", func(t *testing.T) {
+		err := manager.WithRepo(ctx, repoB, func(gs *GitService) error {
+			return fmt.Errorf("boom")
+		})
+		assert.EqualError(t, err, "boom")
+	})
+}
+
 // TestGitServiceManager_TimeoutHandling tests timeout behavior
 func TestGitServiceManager_TimeoutHandling(t *testing.T) {
 	manager := NewGitServiceManager(nil)
@@ -646,3 +752,90 @@ func TestGitServiceManager_ExistingRepositoryWithCommits(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+// TestGitServiceHandle_EvictWorktrees verifies that eviction removes the oldest finished
+// worktree and skips worktrees owned by running tasks or with uncommitted changes
+func TestGitServiceHandle_EvictWorktrees(t *testing.T) {
+	manager := NewGitServiceManager(nil)
+	defer manager.Close()
+
+	tempDir, err := os.MkdirTemp("", "test-evict-repo-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	ctx := context.Background()
+	require.NoError(t, exec.Command("git", "init", tempDir).Run())
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "file.txt"), []byte("content"), 0644))
+	require.NoError(t, exec.Command("git", "-C", tempDir, "add", ".").Run())
+	require.NoError(t, exec.Command("git", "-C", tempDir, "commit", "-m", "Initial commit").Run())
+	out, err := exec.Command("git", "-C", tempDir, "rev-parse", "HEAD").Output()
+	require.NoError(t, err)
+	commit := strings.TrimSpace(string(out))
+
+	handle, err := manager.GetService(tempDir)
+	require.NoError(t, err)
+	defer handle.Release()
+
+	// Oldest first: running, dirty, finished, newest
+	taskIDs := []string{"running", "dirty", "finished", "newest"}
+	paths := make(map[string]string)
+	base := time.Now().Add(-time.Hour)
+	for i, taskID := range taskIDs {
+		path, err := handle.GetWorktreeManager().CreateWorktreeFromCommit(ctx, taskID, commit)
+		require.NoError(t, err)
+		created := base.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, os.Chtimes(filepath.Join(path, ".git"), created, created))
+		handle.RegisterWorktree(taskID, path, GenerateTaskBranchName(taskID))
+		paths[taskID] = path
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(paths["dirty"], "wip.txt"), []byte("wip"), 0644))
+
+	isEvictable := func(ctx context.Context, ownerID string) (bool, error) {
+		return ownerID != "running", nil
+	}
+
+	t.Run("under limit evicts nothing", func(t *testing.T) {
+		evicted, err := handle.EvictWorktrees(ctx, 10, isEvictable)
+		require.NoError(t, err)
+		assert.Empty(t, evicted)
+	})
+
+	t.Run("zero limit disables eviction", func(t *testing.T) {
+		evicted, err := handle.EvictWorktrees(ctx, 0, isEvictable)
+		require.NoError(t, err)
+		assert.Empty(t, evicted)
+	})
+
+	t.Run("evicts oldest finished clean worktree", func(t *testing.T) {
+		evicted, err := handle.EvictWorktrees(ctx, 4, isEvictable)
+		require.NoError(t, err)
+		require.Len(t, evicted, 1)
+		assert.Equal(t, "finished", evicted[0].TaskID)
+
+		_, err = os.Stat(paths["finished"])
+		assert.True(t, os.IsNotExist(err), "evicted worktree directory should be removed")
+		for _, taskID := range []string{"running", "dirty", "newest"} {
+			_, err = os.Stat(paths[taskID])
+			assert.NoError(t, err, "worktree %s should be kept", taskID)
+		}
+
+		active := handle.GetActiveWorktrees()
+		assert.NotContains(t, active, "finished")
+		assert.Contains(t, active, "running")
+
+		// Branch is kept so task history stays reachable
+		err = exec.Command("git", "-C", tempDir, "rev-parse", "--verify", GenerateTaskBranchName("finished")).Run()
+		assert.NoError(t, err)
+	})
+
+	t.Run("stops when no candidate is evictable", func(t *testing.T) {
+		evicted, err := handle.EvictWorktrees(ctx, 1, func(ctx context.Context, ownerID string) (bool, error) {
+			if ownerID == "newest" {
+				return false, fmt.Errorf("lookup failed")
+			}
+			return ownerID != "running", nil
+		})
+		require.NoError(t, err)
+		assert.Empty(t, evicted)
+	})
+}
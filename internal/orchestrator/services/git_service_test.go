@@ -5,6 +5,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -423,6 +424,1011 @@ func TestGitService_GetCommitInfo(t *testing.T) {
 	assert.True(t, timestamp.After(time.Now().Add(-1*time.Minute)))
 }
 
+func TestGitService_GetDiffBetween(t *testing.T) {
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+	createTestRepoWithCommit(t, gitService, repoPath)
+
+	ctx := context.Background()
+	from, err := gitService.getCurrentCommit(ctx, repoPath)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "second.txt"), []byte("one\ntwo\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Second commit"))
+	to, err := gitService.getCurrentCommit(ctx, repoPath)
+	require.NoError(t, err)
+
+	diff, err := gitService.GetDiffBetween(ctx, repoPath, from, to)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "diff --git a/second.txt b/second.txt")
+	assert.Contains(t, diff, "+two")
+
+	diff, err = gitService.GetDiffBetween(ctx, repoPath, to, to)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	stat, err := gitService.GetDiffStatBetween(ctx, repoPath, from, to)
+	require.NoError(t, err)
+	assert.Contains(t, stat, "second.txt")
+	insertions, deletions := gitService.ParseDiffStat(stat)
+	assert.Equal(t, 2, insertions)
+	assert.Equal(t, 0, deletions)
+
+	// Reversed, the same change counts as deletions
+	stat, err = gitService.GetDiffStatBetween(ctx, repoPath, to, from)
+	require.NoError(t, err)
+	insertions, deletions = gitService.ParseDiffStat(stat)
+	assert.Equal(t, 0, insertions)
+	assert.Equal(t, 2, deletions)
+
+	_, err = gitService.GetDiffBetween(ctx, repoPath, "HEAD~1", to)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrCommitNotFound))
+
+	missing := strings.Repeat("e", 40)
+	_, err = gitService.GetDiffBetween(ctx, repoPath, from, missing)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCommitNotFound))
+	assert.Contains(t, err.Error(), missing)
+	_, err = gitService.GetDiffStatBetween(ctx, repoPath, missing, to)
+	assert.True(t, errors.Is(err, ErrCommitNotFound))
+}
+
+func TestGitService_GetDiffNumstat_IgnoreWhitespace(t *testing.T) {
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+	createTestRepoWithCommit(t, gitService, repoPath)
+
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "code.go"), []byte("func f() {\nreturn\n}\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Add code"))
+
+	// Reindent code.go only, and make a real change to test.txt
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "code.go"), []byte("func f() {\n\treturn\n}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "test.txt"), []byte("changed content"), 0644))
+
+	all, err := gitService.GetDiffNumstat(ctx, repoPath, DiffOptions{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"code.go", "test.txt"}, all.Files)
+	assert.Equal(t, 2, all.Insertions)
+	assert.Equal(t, 2, all.Deletions)
+
+	significant, err := gitService.GetDiffNumstat(ctx, repoPath, DiffOptions{IgnoreWhitespace: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test.txt"}, significant.Files)
+	assert.Equal(t, 1, significant.Insertions)
+	assert.Equal(t, 1, significant.Deletions)
+}
+
+func TestGitService_ScopedDiffAndCommit(t *testing.T) {
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+	createTestRepoWithCommit(t, gitService, repoPath)
+
+	ctx := context.Background()
+	apiDir := filepath.Join(repoPath, "services", "api")
+	require.NoError(t, os.MkdirAll(apiDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(apiDir, "main.go"), []byte("package main\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "test.txt"), []byte("changed outside scope"), 0644))
+
+	scope := []string{"services/api"}
+
+	diff, err := gitService.GetDiff(ctx, repoPath, scope...)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "services/api/main.go")
+	assert.NotContains(t, diff, "test.txt")
+
+	files, err := gitService.GetChangedFiles(ctx, repoPath, scope...)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"services/api/main.go"}, files)
+
+	stat, err := gitService.GetDiffStat(ctx, repoPath, scope...)
+	require.NoError(t, err)
+	assert.Contains(t, stat, "1 file changed")
+
+	numstat, err := gitService.GetDiffNumstat(ctx, repoPath, DiffOptions{Paths: scope})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"services/api/main.go"}, numstat.Files)
+
+	// Committing the scope leaves changes elsewhere in the working tree
+	require.NoError(t, gitService.CommitSpecificFiles(ctx, repoPath, scope, "Scoped commit"))
+
+	files, err = gitService.GetChangedFiles(ctx, repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test.txt"}, files)
+}
+
+func TestGitService_GetLiveDiff(t *testing.T) {
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+	createTestRepoWithCommit(t, gitService, repoPath)
+
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "test.txt"), []byte("changed content"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "new.txt"), []byte("untracked\n"), 0644))
+
+	indexPath := filepath.Join(repoPath, ".git", "index")
+	before, err := os.ReadFile(indexPath)
+	require.NoError(t, err)
+
+	live, err := gitService.GetLiveDiff(ctx, repoPath)
+	require.NoError(t, err)
+	assert.Contains(t, live.Diff, "diff --git a/new.txt b/new.txt")
+	assert.Contains(t, live.Diff, "+changed content")
+	assert.Contains(t, live.DiffStat, "2 files changed")
+	assert.ElementsMatch(t, []string{"new.txt", "test.txt"}, live.Numstat.Files)
+
+	// The agent's index is left exactly as it was: new.txt stays untracked
+	after, err := os.ReadFile(indexPath)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	scoped, err := gitService.GetLiveDiff(ctx, repoPath, "new.txt")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new.txt"}, scoped.Numstat.Files)
+	assert.NotContains(t, scoped.Diff, "test.txt")
+}
+
+func TestGitService_GetFileDiff(t *testing.T) {
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+	createTestRepoWithCommit(t, gitService, repoPath)
+
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "unchanged.txt"), []byte("same\n"), 0644))
+	require.NoError(t, gitService.CommitSpecificFiles(ctx, repoPath, []string{"unchanged.txt"}, "Add unchanged file"))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "test.txt"), []byte("changed content"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "new.txt"), []byte("untracked\n"), 0644))
+
+	diff, err := gitService.GetFileDiff(ctx, repoPath, "test.txt")
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+changed content")
+	assert.NotContains(t, diff, "new.txt")
+
+	// Untracked files show up as added
+	diff, err = gitService.GetFileDiff(ctx, repoPath, "new.txt")
+	require.NoError(t, err)
+	assert.Contains(t, diff, "diff --git a/new.txt b/new.txt")
+	assert.Contains(t, diff, "+untracked")
+
+	diff, err = gitService.GetFileDiff(ctx, repoPath, "unchanged.txt")
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	// Dots inside a name are not traversal
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "notes..txt"), []byte("dots\n"), 0644))
+	diff, err = gitService.GetFileDiff(ctx, repoPath, "notes..txt")
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+dots")
+
+	for _, invalid := range []string{"", "../outside.txt", "docs/../../outside.txt", "docs/..", "/etc/passwd", strings.Repeat("a", maxPathLength+1)} {
+		_, err := gitService.GetFileDiff(ctx, repoPath, invalid)
+		assert.Error(t, err, "path %.20q", invalid)
+	}
+}
+
+func TestGitService_GetWordDiff(t *testing.T) {
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+	createTestRepoWithCommit(t, gitService, repoPath)
+
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "style.css"), []byte("a { color: red; }\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Add style"))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "style.css"), []byte("a { color: blue; }\n"), 0644))
+
+	diff, err := gitService.GetWordDiff(ctx, repoPath)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "diff --git a/style.css b/style.css")
+	assert.Contains(t, diff, "\n a { color: \n-red;\n+blue;\n  }\n~\n")
+
+	// Line-based by default
+	diff, err = gitService.GetDiff(ctx, repoPath)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "\n-a { color: red; }\n+a { color: blue; }\n")
+
+	diff, err = gitService.GetWordDiff(ctx, repoPath, "test.txt")
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
+
+func TestFastForwardBranch_CreatesOnlyAbsentBranchWithZeroSHA(t *testing.T) {
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+	createTestRepoWithCommit(t, gitService, repoPath)
+
+	ctx := context.Background()
+	head, err := gitService.GetHeadCommitSHA(ctx, repoPath)
+	require.NoError(t, err)
+	zero := strings.Repeat("0", 40)
+
+	require.NoError(t, gitService.FastForwardBranch(ctx, repoPath, "release", head, zero))
+	exists, err := gitService.BranchExists(ctx, repoPath, "release")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	assert.Error(t, gitService.FastForwardBranch(ctx, repoPath, "release", head, zero), "the branch exists now")
+}
+
+func TestCleanWorkingSubdir(t *testing.T) {
+	repoPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(repoPath, "services", "api"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "README.md"), []byte("readme"), 0644))
+	require.NoError(t, os.Symlink(t.TempDir(), filepath.Join(repoPath, "escape")))
+
+	subdir, err := CleanWorkingSubdir(repoPath, "services/api/")
+	require.NoError(t, err)
+	assert.Equal(t, "services/api", subdir)
+
+	for _, root := range []string{"", ".", "./"} {
+		subdir, err := CleanWorkingSubdir(repoPath, root)
+		require.NoError(t, err)
+		assert.Empty(t, subdir)
+	}
+
+	for _, invalid := range []string{"../other", "services/../../other", "/etc", "missing", "README.md", "escape", ".git/hooks", "-rf"} {
+		_, err := CleanWorkingSubdir(repoPath, invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestParseDiffNumstat(t *testing.T) {
+	stat := parseDiffNumstat("3\t1\tmain.go\n0\t0\tformatted.go\n-\t-\timage.png\n\n")
+	assert.Equal(t, []string{"main.go", "image.png"}, stat.Files)
+	assert.Equal(t, 3, stat.Insertions)
+	assert.Equal(t, 1, stat.Deletions)
+
+	assert.Empty(t, parseDiffNumstat("").Files)
+}
+
+func TestSanitizeCommitText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain title", "Plain title (v2)", "Plain title (v2)"},
+		{"non-ASCII kept", "Résumé ＆ naïve — 日本語", "Résumé ＆ naïve — 日本語"},
+		{"shell-like characters kept", "Fix a | b; then c", "Fix a | b; then c"},
+		{"control characters dropped", "Fix\x00 the\x1b bug", "Fix the bug"},
+		{"line breaks become spaces", "Fix\nthe\tbug", "Fix the bug"},
+		{"bidi overrides dropped", "Fix \u202eevil\u202c \u2066bug\u2069", "Fix evil bug"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SanitizeCommitText(tt.input))
+		})
+	}
+}
+
+func TestValidateCommitText(t *testing.T) {
+	assert.NoError(t, ValidateCommitText("R&D: a & b (v2) — naïve"))
+	for _, title := range []string{"Fix a | b", "Handle <T>", "retry && go", "$(echo)", "a; b"} {
+		assert.Error(t, ValidateCommitText(title), title)
+	}
+}
+
+// patchFixture commits a 10-line file, captures a patch changing its line 1, then
+// restores the committed file so the patch can be applied to it
+func patchFixture(t *testing.T) (*GitService, string, string) {
+	gitService, repoPath, cleanup := createTestGitService(t)
+	t.Cleanup(cleanup)
+	createTestRepoWithCommit(t, gitService, repoPath)
+
+	ctx := context.Background()
+	lines := []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten"}
+	file := filepath.Join(repoPath, "lines.txt")
+	require.NoError(t, os.WriteFile(file, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Add lines"))
+
+	changed := append([]string{"ONE"}, lines[1:]...)
+	require.NoError(t, os.WriteFile(file, []byte(strings.Join(changed, "\n")+"\n"), 0644))
+	patch, err := gitService.GetDiff(ctx, repoPath)
+	require.NoError(t, err)
+	require.NoError(t, gitService.ResetToCommit(ctx, repoPath, "HEAD", true))
+
+	return gitService, repoPath, patch
+}
+
+func TestGitService_Apply(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("clean apply", func(t *testing.T) {
+		gitService, repoPath, patch := patchFixture(t)
+
+		require.NoError(t, gitService.Apply(ctx, repoPath, patch, ApplyOptions{}))
+		content, err := os.ReadFile(filepath.Join(repoPath, "lines.txt"))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(content), "ONE\ntwo\n"))
+	})
+
+	t.Run("check reports rejected hunks without touching the tree", func(t *testing.T) {
+		gitService, repoPath, patch := patchFixture(t)
+		file := filepath.Join(repoPath, "lines.txt")
+		require.NoError(t, os.WriteFile(file, []byte("one\nTWO\nthree\n"), 0644))
+
+		err := gitService.Apply(ctx, repoPath, patch, ApplyOptions{Check: true})
+		var rejected *PatchRejectedError
+		require.ErrorAs(t, err, &rejected)
+		assert.Equal(t, []string{"lines.txt:1"}, rejected.Rejected)
+		assert.Empty(t, rejected.Conflicts)
+
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "one\nTWO\nthree\n", string(content))
+
+		// A patch that fits passes the check and still leaves the tree alone
+		require.NoError(t, gitService.ResetToCommit(ctx, repoPath, "HEAD", true))
+		require.NoError(t, gitService.Apply(ctx, repoPath, patch, ApplyOptions{Check: true}))
+		clean, err := gitService.IsWorkingDirectoryClean(ctx, repoPath)
+		require.NoError(t, err)
+		assert.True(t, clean)
+	})
+
+	t.Run("3-way falls back to a merge when the context changed", func(t *testing.T) {
+		gitService, repoPath, patch := patchFixture(t)
+
+		// Change line 4, inside the patch's context but not next to its change
+		file := filepath.Join(repoPath, "lines.txt")
+		require.NoError(t, os.WriteFile(file, []byte("one\ntwo\nthree\nFOUR\nfive\nsix\nseven\neight\nnine\nten\n"), 0644))
+		require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Change line four"))
+
+		var rejected *PatchRejectedError
+		require.ErrorAs(t, gitService.Apply(ctx, repoPath, patch, ApplyOptions{}), &rejected)
+
+		require.NoError(t, gitService.Apply(ctx, repoPath, patch, ApplyOptions{ThreeWay: true}))
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "ONE\ntwo\nthree\nFOUR\nfive\nsix\nseven\neight\nnine\nten\n", string(content))
+	})
+
+	t.Run("3-way reports conflicting files", func(t *testing.T) {
+		gitService, repoPath, patch := patchFixture(t)
+
+		file := filepath.Join(repoPath, "lines.txt")
+		require.NoError(t, os.WriteFile(file, []byte("uno\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n"), 0644))
+		require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Change line one"))
+
+		var rejected *PatchRejectedError
+		require.ErrorAs(t, gitService.Apply(ctx, repoPath, patch, ApplyOptions{ThreeWay: true}), &rejected)
+		assert.Equal(t, []string{"lines.txt"}, rejected.Conflicts)
+	})
+
+	t.Run("empty patch", func(t *testing.T) {
+		gitService, repoPath, _ := patchFixture(t)
+		assert.Error(t, gitService.Apply(ctx, repoPath, "  \n", ApplyOptions{}))
+	})
+}
+
+// rebaseFixture creates a "task" branch with one commit changing file, then adds a commit
+// on the main branch writing mainContent to the same file when it is not empty, or to
+// another file otherwise. The task branch is left checked out.
+func rebaseFixture(t *testing.T, mainContent string) (*GitService, string, string) {
+	gitService, repoPath, cleanup := createTestGitService(t)
+	t.Cleanup(cleanup)
+	createTestRepoWithCommit(t, gitService, repoPath)
+
+	ctx := context.Background()
+	mainBranch, err := gitService.getCurrentBranch(ctx, repoPath)
+	require.NoError(t, err)
+	file := filepath.Join(repoPath, "shared.txt")
+	require.NoError(t, os.WriteFile(file, []byte("base\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Add shared file"))
+
+	require.NoError(t, gitService.CreateBranch(ctx, repoPath, "task"))
+	require.NoError(t, gitService.SwitchBranch(ctx, repoPath, "task"))
+	require.NoError(t, os.WriteFile(file, []byte("task\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Task change"))
+
+	require.NoError(t, gitService.SwitchBranch(ctx, repoPath, mainBranch))
+	if mainContent != "" {
+		require.NoError(t, os.WriteFile(file, []byte(mainContent), 0644))
+	} else {
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, "other.txt"), []byte("main\n"), 0644))
+	}
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Main change"))
+	require.NoError(t, gitService.SwitchBranch(ctx, repoPath, "task"))
+
+	return gitService, repoPath, mainBranch
+}
+
+func TestGitService_Rebase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("clean rebase, then already up to date", func(t *testing.T) {
+		gitService, repoPath, mainBranch := rebaseFixture(t, "")
+
+		result, err := gitService.Rebase(ctx, repoPath, "task", mainBranch)
+		require.NoError(t, err)
+		assert.NotEqual(t, result.OldHeadSHA, result.NewHeadSHA)
+		assert.False(t, result.UpToDate)
+		assert.Empty(t, result.Conflicts)
+		assert.FileExists(t, filepath.Join(repoPath, "other.txt"))
+
+		again, err := gitService.Rebase(ctx, repoPath, "task", mainBranch)
+		require.NoError(t, err)
+		assert.True(t, again.UpToDate)
+		assert.Equal(t, result.NewHeadSHA, again.NewHeadSHA)
+	})
+
+	t.Run("conflicts are reported and aborted", func(t *testing.T) {
+		gitService, repoPath, mainBranch := rebaseFixture(t, "main\n")
+		before, err := gitService.GetBranchHeadSHA(ctx, repoPath, "task")
+		require.NoError(t, err)
+
+		result, err := gitService.Rebase(ctx, repoPath, "task", mainBranch)
+		require.NoError(t, err)
+		assert.True(t, result.Aborted)
+		assert.Equal(t, []string{"shared.txt"}, result.Conflicts)
+		assert.Equal(t, before, result.NewHeadSHA)
+
+		inProgress, err := gitService.rebaseInProgress(ctx, repoPath)
+		require.NoError(t, err)
+		assert.False(t, inProgress)
+		clean, err := gitService.IsWorkingDirectoryClean(ctx, repoPath)
+		require.NoError(t, err)
+		assert.True(t, clean)
+	})
+
+	t.Run("kept conflicts are aborted by a retry", func(t *testing.T) {
+		gitService, repoPath, mainBranch := rebaseFixture(t, "main\n")
+
+		result, err := gitService.RebaseWithOptions(ctx, repoPath, "task", mainBranch, RebaseOptions{KeepConflicts: true})
+		require.NoError(t, err)
+		assert.True(t, result.InProgress)
+		inProgress, err := gitService.rebaseInProgress(ctx, repoPath)
+		require.NoError(t, err)
+		require.True(t, inProgress)
+
+		// A retried call clears the leftover rebase before trying again
+		retry, err := gitService.Rebase(ctx, repoPath, "task", mainBranch)
+		require.NoError(t, err)
+		assert.True(t, retry.Aborted)
+		assert.Equal(t, result.OldHeadSHA, retry.OldHeadSHA)
+		inProgress, err = gitService.rebaseInProgress(ctx, repoPath)
+		require.NoError(t, err)
+		assert.False(t, inProgress)
+	})
+
+	t.Run("rejects unsafe refs", func(t *testing.T) {
+		gitService, repoPath, _ := rebaseFixture(t, "")
+		_, err := gitService.Rebase(ctx, repoPath, "task", "--exec=rm")
+		assert.Error(t, err)
+	})
+}
+
+func TestGitService_MergeAndCherryPick(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name      string
+		integrate func(gs *GitService, repoPath, branch string) (*MergeResult, error)
+	}{
+		{name: "merge", integrate: func(gs *GitService, repoPath, branch string) (*MergeResult, error) {
+			return gs.Merge(ctx, repoPath, branch)
+		}},
+		{name: "cherry-pick", integrate: func(gs *GitService, repoPath, branch string) (*MergeResult, error) {
+			return gs.CherryPick(ctx, repoPath, branch)
+		}},
+	} {
+		t.Run(tc.name+" applies once", func(t *testing.T) {
+			gitService, repoPath, mainBranch := rebaseFixture(t, "")
+			require.NoError(t, gitService.SwitchBranch(ctx, repoPath, mainBranch))
+
+			result, err := tc.integrate(gitService, repoPath, "task")
+			require.NoError(t, err)
+			assert.Empty(t, result.Conflicts)
+			assert.False(t, result.UpToDate)
+			assert.NotEqual(t, result.OldHeadSHA, result.NewHeadSHA)
+			content, err := os.ReadFile(filepath.Join(repoPath, "shared.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, "task\n", string(content))
+
+			// A retry finds nothing left to apply
+			again, err := tc.integrate(gitService, repoPath, "task")
+			require.NoError(t, err)
+			assert.True(t, again.UpToDate)
+			assert.Equal(t, result.NewHeadSHA, again.NewHeadSHA)
+		})
+
+		t.Run(tc.name+" conflicts are reported and aborted", func(t *testing.T) {
+			gitService, repoPath, mainBranch := rebaseFixture(t, "main\n")
+			require.NoError(t, gitService.SwitchBranch(ctx, repoPath, mainBranch))
+
+			result, err := tc.integrate(gitService, repoPath, "task")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"shared.txt"}, result.Conflicts)
+			assert.Equal(t, result.OldHeadSHA, result.NewHeadSHA)
+
+			clean, err := gitService.IsWorkingDirectoryClean(ctx, repoPath)
+			require.NoError(t, err)
+			assert.True(t, clean)
+			busy, err := gitService.gitStateExists(ctx, repoPath, "MERGE_HEAD", "CHERRY_PICK_HEAD", "sequencer")
+			require.NoError(t, err)
+			assert.False(t, busy)
+		})
+	}
+
+	t.Run("a merge left in progress is aborted by a retry", func(t *testing.T) {
+		gitService, repoPath, mainBranch := rebaseFixture(t, "main\n")
+		require.NoError(t, gitService.SwitchBranch(ctx, repoPath, mainBranch))
+		_, hasConflicts, err := gitService.MergeInWorktree(ctx, repoPath, "task")
+		require.NoError(t, err)
+		require.True(t, hasConflicts)
+
+		result, err := gitService.Merge(ctx, repoPath, "task")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"shared.txt"}, result.Conflicts)
+		merging, err := gitService.gitStateExists(ctx, repoPath, "MERGE_HEAD")
+		require.NoError(t, err)
+		assert.False(t, merging)
+	})
+
+	t.Run("rejects unsafe refs", func(t *testing.T) {
+		gitService, repoPath, _ := rebaseFixture(t, "")
+		_, err := gitService.Merge(ctx, repoPath, "--upload-pack=rm")
+		assert.Error(t, err)
+		_, err = gitService.CherryPick(ctx, repoPath, "--strategy=x")
+		assert.Error(t, err)
+	})
+}
+
+func TestGitService_MergeBranch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates a merge commit", func(t *testing.T) {
+		gitService, repoPath, mainBranch := rebaseFixture(t, "")
+		require.NoError(t, gitService.SwitchBranch(ctx, repoPath, mainBranch))
+
+		mergeSHA, conflicts, err := gitService.MergeBranch(ctx, repoPath, "task", "Keep the task implementation")
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+		head, err := gitService.getCurrentCommit(ctx, repoPath)
+		require.NoError(t, err)
+		assert.Equal(t, head, mergeSHA)
+
+		commit, err := gitService.gitOutput(ctx, repoPath, nil, "log", "-1", "--format=%P%n%s")
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(commit), "\n")
+		assert.Len(t, strings.Fields(lines[0]), 2, "a merge commit has two parents")
+		assert.Equal(t, "Keep the task implementation", lines[1])
+
+		// Merging again commits nothing
+		again, _, err := gitService.MergeBranch(ctx, repoPath, "task", "")
+		require.NoError(t, err)
+		assert.Equal(t, mergeSHA, again)
+	})
+
+	t.Run("conflicts are left in progress", func(t *testing.T) {
+		gitService, repoPath, mainBranch := rebaseFixture(t, "main\n")
+		require.NoError(t, gitService.SwitchBranch(ctx, repoPath, mainBranch))
+
+		mergeSHA, conflicts, err := gitService.MergeBranch(ctx, repoPath, "task", "")
+		require.ErrorIs(t, err, ErrMergeConflict)
+		assert.Empty(t, mergeSHA)
+		assert.Equal(t, []string{"shared.txt"}, conflicts)
+		merging, err := gitService.gitStateExists(ctx, repoPath, "MERGE_HEAD")
+		require.NoError(t, err)
+		assert.True(t, merging)
+		content, err := os.ReadFile(filepath.Join(repoPath, "shared.txt"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "<<<<<<<")
+
+		require.NoError(t, gitService.AbortMerge(ctx, repoPath))
+		clean, err := gitService.IsWorkingDirectoryClean(ctx, repoPath)
+		require.NoError(t, err)
+		assert.True(t, clean)
+	})
+
+	t.Run("rejects unsafe refs", func(t *testing.T) {
+		gitService, repoPath, _ := rebaseFixture(t, "")
+		_, _, err := gitService.MergeBranch(ctx, repoPath, "--upload-pack=rm", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestGitService_CreateTagAndListTags(t *testing.T) {
+	ctx := context.Background()
+	gitService, repoPath, mainBranch := rebaseFixture(t, "")
+	taskHead, err := gitService.GetBranchHeadSHA(ctx, repoPath, "task")
+	require.NoError(t, err)
+	require.NoError(t, gitService.SwitchBranch(ctx, repoPath, mainBranch))
+	head, err := gitService.getCurrentCommit(ctx, repoPath)
+	require.NoError(t, err)
+
+	tags, err := gitService.ListTags(ctx, repoPath)
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+
+	require.NoError(t, gitService.CreateTag(ctx, repoPath, "noldarim/run-abc123", taskHead, "Run abc123 completed"))
+	require.NoError(t, gitService.CreateTag(ctx, repoPath, "v1.0.0", "", ""))
+
+	tags, err = gitService.ListTags(ctx, repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"noldarim/run-abc123", "v1.0.0"}, tags)
+
+	// Annotated tags, on the given commit or HEAD
+	for tag, want := range map[string]string{"noldarim/run-abc123": taskHead, "v1.0.0": head} {
+		objectType, err := gitService.gitOutput(ctx, repoPath, nil, "rev-parse", "--verify", "refs/tags/"+tag)
+		require.NoError(t, err)
+		target, err := gitService.gitOutput(ctx, repoPath, nil, "rev-parse", "--verify", tag+"^{commit}")
+		require.NoError(t, err)
+		assert.NotEqual(t, strings.TrimSpace(target), strings.TrimSpace(objectType), "%s is a tag object", tag)
+		assert.Equal(t, want, strings.TrimSpace(target))
+	}
+	for tag, want := range map[string]string{"noldarim/run-abc123": "Run abc123 completed", "v1.0.0": "v1.0.0"} {
+		message, err := gitService.gitOutput(ctx, repoPath, nil, "tag", "--list", "--format=%(contents:subject)", tag)
+		require.NoError(t, err)
+		assert.Equal(t, want, strings.TrimSpace(message))
+	}
+
+	// Tags are not moved
+	assert.Error(t, gitService.CreateTag(ctx, repoPath, "v1.0.0", taskHead, ""))
+	assert.Error(t, gitService.CreateTag(ctx, repoPath, "-f", "", ""))
+	assert.ErrorIs(t, gitService.CreateTag(ctx, repoPath, "v2", strings.Repeat("e", 40), ""), ErrCommitNotFound)
+}
+
+func TestGitService_Blame(t *testing.T) {
+	ctx := context.Background()
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+	createTestRepoWithCommit(t, gitService, repoPath)
+
+	file := filepath.Join(repoPath, "lines.txt")
+	require.NoError(t, os.WriteFile(file, []byte("one\ntwo\nthree\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Add lines"))
+	first, err := gitService.getCurrentCommit(ctx, repoPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, []byte("one\nTWO\nthree\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Change line two"))
+	second, err := gitService.getCurrentCommit(ctx, repoPath)
+	require.NoError(t, err)
+	author, err := gitService.gitOutput(ctx, repoPath, nil, "log", "-1", "--format=%an")
+	require.NoError(t, err)
+	author = strings.TrimSpace(author)
+
+	lines, err := gitService.Blame(ctx, repoPath, "lines.txt", 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []BlameLine{
+		{CommitHash: first, Author: author, LineNumber: 1},
+		{CommitHash: second, Author: author, LineNumber: 2},
+		{CommitHash: first, Author: author, LineNumber: 3},
+	}, lines)
+
+	lines, err = gitService.Blame(ctx, repoPath, "lines.txt", 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []BlameLine{{CommitHash: second, Author: author, LineNumber: 2}}, lines)
+
+	_, err = gitService.Blame(ctx, repoPath, "lines.txt", 3, 2)
+	assert.Error(t, err)
+	_, err = gitService.Blame(ctx, repoPath, "lines.txt", 0, 2)
+	assert.Error(t, err)
+	_, err = gitService.Blame(ctx, repoPath, "../lines.txt", 1, 1)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "untracked.txt"), []byte("x\n"), 0644))
+	_, err = gitService.Blame(ctx, repoPath, "untracked.txt", 1, 1)
+	assert.ErrorIs(t, err, ErrFileNotTracked)
+	_, err = gitService.Blame(ctx, repoPath, "missing.txt", 1, 1)
+	assert.ErrorIs(t, err, ErrFileNotTracked)
+}
+
+func TestGitService_CommitIdentity(t *testing.T) {
+	ctx := context.Background()
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+	createTestRepoWithCommit(t, gitService, repoPath)
+
+	identityOf := func(gs *GitService) string {
+		output, err := gs.gitOutput(ctx, repoPath, nil, "log", "-1", "--format=%an <%ae> %cn <%ce>")
+		require.NoError(t, err)
+		return strings.TrimSpace(output)
+	}
+	assert.Equal(t, "Test User <test@example.com> Test User <test@example.com>", identityOf(gitService))
+
+	cfg := &config.AppConfig{Git: config.GitConfig{AuthorName: "noldarim-agent", AuthorEmail: "agent@noldarim.local"}}
+	agentService, err := NewGitServiceWithConfig(repoPath, cfg, false)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "agent.txt"), []byte("agent\n"), 0644))
+	require.NoError(t, agentService.CreateCommit(ctx, repoPath, "Agent change"))
+	assert.Equal(t, "noldarim-agent <agent@noldarim.local> noldarim-agent <agent@noldarim.local>", identityOf(agentService))
+
+	// A name alone keeps the configured email
+	require.NoError(t, agentService.SetCommitIdentity(CommitIdentity{Name: "Reviewer"}))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "agent.txt"), []byte("reviewed\n"), 0644))
+	require.NoError(t, agentService.CreateCommit(ctx, repoPath, "Review change"))
+	assert.Equal(t, "Reviewer <test@example.com> Reviewer <test@example.com>", identityOf(agentService))
+
+	assert.Error(t, agentService.SetCommitIdentity(CommitIdentity{Email: "not-an-email"}))
+	cfg.Git.AuthorEmail = "agent@noldarim.local\nuser.name=x"
+	_, err = NewGitServiceWithConfig(repoPath, cfg, false)
+	assert.Error(t, err)
+}
+
+func TestGitService_CreateCommitWithTrailers(t *testing.T) {
+	ctx := context.Background()
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+	createTestRepoWithCommit(t, gitService, repoPath)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "agent.txt"), []byte("agent\n"), 0644))
+	require.NoError(t, gitService.CreateCommitWithTrailers(ctx, repoPath, "Agent change", map[string]string{
+		"Noldarim-Run":   "run-abc123",
+		"Co-authored-by": "noldarim-agent <agent@noldarim.local>",
+	}))
+
+	message, err := gitService.gitOutput(ctx, repoPath, nil, "log", "-1", "--format=%B")
+	require.NoError(t, err)
+	assert.Equal(t, "Agent change\n\nCo-authored-by: noldarim-agent <agent@noldarim.local>\nNoldarim-Run: run-abc123", strings.TrimSpace(message))
+
+	// Invalid trailers are rejected before anything is committed
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "agent.txt"), []byte("again\n"), 0644))
+	head, err := gitService.getCurrentCommit(ctx, repoPath)
+	require.NoError(t, err)
+	assert.Error(t, gitService.CreateCommitWithTrailers(ctx, repoPath, "Again", map[string]string{"Co-authored-by": "A | B <a@x.com>"}))
+	after, err := gitService.getCurrentCommit(ctx, repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, head, after)
+}
+
+func TestGitService_AheadBehind(t *testing.T) {
+	ctx := context.Background()
+	gitService, repoPath, mainBranch := rebaseFixture(t, "")
+
+	// task has "Task change", main has "Main change" since they diverged
+	ahead, behind, err := gitService.AheadBehind(ctx, repoPath, "task", mainBranch)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ahead)
+	assert.Equal(t, 1, behind)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "more.txt"), []byte("more\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "More task work"))
+	ahead, behind, err = gitService.AheadBehind(ctx, repoPath, "task", mainBranch)
+	require.NoError(t, err)
+	assert.Equal(t, 2, ahead)
+	assert.Equal(t, 1, behind)
+
+	ahead, behind, err = gitService.AheadBehind(ctx, repoPath, mainBranch, "task")
+	require.NoError(t, err)
+	assert.Equal(t, 1, ahead)
+	assert.Equal(t, 2, behind)
+
+	ahead, behind, err = gitService.AheadBehind(ctx, repoPath, "task", "task")
+	require.NoError(t, err)
+	assert.Zero(t, ahead)
+	assert.Zero(t, behind)
+
+	_, _, err = gitService.AheadBehind(ctx, repoPath, "missing", mainBranch)
+	assert.ErrorIs(t, err, ErrBranchNotFound)
+	assert.Contains(t, err.Error(), "missing")
+	_, _, err = gitService.AheadBehind(ctx, repoPath, "task", "missing")
+	assert.ErrorIs(t, err, ErrBranchNotFound)
+	_, _, err = gitService.AheadBehind(ctx, repoPath, "task", "--all")
+	assert.Error(t, err)
+}
+
+func TestGitService_GetChangedFilesDetailed(t *testing.T) {
+	ctx := context.Background()
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+
+	files, err := gitService.GetChangedFilesDetailed(ctx, repoPath)
+	require.NoError(t, err)
+	assert.Empty(t, files, "no commits yet")
+
+	createTestRepoWithCommit(t, gitService, repoPath)
+	rename := strings.Repeat("a line that is long enough to be recognised after a rename\n", 5)
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "old name.txt"), []byte(rename), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "gone.txt"), []byte("gone\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "image.png"), []byte{0x89, 'P', 'N', 'G', 0, 1, 2}, 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Add files"))
+
+	files, err = gitService.GetChangedFilesDetailed(ctx, repoPath)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "test.txt"), []byte("changed"), 0644))
+	require.NoError(t, os.Rename(filepath.Join(repoPath, "old name.txt"), filepath.Join(repoPath, "new name.txt")))
+	require.NoError(t, os.Remove(filepath.Join(repoPath, "gone.txt")))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "image.png"), []byte{0x89, 'P', 'N', 'G', 0, 3, 4}, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "added.bin"), []byte{0, 0, 0}, 0644))
+	require.NoError(t, gitService.runSafeGitCommand(ctx, repoPath, "add", "-A"))
+
+	files, err = gitService.GetChangedFilesDetailed(ctx, repoPath)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []ChangedFile{
+		{Path: "added.bin", Status: ChangedFileAdded, IsBinary: true},
+		{Path: "gone.txt", Status: ChangedFileDeleted},
+		{Path: "image.png", Status: ChangedFileModified, IsBinary: true},
+		{Path: "new name.txt", OldPath: "old name.txt", Status: ChangedFileRenamed},
+		{Path: "test.txt", Status: ChangedFileModified},
+	}, files)
+}
+
+func TestGitService_AmendCommit(t *testing.T) {
+	ctx := context.Background()
+	gitService, repoPath, cleanup := createTestGitService(t)
+	defer cleanup()
+
+	// The subject and files of the last commit, and its parent
+	lastCommit := func() (subject string, files []string, parent string) {
+		output, err := gitService.gitOutput(ctx, repoPath, nil, "log", "-1", "--format=%P%n%s", "--name-only")
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		require.Greater(t, len(lines), 3)
+		return lines[1], lines[3:], lines[0]
+	}
+
+	emptyRepo := t.TempDir()
+	require.NoError(t, gitService.runSafeGitCommand(ctx, emptyRepo, "init"))
+	assert.ErrorIs(t, gitService.AmendCommit(ctx, emptyRepo, "Too early"), ErrNoCommits)
+	assert.ErrorIs(t, gitService.AmendCommitNoEdit(ctx, emptyRepo), ErrNoCommits)
+
+	createTestRepoWithCommit(t, gitService, repoPath)
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "a.txt"), []byte("a\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Wrong message"))
+	_, _, parent := lastCommit()
+
+	require.NoError(t, gitService.AmendCommit(ctx, repoPath, "Right message"))
+	subject, files, amendedParent := lastCommit()
+	assert.Equal(t, "Right message", subject)
+	assert.Equal(t, []string{"a.txt"}, files)
+	assert.Equal(t, parent, amendedParent, "amending replaces the commit instead of adding one")
+
+	// Pending changes are folded into the amended commit
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "b.txt"), []byte("b\n"), 0644))
+	require.NoError(t, gitService.AmendCommitNoEdit(ctx, repoPath))
+	subject, files, amendedParent = lastCommit()
+	assert.Equal(t, "Right message", subject)
+	assert.Equal(t, []string{"a.txt", "b.txt"}, files)
+	assert.Equal(t, parent, amendedParent)
+	clean, err := gitService.IsWorkingDirectoryClean(ctx, repoPath)
+	require.NoError(t, err)
+	assert.True(t, clean)
+
+	assert.Error(t, gitService.AmendCommit(ctx, repoPath, ""))
+	assert.Error(t, gitService.AmendCommit(ctx, repoPath, "Fix; rm -rf /"))
+}
+
+func TestGitService_CherryPickCommit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("applies a single commit", func(t *testing.T) {
+		gitService, repoPath, mainBranch := rebaseFixture(t, "")
+		taskHead, err := gitService.GetBranchHeadSHA(ctx, repoPath, "task")
+		require.NoError(t, err)
+		require.NoError(t, gitService.SwitchBranch(ctx, repoPath, mainBranch))
+
+		require.NoError(t, gitService.CherryPickCommit(ctx, repoPath, taskHead))
+		content, err := os.ReadFile(filepath.Join(repoPath, "shared.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "task\n", string(content))
+		clean, err := gitService.IsWorkingDirectoryClean(ctx, repoPath)
+		require.NoError(t, err)
+		assert.True(t, clean)
+	})
+
+	t.Run("conflicts are left in progress until aborted", func(t *testing.T) {
+		gitService, repoPath, mainBranch := rebaseFixture(t, "main\n")
+		taskHead, err := gitService.GetBranchHeadSHA(ctx, repoPath, "task")
+		require.NoError(t, err)
+		require.NoError(t, gitService.SwitchBranch(ctx, repoPath, mainBranch))
+		mainHead, err := gitService.getCurrentCommit(ctx, repoPath)
+		require.NoError(t, err)
+
+		err = gitService.CherryPickCommit(ctx, repoPath, taskHead)
+		require.ErrorIs(t, err, ErrCherryPickConflict)
+		var conflict *CherryPickConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, taskHead, conflict.Commit)
+		assert.Equal(t, []string{"shared.txt"}, conflict.Conflicts)
+		picking, err := gitService.gitStateExists(ctx, repoPath, "CHERRY_PICK_HEAD")
+		require.NoError(t, err)
+		assert.True(t, picking, "the conflict is kept for resolution")
+
+		require.NoError(t, gitService.CherryPickAbort(ctx, repoPath))
+		picking, err = gitService.gitStateExists(ctx, repoPath, "CHERRY_PICK_HEAD")
+		require.NoError(t, err)
+		assert.False(t, picking)
+		head, err := gitService.getCurrentCommit(ctx, repoPath)
+		require.NoError(t, err)
+		assert.Equal(t, mainHead, head)
+		clean, err := gitService.IsWorkingDirectoryClean(ctx, repoPath)
+		require.NoError(t, err)
+		assert.True(t, clean)
+
+		// Nothing left to abort
+		assert.Error(t, gitService.CherryPickAbort(ctx, repoPath))
+	})
+
+	t.Run("rejects invalid and unknown commits", func(t *testing.T) {
+		gitService, repoPath, _ := rebaseFixture(t, "")
+		assert.Error(t, gitService.CherryPickCommit(ctx, repoPath, "--strategy=x"))
+		assert.Error(t, gitService.CherryPickCommit(ctx, repoPath, "HEAD"))
+		assert.ErrorIs(t, gitService.CherryPickCommit(ctx, repoPath, strings.Repeat("e", 40)), ErrCommitNotFound)
+	})
+}
+
+func TestGitService_RevertCommit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("undoes a commit with a new one", func(t *testing.T) {
+		gitService, repoPath, _ := rebaseFixture(t, "")
+		taskHead, err := gitService.GetBranchHeadSHA(ctx, repoPath, "task")
+		require.NoError(t, err)
+
+		revertSHA, err := gitService.RevertCommit(ctx, repoPath, taskHead)
+		require.NoError(t, err)
+		head, err := gitService.getCurrentCommit(ctx, repoPath)
+		require.NoError(t, err)
+		assert.Equal(t, head, revertSHA)
+		parent, err := gitService.gitOutput(ctx, repoPath, nil, "rev-parse", "HEAD^")
+		require.NoError(t, err)
+		assert.Equal(t, taskHead, strings.TrimSpace(parent))
+
+		content, err := os.ReadFile(filepath.Join(repoPath, "shared.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "base\n", string(content))
+		clean, err := gitService.IsWorkingDirectoryClean(ctx, repoPath)
+		require.NoError(t, err)
+		assert.True(t, clean)
+	})
+
+	t.Run("conflicts are left in progress until aborted", func(t *testing.T) {
+		gitService, repoPath, _ := rebaseFixture(t, "")
+		taskHead, err := gitService.GetBranchHeadSHA(ctx, repoPath, "task")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, "shared.txt"), []byte("later\n"), 0644))
+		require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Later change"))
+		laterHead, err := gitService.getCurrentCommit(ctx, repoPath)
+		require.NoError(t, err)
+
+		_, err = gitService.RevertCommit(ctx, repoPath, taskHead)
+		require.ErrorIs(t, err, ErrRevertConflict)
+		var conflict *RevertConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, taskHead, conflict.Commit)
+		assert.Equal(t, []string{"shared.txt"}, conflict.Conflicts)
+		reverting, err := gitService.gitStateExists(ctx, repoPath, "REVERT_HEAD")
+		require.NoError(t, err)
+		assert.True(t, reverting, "the conflict is kept for resolution")
+
+		require.NoError(t, gitService.RevertAbort(ctx, repoPath))
+		head, err := gitService.getCurrentCommit(ctx, repoPath)
+		require.NoError(t, err)
+		assert.Equal(t, laterHead, head)
+		clean, err := gitService.IsWorkingDirectoryClean(ctx, repoPath)
+		require.NoError(t, err)
+		assert.True(t, clean)
+	})
+
+	t.Run("reports conflicted paths with spaces intact", func(t *testing.T) {
+		gitService, repoPath, _ := rebaseFixture(t, "")
+		file := filepath.Join(repoPath, "release notes.txt")
+		require.NoError(t, os.WriteFile(file, []byte("first\n"), 0644))
+		require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Add release notes"))
+		notesHead, err := gitService.getCurrentCommit(ctx, repoPath)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(file, []byte("second\n"), 0644))
+		require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Update release notes"))
+
+		_, err = gitService.RevertCommit(ctx, repoPath, notesHead)
+		var conflict *RevertConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, []string{"release notes.txt"}, conflict.Conflicts)
+		require.NoError(t, gitService.RevertAbort(ctx, repoPath))
+	})
+
+	t.Run("rejects invalid and unknown commits", func(t *testing.T) {
+		gitService, repoPath, _ := rebaseFixture(t, "")
+		_, err := gitService.RevertCommit(ctx, repoPath, "--continue")
+		assert.Error(t, err)
+		_, err = gitService.RevertCommit(ctx, repoPath, strings.Repeat("e", 40))
+		assert.ErrorIs(t, err, ErrCommitNotFound)
+	})
+}
+
 func TestGitService_CleanWorkingDirectory(t *testing.T) {
 	// Create temporary directory for testing
 	tempDir := t.TempDir()
@@ -1174,6 +2180,53 @@ func TestGitService_GetWorktreeBranch(t *testing.T) {
 	}
 }
 
+func TestGitService_EnsureWorktreeOnBranch(t *testing.T) {
+	fixture := WithGitService(t)
+	defer fixture.Cleanup()
+
+	ctx := context.Background()
+	createTestRepoWithCommit(t, fixture.Service, fixture.RepoPath)
+	fixture.Service.workDir = fixture.RepoPath
+
+	initialCommit, err := fixture.Service.getCurrentCommit(ctx, fixture.RepoPath)
+	require.NoError(t, err)
+
+	taskID := "detached-task"
+	worktreeManager := NewWorktreeManager(fixture.Service, fixture.RepoPath)
+	worktreePath, err := worktreeManager.CreateWorktreeFromCommit(ctx, taskID, initialCommit)
+	require.NoError(t, err)
+	defer worktreeManager.CleanupAgentWorktrees(ctx, taskID)
+	branchName := GenerateTaskBranchName(taskID)
+
+	// Already on the branch: nothing to do
+	require.NoError(t, fixture.Service.EnsureWorktreeOnBranch(ctx, worktreePath, branchName))
+
+	// Detached at a commit ahead of the branch: branch is moved forward and checked out
+	require.NoError(t, exec.Command("git", "-C", worktreePath, "checkout", "--detach").Run())
+	require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "detached.txt"), []byte("work"), 0644))
+	require.NoError(t, fixture.Service.CreateCommit(ctx, worktreePath, "Commit on detached HEAD"))
+	detachedCommit, err := fixture.Service.getCurrentCommit(ctx, worktreePath)
+	require.NoError(t, err)
+
+	require.NoError(t, fixture.Service.EnsureWorktreeOnBranch(ctx, worktreePath, branchName))
+	branch, err := fixture.Service.GetWorktreeBranch(worktreePath)
+	require.NoError(t, err)
+	assert.Equal(t, branchName, branch)
+	head, err := fixture.Service.GetBranchHeadSHA(ctx, worktreePath, branchName)
+	require.NoError(t, err)
+	assert.Equal(t, detachedCommit, head)
+
+	// Detached at a commit the branch has moved past: refused rather than rewinding the branch
+	require.NoError(t, exec.Command("git", "-C", worktreePath, "checkout", initialCommit).Run())
+	err = fixture.Service.EnsureWorktreeOnBranch(ctx, worktreePath, branchName)
+	assert.ErrorContains(t, err, "diverged")
+
+	// On some other branch: refused
+	require.NoError(t, exec.Command("git", "-C", worktreePath, "checkout", "-b", "other").Run())
+	err = fixture.Service.EnsureWorktreeOnBranch(ctx, worktreePath, branchName)
+	assert.ErrorContains(t, err, "expected "+branchName)
+}
+
 func TestGitService_ExtractTaskIDFromPath(t *testing.T) {
 	tests := []struct {
 		name       string
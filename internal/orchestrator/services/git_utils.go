@@ -126,6 +126,54 @@ func ValidateWorktreePath(worktreePath string) error {
 	return nil
 }
 
+// CleanWorkingSubdir validates a repository-relative subdirectory for an agent to work in
+// and returns it in slash form, or "" for the repository root. The directory must exist
+// inside repoPath; absolute paths, traversal and symlinks leading outside are rejected.
+func CleanWorkingSubdir(repoPath, subdir string) (string, error) {
+	if subdir == "" {
+		return "", nil
+	}
+	if filepath.IsAbs(subdir) {
+		return "", fmt.Errorf("working subdirectory must be relative to the repository: %s", subdir)
+	}
+
+	cleaned := filepath.Clean(subdir)
+	if cleaned == "." {
+		return "", nil
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path traversal not allowed: %s", subdir)
+	}
+	if strings.HasPrefix(cleaned, "-") {
+		return "", fmt.Errorf("working subdirectory cannot start with '-': %s", subdir)
+	}
+	if first := strings.SplitN(filepath.ToSlash(cleaned), "/", 2)[0]; first == ".git" {
+		return "", fmt.Errorf("working subdirectory cannot be inside .git: %s", subdir)
+	}
+
+	root, err := filepath.EvalSymlinks(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve repository path: %w", err)
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Join(root, cleaned))
+	if err != nil {
+		return "", fmt.Errorf("working subdirectory does not exist: %s", subdir)
+	}
+	if rel, err := filepath.Rel(root, resolved); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("working subdirectory is outside the repository: %s", subdir)
+	}
+
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat working subdirectory: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("working subdirectory is not a directory: %s", subdir)
+	}
+
+	return filepath.ToSlash(cleaned), nil
+}
+
 // ExtractTaskIDFromWorktreePath attempts to extract a task ID from a worktree path
 // This assumes worktree paths follow a specific naming convention
 func ExtractTaskIDFromWorktreePath(worktreePath string) string {
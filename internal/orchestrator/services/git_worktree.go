@@ -156,6 +156,37 @@ func (wm *WorktreeManager) ListWorktrees(ctx context.Context) ([]WorktreeInfo, e
 	return wm.parseWorktreeList(string(output))
 }
 
+// ListWorktreesDetailed lists worktrees that belong to tasks, with TaskID and CreatedAt populated.
+// The main worktree and worktrees not following the task naming scheme are excluded.
+// CreatedAt is taken from the modification time of the worktree's .git file, which git
+// writes once when the worktree is added.
+func (wm *WorktreeManager) ListWorktreesDetailed(ctx context.Context) ([]WorktreeInfo, error) {
+	worktrees, err := wm.ListWorktrees(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var taskWorktrees []WorktreeInfo
+	for _, wt := range worktrees {
+		taskID := ExtractTaskIDFromPath(wt.Path)
+		if taskID == "" {
+			continue
+		}
+
+		info, err := os.Stat(filepath.Join(wt.Path, ".git"))
+		if err != nil || info.IsDir() {
+			// Missing directory (prunable) or the main worktree
+			continue
+		}
+
+		wt.TaskID = taskID
+		wt.CreatedAt = info.ModTime()
+		taskWorktrees = append(taskWorktrees, wt)
+	}
+
+	return taskWorktrees, nil
+}
+
 // PruneWorktrees removes stale worktree references
 func (wm *WorktreeManager) PruneWorktrees(ctx context.Context) error {
 	getWorktreeLog().Debug().Msgf("Pruning worktrees for repository: %s", wm.baseRepo)
@@ -350,9 +381,14 @@ func (wm *WorktreeManager) IsWorktreeValid(ctx context.Context, worktreePath str
 	return true
 }
 
-// CreateWorktreeFromCommit creates a worktree from a specific commit
+// CreateWorktreeFromCommit creates a worktree from a specific commit on the agent's task branch
 func (wm *WorktreeManager) CreateWorktreeFromCommit(ctx context.Context, agentID, commitID string) (string, error) {
-	getWorktreeLog().Debug().Msgf("Creating worktree for agent %s from commit %s", agentID, commitID)
+	return wm.CreateWorktreeOnBranch(ctx, agentID, commitID, GenerateTaskBranchName(agentID))
+}
+
+// CreateWorktreeOnBranch creates a worktree from a specific commit with branchName checked out
+func (wm *WorktreeManager) CreateWorktreeOnBranch(ctx context.Context, agentID, commitID, branchName string) (string, error) {
+	getWorktreeLog().Debug().Msgf("Creating worktree for agent %s from commit %s on branch %s", agentID, commitID, branchName)
 
 	// Validate inputs using GitService validation
 	if err := validateAgentID(agentID); err != nil {
@@ -385,9 +421,6 @@ func (wm *WorktreeManager) CreateWorktreeFromCommit(ctx context.Context, agentID
 		return worktreePath, nil
 	}
 
-	// Generate branch name using GitService method
-	branchName := GenerateTaskBranchName(agentID)
-
 	// Use GitService AddWorktree method for consistency
 	if err := wm.gitService.AddWorktree(ctx, worktreePath, branchName, commitID); err != nil {
 		return "", fmt.Errorf("failed to create worktree from commit: %w", err)
@@ -18,6 +18,7 @@ type TemporalClient interface {
 	GetWorkflowStatus(ctx context.Context, workflowID string) (temporal.WorkflowStatus, error)
 	SignalWorkflow(ctx context.Context, workflowID, signalName string, arg interface{}) error
 	QueryWorkflow(ctx context.Context, workflowID, queryType string, valuePtr interface{}, args ...interface{}) error
+	ListRunningWorkflows(ctx context.Context, workflowType string) ([]temporal.WorkflowExecution, error)
 	CancelWorkflow(ctx context.Context, workflowID string) error
 	GetTemporalClient() client.Client
 	GetTaskQueue() string
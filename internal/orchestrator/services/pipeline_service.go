@@ -10,6 +10,8 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,6 +33,9 @@ var (
 	ErrRunNotFound          = errors.New("source run not found")
 	ErrRunNotCompleted      = errors.New("source run is not completed")
 	ErrCannotPromotePromote = errors.New("cannot promote a promote run")
+
+	// ErrTaskCleanup wraps DeleteTask errors from cleaning up after a task that was deleted
+	ErrTaskCleanup = errors.New("task deleted, but cleanup failed")
 )
 
 func getPipelineLog() *zerolog.Logger {
@@ -89,6 +94,9 @@ type CreateTaskParams struct {
 	Description   string
 	BaseCommitSHA string
 	AgentConfig   *protocol.AgentConfigInput
+	TokenBudget   int      // Overrides the configured agent.token_budget when > 0
+	WorkingSubdir string   // Repository-relative directory to run the agent in (empty = repo root)
+	Labels        []string // Initial labels of the run
 }
 
 // StartPipelineParams groups input for StartPipeline.
@@ -101,12 +109,15 @@ type StartPipelineParams struct {
 	ForkAfterStepID string
 	NoAutoFork      bool
 	AutoPromote     bool
+	Labels          []string // Initial labels of the run
+	WorkingSubdir   string   // Repository-relative directory to run the agents in (empty = repo root)
 }
 
 // --- Public methods ---
 
 // CreateProject validates inputs, initialises a git service, and persists a new project.
-func (ps *PipelineService) CreateProject(ctx context.Context, name, description, repoPath string) (*models.Project, error) {
+// agentDefaults, if set, is applied to tasks of the project that omit their own agent config.
+func (ps *PipelineService) CreateProject(ctx context.Context, name, description, repoPath string, agentDefaults *protocol.AgentConfigInput) (*models.Project, error) {
 	if err := validateProjectInputs(name, description, repoPath); err != nil {
 		return nil, err
 	}
@@ -118,7 +129,7 @@ func (ps *PipelineService) CreateProject(ctx context.Context, name, description,
 	}
 	defer gitHandle.Release()
 
-	project, err := ps.data.CreateProject(ctx, name, description, repoPath)
+	project, err := ps.data.CreateProjectWithAgentConfig(ctx, name, description, repoPath, toProjectAgentConfig(agentDefaults))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create project: %w", err)
 	}
@@ -145,20 +156,120 @@ func (ps *PipelineService) ToggleTask(ctx context.Context, projectID, taskID str
 }
 
 // DeleteTask deletes a task by ID.
-func (ps *PipelineService) DeleteTask(ctx context.Context, projectID, taskID string) error {
+func (ps *PipelineService) DeleteTask(ctx context.Context, projectID, taskID string) (*TaskCleanupResult, error) {
+	// The branch name is gone with the task, so look it up first
+	branch := ps.taskBranch(ctx, taskID)
+
 	if err := ps.data.DeleteTask(ctx, taskID); err != nil {
-		return fmt.Errorf("failed to delete task: %w", err)
+		return nil, fmt.Errorf("failed to delete task: %w", err)
 	}
 	getPipelineLog().Info().Str("project_id", projectID).Str("task_id", taskID).Msg("Deleted task")
+
+	result, err := ps.cleanupDeletedTask(ctx, projectID, taskID, branch)
+	if err != nil {
+		return result, fmt.Errorf("%w: %w", ErrTaskCleanup, err)
+	}
+	return result, nil
+}
+
+// taskBranch returns the branch a task, or a pipeline run listed as one, works on; empty
+// when neither is found
+func (ps *PipelineService) taskBranch(ctx context.Context, taskID string) string {
+	if task, err := ps.data.GetTask(ctx, taskID); err == nil {
+		return task.BranchName
+	}
+	if run, err := ps.data.GetPipelineRun(ctx, taskID); err == nil && run != nil {
+		return run.BranchName
+	}
+	return ""
+}
+
+// cleanupDeletedTask removes the worktree and branch of a deleted task per
+// git.cleanup_on_delete. The result is nil when cleanup is disabled.
+func (ps *PipelineService) cleanupDeletedTask(ctx context.Context, projectID, taskID, branch string) (*TaskCleanupResult, error) {
+	opts := ps.config.Git.CleanupOnDelete
+	if !opts.Worktree && !opts.Branch {
+		return nil, nil
+	}
+
+	project, err := ps.data.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project %s: %w", projectID, err)
+	}
+
+	var result *TaskCleanupResult
+	err = ps.git.WithRepo(ctx, project.RepositoryPath, func(gs *GitService) error {
+		result, err = gs.CleanupTaskBranch(ctx, project.RepositoryPath, taskID, branch, opts)
+		return err
+	})
+	if result != nil {
+		getPipelineLog().Info().Str("task_id", taskID).Str("branch", result.Branch).
+			Bool("worktree_removed", result.WorktreeRemoved).Str("worktree_skipped", result.WorktreeSkipped).
+			Bool("branch_deleted", result.BranchDeleted).Str("branch_skipped", result.BranchSkipped).
+			Msg("Cleaned up after deleted task")
+	}
+	return result, err
+}
+
+// UpdateTaskLabels removes, then adds, labels on a task. Pipeline runs, which the TUI lists
+// as tasks under their run ID, are labelled the same way.
+func (ps *PipelineService) UpdateTaskLabels(ctx context.Context, projectID, taskID string, add, remove []string) error {
+	removeLabels, addLabels := ps.data.RemoveTaskLabels, ps.data.AddTaskLabels
+	if _, err := ps.data.GetTask(ctx, taskID); err != nil {
+		run, runErr := ps.data.GetPipelineRun(ctx, taskID)
+		if runErr != nil || run == nil {
+			return fmt.Errorf("failed to get task: %w", err)
+		}
+		removeLabels, addLabels = ps.data.RemovePipelineRunLabels, ps.data.AddPipelineRunLabels
+	}
+	if err := removeLabels(ctx, taskID, remove...); err != nil {
+		return fmt.Errorf("failed to remove labels: %w", err)
+	}
+	if err := addLabels(ctx, taskID, add...); err != nil {
+		return fmt.Errorf("failed to add labels: %w", err)
+	}
+	getPipelineLog().Info().Str("project_id", projectID).Str("task_id", taskID).
+		Strs("added", add).Strs("removed", remove).Msg("Task labels updated")
 	return nil
 }
 
+// UpdateTask edits a task's title and description. expectedVersion is the Version the
+// caller loaded; ErrStaleWrite is returned if the task changed since.
+func (ps *PipelineService) UpdateTask(ctx context.Context, projectID, taskID, title, description string, expectedVersion int) (*models.Task, error) {
+	task, err := ps.data.UpdateTask(ctx, projectID, taskID, title, description, expectedVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+	getPipelineLog().Info().Str("project_id", projectID).Str("task_id", taskID).
+		Int("version", task.Version).Msg("Task updated")
+	return task, nil
+}
+
+// UpdateProject edits a project's name and description, failing with ErrStaleWrite like
+// UpdateTask when the project is no longer at expectedVersion.
+func (ps *PipelineService) UpdateProject(ctx context.Context, projectID, name, description string, expectedVersion int) (*models.Project, error) {
+	project, err := ps.data.UpdateProject(ctx, projectID, name, description, expectedVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+	getPipelineLog().Info().Str("project_id", projectID).Int("version", project.Version).Msg("Project updated")
+	return project, nil
+}
+
 // CreateTask creates a single-step pipeline run (a "task" is just a 1-step pipeline).
 func (ps *PipelineService) CreateTask(ctx context.Context, params CreateTaskParams) (*PipelineRunResult, error) {
 	repoPath, err := ps.data.GetProjectRepositoryPath(ctx, params.ProjectID)
 	if err != nil {
 		return nil, fmt.Errorf("could not get repository path for project: %w", err)
 	}
+	project, err := ps.data.GetProject(ctx, params.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get project: %w", err)
+	}
+	subdir, err := CleanWorkingSubdir(repoPath, params.WorkingSubdir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid working subdirectory: %w", err)
+	}
 
 	baseCommitSHA := params.BaseCommitSHA
 	if baseCommitSHA == "" {
@@ -168,8 +279,8 @@ func (ps *PipelineService) CreateTask(ctx context.Context, params CreateTaskPara
 		}
 	}
 
-	// Build agent config for the single step
-	stepAgentConfig := ps.buildStepAgentConfig(params.AgentConfig, params.Title, params.Description)
+	// Build agent config for the single step; the task's own config overrides the project default
+	stepAgentConfig := ps.buildStepAgentConfig(ps.applyProjectAgentDefaults(project, params.AgentConfig, params.Title, params.Description), params.Title, params.Description)
 
 	step := models.StepDefinition{
 		StepID:      "main",
@@ -178,8 +289,15 @@ func (ps *PipelineService) CreateTask(ctx context.Context, params CreateTaskPara
 		AgentConfig: stepAgentConfig,
 	}
 	steps := []models.StepDefinition{step}
+	if err := ps.validateStepEnv(steps); err != nil {
+		return nil, err
+	}
 
 	runID := ComputeRunID(baseCommitSHA, workflows.PipelineWorkflowVersion, steps)
+	if subdir != "" {
+		// The same task scoped to another subdirectory is a different run
+		runID = scopeRunID(runID, subdir)
+	}
 	workflowID := fmt.Sprintf("%s-pipeline", runID)
 
 	// Check idempotency
@@ -188,6 +306,12 @@ func (ps *PipelineService) CreateTask(ctx context.Context, params CreateTaskPara
 	}
 
 	input := ps.buildWorkflowInput(runID, params.ProjectID, params.Title, steps, repoPath, baseCommitSHA, "", "", false)
+	if params.TokenBudget > 0 {
+		input.TokenBudget = params.TokenBudget
+	}
+	input.WorkingSubdir = subdir
+	input.CommitTemplate = ps.commitTemplate(project)
+	input.Labels = models.NormalizeLabels(params.Labels)
 
 	if _, err := ps.temporal.StartWorkflow(ctx, workflowID, workflows.PipelineWorkflowName, input); err != nil {
 		return nil, fmt.Errorf("failed to start pipeline workflow: %w", err)
@@ -211,6 +335,14 @@ func (ps *PipelineService) StartPipeline(ctx context.Context, params StartPipeli
 	if err != nil {
 		return nil, fmt.Errorf("could not get repository path for project: %w", err)
 	}
+	subdir, err := CleanWorkingSubdir(repoPath, params.WorkingSubdir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid working subdirectory: %w", err)
+	}
+	if subdir != "" {
+		// Runs found by step hash may have worked in another directory
+		params.NoAutoFork = true
+	}
 
 	baseCommitSHA := params.BaseCommitSHA
 	if baseCommitSHA == "" {
@@ -222,11 +354,17 @@ func (ps *PipelineService) StartPipeline(ctx context.Context, params StartPipeli
 
 	// Convert protocol steps to model steps
 	modelSteps := convertProtocolSteps(params.Steps)
+	if err := ps.validateStepEnv(modelSteps); err != nil {
+		return nil, err
+	}
 
 	// Determine fork parameters
 	forkFromRunID, forkAfterStepID, skippedSteps := ps.resolveForkParams(ctx, params, modelSteps, baseCommitSHA)
 
 	runID := ComputeRunID(baseCommitSHA, workflows.PipelineWorkflowVersion, modelSteps)
+	if subdir != "" {
+		runID = scopeRunID(runID, subdir)
+	}
 	workflowID := fmt.Sprintf("%s-pipeline", runID)
 
 	// Check idempotency
@@ -235,6 +373,11 @@ func (ps *PipelineService) StartPipeline(ctx context.Context, params StartPipeli
 	}
 
 	input := ps.buildWorkflowInput(runID, params.ProjectID, params.Name, modelSteps, repoPath, baseCommitSHA, forkFromRunID, forkAfterStepID, params.AutoPromote)
+	input.WorkingSubdir = subdir
+	if project, err := ps.data.GetProject(ctx, params.ProjectID); err == nil {
+		input.CommitTemplate = ps.commitTemplate(project)
+	}
+	input.Labels = models.NormalizeLabels(params.Labels)
 
 	if _, err := ps.temporal.StartWorkflow(ctx, workflowID, workflows.PipelineWorkflowName, input); err != nil {
 		return nil, fmt.Errorf("failed to start pipeline workflow: %w", err)
@@ -348,6 +491,7 @@ func (ps *PipelineService) buildStepAgentConfig(protocolCfg *protocol.AgentConfi
 			Variables:      protocolCfg.Variables,
 			ToolOptions:    protocolCfg.ToolOptions,
 			FlagFormat:     protocolCfg.FlagFormat,
+			Env:            protocolCfg.Env,
 		}
 	}
 	if ps.config.Agent.DefaultTool == "" {
@@ -374,6 +518,86 @@ func (ps *PipelineService) buildStepAgentConfig(protocolCfg *protocol.AgentConfi
 	}
 }
 
+// applyProjectAgentDefaults layers the task's agent config over the project default,
+// which in turn falls back to the application config for fields it leaves empty.
+// Without a project default the task config is returned unchanged.
+func (ps *PipelineService) applyProjectAgentDefaults(project *models.Project, taskCfg *protocol.AgentConfigInput, title, description string) *protocol.AgentConfigInput {
+	if project == nil || project.DefaultAgentConfig == nil || project.DefaultAgentConfig.IsZero() {
+		return taskCfg
+	}
+
+	merged := protocol.MergeAgentConfig(fromProjectAgentConfig(project.DefaultAgentConfig), taskCfg)
+	if appCfg := ps.buildStepAgentConfig(nil, title, description); appCfg != nil {
+		merged = protocol.MergeAgentConfig(&protocol.AgentConfigInput{
+			ToolName:       appCfg.ToolName,
+			ToolVersion:    appCfg.ToolVersion,
+			PromptTemplate: appCfg.PromptTemplate,
+			Variables:      appCfg.Variables,
+			ToolOptions:    appCfg.ToolOptions,
+			FlagFormat:     appCfg.FlagFormat,
+		}, merged)
+	}
+	return merged
+}
+
+// validateStepEnv checks the names of agent.default_env and of each step's own env, so a
+// bad or reserved variable fails at submission rather than in the container. The step env
+// is left as submitted: agent.default_env, whose values may be expanded secrets, is only
+// applied by the container's LocalExecuteActivity and never enters the run's inputs.
+func (ps *PipelineService) validateStepEnv(steps []models.StepDefinition) error {
+	if err := protocol.ValidateAgentEnv(ps.config.Agent.DefaultEnv); err != nil {
+		return fmt.Errorf("invalid agent.default_env: %w", err)
+	}
+	for _, step := range steps {
+		if step.AgentConfig == nil {
+			continue
+		}
+		if err := protocol.ValidateAgentEnv(step.AgentConfig.Env); err != nil {
+			return fmt.Errorf("invalid agent env for step %s: %w", step.StepID, err)
+		}
+	}
+	return nil
+}
+
+// commitTemplate returns the commit message template for a project's runs: the project's
+// own template, else git.commit_template (empty = the workflow's default message)
+func (ps *PipelineService) commitTemplate(project *models.Project) string {
+	if project != nil && project.CommitTemplate != "" {
+		return project.CommitTemplate
+	}
+	return ps.config.Git.CommitTemplate
+}
+
+func toProjectAgentConfig(cfg *protocol.AgentConfigInput) *models.ProjectAgentConfig {
+	if cfg == nil {
+		return nil
+	}
+	return &models.ProjectAgentConfig{
+		ToolName:       cfg.ToolName,
+		ToolVersion:    cfg.ToolVersion,
+		PromptTemplate: cfg.PromptTemplate,
+		Variables:      cfg.Variables,
+		ToolOptions:    cfg.ToolOptions,
+		FlagFormat:     cfg.FlagFormat,
+		Env:            cfg.Env,
+	}
+}
+
+func fromProjectAgentConfig(cfg *models.ProjectAgentConfig) *protocol.AgentConfigInput {
+	if cfg == nil {
+		return nil
+	}
+	return &protocol.AgentConfigInput{
+		ToolName:       cfg.ToolName,
+		ToolVersion:    cfg.ToolVersion,
+		PromptTemplate: cfg.PromptTemplate,
+		Variables:      cfg.Variables,
+		ToolOptions:    cfg.ToolOptions,
+		FlagFormat:     cfg.FlagFormat,
+		Env:            cfg.Env,
+	}
+}
+
 // checkIdempotency checks if a workflow already exists and returns a result if so.
 // Returns (result, true) if the caller should return early, or (nil, false) to continue.
 func (ps *PipelineService) checkIdempotency(ctx context.Context, workflowID, runID, projectID, name string) (*PipelineRunResult, bool) {
@@ -441,6 +665,13 @@ func (ps *PipelineService) buildWorkflowInput(
 		WorkspaceDir:          ps.config.Container.WorkspaceDir,
 		OrchestratorTaskQueue: ps.config.Temporal.TaskQueue,
 		AutoPromote:           autoPromote,
+		TokenBudget:           ps.config.Agent.TokenBudget,
+		HardBudget:            ps.config.Agent.HardBudget,
+		IdleTimeout:           ps.config.Agent.IdleTimeout,
+		FinalizeOnIdle:        ps.config.Agent.FinalizeOnIdle,
+		CommitTemplate:        ps.config.Git.CommitTemplate,
+		DiffStreamInterval:    ps.config.Git.DiffStreamInterval,
+		DiffStreamMaxBytes:    ps.config.Git.DiffStreamMaxBytes,
 	}
 	if autoPromote {
 		input.MainBranch = ps.mainBranch()
@@ -459,6 +690,8 @@ func convertProtocolSteps(steps []protocol.StepInput) []models.StepDefinition {
 				PromptTemplate: step.AgentConfig.PromptTemplate,
 				Variables:      step.AgentConfig.Variables,
 				ToolOptions:    step.AgentConfig.ToolOptions,
+				FlagFormat:     step.AgentConfig.FlagFormat,
+				Env:            step.AgentConfig.Env,
 			}
 		}
 		modelSteps[i] = models.StepDefinition{
@@ -622,6 +855,14 @@ func ComputeRunID(baseCommitSHA, workflowVersion string, steps []models.StepDefi
 	return hex.EncodeToString(h.Sum(nil))[:16]
 }
 
+// scopeRunID derives the run ID of a task confined to a repository subdirectory
+func scopeRunID(runID, subdir string) string {
+	h := sha256.New()
+	h.Write([]byte(runID))
+	h.Write([]byte(subdir))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
 // --- Promote / Merge Queue methods ---
 
 // PromotePipelineParams groups input for PromotePipeline.
@@ -699,6 +940,65 @@ func (ps *PipelineService) GetMergeQueueState(ctx context.Context, projectID str
 	return &state, nil
 }
 
+// ListRunningTasks enumerates running ProcessTask and pipeline workflows and maps them
+// to tasks. Step lookups are best-effort; a workflow whose step cannot be determined is
+// still listed with an empty Step.
+func (ps *PipelineService) ListRunningTasks(ctx context.Context) ([]protocol.RunningTaskInfo, error) {
+	processTasks, err := ps.temporal.ListRunningWorkflows(ctx, workflows.ProcessTaskWorkflowName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list running task workflows: %w", err)
+	}
+	pipelines, err := ps.temporal.ListRunningWorkflows(ctx, workflows.PipelineWorkflowName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list running pipeline workflows: %w", err)
+	}
+
+	tasks := make([]protocol.RunningTaskInfo, 0, len(processTasks)+len(pipelines))
+	for _, execution := range processTasks {
+		info := protocol.RunningTaskInfo{
+			TaskID:     strings.TrimPrefix(execution.WorkflowID, workflows.ProcessTaskWorkflowIDPrefix),
+			WorkflowID: execution.WorkflowID,
+			StartedAt:  execution.StartTime,
+		}
+
+		var metadata types.ProcessingMetadata
+		if err := ps.temporal.QueryWorkflow(ctx, execution.WorkflowID, workflows.ProcessingMetadataQuery, &metadata); err == nil {
+			info.Step = metadata.Step
+		} else {
+			getPipelineLog().Debug().Err(err).Str("workflow_id", execution.WorkflowID).Msg("Could not query processing step")
+		}
+
+		if task, err := ps.data.GetTask(ctx, info.TaskID); err == nil && task != nil {
+			info.ProjectID = task.ProjectID
+		}
+		tasks = append(tasks, info)
+	}
+
+	for _, execution := range pipelines {
+		info := protocol.RunningTaskInfo{
+			TaskID:     strings.TrimSuffix(execution.WorkflowID, "-pipeline"),
+			WorkflowID: execution.WorkflowID,
+			StartedAt:  execution.StartTime,
+		}
+
+		if run, err := ps.data.GetPipelineRun(ctx, info.TaskID); err == nil && run != nil {
+			info.ProjectID = run.ProjectID
+			for _, step := range run.StepResults {
+				if step.Status == models.StepStatusRunning {
+					info.Step = step.StepID
+					break
+				}
+			}
+		}
+		tasks = append(tasks, info)
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].StartedAt.Before(tasks[j].StartedAt)
+	})
+	return tasks, nil
+}
+
 // shouldApplyPromptComposition determines if prompt prefix/suffix should be applied.
 // Returns true for AI tools (claude, etc.), false for raw command tools (test).
 func shouldApplyPromptComposition(steps []models.StepDefinition) bool {
@@ -0,0 +1,120 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package services
+
+import (
+	"testing"
+
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/protocol"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyProjectAgentDefaults(t *testing.T) {
+	ps := &PipelineService{config: &config.AppConfig{
+		Agent: config.AgentConfig{
+			DefaultTool:    "claude",
+			DefaultVersion: "4.5",
+			PromptTemplate: "Task: {{.title}}",
+			Variables:      map[string]string{"title": "", "description": ""},
+			ToolOptions:    map[string]interface{}{"model": "claude-sonnet-4-5"},
+			FlagFormat:     "space",
+		},
+	}}
+
+	projectWithDefault := &models.Project{
+		ID: "project-1",
+		DefaultAgentConfig: &models.ProjectAgentConfig{
+			ToolOptions: map[string]interface{}{"model": "claude-opus-4", "max-turns": 5},
+			FlagFormat:  "equals",
+		},
+	}
+
+	t.Run("no project default keeps task config unchanged", func(t *testing.T) {
+		taskCfg := &protocol.AgentConfigInput{ToolName: "test", PromptTemplate: "echo"}
+		assert.Same(t, taskCfg, ps.applyProjectAgentDefaults(&models.Project{ID: "project-1"}, taskCfg, "Title", "Desc"))
+		assert.Nil(t, ps.applyProjectAgentDefaults(&models.Project{ID: "project-1"}, nil, "Title", "Desc"))
+	})
+
+	t.Run("project default fills a task without config", func(t *testing.T) {
+		got := ps.applyProjectAgentDefaults(projectWithDefault, nil, "Title", "Desc")
+		assert.Equal(t, &protocol.AgentConfigInput{
+			ToolName:       "claude",
+			ToolVersion:    "4.5",
+			PromptTemplate: "Task: {{.title}}",
+			Variables:      map[string]string{"title": "Title", "description": "Desc"},
+			ToolOptions:    map[string]interface{}{"model": "claude-opus-4", "max-turns": 5},
+			FlagFormat:     "equals",
+		}, got)
+	})
+
+	t.Run("task config overrides project default field by field", func(t *testing.T) {
+		taskCfg := &protocol.AgentConfigInput{
+			PromptTemplate: "Fix: {{.title}}",
+			ToolOptions:    map[string]interface{}{"max-turns": 20},
+		}
+		got := ps.applyProjectAgentDefaults(projectWithDefault, taskCfg, "Title", "Desc")
+		assert.Equal(t, "claude", got.ToolName)
+		assert.Equal(t, "Fix: {{.title}}", got.PromptTemplate)
+		assert.Equal(t, "equals", got.FlagFormat)
+		assert.Equal(t, map[string]interface{}{"model": "claude-opus-4", "max-turns": 20}, got.ToolOptions)
+	})
+}
+
+func TestCommitTemplate_ProjectOverridesConfig(t *testing.T) {
+	ps := &PipelineService{config: &config.AppConfig{
+		Git: config.GitConfig{CommitTemplate: "{{.TaskTitle}}"},
+	}}
+
+	assert.Equal(t, "{{.TaskTitle}}", ps.commitTemplate(nil))
+	assert.Equal(t, "{{.TaskTitle}}", ps.commitTemplate(&models.Project{ID: "project-1"}))
+	assert.Equal(t, "[{{.TaskID}}] {{.TaskTitle}}", ps.commitTemplate(&models.Project{
+		ID:             "project-1",
+		CommitTemplate: "[{{.TaskID}}] {{.TaskTitle}}",
+	}))
+}
+
+func TestConvertProtocolSteps_KeepsFlagFormat(t *testing.T) {
+	steps := convertProtocolSteps([]protocol.StepInput{{
+		StepID:      "main",
+		Name:        "Implement",
+		AgentConfig: &protocol.AgentConfigInput{ToolName: "claude", FlagFormat: "equals"},
+	}})
+
+	require.Len(t, steps, 1)
+	assert.Equal(t, "equals", steps[0].AgentConfig.FlagFormat)
+}
+
+func TestValidateStepEnv(t *testing.T) {
+	ps := &PipelineService{config: &config.AppConfig{
+		Agent: config.AgentConfig{DefaultEnv: map[string]string{"ANTHROPIC_API_KEY": "sk-default", "AGENT_MODE": "ci"}},
+	}}
+
+	t.Run("step env is kept as submitted", func(t *testing.T) {
+		steps := []models.StepDefinition{
+			{StepID: "main", AgentConfig: &models.StepAgentConfig{ToolName: "claude", Env: map[string]string{"AGENT_MODE": "debug"}}},
+			{StepID: "noop"},
+		}
+		require.NoError(t, ps.validateStepEnv(steps))
+		assert.Equal(t, map[string]string{"AGENT_MODE": "debug"}, steps[0].AgentConfig.Env, "default env values stay out of the step")
+		assert.Nil(t, steps[1].AgentConfig)
+	})
+
+	t.Run("reserved names are rejected", func(t *testing.T) {
+		steps := []models.StepDefinition{
+			{StepID: "main", AgentConfig: &models.StepAgentConfig{ToolName: "claude", Env: map[string]string{"PATH": "/tmp"}}},
+		}
+		assert.ErrorContains(t, ps.validateStepEnv(steps), "step main")
+	})
+
+	t.Run("reserved default env names are rejected", func(t *testing.T) {
+		bad := &PipelineService{config: &config.AppConfig{
+			Agent: config.AgentConfig{DefaultEnv: map[string]string{"LD_PRELOAD": "/tmp/x.so"}},
+		}}
+		assert.ErrorContains(t, bad.validateStepEnv(nil), "agent.default_env")
+	})
+}
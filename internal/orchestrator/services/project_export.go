@@ -0,0 +1,217 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/protocol"
+)
+
+// ProjectExportVersion is the format version written by ExportProject
+const ProjectExportVersion = 1
+
+// ProjectExport is a portable description of a project: its metadata, default agent
+// configuration and pipeline definitions, without repository contents or task history
+type ProjectExport struct {
+	Version            int                        `json:"version"`
+	ExportedAt         time.Time                  `json:"exported_at"`
+	Name               string                     `json:"name"`
+	Description        string                     `json:"description,omitempty"`
+	RepositoryPath     string                     `json:"repository_path,omitempty"`
+	CommitTemplate     string                     `json:"commit_template,omitempty"`
+	DefaultAgentConfig *models.ProjectAgentConfig `json:"default_agent_config,omitempty"`
+	Pipelines          []ExportedPipeline         `json:"pipelines,omitempty"`
+
+	// OmittedSecrets lists the env vars left out of the export because they look like
+	// secrets; they have to be set again after importing
+	OmittedSecrets []string `json:"omitted_secrets,omitempty"`
+}
+
+// ExportedPipeline is a pipeline definition without its IDs
+type ExportedPipeline struct {
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description,omitempty"`
+	Steps        models.StepDefinitions `json:"steps"`
+	PromptPrefix string                 `json:"prompt_prefix,omitempty"`
+	PromptSuffix string                 `json:"prompt_suffix,omitempty"`
+}
+
+// ExportProject builds a ProjectExport for projectID. Env vars that look like secrets are
+// dropped from the agent configurations and listed in OmittedSecrets.
+func (ds *DataService) ExportProject(ctx context.Context, projectID string) (*ProjectExport, error) {
+	project, err := ds.db.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project: %w", err)
+	}
+	if project == nil {
+		return nil, fmt.Errorf("project %s not found", projectID)
+	}
+
+	pipelines, err := ds.db.GetPipelinesByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pipelines: %w", err)
+	}
+
+	export := &ProjectExport{
+		Version:        ProjectExportVersion,
+		ExportedAt:     time.Now().UTC(),
+		Name:           project.Name,
+		Description:    project.Description,
+		RepositoryPath: project.RepositoryPath,
+		CommitTemplate: project.CommitTemplate,
+	}
+	omitted := map[string]bool{}
+
+	if project.DefaultAgentConfig != nil && !project.DefaultAgentConfig.IsZero() {
+		agentConfig := *project.DefaultAgentConfig
+		agentConfig.Env = withoutSecrets(agentConfig.Env, omitted)
+		export.DefaultAgentConfig = &agentConfig
+	}
+
+	for _, pipeline := range pipelines {
+		steps := make(models.StepDefinitions, len(pipeline.Steps))
+		for i, step := range pipeline.Steps {
+			if step.AgentConfig != nil {
+				agentConfig := *step.AgentConfig
+				agentConfig.Env = withoutSecrets(agentConfig.Env, omitted)
+				step.AgentConfig = &agentConfig
+			}
+			steps[i] = step
+		}
+		export.Pipelines = append(export.Pipelines, ExportedPipeline{
+			Name:         pipeline.Name,
+			Description:  pipeline.Description,
+			Steps:        steps,
+			PromptPrefix: pipeline.PromptPrefix,
+			PromptSuffix: pipeline.PromptSuffix,
+		})
+	}
+
+	for name := range omitted {
+		export.OmittedSecrets = append(export.OmittedSecrets, name)
+	}
+	sort.Strings(export.OmittedSecrets)
+
+	return export, nil
+}
+
+// ImportProject recreates an exported project under a new ID. repositoryPath overrides the
+// exported path, which may not exist on this machine; either way it must be a directory.
+func (ds *DataService) ImportProject(ctx context.Context, export *ProjectExport, repositoryPath string) (*models.Project, error) {
+	if err := ValidateProjectExport(export); err != nil {
+		return nil, err
+	}
+
+	if repositoryPath == "" {
+		repositoryPath = export.RepositoryPath
+	}
+	if repositoryPath == "" {
+		return nil, fmt.Errorf("export has no repository path; pass one explicitly")
+	}
+	info, err := os.Stat(repositoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("repository path %s: %w", repositoryPath, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("repository path %s is not a directory", repositoryPath)
+	}
+
+	project := &models.Project{
+		ID:             fmt.Sprintf("project-%d", time.Now().UnixNano()),
+		Name:           export.Name,
+		Description:    export.Description,
+		RepositoryPath: repositoryPath,
+		CommitTemplate: export.CommitTemplate,
+	}
+	if export.DefaultAgentConfig != nil && !export.DefaultAgentConfig.IsZero() {
+		project.DefaultAgentConfig = export.DefaultAgentConfig
+	}
+
+	pipelines := make([]*models.Pipeline, 0, len(export.Pipelines))
+	for i, exported := range export.Pipelines {
+		pipelines = append(pipelines, &models.Pipeline{
+			ID:           fmt.Sprintf("%s-pipeline-%d", project.ID, i+1),
+			Name:         exported.Name,
+			Description:  exported.Description,
+			ProjectID:    project.ID,
+			Steps:        exported.Steps,
+			PromptPrefix: exported.PromptPrefix,
+			PromptSuffix: exported.PromptSuffix,
+		})
+	}
+	if err := ds.db.CreateProjectWithPipelines(ctx, project, pipelines); err != nil {
+		return nil, err
+	}
+
+	getDataLog().Info().
+		Str("projectID", project.ID).
+		Str("name", project.Name).
+		Int("pipelines", len(export.Pipelines)).
+		Msg("Imported project")
+	return project, nil
+}
+
+// ValidateProjectExport checks an export before it is imported
+func ValidateProjectExport(export *ProjectExport) error {
+	if export == nil {
+		return fmt.Errorf("export is empty")
+	}
+	if export.Version < 1 || export.Version > ProjectExportVersion {
+		return fmt.Errorf("unsupported project export version %d (this build supports up to %d)", export.Version, ProjectExportVersion)
+	}
+	if export.Name == "" {
+		return fmt.Errorf("project export has no name")
+	}
+	if export.DefaultAgentConfig != nil {
+		if err := protocol.ValidateAgentEnv(export.DefaultAgentConfig.Env); err != nil {
+			return fmt.Errorf("default agent config: %w", err)
+		}
+	}
+	for _, pipeline := range export.Pipelines {
+		if pipeline.Name == "" {
+			return fmt.Errorf("pipeline has no name")
+		}
+		seen := make(map[string]bool, len(pipeline.Steps))
+		for _, step := range pipeline.Steps {
+			if step.StepID == "" {
+				return fmt.Errorf("pipeline %q has a step without an ID", pipeline.Name)
+			}
+			if seen[step.StepID] {
+				return fmt.Errorf("pipeline %q has duplicate step ID %q", pipeline.Name, step.StepID)
+			}
+			seen[step.StepID] = true
+			if step.AgentConfig != nil {
+				if err := protocol.ValidateAgentEnv(step.AgentConfig.Env); err != nil {
+					return fmt.Errorf("pipeline %q step %s: %w", pipeline.Name, step.StepID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// withoutSecrets returns env without secret-looking vars, recording dropped names in omitted
+func withoutSecrets(env map[string]string, omitted map[string]bool) map[string]string {
+	if len(env) == 0 {
+		return nil
+	}
+	kept := make(map[string]string, len(env))
+	for name, value := range env {
+		if protocol.IsSecretEnv(name, value) {
+			omitted[name] = true
+			continue
+		}
+		kept[name] = value
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
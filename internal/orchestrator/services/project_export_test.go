@@ -0,0 +1,115 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataService_ExportImportProject(t *testing.T) {
+	ds := WithDataService(t).Service
+	ctx := context.Background()
+
+	source := &models.Project{
+		ID:             "export-source",
+		Name:           "Exported",
+		Description:    "Project to move",
+		RepositoryPath: "/machine-a/repo",
+		CommitTemplate: "{{.StepName}}",
+		DefaultAgentConfig: &models.ProjectAgentConfig{
+			ToolName: "claude",
+			Env:      map[string]string{"AGENT_MODE": "ci", "ANTHROPIC_API_KEY": "sk-ant-123"},
+		},
+	}
+	require.NoError(t, ds.InsertProject(ctx, source))
+	require.NoError(t, ds.CreatePipeline(ctx, &models.Pipeline{
+		ID:        "export-pipeline",
+		Name:      "Review",
+		ProjectID: source.ID,
+		Steps: models.StepDefinitions{{
+			StepID:      "1a",
+			Name:        "Review",
+			AgentConfig: &models.StepAgentConfig{ToolName: "claude", Env: map[string]string{"GITHUB_TOKEN": "ghp_abc"}},
+		}},
+		PromptPrefix: "Be brief.",
+	}))
+
+	export, err := ds.ExportProject(ctx, source.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ProjectExportVersion, export.Version)
+	assert.Equal(t, map[string]string{"AGENT_MODE": "ci"}, export.DefaultAgentConfig.Env)
+	assert.Equal(t, []string{"ANTHROPIC_API_KEY", "GITHUB_TOKEN"}, export.OmittedSecrets)
+	require.Len(t, export.Pipelines, 1)
+	assert.Nil(t, export.Pipelines[0].Steps[0].AgentConfig.Env)
+
+	data, err := json.Marshal(export)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "sk-ant-123")
+	assert.NotContains(t, string(data), "ghp_abc")
+
+	// The exported repository path doesn't exist here
+	_, err = ds.ImportProject(ctx, export, "")
+	assert.Error(t, err)
+
+	repoPath := t.TempDir()
+	imported, err := ds.ImportProject(ctx, export, repoPath)
+	require.NoError(t, err)
+	assert.NotEqual(t, source.ID, imported.ID)
+	assert.Equal(t, repoPath, imported.RepositoryPath)
+
+	stored, err := ds.GetProject(ctx, imported.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Exported", stored.Name)
+	assert.Equal(t, "{{.StepName}}", stored.CommitTemplate)
+	assert.Equal(t, "claude", stored.DefaultAgentConfig.ToolName)
+
+	pipelines, err := ds.GetPipelinesByProject(ctx, imported.ID)
+	require.NoError(t, err)
+	require.Len(t, pipelines, 1)
+	assert.Equal(t, "Be brief.", pipelines[0].PromptPrefix)
+	assert.Equal(t, "1a", pipelines[0].Steps[0].StepID)
+}
+
+func TestValidateProjectExport(t *testing.T) {
+	valid := func() *ProjectExport {
+		return &ProjectExport{
+			Version: ProjectExportVersion,
+			Name:    "project",
+			Pipelines: []ExportedPipeline{{
+				Name:  "pipeline",
+				Steps: models.StepDefinitions{{StepID: "1a"}, {StepID: "1b"}},
+			}},
+		}
+	}
+	require.NoError(t, ValidateProjectExport(valid()))
+
+	tests := []struct {
+		name   string
+		modify func(*ProjectExport)
+	}{
+		{"future version", func(e *ProjectExport) { e.Version = ProjectExportVersion + 1 }},
+		{"missing version", func(e *ProjectExport) { e.Version = 0 }},
+		{"missing name", func(e *ProjectExport) { e.Name = "" }},
+		{"reserved env", func(e *ProjectExport) {
+			e.DefaultAgentConfig = &models.ProjectAgentConfig{Env: map[string]string{"PATH": "/tmp"}}
+		}},
+		{"unnamed pipeline", func(e *ProjectExport) { e.Pipelines[0].Name = "" }},
+		{"duplicate step", func(e *ProjectExport) { e.Pipelines[0].Steps[1].StepID = "1a" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			export := valid()
+			tt.modify(export)
+			assert.Error(t, ValidateProjectExport(export))
+		})
+	}
+	assert.Error(t, ValidateProjectExport(nil))
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+)
+
+// RunTotals aggregates token usage and diff statistics across a run's steps
+type RunTotals struct {
+	InputTokens  int
+	OutputTokens int
+	FilesChanged int
+	Insertions   int
+	Deletions    int
+}
+
+// StepStatusChange describes a step whose status differs between two runs.
+// InA/InB are false when the step did not run in that run at all.
+type StepStatusChange struct {
+	StepID  string
+	StatusA models.StepStatus
+	StatusB models.StepStatus
+	InA     bool
+	InB     bool
+}
+
+// RunComparison is the read-only comparison of run B against run A
+type RunComparison struct {
+	RunA    *models.PipelineRun
+	RunB    *models.PipelineRun
+	TotalsA RunTotals
+	TotalsB RunTotals
+
+	// StepChanges lists steps whose status differs, in run A's step order followed by steps only in B
+	StepChanges []StepStatusChange
+
+	// BranchDiff is the diff between the runs' head commits, empty when either has none
+	BranchDiff string
+}
+
+// TokenDelta returns the change in total (input+output) tokens from A to B
+func (c *RunComparison) TokenDelta() int {
+	return (c.TotalsB.InputTokens + c.TotalsB.OutputTokens) - (c.TotalsA.InputTokens + c.TotalsA.OutputTokens)
+}
+
+// FilesChangedDelta returns the change in files changed from A to B
+func (c *RunComparison) FilesChangedDelta() int {
+	return c.TotalsB.FilesChanged - c.TotalsA.FilesChanged
+}
+
+// SumRunTotals adds up the step results of run
+func SumRunTotals(run *models.PipelineRun) RunTotals {
+	var totals RunTotals
+	for _, step := range run.StepResults {
+		totals.InputTokens += step.InputTokens
+		totals.OutputTokens += step.OutputTokens
+		totals.FilesChanged += step.FilesChanged
+		totals.Insertions += step.Insertions
+		totals.Deletions += step.Deletions
+	}
+	return totals
+}
+
+// SameTask reports whether two runs are runs of the same task: runs of the same project
+// under the same name, as a retry is, or one run forked from or promoting the other
+func SameTask(runA, runB *models.PipelineRun) bool {
+	if runA.ProjectID != runB.ProjectID {
+		return false
+	}
+	if runA.Name == runB.Name {
+		return true
+	}
+	derived := func(run, from *models.PipelineRun) bool {
+		return run.ParentRunID == from.ID || run.SourceRunID == from.ID
+	}
+	return derived(runA, runB) || derived(runB, runA)
+}
+
+// CompareRuns builds the comparison of two runs from their loaded step results.
+// It does not touch git; BranchDiff is left empty.
+func CompareRuns(runA, runB *models.PipelineRun) *RunComparison {
+	comparison := &RunComparison{
+		RunA:    runA,
+		RunB:    runB,
+		TotalsA: SumRunTotals(runA),
+		TotalsB: SumRunTotals(runB),
+	}
+
+	statusB := make(map[string]models.StepStatus, len(runB.StepResults))
+	for _, step := range runB.StepResults {
+		statusB[step.StepID] = step.Status
+	}
+
+	seen := make(map[string]bool, len(runA.StepResults))
+	for _, step := range runA.StepResults {
+		seen[step.StepID] = true
+		status, inB := statusB[step.StepID]
+		if inB && status == step.Status {
+			continue
+		}
+		comparison.StepChanges = append(comparison.StepChanges, StepStatusChange{
+			StepID:  step.StepID,
+			StatusA: step.Status,
+			StatusB: status,
+			InA:     true,
+			InB:     inB,
+		})
+	}
+	for _, step := range runB.StepResults {
+		if seen[step.StepID] {
+			continue
+		}
+		comparison.StepChanges = append(comparison.StepChanges, StepStatusChange{
+			StepID:  step.StepID,
+			StatusB: step.Status,
+			InB:     true,
+		})
+	}
+
+	return comparison
+}
+
+// CompareRuns loads two runs of the same task and compares them. With withDiff set the
+// comparison includes the diff between their head commits in the project's repository,
+// read through git.
+func (ds *DataService) CompareRuns(ctx context.Context, git *GitServiceManager, runAID, runBID string, withDiff bool) (*RunComparison, error) {
+	runA, err := ds.GetPipelineRun(ctx, runAID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run %s: %w", runAID, err)
+	}
+	if runA == nil {
+		return nil, fmt.Errorf("run not found: %s", runAID)
+	}
+	runB, err := ds.GetPipelineRun(ctx, runBID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run %s: %w", runBID, err)
+	}
+	if runB == nil {
+		return nil, fmt.Errorf("run not found: %s", runBID)
+	}
+	if !SameTask(runA, runB) {
+		return nil, fmt.Errorf("runs belong to different tasks: %q and %q", runA.Name, runB.Name)
+	}
+
+	comparison := CompareRuns(runA, runB)
+
+	if !withDiff || runA.HeadCommitSHA == "" || runB.HeadCommitSHA == "" || runA.HeadCommitSHA == runB.HeadCommitSHA {
+		return comparison, nil
+	}
+
+	project, err := ds.GetProject(ctx, runA.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return nil, fmt.Errorf("project not found: %s", runA.ProjectID)
+	}
+
+	handle, err := git.GetService(project.RepositoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+	defer handle.Release()
+
+	err = handle.WithReadLock(ctx, func(gs *GitService) error {
+		diff, err := gs.GetDiffBetween(ctx, project.RepositoryPath, runA.HeadCommitSHA, runB.HeadCommitSHA)
+		if err != nil {
+			return err
+		}
+		comparison.BranchDiff = diff
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff head commits: %w", err)
+	}
+
+	return comparison, nil
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package services
+
+import (
+	"testing"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareRuns(t *testing.T) {
+	runA := &models.PipelineRun{
+		ID: "run-a",
+		StepResults: []models.StepResult{
+			{StepID: "plan", Status: models.StepStatusCompleted, InputTokens: 100, OutputTokens: 50, FilesChanged: 1},
+			{StepID: "implement", Status: models.StepStatusFailed, InputTokens: 300, OutputTokens: 100, FilesChanged: 2},
+			{StepID: "lint", Status: models.StepStatusCompleted},
+		},
+	}
+	runB := &models.PipelineRun{
+		ID: "run-b",
+		StepResults: []models.StepResult{
+			{StepID: "plan", Status: models.StepStatusCompleted, InputTokens: 80, OutputTokens: 40, FilesChanged: 1},
+			{StepID: "implement", Status: models.StepStatusCompleted, InputTokens: 500, OutputTokens: 200, FilesChanged: 4},
+			{StepID: "test", Status: models.StepStatusCompleted},
+		},
+	}
+
+	c := CompareRuns(runA, runB)
+
+	assert.Equal(t, 550, c.TotalsA.InputTokens+c.TotalsA.OutputTokens)
+	assert.Equal(t, 270, c.TokenDelta())
+	assert.Equal(t, 2, c.FilesChangedDelta())
+	assert.Equal(t, []StepStatusChange{
+		{StepID: "implement", StatusA: models.StepStatusFailed, StatusB: models.StepStatusCompleted, InA: true, InB: true},
+		{StepID: "lint", StatusA: models.StepStatusCompleted, InA: true},
+		{StepID: "test", StatusB: models.StepStatusCompleted, InB: true},
+	}, c.StepChanges)
+	assert.Empty(t, c.BranchDiff)
+}
+
+func TestCompareRuns_Identical(t *testing.T) {
+	run := &models.PipelineRun{StepResults: []models.StepResult{{StepID: "only", Status: models.StepStatusCompleted, InputTokens: 10}}}
+
+	c := CompareRuns(run, run)
+
+	assert.Zero(t, c.TokenDelta())
+	assert.Zero(t, c.FilesChangedDelta())
+	assert.Empty(t, c.StepChanges)
+}
+
+func TestSameTask(t *testing.T) {
+	tests := []struct {
+		name string
+		runA *models.PipelineRun
+		runB *models.PipelineRun
+		want bool
+	}{
+		{
+			name: "retry under the same name",
+			runA: &models.PipelineRun{ID: "a", ProjectID: "p", Name: "Fix login"},
+			runB: &models.PipelineRun{ID: "b", ProjectID: "p", Name: "Fix login"},
+			want: true,
+		},
+		{
+			name: "fork of the other run",
+			runA: &models.PipelineRun{ID: "a", ProjectID: "p", Name: "Fix login"},
+			runB: &models.PipelineRun{ID: "b", ProjectID: "p", Name: "Fix login (fork)", ParentRunID: "a"},
+			want: true,
+		},
+		{
+			name: "promote of the other run",
+			runA: &models.PipelineRun{ID: "a", ProjectID: "p", Name: "Promote", SourceRunID: "b"},
+			runB: &models.PipelineRun{ID: "b", ProjectID: "p", Name: "Fix login"},
+			want: true,
+		},
+		{
+			name: "different tasks",
+			runA: &models.PipelineRun{ID: "a", ProjectID: "p", Name: "Fix login"},
+			runB: &models.PipelineRun{ID: "b", ProjectID: "p", Name: "Add logout"},
+			want: false,
+		},
+		{
+			name: "same name in another project",
+			runA: &models.PipelineRun{ID: "a", ProjectID: "p", Name: "Fix login"},
+			runB: &models.PipelineRun{ID: "b", ProjectID: "q", Name: "Fix login"},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SameTask(tt.runA, tt.runB))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+)
+
+// ToolCallDuration is the latency of one tool call, from its tool_use to the matching tool_result
+type ToolCallDuration struct {
+	ToolUseID string
+	ToolName  string
+	StartedAt time.Time
+	Duration  time.Duration // Zero when the call has no result
+	Success   *bool         // From the tool_result; nil when the call has no result
+
+	// Completed is false when no tool_result was recorded: the tool is still running,
+	// or the session ended mid-call
+	Completed bool
+}
+
+// PairToolCalls joins tool_use records with their tool_result records on ToolUseID and
+// returns one entry per tool call in start order. Records without a ToolUseID, and results
+// whose tool_use was never recorded, are ignored.
+func PairToolCalls(records []*models.AIActivityRecord) []ToolCallDuration {
+	sorted := make([]*models.AIActivityRecord, 0, len(records))
+	for _, r := range records {
+		if r != nil && r.ToolUseID != "" {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	var calls []ToolCallDuration
+	index := make(map[string]int)
+	for _, r := range sorted {
+		switch r.EventType {
+		case models.AIEventToolUse:
+			if _, seen := index[r.ToolUseID]; seen {
+				continue // Re-read transcript entry; keep the first
+			}
+			index[r.ToolUseID] = len(calls)
+			calls = append(calls, ToolCallDuration{
+				ToolUseID: r.ToolUseID,
+				ToolName:  r.ToolName,
+				StartedAt: r.Timestamp,
+			})
+
+		case models.AIEventToolResult:
+			i, ok := index[r.ToolUseID]
+			if !ok || calls[i].Completed {
+				continue
+			}
+			call := &calls[i]
+			call.Completed = true
+			call.Success = r.ToolSuccess
+			if d := r.Timestamp.Sub(call.StartedAt); d > 0 {
+				call.Duration = d
+			}
+			if call.ToolName == "" {
+				call.ToolName = r.ToolName
+			}
+		}
+	}
+	return calls
+}
+
+// GetToolCallDurations returns the latency of every tool call recorded for a task
+func (ds *DataService) GetToolCallDurations(ctx context.Context, taskID string) ([]ToolCallDuration, error) {
+	records, err := ds.db.GetAIActivityByTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AI activity: %w", err)
+	}
+	return PairToolCalls(records), nil
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+)
+
+func TestPairToolCalls(t *testing.T) {
+	base := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	ok, failed := true, false
+	records := []*models.AIActivityRecord{
+		// Results are recorded out of order relative to their calls
+		{EventID: "r2", EventType: models.AIEventToolResult, ToolUseID: "toolu_2", ToolSuccess: &failed, Timestamp: base.Add(5 * time.Second)},
+		{EventID: "u1", EventType: models.AIEventToolUse, ToolUseID: "toolu_1", ToolName: "Bash", Timestamp: base},
+		{EventID: "u2", EventType: models.AIEventToolUse, ToolUseID: "toolu_2", ToolName: "Read", Timestamp: base.Add(time.Second)},
+		{EventID: "r1", EventType: models.AIEventToolResult, ToolUseID: "toolu_1", ToolSuccess: &ok, Timestamp: base.Add(3 * time.Second)},
+		// Still running when the transcript was read
+		{EventID: "u3", EventType: models.AIEventToolUse, ToolUseID: "toolu_3", ToolName: "Bash", Timestamp: base.Add(6 * time.Second)},
+		// Result of a call that was never recorded, and a record without an id
+		{EventID: "r9", EventType: models.AIEventToolResult, ToolUseID: "toolu_9", Timestamp: base.Add(7 * time.Second)},
+		{EventID: "out", EventType: models.AIEventAIOutput, Timestamp: base.Add(8 * time.Second)},
+	}
+
+	calls := PairToolCalls(records)
+	require.Len(t, calls, 3)
+
+	assert.Equal(t, "toolu_1", calls[0].ToolUseID)
+	assert.Equal(t, "Bash", calls[0].ToolName)
+	assert.True(t, calls[0].Completed)
+	assert.Equal(t, 3*time.Second, calls[0].Duration)
+	require.NotNil(t, calls[0].Success)
+	assert.True(t, *calls[0].Success)
+
+	assert.Equal(t, "toolu_2", calls[1].ToolUseID)
+	assert.True(t, calls[1].Completed)
+	assert.Equal(t, 4*time.Second, calls[1].Duration)
+	require.NotNil(t, calls[1].Success)
+	assert.False(t, *calls[1].Success)
+
+	assert.Equal(t, "toolu_3", calls[2].ToolUseID)
+	assert.False(t, calls[2].Completed)
+	assert.Zero(t, calls[2].Duration)
+	assert.Nil(t, calls[2].Success)
+	assert.Equal(t, base.Add(6*time.Second), calls[2].StartedAt)
+}
+
+func TestPairToolCalls_Empty(t *testing.T) {
+	assert.Empty(t, PairToolCalls(nil))
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal"
+	"github.com/noldarim/noldarim/internal/protocol"
+)
+
+// taskSlotPollInterval is how often a running task's workflow is checked to see whether
+// it has finished and its slot can go to the next queued task
+var taskSlotPollInterval = 2 * time.Second
+
+// queuedTask is a CreateTaskCommand waiting for a free slot
+type queuedTask struct {
+	id  string
+	cmd protocol.CreateTaskCommand
+}
+
+// taskQueue caps the number of running tasks per project and holds the rest in FIFO order
+type taskQueue struct {
+	mu      sync.Mutex
+	running map[string]int           // Project ID -> tasks holding a slot
+	waiting map[string][]*queuedTask // Project ID -> tasks waiting for a slot, oldest first
+	nextID  int
+}
+
+func newTaskQueue() *taskQueue {
+	return &taskQueue{
+		running: make(map[string]int),
+		waiting: make(map[string][]*queuedTask),
+	}
+}
+
+// acquire takes a slot for cmd when fewer than limit tasks are running and none are
+// waiting ahead of it. Otherwise cmd is queued and its queue ID and 1-based position
+// are returned.
+func (q *taskQueue) acquire(projectID string, limit int, cmd protocol.CreateTaskCommand) (started bool, queueID string, position int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.running[projectID] < limit && len(q.waiting[projectID]) == 0 {
+		q.running[projectID]++
+		return true, "", 0
+	}
+
+	q.nextID++
+	task := &queuedTask{id: fmt.Sprintf("queued-%d", q.nextID), cmd: cmd}
+	q.waiting[projectID] = append(q.waiting[projectID], task)
+	return false, task.id, len(q.waiting[projectID])
+}
+
+// release frees a slot and hands free slots to queued tasks in order. It returns the
+// tasks that now hold a slot and must be started, and the tasks still waiting.
+func (q *taskQueue) release(projectID string, limit int) (start, waiting []*queuedTask) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.running[projectID] > 0 {
+		q.running[projectID]--
+	}
+	queue := q.waiting[projectID]
+	for len(queue) > 0 && q.running[projectID] < limit {
+		start = append(start, queue[0])
+		queue = queue[1:]
+		q.running[projectID]++
+	}
+	q.setWaiting(projectID, queue)
+	if q.running[projectID] == 0 {
+		delete(q.running, projectID)
+	}
+	return start, queue
+}
+
+// cancel removes a queued task. It reports false when the task is not queued, because
+// it has already started or the ID is unknown.
+func (q *taskQueue) cancel(projectID, queueID string) (removed bool, waiting []*queuedTask) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	queue := q.waiting[projectID]
+	for i, task := range queue {
+		if task.id == queueID {
+			queue = append(queue[:i:i], queue[i+1:]...)
+			q.setWaiting(projectID, queue)
+			return true, queue
+		}
+	}
+	return false, queue
+}
+
+func (q *taskQueue) setWaiting(projectID string, queue []*queuedTask) {
+	if len(queue) == 0 {
+		delete(q.waiting, projectID)
+		return
+	}
+	q.waiting[projectID] = queue
+}
+
+// maxConcurrentTasks returns the project's concurrent task limit, falling back to
+// agent.max_concurrent_tasks. Zero means unlimited.
+func (o *Orchestrator) maxConcurrentTasks(ctx context.Context, projectID string) int {
+	if project, err := o.dataService.GetProject(ctx, projectID); err == nil &&
+		project.DefaultAgentConfig != nil && project.DefaultAgentConfig.MaxConcurrentTasks > 0 {
+		return project.DefaultAgentConfig.MaxConcurrentTasks
+	}
+	return o.config.Agent.MaxConcurrentTasks
+}
+
+// watchTaskSlot waits for a task's workflow to stop running, then releases its slot.
+// Returns without releasing when ctx is cancelled, since the orchestrator is shutting down.
+func (o *Orchestrator) watchTaskSlot(ctx context.Context, projectID, workflowID string) {
+	ticker := time.NewTicker(taskSlotPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			status, err := o.temporalClient.GetWorkflowStatus(ctx, workflowID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				// An unknown workflow would otherwise hold the slot forever
+				getLog().Warn().Err(err).Str("workflow_id", workflowID).Msg("Could not check task workflow, releasing its slot")
+			} else if status == temporal.WorkflowStatusRunning {
+				continue
+			}
+			o.releaseTaskSlot(ctx, projectID)
+			return
+		}
+	}
+}
+
+// releaseTaskSlot frees a project slot and starts whichever queued tasks now fit
+func (o *Orchestrator) releaseTaskSlot(ctx context.Context, projectID string) {
+	start, waiting := o.taskQueue.release(projectID, o.maxConcurrentTasks(ctx, projectID))
+	if len(start) == 0 {
+		return
+	}
+	for _, task := range start {
+		getLog().Info().Str("project_id", projectID).Str("queue_id", task.id).Msg("Starting queued task")
+		o.sendEvent(protocol.TaskDequeuedEvent{Metadata: task.cmd.Metadata, ProjectID: projectID, QueueID: task.id})
+		go o.startTask(ctx, task.cmd, true)
+	}
+	o.sendQueuePositions(projectID, waiting)
+}
+
+func (o *Orchestrator) handleCancelQueuedTask(cmd protocol.CancelQueuedTaskCommand) {
+	removed, waiting := o.taskQueue.cancel(cmd.ProjectID, cmd.QueueID)
+	if !removed {
+		o.sendEvent(protocol.ErrorEvent{Metadata: cmd.Metadata, Message: "Failed to cancel queued task", Context: "task " + cmd.QueueID + " is no longer queued"})
+		return
+	}
+	o.sendEvent(protocol.TaskDequeuedEvent{Metadata: cmd.Metadata, ProjectID: cmd.ProjectID, QueueID: cmd.QueueID, Cancelled: true})
+	o.sendQueuePositions(cmd.ProjectID, waiting)
+}
+
+// sendQueuePositions re-announces every waiting task so clients can show its new position
+func (o *Orchestrator) sendQueuePositions(projectID string, waiting []*queuedTask) {
+	for i, task := range waiting {
+		o.sendEvent(protocol.TaskQueuedEvent{
+			Metadata:  task.cmd.Metadata,
+			ProjectID: projectID,
+			QueueID:   task.id,
+			Title:     task.cmd.Title,
+			Position:  i + 1,
+		})
+	}
+}
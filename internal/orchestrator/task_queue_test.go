@@ -0,0 +1,103 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/protocol"
+)
+
+func TestTaskQueue_AcquireAndRelease(t *testing.T) {
+	q := newTaskQueue()
+
+	started, _, _ := q.acquire("p1", 2, protocol.CreateTaskCommand{Title: "a"})
+	assert.True(t, started)
+	started, _, _ = q.acquire("p1", 2, protocol.CreateTaskCommand{Title: "b"})
+	assert.True(t, started)
+
+	started, firstID, position := q.acquire("p1", 2, protocol.CreateTaskCommand{Title: "c"})
+	assert.False(t, started)
+	assert.Equal(t, 1, position)
+	started, _, position = q.acquire("p1", 2, protocol.CreateTaskCommand{Title: "d"})
+	assert.False(t, started)
+	assert.Equal(t, 2, position)
+
+	// Limits are per project
+	started, _, _ = q.acquire("p2", 2, protocol.CreateTaskCommand{Title: "other"})
+	assert.True(t, started)
+
+	start, waiting := q.release("p1", 2)
+	require.Len(t, start, 1)
+	assert.Equal(t, firstID, start[0].id)
+	assert.Equal(t, "c", start[0].cmd.Title)
+	require.Len(t, waiting, 1)
+	assert.Equal(t, "d", waiting[0].cmd.Title)
+
+	// A raised limit hands out every free slot at once
+	start, waiting = q.release("p1", 4)
+	require.Len(t, start, 1)
+	assert.Equal(t, "d", start[0].cmd.Title)
+	assert.Empty(t, waiting)
+}
+
+func TestTaskQueue_NewTasksWaitBehindQueue(t *testing.T) {
+	q := newTaskQueue()
+	q.acquire("p1", 1, protocol.CreateTaskCommand{Title: "running"})
+	q.acquire("p1", 1, protocol.CreateTaskCommand{Title: "queued"})
+
+	// The limit was raised, but the queued task keeps its place in line
+	started, _, position := q.acquire("p1", 2, protocol.CreateTaskCommand{Title: "late"})
+	assert.False(t, started)
+	assert.Equal(t, 2, position)
+}
+
+func TestTaskQueue_Cancel(t *testing.T) {
+	q := newTaskQueue()
+	q.acquire("p1", 1, protocol.CreateTaskCommand{Title: "running"})
+	_, firstID, _ := q.acquire("p1", 1, protocol.CreateTaskCommand{Title: "first"})
+	_, secondID, _ := q.acquire("p1", 1, protocol.CreateTaskCommand{Title: "second"})
+
+	removed, waiting := q.cancel("p1", firstID)
+	assert.True(t, removed)
+	require.Len(t, waiting, 1)
+	assert.Equal(t, secondID, waiting[0].id)
+
+	removed, _ = q.cancel("p1", firstID)
+	assert.False(t, removed, "already cancelled")
+
+	// The cancelled task is never handed a slot
+	start, waiting := q.release("p1", 1)
+	require.Len(t, start, 1)
+	assert.Equal(t, "second", start[0].cmd.Title)
+	assert.Empty(t, waiting)
+}
+
+func TestHandleCancelQueuedTask(t *testing.T) {
+	eventChan := make(chan protocol.Event, 10)
+	orch := &Orchestrator{eventChan: eventChan, taskQueue: newTaskQueue()}
+
+	orch.taskQueue.acquire("p1", 1, protocol.CreateTaskCommand{Title: "running"})
+	_, firstID, _ := orch.taskQueue.acquire("p1", 1, protocol.CreateTaskCommand{Title: "first"})
+	_, secondID, _ := orch.taskQueue.acquire("p1", 1, protocol.CreateTaskCommand{Title: "second"})
+
+	orch.handleCancelQueuedTask(protocol.CancelQueuedTaskCommand{ProjectID: "p1", QueueID: firstID})
+
+	dequeued, ok := (<-eventChan).(protocol.TaskDequeuedEvent)
+	require.True(t, ok)
+	assert.Equal(t, firstID, dequeued.QueueID)
+	assert.True(t, dequeued.Cancelled)
+
+	queued, ok := (<-eventChan).(protocol.TaskQueuedEvent)
+	require.True(t, ok, "remaining tasks are re-announced with their new position")
+	assert.Equal(t, secondID, queued.QueueID)
+	assert.Equal(t, 1, queued.Position)
+
+	orch.handleCancelQueuedTask(protocol.CancelQueuedTaskCommand{ProjectID: "p1", QueueID: firstID})
+	_, ok = (<-eventChan).(protocol.ErrorEvent)
+	assert.True(t, ok)
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/services"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/workflows"
+	"github.com/noldarim/noldarim/internal/protocol"
+)
+
+// handleRebaseTask resolves a finished task's worktree and branch and starts a
+// RebaseTaskWorkflow, which answers the command itself. Running tasks are refused: the agent
+// would be working on top of rewritten history.
+func (o *Orchestrator) handleRebaseTask(ctx context.Context, cmd protocol.RebaseTaskCommand) {
+	metadata := cmd.Metadata
+	fail := func(message string, err error) {
+		if ctx.Err() != nil {
+			return
+		}
+		event := protocol.ErrorEvent{Metadata: metadata, TaskID: cmd.TaskID, Message: message}
+		if err != nil {
+			event.Context = err.Error()
+		}
+		o.sendEvent(event)
+	}
+
+	// Pipeline runs use the run ID as task ID (see PipelineService.CreateTask)
+	status, err := o.temporalClient.GetWorkflowStatus(ctx, fmt.Sprintf("%s-pipeline", cmd.TaskID))
+	if err == nil && status == temporal.WorkflowStatusRunning {
+		fail("Cannot rebase task "+cmd.TaskID+" while it is running", nil)
+		return
+	}
+	workflowID := types.RebaseTaskWorkflowID(cmd.ProjectID, cmd.TaskID)
+	status, err = o.temporalClient.GetWorkflowStatus(ctx, workflowID)
+	if err == nil && status == temporal.WorkflowStatusRunning {
+		fail("Task "+cmd.TaskID+" is already being rebased", nil)
+		return
+	}
+
+	project, err := o.dataService.GetProject(ctx, cmd.ProjectID)
+	if err != nil {
+		fail("Failed to load project details for "+cmd.ProjectID, err)
+		return
+	}
+
+	handle, err := o.gitServiceManager.GetService(project.RepositoryPath)
+	if err != nil {
+		fail("Failed to access git repository", err)
+		return
+	}
+	defer handle.Release()
+
+	input := types.RebaseTaskWorkflowInput{
+		Metadata:              metadata,
+		ProjectID:             cmd.ProjectID,
+		TaskID:                cmd.TaskID,
+		Onto:                  cmd.Onto,
+		OrchestratorTaskQueue: o.temporalClient.GetTaskQueue(),
+	}
+	if run, err := o.dataService.GetPipelineRun(ctx, cmd.TaskID); err == nil && run != nil {
+		input.RunID = run.ID
+	}
+	err = handle.WithReadLock(ctx, func(gs *services.GitService) error {
+		input.WorktreePath = gs.GetWorktreePath(cmd.TaskID)
+		if input.WorktreePath == "" || !gs.WorktreeExists(input.WorktreePath) {
+			return fmt.Errorf("task %s has no worktree", cmd.TaskID)
+		}
+		branch, err := gs.GetWorktreeBranch(input.WorktreePath)
+		if err != nil {
+			return err
+		}
+		if services.IsDetachedHead(branch) {
+			return fmt.Errorf("worktree %s is not on a branch", input.WorktreePath)
+		}
+		input.Branch = branch
+
+		if input.Onto == "" {
+			state, err := gs.ValidateRepository(ctx, project.RepositoryPath)
+			if err != nil {
+				return fmt.Errorf("failed to resolve the project's current branch: %w", err)
+			}
+			input.Onto = state.Branch
+		}
+		return nil
+	})
+	if err != nil {
+		fail("Failed to rebase task "+cmd.TaskID, err)
+		return
+	}
+
+	if _, err := o.temporalClient.StartWorkflow(ctx, workflowID, workflows.RebaseTaskWorkflow, input); err != nil {
+		fail("Failed to start rebasing task "+cmd.TaskID, err)
+		return
+	}
+
+	getLog().Info().
+		Str("task_id", cmd.TaskID).
+		Str("branch", input.Branch).
+		Str("onto", input.Onto).
+		Str("run_id", input.RunID).
+		Msg("Started task rebase")
+}
@@ -4,18 +4,34 @@
 package activities
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/noldarim/noldarim/internal/orchestrator/agents"
 	"github.com/noldarim/noldarim/internal/protocol"
+	"go.temporal.io/sdk/activity"
 )
 
-// PrepareAgentCommand converts an AgentConfigInput into a command ready to execute
-func PrepareAgentCommand(input *protocol.AgentConfigInput) ([]string, error) {
+// PrepareAgentCommand converts an AgentConfigInput into a command ready to execute.
+// The prompt template is validated first: undefined variables are an error, unused
+// variables are logged as warnings. Env names are validated too; the env itself is
+// passed to LocalExecuteActivity by the workflow.
+func PrepareAgentCommand(ctx context.Context, input *protocol.AgentConfigInput) ([]string, error) {
 	if input == nil {
 		return nil, fmt.Errorf("agent config is nil")
 	}
 
+	prompt, err := agents.ResolvePrompt(input.PromptTemplate, input.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prompt template: %w", err)
+	}
+	if len(prompt.Unused) > 0 && activity.IsActivity(ctx) {
+		activity.GetLogger(ctx).Warn("Prompt variables are not referenced by the template", "variables", prompt.Unused)
+	}
+	if err := protocol.ValidateAgentEnv(input.Env); err != nil {
+		return nil, fmt.Errorf("invalid agent env: %w", err)
+	}
+
 	// Convert protocol.AgentConfigInput to agents.AgentConfig
 	agentConfig := agents.AgentConfig{
 		ToolName:       input.ToolName,
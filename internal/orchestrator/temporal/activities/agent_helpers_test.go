@@ -4,6 +4,7 @@
 package activities
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -101,6 +102,40 @@ func TestPrepareAgentCommand(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "undefined template variable",
+			input: &protocol.AgentConfigInput{
+				ToolName:       "claude",
+				PromptTemplate: "Fix {{.TaskTitle}} in {{.file}}",
+				Variables:      map[string]string{"title": "bug"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "built-in variables from task",
+			input: &protocol.AgentConfigInput{
+				ToolName:       "test",
+				PromptTemplate: "cat {{.TaskFilePath}} # {{.TaskTitle}}",
+				Variables:      map[string]string{"title": "Fix bug", "task_file": "/tmp/task.md", "unused": "x"},
+			},
+			want: []string{
+				"sh",
+				"-c",
+				"cat /tmp/task.md # Fix bug",
+			},
+			wantErr: false,
+		},
+		{
+			name: "reserved env var",
+			input: &protocol.AgentConfigInput{
+				ToolName:       "test",
+				PromptTemplate: "echo hi",
+				Env:            map[string]string{"PATH": "/tmp/evil"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "unsupported tool name",
 			input: &protocol.AgentConfigInput{
@@ -115,7 +150,7 @@ func TestPrepareAgentCommand(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := PrepareAgentCommand(tt.input)
+			got, err := PrepareAgentCommand(context.Background(), tt.input)
 			if tt.wantErr {
 				if err == nil {
 					t.Errorf("PrepareAgentCommand() expected error, got nil")
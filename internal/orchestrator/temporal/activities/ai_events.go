@@ -133,7 +133,7 @@ func (a *AIEventActivities) ParseEventActivity(
 	}
 
 	rawEntry := aiobsTypes.RawEntry{
-		Line:      0, // Line number not available in this context
+		Line:      input.SourceLine,
 		Data:      input.RawPayload,
 		SessionID: aiobsTypes.ExtractSessionID(input.RawPayload),
 	}
@@ -167,6 +167,10 @@ func (a *AIEventActivities) ParseEventActivity(
 		}
 		// Override the EventID from parsed with our workflow-assigned ID
 		parsed.EventID = eventID
+		if input.SourceFile != "" {
+			parsed.SourceFile = input.SourceFile
+			parsed.SourceLine = input.SourceLine
+		}
 		events = append(events, models.NewAIActivityRecordFromParsed(parsed, input.TaskID, input.RunID, input.StepID))
 	}
 
@@ -5,6 +5,7 @@ package activities
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"strings"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/noldarim/noldarim/internal/config"
 	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+	"github.com/noldarim/noldarim/internal/protocol"
 	"github.com/noldarim/noldarim/pkg/containers/models"
 	"github.com/noldarim/noldarim/pkg/containers/validation"
 
@@ -149,6 +151,15 @@ func (a *ContainerActivities) CreateContainerActivity(ctx context.Context, input
 	// Add any additional configured environment variables
 	maps.Copy(containerConfig.Environment, config.Container.Environment)
 
+	// agent.default_env is handed to the container's LocalExecuteActivity as one variable
+	if len(config.Agent.DefaultEnv) > 0 {
+		encoded, err := json.Marshal(config.Agent.DefaultEnv)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode agent default env: %w", err)
+		}
+		containerConfig.Environment[protocol.AgentDefaultEnvVar] = string(encoded)
+	}
+
 	// Validate container labels and environment variables
 	if err := validation.ValidateContainerLabels(containerConfig.Labels); err != nil {
 		return nil, fmt.Errorf("invalid container labels: %w", err)
@@ -5,6 +5,7 @@ package activities
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -76,7 +77,7 @@ func (a *DataActivities) CreateTaskActivity(ctx context.Context, input types.Cre
 	}
 
 	// Create the task using the data service with provided task ID
-	task, err := a.dataService.CreateTask(ctx, input.ProjectID, input.TaskID, input.Title, input.Description, input.TaskFilePath)
+	task, err := a.dataService.CreateTaskWithLabels(ctx, input.ProjectID, input.TaskID, input.Title, input.Description, input.TaskFilePath, input.Labels)
 	if err != nil {
 		logger.Error("Failed to create task", "error", err)
 		return nil, fmt.Errorf("failed to create task: %w", err)
@@ -147,6 +148,42 @@ func (a *DataActivities) UpdateTaskGitDiffActivity(ctx context.Context, input ty
 	return nil
 }
 
+// SaveProcessTaskCheckpointActivity saves the progress of a task's processing workflow
+func (a *DataActivities) SaveProcessTaskCheckpointActivity(ctx context.Context, input types.SaveProcessTaskCheckpointActivityInput) error {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Saving task processing checkpoint", "taskID", input.TaskID, "completedStep", input.Checkpoint.CompletedStep)
+
+	data, err := json.Marshal(input.Checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	if err := a.dataService.UpdateTaskProcessCheckpoint(ctx, input.TaskID, string(data)); err != nil {
+		logger.Error("Failed to save task processing checkpoint", "error", err)
+		return fmt.Errorf("failed to save task processing checkpoint: %w", err)
+	}
+	return nil
+}
+
+// LoadProcessTaskCheckpointActivity loads the saved progress of a task's processing workflow,
+// or an empty checkpoint when none was saved
+func (a *DataActivities) LoadProcessTaskCheckpointActivity(ctx context.Context, input types.LoadProcessTaskCheckpointActivityInput) (*types.ProcessTaskCheckpoint, error) {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Loading task processing checkpoint", "taskID", input.TaskID)
+
+	task, err := a.dataService.GetTask(ctx, input.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task: %w", err)
+	}
+	checkpoint := &types.ProcessTaskCheckpoint{}
+	if task.ProcessCheckpoint == "" {
+		return checkpoint, nil
+	}
+	if err := json.Unmarshal([]byte(task.ProcessCheckpoint), checkpoint); err != nil {
+		return nil, fmt.Errorf("invalid task processing checkpoint: %w", err)
+	}
+	return checkpoint, nil
+}
+
 // LoadProjectsActivity loads all projects
 func (a *DataActivities) LoadProjectsActivity(ctx context.Context) (interface{}, error) {
 	logger := activity.GetLogger(ctx)
@@ -200,7 +237,7 @@ func (a *DataActivities) PrepareAgentCommandActivity(ctx context.Context, input
 	logger := activity.GetLogger(ctx)
 	logger.Info("Preparing agent command", "tool", input.ToolName)
 
-	command, err := PrepareAgentCommand(input)
+	command, err := PrepareAgentCommand(ctx, input)
 	if err != nil {
 		logger.Error("Failed to prepare agent command", "error", err)
 		return nil, fmt.Errorf("failed to prepare agent command: %w", err)
@@ -0,0 +1,126 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package activities
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/common"
+	"github.com/noldarim/noldarim/internal/orchestrator/services"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+	"github.com/noldarim/noldarim/internal/protocol"
+
+	"go.temporal.io/sdk/activity"
+)
+
+// DiffStreamActivities publishes the diff of a worktree while an agent is working in it
+type DiffStreamActivities struct {
+	manager   *services.GitServiceManager
+	eventChan chan<- common.Event
+}
+
+// NewDiffStreamActivities creates a new instance of DiffStreamActivities
+func NewDiffStreamActivities(manager *services.GitServiceManager, eventChan chan<- common.Event) *DiffStreamActivities {
+	return &DiffStreamActivities{
+		manager:   manager,
+		eventChan: eventChan,
+	}
+}
+
+// StreamGitDiffActivity re-captures the worktree diff every input.Interval and publishes a
+// DiffUpdatedEvent whenever it changed. It runs until the workflow cancels it once the agent
+// has finished. Capture failures are logged and retried on the next tick.
+func (a *DiffStreamActivities) StreamGitDiffActivity(ctx context.Context, input types.StreamGitDiffActivityInput) error {
+	logger := activity.GetLogger(ctx)
+
+	if input.RepositoryPath == "" {
+		return fmt.Errorf("repository path must be provided")
+	}
+	if input.Interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(input.Interval)
+	defer ticker.Stop()
+
+	var lastDiff string
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		activity.RecordHeartbeat(ctx, "Streaming git diff")
+
+		live, err := a.capture(ctx, input)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Warn("Failed to capture live diff", "repositoryPath", input.RepositoryPath, "error", err)
+			continue
+		}
+		if live.Diff == lastDiff {
+			continue
+		}
+		lastDiff = live.Diff
+		a.publishDiff(ctx, input, live)
+	}
+}
+
+// capture takes a live diff snapshot under the repository's read lock
+func (a *DiffStreamActivities) capture(ctx context.Context, input types.StreamGitDiffActivityInput) (*services.LiveDiff, error) {
+	handle, err := a.manager.GetService(input.RepositoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git service handle: %w", err)
+	}
+	defer handle.Release()
+
+	var live *services.LiveDiff
+	err = handle.WithReadLock(ctx, func(gs *services.GitService) error {
+		live, err = gs.GetLiveDiff(ctx, input.RepositoryPath, input.Paths...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return live, nil
+}
+
+// publishDiff sends a DiffUpdatedEvent without blocking the stream for long; a dropped
+// update is superseded by the next one.
+func (a *DiffStreamActivities) publishDiff(ctx context.Context, input types.StreamGitDiffActivityInput, live *services.LiveDiff) {
+	if a.eventChan == nil {
+		return
+	}
+
+	event := protocol.DiffUpdatedEvent{
+		Metadata: protocol.Metadata{
+			TaskID:  input.TaskID,
+			Version: protocol.CurrentProtocolVersion,
+		},
+		ProjectID:    input.ProjectID,
+		TaskID:       input.TaskID,
+		StepID:       input.StepID,
+		DiffStat:     live.DiffStat,
+		Diff:         live.Diff,
+		FilesChanged: len(live.Numstat.Files),
+		Insertions:   live.Numstat.Insertions,
+		Deletions:    live.Numstat.Deletions,
+	}
+	if input.MaxDiffBytes > 0 && len(live.Diff) > input.MaxDiffBytes {
+		event.Diff = ""
+		event.DiffOmitted = true
+	}
+
+	select {
+	case a.eventChan <- event:
+	case <-time.After(time.Second):
+		activity.GetLogger(ctx).Warn("Timed out publishing diff update", "taskID", input.TaskID)
+	case <-ctx.Done():
+	}
+}
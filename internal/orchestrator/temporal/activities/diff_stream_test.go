@@ -0,0 +1,104 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package activities
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/common"
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/noldarim/noldarim/internal/orchestrator/services"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+	"github.com/noldarim/noldarim/internal/protocol"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+)
+
+func TestStreamGitDiffActivity_PublishesChangedDiffs(t *testing.T) {
+	tmpDir := t.TempDir()
+	repoPath := filepath.Join(tmpDir, "test-repo")
+	cfg := &config.AppConfig{Git: config.GitConfig{WorktreeBasePath: tmpDir}}
+
+	gitService, err := services.NewGitServiceWithConfig(repoPath, cfg, true)
+	require.NoError(t, err)
+	defer gitService.Close()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "README.md"), []byte("readme\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(context.Background(), repoPath, "Initial commit"))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "small.txt"), []byte("small\n"), 0644))
+
+	eventChan := make(chan common.Event, 10)
+	diffStreamActivities := NewDiffStreamActivities(services.NewGitServiceManager(cfg), eventChan)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+	env.SetWorkerOptions(worker.Options{BackgroundActivityContext: ctx})
+	env.RegisterActivity(diffStreamActivities.StreamGitDiffActivity)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := env.ExecuteActivity(diffStreamActivities.StreamGitDiffActivity, types.StreamGitDiffActivityInput{
+			ProjectID:      "project-1",
+			TaskID:         "run-1",
+			StepID:         "1a",
+			RepositoryPath: repoPath,
+			Interval:       20 * time.Millisecond,
+			MaxDiffBytes:   1024,
+		})
+		done <- err
+	}()
+
+	next := func() protocol.DiffUpdatedEvent {
+		t.Helper()
+		select {
+		case event := <-eventChan:
+			diffEvent, ok := event.(protocol.DiffUpdatedEvent)
+			require.True(t, ok, "unexpected event %T", event)
+			return diffEvent
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for DiffUpdatedEvent")
+			return protocol.DiffUpdatedEvent{}
+		}
+	}
+
+	first := next()
+	assert.Equal(t, "run-1", first.TaskID)
+	assert.Equal(t, "1a", first.StepID)
+	assert.Contains(t, first.Diff, "+small")
+	assert.False(t, first.DiffOmitted)
+	assert.Equal(t, 1, first.FilesChanged)
+	assert.Equal(t, 1, first.Insertions)
+
+	// Diffs over MaxDiffBytes are sent as stats only
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "large.txt"), []byte(strings.Repeat("line\n", 500)), 0644))
+	second := next()
+	assert.True(t, second.DiffOmitted)
+	assert.Empty(t, second.Diff)
+	assert.Contains(t, second.DiffStat, "large.txt")
+	assert.Equal(t, 2, second.FilesChanged)
+
+	// An unchanged working tree publishes nothing further
+	select {
+	case event := <-eventChan:
+		t.Fatalf("unexpected event for unchanged diff: %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("activity did not stop after cancellation")
+	}
+}
@@ -85,11 +85,17 @@ func (a *EventActivities) PublishTaskRequestedEventActivity(ctx context.Context,
 
 // PublishTaskInProgressEventActivity publishes a TaskInProgress lifecycle event
 func (a *EventActivities) PublishTaskInProgressEventActivity(ctx context.Context, input types.PublishEventInput) error {
+	keyPrefix := "task-in-progress"
+	if input.ResumedFrom != "" {
+		// A resume is a separate event from the fresh start; keep it from being deduplicated
+		keyPrefix = "task-resumed-" + input.ResumedFrom
+	}
 	event := protocol.TaskLifecycleEvent{
-		Metadata:  a.metadata(input.ProjectID, input.TaskID, "task-in-progress"),
-		Type:      protocol.TaskInProgress,
-		ProjectID: input.ProjectID,
-		TaskID:    input.TaskID,
+		Metadata:    a.metadata(input.ProjectID, input.TaskID, keyPrefix),
+		Type:        protocol.TaskInProgress,
+		ProjectID:   input.ProjectID,
+		TaskID:      input.TaskID,
+		ResumedFrom: input.ResumedFrom,
 	}
 	return a.publish(ctx, event, "TaskInProgress")
 }
@@ -235,6 +241,105 @@ func (a *EventActivities) PublishAIActivityEventActivity(ctx context.Context, re
 	return a.publish(ctx, record, "AIActivity")
 }
 
+// PublishBudgetExceededEventActivity publishes a BudgetExceededEvent
+func (a *EventActivities) PublishBudgetExceededEventActivity(ctx context.Context, input types.PublishBudgetExceededEventInput) error {
+	event := protocol.BudgetExceededEvent{
+		Metadata:   a.metadata(input.ProjectID, input.TaskID, "budget-exceeded"),
+		TaskID:     input.TaskID,
+		ProjectID:  input.ProjectID,
+		RunID:      input.RunID,
+		Budget:     input.Budget,
+		TokensUsed: input.TokensUsed,
+		Cancelled:  input.Cancelled,
+	}
+	return a.publish(ctx, event, "BudgetExceeded")
+}
+
+// PublishAgentIdleEventActivity publishes an AgentIdleEvent
+func (a *EventActivities) PublishAgentIdleEventActivity(ctx context.Context, input types.PublishAgentIdleEventInput) error {
+	// Each report of an idle stretch is distinct; the TUI would drop repeats of one key
+	entityID := fmt.Sprintf("%s-%s-%d", input.TaskID, input.StepID, int64(input.IdleFor.Seconds()))
+	event := protocol.AgentIdleEvent{
+		Metadata:   a.metadata(input.ProjectID, entityID, "agent-idle"),
+		TaskID:     input.TaskID,
+		ProjectID:  input.ProjectID,
+		RunID:      input.RunID,
+		StepID:     input.StepID,
+		IdleFor:    input.IdleFor,
+		Finalizing: input.Finalizing,
+	}
+	return a.publish(ctx, event, "AgentIdle")
+}
+
+// PublishBranchAssemblyProgressEventActivity publishes a BranchAssemblyProgressEvent for the
+// last source in the input
+func (a *EventActivities) PublishBranchAssemblyProgressEventActivity(ctx context.Context, input types.PublishBranchAssemblyEventInput) error {
+	if len(input.Sources) == 0 {
+		return fmt.Errorf("at least one source result is required")
+	}
+	index := len(input.Sources) - 1
+	event := protocol.BranchAssemblyProgressEvent{
+		Metadata:     a.metadata(input.ProjectID, fmt.Sprintf("%s-%d", input.TargetBranch, index), "branch-assembly"),
+		ProjectID:    input.ProjectID,
+		TargetBranch: input.TargetBranch,
+		Index:        index,
+		Total:        input.Total,
+		Source:       input.Sources[index],
+	}
+	return a.publish(ctx, event, "BranchAssemblyProgress")
+}
+
+// PublishBranchAssembledEventActivity answers an AssembleBranchCommand with a
+// BranchAssembledEvent, or with an ErrorEvent when the assembly failed
+func (a *EventActivities) PublishBranchAssembledEventActivity(ctx context.Context, input types.PublishBranchAssemblyEventInput) error {
+	if input.Error != "" {
+		event := protocol.ErrorEvent{
+			Metadata: input.Metadata,
+			Message:  "Failed to assemble branch " + input.TargetBranch,
+			Context:  input.Error,
+		}
+		return a.publish(ctx, event, "Error")
+	}
+
+	event := protocol.BranchAssembledEvent{
+		Metadata:     input.Metadata,
+		ProjectID:    input.ProjectID,
+		TargetBranch: input.TargetBranch,
+		BaseBranch:   input.BaseBranch,
+		Strategy:     input.Strategy,
+		HeadSHA:      input.HeadSHA,
+		Sources:      input.Sources,
+	}
+	return a.publish(ctx, event, "BranchAssembled")
+}
+
+// PublishTaskRebasedEventActivity answers a RebaseTaskCommand with a TaskRebasedEvent, or
+// with an ErrorEvent when the rebase failed
+func (a *EventActivities) PublishTaskRebasedEventActivity(ctx context.Context, input types.PublishTaskRebasedEventInput) error {
+	if input.Error != "" {
+		event := protocol.ErrorEvent{
+			Metadata: input.Metadata,
+			TaskID:   input.TaskID,
+			Message:  "Failed to rebase task " + input.TaskID,
+			Context:  input.Error,
+		}
+		return a.publish(ctx, event, "Error")
+	}
+
+	event := protocol.TaskRebasedEvent{
+		Metadata:   input.Metadata,
+		ProjectID:  input.ProjectID,
+		TaskID:     input.TaskID,
+		Branch:     input.Branch,
+		Onto:       input.Onto,
+		OldHeadSHA: input.OldHeadSHA,
+		NewHeadSHA: input.NewHeadSHA,
+		UpToDate:   input.UpToDate,
+		Conflicts:  input.Conflicts,
+	}
+	return a.publish(ctx, event, "TaskRebased")
+}
+
 // ============================================================================
 // Shared Implementation
 // ============================================================================
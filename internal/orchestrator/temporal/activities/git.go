@@ -6,8 +6,11 @@ package activities
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"go.temporal.io/sdk/activity"
+	"github.com/noldarim/noldarim/internal/orchestrator/agents"
 	"github.com/noldarim/noldarim/internal/orchestrator/services"
 	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
 )
@@ -46,6 +49,12 @@ func (a *GitActivities) CreateWorktreeActivity(ctx context.Context, input types.
 	}
 	defer handle.Release()
 
+	// Worktrees are created on the requested branch, e.g. pipeline/<run>, or on the task branch
+	expectedBranch := input.BranchName
+	if expectedBranch == "" {
+		expectedBranch = services.GenerateTaskBranchName(input.TaskID)
+	}
+
 	var worktreePath string
 	var branchName string
 
@@ -55,18 +64,40 @@ func (a *GitActivities) CreateWorktreeActivity(ctx context.Context, input types.
 		if gs.WorktreeExists(expectedPath) {
 			// Verify it's on the correct branch
 			currentBranch, err := gs.GetWorktreeBranch(expectedPath)
-			if err == nil && currentBranch == input.BranchName {
+			if err == nil && currentBranch == expectedBranch {
 				logger.Info("Worktree already exists with correct branch", "path", expectedPath, "branch", currentBranch)
 				worktreePath = expectedPath
 				branchName = currentBranch
 				return nil
 			}
+			if err == nil && services.IsDetachedHead(currentBranch) {
+				// Keep the worktree (and any work in it); it is moved back onto its branch below
+				logger.Info("Worktree exists in detached HEAD state, will repair", "state", currentBranch)
+				worktreePath = expectedPath
+				return fmt.Errorf("need to repair worktree")
+			}
 			// Wrong branch - need to clean up and recreate
-			logger.Info("Worktree exists with wrong branch, will recreate", "currentBranch", currentBranch, "expectedBranch", input.BranchName)
+			logger.Info("Worktree exists with wrong branch, will recreate", "currentBranch", currentBranch, "expectedBranch", expectedBranch)
 		}
 		return fmt.Errorf("need to create worktree")
 	})
 
+	// A detached worktree is put back on its branch rather than recreated
+	if worktreePath != "" {
+		err = handle.WithWriteLock(ctx, func(gs *services.GitService) error {
+			return gs.EnsureWorktreeOnBranch(ctx, worktreePath, expectedBranch)
+		})
+		if err == nil {
+			logger.Info("Repaired detached worktree", "path", worktreePath, "branch", expectedBranch)
+			return &types.CreateWorktreeActivityOutput{
+				WorktreePath: worktreePath,
+				BranchName:   expectedBranch,
+			}, nil
+		}
+		logger.Warn("Failed to repair detached worktree, will recreate", "error", err)
+		worktreePath = ""
+	}
+
 	// If worktree exists with correct branch, return success
 	if err == nil && worktreePath != "" {
 		return &types.CreateWorktreeActivityOutput{
@@ -101,16 +132,13 @@ func (a *GitActivities) CreateWorktreeActivity(ctx context.Context, input types.
 
 		// Create worktree manager and create worktree from commit
 		worktreeManager := services.NewWorktreeManager(gs, gs.GetWorkDir())
-		path, err := worktreeManager.CreateWorktreeFromCommit(ctx, input.TaskID, commitSHA)
+		path, err := worktreeManager.CreateWorktreeOnBranch(ctx, input.TaskID, commitSHA, expectedBranch)
 		if err != nil {
 			return fmt.Errorf("failed to create worktree: %w", err)
 		}
 
-		// Generate branch name for consistency
-		branch := services.GenerateTaskBranchName(input.TaskID)
-
 		worktreePath = path
-		branchName = branch
+		branchName = expectedBranch
 		return nil
 	})
 
@@ -202,6 +230,26 @@ func (a *GitActivities) RemoveWorktreeActivity(ctx context.Context, input types.
 	return nil
 }
 
+// DeleteBranchActivity deletes a local branch whose worktree is gone (compensation activity).
+// A branch that does not exist is already deleted.
+func (a *GitActivities) DeleteBranchActivity(ctx context.Context, input types.DeleteBranchActivityInput) error {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Deleting branch", "branch", input.BranchName, "repositoryPath", input.RepositoryPath)
+
+	activity.RecordHeartbeat(ctx, "Deleting branch")
+
+	err := a.manager.WithRepo(ctx, input.RepositoryPath, func(gs *services.GitService) error {
+		return gs.DeleteBranch(ctx, input.RepositoryPath, input.BranchName)
+	})
+	if err != nil {
+		logger.Error("Failed to delete branch", "error", err)
+		return err
+	}
+
+	logger.Info("Branch deleted successfully", "branch", input.BranchName)
+	return nil
+}
+
 // CommitChangesActivity commits changes in a worktree
 func (a *GitActivities) CommitChangesActivity(ctx context.Context, worktreePath, message, agentID string) error {
 	logger := activity.GetLogger(ctx)
@@ -210,16 +258,12 @@ func (a *GitActivities) CommitChangesActivity(ctx context.Context, worktreePath,
 	// Record heartbeat
 	activity.RecordHeartbeat(ctx, "Committing changes")
 
-	// Get git service handle for the worktree path (it's a git repo itself)
-	handle, err := a.manager.GetService(worktreePath)
-	if err != nil {
-		logger.Error("Failed to get git service handle", "error", err)
-		return fmt.Errorf("failed to get git service handle: %w", err)
-	}
-	defer handle.Release()
+	// Branch check and commit run under one repository lock
+	err := a.manager.WithRepo(ctx, worktreePath, func(gs *services.GitService) error {
+		if err := ensureCommitBranch(ctx, gs, worktreePath, ""); err != nil {
+			return err
+		}
 
-	// Use write lock for commit
-	err = handle.WithWriteLock(ctx, func(gs *services.GitService) error {
 		// Commit changes using the git service
 		if err := gs.CreateCommit(ctx, worktreePath, message); err != nil {
 			return fmt.Errorf("failed to commit changes: %w", err)
@@ -306,6 +350,16 @@ func (a *GitActivities) GitCommitActivity(ctx context.Context, input types.GitCo
 		}, fmt.Errorf("commit message must be provided")
 	}
 
+	commitMessage := input.CommitMessage
+	if input.CommitTemplate != "" {
+		rendered, err := renderCommitMessage(input.CommitTemplate, input.TemplateVars)
+		if err != nil {
+			logger.Warn("Failed to render commit template, using default message", "error", err)
+		} else {
+			commitMessage = rendered
+		}
+	}
+
 	// Get git service handle for this repository
 	handle, err := a.manager.GetService(input.RepositoryPath)
 	if err != nil {
@@ -322,8 +376,12 @@ func (a *GitActivities) GitCommitActivity(ctx context.Context, input types.GitCo
 
 	// Use write lock for commit operation
 	err = handle.WithWriteLock(ctx, func(gs *services.GitService) error {
+		if err := ensureCommitBranch(ctx, gs, input.RepositoryPath, input.BranchName); err != nil {
+			return err
+		}
+
 		// Use the new CommitSpecificFiles method
-		if err := gs.CommitSpecificFiles(ctx, input.RepositoryPath, input.FileNames, input.CommitMessage); err != nil {
+		if err := gs.CommitSpecificFiles(ctx, input.RepositoryPath, input.FileNames, commitMessage); err != nil {
 			return fmt.Errorf("failed to commit specific files: %w", err)
 		}
 
@@ -349,12 +407,65 @@ func (a *GitActivities) GitCommitActivity(ctx context.Context, input types.GitCo
 
 	logger.Info("Successfully committed files to repository", "commitSHA", commitSHA)
 	return &types.GitCommitActivityOutput{
-		Success:   true,
-		Error:     "",
-		CommitSHA: commitSHA,
+		Success:       true,
+		Error:         "",
+		CommitSHA:     commitSHA,
+		CommitMessage: commitMessage,
 	}, nil
 }
 
+// renderCommitMessage fills a commit message template. Control and bidi characters are
+// stripped from the task title; a title that would fail message validation is rejected,
+// so the caller falls back to its default message rather than committing a rewritten title.
+func renderCommitMessage(template string, vars types.CommitTemplateVars) (string, error) {
+	title := services.SanitizeCommitText(vars.TaskTitle)
+	if err := services.ValidateCommitText(title); err != nil {
+		return "", fmt.Errorf("task title cannot be used in a commit message: %w", err)
+	}
+
+	result, err := agents.ResolvePrompt(template, map[string]string{
+		"TaskTitle":    title,
+		"TaskID":       vars.TaskID,
+		"FilesChanged": strconv.Itoa(vars.FilesChanged),
+		"Tokens":       strconv.Itoa(vars.Tokens),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render commit template: %w", err)
+	}
+	message := strings.TrimSpace(result.Prompt)
+	if message == "" {
+		return "", fmt.Errorf("commit template rendered an empty message")
+	}
+	return message, nil
+}
+
+// ensureCommitBranch guards against committing onto a detached HEAD. A detached HEAD is
+// moved onto branchName, or onto the task branch when repoPath is a task worktree; with
+// neither to go on the commit is refused.
+func ensureCommitBranch(ctx context.Context, gs *services.GitService, repoPath, branchName string) error {
+	current, err := gs.GetWorktreeBranch(repoPath)
+	if err != nil {
+		return err
+	}
+	if !services.IsDetachedHead(current) && (branchName == "" || current == branchName) {
+		return nil
+	}
+
+	if branchName == "" {
+		if taskID := services.ExtractTaskIDFromPath(repoPath); taskID != "" {
+			branchName = services.GenerateTaskBranchName(taskID)
+		}
+	}
+	if branchName == "" {
+		return fmt.Errorf("refusing to commit onto %s in %s", current, repoPath)
+	}
+
+	if err := gs.EnsureWorktreeOnBranch(ctx, repoPath, branchName); err != nil {
+		return fmt.Errorf("failed to prepare branch for commit: %w", err)
+	}
+	return nil
+}
+
 // CheckFastForwardActivity checks if a fast-forward merge is possible.
 func (a *GitActivities) CheckFastForwardActivity(ctx context.Context, input types.CheckFastForwardInput) (*types.CheckFastForwardOutput, error) {
 	logger := activity.GetLogger(ctx)
@@ -406,13 +517,7 @@ func (a *GitActivities) FastForwardBranchActivity(ctx context.Context, input typ
 
 	activity.RecordHeartbeat(ctx, "Fast-forwarding branch")
 
-	handle, err := a.manager.GetService(input.RepoPath)
-	if err != nil {
-		return fmt.Errorf("failed to get git service handle: %w", err)
-	}
-	defer handle.Release()
-
-	err = handle.WithWriteLock(ctx, func(gs *services.GitService) error {
+	err := a.manager.WithRepo(ctx, input.RepoPath, func(gs *services.GitService) error {
 		return gs.FastForwardBranch(ctx, input.RepoPath, input.Branch, input.TargetSHA, input.ExpectedOldSHA)
 	})
 
@@ -432,15 +537,9 @@ func (a *GitActivities) MergeInWorktreeActivity(ctx context.Context, input types
 
 	activity.RecordHeartbeat(ctx, "Merging in worktree")
 
-	handle, err := a.manager.GetService(input.WorktreePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get git service handle: %w", err)
-	}
-	defer handle.Release()
-
 	output := &types.MergeInWorktreeOutput{}
 
-	err = handle.WithWriteLock(ctx, func(gs *services.GitService) error {
+	err := a.manager.WithRepo(ctx, input.WorktreePath, func(gs *services.GitService) error {
 		sha, hasConflicts, mergeErr := gs.MergeInWorktree(ctx, input.WorktreePath, input.BranchToMerge)
 		if mergeErr != nil {
 			return mergeErr
@@ -459,6 +558,77 @@ func (a *GitActivities) MergeInWorktreeActivity(ctx context.Context, input types
 	return output, nil
 }
 
+// RebaseWorktreeActivity rebases a task branch onto a new base in its worktree. Safe to
+// retry: a rebase left in progress by an earlier attempt is aborted before starting again.
+func (a *GitActivities) RebaseWorktreeActivity(ctx context.Context, input types.RebaseWorktreeActivityInput) (*types.RebaseWorktreeActivityOutput, error) {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Rebasing worktree", "worktree", input.WorktreePath, "branch", input.Branch, "onto", input.Onto)
+
+	activity.RecordHeartbeat(ctx, "Rebasing worktree")
+
+	output := &types.RebaseWorktreeActivityOutput{}
+
+	err := a.manager.WithRepo(ctx, input.WorktreePath, func(gs *services.GitService) error {
+		result, rebaseErr := gs.RebaseWithOptions(ctx, input.WorktreePath, input.Branch, input.Onto, services.RebaseOptions{
+			KeepConflicts: input.KeepConflicts,
+		})
+		if rebaseErr != nil {
+			return rebaseErr
+		}
+		output.OldHeadSHA = result.OldHeadSHA
+		output.NewHeadSHA = result.NewHeadSHA
+		output.UpToDate = result.UpToDate
+		output.Conflicts = result.Conflicts
+		output.Aborted = result.Aborted
+		output.InProgress = result.InProgress
+		return nil
+	})
+
+	if err != nil {
+		logger.Error("Rebase failed", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Rebase activity complete", "newHeadSHA", output.NewHeadSHA, "conflicts", len(output.Conflicts))
+	return output, nil
+}
+
+// IntegrateBranchActivity merges or cherry-picks a branch into the branch checked out in a
+// worktree. Conflicts are aborted and reported in the output. Safe to retry: an operation left
+// in progress is aborted first, and changes already integrated are not applied twice.
+func (a *GitActivities) IntegrateBranchActivity(ctx context.Context, input types.IntegrateBranchActivityInput) (*types.IntegrateBranchActivityOutput, error) {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Integrating branch", "worktree", input.WorktreePath, "branch", input.Branch, "cherryPick", input.CherryPick)
+
+	activity.RecordHeartbeat(ctx, "Integrating branch")
+
+	output := &types.IntegrateBranchActivityOutput{}
+
+	err := a.manager.WithRepo(ctx, input.WorktreePath, func(gs *services.GitService) error {
+		integrate := gs.Merge
+		if input.CherryPick {
+			integrate = gs.CherryPick
+		}
+		result, integrateErr := integrate(ctx, input.WorktreePath, input.Branch)
+		if integrateErr != nil {
+			return integrateErr
+		}
+		output.HeadSHA = result.NewHeadSHA
+		output.UpToDate = result.UpToDate
+		output.Picked = result.Picked
+		output.Conflicts = result.Conflicts
+		return nil
+	})
+
+	if err != nil {
+		logger.Error("Integrating branch failed", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Integrate branch activity complete", "headSHA", output.HeadSHA, "conflicts", len(output.Conflicts))
+	return output, nil
+}
+
 // GetBranchHeadActivity gets the HEAD commit SHA of a branch.
 func (a *GitActivities) GetBranchHeadActivity(ctx context.Context, input types.GetBranchHeadInput) (*types.GetBranchHeadOutput, error) {
 	logger := activity.GetLogger(ctx)
@@ -526,26 +696,33 @@ func (a *GitActivities) CaptureGitDiffActivity(ctx context.Context, input types.
 	// Use read lock for diff operation
 	err = handle.WithReadLock(ctx, func(gs *services.GitService) error {
 		// Get full diff output
-		diff, err := gs.GetDiff(ctx, input.RepositoryPath)
+		diff, err := gs.GetDiff(ctx, input.RepositoryPath, input.Paths...)
 		if err != nil {
 			return fmt.Errorf("failed to get git diff: %w", err)
 		}
 		output.Diff = diff
 
 		// Get diff stat
-		diffStat, err := gs.GetDiffStat(ctx, input.RepositoryPath)
+		diffStat, err := gs.GetDiffStat(ctx, input.RepositoryPath, input.Paths...)
 		if err != nil {
 			return fmt.Errorf("failed to get git diff stat: %w", err)
 		}
 		output.DiffStat = diffStat
 
 		// Get changed files
-		changedFiles, err := gs.GetChangedFiles(ctx, input.RepositoryPath)
+		changedFiles, err := gs.GetChangedFiles(ctx, input.RepositoryPath, input.Paths...)
 		if err != nil {
 			return fmt.Errorf("failed to get changed files: %w", err)
 		}
 		output.FilesChanged = changedFiles
 
+		// Count files with non-whitespace changes separately; the totals above keep whitespace for fidelity
+		significant, err := gs.GetDiffNumstat(ctx, input.RepositoryPath, services.DiffOptions{IgnoreWhitespace: true, Paths: input.Paths})
+		if err != nil {
+			return fmt.Errorf("failed to get significant changes: %w", err)
+		}
+		output.SignificantFilesChanged = significant.Files
+
 		// Parse diff stat for insertions/deletions
 		insertions, deletions := gs.ParseDiffStat(diffStat)
 		output.Insertions = insertions
@@ -567,6 +744,7 @@ func (a *GitActivities) CaptureGitDiffActivity(ctx context.Context, input types.
 
 	logger.Info("Successfully captured git diff",
 		"filesChanged", len(output.FilesChanged),
+		"significantFilesChanged", len(output.SignificantFilesChanged),
 		"insertions", output.Insertions,
 		"deletions", output.Deletions,
 		"hasChanges", output.HasChanges)
@@ -6,7 +6,9 @@ package activities
 import (
 	"context"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/noldarim/noldarim/internal/config"
@@ -272,3 +274,167 @@ func TestCaptureGitDiffActivity_WithDeletions(t *testing.T) {
 	assert.Equal(t, 0, result.Insertions, "Should have 0 insertions")
 	assert.Equal(t, 3, result.Deletions, "Should have 3 deletions")
 }
+
+func TestGitCommitActivity_RepairsDetachedWorktree(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+
+	tmpDir := t.TempDir()
+	repoPath := filepath.Join(tmpDir, "test-repo")
+	cfg := &config.AppConfig{
+		Git: config.GitConfig{
+			WorktreeBasePath: tmpDir,
+		},
+	}
+
+	gitService, err := services.NewGitServiceWithConfig(repoPath, cfg, true)
+	require.NoError(t, err)
+	defer gitService.Close()
+
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "base.txt"), []byte("base\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Initial commit"))
+	baseCommit, err := gitService.GetHeadCommitSHA(ctx, repoPath)
+	require.NoError(t, err)
+
+	taskID := "detached-run"
+	worktreeManager := services.NewWorktreeManager(gitService, repoPath)
+	worktreePath, err := worktreeManager.CreateWorktreeFromCommit(ctx, taskID, baseCommit)
+	require.NoError(t, err)
+	defer worktreeManager.CleanupAgentWorktrees(ctx, taskID)
+
+	// Simulate an agent leaving the worktree on a detached HEAD
+	require.NoError(t, exec.Command("git", "-C", worktreePath, "checkout", "--detach").Run())
+	require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "agent.txt"), []byte("agent work\n"), 0644))
+
+	gitActivities := NewGitActivities(services.NewGitServiceManager(cfg))
+	env.RegisterActivity(gitActivities.GitCommitActivity)
+
+	val, err := env.ExecuteActivity(gitActivities.GitCommitActivity, types.GitCommitActivityInput{
+		RepositoryPath: worktreePath,
+		FileNames:      []string{"."},
+		CommitMessage:  "Step 1: agent work",
+	})
+	require.NoError(t, err)
+
+	var result types.GitCommitActivityOutput
+	require.NoError(t, val.Get(&result))
+	assert.True(t, result.Success)
+
+	branch, err := gitService.GetWorktreeBranch(worktreePath)
+	require.NoError(t, err)
+	assert.Equal(t, services.GenerateTaskBranchName(taskID), branch)
+
+	branchHead, err := gitService.GetBranchHeadSHA(ctx, repoPath, branch)
+	require.NoError(t, err)
+	assert.Equal(t, result.CommitSHA, branchHead, "commit lands on the task branch")
+}
+
+func TestCreateWorktreeActivity_RepairsDetachedPipelineWorktree(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+
+	repoPath := filepath.Join(t.TempDir(), "test-repo")
+	cfg := &config.AppConfig{} // Worktrees under the repository, where the activity looks for them
+
+	gitService, err := services.NewGitServiceWithConfig(repoPath, cfg, true)
+	require.NoError(t, err)
+	defer gitService.Close()
+
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "base.txt"), []byte("base\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Initial commit"))
+	baseCommit, err := gitService.GetHeadCommitSHA(ctx, repoPath)
+	require.NoError(t, err)
+
+	gitActivities := NewGitActivities(services.NewGitServiceManager(cfg))
+	env.RegisterActivity(gitActivities.CreateWorktreeActivity)
+
+	input := types.CreateWorktreeActivityInput{
+		TaskID:         "run-12345678",
+		BranchName:     "pipeline/run-1234",
+		RepositoryPath: repoPath,
+		BaseCommitSHA:  baseCommit,
+	}
+	val, err := env.ExecuteActivity(gitActivities.CreateWorktreeActivity, input)
+	require.NoError(t, err)
+	var created types.CreateWorktreeActivityOutput
+	require.NoError(t, val.Get(&created))
+	defer services.NewWorktreeManager(gitService, repoPath).CleanupAgentWorktrees(ctx, input.TaskID)
+	assert.Equal(t, input.BranchName, created.BranchName)
+
+	// Simulate an agent leaving the worktree on a detached HEAD, then a retried setup
+	require.NoError(t, exec.Command("git", "-C", created.WorktreePath, "checkout", "--detach").Run())
+	val, err = env.ExecuteActivity(gitActivities.CreateWorktreeActivity, input)
+	require.NoError(t, err)
+	var repaired types.CreateWorktreeActivityOutput
+	require.NoError(t, val.Get(&repaired))
+
+	assert.Equal(t, created.WorktreePath, repaired.WorktreePath)
+	assert.Equal(t, input.BranchName, repaired.BranchName)
+	branch, err := gitService.GetWorktreeBranch(created.WorktreePath)
+	require.NoError(t, err)
+	assert.Equal(t, input.BranchName, branch, "repaired onto the pipeline branch, not the task branch")
+}
+
+func TestGitCommitActivity_RendersCommitTemplate(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+
+	repoPath := filepath.Join(t.TempDir(), "test-repo")
+	cfg := &config.AppConfig{}
+
+	gitService, err := services.NewGitServiceWithConfig(repoPath, cfg, true)
+	require.NoError(t, err)
+	defer gitService.Close()
+
+	ctx := context.Background()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "base.txt"), []byte("base\n"), 0644))
+	require.NoError(t, gitService.CreateCommit(ctx, repoPath, "Initial commit"))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "agent.txt"), []byte("agent work\n"), 0644))
+
+	gitActivities := NewGitActivities(services.NewGitServiceManager(cfg))
+	env.RegisterActivity(gitActivities.GitCommitActivity)
+
+	val, err := env.ExecuteActivity(gitActivities.GitCommitActivity, types.GitCommitActivityInput{
+		RepositoryPath: repoPath,
+		FileNames:      []string{"."},
+		CommitMessage:  "Step main: fallback",
+		CommitTemplate: "{{.TaskTitle}}\n\nTask: {{.TaskID}}\nFiles changed: {{.FilesChanged}}, tokens: {{.Tokens}}",
+		TemplateVars: types.CommitTemplateVars{
+			TaskTitle:    "Corriger l'analyseur\u202e « naïf »",
+			TaskID:       "run-42",
+			FilesChanged: 1,
+			Tokens:       1234,
+		},
+	})
+	require.NoError(t, err)
+
+	var result types.GitCommitActivityOutput
+	require.NoError(t, val.Get(&result))
+	require.True(t, result.Success)
+
+	expected := "Corriger l'analyseur « naïf »\n\nTask: run-42\nFiles changed: 1, tokens: 1234"
+	assert.Equal(t, expected, result.CommitMessage)
+
+	logged, err := exec.Command("git", "-C", repoPath, "log", "-1", "--format=%B").Output()
+	require.NoError(t, err)
+	assert.Equal(t, expected, strings.TrimSpace(string(logged)))
+}
+
+func TestRenderCommitMessage_RejectsUnsafeTitle(t *testing.T) {
+	_, err := renderCommitMessage("{{.TaskTitle}}", types.CommitTemplateVars{TaskTitle: "Fix a|b; retry && $(cleanup)"})
+	assert.Error(t, err)
+
+	message, err := renderCommitMessage("{{.TaskTitle}} ({{.TaskID}})", types.CommitTemplateVars{TaskTitle: "R&D: naïve café", TaskID: "run-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "R&D: naïve café (run-1)", message)
+}
+
+func TestRenderCommitMessage_UndefinedVariable(t *testing.T) {
+	_, err := renderCommitMessage("{{.TaskTitle}} by {{.Author}}", types.CommitTemplateVars{TaskTitle: "Title"})
+	assert.Error(t, err)
+
+	_, err = renderCommitMessage("  ", types.CommitTemplateVars{})
+	assert.Error(t, err)
+}
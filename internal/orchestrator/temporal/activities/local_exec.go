@@ -6,8 +6,10 @@ package activities
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"strings"
 	"sync"
@@ -17,6 +19,7 @@ import (
 	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/temporal"
 	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+	"github.com/noldarim/noldarim/internal/protocol"
 )
 
 // HeartbeatInterval is how often we send heartbeats during command execution
@@ -35,13 +38,38 @@ const ReadBufferSize = 4096
 const PartialLineFlushInterval = 2 * time.Second
 
 // LocalExecutionActivities provides activities that run locally using os/exec
-type LocalExecutionActivities struct{}
+type LocalExecutionActivities struct {
+	defaultEnv map[string]string // agent.default_env, layered under each command's own env
+}
 
 // NewLocalExecutionActivities creates a new instance of LocalExecutionActivities
 func NewLocalExecutionActivities() *LocalExecutionActivities {
 	return &LocalExecutionActivities{}
 }
 
+// WithDefaultEnv sets the env every command gets unless its own env sets the same name
+func (a *LocalExecutionActivities) WithDefaultEnv(env map[string]string) *LocalExecutionActivities {
+	a.defaultEnv = env
+	return a
+}
+
+// AgentDefaultEnvFromEnviron decodes the agent.default_env the orchestrator passed to this
+// container in protocol.AgentDefaultEnvVar; nil when it passed none
+func AgentDefaultEnvFromEnviron() (map[string]string, error) {
+	encoded := os.Getenv(protocol.AgentDefaultEnvVar)
+	if encoded == "" {
+		return nil, nil
+	}
+	var env map[string]string
+	if err := json.Unmarshal([]byte(encoded), &env); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", protocol.AgentDefaultEnvVar, err)
+	}
+	if err := protocol.ValidateAgentEnv(env); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", protocol.AgentDefaultEnvVar, err)
+	}
+	return env, nil
+}
+
 // ExecutionProgress holds the current state of command execution for heartbeats
 type ExecutionProgress struct {
 	Phase          string   `json:"phase"`            // Current phase: "starting", "running", "completed"
@@ -51,6 +79,8 @@ type ExecutionProgress struct {
 	RecentOutput   []string `json:"recent_output"`    // Last N lines of output (for debugging)
 	Command        string   `json:"command_preview"`  // First part of command (for identification)
 	Truncated      bool     `json:"truncated"`        // Whether output was truncated due to size limit
+
+	Env map[string]string `json:"env,omitempty"` // Injected env with secrets redacted (starting heartbeat only)
 }
 
 // outputCollector collects output from a pipe with support for:
@@ -198,10 +228,13 @@ func (a *LocalExecutionActivities) LocalExecuteActivity(ctx context.Context, inp
 
 	// Log the full command for observability (including any prompts)
 	commandPreview := formatCommandForLogging(input.Command)
+	envPreview := protocol.RedactEnv(input.Env)
 	logger.Info("LocalExecuteActivity starting",
 		"command", input.Command,
 		"commandPreview", commandPreview,
 		"workDir", input.WorkDir,
+		"env", envPreview,
+		"defaultEnvNames", protocol.SortedEnvNames(a.defaultEnv),
 		"commandLength", len(input.Command))
 
 	// Log each argument separately for very long prompts
@@ -219,6 +252,7 @@ func (a *LocalExecutionActivities) LocalExecuteActivity(ctx context.Context, inp
 		Phase:        "starting",
 		Command:      commandPreview,
 		RecentOutput: []string{},
+		Env:          envPreview,
 	})
 
 	// Validate command
@@ -241,6 +275,15 @@ func (a *LocalExecutionActivities) LocalExecuteActivity(ctx context.Context, inp
 		cmd.Dir = input.WorkDir
 	}
 
+	// Injected env, over the default env, is added to the worker's own environment in a
+	// stable order. The default env is applied only here so its values stay out of history.
+	if injected := protocol.MergeAgentConfig(&protocol.AgentConfigInput{Env: a.defaultEnv}, &protocol.AgentConfigInput{Env: input.Env}).Env; len(injected) > 0 {
+		cmd.Env = os.Environ()
+		for _, name := range protocol.SortedEnvNames(injected) {
+			cmd.Env = append(cmd.Env, name+"="+injected[name])
+		}
+	}
+
 	// Create output collectors
 	stdoutCollector := newOutputCollector(false)
 	stderrCollector := newOutputCollector(true)
@@ -12,6 +12,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+	"github.com/noldarim/noldarim/internal/protocol"
 )
 
 // =============================================================================
@@ -289,6 +295,107 @@ func TestLocalExecuteActivity_SimpleCommand(t *testing.T) {
 	t.Skip("Requires Temporal test environment setup - see integration tests")
 }
 
+// TestLocalExecuteActivity_InjectsEnv runs a real shell command: the injected env reaches
+// the process while the starting heartbeat only carries redacted values
+func TestLocalExecuteActivity_InjectsEnv(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+	env.RegisterActivity(NewLocalExecutionActivities().LocalExecuteActivity)
+
+	var mu sync.Mutex
+	var heartbeats []ExecutionProgress
+	env.SetOnActivityHeartbeatListener(func(_ *activity.Info, details converter.EncodedValues) {
+		var progress ExecutionProgress
+		if details.Get(&progress) == nil {
+			mu.Lock()
+			heartbeats = append(heartbeats, progress)
+			mu.Unlock()
+		}
+	})
+
+	val, err := env.ExecuteActivity(NewLocalExecutionActivities().LocalExecuteActivity, types.LocalExecuteActivityInput{
+		// The sleep keeps the pipes open until the readers have drained the output
+		Command: []string{"sh", "-c", `echo "$AGENT_MODE $ANTHROPIC_API_KEY"; sleep 0.2`},
+		Env: map[string]string{
+			"AGENT_MODE":        "ci",
+			"ANTHROPIC_API_KEY": "sk-ant-secret",
+		},
+	})
+	require.NoError(t, err)
+
+	var out types.LocalExecuteActivityOutput
+	require.NoError(t, val.Get(&out))
+	assert.True(t, out.Success)
+	assert.Equal(t, "ci sk-ant-secret\n", out.Output)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, heartbeats)
+	assert.Equal(t, "starting", heartbeats[0].Phase)
+	assert.Equal(t, map[string]string{
+		"AGENT_MODE":        "ci",
+		"ANTHROPIC_API_KEY": protocol.RedactedValue,
+	}, heartbeats[0].Env)
+}
+
+// TestLocalExecuteActivity_AppliesDefaultEnv checks that the command's own env wins over
+// the default env per variable, and that default values stay out of the heartbeat
+func TestLocalExecuteActivity_AppliesDefaultEnv(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+	activities := NewLocalExecutionActivities().WithDefaultEnv(map[string]string{
+		"AGENT_MODE":        "ci",
+		"ANTHROPIC_API_KEY": "sk-ant-default",
+	})
+	env.RegisterActivity(activities.LocalExecuteActivity)
+
+	var mu sync.Mutex
+	var heartbeats []ExecutionProgress
+	env.SetOnActivityHeartbeatListener(func(_ *activity.Info, details converter.EncodedValues) {
+		var progress ExecutionProgress
+		if details.Get(&progress) == nil {
+			mu.Lock()
+			heartbeats = append(heartbeats, progress)
+			mu.Unlock()
+		}
+	})
+
+	val, err := env.ExecuteActivity(activities.LocalExecuteActivity, types.LocalExecuteActivityInput{
+		Command: []string{"sh", "-c", `echo "$AGENT_MODE $ANTHROPIC_API_KEY"; sleep 0.2`},
+		Env:     map[string]string{"AGENT_MODE": "debug"},
+	})
+	require.NoError(t, err)
+
+	var out types.LocalExecuteActivityOutput
+	require.NoError(t, val.Get(&out))
+	assert.Equal(t, "debug sk-ant-default\n", out.Output)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, heartbeats)
+	assert.Equal(t, map[string]string{"AGENT_MODE": "debug"}, heartbeats[0].Env)
+}
+
+func TestAgentDefaultEnvFromEnviron(t *testing.T) {
+	t.Setenv(protocol.AgentDefaultEnvVar, "")
+	env, err := AgentDefaultEnvFromEnviron()
+	require.NoError(t, err)
+	assert.Nil(t, env)
+
+	t.Setenv(protocol.AgentDefaultEnvVar, `{"AGENT_MODE":"ci"}`)
+	env, err = AgentDefaultEnvFromEnviron()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"AGENT_MODE": "ci"}, env)
+
+	t.Setenv(protocol.AgentDefaultEnvVar, `{"PATH":"/tmp"}`)
+	_, err = AgentDefaultEnvFromEnviron()
+	assert.Error(t, err)
+
+	t.Setenv(protocol.AgentDefaultEnvVar, `not json`)
+	_, err = AgentDefaultEnvFromEnviron()
+	assert.Error(t, err)
+}
+
 // =============================================================================
 // Benchmark tests
 // =============================================================================
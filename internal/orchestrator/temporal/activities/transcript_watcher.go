@@ -98,7 +98,8 @@ func (a *TranscriptWatcherActivities) watchWithParser(
 		return a.watchRawMode(ctx, input, parentWorkflowID)
 	}
 
-	// Discover transcript sources
+	// Discover transcript sources. Delta mode (RunContext.ToolResultDeltas) stays off: every
+	// delta would be saved and published as its own record, and no feed here merges them.
 	runCtx := aiobsTypes.RunContext{
 		TaskID:    input.TaskID,
 		RunID:     input.RunID,
@@ -159,6 +160,9 @@ func (a *TranscriptWatcherActivities) watchWithParser(
 			DiscoverUUID:    true,
 			RawMode:         true,
 		}
+		if input.ResumeOffsets {
+			cfg.ResumeFrom = watcher.NewFileOffsetStore()
+		}
 
 		w, err = watcher.NewTranscriptWatcher(ctx, cfg)
 		if err != nil {
@@ -200,6 +204,18 @@ func (a *TranscriptWatcherActivities) watchWithParser(
 	heartbeatTicker := time.NewTicker(10 * time.Second)
 	defer heartbeatTicker.Stop()
 
+	// Idle detection counts lines from every stream
+	var sseLines int64
+	idle := newIdleMonitor(input.IdleTimeout, func() int64 {
+		n := sseLines
+		if w != nil {
+			n += w.Stats().LinesRead
+		}
+		return n
+	}, time.Now())
+	idleTick, stopIdleTicker := idle.ticker()
+	defer stopIdleTicker()
+
 	eventsCount := 0
 	batchesSent := 0
 
@@ -242,9 +258,12 @@ func (a *TranscriptWatcherActivities) watchWithParser(
 
 		parsedEvents, err := parser.OnLine(ctx, streamID, rawLine.Line)
 		if err != nil {
-			logger.Warn("Parser error", "error", err, "sourceFile", rawLine.SourceFile)
+			logger.Warn("Parser error", "error", err, "sourceFile", rawLine.SourceFile, "sourceLine", rawLine.SourceLine)
 			return
 		}
+		for i := range parsedEvents {
+			parsedEvents[i].SourceLine = rawLine.SourceLine
+		}
 
 		if len(parsedEvents) > 0 {
 			parsedBatch = append(parsedBatch, types.ParsedTranscriptEvent{
@@ -307,10 +326,10 @@ func (a *TranscriptWatcherActivities) watchWithParser(
 		case <-doneChan:
 			flushParser()
 			if w != nil {
-				stats := w.Stats()
+				final, _ := w.FinalStats()
 				logger.Info("Watcher done",
 					"taskID", input.TaskID,
-					"linesRead", stats.LinesRead,
+					"summary", final.String(),
 					"eventsForwarded", eventsCount)
 			}
 			output.Success = true
@@ -333,8 +352,14 @@ func (a *TranscriptWatcherActivities) watchWithParser(
 			if !ok {
 				continue
 			}
+			sseLines++
 			processLine(rawLine, "sse")
 
+		case now := <-idleTick:
+			if notice, isIdle := idle.check(now); isIdle {
+				a.signalIdle(ctx, parentWorkflowID, input, notice)
+			}
+
 		case err := <-errorChan:
 			logger.Warn("Watcher error", "error", err, "taskID", input.TaskID)
 
@@ -383,6 +408,9 @@ func (a *TranscriptWatcherActivities) watchRawMode(
 		DiscoverUUID:    true,
 		RawMode:         true,
 	}
+	if input.ResumeOffsets {
+		cfg.ResumeFrom = watcher.NewFileOffsetStore()
+	}
 
 	w, err := watcher.NewTranscriptWatcher(ctx, cfg)
 	if err != nil {
@@ -409,6 +437,10 @@ func (a *TranscriptWatcherActivities) watchRawMode(
 	heartbeatTicker := time.NewTicker(10 * time.Second)
 	defer heartbeatTicker.Stop()
 
+	idle := newIdleMonitor(input.IdleTimeout, func() int64 { return w.Stats().LinesRead }, time.Now())
+	idleTick, stopIdleTicker := idle.ticker()
+	defer stopIdleTicker()
+
 	eventsCount := 0
 	batchesSent := 0
 
@@ -455,10 +487,10 @@ func (a *TranscriptWatcherActivities) watchRawMode(
 
 		case <-doneChan:
 			flushBatch()
-			stats := w.Stats()
+			final, _ := w.FinalStats()
 			logger.Info("Watcher done",
 				"taskID", input.TaskID,
-				"linesRead", stats.LinesRead,
+				"summary", final.String(),
 				"linesForwarded", eventsCount)
 			output.Success = true
 			output.EventsCount = eventsCount
@@ -482,6 +514,7 @@ func (a *TranscriptWatcherActivities) watchRawMode(
 				TaskID:     input.TaskID,
 				ProjectID:  input.ProjectID,
 				SourceFile: rawLine.SourceFile,
+				SourceLine: rawLine.SourceLine,
 			}
 			batch = append(batch, rawEvent)
 
@@ -495,6 +528,11 @@ func (a *TranscriptWatcherActivities) watchRawMode(
 		case <-batchTicker.C:
 			flushBatch()
 
+		case now := <-idleTick:
+			if notice, isIdle := idle.check(now); isIdle {
+				a.signalIdle(ctx, parentWorkflowID, input, notice)
+			}
+
 		case <-heartbeatTicker.C:
 			stats := w.Stats()
 			activity.RecordHeartbeat(ctx, map[string]interface{}{
@@ -509,6 +547,77 @@ func (a *TranscriptWatcherActivities) watchRawMode(
 	}
 }
 
+// signalIdle tells the parent workflow that the agent has stopped producing transcript lines
+func (a *TranscriptWatcherActivities) signalIdle(ctx context.Context, parentWorkflowID string, input types.WatchTranscriptActivityInput, notice types.AgentIdleNotice) {
+	logger := activity.GetLogger(ctx)
+	logger.Warn("Agent idle: no new transcript lines",
+		"taskID", input.TaskID,
+		"idleFor", notice.IdleFor,
+		"linesRead", notice.LinesRead)
+
+	signalCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := a.temporalClient.SignalWorkflow(signalCtx, parentWorkflowID, "", types.AgentIdleSignal, notice); err != nil {
+		logger.Warn("Failed to signal parent workflow that the agent is idle", "error", err, "taskID", input.TaskID)
+	}
+}
+
+// idleMonitor detects an agent that stopped producing transcript lines by sampling the
+// watcher's line count. Any new line resets the timeout; while no line arrives, idleness
+// is reported once per timeout.
+type idleMonitor struct {
+	timeout    time.Duration
+	lines      func() int64
+	lastLines  int64
+	lastChange time.Time // When the line count last moved
+	reported   time.Time // When idleness was last reported; zero since the last line
+}
+
+// newIdleMonitor returns nil, which never reports, when timeout is not positive
+func newIdleMonitor(timeout time.Duration, lines func() int64, now time.Time) *idleMonitor {
+	if timeout <= 0 {
+		return nil
+	}
+	return &idleMonitor{timeout: timeout, lines: lines, lastLines: lines(), lastChange: now}
+}
+
+// ticker returns the channel to call check on; it never fires for a nil monitor
+func (m *idleMonitor) ticker() (<-chan time.Time, func()) {
+	if m == nil {
+		return nil, func() {}
+	}
+	interval := min(m.timeout/4, 5*time.Second)
+	if interval <= 0 {
+		interval = m.timeout
+	}
+	t := time.NewTicker(interval)
+	return t.C, t.Stop
+}
+
+// check samples the line count at now and reports the agent as idle when a full timeout has
+// passed since the last line or the last report
+func (m *idleMonitor) check(now time.Time) (types.AgentIdleNotice, bool) {
+	if m == nil {
+		return types.AgentIdleNotice{}, false
+	}
+	if n := m.lines(); n != m.lastLines {
+		m.lastLines = n
+		m.lastChange = now
+		m.reported = time.Time{}
+		return types.AgentIdleNotice{}, false
+	}
+
+	since := m.lastChange
+	if !m.reported.IsZero() {
+		since = m.reported
+	}
+	if now.Sub(since) < m.timeout {
+		return types.AgentIdleNotice{}, false
+	}
+	m.reported = now
+	return types.AgentIdleNotice{IdleFor: now.Sub(m.lastChange), LinesRead: m.lastLines}, true
+}
+
 // hasSSEStream checks if any stream spec has type "sse".
 func hasSSEStream(streams []aiobsTypes.StreamSpec) bool {
 	for _, s := range streams {
@@ -134,3 +134,56 @@ func TestSourceDefault_Logic(t *testing.T) {
 // For true end-to-end testing with real Temporal, use integration tests
 // that spin up actual workflows and containers.
 // =============================================================================
+
+func TestIdleMonitor_ReportsWatcherThatStopsProducing(t *testing.T) {
+	// A simulated watcher: lines arrive for a while, then stop
+	var linesRead int64
+	start := time.Now()
+	idle := newIdleMonitor(time.Minute, func() int64 { return linesRead }, start)
+
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+
+	for i := 1; i <= 5; i++ {
+		linesRead += 3
+		_, isIdle := idle.check(at(time.Duration(i) * 30 * time.Second))
+		assert.False(t, isIdle, "agent producing lines is not idle")
+	}
+	lastLine := 150 * time.Second
+
+	_, isIdle := idle.check(at(lastLine + 59*time.Second))
+	assert.False(t, isIdle, "idle for less than the timeout")
+
+	notice, isIdle := idle.check(at(lastLine + time.Minute))
+	require.True(t, isIdle, "idle event fires once the timeout passes without new lines")
+	assert.Equal(t, time.Minute, notice.IdleFor)
+	assert.Equal(t, int64(15), notice.LinesRead)
+
+	_, isIdle = idle.check(at(lastLine + 90*time.Second))
+	assert.False(t, isIdle, "reported once per timeout")
+
+	notice, isIdle = idle.check(at(lastLine + 2*time.Minute))
+	require.True(t, isIdle, "still idle a timeout later")
+	assert.Equal(t, 2*time.Minute, notice.IdleFor)
+
+	// A new line resets the timeout
+	linesRead++
+	_, isIdle = idle.check(at(lastLine + 3*time.Minute))
+	assert.False(t, isIdle)
+	_, isIdle = idle.check(at(lastLine + 3*time.Minute + 59*time.Second))
+	assert.False(t, isIdle)
+	notice, isIdle = idle.check(at(lastLine + 4*time.Minute))
+	require.True(t, isIdle)
+	assert.Equal(t, time.Minute, notice.IdleFor)
+}
+
+func TestIdleMonitor_Disabled(t *testing.T) {
+	idle := newIdleMonitor(0, func() int64 { return 0 }, time.Now())
+	assert.Nil(t, idle)
+
+	tick, stop := idle.ticker()
+	defer stop()
+	assert.Nil(t, tick)
+
+	_, isIdle := idle.check(time.Now().Add(time.Hour))
+	assert.False(t, isIdle)
+}
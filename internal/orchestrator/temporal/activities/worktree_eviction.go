@@ -0,0 +1,129 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/common"
+	"github.com/noldarim/noldarim/internal/config"
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/orchestrator/services"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+	"github.com/noldarim/noldarim/internal/protocol"
+
+	"go.temporal.io/sdk/activity"
+	"gorm.io/gorm"
+)
+
+// WorktreeEvictionActivities enforces git.max_worktrees by evicting worktrees of finished tasks
+type WorktreeEvictionActivities struct {
+	manager     *services.GitServiceManager
+	dataService *services.DataService
+	eventChan   chan<- common.Event
+	config      *config.AppConfig
+}
+
+// NewWorktreeEvictionActivities creates a new instance of WorktreeEvictionActivities
+func NewWorktreeEvictionActivities(manager *services.GitServiceManager, dataService *services.DataService, eventChan chan<- common.Event, config *config.AppConfig) *WorktreeEvictionActivities {
+	return &WorktreeEvictionActivities{
+		manager:     manager,
+		dataService: dataService,
+		eventChan:   eventChan,
+		config:      config,
+	}
+}
+
+// EvictWorktreesActivity makes room for a new worktree in the repository by evicting the
+// oldest worktrees owned by finished pipeline runs or tasks. Worktrees of running owners and
+// worktrees with uncommitted changes are never evicted. No-op when git.max_worktrees is 0.
+func (a *WorktreeEvictionActivities) EvictWorktreesActivity(ctx context.Context, input types.EvictWorktreesActivityInput) (*types.EvictWorktreesActivityOutput, error) {
+	logger := activity.GetLogger(ctx)
+	output := &types.EvictWorktreesActivityOutput{}
+
+	maxWorktrees := 0
+	if a.config != nil {
+		maxWorktrees = a.config.Git.MaxWorktrees
+	}
+	if maxWorktrees <= 0 {
+		return output, nil
+	}
+
+	if input.RepositoryPath == "" {
+		return nil, fmt.Errorf("repository path must be provided")
+	}
+
+	activity.RecordHeartbeat(ctx, "Evicting worktrees")
+
+	handle, err := a.manager.GetService(input.RepositoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git service handle: %w", err)
+	}
+	defer handle.Release()
+
+	evicted, err := handle.EvictWorktrees(ctx, maxWorktrees, a.isOwnerFinished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evict worktrees: %w", err)
+	}
+
+	for _, wt := range evicted {
+		logger.Info("Evicted worktree", "taskID", wt.TaskID, "worktreePath", wt.Path)
+		output.EvictedPaths = append(output.EvictedPaths, wt.Path)
+		a.publishEvicted(ctx, input, wt)
+	}
+
+	return output, nil
+}
+
+// isOwnerFinished reports whether the worktree owner has finished. Pipeline worktrees are
+// keyed by run ID, legacy task worktrees by task ID. Unknown owners are treated as not finished.
+func (a *WorktreeEvictionActivities) isOwnerFinished(ctx context.Context, ownerID string) (bool, error) {
+	run, err := a.dataService.GetPipelineRun(ctx, ownerID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get pipeline run: %w", err)
+	}
+	if run != nil {
+		return run.Status == models.PipelineRunStatusCompleted || run.Status == models.PipelineRunStatusFailed, nil
+	}
+
+	task, err := a.dataService.GetTask(ctx, ownerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get task: %w", err)
+	}
+	return task.Status == models.TaskStatusCompleted || task.Status == models.TaskStatusFailed, nil
+}
+
+// publishEvicted sends a WorktreeEvictedEvent; failures are logged but not returned
+// since the worktree is already gone.
+func (a *WorktreeEvictionActivities) publishEvicted(ctx context.Context, input types.EvictWorktreesActivityInput, wt services.WorktreeInfo) {
+	if a.eventChan == nil {
+		return
+	}
+
+	event := protocol.WorktreeEvictedEvent{
+		Metadata: protocol.Metadata{
+			TaskID:         wt.TaskID,
+			IdempotencyKey: fmt.Sprintf("worktree-evicted-%s-%s", input.ProjectID, wt.TaskID),
+			Version:        protocol.CurrentProtocolVersion,
+		},
+		ProjectID:      input.ProjectID,
+		RepositoryPath: input.RepositoryPath,
+		TaskID:         wt.TaskID,
+		WorktreePath:   wt.Path,
+		Branch:         wt.Branch,
+	}
+
+	select {
+	case a.eventChan <- event:
+	case <-time.After(5 * time.Second):
+		activity.GetLogger(ctx).Warn("Timed out publishing worktree evicted event", "taskID", wt.TaskID)
+	case <-ctx.Done():
+	}
+}
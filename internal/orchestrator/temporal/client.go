@@ -11,6 +11,7 @@ import (
 
 	"github.com/rs/zerolog"
 	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
 
 	"github.com/noldarim/noldarim/internal/logger"
@@ -209,6 +210,47 @@ func (c *Client) GetWorkflowStatus(ctx context.Context, workflowID string) (Work
 	return MapWorkflowExecutionStatus(desc.WorkflowExecutionInfo.Status), nil
 }
 
+// WorkflowExecution identifies one execution returned by a visibility query
+type WorkflowExecution struct {
+	WorkflowID string
+	RunID      string
+	StartTime  time.Time
+}
+
+// ListRunningWorkflows returns the running executions of workflowType in the client's namespace
+func (c *Client) ListRunningWorkflows(ctx context.Context, workflowType string) ([]WorkflowExecution, error) {
+	query := fmt.Sprintf("WorkflowType = '%s' AND ExecutionStatus = 'Running'", workflowType)
+
+	var executions []WorkflowExecution
+	var nextPageToken []byte
+	for {
+		resp, err := c.temporalClient.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     c.namespace,
+			Query:         query,
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workflows: %w", err)
+		}
+
+		for _, info := range resp.GetExecutions() {
+			execution := WorkflowExecution{
+				WorkflowID: info.GetExecution().GetWorkflowId(),
+				RunID:      info.GetExecution().GetRunId(),
+			}
+			if info.GetStartTime() != nil {
+				execution.StartTime = info.GetStartTime().AsTime()
+			}
+			executions = append(executions, execution)
+		}
+
+		nextPageToken = resp.GetNextPageToken()
+		if len(nextPageToken) == 0 {
+			return executions, nil
+		}
+	}
+}
+
 // CancelWorkflow requests cancellation of a running workflow.
 // The workflow will receive a cancellation signal and can clean up gracefully.
 func (c *Client) CancelWorkflow(ctx context.Context, workflowID string) error {
@@ -31,6 +31,7 @@ type RawTranscriptEvent struct {
 	ProjectID string `json:"project_id"`
 
 	SourceFile string `json:"source_file"`
+	SourceLine int    `json:"source_line,omitempty"`
 }
 
 // RawTranscriptBatch is a batch of raw transcript events.
@@ -87,6 +88,10 @@ type ParseEventInput struct {
 	// RawPayload is the raw JSON line to parse
 	// This is passed directly to avoid a database round-trip
 	RawPayload json.RawMessage `json:"raw_payload"`
+
+	// Origin of the line, copied onto the parsed records
+	SourceFile string `json:"source_file,omitempty"`
+	SourceLine int    `json:"source_line,omitempty"`
 }
 
 // ParsedTranscriptEvent contains parsed events from a single transcript line.
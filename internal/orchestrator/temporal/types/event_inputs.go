@@ -4,7 +4,10 @@
 package types
 
 import (
+	"time"
+
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/protocol"
 )
 
 // PublishEventInput is a unified input type for all event publishing activities.
@@ -24,6 +27,8 @@ type PublishEventInput struct {
 	AIRecord *models.AIActivityRecord
 	// Status is used for TaskStatusUpdated events
 	Status models.TaskStatus
+	// ResumedFrom is used for TaskInProgress events of a retry resuming from a checkpoint
+	ResumedFrom string
 }
 
 // PublishErrorEventInput remains separate as it has different fields
@@ -32,3 +37,51 @@ type PublishErrorEventInput struct {
 	ErrorContext string
 	TaskID       string
 }
+
+// PublishBudgetExceededEventInput holds the data for a BudgetExceededEvent
+type PublishBudgetExceededEventInput struct {
+	ProjectID  string
+	TaskID     string
+	RunID      string
+	Budget     int
+	TokensUsed int
+	Cancelled  bool
+}
+
+// PublishBranchAssemblyEventInput holds the data for BranchAssemblyProgressEvent and
+// BranchAssembledEvent. Progress events report the last of Sources.
+type PublishBranchAssemblyEventInput struct {
+	Metadata     protocol.Metadata // Of the AssembleBranchCommand; used by the final event only
+	ProjectID    string
+	TargetBranch string
+	BaseBranch   string
+	Strategy     protocol.AssembleStrategy
+	HeadSHA      string
+	Sources      []protocol.AssembleSourceResult
+	Total        int
+	Error        string // Set when the assembly failed; the final event is then an ErrorEvent
+}
+
+// PublishTaskRebasedEventInput holds the data for a TaskRebasedEvent
+type PublishTaskRebasedEventInput struct {
+	Metadata   protocol.Metadata // Of the RebaseTaskCommand
+	ProjectID  string
+	TaskID     string
+	Branch     string
+	Onto       string
+	OldHeadSHA string
+	NewHeadSHA string
+	UpToDate   bool
+	Conflicts  []string
+	Error      string // Set when the rebase failed; the event is then an ErrorEvent
+}
+
+// PublishAgentIdleEventInput holds the data for an AgentIdleEvent
+type PublishAgentIdleEventInput struct {
+	ProjectID  string
+	TaskID     string
+	RunID      string
+	StepID     string
+	IdleFor    time.Duration
+	Finalizing bool
+}
@@ -33,6 +33,19 @@ type PipelineWorkflowInput struct {
 	BaseCommitSHA  string `json:"base_commit_sha"` // Starting commit (HEAD if empty)
 	BranchName     string `json:"branch_name"`     // Branch to create for this run
 
+	// Repository-relative directory the agent works in; diffs and commits are scoped to it
+	WorkingSubdir string `json:"working_subdir,omitempty"`
+
+	// Commit message template for step commits (empty = "Step <id>: <name>")
+	CommitTemplate string `json:"commit_template,omitempty"`
+
+	// Labels attached to the run when its record is created
+	Labels []string `json:"labels,omitempty"`
+
+	// Live diff streaming while each step's agent runs (0 interval = disabled)
+	DiffStreamInterval time.Duration `json:"diff_stream_interval,omitempty"`
+	DiffStreamMaxBytes int           `json:"diff_stream_max_bytes,omitempty"`
+
 	// Fork configuration (optional - for branching from previous run)
 	ForkFromRunID   string `json:"fork_from_run_id,omitempty"`
 	ForkAfterStepID string `json:"fork_after_step_id,omitempty"`
@@ -48,6 +61,14 @@ type PipelineWorkflowInput struct {
 
 	// Auto-promote: queue for merge into main on successful completion
 	AutoPromote bool `json:"auto_promote,omitempty"`
+
+	// Token budget for the whole run (0 = unlimited); HardBudget cancels the run once exceeded
+	TokenBudget int  `json:"token_budget,omitempty"`
+	HardBudget  bool `json:"hard_budget,omitempty"`
+
+	// Idle agent detection (0 timeout = disabled); FinalizeOnIdle commits an idle agent's work
+	IdleTimeout    time.Duration `json:"idle_timeout,omitempty"`
+	FinalizeOnIdle bool          `json:"finalize_on_idle,omitempty"`
 }
 
 // PipelineWorkflowOutput represents the output from the PipelineWorkflow
@@ -98,6 +119,12 @@ type PipelineSetupInput struct {
 
 	// Auto-promote flag (persisted to DB for display)
 	AutoPromote bool `json:"auto_promote,omitempty"`
+
+	// Labels persisted with the run record
+	Labels []string `json:"labels,omitempty"`
+
+	// Agent working directory persisted with the run record, so a retry can reuse it
+	WorkingSubdir string `json:"working_subdir,omitempty"`
 }
 
 // PipelineSetupOutput represents output from the setup phase
@@ -122,14 +149,22 @@ type ProcessingStepInput struct {
 	StepID    string `json:"step_id"`    // e.g., "1a", "1b"
 	StepIndex int    `json:"step_index"` // 0, 1, 2...
 	StepName  string `json:"step_name"`
+	TaskTitle string `json:"task_title,omitempty"` // Title of the task (run) the step belongs to
 
 	// Agent configuration for this step
 	AgentConfig *protocol.AgentConfigInput `json:"agent_config"`
 
 	// Execution context
 	WorktreePath          string `json:"worktree_path"`
+	BranchName            string `json:"branch_name,omitempty"` // Branch checked out in the worktree; commits land on it
 	WorkspaceDir          string `json:"workspace_dir"`
 	OrchestratorTaskQueue string `json:"orchestrator_task_queue"`
+	WorkingSubdir         string `json:"working_subdir,omitempty"`  // Agent working directory relative to the worktree
+	CommitTemplate        string `json:"commit_template,omitempty"` // Commit message template (empty = default message)
+
+	// Live diff streaming while the agent runs (0 interval = disabled)
+	DiffStreamInterval time.Duration `json:"diff_stream_interval,omitempty"`
+	DiffStreamMaxBytes int           `json:"diff_stream_max_bytes,omitempty"`
 
 	// Previous step's commit (for chaining)
 	PreviousCommitSHA string `json:"previous_commit_sha,omitempty"`
@@ -149,9 +184,10 @@ type ProcessingStepOutput struct {
 	GitDiff       string `json:"git_diff"`
 
 	// Diff statistics
-	FilesChanged int `json:"files_changed"`
-	Insertions   int `json:"insertions"`
-	Deletions    int `json:"deletions"`
+	FilesChanged            int `json:"files_changed"`
+	SignificantFilesChanged int `json:"significant_files_changed"` // Excluding whitespace-only changes
+	Insertions              int `json:"insertions"`
+	Deletions               int `json:"deletions"`
 
 	// Token usage
 	InputTokens       int `json:"input_tokens"`
@@ -163,6 +199,7 @@ type ProcessingStepOutput struct {
 	AgentOutput string        `json:"agent_output"`
 	Duration    time.Duration `json:"duration"`
 	Error       string        `json:"error,omitempty"`
+	Warning     string        `json:"warning,omitempty"` // Set when the step completed abnormally, e.g. finalized after the agent went idle
 }
 
 // TotalTokens returns total tokens used in this step
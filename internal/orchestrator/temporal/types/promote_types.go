@@ -6,6 +6,8 @@ package types
 import (
 	"fmt"
 	"time"
+
+	"github.com/noldarim/noldarim/internal/protocol"
 )
 
 // ============================================================================
@@ -130,6 +132,64 @@ func MergeQueueWorkflowID(projectID string) string {
 	return fmt.Sprintf("merge-queue-%s", projectID)
 }
 
+// ============================================================================
+// AssembleBranchWorkflow Types
+// ============================================================================
+
+// AssembleBranchWorkflowInput is the input for the AssembleBranchWorkflow.
+type AssembleBranchWorkflowInput struct {
+	Metadata              protocol.Metadata         `json:"metadata"` // Of the AssembleBranchCommand; the result event replies with it
+	ProjectID             string                    `json:"project_id"`
+	RepositoryPath        string                    `json:"repository_path"`
+	TargetBranch          string                    `json:"target_branch"`
+	BaseBranch            string                    `json:"base_branch"`
+	BaseCommitSHA         string                    `json:"base_commit_sha"`
+	Strategy              protocol.AssembleStrategy `json:"strategy"`
+	Sources               []AssembleSource          `json:"sources"`
+	OrchestratorTaskQueue string                    `json:"orchestrator_task_queue"`
+}
+
+// AssembleSource is one task branch to integrate into the target branch.
+type AssembleSource struct {
+	TaskID string `json:"task_id"`
+	Branch string `json:"branch"`
+}
+
+// AssembleBranchWorkflowOutput is the output from the AssembleBranchWorkflow.
+type AssembleBranchWorkflowOutput struct {
+	TargetBranch string                          `json:"target_branch"`
+	HeadSHA      string                          `json:"head_sha"`
+	Sources      []protocol.AssembleSourceResult `json:"sources"`
+}
+
+// AssembleBranchWorkflowID returns the canonical workflow ID for assembling a target branch.
+// One assembly per target runs at a time.
+func AssembleBranchWorkflowID(projectID, targetBranch string) string {
+	return fmt.Sprintf("assemble-%s-%s", projectID, targetBranch)
+}
+
+// ============================================================================
+// RebaseTaskWorkflow Types
+// ============================================================================
+
+// RebaseTaskWorkflowInput is the input for the RebaseTaskWorkflow.
+type RebaseTaskWorkflowInput struct {
+	Metadata              protocol.Metadata `json:"metadata"` // Of the RebaseTaskCommand; the result event replies with it
+	ProjectID             string            `json:"project_id"`
+	TaskID                string            `json:"task_id"`
+	RunID                 string            `json:"run_id"` // Pipeline run whose HeadCommitSHA follows the branch; empty when the task has none
+	WorktreePath          string            `json:"worktree_path"`
+	Branch                string            `json:"branch"`
+	Onto                  string            `json:"onto"`
+	OrchestratorTaskQueue string            `json:"orchestrator_task_queue"`
+}
+
+// RebaseTaskWorkflowID returns the canonical workflow ID for rebasing a task's branch.
+// One rebase per task runs at a time.
+func RebaseTaskWorkflowID(projectID, taskID string) string {
+	return fmt.Sprintf("rebase-%s-%s", projectID, taskID)
+}
+
 // MergeQueueState is the state returned by the merge queue query.
 type MergeQueueState struct {
 	Items               []MergeQueueItem `json:"items"`
@@ -3,6 +3,8 @@
 
 package types
 
+import "time"
+
 // Signal names for AIObservabilityWorkflow communication.
 // Consolidated here to avoid duplication between activities and workflows packages.
 const (
@@ -20,4 +22,18 @@ const (
 
 	// StepChangeSignal is sent by PipelineWorkflow to communicate the current step ID.
 	StepChangeSignal = "step-change"
+
+	// AgentIdleSignal is sent by WatchTranscriptActivity when no transcript lines arrived
+	// for the idle timeout. Carries an AgentIdleNotice.
+	AgentIdleSignal = "agent-idle"
+
+	// FinalizeAgentSignal is sent by AIObservabilityWorkflow to the ProcessingStepWorkflow of
+	// an idle agent when finalize-on-idle is enabled. Carries an AgentIdleNotice.
+	FinalizeAgentSignal = "finalize-agent"
 )
+
+// AgentIdleNotice describes an agent that stopped producing transcript lines
+type AgentIdleNotice struct {
+	IdleFor   time.Duration `json:"idle_for"`
+	LinesRead int64         `json:"lines_read"` // Transcript lines read before the agent went quiet
+}
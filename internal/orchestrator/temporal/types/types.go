@@ -22,6 +22,7 @@ type CreateTaskWorkflowInput struct {
 	WorkspaceDir          string                     // Workspace directory from config
 	OrchestratorTaskQueue string                     // Task queue where orchestrator is listening (for passing to child workflows)
 	HooksConfig           *HooksConfigInput          // Optional hooks configuration
+	Labels                []string                   // Initial labels
 }
 
 // HooksConfigInput holds hooks configuration passed to workflows
@@ -40,7 +41,7 @@ type CreateTaskWorkflowOutput struct {
 // CreateWorktreeActivityInput represents input for git worktree creation
 type CreateWorktreeActivityInput struct {
 	TaskID         string
-	BranchName     string
+	BranchName     string // Branch checked out in the worktree (empty = the task branch)
 	RepositoryPath string // Path to the git repository
 	BaseCommitSHA  string // Commit SHA to create worktree from (empty = HEAD)
 }
@@ -57,6 +58,23 @@ type RemoveWorktreeActivityInput struct {
 	RepositoryPath string
 }
 
+// DeleteBranchActivityInput represents input for deleting a local branch
+type DeleteBranchActivityInput struct {
+	RepositoryPath string
+	BranchName     string
+}
+
+// EvictWorktreesActivityInput represents input for enforcing the worktree limit of a repository
+type EvictWorktreesActivityInput struct {
+	ProjectID      string
+	RepositoryPath string
+}
+
+// EvictWorktreesActivityOutput represents output from worktree eviction
+type EvictWorktreesActivityOutput struct {
+	EvictedPaths []string
+}
+
 // CreateContainerActivityInput represents input for container creation
 type CreateContainerActivityInput struct {
 	TaskID            string
@@ -79,6 +97,7 @@ type CreateTaskActivityInput struct {
 	Title        string
 	Description  string
 	TaskFilePath string
+	Labels       []string // Initial labels
 }
 
 // CreateTaskActivityOutput represents output from task database creation
@@ -183,8 +202,9 @@ type ProcessTaskWorkflowOutput struct {
 
 // LocalExecuteActivityInput represents input for local command execution
 type LocalExecuteActivityInput struct {
-	Command []string // Command and arguments to execute
-	WorkDir string   // Working directory for command execution
+	Command []string          // Command and arguments to execute
+	WorkDir string            // Working directory for command execution
+	Env     map[string]string // Added to the worker's environment; may hold secrets, log only via protocol.RedactEnv
 }
 
 // LocalExecuteActivityOutput represents output from local command execution
@@ -202,6 +222,20 @@ type GitCommitActivityInput struct {
 	RepositoryPath string   // Path to the git repository
 	FileNames      []string // List of file names to commit (relative to repository root)
 	CommitMessage  string   // Commit message
+	BranchName     string   // Branch expected to be checked out; a detached HEAD is moved onto it (optional)
+
+	// CommitTemplate, when set, is rendered with TemplateVars and replaces CommitMessage;
+	// CommitMessage is kept as the fallback if the template cannot be rendered
+	CommitTemplate string
+	TemplateVars   CommitTemplateVars
+}
+
+// CommitTemplateVars are the values available to a commit message template
+type CommitTemplateVars struct {
+	TaskTitle    string
+	TaskID       string
+	FilesChanged int
+	Tokens       int
 }
 
 // GitCommitActivityOutput represents output from git commit activity
@@ -209,6 +243,8 @@ type GitCommitActivityOutput struct {
 	Success   bool   // Whether the commit was successful
 	Error     string // Error message if failed
 	CommitSHA string // SHA of the created commit (if successful)
+
+	CommitMessage string // Message the commit was created with
 }
 
 // WriteTaskFileActivityInput represents input for writing task details to file
@@ -227,19 +263,32 @@ type WriteTaskFileActivityOutput struct {
 
 // CaptureGitDiffActivityInput represents input for capturing git diff
 type CaptureGitDiffActivityInput struct {
-	RepositoryPath string // Path to the git repository (worktree)
+	RepositoryPath string   // Path to the git repository (worktree)
+	Paths          []string // Limit the diff to these repository-relative paths (all when empty)
 }
 
 // CaptureGitDiffActivityOutput represents output from capturing git diff
 type CaptureGitDiffActivityOutput struct {
-	Success      bool
-	Error        string
-	Diff         string   // Full git diff output (raw text)
-	DiffStat     string   // Git diff --stat output
-	FilesChanged []string // List of changed file paths
-	Insertions   int      // Number of lines inserted
-	Deletions    int      // Number of lines deleted
-	HasChanges   bool     // Whether there are any changes
+	Success                 bool
+	Error                   string
+	Diff                    string   // Full git diff output (raw text)
+	DiffStat                string   // Git diff --stat output
+	FilesChanged            []string // List of changed file paths
+	SignificantFilesChanged []string // Changed files excluding whitespace-only changes
+	Insertions              int      // Number of lines inserted
+	Deletions               int      // Number of lines deleted
+	HasChanges              bool     // Whether there are any changes
+}
+
+// StreamGitDiffActivityInput represents input for streaming the diff of a running task
+type StreamGitDiffActivityInput struct {
+	ProjectID      string
+	TaskID         string // Task the DiffUpdatedEvents are published for
+	StepID         string
+	RepositoryPath string        // Path to the git repository (worktree)
+	Paths          []string      // Limit the diff to these repository-relative paths (all when empty)
+	Interval       time.Duration // How often the diff is re-captured
+	MaxDiffBytes   int           // Larger diffs are published as stats only (0 = no limit)
 }
 
 // UpdateTaskGitDiffActivityInput represents input for updating task git diff
@@ -248,13 +297,72 @@ type UpdateTaskGitDiffActivityInput struct {
 	GitDiff string // Git diff content to save
 }
 
+// SaveProcessTaskCheckpointActivityInput represents input for saving a task's processing checkpoint
+type SaveProcessTaskCheckpointActivityInput struct {
+	TaskID     string                // ID of the task to update
+	Checkpoint ProcessTaskCheckpoint // Progress of the running attempt
+}
+
+// LoadProcessTaskCheckpointActivityInput represents input for loading a task's processing checkpoint
+type LoadProcessTaskCheckpointActivityInput struct {
+	TaskID string // ID of the task to load the checkpoint of
+}
+
+// RebaseWorktreeActivityInput represents input for rebasing a task branch in its worktree
+type RebaseWorktreeActivityInput struct {
+	WorktreePath  string // Worktree with Branch checked out
+	Branch        string // Branch to rebase
+	Onto          string // Ref to rebase the branch onto
+	KeepConflicts bool   // Leave a conflicted rebase in progress instead of aborting it
+}
+
+// RebaseWorktreeActivityOutput represents the outcome of a worktree rebase
+type RebaseWorktreeActivityOutput struct {
+	OldHeadSHA string
+	NewHeadSHA string
+	UpToDate   bool     // Branch already contained Onto
+	Conflicts  []string // Conflicting files; empty on success
+	Aborted    bool     // Conflicted rebase was aborted, branch unchanged
+	InProgress bool     // Conflicted rebase was kept for resolution
+}
+
+// IntegrateBranchActivityInput represents input for merging or cherry-picking a branch in a worktree
+type IntegrateBranchActivityInput struct {
+	WorktreePath string // Worktree with the receiving branch checked out
+	Branch       string // Branch to integrate
+	CherryPick   bool   // Cherry-pick the branch's commits instead of merging it
+}
+
+// IntegrateBranchActivityOutput represents the outcome of integrating a branch
+type IntegrateBranchActivityOutput struct {
+	HeadSHA   string   // Worktree HEAD afterwards; unchanged on conflicts
+	UpToDate  bool     // The branch was already integrated
+	Picked    []string // Commits applied by a cherry-pick
+	Conflicts []string // Conflicting files; the merge or cherry-pick was aborted
+}
+
 // ProcessingMetadata represents metadata collected during task processing
 // This data is available via Temporal queries
 type ProcessingMetadata struct {
+	Step           string                        // Current processing step (see workflows.ProcessTaskStep*)
 	GitDiff        *CaptureGitDiffActivityOutput // Git diff information
 	ProcessingTime time.Duration                 // Time taken for processing
 	CommandOutput  string                        // Output from the processing command
 	Timestamp      time.Time                     // When metadata was captured
+
+	ResumedFrom string // Step the attempt resumed after (see ProcessTaskCheckpoint); empty = fresh start
+}
+
+// ProcessTaskCheckpoint is the progress of a ProcessTaskWorkflow attempt. It is saved on the
+// task as each step finishes and returned in a failed attempt's error details, so that the
+// retry reuses the worktree and skips finished steps.
+type ProcessTaskCheckpoint struct {
+	StepIndex      int                           // Number of checkpointed steps finished; 0 = none
+	CompletedStep  string                        // Last finished step (see workflows.ProcessTaskStep*); empty = none
+	WorktreePath   string                        // Worktree the attempt worked in
+	AgentOutput    string                        // Output of the finished agent run
+	ProcessingTime time.Duration                 // Duration of the finished agent run
+	GitDiff        *CaptureGitDiffActivityOutput // Diff captured after the agent run
 }
 
 // NOTE: AIActivitySignal has been removed.
@@ -316,6 +424,14 @@ type AIObservabilityWorkflowInput struct {
 	RuntimeName           string `json:"runtime_name,omitempty"` // Agent runtime name (e.g., "claude", "opencode"). When set, uses Observer/Parser pipeline.
 	InitialStepID         string `json:"initial_step_id,omitempty"`
 	EventsOffset          int    `json:"events_offset,omitempty"`
+	TokenBudget           int    `json:"token_budget,omitempty"`    // Input+output token budget (0 = unlimited)
+	HardBudget            bool   `json:"hard_budget,omitempty"`     // Cancel the parent workflow once the budget is exceeded
+	TokensUsed            int    `json:"tokens_used,omitempty"`     // Tokens counted by previous runs (carried across ContinueAsNew)
+	BudgetExceeded        bool   `json:"budget_exceeded,omitempty"` // Budget already reported by a previous run
+
+	IdleTimeout    time.Duration `json:"idle_timeout,omitempty"`     // Report the agent after this long without transcript lines (0 = never)
+	FinalizeOnIdle bool          `json:"finalize_on_idle,omitempty"` // Ask the running step to stop the idle agent and commit
+	ResumeOffsets  bool          `json:"resume_offsets,omitempty"`   // Persist transcript read offsets so a retried watcher resumes after them
 }
 
 // AIObservabilityWorkflowOutput represents output from the AI observability workflow
@@ -328,12 +444,14 @@ type AIObservabilityWorkflowOutput struct {
 
 // WatchTranscriptActivityInput represents input for the blocking transcript watch activity
 type WatchTranscriptActivityInput struct {
-	TaskID        string // Task ID for correlation
-	RunID         string // Pipeline run ID for correlation
-	ProjectID     string // Project ID for event correlation
-	TranscriptDir string // Directory to watch for transcript files
-	Source        string // AI tool source ("claude", "gemini", etc.)
-	RuntimeName   string // Agent runtime name (e.g., "claude", "opencode"). When set, uses Observer/Parser pipeline.
+	TaskID        string        // Task ID for correlation
+	RunID         string        // Pipeline run ID for correlation
+	ProjectID     string        // Project ID for event correlation
+	TranscriptDir string        // Directory to watch for transcript files
+	Source        string        // AI tool source ("claude", "gemini", etc.)
+	RuntimeName   string        // Agent runtime name (e.g., "claude", "opencode"). When set, uses Observer/Parser pipeline.
+	IdleTimeout   time.Duration // Signal AgentIdleSignal after this long without new lines (0 = never)
+	ResumeOffsets bool          // Persist read offsets next to the transcripts and resume from them
 	// Note: Activity signals its parent workflow (AIObservabilityWorkflow) directly
 	// using activity.GetInfo(ctx).WorkflowExecution.ID
 }
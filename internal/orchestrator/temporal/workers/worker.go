@@ -50,6 +50,8 @@ type Worker struct {
 	pipelineDataActivities *activities.PipelineDataActivities // Pipeline workflow activities
 	stepDocActivities      *activities.StepDocumentationActivities
 	mergeQueueActivities   *activities.MergeQueueActivities
+	evictionActivities     *activities.WorktreeEvictionActivities
+	diffStreamActivities   *activities.DiffStreamActivities
 	config                 *config.AppConfig
 	mu                     sync.Mutex
 	stopped                bool
@@ -76,6 +78,8 @@ func NewWorker(
 	pipelineDataActivities := activities.NewPipelineDataActivities(dataService)
 	stepDocActivities := activities.NewStepDocumentationActivities()
 	mergeQueueActivities := activities.NewMergeQueueActivities(mergeQueueSignaler)
+	evictionActivities := activities.NewWorktreeEvictionActivities(gitServiceManager, dataService, eventChan, cfg)
+	diffStreamActivities := activities.NewDiffStreamActivities(gitServiceManager, eventChan)
 
 	return &Worker{
 		temporalClient:         temporalClient,
@@ -90,6 +94,8 @@ func NewWorker(
 		pipelineDataActivities: pipelineDataActivities,
 		stepDocActivities:      stepDocActivities,
 		mergeQueueActivities:   mergeQueueActivities,
+		evictionActivities:     evictionActivities,
+		diffStreamActivities:   diffStreamActivities,
 		config:                 cfg,
 	}
 }
@@ -134,6 +140,8 @@ func (w *Worker) Start(ctx context.Context) error {
 	w.worker.RegisterWorkflow(workflows.ProcessingStepWorkflow)
 	w.worker.RegisterWorkflow(workflows.PromoteWorkflow)
 	w.worker.RegisterWorkflow(workflows.MergeQueueWorkflow)
+	w.worker.RegisterWorkflow(workflows.AssembleBranchWorkflow)
+	w.worker.RegisterWorkflow(workflows.RebaseTaskWorkflow)
 
 	// Register activities
 	w.registerActivities()
@@ -157,6 +165,7 @@ func (w *Worker) registerActivities() {
 	// Register Git activities
 	w.worker.RegisterActivity(w.gitActivities.CreateWorktreeActivity)
 	w.worker.RegisterActivity(w.gitActivities.RemoveWorktreeActivity)
+	w.worker.RegisterActivity(w.gitActivities.DeleteBranchActivity)
 	w.worker.RegisterActivity(w.gitActivities.CommitChangesActivity)
 	w.worker.RegisterActivity(w.gitActivities.GetWorktreeStatusActivity)
 	w.worker.RegisterActivity(w.gitActivities.GitCommitActivity)
@@ -164,13 +173,19 @@ func (w *Worker) registerActivities() {
 	w.worker.RegisterActivity(w.gitActivities.CheckFastForwardActivity)
 	w.worker.RegisterActivity(w.gitActivities.FastForwardBranchActivity)
 	w.worker.RegisterActivity(w.gitActivities.MergeInWorktreeActivity)
+	w.worker.RegisterActivity(w.gitActivities.RebaseWorktreeActivity)
+	w.worker.RegisterActivity(w.gitActivities.IntegrateBranchActivity)
 	w.worker.RegisterActivity(w.gitActivities.GetBranchHeadActivity)
+	w.worker.RegisterActivity(w.evictionActivities.EvictWorktreesActivity)
+	w.worker.RegisterActivity(w.diffStreamActivities.StreamGitDiffActivity)
 
 	// Register Data activities
 	w.worker.RegisterActivity(w.dataActivities.CreateTaskActivity)
 	w.worker.RegisterActivity(w.dataActivities.DeleteTaskActivity)
 	w.worker.RegisterActivity(w.dataActivities.UpdateTaskStatusActivity)
 	w.worker.RegisterActivity(w.dataActivities.UpdateTaskGitDiffActivity)
+	w.worker.RegisterActivity(w.dataActivities.SaveProcessTaskCheckpointActivity)
+	w.worker.RegisterActivity(w.dataActivities.LoadProcessTaskCheckpointActivity)
 	w.worker.RegisterActivity(w.dataActivities.LoadProjectsActivity)
 	w.worker.RegisterActivity(w.dataActivities.LoadTasksActivity)
 	w.worker.RegisterActivity(w.dataActivities.SaveAIActivityRecordActivity)
@@ -195,6 +210,11 @@ func (w *Worker) registerActivities() {
 	w.worker.RegisterActivity(w.eventActivities.PublishTaskRequestedEventActivity)
 	w.worker.RegisterActivity(w.eventActivities.PublishErrorEventActivity)
 	w.worker.RegisterActivity(w.eventActivities.PublishAIActivityEventActivity)
+	w.worker.RegisterActivity(w.eventActivities.PublishBudgetExceededEventActivity)
+	w.worker.RegisterActivity(w.eventActivities.PublishAgentIdleEventActivity)
+	w.worker.RegisterActivity(w.eventActivities.PublishBranchAssemblyProgressEventActivity)
+	w.worker.RegisterActivity(w.eventActivities.PublishBranchAssembledEventActivity)
+	w.worker.RegisterActivity(w.eventActivities.PublishTaskRebasedEventActivity)
 
 	// Register Pipeline Event activities - for pipeline lifecycle events to TUI
 	w.worker.RegisterActivity(w.eventActivities.PublishPipelineCreatedEventActivity)
@@ -263,14 +283,18 @@ func (w *Worker) GetRegisteredActivities() []string {
 	return []string{
 		"CreateWorktreeActivity",
 		"RemoveWorktreeActivity",
+		"DeleteBranchActivity",
 		"CommitChangesActivity",
 		"GetWorktreeStatusActivity",
 		"GitCommitActivity",
 		"CaptureGitDiffActivity",
+		"EvictWorktreesActivity",
 		"CreateTaskActivity",
 		"DeleteTaskActivity",
 		"UpdateTaskStatusActivity",
 		"UpdateTaskGitDiffActivity",
+		"SaveProcessTaskCheckpointActivity",
+		"LoadProcessTaskCheckpointActivity",
 		"LoadProjectsActivity",
 		"LoadTasksActivity",
 		"CreateContainerActivity",
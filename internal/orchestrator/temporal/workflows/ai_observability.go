@@ -81,6 +81,9 @@ func AIObservabilityWorkflow(ctx workflow.Context, input types.AIObservabilityWo
 	failedEvents := 0
 	shouldContinueAsNew := false
 
+	// Cumulative token usage, carried across ContinueAsNew
+	budget := newTokenBudget(input.TokenBudget, input.TokensUsed, input.BudgetExceeded)
+
 	// Track which pipeline step is currently executing (set via StepChangeSignal from PipelineWorkflow)
 	currentStepID := input.InitialStepID
 
@@ -98,6 +101,28 @@ func AIObservabilityWorkflow(ctx workflow.Context, input types.AIObservabilityWo
 		}
 	})
 
+	// Idle agent reports from the watch activity; only meaningful while a step's agent runs
+	agentIdleChan := workflow.GetSignalChannel(ctx, types.AgentIdleSignal)
+	finalizedStepID := ""
+	workflow.Go(ctx, func(gCtx workflow.Context) {
+		for {
+			var notice types.AgentIdleNotice
+			more := agentIdleChan.Receive(gCtx, &notice)
+			if !more {
+				return
+			}
+			stepID := currentStepID
+			if stepID == "" {
+				continue
+			}
+			finalize := input.FinalizeOnIdle && stepID != finalizedStepID
+			if finalize {
+				finalizedStepID = stepID
+			}
+			handleAgentIdle(gCtx, orchestratorCtx, input, stepID, notice, finalize, logger)
+		}
+	})
+
 	// =========================================================================
 	// New: ParsedTranscriptBatchSignal handler (Observer/Parser pipeline)
 	// =========================================================================
@@ -114,9 +139,10 @@ func AIObservabilityWorkflow(ctx workflow.Context, input types.AIObservabilityWo
 			for _, parsedEvent := range batch.Events {
 				pendingEvents++
 				stepID := currentStepID
-				processedDelta, failedDelta := processParsedBatch(gCtx, orchestratorCtx, parsedEvent, stepID, logger)
+				processedDelta, failedDelta := processParsedBatch(gCtx, orchestratorCtx, parsedEvent, stepID, budget, logger)
 				eventsProcessed += processedDelta
 				failedEvents += failedDelta
+				enforceTokenBudget(gCtx, orchestratorCtx, input, budget, logger)
 				if input.EventsOffset+eventsProcessed >= continueAsNewThreshold {
 					shouldContinueAsNew = true
 				}
@@ -144,9 +170,10 @@ func AIObservabilityWorkflow(ctx workflow.Context, input types.AIObservabilityWo
 
 			pendingEvents++
 			stepID := currentStepID
-			processedDelta, failedDelta := processRawEvent(gCtx, orchestratorCtx, rawEvent, stepID, input.RunID, logger)
+			processedDelta, failedDelta := processRawEvent(gCtx, orchestratorCtx, rawEvent, stepID, input.RunID, budget, logger)
 			eventsProcessed += processedDelta
 			failedEvents += failedDelta
+			enforceTokenBudget(gCtx, orchestratorCtx, input, budget, logger)
 			if input.EventsOffset+eventsProcessed >= continueAsNewThreshold {
 				shouldContinueAsNew = true
 			}
@@ -166,9 +193,10 @@ func AIObservabilityWorkflow(ctx workflow.Context, input types.AIObservabilityWo
 			for _, rawEvent := range batch.Events {
 				pendingEvents++
 				stepID := currentStepID
-				processedDelta, failedDelta := processRawEvent(gCtx, orchestratorCtx, rawEvent, stepID, input.RunID, logger)
+				processedDelta, failedDelta := processRawEvent(gCtx, orchestratorCtx, rawEvent, stepID, input.RunID, budget, logger)
 				eventsProcessed += processedDelta
 				failedEvents += failedDelta
+				enforceTokenBudget(gCtx, orchestratorCtx, input, budget, logger)
 				if input.EventsOffset+eventsProcessed >= continueAsNewThreshold {
 					shouldContinueAsNew = true
 				}
@@ -187,6 +215,8 @@ func AIObservabilityWorkflow(ctx workflow.Context, input types.AIObservabilityWo
 		TranscriptDir: input.TranscriptDir,
 		Source:        "claude",
 		RuntimeName:   input.RuntimeName,
+		IdleTimeout:   input.IdleTimeout,
+		ResumeOffsets: input.ResumeOffsets,
 	}).Get(ctx, &activityResult)
 
 	// Activity completed (either naturally or via parent termination)
@@ -208,6 +238,8 @@ func AIObservabilityWorkflow(ctx workflow.Context, input types.AIObservabilityWo
 		nextInput := input
 		nextInput.InitialStepID = currentStepID
 		nextInput.EventsOffset = input.EventsOffset + eventsProcessed
+		nextInput.TokensUsed = budget.used
+		nextInput.BudgetExceeded = budget.reported
 
 		logger.Info("ContinueAsNew triggered",
 			"eventsProcessed", nextInput.EventsOffset,
@@ -227,6 +259,46 @@ func AIObservabilityWorkflow(ctx workflow.Context, input types.AIObservabilityWo
 	return output, nil
 }
 
+// handleAgentIdle publishes an AgentIdleEvent and, when finalize is set, asks the step
+// workflow running the agent to stop it and commit its work so far
+func handleAgentIdle(
+	gCtx workflow.Context,
+	orchestratorCtx workflow.Context,
+	input types.AIObservabilityWorkflowInput,
+	stepID string,
+	notice types.AgentIdleNotice,
+	finalize bool,
+	logger log.Logger,
+) {
+	logger.Warn("Agent idle",
+		"taskID", input.TaskID,
+		"stepID", stepID,
+		"idleFor", notice.IdleFor,
+		"finalize", finalize)
+
+	publishErr := workflow.ExecuteActivity(orchestratorCtx, "PublishAgentIdleEventActivity", types.PublishAgentIdleEventInput{
+		ProjectID:  input.ProjectID,
+		TaskID:     input.TaskID,
+		RunID:      input.RunID,
+		StepID:     stepID,
+		IdleFor:    notice.IdleFor,
+		Finalizing: finalize,
+	}).Get(gCtx, nil)
+	if publishErr != nil {
+		logger.Warn("Failed to publish agent idle event", "error", publishErr, "taskID", input.TaskID)
+	}
+
+	if !finalize {
+		return
+	}
+	stepWorkflow := stepWorkflowID(input.RunID, stepID)
+	if err := workflow.SignalExternalWorkflow(gCtx, stepWorkflow, "", types.FinalizeAgentSignal, notice).Get(gCtx, nil); err != nil {
+		logger.Error("Failed to ask step workflow to finalize idle agent",
+			"error", err,
+			"workflowID", stepWorkflow)
+	}
+}
+
 // processParsedBatch handles a single ParsedTranscriptEvent from the Observer/Parser pipeline.
 // For each ParsedEvent: Save + Publish (2 activities instead of 4).
 func processParsedBatch(
@@ -234,6 +306,7 @@ func processParsedBatch(
 	orchestratorCtx workflow.Context,
 	parsedEvent types.ParsedTranscriptEvent,
 	stepID string,
+	budget *tokenBudget,
 	logger log.Logger,
 ) (int, int) {
 	processed := 0
@@ -252,6 +325,7 @@ func processParsedBatch(
 			failed++
 			continue
 		}
+		budget.add(record)
 
 		// Publish to TUI
 		publishErr := workflow.ExecuteActivity(orchestratorCtx, "PublishAIActivityEventActivity", record).Get(gCtx, nil)
@@ -276,6 +350,7 @@ func processRawEvent(
 	rawEvent types.RawTranscriptEvent,
 	stepID string,
 	runID string,
+	budget *tokenBudget,
 	logger log.Logger,
 ) (int, int) {
 	var saveOutput types.SaveRawEventOutput
@@ -311,6 +386,8 @@ func processRawEvent(
 		StepID:     stepID,
 		ProjectID:  rawEvent.ProjectID,
 		RawPayload: rawEvent.RawLine,
+		SourceFile: rawEvent.SourceFile,
+		SourceLine: rawEvent.SourceLine,
 	}).Get(gCtx, &parseOutput)
 	if parseErr != nil {
 		logger.Warn("Failed to parse event",
@@ -329,6 +406,8 @@ func processRawEvent(
 	}
 
 	for _, event := range parseOutput.Events {
+		budget.add(event)
+
 		updateErr := workflow.ExecuteActivity(orchestratorCtx, "UpdateParsedEventActivity", event).Get(gCtx, nil)
 		if updateErr != nil {
 			logger.Warn("Failed to update parsed event in database",
@@ -0,0 +1,166 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package workflows
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+	"github.com/noldarim/noldarim/internal/protocol"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const AssembleBranchWorkflowName = "AssembleBranchWorkflow"
+
+// AssembleBranchWorkflow builds an integration branch out of several task branches.
+//
+//  1. Create a scratch worktree at the base commit
+//  2. Merge or cherry-pick each source branch into it, in order. A conflicting source is
+//     aborted, reported and skipped; the remaining sources still go in.
+//  3. Point the target branch at the result and remove the scratch worktree and its branch
+//
+// Every step is safe to replay: the worktree ID is derived from the workflow ID, so a retried
+// run picks up the same worktree, and integrating a source that is already in is a no-op.
+//
+// Workflow ID: assemble-<projectID>-<targetBranch>
+func AssembleBranchWorkflow(ctx workflow.Context, input types.AssembleBranchWorkflowInput) (*types.AssembleBranchWorkflowOutput, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting AssembleBranchWorkflow",
+		"projectID", input.ProjectID,
+		"targetBranch", input.TargetBranch,
+		"baseBranch", input.BaseBranch,
+		"strategy", input.Strategy,
+		"sources", len(input.Sources))
+
+	output := &types.AssembleBranchWorkflowOutput{TargetBranch: input.TargetBranch}
+
+	orchCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		TaskQueue:           input.OrchestratorTaskQueue,
+		StartToCloseTimeout: 2 * time.Minute,
+		HeartbeatTimeout:    30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    30 * time.Second,
+			MaximumAttempts:    3,
+		},
+	})
+
+	event := types.PublishBranchAssemblyEventInput{
+		Metadata:     input.Metadata,
+		ProjectID:    input.ProjectID,
+		TargetBranch: input.TargetBranch,
+		BaseBranch:   input.BaseBranch,
+		Strategy:     input.Strategy,
+		Total:        len(input.Sources),
+	}
+
+	var compensations []compensation
+	failAssembly := func(errMsg string) (*types.AssembleBranchWorkflowOutput, error) {
+		logger.Error("Branch assembly failed: " + errMsg)
+
+		cleanupCtx, _ := workflow.NewDisconnectedContext(ctx)
+		runCompensations(cleanupCtx, compensations)
+
+		event.Error = errMsg
+		_ = workflow.ExecuteActivity(workflow.WithActivityOptions(cleanupCtx, compensationActivityOptions()),
+			"PublishBranchAssembledEventActivity", event).Get(cleanupCtx, nil)
+		return output, fmt.Errorf("%s", errMsg)
+	}
+
+	// Phase 1: scratch worktree at the base commit
+	worktreeID := assemblyWorktreeID(workflow.GetInfo(ctx).WorkflowExecution.ID)
+	scratchBranch := "task-" + worktreeID // As named by CreateWorktreeActivity, so a retry reuses the worktree
+	var worktree types.CreateWorktreeActivityOutput
+	err := workflow.ExecuteActivity(orchCtx, "CreateWorktreeActivity",
+		types.CreateWorktreeActivityInput{
+			TaskID:         worktreeID,
+			BranchName:     scratchBranch,
+			RepositoryPath: input.RepositoryPath,
+			BaseCommitSHA:  input.BaseCommitSHA,
+		}).Get(ctx, &worktree)
+	if err != nil {
+		return failAssembly(fmt.Sprintf("Failed to create assembly worktree: %v", err))
+	}
+	compensations = append(compensations,
+		branchCompensation(scratchBranch, input.RepositoryPath),
+		worktreeCompensation(worktree.WorktreePath, input.RepositoryPath))
+
+	// Phase 2: integrate the sources in order
+	output.HeadSHA = input.BaseCommitSHA
+	for _, source := range input.Sources {
+		var integrated types.IntegrateBranchActivityOutput
+		err := workflow.ExecuteActivity(orchCtx, "IntegrateBranchActivity",
+			types.IntegrateBranchActivityInput{
+				WorktreePath: worktree.WorktreePath,
+				Branch:       source.Branch,
+				CherryPick:   input.Strategy == protocol.AssembleStrategyCherryPick,
+			}).Get(ctx, &integrated)
+		if err != nil {
+			return failAssembly(fmt.Sprintf("Failed to integrate task %s (%s): %v", source.TaskID, source.Branch, err))
+		}
+
+		result := protocol.AssembleSourceResult{
+			TaskID:    source.TaskID,
+			Branch:    source.Branch,
+			Status:    protocol.AssembleSourceMerged,
+			HeadSHA:   integrated.HeadSHA,
+			Conflicts: integrated.Conflicts,
+		}
+		switch {
+		case len(integrated.Conflicts) > 0:
+			result.Status = protocol.AssembleSourceConflicted
+		case integrated.UpToDate:
+			result.Status = protocol.AssembleSourceUpToDate
+		}
+		output.Sources = append(output.Sources, result)
+		output.HeadSHA = integrated.HeadSHA
+		logger.Info("Integrated source", "taskID", source.TaskID, "status", result.Status, "headSHA", result.HeadSHA)
+
+		event.Sources = output.Sources
+		_ = workflow.ExecuteActivity(orchCtx, "PublishBranchAssemblyProgressEventActivity", event).Get(ctx, nil)
+	}
+
+	// Phase 3: create the target branch at the result. The zero SHA as the expected old
+	// value makes the update fail if the branch appeared since the assembly was requested.
+	err = workflow.ExecuteActivity(orchCtx, "FastForwardBranchActivity",
+		types.FastForwardBranchInput{
+			RepoPath:       input.RepositoryPath,
+			Branch:         input.TargetBranch,
+			TargetSHA:      output.HeadSHA,
+			ExpectedOldSHA: absentBranchSHA,
+		}).Get(ctx, nil)
+	if err != nil {
+		return failAssembly(fmt.Sprintf("Failed to update %s: %v", input.TargetBranch, err))
+	}
+
+	cleanupCtx, _ := workflow.NewDisconnectedContext(ctx)
+	runCompensations(cleanupCtx, compensations)
+
+	event.HeadSHA = output.HeadSHA
+	if err := workflow.ExecuteActivity(orchCtx, "PublishBranchAssembledEventActivity", event).Get(ctx, nil); err != nil {
+		logger.Error("Failed to publish assembly result", "error", err)
+		// The branch is in place; the result is still returned
+	}
+
+	logger.Info("AssembleBranchWorkflow completed",
+		"targetBranch", input.TargetBranch,
+		"headSHA", output.HeadSHA)
+	return output, nil
+}
+
+// absentBranchSHA is the old value git update-ref expects of a ref that must not exist yet
+const absentBranchSHA = "0000000000000000000000000000000000000000"
+
+// assemblyWorktreeID derives the scratch worktree's ID from the workflow ID, so that retries
+// and replays reuse the same worktree
+func assemblyWorktreeID(workflowID string) string {
+	sum := sha256.Sum256([]byte(workflowID))
+	return "assemble-" + hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package workflows
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func baseAssembleInput() types.AssembleBranchWorkflowInput {
+	return types.AssembleBranchWorkflowInput{
+		Metadata:       protocol.Metadata{IdempotencyKey: "rpc-7"},
+		ProjectID:      "project-1",
+		RepositoryPath: "/tmp/repo",
+		TargetBranch:   "release",
+		BaseBranch:     "main",
+		BaseCommitSHA:  "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		Strategy:       protocol.AssembleStrategyMerge,
+		Sources: []types.AssembleSource{
+			{TaskID: "run-1", Branch: "task-run-1"},
+			{TaskID: "run-2", Branch: "task-run-2"},
+			{TaskID: "run-3", Branch: "task-run-3"},
+		},
+		OrchestratorTaskQueue: "orchestrator-queue",
+	}
+}
+
+func registerAssembleActivities(env *testsuite.TestWorkflowEnvironment) {
+	env.RegisterActivityWithOptions(func(context.Context, types.CreateWorktreeActivityInput) (*types.CreateWorktreeActivityOutput, error) {
+		return nil, nil
+	}, activity.RegisterOptions{Name: "CreateWorktreeActivity"})
+	env.RegisterActivityWithOptions(func(context.Context, types.IntegrateBranchActivityInput) (*types.IntegrateBranchActivityOutput, error) {
+		return nil, nil
+	}, activity.RegisterOptions{Name: "IntegrateBranchActivity"})
+	env.RegisterActivityWithOptions(promoteFastForwardBranchActivity, activity.RegisterOptions{Name: "FastForwardBranchActivity"})
+	env.RegisterActivityWithOptions(func(context.Context, types.RemoveWorktreeActivityInput) error {
+		return nil
+	}, activity.RegisterOptions{Name: "RemoveWorktreeActivity"})
+	env.RegisterActivityWithOptions(func(context.Context, types.DeleteBranchActivityInput) error {
+		return nil
+	}, activity.RegisterOptions{Name: "DeleteBranchActivity"})
+	env.RegisterActivityWithOptions(func(context.Context, types.PublishBranchAssemblyEventInput) error {
+		return nil
+	}, activity.RegisterOptions{Name: "PublishBranchAssemblyProgressEventActivity"})
+	env.RegisterActivityWithOptions(func(context.Context, types.PublishBranchAssemblyEventInput) error {
+		return nil
+	}, activity.RegisterOptions{Name: "PublishBranchAssembledEventActivity"})
+}
+
+func TestAssembleBranchWorkflow_ReportsConflictsPerSource(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	registerAssembleActivities(env)
+
+	input := baseAssembleInput()
+	firstSHA := "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+
+	var scratchBranch string
+	env.OnActivity("CreateWorktreeActivity", mock.Anything, mock.MatchedBy(func(in types.CreateWorktreeActivityInput) bool {
+		scratchBranch = in.BranchName
+		return in.BaseCommitSHA == input.BaseCommitSHA && in.BranchName == "task-"+in.TaskID
+	})).Return(&types.CreateWorktreeActivityOutput{WorktreePath: "/tmp/repo/.worktrees/assemble"}, nil)
+
+	// run-1 merges, run-2 conflicts and is skipped, run-3 was already in
+	integrated := []types.IntegrateBranchActivityOutput{
+		{HeadSHA: firstSHA},
+		{HeadSHA: firstSHA, Conflicts: []string{"main.go"}},
+		{HeadSHA: firstSHA, UpToDate: true},
+	}
+	for i, source := range input.Sources {
+		out := integrated[i]
+		branch := source.Branch
+		env.OnActivity("IntegrateBranchActivity", mock.Anything, mock.MatchedBy(func(in types.IntegrateBranchActivityInput) bool {
+			return in.Branch == branch && !in.CherryPick
+		})).Return(&out, nil).Once()
+	}
+
+	var progress []protocol.AssembleSourceResult
+	env.OnActivity("PublishBranchAssemblyProgressEventActivity", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, in types.PublishBranchAssemblyEventInput) error {
+			progress = append(progress, in.Sources[len(in.Sources)-1])
+			return nil
+		}).Times(3)
+
+	env.OnActivity("FastForwardBranchActivity", mock.Anything, mock.MatchedBy(func(in types.FastForwardBranchInput) bool {
+		return in.Branch == "release" && in.TargetSHA == firstSHA && in.ExpectedOldSHA == absentBranchSHA
+	})).Return(nil)
+	// The scratch worktree goes first, then its branch
+	var cleanup []string
+	env.OnActivity("RemoveWorktreeActivity", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, in types.RemoveWorktreeActivityInput) error {
+			cleanup = append(cleanup, "worktree "+in.WorktreePath)
+			return nil
+		}).Once()
+	env.OnActivity("DeleteBranchActivity", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, in types.DeleteBranchActivityInput) error {
+			cleanup = append(cleanup, "branch "+in.BranchName)
+			return nil
+		}).Once()
+
+	var final types.PublishBranchAssemblyEventInput
+	env.OnActivity("PublishBranchAssembledEventActivity", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, in types.PublishBranchAssemblyEventInput) error {
+			final = in
+			return nil
+		}).Once()
+
+	env.ExecuteWorkflow(AssembleBranchWorkflow, input)
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+
+	var output types.AssembleBranchWorkflowOutput
+	assert.NoError(t, env.GetWorkflowResult(&output))
+	assert.Equal(t, firstSHA, output.HeadSHA)
+	statuses := make([]protocol.AssembleSourceStatus, 0, len(output.Sources))
+	for _, source := range output.Sources {
+		statuses = append(statuses, source.Status)
+	}
+	assert.Equal(t, []protocol.AssembleSourceStatus{
+		protocol.AssembleSourceMerged, protocol.AssembleSourceConflicted, protocol.AssembleSourceUpToDate,
+	}, statuses)
+	assert.Equal(t, []string{"main.go"}, output.Sources[1].Conflicts)
+	assert.Equal(t, output.Sources, progress)
+
+	assert.Equal(t, "rpc-7", final.Metadata.IdempotencyKey)
+	assert.Empty(t, final.Error)
+	assert.Equal(t, firstSHA, final.HeadSHA)
+	assert.Len(t, final.Sources, 3)
+
+	assert.Equal(t, []string{"worktree /tmp/repo/.worktrees/assemble", "branch " + scratchBranch}, cleanup)
+
+	env.AssertExpectations(t)
+}
+
+func TestAssembleBranchWorkflow_FailureRepliesWithError(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	registerAssembleActivities(env)
+
+	input := baseAssembleInput()
+	input.Strategy = protocol.AssembleStrategyCherryPick
+
+	env.OnActivity("CreateWorktreeActivity", mock.Anything, mock.Anything).
+		Return(&types.CreateWorktreeActivityOutput{WorktreePath: "/tmp/repo/.worktrees/assemble"}, nil)
+	env.OnActivity("IntegrateBranchActivity", mock.Anything, mock.MatchedBy(func(in types.IntegrateBranchActivityInput) bool {
+		return in.CherryPick
+	})).Return(nil, errors.New("disk full"))
+
+	// The scratch worktree and its branch are removed and the target branch never created
+	env.OnActivity("RemoveWorktreeActivity", mock.Anything, mock.Anything).Return(nil).Once()
+	env.OnActivity("DeleteBranchActivity", mock.Anything, mock.MatchedBy(func(in types.DeleteBranchActivityInput) bool {
+		return in.RepositoryPath == input.RepositoryPath && in.BranchName != input.TargetBranch
+	})).Return(nil).Once()
+
+	var final types.PublishBranchAssemblyEventInput
+	env.OnActivity("PublishBranchAssembledEventActivity", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, in types.PublishBranchAssemblyEventInput) error {
+			final = in
+			return nil
+		}).Once()
+
+	env.ExecuteWorkflow(AssembleBranchWorkflow, input)
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.Error(t, env.GetWorkflowError())
+	assert.Contains(t, final.Error, "run-1")
+	assert.Equal(t, "rpc-7", final.Metadata.IdempotencyKey)
+
+	env.AssertExpectations(t)
+	env.AssertNotCalled(t, "FastForwardBranchActivity", mock.Anything, mock.Anything)
+}
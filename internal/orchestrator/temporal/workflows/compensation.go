@@ -74,3 +74,19 @@ func containerCompensation(containerID string) compensation {
 		},
 	}
 }
+
+// branchCompensation deletes a branch. Register it before the compensation removing the
+// branch's worktree: compensations run in reverse, and a branch checked out in a worktree
+// cannot be deleted.
+func branchCompensation(branchName, repositoryPath string) compensation {
+	return compensation{
+		name: "DeleteBranch",
+		action: func(ctx workflow.Context) error {
+			cleanupCtx := workflow.WithActivityOptions(ctx, compensationActivityOptions())
+			return workflow.ExecuteActivity(cleanupCtx, "DeleteBranchActivity", types.DeleteBranchActivityInput{
+				RepositoryPath: repositoryPath,
+				BranchName:     branchName,
+			}).Get(cleanupCtx, nil)
+		},
+	}
+}
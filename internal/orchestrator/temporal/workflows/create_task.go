@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/noldarim/noldarim/internal/orchestrator/agents"
 	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
 	"github.com/noldarim/noldarim/internal/orchestrator/temporal/utils"
 
@@ -124,9 +125,10 @@ func CreateTaskWorkflow(ctx workflow.Context, input types.CreateTaskWorkflowInpu
 	}
 	// Note: Task file is idempotent and reusable, no compensation needed
 
-	// Update agent config with actual file path
+	// Update agent config with actual file path and the project built-in
 	if input.AgentConfig != nil && input.AgentConfig.Variables != nil {
 		input.AgentConfig.Variables["task_file"] = writeFileResult.FilePath
+		input.AgentConfig.Variables[agents.VarProjectID] = input.ProjectID
 	}
 
 	// Step 2: Create task in database with file path
@@ -137,6 +139,7 @@ func CreateTaskWorkflow(ctx workflow.Context, input types.CreateTaskWorkflowInpu
 		Title:        input.Title,
 		Description:  input.Description,
 		TaskFilePath: writeFileResult.FilePath,
+		Labels:       input.Labels,
 	}).Get(ctx, &createTaskResult)
 	if err != nil {
 		logger.Error("Failed to create task in database", "error", err)
@@ -179,7 +182,7 @@ func CreateTaskWorkflow(ctx workflow.Context, input types.CreateTaskWorkflowInpu
 	var worktreeResult types.CreateWorktreeActivityOutput
 	err = workflow.ExecuteActivity(ctx, "CreateWorktreeActivity", types.CreateWorktreeActivityInput{
 		TaskID:         taskID,
-		BranchName:     fmt.Sprintf("task/%s", taskID),
+		BranchName:     fmt.Sprintf("task-%s", taskID), // The task branch, see services.GenerateTaskBranchName
 		RepositoryPath: input.RepositoryPath,
 		BaseCommitSHA:  input.BaseCommitSHA,
 	}).Get(ctx, &worktreeResult)
@@ -278,11 +281,17 @@ func CreateTaskWorkflow(ctx workflow.Context, input types.CreateTaskWorkflowInpu
 
 	// Step 8: Start AI processing as child workflow
 	childWorkflowOptions := workflow.ChildWorkflowOptions{
-		WorkflowID:               fmt.Sprintf("process-task-%s", taskID), // Set explicit workflow ID
-		WorkflowExecutionTimeout: 30 * time.Minute,                       // Extended timeout for AI processing
+		WorkflowID:               ProcessTaskWorkflowIDPrefix + taskID, // Set explicit workflow ID
+		WorkflowExecutionTimeout: 30 * time.Minute,                     // Extended timeout for AI processing
 		WorkflowTaskTimeout:      time.Minute,
 		ParentClosePolicy:        enums.PARENT_CLOSE_POLICY_ABANDON, // Let processing continue even if parent finishes
 		TaskQueue:                taskQueueName,                     // Use dynamic task queue for remote execution
+		// Retried attempts resume from the checkpoint of the failed one (e.g. after an agent crash)
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumAttempts:    3,
+		},
 	}
 	childCtx := workflow.WithChildOptions(ctx, childWorkflowOptions)
 
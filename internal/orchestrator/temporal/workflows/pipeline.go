@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/noldarim/noldarim/internal/orchestrator/agents"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
 	"github.com/noldarim/noldarim/internal/protocol"
@@ -22,6 +23,11 @@ const (
 	PipelineWorkflowVersion = "v2.3.0" // Explicit child workflow cancellation for faster Ctrl+C response
 )
 
+// stepWorkflowID is the workflow ID of the ProcessingStepWorkflow running stepID of a run
+func stepWorkflowID(runID, stepID string) string {
+	return fmt.Sprintf("%s-step-%s", runID, stepID)
+}
+
 // handlePipelineCancellation handles cleanup when pipeline is cancelled
 func handlePipelineCancellation(ctx workflow.Context, runID string, orchestratorActivityOptions workflow.ActivityOptions, output *types.PipelineWorkflowOutput, operation string) error {
 	workflow.GetLogger(ctx).Info("Pipeline cancelled by user", "operation", operation)
@@ -165,6 +171,8 @@ func PipelineWorkflow(ctx workflow.Context, input types.PipelineWorkflowInput) (
 		OrchestratorTaskQueue: input.OrchestratorTaskQueue,
 		ParentWorkflowID:      workflow.GetInfo(ctx).WorkflowExecution.ID,
 		AutoPromote:           input.AutoPromote,
+		Labels:                input.Labels,
+		WorkingSubdir:         input.WorkingSubdir,
 	}
 
 	var setupOutput types.PipelineSetupOutput
@@ -233,6 +241,10 @@ func PipelineWorkflow(ctx workflow.Context, input types.PipelineWorkflowInput) (
 
 	// Default transcript directory (where Claude writes session files)
 	transcriptDir := "/home/noldarim/.claude/projects/-workspace"
+	if input.WorkingSubdir != "" {
+		// Claude keys its project directory by the agent's working directory
+		transcriptDir = claudeTranscriptDir(agentWorkDir(input.WorkspaceDir, input.WorkingSubdir))
+	}
 
 	obsWorkflowOptions := workflow.ChildWorkflowOptions{
 		WorkflowID:               fmt.Sprintf("%s-observability", input.RunID),
@@ -252,6 +264,10 @@ func PipelineWorkflow(ctx workflow.Context, input types.PipelineWorkflowInput) (
 		ProcessTaskWorkflowID: workflow.GetInfo(ctx).WorkflowExecution.ID,
 		OrchestratorTaskQueue: input.OrchestratorTaskQueue,
 		RuntimeName:           pipelineRuntimeName,
+		TokenBudget:           input.TokenBudget,
+		HardBudget:            input.HardBudget,
+		IdleTimeout:           input.IdleTimeout,
+		FinalizeOnIdle:        input.FinalizeOnIdle,
 	})
 
 	// Wait for observability workflow to start (but not complete)
@@ -355,19 +371,27 @@ func PipelineWorkflow(ctx workflow.Context, input types.PipelineWorkflowInput) (
 				finalPrompt = composedPrompt
 			}
 
+			// Copy variables so the step definition (and its hash) stays untouched
+			variables := make(map[string]string, len(stepDef.AgentConfig.Variables)+1)
+			for k, v := range stepDef.AgentConfig.Variables {
+				variables[k] = v
+			}
+			variables[agents.VarProjectID] = input.ProjectID
+
 			agentConfig = &protocol.AgentConfigInput{
 				ToolName:       stepDef.AgentConfig.ToolName,
 				ToolVersion:    stepDef.AgentConfig.ToolVersion,
 				PromptTemplate: finalPrompt,
-				Variables:      stepDef.AgentConfig.Variables,
+				Variables:      variables,
 				ToolOptions:    stepDef.AgentConfig.ToolOptions,
 				FlagFormat:     stepDef.AgentConfig.FlagFormat,
+				Env:            stepDef.AgentConfig.Env,
 			}
 		}
 
 		// Execute ProcessingStepWorkflow as child
 		stepChildOpts := workflow.ChildWorkflowOptions{
-			WorkflowID:               fmt.Sprintf("%s-step-%s", input.RunID, stepDef.StepID),
+			WorkflowID:               stepWorkflowID(input.RunID, stepDef.StepID),
 			WorkflowExecutionTimeout: 30 * time.Minute, // AI processing can take time
 			WorkflowTaskTimeout:      time.Minute,
 			TaskQueue:                runTaskQueue, // Steps run in container worker
@@ -381,15 +405,21 @@ func PipelineWorkflow(ctx workflow.Context, input types.PipelineWorkflowInput) (
 			StepID:                stepDef.StepID,
 			StepIndex:             i,
 			StepName:              stepDef.Name,
+			TaskTitle:             input.Name,
 			AgentConfig:           agentConfig,
 			WorktreePath:          setupOutput.WorktreePath,
+			BranchName:            branchName,
 			WorkspaceDir:          input.WorkspaceDir,
 			OrchestratorTaskQueue: input.OrchestratorTaskQueue,
+			WorkingSubdir:         input.WorkingSubdir,
+			CommitTemplate:        input.CommitTemplate,
+			DiffStreamInterval:    input.DiffStreamInterval,
+			DiffStreamMaxBytes:    input.DiffStreamMaxBytes,
 			PreviousCommitSHA:     currentCommit,
 		}
 
 		// Execute child workflow and track it for potential cancellation
-		childWorkflowID := fmt.Sprintf("%s-step-%s", input.RunID, stepDef.StepID)
+		childWorkflowID := stepWorkflowID(input.RunID, stepDef.StepID)
 		childFuture := workflow.ExecuteChildWorkflow(stepCtx, ProcessingStepWorkflow, stepInput)
 
 		var stepOutput types.ProcessingStepOutput
@@ -495,6 +525,7 @@ func PipelineWorkflow(ctx workflow.Context, input types.PipelineWorkflowInput) (
 		stepResult.CommitMessage = stepOutput.CommitMessage
 		stepResult.GitDiff = stepOutput.GitDiff
 		stepResult.FilesChanged = stepOutput.FilesChanged
+		stepResult.SignificantFilesChanged = stepOutput.SignificantFilesChanged
 		stepResult.Insertions = stepOutput.Insertions
 		stepResult.Deletions = stepOutput.Deletions
 		stepResult.InputTokens = stepOutput.InputTokens
@@ -503,6 +534,7 @@ func PipelineWorkflow(ctx workflow.Context, input types.PipelineWorkflowInput) (
 		stepResult.CacheCreateTokens = stepOutput.CacheCreateTokens
 		stepResult.AgentOutput = stepOutput.AgentOutput
 		stepResult.Duration = stepOutput.Duration
+		stepResult.Warning = stepOutput.Warning
 		stepResult.CompletedAt = &stepEndTime
 
 		// Non-fatal: best-effort save of completed step status
@@ -4,7 +4,9 @@
 package workflows
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
@@ -16,9 +18,111 @@ import (
 
 const (
 	ProcessTaskWorkflowName = "ProcessTaskWorkflow"
+
+	// ProcessTaskWorkflowIDPrefix prefixes the task ID in ProcessTask workflow IDs
+	ProcessTaskWorkflowIDPrefix = "process-task-"
+
+	// ProcessingMetadataQuery returns the workflow's *types.ProcessingMetadata
+	ProcessingMetadataQuery = "GetProcessingMetadata"
+
+	// ProcessTaskCheckpointErrorType is the ApplicationError type of a failed attempt;
+	// its details hold the attempt's *types.ProcessTaskCheckpoint
+	ProcessTaskCheckpointErrorType = "ProcessTaskCheckpoint"
+
+	// ProcessTaskConfigErrorType marks failures no retry can fix, such as a missing or
+	// unusable agent configuration
+	ProcessTaskConfigErrorType = "ProcessTaskConfig"
+)
+
+// Processing steps reported in ProcessingMetadata.Step
+const (
+	ProcessTaskStepStarting     = "starting"
+	ProcessTaskStepPreparing    = "preparing"
+	ProcessTaskStepRunningAgent = "running-agent"
+	ProcessTaskStepCapturing    = "capturing-diff"
+	ProcessTaskStepCommitting   = "committing"
+	ProcessTaskStepFinishing    = "finishing"
 )
 
-// handleProcessTaskError handles error scenarios by updating status and publishing events
+// processTaskCheckpointSteps are the steps a retry can skip, in execution order
+var processTaskCheckpointSteps = []string{
+	ProcessTaskStepRunningAgent,
+	ProcessTaskStepCapturing,
+	ProcessTaskStepCommitting,
+}
+
+// checkpointReached reports whether the checkpoint records step as finished
+func checkpointReached(checkpoint types.ProcessTaskCheckpoint, step string) bool {
+	return checkpoint.StepIndex > slices.Index(processTaskCheckpointSteps, step)
+}
+
+// completeStep records step as finished in the checkpoint and saves it on the task, so a
+// retry resumes after it even when this attempt ends without returning its checkpoint
+// (e.g. a workflow timeout). Failing to save is not fatal.
+func completeStep(orchestratorCtx workflow.Context, input types.ProcessTaskWorkflowInput, checkpoint *types.ProcessTaskCheckpoint, step string) {
+	checkpoint.StepIndex = slices.Index(processTaskCheckpointSteps, step) + 1
+	checkpoint.CompletedStep = step
+	err := workflow.ExecuteActivity(orchestratorCtx, "SaveProcessTaskCheckpointActivity", types.SaveProcessTaskCheckpointActivityInput{
+		TaskID:     input.TaskID,
+		Checkpoint: *checkpoint,
+	}).Get(orchestratorCtx, nil)
+	if err != nil {
+		workflow.GetLogger(orchestratorCtx).Warn("Failed to save processing checkpoint", "error", err, "step", step)
+	}
+}
+
+// lastCheckpoint returns the checkpoint left by the previous attempt of this workflow,
+// or an empty checkpoint for the first attempt. The checkpoint comes from the previous
+// attempt's error, or from the task when that attempt failed without one.
+func lastCheckpoint(ctx, orchestratorCtx workflow.Context, input types.ProcessTaskWorkflowInput) types.ProcessTaskCheckpoint {
+	logger := workflow.GetLogger(ctx)
+	var checkpoint types.ProcessTaskCheckpoint
+	lastErr := workflow.GetLastError(ctx)
+	if lastErr == nil {
+		return checkpoint
+	}
+
+	var appErr *temporal.ApplicationError
+	if errors.As(lastErr, &appErr) && appErr.Type() == ProcessTaskCheckpointErrorType {
+		err := appErr.Details(&checkpoint)
+		if err == nil {
+			return checkpoint
+		}
+		logger.Warn("Ignoring unreadable checkpoint of previous attempt", "error", err)
+	}
+
+	checkpoint = types.ProcessTaskCheckpoint{}
+	err := workflow.ExecuteActivity(orchestratorCtx, "LoadProcessTaskCheckpointActivity", types.LoadProcessTaskCheckpointActivityInput{
+		TaskID: input.TaskID,
+	}).Get(orchestratorCtx, &checkpoint)
+	if err != nil {
+		logger.Warn("Failed to load saved checkpoint, starting fresh", "error", err)
+		return types.ProcessTaskCheckpoint{}
+	}
+	return checkpoint
+}
+
+// finalAttempt reports whether this attempt is the last one the workflow's retry policy allows
+func finalAttempt(ctx workflow.Context) bool {
+	info := workflow.GetInfo(ctx)
+	return isFinalAttempt(info.Attempt, info.RetryPolicy)
+}
+
+// isFinalAttempt reports whether policy allows no attempt after attempt. Without a policy
+// there are no retries; without a maximum they are unlimited.
+func isFinalAttempt(attempt int32, policy *temporal.RetryPolicy) bool {
+	return policy == nil || (policy.MaximumAttempts > 0 && attempt >= policy.MaximumAttempts)
+}
+
+// isNonRetryable reports whether err is an ApplicationError that stops the workflow's retries
+func isNonRetryable(err error) bool {
+	var appErr *temporal.ApplicationError
+	return errors.As(err, &appErr) && appErr.NonRetryable()
+}
+
+// handleProcessTaskError handles error scenarios by updating status and publishing events.
+// An attempt that will be retried leaves the task in progress: only the final attempt, or
+// a non-retryable error, fails it.
 func handleProcessTaskError(
 	orchestratorCtx workflow.Context,
 	input types.ProcessTaskWorkflowInput,
@@ -27,6 +131,12 @@ func handleProcessTaskError(
 	errorContext string,
 ) {
 	logger := workflow.GetLogger(orchestratorCtx)
+	if !finalAttempt(orchestratorCtx) && !isNonRetryable(err) {
+		logger.Warn("Processing attempt failed, leaving the task to the retry",
+			"error", err,
+			"attempt", workflow.GetInfo(orchestratorCtx).Attempt)
+		return
+	}
 
 	// Update task status to failed in database
 	updateErr := workflow.ExecuteActivity(orchestratorCtx, "UpdateTaskStatusActivity", types.UpdateTaskStatusActivityInput{
@@ -57,24 +167,29 @@ func eventInput(projectID, taskID string) types.PublishEventInput {
 	}
 }
 
-// ProcessTaskWorkflow orchestrates AI processing of a created task
-func ProcessTaskWorkflow(ctx workflow.Context, input types.ProcessTaskWorkflowInput) (*types.ProcessTaskWorkflowOutput, error) {
+// ProcessTaskWorkflow orchestrates AI processing of a created task.
+// Every step that finishes is recorded in a checkpoint, which is saved on the task and
+// returned in a failed attempt's error. When the workflow is retried (e.g. after the agent
+// process died), the next attempt reuses the worktree and skips the steps the checkpoint
+// records as finished; the transcript watcher resumes from its persisted read offsets.
+func ProcessTaskWorkflow(ctx workflow.Context, input types.ProcessTaskWorkflowInput) (output *types.ProcessTaskWorkflowOutput, err error) {
 	logger := workflow.GetLogger(ctx)
 	logger.Info("Starting ProcessTask workflow",
 		"taskID", input.TaskID,
 		"taskFilePath", input.TaskFilePath)
 
-	output := &types.ProcessTaskWorkflowOutput{
+	output = &types.ProcessTaskWorkflowOutput{
 		Success: false,
 	}
 
 	// Initialize metadata for query handler
 	metadata := &types.ProcessingMetadata{
+		Step:      ProcessTaskStepStarting,
 		Timestamp: workflow.Now(ctx),
 	}
 
 	// Register query handler for processing metadata
-	err := workflow.SetQueryHandler(ctx, "GetProcessingMetadata", func() (*types.ProcessingMetadata, error) {
+	err = workflow.SetQueryHandler(ctx, ProcessingMetadataQuery, func() (*types.ProcessingMetadata, error) {
 		return metadata, nil
 	})
 	if err != nil {
@@ -108,6 +223,27 @@ func ProcessTaskWorkflow(ctx workflow.Context, input types.ProcessTaskWorkflowIn
 	}
 	orchestratorCtx := workflow.WithActivityOptions(ctx, orchestratorActivityOptions)
 
+	checkpoint := lastCheckpoint(ctx, orchestratorCtx, input)
+	if input.WorktreePath == "" {
+		input.WorktreePath = checkpoint.WorktreePath
+	}
+	checkpoint.WorktreePath = input.WorktreePath
+	defer func() {
+		switch {
+		case err == nil || temporal.IsCanceledError(err):
+		case isNonRetryable(err):
+			err = temporal.NewNonRetryableApplicationError(err.Error(), ProcessTaskCheckpointErrorType, err, checkpoint)
+		default:
+			err = temporal.NewApplicationErrorWithCause(err.Error(), ProcessTaskCheckpointErrorType, err, checkpoint)
+		}
+	}()
+	metadata.ResumedFrom = checkpoint.CompletedStep
+	if checkpoint.CompletedStep != "" {
+		logger.Info("Resuming from checkpoint of previous attempt",
+			"completedStep", checkpoint.CompletedStep,
+			"worktreePath", checkpoint.WorktreePath)
+	}
+
 	// Start AIObservabilityWorkflow as a child workflow
 	// Uses TERMINATE policy so it automatically stops when ProcessTask completes
 	obsWorkflowOptions := workflow.ChildWorkflowOptions{
@@ -136,6 +272,7 @@ func ProcessTaskWorkflow(ctx workflow.Context, input types.ProcessTaskWorkflowIn
 		ProcessTaskWorkflowID: workflow.GetInfo(ctx).WorkflowExecution.ID,
 		OrchestratorTaskQueue: input.OrchestratorTaskQueue,
 		RuntimeName:           runtimeName,
+		ResumeOffsets:         true,
 	})
 
 	// Wait for observability workflow to start (but not complete)
@@ -162,65 +299,89 @@ func ProcessTaskWorkflow(ctx workflow.Context, input types.ProcessTaskWorkflowIn
 		return output, fmt.Errorf("failed to update task status: %w", err)
 	}
 
-	// Step 2: Publish TaskInProgress event to indicate processing has started
-	err = workflow.ExecuteActivity(orchestratorCtx, "PublishTaskInProgressEventActivity", eventInput(input.ProjectID, input.TaskID)).Get(orchestratorCtx, nil)
+	// Step 2: Publish TaskInProgress event to indicate processing has started (or resumed)
+	inProgress := eventInput(input.ProjectID, input.TaskID)
+	inProgress.ResumedFrom = checkpoint.CompletedStep
+	err = workflow.ExecuteActivity(orchestratorCtx, "PublishTaskInProgressEventActivity", inProgress).Get(orchestratorCtx, nil)
 	if err != nil {
 		logger.Error("Failed to publish TaskInProgressEvent", "error", err)
 		output.Error = fmt.Sprintf("Failed to publish TaskInProgressEvent: %v", err)
 		return output, fmt.Errorf("failed to publish TaskInProgressEvent: %w", err)
 	}
 
-	// Step 3: Prepare command from AgentConfig
-	if input.AgentConfig == nil {
-		err := fmt.Errorf("AgentConfig is required but was not provided")
-		logger.Error("Missing agent configuration", "error", err)
-		output.Error = err.Error()
-		return output, err
-	}
+	// Steps 3-4 are skipped when a previous attempt already ran the agent to completion
+	if checkpointReached(checkpoint, ProcessTaskStepRunningAgent) {
+		logger.Info("Skipping agent run finished by previous attempt")
+		output.Success = true
+		output.ProcessedData = checkpoint.AgentOutput
+		metadata.CommandOutput = checkpoint.AgentOutput
+		metadata.ProcessingTime = checkpoint.ProcessingTime
+	} else {
+		// Step 3: Prepare command from AgentConfig
+		metadata.Step = ProcessTaskStepPreparing
+		if input.AgentConfig == nil {
+			err := temporal.NewNonRetryableApplicationError("AgentConfig is required but was not provided", ProcessTaskConfigErrorType, nil)
+			logger.Error("Missing agent configuration", "error", err)
+			handleProcessTaskError(orchestratorCtx, input, err, "Missing agent configuration", err.Error())
+			output.Error = err.Error()
+			return output, err
+		}
 
-	var commandToExecute []string
-	err = workflow.ExecuteActivity(ctx, "PrepareAgentCommandActivity", input.AgentConfig).Get(ctx, &commandToExecute)
-	if err != nil {
-		logger.Error("Failed to prepare agent command", "error", err)
-		handleProcessTaskError(orchestratorCtx, input, err, "Failed to prepare agent command", fmt.Sprintf("Preparation error: %v", err))
-		output.Error = fmt.Sprintf("Failed to prepare agent command: %v", err)
-		return output, err
-	}
-	logger.Info("Using agent config", "tool", input.AgentConfig.ToolName)
+		var commandToExecute []string
+		err = workflow.ExecuteActivity(ctx, "PrepareAgentCommandActivity", input.AgentConfig).Get(ctx, &commandToExecute)
+		if err != nil {
+			logger.Error("Failed to prepare agent command", "error", err)
+			// The activity already retried; an agent config it cannot turn into a command won't improve
+			err = temporal.NewNonRetryableApplicationError(fmt.Sprintf("failed to prepare agent command: %v", err), ProcessTaskConfigErrorType, err)
+			handleProcessTaskError(orchestratorCtx, input, err, "Failed to prepare agent command", fmt.Sprintf("Preparation error: %v", err))
+			output.Error = err.Error()
+			return output, err
+		}
+		logger.Info("Using agent config", "tool", input.AgentConfig.ToolName)
 
-	// Step 4: Execute dynamic processing command locally
-	var commandResult types.LocalExecuteActivityOutput
-	err = workflow.ExecuteActivity(ctx, "LocalExecuteActivity", types.LocalExecuteActivityInput{
-		Command: commandToExecute,
-		WorkDir: input.WorkspaceDir,
-	}).Get(ctx, &commandResult)
+		// Step 4: Execute dynamic processing command locally
+		metadata.Step = ProcessTaskStepRunningAgent
+		var commandResult types.LocalExecuteActivityOutput
+		err = workflow.ExecuteActivity(ctx, "LocalExecuteActivity", types.LocalExecuteActivityInput{
+			Command: commandToExecute,
+			WorkDir: input.WorkspaceDir,
+			Env:     input.AgentConfig.Env,
+		}).Get(ctx, &commandResult)
 
-	if err != nil {
-		logger.Error("Failed to execute AI processing command", "error", err)
-		handleProcessTaskError(orchestratorCtx, input, err, "Failed to process task", fmt.Sprintf("Command execution error: %v", err))
-		output.Error = fmt.Sprintf("Failed to execute processing command: %v", err)
-		return output, err
-	}
+		if err != nil {
+			logger.Error("Failed to execute AI processing command", "error", err)
+			handleProcessTaskError(orchestratorCtx, input, err, "Failed to process task", fmt.Sprintf("Command execution error: %v", err))
+			output.Error = fmt.Sprintf("Failed to execute processing command: %v", err)
+			return output, err
+		}
 
-	// Check if command executed successfully
-	if !commandResult.Success {
-		err := fmt.Errorf("processing command failed: exit code %d", commandResult.ExitCode)
-		logger.Error("AI processing command failed",
-			"exitCode", commandResult.ExitCode,
-			"errorOutput", commandResult.ErrorOutput)
-		handleProcessTaskError(orchestratorCtx, input, err, "Task processing failed", fmt.Sprintf("Exit code: %d, stderr: %s", commandResult.ExitCode, commandResult.ErrorOutput))
-		output.Error = fmt.Sprintf("Processing command failed with exit code %d: %s", commandResult.ExitCode, commandResult.ErrorOutput)
-		return output, err
-	}
+		// Check if command executed successfully
+		if !commandResult.Success {
+			err := fmt.Errorf("processing command failed: exit code %d", commandResult.ExitCode)
+			logger.Error("AI processing command failed",
+				"exitCode", commandResult.ExitCode,
+				"errorOutput", commandResult.ErrorOutput)
+			handleProcessTaskError(orchestratorCtx, input, err, "Task processing failed", fmt.Sprintf("Exit code: %d, stderr: %s", commandResult.ExitCode, commandResult.ErrorOutput))
+			output.Error = fmt.Sprintf("Processing command failed with exit code %d: %s", commandResult.ExitCode, commandResult.ErrorOutput)
+			return output, err
+		}
 
-	// Store processing results
-	output.Success = true
-	output.ProcessedData = commandResult.Output
-	metadata.CommandOutput = commandResult.Output
-	metadata.ProcessingTime = commandResult.Duration
+		// Store processing results
+		output.Success = true
+		output.ProcessedData = commandResult.Output
+		metadata.CommandOutput = commandResult.Output
+		metadata.ProcessingTime = commandResult.Duration
+
+		checkpoint.AgentOutput = commandResult.Output
+		checkpoint.ProcessingTime = commandResult.Duration
+		completeStep(orchestratorCtx, input, &checkpoint, ProcessTaskStepRunningAgent)
+	}
 
 	// Step 5: Capture git diff before committing (for metadata)
-	if input.WorktreePath != "" {
+	if input.WorktreePath != "" && checkpointReached(checkpoint, ProcessTaskStepCapturing) {
+		metadata.GitDiff = checkpoint.GitDiff
+	} else if input.WorktreePath != "" {
+		metadata.Step = ProcessTaskStepCapturing
 		logger.Info("Capturing git diff", "worktreePath", input.WorktreePath)
 
 		var diffResult types.CaptureGitDiffActivityOutput
@@ -255,11 +416,15 @@ func ProcessTaskWorkflow(ctx workflow.Context, input types.ProcessTaskWorkflowIn
 				logger.Info("Successfully saved git diff to database")
 			}
 		}
+
+		checkpoint.GitDiff = &diffResult
+		completeStep(orchestratorCtx, input, &checkpoint, ProcessTaskStepCapturing)
 	}
 
 	// Step 6: Commit any changes made by the agent (CRITICAL for idempotency)
 	// This executes on the orchestrator worker since GitCommitActivity is not registered on agent worker
-	if input.WorktreePath != "" {
+	if input.WorktreePath != "" && !checkpointReached(checkpoint, ProcessTaskStepCommitting) {
+		metadata.Step = ProcessTaskStepCommitting
 		logger.Info("Attempting to commit agent changes", "worktreePath", input.WorktreePath)
 
 		var commitResult types.GitCommitActivityOutput
@@ -292,9 +457,11 @@ func ProcessTaskWorkflow(ctx workflow.Context, input types.ProcessTaskWorkflowIn
 
 		logger.Info("Successfully committed agent changes",
 			"worktreePath", input.WorktreePath)
+		completeStep(orchestratorCtx, input, &checkpoint, ProcessTaskStepCommitting)
 	}
 
 	// Step 7: Wait 5 seconds for observability to finish reading final data
+	metadata.Step = ProcessTaskStepFinishing
 	// The child workflow will be terminated when we complete (PARENT_CLOSE_POLICY_TERMINATE)
 	// but we give it time to read/forward the last transcript lines
 	logger.Info("Waiting 5s for observability to read final data")
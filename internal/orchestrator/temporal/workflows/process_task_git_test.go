@@ -5,6 +5,7 @@ package workflows
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/testsuite"
 )
 
@@ -40,6 +42,16 @@ func PublishTaskFinishedEventActivity(ctx context.Context, input types.PublishEv
 	return nil
 }
 
+// SaveProcessTaskCheckpointActivity mock for testing
+func SaveProcessTaskCheckpointActivity(ctx context.Context, input types.SaveProcessTaskCheckpointActivityInput) error {
+	return nil
+}
+
+// LoadProcessTaskCheckpointActivity mock for testing
+func LoadProcessTaskCheckpointActivity(ctx context.Context, input types.LoadProcessTaskCheckpointActivityInput) (*types.ProcessTaskCheckpoint, error) {
+	return &types.ProcessTaskCheckpoint{}, nil
+}
+
 
 // ProcessTaskGitTestSuite tests the cross-worker git commit functionality
 type ProcessTaskGitTestSuite struct {
@@ -65,6 +77,7 @@ func (s *ProcessTaskGitTestSuite) TestProcessTaskWorkflow_GitCommit() {
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(PublishErrorEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Register child workflow
 	env.RegisterWorkflow(AIObservabilityWorkflow)
@@ -159,6 +172,7 @@ func (s *ProcessTaskGitTestSuite) TestProcessTaskWorkflow_GitCommitFailure() {
 	env.RegisterActivity(PublishTaskInProgressEventActivity)
 	env.RegisterActivity(PublishErrorEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Register child workflow
 	env.RegisterWorkflow(AIObservabilityWorkflow)
@@ -223,7 +237,165 @@ func (s *ProcessTaskGitTestSuite) TestProcessTaskWorkflow_GitCommitFailure() {
 	require.Error(s.T(), err)
 	require.Contains(s.T(), err.Error(), "git commit failed")
 
-	// Verify the workflow returns an error (idempotency check failed)
+	// The error carries the checkpoint a retry resumes from: agent run and diff are done
+	var appErr *temporal.ApplicationError
+	require.True(s.T(), errors.As(err, &appErr))
+	require.Equal(s.T(), ProcessTaskCheckpointErrorType, appErr.Type())
+
+	var checkpoint types.ProcessTaskCheckpoint
+	require.NoError(s.T(), appErr.Details(&checkpoint))
+	require.Equal(s.T(), 2, checkpoint.StepIndex)
+	require.Equal(s.T(), ProcessTaskStepCapturing, checkpoint.CompletedStep)
+	require.Equal(s.T(), "/tmp/worktrees/task-789", checkpoint.WorktreePath)
+	require.Equal(s.T(), "Success", checkpoint.AgentOutput)
+	require.NotNil(s.T(), checkpoint.GitDiff)
+	require.Equal(s.T(), []string{"test.txt"}, checkpoint.GitDiff.FilesChanged)
+}
+
+// TestProcessTaskWorkflow_ResumesFromCheckpoint tests that a retry skips the steps the
+// failed attempt finished and reuses its worktree
+func (s *ProcessTaskGitTestSuite) TestProcessTaskWorkflow_ResumesFromCheckpoint() {
+	env := s.NewTestWorkflowEnvironment()
+
+	env.RegisterWorkflow(ProcessTaskWorkflow)
+	env.RegisterActivity(PrepareAgentCommandActivity)
+	env.RegisterActivity(LocalExecuteActivity)
+	env.RegisterActivity(CaptureGitDiffActivity)
+	env.RegisterActivity(GitCommitActivity)
+	env.RegisterActivity(PublishTaskInProgressEventActivity)
+	env.RegisterActivity(PublishTaskFinishedEventActivity)
+	env.RegisterActivity(PublishErrorEventActivity)
+	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
+	env.RegisterWorkflow(AIObservabilityWorkflow)
+
+	checkpoint := types.ProcessTaskCheckpoint{
+		StepIndex:     2,
+		CompletedStep: ProcessTaskStepCapturing,
+		WorktreePath:  "/tmp/worktrees/task-789",
+		AgentOutput:   "Agent finished",
+		GitDiff:       &types.CaptureGitDiffActivityOutput{Success: true, FilesChanged: []string{"test.txt"}, HasChanges: true},
+	}
+	env.SetLastError(temporal.NewApplicationError("git commit failed", ProcessTaskCheckpointErrorType, checkpoint))
+
+	// The input of a retry without a worktree path falls back to the checkpoint's
+	input := types.ProcessTaskWorkflowInput{
+		TaskID:       "task-789",
+		ProjectID:    "project-456",
+		WorkspaceDir: "/workspace",
+		AgentConfig: &protocol.AgentConfigInput{
+			ToolName:       "test",
+			PromptTemplate: "echo 'test' > test.txt",
+		},
+		OrchestratorTaskQueue: "noldarim-task-queue",
+	}
+
+	env.OnActivity(PublishTaskInProgressEventActivity, mock.Anything, mock.MatchedBy(func(input types.PublishEventInput) bool {
+		return input.ResumedFrom == ProcessTaskStepCapturing
+	})).Return(nil).Once()
+	env.OnActivity(GitCommitActivity, mock.Anything, mock.MatchedBy(func(input types.GitCommitActivityInput) bool {
+		return input.RepositoryPath == "/tmp/worktrees/task-789"
+	})).Return(&types.GitCommitActivityOutput{Success: true}, nil).Once()
+	env.OnActivity(PublishTaskFinishedEventActivity, mock.Anything, mock.Anything).Return(nil).Once()
+
+	env.ExecuteWorkflow(ProcessTaskWorkflow, input)
+
+	require.True(s.T(), env.IsWorkflowCompleted())
+	require.NoError(s.T(), env.GetWorkflowError())
+
+	var output types.ProcessTaskWorkflowOutput
+	require.NoError(s.T(), env.GetWorkflowResult(&output))
+	require.True(s.T(), output.Success)
+	require.Equal(s.T(), "Agent finished", output.ProcessedData)
+
+	queryResult, err := env.QueryWorkflow(ProcessingMetadataQuery)
+	require.NoError(s.T(), err)
+	var metadata types.ProcessingMetadata
+	require.NoError(s.T(), queryResult.Get(&metadata))
+	require.Equal(s.T(), ProcessTaskStepCapturing, metadata.ResumedFrom)
+	require.Equal(s.T(), []string{"test.txt"}, metadata.GitDiff.FilesChanged)
+
+	// The agent run and diff capture were not repeated
+	env.AssertNotCalled(s.T(), "LocalExecuteActivity", mock.Anything, mock.Anything)
+	env.AssertNotCalled(s.T(), "CaptureGitDiffActivity", mock.Anything, mock.Anything)
+	env.AssertExpectations(s.T())
+}
+
+// TestProcessTaskWorkflow_ResumesFromSavedCheckpoint tests that a retry after an attempt that
+// failed without returning its checkpoint resumes from the one saved on the task
+func (s *ProcessTaskGitTestSuite) TestProcessTaskWorkflow_ResumesFromSavedCheckpoint() {
+	env := s.NewTestWorkflowEnvironment()
+
+	env.RegisterWorkflow(ProcessTaskWorkflow)
+	env.RegisterActivity(PrepareAgentCommandActivity)
+	env.RegisterActivity(LocalExecuteActivity)
+	env.RegisterActivity(CaptureGitDiffActivity)
+	env.RegisterActivity(GitCommitActivity)
+	env.RegisterActivity(PublishTaskInProgressEventActivity)
+	env.RegisterActivity(PublishTaskFinishedEventActivity)
+	env.RegisterActivity(PublishErrorEventActivity)
+	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
+	env.RegisterActivity(LoadProcessTaskCheckpointActivity)
+	env.RegisterWorkflow(AIObservabilityWorkflow)
+
+	// E.g. the previous attempt timed out while capturing the diff
+	env.SetLastError(errors.New("workflow timed out"))
+
+	input := types.ProcessTaskWorkflowInput{
+		TaskID:       "task-789",
+		ProjectID:    "project-456",
+		WorkspaceDir: "/workspace",
+		AgentConfig: &protocol.AgentConfigInput{
+			ToolName:       "test",
+			PromptTemplate: "echo 'test' > test.txt",
+		},
+		OrchestratorTaskQueue: "noldarim-task-queue",
+	}
+
+	env.OnActivity(LoadProcessTaskCheckpointActivity, mock.Anything, types.LoadProcessTaskCheckpointActivityInput{TaskID: "task-789"}).
+		Return(&types.ProcessTaskCheckpoint{
+			StepIndex:     1,
+			CompletedStep: ProcessTaskStepRunningAgent,
+			WorktreePath:  "/tmp/worktrees/task-789",
+			AgentOutput:   "Agent finished",
+		}, nil).Once()
+	env.OnActivity(PublishTaskInProgressEventActivity, mock.Anything, mock.MatchedBy(func(input types.PublishEventInput) bool {
+		return input.ResumedFrom == ProcessTaskStepRunningAgent
+	})).Return(nil).Once()
+	env.OnActivity(CaptureGitDiffActivity, mock.Anything, mock.Anything).
+		Return(&types.CaptureGitDiffActivityOutput{Success: true}, nil).Once()
+	env.OnActivity(GitCommitActivity, mock.Anything, mock.MatchedBy(func(input types.GitCommitActivityInput) bool {
+		return input.RepositoryPath == "/tmp/worktrees/task-789"
+	})).Return(&types.GitCommitActivityOutput{Success: true}, nil).Once()
+	env.OnActivity(PublishTaskFinishedEventActivity, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// Every finished step is saved on the task as it completes
+	var saved []types.ProcessTaskCheckpoint
+	env.OnActivity(SaveProcessTaskCheckpointActivity, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input types.SaveProcessTaskCheckpointActivityInput) error {
+			saved = append(saved, input.Checkpoint)
+			return nil
+		})
+
+	env.ExecuteWorkflow(ProcessTaskWorkflow, input)
+
+	require.True(s.T(), env.IsWorkflowCompleted())
+	require.NoError(s.T(), env.GetWorkflowError())
+
+	var output types.ProcessTaskWorkflowOutput
+	require.NoError(s.T(), env.GetWorkflowResult(&output))
+	require.Equal(s.T(), "Agent finished", output.ProcessedData)
+
+	require.Len(s.T(), saved, 2)
+	require.Equal(s.T(), 2, saved[0].StepIndex)
+	require.Equal(s.T(), ProcessTaskStepCapturing, saved[0].CompletedStep)
+	require.Equal(s.T(), 3, saved[1].StepIndex)
+	require.Equal(s.T(), ProcessTaskStepCommitting, saved[1].CompletedStep)
+	require.Equal(s.T(), "/tmp/worktrees/task-789", saved[1].WorktreePath)
+
+	env.AssertNotCalled(s.T(), "LocalExecuteActivity", mock.Anything, mock.Anything)
+	env.AssertExpectations(s.T())
 }
 
 // TestProcessTaskWorkflow_NoWorktreePath tests backward compatibility when worktree path is empty
@@ -238,6 +410,7 @@ func (s *ProcessTaskGitTestSuite) TestProcessTaskWorkflow_NoWorktreePath() {
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(PublishErrorEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Register child workflow
 	env.RegisterWorkflow(AIObservabilityWorkflow)
@@ -320,6 +493,7 @@ func (s *ProcessTaskGitTestSuite) TestProcessTaskWorkflow_CrossWorkerExecution()
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(PublishErrorEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Register child workflow
 	env.RegisterWorkflow(AIObservabilityWorkflow)
@@ -407,6 +581,7 @@ func (s *ProcessTaskGitTestSuite) TestProcessTaskWorkflow_GitDiffCapture() {
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(PublishErrorEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Register child workflow
 	env.RegisterWorkflow(AIObservabilityWorkflow)
@@ -514,6 +689,7 @@ func (s *ProcessTaskGitTestSuite) TestProcessTaskWorkflow_GitDiffCaptureFailureC
 	env.RegisterActivity(PublishTaskInProgressEventActivity)
 	env.RegisterActivity(PublishErrorEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Register child workflow
 	env.RegisterWorkflow(AIObservabilityWorkflow)
@@ -591,6 +767,7 @@ func (s *ProcessTaskGitTestSuite) TestProcessTaskWorkflow_GitDiffNoChanges() {
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(PublishErrorEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Register child workflow
 	env.RegisterWorkflow(AIObservabilityWorkflow)
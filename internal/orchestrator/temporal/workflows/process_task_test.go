@@ -11,6 +11,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/testsuite"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
@@ -82,6 +85,7 @@ func TestProcessTaskWorkflow_Success(t *testing.T) {
 	env.RegisterActivity(PublishTaskInProgressEventActivity)
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 	env.OnActivity(PrepareAgentCommandActivity, mock.AnythingOfType("*context.timerCtx"), mock.Anything).Return(expectedCommandInput.Command, nil)
 	env.OnActivity(LocalExecuteActivity, mock.AnythingOfType("*context.timerCtx"), expectedCommandInput).Return(&mockCommandOutput, nil)
 
@@ -135,6 +139,7 @@ func TestProcessTaskWorkflow_LocalExecuteActivity_Error(t *testing.T) {
 	env.RegisterActivity(PublishTaskInProgressEventActivity)
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Register child workflow
 	env.RegisterWorkflow(AIObservabilityWorkflow)
@@ -167,6 +172,66 @@ func TestProcessTaskWorkflow_LocalExecuteActivity_Error(t *testing.T) {
 	env.AssertExpectations(t)
 }
 
+func TestProcessTaskWorkflow_ConfigErrorsAreNonRetryable(t *testing.T) {
+	agentConfig := &protocol.AgentConfigInput{ToolName: "unknown-tool", PromptTemplate: "noop"}
+
+	tests := []struct {
+		name        string
+		agentConfig *protocol.AgentConfigInput
+		prepareErr  error
+		wantMessage string
+	}{
+		{name: "missing agent config", agentConfig: nil, wantMessage: "AgentConfig is required"},
+		{name: "agent command cannot be prepared", agentConfig: agentConfig, prepareErr: errors.New("unknown tool: unknown-tool"), wantMessage: "unknown tool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testSuite := &testsuite.WorkflowTestSuite{}
+			env := testSuite.NewTestWorkflowEnvironment()
+
+			env.RegisterActivity(PrepareAgentCommandActivity)
+			env.RegisterActivity(PublishErrorEventActivity)
+			env.RegisterActivity(PublishTaskInProgressEventActivity)
+			env.RegisterActivity(UpdateTaskStatusActivity)
+			env.RegisterActivity(SaveProcessTaskCheckpointActivity)
+			env.RegisterWorkflow(AIObservabilityWorkflow)
+			env.SetStartWorkflowOptions(client.StartWorkflowOptions{
+				RetryPolicy: &temporal.RetryPolicy{MaximumAttempts: 3},
+			})
+
+			if tt.prepareErr != nil {
+				env.OnActivity(PrepareAgentCommandActivity, mock.Anything, mock.Anything).Return(nil, tt.prepareErr)
+			}
+			env.OnActivity(UpdateTaskStatusActivity, mock.Anything, mock.MatchedBy(func(input types.UpdateTaskStatusActivityInput) bool {
+				return input.Status == models.TaskStatusInProgress
+			})).Return(nil)
+			// The task is failed right away, although retries would remain
+			env.OnActivity(UpdateTaskStatusActivity, mock.Anything, mock.MatchedBy(func(input types.UpdateTaskStatusActivityInput) bool {
+				return input.Status == models.TaskStatusFailed
+			})).Return(nil).Once()
+
+			env.ExecuteWorkflow(ProcessTaskWorkflow, types.ProcessTaskWorkflowInput{
+				TaskID:                "task-123",
+				ProjectID:             "project-789",
+				WorkspaceDir:          "/workspace",
+				AgentConfig:           tt.agentConfig,
+				OrchestratorTaskQueue: "noldarim-task-queue",
+			})
+
+			require.True(t, env.IsWorkflowCompleted())
+			workflowError := env.GetWorkflowError()
+			require.Error(t, workflowError)
+			assert.Contains(t, workflowError.Error(), tt.wantMessage)
+
+			var appErr *temporal.ApplicationError
+			require.True(t, errors.As(workflowError, &appErr))
+			assert.True(t, appErr.NonRetryable())
+			assert.True(t, isNonRetryable(workflowError))
+		})
+	}
+}
+
 func TestProcessTaskWorkflow_CommandFailed_NonZeroExitCode(t *testing.T) {
 	testSuite := &testsuite.WorkflowTestSuite{}
 	env := testSuite.NewTestWorkflowEnvironment()
@@ -198,6 +263,7 @@ func TestProcessTaskWorkflow_CommandFailed_NonZeroExitCode(t *testing.T) {
 	env.RegisterActivity(PublishTaskInProgressEventActivity)
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Register child workflow
 	env.RegisterWorkflow(AIObservabilityWorkflow)
@@ -266,6 +332,7 @@ func TestProcessTaskWorkflow_PublishErrorEventActivity_Fails(t *testing.T) {
 	env.RegisterActivity(PublishTaskInProgressEventActivity)
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Register child workflow
 	env.RegisterWorkflow(AIObservabilityWorkflow)
@@ -341,6 +408,7 @@ func TestProcessTaskWorkflow_ActivityOptions_Configuration(t *testing.T) {
 	env.RegisterActivity(PublishTaskInProgressEventActivity)
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Mock PrepareAgentCommandActivity
 	env.OnActivity(PrepareAgentCommandActivity, mock.AnythingOfType("*context.timerCtx"), mock.Anything).Return(expectedCommandInput.Command, nil)
@@ -406,6 +474,7 @@ func TestProcessTaskWorkflow_EmptyInputFields(t *testing.T) {
 	env.RegisterActivity(PublishTaskInProgressEventActivity)
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Mock PrepareAgentCommandActivity
 	env.OnActivity(PrepareAgentCommandActivity, mock.AnythingOfType("*context.timerCtx"), mock.Anything).Return(expectedCommandInput.Command, nil)
@@ -471,6 +540,7 @@ func TestProcessTaskWorkflow_SpecialCharactersInInput(t *testing.T) {
 	env.RegisterActivity(PublishTaskInProgressEventActivity)
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Mock PrepareAgentCommandActivity
 	env.OnActivity(PrepareAgentCommandActivity, mock.AnythingOfType("*context.timerCtx"), mock.Anything).Return(expectedCommandInput.Command, nil)
@@ -538,6 +608,7 @@ func TestProcessTaskWorkflow_WorkflowOptions_Validation(t *testing.T) {
 	env.RegisterActivity(PublishTaskInProgressEventActivity)
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	// Mock PrepareAgentCommandActivity
 	env.OnActivity(PrepareAgentCommandActivity, mock.AnythingOfType("*context.timerCtx"), mock.Anything).Return(expectedCommandInput.Command, nil)
@@ -597,6 +668,7 @@ func TestProcessTaskWorkflow_QueryHandler_GetProcessingMetadata(t *testing.T) {
 	env.RegisterActivity(PublishTaskInProgressEventActivity)
 	env.RegisterActivity(PublishTaskFinishedEventActivity)
 	env.RegisterActivity(UpdateTaskStatusActivity)
+	env.RegisterActivity(SaveProcessTaskCheckpointActivity)
 
 	env.OnActivity(PrepareAgentCommandActivity, mock.AnythingOfType("*context.timerCtx"), mock.Anything).Return(expectedCommandInput.Command, nil)
 	env.OnActivity(LocalExecuteActivity, mock.AnythingOfType("*context.timerCtx"), expectedCommandInput).Return(&mockCommandOutput, nil)
@@ -621,6 +693,27 @@ func TestProcessTaskWorkflow_QueryHandler_GetProcessingMetadata(t *testing.T) {
 	assert.Equal(t, "Query test\n", metadata.CommandOutput)
 }
 
+func TestIsFinalAttempt(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int32
+		policy  *temporal.RetryPolicy
+		want    bool
+	}{
+		{name: "no retry policy", attempt: 1, policy: nil, want: true},
+		{name: "first of three", attempt: 1, policy: &temporal.RetryPolicy{MaximumAttempts: 3}, want: false},
+		{name: "second of three", attempt: 2, policy: &temporal.RetryPolicy{MaximumAttempts: 3}, want: false},
+		{name: "third of three", attempt: 3, policy: &temporal.RetryPolicy{MaximumAttempts: 3}, want: true},
+		{name: "unlimited retries", attempt: 10, policy: &temporal.RetryPolicy{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isFinalAttempt(tt.attempt, tt.policy))
+		})
+	}
+}
+
 // Mock activities for transcript watcher tests
 func InitTranscriptWatcherActivity(ctx context.Context, input types.InitTranscriptWatcherActivityInput) (*types.InitTranscriptWatcherActivityOutput, error) {
 	return &types.InitTranscriptWatcherActivityOutput{
@@ -5,6 +5,8 @@ package workflows
 
 import (
 	"fmt"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
@@ -15,7 +17,7 @@ import (
 
 const (
 	ProcessingStepWorkflowName    = "ProcessingStepWorkflow"
-	ProcessingStepWorkflowVersion = "v2.5.0" // Added cancellation handling helper
+	ProcessingStepWorkflowVersion = "v2.8.0" // Idle agents can be finalized on signal
 )
 
 // propagateCancellation checks if err is a cancellation error and returns a standardized response.
@@ -54,12 +56,92 @@ func handleProcessingStepError(
 	}
 }
 
+// executeAgent runs LocalExecuteActivity until it completes or the step receives a
+// FinalizeAgentSignal, in which case the agent is cancelled and the notice returned
+func executeAgent(
+	ctx workflow.Context,
+	execInput types.LocalExecuteActivityInput,
+	localActivityOptions workflow.ActivityOptions,
+	result *types.LocalExecuteActivityOutput,
+) (*types.AgentIdleNotice, error) {
+	agentCtx, cancelAgent := workflow.WithCancel(ctx)
+	defer cancelAgent()
+	agentOptions := localActivityOptions
+	agentOptions.WaitForCancellation = true
+	agentCtx = workflow.WithActivityOptions(agentCtx, agentOptions)
+
+	future := workflow.ExecuteActivity(agentCtx, "LocalExecuteActivity", execInput)
+	finalizeChan := workflow.GetSignalChannel(ctx, types.FinalizeAgentSignal)
+
+	var notice *types.AgentIdleNotice
+	selector := workflow.NewSelector(ctx)
+	selector.AddFuture(future, func(workflow.Future) {})
+	selector.AddReceive(finalizeChan, func(c workflow.ReceiveChannel, more bool) {
+		var received types.AgentIdleNotice
+		c.Receive(ctx, &received)
+		notice = &received
+		cancelAgent()
+	})
+	selector.Select(ctx)
+
+	return notice, future.Get(ctx, result)
+}
+
+// agentWorkDir returns the container directory the agent runs in: the workspace mount,
+// or a subdirectory of it for runs scoped to part of the repository
+func agentWorkDir(workspaceDir, subdir string) string {
+	if subdir == "" {
+		return workspaceDir
+	}
+	return path.Join(workspaceDir, subdir)
+}
+
+// claudeTranscriptDir returns where Claude writes session files for a working directory;
+// Claude names the project directory after the path with non-alphanumerics replaced by '-'
+func claudeTranscriptDir(workDir string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, workDir)
+	return "/home/noldarim/.claude/projects/" + name
+}
+
+// startDiffStream starts StreamGitDiffActivity on the orchestrator worker for the duration of
+// the agent run and returns the function that stops it. It is a no-op when streaming is
+// disabled. The stream is best-effort: it is never retried and its result is ignored.
+func startDiffStream(ctx workflow.Context, input types.ProcessingStepInput, diffScope []string, agentOptions workflow.ActivityOptions) func() {
+	if input.DiffStreamInterval <= 0 {
+		return func() {}
+	}
+
+	streamCtx, cancel := workflow.WithCancel(ctx)
+	streamCtx = workflow.WithActivityOptions(streamCtx, workflow.ActivityOptions{
+		TaskQueue: input.OrchestratorTaskQueue,
+		// Outlives every attempt of the agent; the stream is cancelled as soon as it finishes
+		StartToCloseTimeout: time.Duration(agentOptions.RetryPolicy.MaximumAttempts+1) * agentOptions.StartToCloseTimeout,
+		HeartbeatTimeout:    input.DiffStreamInterval + 30*time.Second,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 1},
+	})
+	workflow.ExecuteActivity(streamCtx, "StreamGitDiffActivity", types.StreamGitDiffActivityInput{
+		ProjectID:      input.ProjectID,
+		TaskID:         input.RunID,
+		StepID:         input.StepID,
+		RepositoryPath: input.WorktreePath,
+		Paths:          diffScope,
+		Interval:       input.DiffStreamInterval,
+		MaxDiffBytes:   input.DiffStreamMaxBytes,
+	})
+	return cancel
+}
+
 // ProcessingStepWorkflow executes a single processing step within a pipeline:
 // 1. Prepares agent command from config
-// 2. Executes agent (AI processing)
+// 2. Executes agent (AI processing), streaming its diff while it runs
 // 3. Captures git diff
-// 4. Commits changes with step-specific message
-// 5. Retrieves token totals from AI activity records
+// 4. Retrieves token totals from AI activity records
+// 5. Commits changes with the step-specific or templated message
 //
 // Note: AIObservabilityWorkflow is started at the pipeline level (not per-step)
 // to avoid duplicate transcript reading across steps.
@@ -139,14 +221,32 @@ func ProcessingStepWorkflow(ctx workflow.Context, input types.ProcessingStepInpu
 
 	logger.Info("Agent command prepared", "command", commandToExecute)
 
-	// Step 1b: Execute agent
+	var diffScope []string
+	if input.WorkingSubdir != "" {
+		diffScope = []string{input.WorkingSubdir}
+	}
+
+	// Step 1b: Execute agent, streaming its diff to the TUI while it works
 	logger.Info("Executing agent")
 
+	stopDiffStream := startDiffStream(ctx, input, diffScope, localActivityOptions)
+
 	var commandResult types.LocalExecuteActivityOutput
-	err = workflow.ExecuteActivity(localCtx, "LocalExecuteActivity", types.LocalExecuteActivityInput{
+	idleNotice, err := executeAgent(ctx, types.LocalExecuteActivityInput{
 		Command: commandToExecute,
-		WorkDir: input.WorkspaceDir,
-	}).Get(localCtx, &commandResult)
+		WorkDir: agentWorkDir(input.WorkspaceDir, input.WorkingSubdir),
+		Env:     input.AgentConfig.Env,
+	}, localActivityOptions, &commandResult)
+	stopDiffStream()
+
+	// An agent stopped for being idle is finalized with whatever it produced so far
+	finalizedIdle := idleNotice != nil && ctx.Err() == nil && (err == nil || temporal.IsCanceledError(err))
+	if finalizedIdle {
+		logger.Warn("Agent stopped after going idle, finalizing step", "idleFor", idleNotice.IdleFor)
+		output.Warning = fmt.Sprintf("Agent idle for %s; stopped and its changes committed", idleNotice.IdleFor)
+		err = nil
+		commandResult.Success = true
+	}
 
 	if cancelled, cancelErr := propagateCancellation(err, ctx, output, "LocalExecuteActivity"); cancelled {
 		return output, cancelErr
@@ -181,6 +281,7 @@ func ProcessingStepWorkflow(ctx workflow.Context, input types.ProcessingStepInpu
 	var diffResult types.CaptureGitDiffActivityOutput
 	err = workflow.ExecuteActivity(orchestratorCtx, "CaptureGitDiffActivity", types.CaptureGitDiffActivityInput{
 		RepositoryPath: input.WorktreePath,
+		Paths:          diffScope,
 	}).Get(orchestratorCtx, &diffResult)
 
 	if cancelled, cancelErr := propagateCancellation(err, ctx, output, "CaptureGitDiffActivity"); cancelled {
@@ -195,12 +296,14 @@ func ProcessingStepWorkflow(ctx workflow.Context, input types.ProcessingStepInpu
 
 	output.GitDiff = diffResult.Diff
 	output.FilesChanged = len(diffResult.FilesChanged)
+	output.SignificantFilesChanged = len(diffResult.SignificantFilesChanged)
 	output.Insertions = diffResult.Insertions
 	output.Deletions = diffResult.Deletions
 
 	logger.Info("Git diff captured",
 		"hasChanges", diffResult.HasChanges,
 		"filesChanged", output.FilesChanged,
+		"significantFilesChanged", output.SignificantFilesChanged,
 		"insertions", output.Insertions,
 		"deletions", output.Deletions)
 
@@ -254,15 +357,58 @@ func ProcessingStepWorkflow(ctx workflow.Context, input types.ProcessingStepInpu
 		"path", docResult.DocumentPath,
 		"hasSummary", docResult.Summary != nil)
 
-	// Step 2c: Commit changes (on orchestrator worker)
+	// Step 2c: Get token totals from AI activity records (the commit template can reference them)
+	// Note: We don't wait for observability here - the pipeline-level observability
+	// workflow continues running and will be waited on at the end of PipelineWorkflow.
+	// Activity records are stored with task_id = runID (pipeline-level aggregation),
+	// so query by runID to match what was actually written by the observability workflow.
+	var tokenTotals types.GetTokenTotalsActivityOutput
+	err = workflow.ExecuteActivity(orchestratorCtx, "GetTokenTotalsActivity",
+		types.GetTokenTotalsActivityInput{TaskID: input.RunID}).Get(orchestratorCtx, &tokenTotals)
+	if cancelled, cancelErr := propagateCancellation(err, ctx, output, "GetTokenTotalsActivity"); cancelled {
+		return output, cancelErr
+	}
+	if err != nil {
+		logger.Warn("Failed to get token totals", "error", err)
+		// Non-fatal - continue without tokens
+	} else {
+		output.InputTokens = tokenTotals.InputTokens
+		output.OutputTokens = tokenTotals.OutputTokens
+		output.CacheReadTokens = tokenTotals.CacheReadTokens
+		output.CacheCreateTokens = tokenTotals.CacheCreateTokens
+		logger.Info("Token totals retrieved",
+			"inputTokens", output.InputTokens,
+			"outputTokens", output.OutputTokens)
+	}
+
+	// Step 2d: Commit changes (on orchestrator worker)
 	commitMessage := fmt.Sprintf("Step %s: %s", input.StepID, input.StepName)
 	logger.Info("Committing changes", "message", commitMessage)
 
+	// A scoped step commits its subdirectory plus the step documentation written above
+	commitFiles := []string{"."}
+	if input.WorkingSubdir != "" {
+		commitFiles = []string{input.WorkingSubdir, docResult.DocumentPath}
+	}
+
+	taskTitle := input.TaskTitle
+	if taskTitle == "" {
+		taskTitle = input.StepName // Steps run outside a task, such as promote's conflict resolution
+	}
+
 	var commitResult types.GitCommitActivityOutput
 	err = workflow.ExecuteActivity(orchestratorCtx, "GitCommitActivity", types.GitCommitActivityInput{
 		RepositoryPath: input.WorktreePath,
-		FileNames:      []string{"."},
+		FileNames:      commitFiles,
 		CommitMessage:  commitMessage,
+		BranchName:     input.BranchName,
+		CommitTemplate: input.CommitTemplate,
+		TemplateVars: types.CommitTemplateVars{
+			TaskTitle:    taskTitle,
+			TaskID:       input.RunID,
+			FilesChanged: output.FilesChanged,
+			Tokens:       output.InputTokens + output.OutputTokens,
+		},
 	}).Get(orchestratorCtx, &commitResult)
 
 	if cancelled, cancelErr := propagateCancellation(err, ctx, output, "GitCommitActivity"); cancelled {
@@ -292,35 +438,12 @@ func ProcessingStepWorkflow(ctx workflow.Context, input types.ProcessingStepInpu
 	} else {
 		output.CommitSHA = commitResult.CommitSHA
 		output.CommitMessage = commitMessage
+		if commitResult.CommitMessage != "" {
+			output.CommitMessage = commitResult.CommitMessage
+		}
 		logger.Info("Changes committed", "commitSHA", commitResult.CommitSHA)
 	}
 
-	// =========================================================================
-	// Phase 3: Get token totals from AI activity records
-	// =========================================================================
-	// Note: We don't wait for observability here - the pipeline-level observability
-	// workflow continues running and will be waited on at the end of PipelineWorkflow.
-	// Activity records are stored with task_id = runID (pipeline-level aggregation),
-	// so query by runID to match what was actually written by the observability workflow.
-	var tokenTotals types.GetTokenTotalsActivityOutput
-	err = workflow.ExecuteActivity(orchestratorCtx, "GetTokenTotalsActivity",
-		types.GetTokenTotalsActivityInput{TaskID: input.RunID}).Get(orchestratorCtx, &tokenTotals)
-	if cancelled, cancelErr := propagateCancellation(err, ctx, output, "GetTokenTotalsActivity"); cancelled {
-		return output, cancelErr
-	}
-	if err != nil {
-		logger.Warn("Failed to get token totals", "error", err)
-		// Non-fatal - continue without tokens
-	} else {
-		output.InputTokens = tokenTotals.InputTokens
-		output.OutputTokens = tokenTotals.OutputTokens
-		output.CacheReadTokens = tokenTotals.CacheReadTokens
-		output.CacheCreateTokens = tokenTotals.CacheCreateTokens
-		logger.Info("Token totals retrieved",
-			"inputTokens", output.InputTokens,
-			"outputTokens", output.OutputTokens)
-	}
-
 	// Calculate duration
 	output.Duration = workflow.Now(ctx).Sub(startTime)
 	output.Success = true
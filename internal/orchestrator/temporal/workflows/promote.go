@@ -376,6 +376,7 @@ func PromoteWorkflow(ctx workflow.Context, input types.PromoteWorkflowInput) (*t
 					PromptTemplate: resolvePrompt,
 				},
 				WorktreePath:          worktreeResult.WorktreePath,
+				BranchName:            worktreeResult.BranchName,
 				WorkspaceDir:          input.WorkspaceDir,
 				OrchestratorTaskQueue: input.OrchestratorTaskQueue,
 			}
@@ -0,0 +1,107 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const RebaseTaskWorkflowName = "RebaseTaskWorkflow"
+
+// RebaseTaskWorkflow rebases a finished task's branch onto a new base in its worktree.
+//
+//  1. Rebase the branch. Conflicts abort the rebase and leave the branch unchanged.
+//  2. Point the pipeline run's HeadCommitSHA at the rebased head, so a later promotion
+//     ships the rebased commits
+//  3. Answer the RebaseTaskCommand with a TaskRebasedEvent
+//
+// Safe to retry: a rebase left in progress by an earlier attempt is aborted before starting
+// again, and rebasing a branch that already contains the base is a no-op.
+//
+// Workflow ID: rebase-<projectID>-<taskID>
+func RebaseTaskWorkflow(ctx workflow.Context, input types.RebaseTaskWorkflowInput) (*types.RebaseWorktreeActivityOutput, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting RebaseTaskWorkflow",
+		"projectID", input.ProjectID,
+		"taskID", input.TaskID,
+		"branch", input.Branch,
+		"onto", input.Onto)
+
+	orchCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		TaskQueue:           input.OrchestratorTaskQueue,
+		StartToCloseTimeout: 2 * time.Minute,
+		HeartbeatTimeout:    30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    30 * time.Second,
+			MaximumAttempts:    3,
+		},
+	})
+
+	event := types.PublishTaskRebasedEventInput{
+		Metadata:  input.Metadata,
+		ProjectID: input.ProjectID,
+		TaskID:    input.TaskID,
+		Branch:    input.Branch,
+		Onto:      input.Onto,
+	}
+	failRebase := func(errMsg string) (*types.RebaseWorktreeActivityOutput, error) {
+		logger.Error("Task rebase failed: " + errMsg)
+
+		cleanupCtx, _ := workflow.NewDisconnectedContext(ctx)
+		event.Error = errMsg
+		_ = workflow.ExecuteActivity(workflow.WithActivityOptions(cleanupCtx, compensationActivityOptions()),
+			"PublishTaskRebasedEventActivity", event).Get(cleanupCtx, nil)
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	// Phase 1: rebase the branch in its worktree
+	var rebased types.RebaseWorktreeActivityOutput
+	err := workflow.ExecuteActivity(orchCtx, "RebaseWorktreeActivity",
+		types.RebaseWorktreeActivityInput{
+			WorktreePath: input.WorktreePath,
+			Branch:       input.Branch,
+			Onto:         input.Onto,
+		}).Get(ctx, &rebased)
+	if err != nil {
+		return failRebase(err.Error())
+	}
+
+	// Phase 2: the run's head follows the rewritten branch
+	moved := len(rebased.Conflicts) == 0 && rebased.NewHeadSHA != rebased.OldHeadSHA
+	if input.RunID != "" && moved {
+		err := workflow.ExecuteActivity(orchCtx, "SavePipelineRunActivity",
+			types.SavePipelineRunActivityInput{Run: &models.PipelineRun{
+				ID:            input.RunID,
+				HeadCommitSHA: rebased.NewHeadSHA,
+			}}).Get(ctx, nil)
+		if err != nil {
+			return failRebase(fmt.Sprintf("Rebased %s to %s but failed to record the new head: %v", input.Branch, rebased.NewHeadSHA, err))
+		}
+	}
+
+	// Phase 3: answer the command
+	event.OldHeadSHA = rebased.OldHeadSHA
+	event.NewHeadSHA = rebased.NewHeadSHA
+	event.UpToDate = rebased.UpToDate
+	event.Conflicts = rebased.Conflicts
+	if err := workflow.ExecuteActivity(orchCtx, "PublishTaskRebasedEventActivity", event).Get(ctx, nil); err != nil {
+		logger.Error("Failed to publish rebase result", "error", err)
+		// The branch is rebased; the result is still returned
+	}
+
+	logger.Info("RebaseTaskWorkflow completed",
+		"taskID", input.TaskID,
+		"newHeadSHA", rebased.NewHeadSHA,
+		"conflicts", len(rebased.Conflicts))
+	return &rebased, nil
+}
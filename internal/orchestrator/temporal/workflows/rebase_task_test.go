@@ -0,0 +1,145 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package workflows
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func baseRebaseInput() types.RebaseTaskWorkflowInput {
+	return types.RebaseTaskWorkflowInput{
+		Metadata:              protocol.Metadata{IdempotencyKey: "rpc-9"},
+		ProjectID:             "project-1",
+		TaskID:                "run-1",
+		RunID:                 "run-1",
+		WorktreePath:          "/tmp/repo/.worktrees/task-run-1",
+		Branch:                "task-run-1",
+		Onto:                  "main",
+		OrchestratorTaskQueue: "orchestrator-queue",
+	}
+}
+
+func registerRebaseActivities(env *testsuite.TestWorkflowEnvironment) {
+	env.RegisterActivityWithOptions(func(context.Context, types.RebaseWorktreeActivityInput) (*types.RebaseWorktreeActivityOutput, error) {
+		return nil, nil
+	}, activity.RegisterOptions{Name: "RebaseWorktreeActivity"})
+	env.RegisterActivityWithOptions(func(context.Context, types.SavePipelineRunActivityInput) error {
+		return nil
+	}, activity.RegisterOptions{Name: "SavePipelineRunActivity"})
+	env.RegisterActivityWithOptions(func(context.Context, types.PublishTaskRebasedEventInput) error {
+		return nil
+	}, activity.RegisterOptions{Name: "PublishTaskRebasedEventActivity"})
+}
+
+func TestRebaseTaskWorkflow_RecordsNewHead(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	registerRebaseActivities(env)
+
+	input := baseRebaseInput()
+	oldSHA := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	newSHA := "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+
+	env.OnActivity("RebaseWorktreeActivity", mock.Anything, mock.MatchedBy(func(in types.RebaseWorktreeActivityInput) bool {
+		return in.WorktreePath == input.WorktreePath && in.Branch == "task-run-1" && in.Onto == "main" && !in.KeepConflicts
+	})).Return(&types.RebaseWorktreeActivityOutput{OldHeadSHA: oldSHA, NewHeadSHA: newSHA}, nil)
+	env.OnActivity("SavePipelineRunActivity", mock.Anything, mock.MatchedBy(func(in types.SavePipelineRunActivityInput) bool {
+		return in.Run.ID == "run-1" && in.Run.HeadCommitSHA == newSHA
+	})).Return(nil).Once()
+
+	var final types.PublishTaskRebasedEventInput
+	env.OnActivity("PublishTaskRebasedEventActivity", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, in types.PublishTaskRebasedEventInput) error {
+			final = in
+			return nil
+		}).Once()
+
+	env.ExecuteWorkflow(RebaseTaskWorkflow, input)
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+	assert.Equal(t, "rpc-9", final.Metadata.IdempotencyKey)
+	assert.Empty(t, final.Error)
+	assert.Equal(t, oldSHA, final.OldHeadSHA)
+	assert.Equal(t, newSHA, final.NewHeadSHA)
+
+	env.AssertExpectations(t)
+}
+
+func TestRebaseTaskWorkflow_KeepsHeadWhenBranchUnchanged(t *testing.T) {
+	sha := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	tests := []struct {
+		name    string
+		runID   string
+		rebased types.RebaseWorktreeActivityOutput
+	}{
+		{"conflicts", "run-1", types.RebaseWorktreeActivityOutput{OldHeadSHA: sha, NewHeadSHA: sha, Conflicts: []string{"main.go"}, Aborted: true}},
+		{"up to date", "run-1", types.RebaseWorktreeActivityOutput{OldHeadSHA: sha, NewHeadSHA: sha, UpToDate: true}},
+		{"no pipeline run", "", types.RebaseWorktreeActivityOutput{OldHeadSHA: sha, NewHeadSHA: "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testSuite := &testsuite.WorkflowTestSuite{}
+			env := testSuite.NewTestWorkflowEnvironment()
+			registerRebaseActivities(env)
+
+			input := baseRebaseInput()
+			input.RunID = tt.runID
+			rebased := tt.rebased
+			env.OnActivity("RebaseWorktreeActivity", mock.Anything, mock.Anything).Return(&rebased, nil)
+
+			var final types.PublishTaskRebasedEventInput
+			env.OnActivity("PublishTaskRebasedEventActivity", mock.Anything, mock.Anything).Return(
+				func(_ context.Context, in types.PublishTaskRebasedEventInput) error {
+					final = in
+					return nil
+				}).Once()
+
+			env.ExecuteWorkflow(RebaseTaskWorkflow, input)
+
+			assert.True(t, env.IsWorkflowCompleted())
+			assert.NoError(t, env.GetWorkflowError())
+			assert.Equal(t, tt.rebased.Conflicts, final.Conflicts)
+			assert.Equal(t, tt.rebased.UpToDate, final.UpToDate)
+
+			env.AssertExpectations(t)
+			env.AssertNotCalled(t, "SavePipelineRunActivity", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRebaseTaskWorkflow_FailureRepliesWithError(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	registerRebaseActivities(env)
+
+	env.OnActivity("RebaseWorktreeActivity", mock.Anything, mock.Anything).Return(nil, errors.New("worktree locked"))
+
+	var final types.PublishTaskRebasedEventInput
+	env.OnActivity("PublishTaskRebasedEventActivity", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, in types.PublishTaskRebasedEventInput) error {
+			final = in
+			return nil
+		}).Once()
+
+	env.ExecuteWorkflow(RebaseTaskWorkflow, baseRebaseInput())
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.Error(t, env.GetWorkflowError())
+	assert.Contains(t, final.Error, "worktree locked")
+	assert.Equal(t, "rpc-9", final.Metadata.IdempotencyKey)
+
+	env.AssertExpectations(t)
+	env.AssertNotCalled(t, "SavePipelineRunActivity", mock.Anything, mock.Anything)
+}
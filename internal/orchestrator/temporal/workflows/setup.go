@@ -16,13 +16,13 @@ import (
 
 const (
 	SetupWorkflowName    = "SetupWorkflow"
-	SetupWorkflowVersion = "v2.1.0" // Bumped for saga pattern refactoring
+	SetupWorkflowVersion = "v2.2.0" // Bumped for worktree eviction before creation
 )
 
 // SetupWorkflow handles ALL setup for pipeline execution:
 // 1. Resolves fork logic (determine start commit from parent run)
 // 2. Creates PipelineRun record in DB
-// 3. Creates git worktree at resolved commit
+// 3. Evicts finished worktrees over git.max_worktrees, then creates git worktree at resolved commit
 // 4. Creates container with mounted worktree
 // 5. Copies Claude configuration and credentials
 // 6. Updates PipelineRun with infrastructure info
@@ -148,6 +148,8 @@ func SetupWorkflow(ctx workflow.Context, input types.PipelineSetupInput) (*types
 		BranchName:         branchName,
 		TemporalWorkflowID: input.ParentWorkflowID,
 		StartedAt:          &now,
+		Labels:             input.Labels,
+		WorkingSubdir:      input.WorkingSubdir,
 	}
 
 	err := workflow.ExecuteActivity(orchestratorCtx, "SavePipelineRunActivity",
@@ -177,7 +179,20 @@ func SetupWorkflow(ctx workflow.Context, input types.PipelineSetupInput) (*types
 	// Phase 3: Create infrastructure (with saga compensations)
 	// =========================================================================
 
-	// Step 3a: Create git worktree
+	// Step 3a: Make room for the new worktree (git.max_worktrees). Non-fatal: a failed
+	// eviction only means the limit is temporarily exceeded.
+	var evictResult types.EvictWorktreesActivityOutput
+	err = workflow.ExecuteActivity(ctx, "EvictWorktreesActivity", types.EvictWorktreesActivityInput{
+		ProjectID:      input.ProjectID,
+		RepositoryPath: input.RepositoryPath,
+	}).Get(ctx, &evictResult)
+	if err != nil {
+		logger.Warn("Failed to evict worktrees, continuing", "error", err)
+	} else if len(evictResult.EvictedPaths) > 0 {
+		logger.Info("Evicted worktrees", "count", len(evictResult.EvictedPaths))
+	}
+
+	// Step 3b: Create git worktree
 	logger.Info("Creating git worktree", "branchName", branchName, "startCommit", startCommit)
 
 	var worktreeResult types.CreateWorktreeActivityOutput
@@ -201,7 +216,7 @@ func SetupWorkflow(ctx workflow.Context, input types.PipelineSetupInput) (*types
 	output.WorktreePath = worktreeResult.WorktreePath
 	logger.Info("Worktree created", "path", worktreeResult.WorktreePath)
 
-	// Step 3b: Create container with mounted worktree
+	// Step 3c: Create container with mounted worktree
 	logger.Info("Creating container")
 
 	var containerResult types.CreateContainerActivityOutput
@@ -225,7 +240,7 @@ func SetupWorkflow(ctx workflow.Context, input types.PipelineSetupInput) (*types
 	output.ContainerID = containerResult.ContainerID
 	logger.Info("Container created", "containerID", containerResult.ContainerID)
 
-	// Step 3c: Copy Claude configuration
+	// Step 3d: Copy Claude configuration
 	logger.Info("Copying Claude configuration")
 
 	var configResult types.CopyClaudeConfigActivityOutput
@@ -244,7 +259,7 @@ func SetupWorkflow(ctx workflow.Context, input types.PipelineSetupInput) (*types
 
 	logger.Info("Claude config copied", "success", configResult.Success)
 
-	// Step 3d: Copy Claude credentials
+	// Step 3e: Copy Claude credentials
 	logger.Info("Copying Claude credentials")
 
 	var credentialsResult types.CopyClaudeCredentialsActivityOutput
@@ -0,0 +1,82 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package workflows
+
+import (
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/workflow"
+)
+
+// tokenBudget accumulates input+output tokens for a task and detects the moment
+// the budget is first crossed. A zero limit disables tracking.
+type tokenBudget struct {
+	limit    int
+	used     int
+	reported bool
+}
+
+func newTokenBudget(limit, used int, reported bool) *tokenBudget {
+	return &tokenBudget{limit: limit, used: used, reported: reported}
+}
+
+// add counts the tokens of record
+func (b *tokenBudget) add(record *models.AIActivityRecord) {
+	if b == nil || record == nil {
+		return
+	}
+	b.used += record.InputTokens + record.OutputTokens
+}
+
+// crossed reports true exactly once, on the first call after usage exceeds the limit
+func (b *tokenBudget) crossed() bool {
+	if b == nil || b.limit <= 0 || b.reported || b.used <= b.limit {
+		return false
+	}
+	b.reported = true
+	return true
+}
+
+// enforceTokenBudget publishes a BudgetExceededEvent when the budget is first crossed and,
+// for a hard budget, requests cancellation of the parent workflow running the agent
+func enforceTokenBudget(
+	gCtx workflow.Context,
+	orchestratorCtx workflow.Context,
+	input types.AIObservabilityWorkflowInput,
+	budget *tokenBudget,
+	logger log.Logger,
+) {
+	if !budget.crossed() {
+		return
+	}
+
+	logger.Warn("Token budget exceeded",
+		"taskID", input.TaskID,
+		"budget", budget.limit,
+		"tokensUsed", budget.used,
+		"hardBudget", input.HardBudget)
+
+	publishErr := workflow.ExecuteActivity(orchestratorCtx, "PublishBudgetExceededEventActivity", types.PublishBudgetExceededEventInput{
+		ProjectID:  input.ProjectID,
+		TaskID:     input.TaskID,
+		RunID:      input.RunID,
+		Budget:     budget.limit,
+		TokensUsed: budget.used,
+		Cancelled:  input.HardBudget,
+	}).Get(gCtx, nil)
+	if publishErr != nil {
+		logger.Warn("Failed to publish budget exceeded event", "error", publishErr, "taskID", input.TaskID)
+	}
+
+	if !input.HardBudget || input.ProcessTaskWorkflowID == "" {
+		return
+	}
+	if err := workflow.RequestCancelExternalWorkflow(gCtx, input.ProcessTaskWorkflowID, "").Get(gCtx, nil); err != nil {
+		logger.Error("Failed to cancel workflow over token budget",
+			"error", err,
+			"workflowID", input.ProcessTaskWorkflowID)
+	}
+}
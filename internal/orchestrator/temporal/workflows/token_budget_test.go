@@ -0,0 +1,120 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package workflows
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	aiobsTypes "github.com/noldarim/noldarim/internal/aiobs/types"
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
+)
+
+func PublishBudgetExceededEventActivity(ctx context.Context, input types.PublishBudgetExceededEventInput) error {
+	return nil
+}
+
+func TestTokenBudget_CrossedOnce(t *testing.T) {
+	budget := newTokenBudget(100, 0, false)
+
+	budget.add(&models.AIActivityRecord{InputTokens: 60, OutputTokens: 40})
+	assert.False(t, budget.crossed(), "reaching the budget exactly is not exceeding it")
+
+	budget.add(&models.AIActivityRecord{OutputTokens: 1})
+	assert.True(t, budget.crossed())
+	assert.Equal(t, 101, budget.used)
+
+	budget.add(&models.AIActivityRecord{OutputTokens: 50})
+	assert.False(t, budget.crossed(), "exceeding is reported only once")
+}
+
+func TestTokenBudget_DisabledAndCarriedOver(t *testing.T) {
+	unlimited := newTokenBudget(0, 0, false)
+	unlimited.add(&models.AIActivityRecord{InputTokens: 1_000_000})
+	assert.False(t, unlimited.crossed())
+
+	// A run continued-as-new after reporting must not report again
+	continued := newTokenBudget(10, 50, true)
+	continued.add(&models.AIActivityRecord{InputTokens: 5})
+	assert.False(t, continued.crossed())
+	assert.Equal(t, 55, continued.used)
+
+	var nilBudget *tokenBudget
+	nilBudget.add(&models.AIActivityRecord{InputTokens: 5})
+	assert.False(t, nilBudget.crossed())
+}
+
+func TestAIObservabilityWorkflow_HardBudgetCancelsParent(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	registerAIObsActivities(env)
+	env.RegisterActivity(PublishBudgetExceededEventActivity)
+
+	input := types.AIObservabilityWorkflowInput{
+		TaskID:                "task-budget",
+		RunID:                 "run-budget",
+		ProjectID:             "project-budget",
+		ProcessTaskWorkflowID: "run-budget-pipeline",
+		OrchestratorTaskQueue: "noldarim-task-queue",
+		RuntimeName:           "claude",
+		TokenBudget:           1000,
+		HardBudget:            true,
+	}
+
+	env.OnActivity("WatchTranscriptActivity", mock.Anything, mock.Anything).Return(
+		&types.WatchTranscriptActivityOutput{Success: true}, nil,
+	).After(100 * time.Millisecond)
+	env.OnActivity("SaveCompleteEventActivity", mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity("PublishAIActivityEventActivity", mock.Anything, mock.Anything).Return(nil)
+
+	var published []types.PublishBudgetExceededEventInput
+	env.OnActivity("PublishBudgetExceededEventActivity", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		published = append(published, args.Get(1).(types.PublishBudgetExceededEventInput))
+	}).Return(nil)
+
+	var cancelledID string
+	env.OnRequestCancelExternalWorkflow(mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		cancelledID = args.String(1)
+	}).Return(nil)
+
+	event := func(id string, in, out int) aiobsTypes.ParsedEvent {
+		e := aiobsTypes.ParsedEvent{
+			EventID:   id,
+			EventType: aiobsTypes.EventTypeAIOutput,
+			Kind:      aiobsTypes.KindMessage,
+			Timestamp: time.Now(),
+		}
+		e.InputTokens = in
+		e.OutputTokens = out
+		return e
+	}
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(types.ParsedTranscriptBatchSignal, types.ParsedTranscriptBatch{
+			Events: []types.ParsedTranscriptEvent{
+				{TaskID: "task-budget", RunID: "run-budget", ParsedEvents: []aiobsTypes.ParsedEvent{event("evt-1", 600, 300)}},
+				{TaskID: "task-budget", RunID: "run-budget", ParsedEvents: []aiobsTypes.ParsedEvent{event("evt-2", 200, 100)}},
+				{TaskID: "task-budget", RunID: "run-budget", ParsedEvents: []aiobsTypes.ParsedEvent{event("evt-3", 500, 0)}},
+			},
+		})
+	}, 10*time.Millisecond)
+
+	env.ExecuteWorkflow(AIObservabilityWorkflow, input)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	require.Len(t, published, 1, "budget exceeded is published once")
+	assert.Equal(t, 1000, published[0].Budget)
+	assert.Equal(t, 1200, published[0].TokensUsed)
+	assert.True(t, published[0].Cancelled)
+	assert.Equal(t, "run-budget-pipeline", cancelledID)
+}
@@ -12,4 +12,53 @@ type AgentConfigInput struct {
 	Variables      map[string]string      `json:"variables"`        // Values to substitute in template
 	ToolOptions    map[string]interface{} `json:"tool_options,omitempty"` // Tool-specific options
 	FlagFormat     string                 `json:"flag_format,omitempty"`  // "space" or "equals" for CLI flags
+	Env            map[string]string      `json:"env,omitempty"`          // Extra environment for the agent process (e.g. provider API keys)
+}
+
+// MergeAgentConfig returns defaults overridden field by field by override. Non-empty
+// strings in override win, and Variables, ToolOptions and Env are merged key by key with
+// override keys winning. Either argument may be nil; the result never aliases their maps.
+func MergeAgentConfig(defaults, override *AgentConfigInput) *AgentConfigInput {
+	if defaults == nil && override == nil {
+		return nil
+	}
+	if defaults == nil {
+		defaults = &AgentConfigInput{}
+	}
+	if override == nil {
+		override = &AgentConfigInput{}
+	}
+
+	return &AgentConfigInput{
+		ToolName:       firstNonEmpty(override.ToolName, defaults.ToolName),
+		ToolVersion:    firstNonEmpty(override.ToolVersion, defaults.ToolVersion),
+		PromptTemplate: firstNonEmpty(override.PromptTemplate, defaults.PromptTemplate),
+		Variables:      mergeMaps(defaults.Variables, override.Variables),
+		ToolOptions:    mergeMaps(defaults.ToolOptions, override.ToolOptions),
+		FlagFormat:     firstNonEmpty(override.FlagFormat, defaults.FlagFormat),
+		Env:            mergeMaps(defaults.Env, override.Env),
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func mergeMaps[V any](base, override map[string]V) map[string]V {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]V, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
 }
@@ -0,0 +1,101 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package protocol
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RedactedValue replaces secret-looking env values in logs and previews
+const RedactedValue = "[REDACTED]"
+
+// AgentDefaultEnvVar carries agent.default_env, JSON-encoded, into agent containers. The
+// values reach the agent process from there and never pass through workflow inputs.
+const AgentDefaultEnvVar = "NOLDARIM_AGENT_DEFAULT_ENV"
+
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reservedEnvNames are set by the agent's environment itself; overriding them would let
+// a task redirect which binaries or libraries the agent runs
+var reservedEnvNames = map[string]bool{
+	"PATH":  true,
+	"HOME":  true,
+	"USER":  true,
+	"SHELL": true,
+	"PWD":   true,
+}
+
+// reservedEnvPrefixes cover loader variables and the orchestrator's own settings
+var reservedEnvPrefixes = []string{"LD_", "DYLD_", "TEMPORAL_", "NOLDARIM_"}
+
+// secretNameMarkers mark env names whose values are treated as secrets
+var secretNameMarkers = []string{"KEY", "TOKEN", "SECRET", "PASSWORD", "PASSWD", "CREDENTIAL", "AUTH", "PRIVATE"}
+
+// secretValuePrefixes mark values that look like provider credentials whatever the name
+var secretValuePrefixes = []string{"sk-", "ghp_", "gho_", "github_pat_", "xoxb-", "xoxp-", "AKIA"}
+
+// ValidateAgentEnv checks that every name is a valid env var name and not reserved
+func ValidateAgentEnv(env map[string]string) error {
+	for _, name := range SortedEnvNames(env) {
+		if !envNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid env var name %q", name)
+		}
+		upper := strings.ToUpper(name)
+		if reservedEnvNames[upper] {
+			return fmt.Errorf("env var %q is reserved and cannot be set for an agent", name)
+		}
+		for _, prefix := range reservedEnvPrefixes {
+			if strings.HasPrefix(upper, prefix) {
+				return fmt.Errorf("env var %q is reserved (prefix %s) and cannot be set for an agent", name, prefix)
+			}
+		}
+	}
+	return nil
+}
+
+// RedactEnv returns a copy of env with secret-looking values replaced by RedactedValue.
+// A value is secret-looking when its name contains a marker such as KEY or TOKEN, or
+// when it starts like a known credential format.
+func RedactEnv(env map[string]string) map[string]string {
+	if len(env) == 0 {
+		return nil
+	}
+	redacted := make(map[string]string, len(env))
+	for name, value := range env {
+		if IsSecretEnv(name, value) {
+			value = RedactedValue
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+// SortedEnvNames returns the names in env in sorted order
+func SortedEnvNames(env map[string]string) []string {
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsSecretEnv reports whether an env var looks like it holds a secret (see RedactEnv)
+func IsSecretEnv(name, value string) bool {
+	upper := strings.ToUpper(name)
+	for _, marker := range secretNameMarkers {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	for _, prefix := range secretValuePrefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
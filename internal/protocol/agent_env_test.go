@@ -0,0 +1,59 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAgentEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{name: "nil", env: nil},
+		{name: "valid names", env: map[string]string{"ANTHROPIC_API_KEY": "x", "_debug": "1", "Mode2": "ci"}},
+		{name: "empty name", env: map[string]string{"": "x"}, wantErr: "invalid env var name"},
+		{name: "leading digit", env: map[string]string{"1MODE": "x"}, wantErr: "invalid env var name"},
+		{name: "contains equals", env: map[string]string{"A=B": "x"}, wantErr: "invalid env var name"},
+		{name: "PATH is reserved", env: map[string]string{"PATH": "/tmp/evil"}, wantErr: "reserved"},
+		{name: "lowercase PATH is reserved", env: map[string]string{"path": "/tmp/evil"}, wantErr: "reserved"},
+		{name: "loader prefix is reserved", env: map[string]string{"LD_PRELOAD": "/tmp/evil.so"}, wantErr: "reserved"},
+		{name: "orchestrator prefix is reserved", env: map[string]string{"TEMPORAL_HOST_PORT": "x"}, wantErr: "reserved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAgentEnv(tt.env)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRedactEnv(t *testing.T) {
+	env := map[string]string{
+		"ANTHROPIC_API_KEY": "sk-ant-123",
+		"GITHUB_TOKEN":      "plain",
+		"DB_PASSWORD":       "hunter2",
+		"UPSTREAM":          "ghp_abcdef", // Credential format under an innocent name
+		"AGENT_MODE":        "ci",
+	}
+
+	assert.Equal(t, map[string]string{
+		"ANTHROPIC_API_KEY": RedactedValue,
+		"GITHUB_TOKEN":      RedactedValue,
+		"DB_PASSWORD":       RedactedValue,
+		"UPSTREAM":          RedactedValue,
+		"AGENT_MODE":        "ci",
+	}, RedactEnv(env))
+	assert.Equal(t, "sk-ant-123", env["ANTHROPIC_API_KEY"], "input must not be modified")
+	assert.Nil(t, RedactEnv(nil))
+}
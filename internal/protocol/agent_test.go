@@ -0,0 +1,86 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeAgentConfig(t *testing.T) {
+	projectDefault := &AgentConfigInput{
+		ToolName:       "claude",
+		ToolVersion:    "4.5",
+		PromptTemplate: "Do {{.title}}",
+		Variables:      map[string]string{"title": "", "style": "terse"},
+		ToolOptions:    map[string]interface{}{"model": "claude-sonnet-4-5", "max-turns": 10},
+		FlagFormat:     "space",
+	}
+
+	tests := []struct {
+		name     string
+		defaults *AgentConfigInput
+		override *AgentConfigInput
+		want     *AgentConfigInput
+	}{
+		{
+			name: "both nil",
+			want: nil,
+		},
+		{
+			name:     "no override uses defaults",
+			defaults: projectDefault,
+			want:     projectDefault,
+		},
+		{
+			name:     "no defaults uses override",
+			override: &AgentConfigInput{ToolName: "test", PromptTemplate: "echo"},
+			want:     &AgentConfigInput{ToolName: "test", PromptTemplate: "echo"},
+		},
+		{
+			name:     "override wins field by field",
+			defaults: projectDefault,
+			override: &AgentConfigInput{
+				PromptTemplate: "Fix {{.title}}",
+				Variables:      map[string]string{"title": "bug"},
+				ToolOptions:    map[string]interface{}{"model": "claude-opus-4"},
+			},
+			want: &AgentConfigInput{
+				ToolName:       "claude",
+				ToolVersion:    "4.5",
+				PromptTemplate: "Fix {{.title}}",
+				Variables:      map[string]string{"title": "bug", "style": "terse"},
+				ToolOptions:    map[string]interface{}{"model": "claude-opus-4", "max-turns": 10},
+				FlagFormat:     "space",
+			},
+		},
+		{
+			name:     "empty override strings keep defaults",
+			defaults: &AgentConfigInput{ToolName: "claude", FlagFormat: "equals"},
+			override: &AgentConfigInput{ToolName: "", FlagFormat: ""},
+			want:     &AgentConfigInput{ToolName: "claude", FlagFormat: "equals"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MergeAgentConfig(tt.defaults, tt.override))
+		})
+	}
+}
+
+func TestMergeAgentConfig_DoesNotAliasInputs(t *testing.T) {
+	defaults := &AgentConfigInput{Variables: map[string]string{"a": "1"}}
+	override := &AgentConfigInput{ToolOptions: map[string]interface{}{"x": true}, Env: map[string]string{"MODE": "ci"}}
+
+	merged := MergeAgentConfig(defaults, override)
+	merged.Variables["a"] = "changed"
+	merged.ToolOptions["y"] = false
+	merged.Env["MODE"] = "changed"
+
+	assert.Equal(t, "1", defaults.Variables["a"])
+	assert.NotContains(t, override.ToolOptions, "y")
+	assert.Equal(t, "ci", override.Env["MODE"])
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package protocol
+
+import "reflect"
+
+// CloneEvent returns a deep copy of event: pointers, slices and maps reachable through
+// exported fields are copied, so the copy shares no mutable state with the original.
+// Unexported fields (time.Time internals and the like) are copied by value.
+func CloneEvent(event Event) Event {
+	if event == nil {
+		return nil
+	}
+	c := cloner{seen: make(map[pointerKey]reflect.Value)}
+	return c.clone(reflect.ValueOf(event)).Interface().(Event)
+}
+
+// pointerKey identifies a pointer already copied, so shared and cyclic references are
+// preserved in the copy
+type pointerKey struct {
+	typ  reflect.Type
+	addr uintptr
+}
+
+type cloner struct {
+	seen map[pointerKey]reflect.Value
+}
+
+func (c *cloner) clone(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := pointerKey{typ: v.Type(), addr: v.Pointer()}
+		if copied, ok := c.seen[key]; ok {
+			return copied
+		}
+		out := reflect.New(v.Type().Elem())
+		c.seen[key] = out
+		out.Elem().Set(c.clone(v.Elem()))
+		return out
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(c.clone(v.Elem()))
+		return out
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return out
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), c.clone(iter.Value()))
+		}
+		return out
+
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return out
+
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := range v.NumField() {
+			if field := out.Field(i); field.CanSet() {
+				field.Set(c.clone(v.Field(i)))
+			}
+		}
+		return out
+
+	default:
+		return v
+	}
+}
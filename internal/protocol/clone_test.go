@@ -0,0 +1,47 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+)
+
+func TestCloneEvent_SharesNoMutableState(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	task := &models.Task{ID: "task-1", Title: "Original", Labels: []string{"bug"}, CreatedAt: created}
+	original := TasksLoadedEvent{
+		Metadata: Metadata{IdempotencyKey: "key"},
+		Tasks:    map[string]*models.Task{"task-1": task, "alias": task},
+		Labels:   []string{"bug"},
+	}
+
+	cloned, ok := CloneEvent(original).(TasksLoadedEvent)
+	require.True(t, ok)
+	assert.Equal(t, original, cloned)
+
+	clonedTask := cloned.Tasks["task-1"]
+	assert.NotSame(t, task, clonedTask)
+	assert.Same(t, clonedTask, cloned.Tasks["alias"], "shared pointers stay shared in the copy")
+
+	clonedTask.Title = "Changed"
+	clonedTask.Labels[0] = "feature"
+	cloned.Labels[0] = "feature"
+	delete(cloned.Tasks, "alias")
+
+	assert.Equal(t, "Original", task.Title)
+	assert.Equal(t, []string{"bug"}, task.Labels)
+	assert.Equal(t, []string{"bug"}, original.Labels)
+	assert.Len(t, original.Tasks, 2)
+	assert.True(t, created.Equal(clonedTask.CreatedAt))
+}
+
+func TestCloneEvent_Nil(t *testing.T) {
+	assert.Nil(t, CloneEvent(nil))
+}
@@ -31,6 +31,7 @@ func (c LoadProjectsCommand) GetBaseMessage() Metadata {
 type LoadTasksCommand struct {
 	Metadata
 	ProjectID string
+	Labels    []string // Only load tasks carrying ALL of these labels (empty = every task)
 }
 
 func (c LoadTasksCommand) GetBaseMessage() Metadata {
@@ -59,6 +60,27 @@ func (c LoadAIActivityCommand) GetBaseMessage() Metadata {
 	return c.Metadata
 }
 
+// ListRunningTasksCommand requests the tasks whose workflows are still running,
+// so a restarted client can reattach to them
+type ListRunningTasksCommand struct {
+	Metadata
+}
+
+func (c ListRunningTasksCommand) GetBaseMessage() Metadata {
+	return c.Metadata
+}
+
+// ResolveWorktreeCommand requests the worktree path of a task, e.g. to open its files in an editor
+type ResolveWorktreeCommand struct {
+	Metadata
+	ProjectID string
+	TaskID    string
+}
+
+func (c ResolveWorktreeCommand) GetBaseMessage() Metadata {
+	return c.Metadata
+}
+
 // ReadWrite commands
 
 // ToggleTaskCommand toggles a task's completion status
@@ -91,6 +113,9 @@ type CreateTaskCommand struct {
 	Description   string
 	BaseCommitSHA string            // Commit SHA to create worktree from (for content-based task ID)
 	AgentConfig   *AgentConfigInput // Structured agent configuration for task processing
+	TokenBudget   int               // Optional input+output token budget (0 = use the configured default)
+	WorkingSubdir string            // Repository-relative directory the agent works in and diffs are scoped to (empty = repo root)
+	Labels        []string          // Initial labels of the task
 }
 
 func (c CreateTaskCommand) GetBaseMessage() Metadata {
@@ -100,22 +125,38 @@ func (c CreateTaskCommand) GetBaseMessage() Metadata {
 // UpdateTaskCommand updates task details
 type UpdateTaskCommand struct {
 	Metadata
-	ProjectID   string
-	TaskID      string
-	Title       string
-	Description string
+	ProjectID       string
+	TaskID          string
+	Title           string
+	Description     string
+	ExpectedVersion int // Version of the task the edit is based on; a stale version is rejected
 }
 
 func (c UpdateTaskCommand) GetBaseMessage() Metadata {
 	return c.Metadata
 }
 
+// UpdateTaskLabelsCommand adds and removes task labels. Both are idempotent; removals
+// are applied first.
+type UpdateTaskLabelsCommand struct {
+	Metadata
+	ProjectID string
+	TaskID    string
+	Add       []string
+	Remove    []string
+}
+
+func (c UpdateTaskLabelsCommand) GetBaseMessage() Metadata {
+	return c.Metadata
+}
+
 // CreateProjectCommand creates a new project
 type CreateProjectCommand struct {
 	Metadata
-	Name           string
-	Description    string
-	RepositoryPath string
+	Name               string
+	Description        string
+	RepositoryPath     string
+	DefaultAgentConfig *AgentConfigInput // Optional default applied to tasks that omit their own config
 }
 
 func (c CreateProjectCommand) GetBaseMessage() Metadata {
@@ -130,9 +171,11 @@ type StartPipelineCommand struct {
 	Steps         []StepInput // Step definitions (single step for simple runs)
 	BaseCommitSHA string      // Optional: commit to start from (defaults to HEAD)
 	// Fork options for smart step reuse
-	ForkFromRunID   string // Explicitly fork from a previous run ID
-	ForkAfterStepID string // Fork after this step ID (reuse steps up to and including this one)
-	NoAutoFork      bool   // Disable automatic fork detection
+	ForkFromRunID   string   // Explicitly fork from a previous run ID
+	ForkAfterStepID string   // Fork after this step ID (reuse steps up to and including this one)
+	NoAutoFork      bool     // Disable automatic fork detection
+	Labels          []string // Initial labels of the run
+	WorkingSubdir   string   // Repository-relative directory the agents work in and diffs are scoped to (empty = repo root)
 }
 
 func (c StartPipelineCommand) GetBaseMessage() Metadata {
@@ -166,3 +209,54 @@ type CancelPipelineCommand struct {
 func (c CancelPipelineCommand) GetBaseMessage() Metadata {
 	return c.Metadata
 }
+
+// CancelQueuedTaskCommand removes a task waiting for a free slot from the queue without starting it
+type CancelQueuedTaskCommand struct {
+	Metadata
+	ProjectID string
+	QueueID   string // From the TaskQueuedEvent
+}
+
+func (c CancelQueuedTaskCommand) GetBaseMessage() Metadata {
+	return c.Metadata
+}
+
+// RebaseTaskCommand rebases a task's branch, in its worktree, onto a new base. Onto defaults
+// to the branch checked out in the project repository. Conflicts abort the rebase and are
+// reported in the TaskRebasedEvent.
+type RebaseTaskCommand struct {
+	Metadata
+	ProjectID string
+	TaskID    string
+	Onto      string
+}
+
+func (c RebaseTaskCommand) GetBaseMessage() Metadata {
+	return c.Metadata
+}
+
+// AssembleStrategy selects how AssembleBranchCommand combines task branches
+type AssembleStrategy string
+
+const (
+	AssembleStrategyMerge      AssembleStrategy = "merge"       // A merge commit per task branch
+	AssembleStrategyCherryPick AssembleStrategy = "cherry-pick" // The task branches' commits, replayed in order
+)
+
+// AssembleBranchCommand combines several task branches into one integration branch.
+// TargetBranch is created from BaseBranch (the branch checked out in the project repository
+// by default) and each source task's branch is merged or cherry-picked into it in order.
+// A source that conflicts is skipped and reported; the others still go in. Progress arrives
+// as BranchAssemblyProgressEvents and the outcome as a BranchAssembledEvent.
+type AssembleBranchCommand struct {
+	Metadata
+	ProjectID     string
+	SourceTaskIDs []string
+	TargetBranch  string
+	BaseBranch    string
+	Strategy      AssembleStrategy // Defaults to AssembleStrategyMerge
+}
+
+func (c AssembleBranchCommand) GetBaseMessage() Metadata {
+	return c.Metadata
+}
@@ -22,3 +22,14 @@ func (e PipelineRunsLoadedEvent) GetProjectID() string    { return e.ProjectID }
 func (e ErrorEvent) GetTaskID() string                    { return e.TaskID }
 func (e PipelineLifecycleEvent) GetProjectID() string     { return e.ProjectID }
 func (e PipelineLifecycleEvent) GetRunID() string         { return e.RunID }
+func (e WorktreeEvictedEvent) GetProjectID() string       { return e.ProjectID }
+func (e TaskCleanedUpEvent) GetProjectID() string         { return e.ProjectID }
+func (e TaskCleanedUpEvent) GetTaskID() string            { return e.TaskID }
+func (e DiffUpdatedEvent) GetProjectID() string           { return e.ProjectID }
+func (e DiffUpdatedEvent) GetTaskID() string              { return e.TaskID }
+func (e AgentIdleEvent) GetProjectID() string             { return e.ProjectID }
+func (e AgentIdleEvent) GetTaskID() string                { return e.TaskID }
+func (e TaskRebasedEvent) GetProjectID() string           { return e.ProjectID }
+func (e TaskRebasedEvent) GetTaskID() string              { return e.TaskID }
+func (e BranchAssemblyProgressEvent) GetProjectID() string { return e.ProjectID }
+func (e BranchAssembledEvent) GetProjectID() string        { return e.ProjectID }
@@ -10,6 +10,8 @@
 package protocol
 
 import (
+	"time"
+
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 )
 
@@ -47,6 +49,7 @@ type TasksLoadedEvent struct {
 	ProjectName    string
 	RepositoryPath string
 	Tasks          map[string]*models.Task
+	Labels         []string // Label filter the tasks were loaded with, if any
 }
 
 func (e TasksLoadedEvent) GetMetadata() Metadata {
@@ -116,6 +119,29 @@ func (e CriticalErrorEvent) GetMetadata() Metadata {
 	return e.Metadata
 }
 
+// NotificationLevel is the severity of a NotificationEvent
+type NotificationLevel string
+
+// Notification level constants
+const (
+	NotificationInfo    NotificationLevel = "info"
+	NotificationSuccess NotificationLevel = "success"
+	NotificationWarning NotificationLevel = "warning"
+	NotificationError   NotificationLevel = "error"
+)
+
+// NotificationEvent carries a transient, user-facing message (shown as a toast in the TUI).
+// Use it for feedback that is not an error, instead of repurposing ErrorEvent.
+type NotificationEvent struct {
+	Metadata
+	Level   NotificationLevel
+	Message string
+}
+
+func (e NotificationEvent) GetMetadata() Metadata {
+	return e.Metadata
+}
+
 // ProjectCreatedEvent is sent when a project has been created
 type ProjectCreatedEvent struct {
 	Metadata
@@ -166,6 +192,37 @@ func (e AIStreamEndEvent) GetMetadata() Metadata {
 	return e.Metadata
 }
 
+// BudgetExceededEvent is sent once when a running task's cumulative tokens cross its budget
+type BudgetExceededEvent struct {
+	Metadata
+	TaskID     string
+	ProjectID  string
+	RunID      string
+	Budget     int  // Configured input+output token budget
+	TokensUsed int  // Cumulative input+output tokens when the budget was crossed
+	Cancelled  bool // True if the task is being cancelled (hard budget)
+}
+
+func (e BudgetExceededEvent) GetMetadata() Metadata {
+	return e.Metadata
+}
+
+// AgentIdleEvent is sent when a running task's agent has written no transcript lines for
+// agent.idle_timeout. It repeats every further timeout while the agent stays idle.
+type AgentIdleEvent struct {
+	Metadata
+	TaskID     string
+	ProjectID  string
+	RunID      string
+	StepID     string
+	IdleFor    time.Duration
+	Finalizing bool // True if the agent is being stopped and its work committed (agent.finalize_on_idle)
+}
+
+func (e AgentIdleEvent) GetMetadata() Metadata {
+	return e.Metadata
+}
+
 // PipelineRunStartedEvent is sent when a pipeline workflow starts.
 // If AlreadyExists is true, the workflow was already running or completed.
 type PipelineRunStartedEvent struct {
@@ -210,3 +267,206 @@ type PipelineCancelledEvent struct {
 func (e PipelineCancelledEvent) GetMetadata() Metadata {
 	return e.Metadata
 }
+
+// RunningTaskInfo describes a task whose workflow is still executing
+type RunningTaskInfo struct {
+	TaskID     string // Task ID, or pipeline run ID for tasks started as pipelines
+	ProjectID  string // Empty if the task could not be found in the database
+	WorkflowID string
+	Step       string // Current step; empty when unknown
+	StartedAt  time.Time
+}
+
+// RunningTasksEvent answers ListRunningTasksCommand. Unavailable is set (with Error)
+// when Temporal could not be reached; Tasks is then empty rather than authoritative.
+type RunningTasksEvent struct {
+	Metadata
+	Tasks       []RunningTaskInfo // Oldest first
+	Unavailable bool
+	Error       string
+}
+
+func (e RunningTasksEvent) GetMetadata() Metadata {
+	return e.Metadata
+}
+
+// WorktreeResolvedEvent answers ResolveWorktreeCommand. Exists is false when the task's
+// worktree is not (or no longer) on disk.
+type WorktreeResolvedEvent struct {
+	Metadata
+	ProjectID    string
+	TaskID       string
+	WorktreePath string
+	Exists       bool
+}
+
+func (e WorktreeResolvedEvent) GetMetadata() Metadata {
+	return e.Metadata
+}
+
+// TaskRebasedEvent answers RebaseTaskCommand. When Conflicts is non-empty the rebase was
+// aborted and the branch is unchanged.
+type TaskRebasedEvent struct {
+	Metadata
+	ProjectID  string
+	TaskID     string
+	Branch     string
+	Onto       string
+	OldHeadSHA string
+	NewHeadSHA string
+	UpToDate   bool
+	Conflicts  []string
+}
+
+func (e TaskRebasedEvent) GetMetadata() Metadata {
+	return e.Metadata
+}
+
+// AssembleSourceStatus is the outcome of integrating one source of an AssembleBranchCommand
+type AssembleSourceStatus string
+
+const (
+	AssembleSourceMerged     AssembleSourceStatus = "merged"     // Merged or cherry-picked cleanly
+	AssembleSourceUpToDate   AssembleSourceStatus = "up-to-date" // Already contained in the target
+	AssembleSourceConflicted AssembleSourceStatus = "conflicted" // Skipped; Conflicts lists the files
+)
+
+// AssembleSourceResult reports how one source task branch went into the target branch
+type AssembleSourceResult struct {
+	TaskID    string
+	Branch    string
+	Status    AssembleSourceStatus
+	HeadSHA   string   // Target head after this source
+	Conflicts []string // Conflicting files when Status is AssembleSourceConflicted
+}
+
+// BranchAssemblyProgressEvent is sent as each source of an AssembleBranchCommand is
+// integrated. Index counts from 0 up to Total-1.
+type BranchAssemblyProgressEvent struct {
+	Metadata
+	ProjectID    string
+	TargetBranch string
+	Index        int
+	Total        int
+	Source       AssembleSourceResult
+}
+
+func (e BranchAssemblyProgressEvent) GetMetadata() Metadata {
+	return e.Metadata
+}
+
+// BranchAssembledEvent answers AssembleBranchCommand once the target branch exists.
+// Sources are listed in command order.
+type BranchAssembledEvent struct {
+	Metadata
+	ProjectID    string
+	TargetBranch string
+	BaseBranch   string
+	Strategy     AssembleStrategy
+	HeadSHA      string
+	Sources      []AssembleSourceResult
+}
+
+func (e BranchAssembledEvent) GetMetadata() Metadata {
+	return e.Metadata
+}
+
+// Merged returns the task IDs of the sources that went in cleanly or were already in
+func (e BranchAssembledEvent) Merged() []string {
+	var ids []string
+	for _, source := range e.Sources {
+		if source.Status != AssembleSourceConflicted {
+			ids = append(ids, source.TaskID)
+		}
+	}
+	return ids
+}
+
+// Conflicted returns the task IDs of the sources that were skipped because of conflicts
+func (e BranchAssembledEvent) Conflicted() []string {
+	var ids []string
+	for _, source := range e.Sources {
+		if source.Status == AssembleSourceConflicted {
+			ids = append(ids, source.TaskID)
+		}
+	}
+	return ids
+}
+
+// TaskQueuedEvent is sent when a CreateTaskCommand has to wait because the project is at its
+// concurrent task limit, and again whenever the task moves up the queue.
+type TaskQueuedEvent struct {
+	Metadata
+	ProjectID string
+	QueueID   string // Identifies the queued task until it starts; use it to cancel
+	Title     string
+	Position  int // 1-based position in the project's queue
+}
+
+func (e TaskQueuedEvent) GetMetadata() Metadata {
+	return e.Metadata
+}
+
+// TaskDequeuedEvent is sent when a queued task leaves the queue, either because a slot
+// freed up and it was started or because it was cancelled.
+type TaskDequeuedEvent struct {
+	Metadata
+	ProjectID string
+	QueueID   string
+	Cancelled bool // False when the task was started
+}
+
+func (e TaskDequeuedEvent) GetMetadata() Metadata {
+	return e.Metadata
+}
+
+// WorktreeEvictedEvent is sent when a finished task's worktree is removed to stay under git.max_worktrees
+type WorktreeEvictedEvent struct {
+	Metadata
+	ProjectID      string
+	RepositoryPath string
+	TaskID         string // Owner of the evicted worktree (pipeline run ID for pipeline worktrees)
+	WorktreePath   string
+	Branch         string // Branch is kept after eviction
+}
+
+func (e WorktreeEvictedEvent) GetMetadata() Metadata {
+	return e.Metadata
+}
+
+// TaskCleanedUpEvent summarizes what git.cleanup_on_delete removed after a task was deleted.
+// The Skipped fields give the reason a worktree or branch was kept.
+type TaskCleanedUpEvent struct {
+	Metadata
+	ProjectID       string
+	TaskID          string
+	WorktreePath    string
+	WorktreeRemoved bool
+	WorktreeSkipped string
+	Branch          string
+	BranchDeleted   bool
+	BranchSkipped   string
+}
+
+func (e TaskCleanedUpEvent) GetMetadata() Metadata {
+	return e.Metadata
+}
+
+// DiffUpdatedEvent carries the working tree diff of a running task each time it changes.
+// Diff is empty with DiffOmitted set when it exceeds git.diff_stream_max_bytes.
+type DiffUpdatedEvent struct {
+	Metadata
+	ProjectID    string
+	TaskID       string // Pipeline run ID for pipeline tasks
+	StepID       string
+	DiffStat     string
+	Diff         string
+	DiffOmitted  bool
+	FilesChanged int
+	Insertions   int
+	Deletions    int
+}
+
+func (e DiffUpdatedEvent) GetMetadata() Metadata {
+	return e.Metadata
+}
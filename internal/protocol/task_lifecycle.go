@@ -37,6 +37,14 @@ type TaskLifecycleEvent struct {
 	Task *models.Task
 	// NewStatus is populated for TaskStatusUpdated events
 	NewStatus models.TaskStatus
+	// ResumedFrom is set on TaskInProgress events when processing resumed after this step
+	// of a failed attempt instead of starting fresh
+	ResumedFrom string
+}
+
+// Resumed reports whether a TaskInProgress event is a resume rather than a fresh start
+func (e TaskLifecycleEvent) Resumed() bool {
+	return e.ResumedFrom != ""
 }
 
 func (e TaskLifecycleEvent) GetMetadata() Metadata {
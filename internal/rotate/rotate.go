@@ -0,0 +1,329 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package rotate provides size- and age-based rotation for append-only files such as
+// the event log and archived transcripts, plus pruning of old rotated files.
+//
+// Rotation renames the active file to a timestamped backup and then opens a fresh file
+// under the original name. Because rename is atomic, a reader always sees either the
+// complete old file or the new one, never a half-rotated file. Tailers holding the old
+// file open keep reading it to the end and can then reopen the path.
+package rotate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is embedded in rotated file names: <name>-<timestamp><ext>
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// Policy controls when a file is rotated and which rotated files are pruned.
+// Zero values disable the corresponding limit.
+type Policy struct {
+	MaxSize     int64         // Rotate before a write would grow the file beyond this many bytes
+	RotateEvery time.Duration // Rotate once the active file is older than this
+	MaxAge      time.Duration // Prune rotated files older than this
+	MaxBackups  int           // Keep at most this many rotated files
+}
+
+// File is an append-only file that rotates itself according to a Policy.
+// It is safe for concurrent use.
+type File struct {
+	mu       sync.Mutex
+	path     string
+	policy   Policy
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	now      func() time.Time
+}
+
+// Open opens path for appending, creating it and its directory if needed
+func Open(path string, policy Policy) (*File, error) {
+	f := &File{
+		path:   path,
+		policy: policy,
+		now:    time.Now,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Path returns the path of the active file
+func (f *File) Path() string {
+	return f.path
+}
+
+// Write appends p to the active file, rotating first if the policy requires it
+func (f *File) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if f.shouldRotate(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate renames the active file to a backup and reopens a fresh one.
+// It returns the path of the backup, or "" if the active file was empty.
+func (f *File) Rotate() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return "", os.ErrClosed
+	}
+	if f.size == 0 {
+		return "", nil
+	}
+	backup := f.nextBackupName()
+	if err := f.rotateTo(backup); err != nil {
+		return "", err
+	}
+	return backup, nil
+}
+
+// Sync flushes the active file to disk
+func (f *File) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return os.ErrClosed
+	}
+	return f.file.Sync()
+}
+
+// Close closes the active file
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *File) shouldRotate(incoming int64) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.policy.MaxSize > 0 && f.size+incoming > f.policy.MaxSize {
+		return true
+	}
+	return f.policy.RotateEvery > 0 && f.now().Sub(f.openedAt) >= f.policy.RotateEvery
+}
+
+func (f *File) rotate() error {
+	return f.rotateTo(f.nextBackupName())
+}
+
+// nextBackupName returns an unused backup name so that two rotations within
+// the same millisecond never overwrite each other
+func (f *File) nextBackupName() string {
+	t := f.now()
+	for {
+		name := backupName(f.path, t)
+		if _, err := os.Lstat(name); os.IsNotExist(err) {
+			return name
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+// rotateTo renames the active file to backup, then swaps in a freshly opened file.
+// The old handle is only closed once the new file is in place.
+func (f *File) rotateTo(backup string) error {
+	if err := os.Rename(f.path, backup); err != nil {
+		return fmt.Errorf("failed to rename %s: %w", f.path, err)
+	}
+
+	old := f.file
+	if err := f.open(); err != nil {
+		// Put the original back so writes keep going to the same file
+		if renameErr := os.Rename(backup, f.path); renameErr != nil {
+			return fmt.Errorf("failed to reopen %s: %w (restore failed: %v)", f.path, err, renameErr)
+		}
+		f.file = old
+		return err
+	}
+
+	if err := old.Close(); err != nil {
+		return fmt.Errorf("failed to close rotated file: %w", err)
+	}
+	return nil
+}
+
+func (f *File) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat %s: %w", f.path, err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.now()
+	if info.Size() > 0 {
+		// An existing file counts its age from its last modification
+		f.openedAt = info.ModTime()
+	}
+	return nil
+}
+
+// backupName returns the rotated name for path at t, e.g. events-2026-01-02T15-04-05.000.jsonl
+func backupName(path string, t time.Time) string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	return fmt.Sprintf("%s-%s%s", base, t.UTC().Format(backupTimeFormat), ext)
+}
+
+// Backup is a rotated file belonging to an active path
+type Backup struct {
+	Path      string
+	RotatedAt time.Time
+}
+
+// ListBackups returns the rotated files of path, newest first
+func ListBackups(path string) ([]Backup, error) {
+	dir := filepath.Dir(path)
+	ext := filepath.Ext(path)
+	prefix := strings.TrimSuffix(filepath.Base(path), ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	var backups []Backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		rotatedAt, err := time.Parse(backupTimeFormat, stamp)
+		if err != nil {
+			continue // Not one of ours
+		}
+		backups = append(backups, Backup{Path: filepath.Join(dir, name), RotatedAt: rotatedAt})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].RotatedAt.After(backups[j].RotatedAt)
+	})
+	return backups, nil
+}
+
+// Prune removes rotated files of path that exceed the policy's MaxAge or MaxBackups
+// and returns the removed paths. If path is a directory, every file family in it is
+// pruned independently (as used for a directory of archived transcripts).
+func Prune(path string, policy Policy, now time.Time) ([]string, error) {
+	if policy.MaxAge <= 0 && policy.MaxBackups <= 0 {
+		return nil, nil
+	}
+
+	info, err := os.Stat(path)
+	if err == nil && info.IsDir() {
+		return pruneDir(path, policy, now)
+	}
+	return pruneFile(path, policy, now)
+}
+
+func pruneFile(path string, policy Policy, now time.Time) ([]string, error) {
+	backups, err := ListBackups(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for i, b := range backups {
+		expired := policy.MaxAge > 0 && now.Sub(b.RotatedAt) > policy.MaxAge
+		overLimit := policy.MaxBackups > 0 && i >= policy.MaxBackups
+		if !expired && !overLimit {
+			continue
+		}
+		if err := os.Remove(b.Path); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to remove %s: %w", b.Path, err)
+		}
+		removed = append(removed, b.Path)
+	}
+	return removed, nil
+}
+
+func pruneDir(dir string, policy Policy, now time.Time) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	// Derive each active file name from its backups so families whose active
+	// file is already gone are pruned too
+	families := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if active, ok := activeName(entry.Name()); ok {
+			families[filepath.Join(dir, active)] = true
+		}
+	}
+
+	var removed []string
+	for active := range families {
+		r, err := pruneFile(active, policy, now)
+		removed = append(removed, r...)
+		if err != nil {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+// activeName maps a backup file name back to the name of the file it was rotated from
+func activeName(name string) (string, bool) {
+	n := len(backupTimeFormat)
+	// The timestamp contains a '.', so for extensionless files Ext returns part of it
+	for _, ext := range []string{filepath.Ext(name), ""} {
+		stem := strings.TrimSuffix(name, ext)
+		if len(stem) <= n+1 || stem[len(stem)-n-1] != '-' {
+			continue
+		}
+		if _, err := time.Parse(backupTimeFormat, stem[len(stem)-n:]); err != nil {
+			continue
+		}
+		return stem[:len(stem)-n-1] + ext, true
+	}
+	return "", false
+}
@@ -0,0 +1,273 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package rotate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock returns a controllable time source for File.now
+func fakeClock(start time.Time) (func() time.Time, func(time.Duration)) {
+	now := start
+	return func() time.Time { return now }, func(d time.Duration) { now = now.Add(d) }
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestOpen_AppendsToExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("existing\n"), 0644))
+
+	f, err := Open(path, Policy{})
+	require.NoError(t, err)
+	_, err = f.Write([]byte("appended\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, "existing\nappended\n", readFile(t, path))
+}
+
+func TestOpen_CreatesDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "events.jsonl")
+
+	f, err := Open(path, Policy{})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.FileExists(t, path)
+}
+
+func TestWrite_RotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	f, err := Open(path, Policy{MaxSize: 10})
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Write([]byte("12345678\n"))
+	require.NoError(t, err)
+	_, err = f.Write([]byte("abc\n"))
+	require.NoError(t, err)
+
+	backups, err := ListBackups(path)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, "12345678\n", readFile(t, backups[0].Path), "rotated file holds complete writes only")
+	assert.Equal(t, "abc\n", readFile(t, path))
+}
+
+func TestWrite_OversizedWriteIsNotSplit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	f, err := Open(path, Policy{MaxSize: 4})
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Write([]byte("longer than max\n"))
+	require.NoError(t, err)
+
+	backups, err := ListBackups(path)
+	require.NoError(t, err)
+	assert.Empty(t, backups, "an empty file is never rotated")
+	assert.Equal(t, "longer than max\n", readFile(t, path))
+}
+
+func TestWrite_RotatesByAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	f, err := Open(path, Policy{RotateEvery: time.Hour})
+	require.NoError(t, err)
+	defer f.Close()
+
+	now, advance := fakeClock(time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC))
+	f.now = now
+	f.openedAt = now()
+
+	_, err = f.Write([]byte("first\n"))
+	require.NoError(t, err)
+	advance(30 * time.Minute)
+	_, err = f.Write([]byte("second\n"))
+	require.NoError(t, err)
+
+	backups, err := ListBackups(path)
+	require.NoError(t, err)
+	assert.Empty(t, backups)
+
+	advance(time.Hour)
+	_, err = f.Write([]byte("third\n"))
+	require.NoError(t, err)
+
+	backups, err = ListBackups(path)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, "first\nsecond\n", readFile(t, backups[0].Path))
+	assert.Equal(t, "third\n", readFile(t, path))
+}
+
+func TestRotate_ReaderKeepsOldFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	f, err := Open(path, Policy{})
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Write([]byte("before\n"))
+	require.NoError(t, err)
+
+	// A tailer that opened the file before rotation keeps reading the old content
+	reader, err := os.Open(path)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	backup, err := f.Rotate()
+	require.NoError(t, err)
+	require.NotEmpty(t, backup)
+
+	_, err = f.Write([]byte("after\n"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 64)
+	n, _ := reader.Read(buf)
+	assert.Equal(t, "before\n", string(buf[:n]))
+	assert.Equal(t, "before\n", readFile(t, backup))
+	assert.Equal(t, "after\n", readFile(t, path))
+}
+
+func TestRotate_EmptyFileAndUniqueNames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events")
+	f, err := Open(path, Policy{})
+	require.NoError(t, err)
+	defer f.Close()
+
+	backup, err := f.Rotate()
+	require.NoError(t, err)
+	assert.Empty(t, backup, "nothing to rotate")
+
+	now, _ := fakeClock(time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC))
+	f.now = now
+
+	var names []string
+	for _, content := range []string{"one", "two"} {
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+		backup, err := f.Rotate()
+		require.NoError(t, err)
+		names = append(names, backup)
+	}
+
+	require.Len(t, names, 2)
+	assert.NotEqual(t, names[0], names[1], "rotations at the same instant must not overwrite each other")
+	assert.Equal(t, "one", readFile(t, names[0]))
+	assert.Equal(t, "two", readFile(t, names[1]))
+}
+
+func TestWrite_AfterClose(t *testing.T) {
+	f, err := Open(filepath.Join(t.TempDir(), "events.jsonl"), Policy{})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.NoError(t, f.Close(), "closing twice is a no-op")
+
+	_, err = f.Write([]byte("x"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+	_, err = f.Rotate()
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
+
+func writeBackups(t *testing.T, path string, times ...time.Time) {
+	t.Helper()
+	for _, ts := range times {
+		require.NoError(t, os.WriteFile(backupName(path, ts), []byte("x"), 0644))
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name        string
+		policy      Policy
+		wantRemoved int
+		wantKept    []time.Time
+	}{
+		{
+			name:        "no limits keeps everything",
+			policy:      Policy{},
+			wantRemoved: 0,
+			wantKept:    []time.Time{now.Add(-1 * day), now.Add(-5 * day), now.Add(-40 * day)},
+		},
+		{
+			name:        "max age",
+			policy:      Policy{MaxAge: 30 * day},
+			wantRemoved: 1,
+			wantKept:    []time.Time{now.Add(-1 * day), now.Add(-5 * day)},
+		},
+		{
+			name:        "max backups keeps newest",
+			policy:      Policy{MaxBackups: 1},
+			wantRemoved: 2,
+			wantKept:    []time.Time{now.Add(-1 * day)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "events.jsonl")
+			require.NoError(t, os.WriteFile(path, []byte("active"), 0644))
+			writeBackups(t, path, now.Add(-40*day), now.Add(-1*day), now.Add(-5*day))
+			// Unrelated files in the same directory are never touched
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "events-notes.jsonl"), []byte("x"), 0644))
+
+			removed, err := Prune(path, tt.policy, now)
+			require.NoError(t, err)
+			assert.Len(t, removed, tt.wantRemoved)
+
+			backups, err := ListBackups(path)
+			require.NoError(t, err)
+			var kept []time.Time
+			for _, b := range backups {
+				kept = append(kept, b.RotatedAt)
+			}
+			assert.Equal(t, tt.wantKept, kept)
+			assert.FileExists(t, path)
+			assert.FileExists(t, filepath.Join(dir, "events-notes.jsonl"))
+		})
+	}
+}
+
+func TestPrune_Directory(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+
+	transcript := filepath.Join(dir, "session-a.jsonl")
+	extensionless := filepath.Join(dir, "session-b")
+	writeBackups(t, transcript, now.Add(-time.Hour), now.Add(-2*time.Hour))
+	writeBackups(t, extensionless, now.Add(-time.Hour), now.Add(-2*time.Hour))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("x"), 0644))
+
+	removed, err := Prune(dir, Policy{MaxBackups: 1}, now)
+	require.NoError(t, err)
+	assert.Len(t, removed, 2, "each file family is pruned independently")
+
+	for _, path := range []string{transcript, extensionless} {
+		backups, err := ListBackups(path)
+		require.NoError(t, err)
+		require.Len(t, backups, 1)
+		assert.Equal(t, now.Add(-time.Hour), backups[0].RotatedAt)
+	}
+	assert.FileExists(t, filepath.Join(dir, "README.md"))
+}
+
+func TestPrune_MissingPath(t *testing.T) {
+	removed, err := Prune(filepath.Join(t.TempDir(), "missing", "events.jsonl"), Policy{MaxBackups: 1}, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+)
+
+// ErrClientClosed is returned by calls made on, or interrupted by, a closed client
+var ErrClientClosed = errors.New("rpc client closed")
+
+// Client is a minimal JSON-RPC client for the control socket
+type Client struct {
+	conn net.Conn
+
+	writeMu sync.Mutex
+	enc     *json.Encoder
+
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[string]chan *wireMessage
+	err     error
+
+	events chan EventMessage
+	done   chan struct{}
+}
+
+// wireMessage is anything the server sends: a response or a notification
+type wireMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+// Dial connects to the RPC server listening on socketPath
+func Dial(socketPath string) (*Client, error) {
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", socketPath, err)
+	}
+	c := &Client{
+		conn:    conn,
+		enc:     json.NewEncoder(conn),
+		pending: make(map[string]chan *wireMessage),
+		events:  make(chan EventMessage, 256),
+		done:    make(chan struct{}),
+	}
+	go c.readLoop()
+	return c, nil
+}
+
+// Events delivers event notifications after a successful Subscribe call. It is closed
+// when the connection ends. Events are dropped while the channel is full.
+func (c *Client) Events() <-chan EventMessage {
+	return c.events
+}
+
+// Subscribe asks the server to stream every event to Events
+func (c *Client) Subscribe(ctx context.Context) error {
+	return c.Call(ctx, MethodSubscribe, nil, nil)
+}
+
+// Call invokes method with params and decodes the result into result, which may be nil.
+// A JSON-RPC error is returned as *Error.
+func (c *Client) Call(ctx context.Context, method string, params, result any) error {
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return c.err
+	}
+	c.nextID++
+	id := strconv.FormatUint(c.nextID, 10)
+	reply := make(chan *wireMessage, 1)
+	c.pending[id] = reply
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	req := struct {
+		JSONRPC string `json:"jsonrpc"`
+		ID      string `json:"id"`
+		Method  string `json:"method"`
+		Params  any    `json:"params,omitempty"`
+	}{Version, id, method, params}
+
+	c.writeMu.Lock()
+	err := c.enc.Encode(req)
+	c.writeMu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to send %s: %w", method, err)
+	}
+
+	select {
+	case msg := <-reply:
+		if msg.Error != nil {
+			return msg.Error
+		}
+		if result == nil || len(msg.Result) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(msg.Result, result); err != nil {
+			return fmt.Errorf("failed to decode %s result: %w", method, err)
+		}
+		return nil
+	case <-c.done:
+		return ErrClientClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close closes the connection
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) readLoop() {
+	defer func() {
+		c.mu.Lock()
+		c.err = ErrClientClosed
+		c.mu.Unlock()
+		close(c.done)
+		close(c.events)
+	}()
+
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for scanner.Scan() {
+		var msg wireMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+
+		if msg.Method == MethodEvent {
+			var event EventMessage
+			if err := json.Unmarshal(msg.Params, &event); err != nil {
+				continue
+			}
+			select {
+			case c.events <- event:
+			default:
+			}
+			continue
+		}
+
+		var id string
+		if err := json.Unmarshal(msg.ID, &id); err != nil {
+			continue // Not one of ours: the client only sends string IDs
+		}
+		c.mu.Lock()
+		reply, ok := c.pending[id]
+		c.mu.Unlock()
+		if ok {
+			reply <- &msg
+		}
+	}
+}
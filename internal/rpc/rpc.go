@@ -0,0 +1,131 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package rpc exposes the orchestrator's core commands and its event stream to
+// external tools (editors, scripts) as newline-delimited JSON-RPC 2.0 over a
+// unix socket. Params are the protocol command types and results are protocol
+// events, so the RPC schema is the same one the TUI speaks.
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/noldarim/noldarim/internal/logger"
+	"github.com/noldarim/noldarim/internal/protocol"
+)
+
+var (
+	log     *zerolog.Logger
+	logOnce sync.Once
+)
+
+func getLog() *zerolog.Logger {
+	logOnce.Do(func() {
+		l := logger.GetLogger("rpc")
+		log = &l
+	})
+	return log
+}
+
+// Version is the JSON-RPC version spoken on the socket
+const Version = "2.0"
+
+// Methods. Unless noted, params are the protocol command of the same name and the
+// result is the first event the orchestrator sends in reply, as an EventMessage.
+const (
+	MethodListProjects     = "ListProjects"     // No params; replies ProjectsLoadedEvent
+	MethodListTasks        = "ListTasks"        // protocol.LoadTasksCommand; replies TasksLoadedEvent
+	MethodCreateTask       = "CreateTask"       // protocol.CreateTaskCommand; replies PipelineRunStartedEvent or TaskQueuedEvent
+	MethodRetryTask        = "RetryTask"        // protocol.CreateTaskCommand with the failed run's BaseCommitSHA; same replies as CreateTask
+	MethodCancelTask       = "CancelTask"       // protocol.CancelPipelineCommand; replies PipelineCancelledEvent
+	MethodCancelQueuedTask = "CancelQueuedTask" // protocol.CancelQueuedTaskCommand; replies TaskDequeuedEvent
+	MethodRebaseTask       = "RebaseTask"       // protocol.RebaseTaskCommand; replies TaskRebasedEvent
+	MethodAssembleBranch   = "AssembleBranch"   // protocol.AssembleBranchCommand; replies BranchAssembledEvent
+	MethodSubscribe        = "Subscribe"        // No params; afterwards every event arrives as a MethodEvent notification
+
+	// MethodEvent is the notification carrying one event to a subscribed connection
+	MethodEvent = "Event"
+)
+
+// Error codes. The -326xx codes are defined by JSON-RPC 2.0.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	CodeCommandFailed  = -32000 // The orchestrator answered with an ErrorEvent
+	CodeTimeout        = -32001 // No reply from the orchestrator in time
+)
+
+// Request is a JSON-RPC request. Requests without an ID are notifications and get no response.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a JSON-RPC response; exactly one of Result and Error is set
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Notification is a server-to-client message without an ID
+type Notification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+// Error is a JSON-RPC error object
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Data != "" {
+		return fmt.Sprintf("rpc error %d: %s: %s", e.Code, e.Message, e.Data)
+	}
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// EventMessage wraps a protocol event with its type name (e.g. "PipelineRunStartedEvent")
+// so clients know what to decode Event into.
+type EventMessage struct {
+	Type  string          `json:"type"`
+	Event json.RawMessage `json:"event"`
+}
+
+// Decode unmarshals the event into v
+func (m EventMessage) Decode(v any) error {
+	return json.Unmarshal(m.Event, v)
+}
+
+// newEventMessage encodes event for the wire
+func newEventMessage(event protocol.Event) (EventMessage, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return EventMessage{}, fmt.Errorf("failed to encode %T: %w", event, err)
+	}
+	return EventMessage{Type: EventType(event), Event: data}, nil
+}
+
+// EventType returns the bare type name of an event, without package or pointer
+func EventType(event protocol.Event) string {
+	t := reflect.TypeOf(event)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/orchestrator"
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/protocol"
+)
+
+// fakeOrchestrator answers commands the way the orchestrator does, echoing the
+// command's metadata onto the reply
+func fakeOrchestrator(ctx context.Context, cmdChan <-chan protocol.Command, eventChan chan<- protocol.Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case cmd := <-cmdChan:
+			switch c := cmd.(type) {
+			case protocol.LoadProjectsCommand:
+				eventChan <- protocol.ProjectsLoadedEvent{Metadata: c.Metadata, Projects: map[string]*models.Project{
+					"p1": {ID: "p1", Name: "Demo"},
+				}}
+			case protocol.CreateTaskCommand:
+				eventChan <- protocol.TaskQueuedEvent{Metadata: c.Metadata, ProjectID: c.ProjectID, QueueID: "queued-1", Title: c.Title, Position: 1}
+			case protocol.RebaseTaskCommand:
+				eventChan <- protocol.TaskRebasedEvent{Metadata: c.Metadata, ProjectID: c.ProjectID, TaskID: c.TaskID, Onto: c.Onto, Conflicts: []string{"main.go"}}
+			case protocol.AssembleBranchCommand:
+				eventChan <- protocol.BranchAssembledEvent{Metadata: c.Metadata, ProjectID: c.ProjectID, TargetBranch: c.TargetBranch, Sources: []protocol.AssembleSourceResult{
+					{TaskID: c.SourceTaskIDs[0], Status: protocol.AssembleSourceMerged},
+					{TaskID: c.SourceTaskIDs[1], Status: protocol.AssembleSourceConflicted, Conflicts: []string{"main.go"}},
+				}}
+			case protocol.CancelPipelineCommand:
+				eventChan <- protocol.ErrorEvent{Metadata: c.Metadata, Message: "Failed to cancel pipeline", Context: "no such run"}
+			}
+		}
+	}
+}
+
+func startServer(t *testing.T) (*Client, chan<- protocol.Event) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cmdChan := make(chan protocol.Command, 10)
+	eventChan := make(chan protocol.Event, 10)
+	bus := orchestrator.NewEventBus(100)
+	go bus.Run(ctx, eventChan)
+	go fakeOrchestrator(ctx, cmdChan, eventChan)
+
+	// Unix socket paths are length-limited, so stay out of the long test temp dir
+	dir, err := os.MkdirTemp("", "noldarim-rpc")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socketPath := filepath.Join(dir, "rpc.sock")
+
+	srv := NewServer(socketPath, cmdChan, bus)
+	go srv.Run(ctx)
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(socketPath)
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	client, err := Dial(socketPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client, eventChan
+}
+
+func TestServer_ListProjects(t *testing.T) {
+	client, _ := startServer(t)
+
+	var result EventMessage
+	require.NoError(t, client.Call(context.Background(), MethodListProjects, nil, &result))
+	assert.Equal(t, "ProjectsLoadedEvent", result.Type)
+
+	var event protocol.ProjectsLoadedEvent
+	require.NoError(t, result.Decode(&event))
+	require.Contains(t, event.Projects, "p1")
+	assert.Equal(t, "Demo", event.Projects["p1"].Name)
+}
+
+func TestServer_CreateTask(t *testing.T) {
+	client, _ := startServer(t)
+
+	var result EventMessage
+	err := client.Call(context.Background(), MethodCreateTask, protocol.CreateTaskCommand{ProjectID: "p1", Title: "Fix bug"}, &result)
+	require.NoError(t, err)
+	assert.Equal(t, "TaskQueuedEvent", result.Type)
+
+	var event protocol.TaskQueuedEvent
+	require.NoError(t, result.Decode(&event))
+	assert.Equal(t, "Fix bug", event.Title)
+}
+
+func TestServer_RetryTask(t *testing.T) {
+	client, _ := startServer(t)
+
+	var result EventMessage
+	cmd := protocol.CreateTaskCommand{ProjectID: "p1", Title: "Fix bug", BaseCommitSHA: "abc123"}
+	require.NoError(t, client.Call(context.Background(), MethodRetryTask, cmd, &result))
+	assert.Equal(t, "TaskQueuedEvent", result.Type)
+}
+
+func TestServer_RebaseTask(t *testing.T) {
+	client, _ := startServer(t)
+	ctx := context.Background()
+
+	var result EventMessage
+	require.NoError(t, client.Call(ctx, MethodRebaseTask, protocol.RebaseTaskCommand{ProjectID: "p1", TaskID: "run-1", Onto: "main"}, &result))
+	assert.Equal(t, "TaskRebasedEvent", result.Type)
+
+	var event protocol.TaskRebasedEvent
+	require.NoError(t, result.Decode(&event))
+	assert.Equal(t, "run-1", event.TaskID)
+	assert.Equal(t, "run-1", event.Metadata.TaskID)
+	assert.Equal(t, []string{"main.go"}, event.Conflicts)
+
+	var rpcErr *Error
+	err := client.Call(ctx, MethodRebaseTask, protocol.RebaseTaskCommand{ProjectID: "p1"}, nil)
+	require.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, CodeInvalidParams, rpcErr.Code)
+}
+
+func TestServer_AssembleBranch(t *testing.T) {
+	client, _ := startServer(t)
+	ctx := context.Background()
+
+	var result EventMessage
+	cmd := protocol.AssembleBranchCommand{ProjectID: "p1", SourceTaskIDs: []string{"run-1", "run-2"}, TargetBranch: "release"}
+	require.NoError(t, client.Call(ctx, MethodAssembleBranch, cmd, &result))
+	assert.Equal(t, "BranchAssembledEvent", result.Type)
+
+	var event protocol.BranchAssembledEvent
+	require.NoError(t, result.Decode(&event))
+	assert.Equal(t, "release", event.TargetBranch)
+	assert.Equal(t, []string{"run-1"}, event.Merged())
+	assert.Equal(t, []string{"run-2"}, event.Conflicted())
+
+	var rpcErr *Error
+	err := client.Call(ctx, MethodAssembleBranch, protocol.AssembleBranchCommand{ProjectID: "p1", TargetBranch: "release"}, nil)
+	require.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, CodeInvalidParams, rpcErr.Code)
+}
+
+func TestServer_Errors(t *testing.T) {
+	client, _ := startServer(t)
+	ctx := context.Background()
+
+	var rpcErr *Error
+	err := client.Call(ctx, MethodCancelTask, protocol.CancelPipelineCommand{RunID: "missing"}, nil)
+	require.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, CodeCommandFailed, rpcErr.Code)
+	assert.Equal(t, "no such run", rpcErr.Data)
+
+	err = client.Call(ctx, MethodRetryTask, protocol.CreateTaskCommand{ProjectID: "p1", Title: "Fix bug"}, nil)
+	require.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, CodeInvalidParams, rpcErr.Code)
+
+	err = client.Call(ctx, "DropDatabase", nil, nil)
+	require.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, CodeMethodNotFound, rpcErr.Code)
+}
+
+func TestServer_Subscribe(t *testing.T) {
+	client, eventChan := startServer(t)
+	require.NoError(t, client.Subscribe(context.Background()))
+
+	// The forwarder subscribes just after the reply is written
+	require.Eventually(t, func() bool {
+		eventChan <- protocol.NotificationEvent{Message: "hello"}
+		select {
+		case msg := <-client.Events():
+			var event protocol.NotificationEvent
+			require.NoError(t, msg.Decode(&event))
+			return msg.Type == "NotificationEvent" && event.Message == "hello"
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestEventType(t *testing.T) {
+	assert.Equal(t, "ErrorEvent", EventType(protocol.ErrorEvent{}))
+	assert.Equal(t, "AIActivityRecord", EventType(&models.AIActivityRecord{}))
+}
@@ -0,0 +1,358 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/noldarim/noldarim/internal/orchestrator"
+	"github.com/noldarim/noldarim/internal/protocol"
+)
+
+// RequestTimeout bounds how long a request waits for the orchestrator's reply
+var RequestTimeout = 90 * time.Second
+
+// maxRequestBytes caps a single request line
+const maxRequestBytes = 1 << 20
+
+// Server accepts JSON-RPC connections on a unix socket, forwards commands to the
+// orchestrator's command channel and answers from its event bus
+type Server struct {
+	socketPath string
+	cmdChan    chan<- protocol.Command
+	bus        *orchestrator.EventBus
+
+	keyPrefix string
+	nextKey   atomic.Uint64
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[*connection]struct{}
+}
+
+// NewServer creates a server; call Run to start listening
+func NewServer(socketPath string, cmdChan chan<- protocol.Command, bus *orchestrator.EventBus) *Server {
+	return &Server{
+		socketPath: socketPath,
+		cmdChan:    cmdChan,
+		bus:        bus,
+		keyPrefix:  fmt.Sprintf("rpc-%d", time.Now().UnixNano()),
+		conns:      make(map[*connection]struct{}),
+	}
+}
+
+// Run listens on the socket and serves connections until ctx is cancelled
+func (s *Server) Run(ctx context.Context) error {
+	if err := os.MkdirAll(filepath.Dir(s.socketPath), 0o700); err != nil {
+		return fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	// A socket left behind by a crashed process would make Listen fail, but one that
+	// still answers belongs to a running instance
+	if conn, err := net.Dial("unix", s.socketPath); err == nil {
+		conn.Close()
+		return fmt.Errorf("rpc socket %s is already in use", s.socketPath)
+	}
+	if err := os.Remove(s.socketPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+
+	listener, err := net.Listen("unix", s.socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.socketPath, err)
+	}
+	// The socket drives the orchestrator, so only the owner may connect
+	if err := os.Chmod(s.socketPath, 0o600); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+
+	getLog().Info().Str("socket", s.socketPath).Msg("RPC server listening")
+
+	go func() {
+		<-ctx.Done()
+		s.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		go s.serve(ctx, conn)
+	}
+}
+
+// Close stops listening, drops every connection and removes the socket
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+		s.listener = nil
+		os.Remove(s.socketPath)
+	}
+	for c := range s.conns {
+		c.conn.Close()
+	}
+	return err
+}
+
+// connection is one client; responses and event notifications share the writer
+type connection struct {
+	conn net.Conn
+
+	writeMu sync.Mutex
+	enc     *json.Encoder
+
+	subscribeOnce sync.Once
+	done          chan struct{}
+}
+
+func (c *connection) send(v any) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.enc.Encode(v)
+}
+
+func (s *Server) serve(ctx context.Context, conn net.Conn) {
+	c := &connection{conn: conn, enc: json.NewEncoder(conn), done: make(chan struct{})}
+	s.mu.Lock()
+	s.conns[c] = struct{}{}
+	s.mu.Unlock()
+
+	// In-flight requests stop waiting once their client has gone
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		close(c.done)
+		conn.Close()
+		wg.Wait()
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRequestBytes)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req Request
+		if err := json.Unmarshal(line, &req); err != nil {
+			c.send(Response{JSONRPC: Version, ID: json.RawMessage("null"), Error: &Error{Code: CodeParseError, Message: "parse error", Data: err.Error()}})
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.handle(ctx, c, req)
+		}()
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, net.ErrClosed) {
+		getLog().Debug().Err(err).Msg("RPC connection read failed")
+	}
+}
+
+func (s *Server) handle(ctx context.Context, c *connection, req Request) {
+	result, rpcErr := s.dispatch(ctx, req)
+	if len(req.ID) == 0 {
+		return // Notification: no response
+	}
+	resp := Response{JSONRPC: Version, ID: req.ID, Result: result, Error: rpcErr}
+	if err := c.send(resp); err != nil {
+		getLog().Debug().Err(err).Str("method", req.Method).Msg("Failed to write RPC response")
+		return
+	}
+	if req.Method == MethodSubscribe && rpcErr == nil {
+		c.subscribeOnce.Do(func() { go s.forwardEvents(c) })
+	}
+}
+
+func (s *Server) dispatch(ctx context.Context, req Request) (any, *Error) {
+	if req.JSONRPC != Version {
+		return nil, &Error{Code: CodeInvalidRequest, Message: `jsonrpc must be "2.0"`}
+	}
+
+	key := fmt.Sprintf("%s-%d", s.keyPrefix, s.nextKey.Add(1))
+	metadata := protocol.Metadata{IdempotencyKey: key, Version: protocol.CurrentProtocolVersion}
+
+	switch req.Method {
+	case MethodListProjects:
+		return s.call(ctx, key, protocol.LoadProjectsCommand{Metadata: metadata})
+
+	case MethodListTasks:
+		var cmd protocol.LoadTasksCommand
+		if rpcErr := decodeParams(req.Params, &cmd); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if cmd.ProjectID == "" {
+			return nil, &Error{Code: CodeInvalidParams, Message: "ProjectID is required"}
+		}
+		cmd.Metadata = metadata
+		return s.call(ctx, key, cmd)
+
+	case MethodCreateTask, MethodRetryTask:
+		var cmd protocol.CreateTaskCommand
+		if rpcErr := decodeParams(req.Params, &cmd); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if cmd.ProjectID == "" || cmd.Title == "" {
+			return nil, &Error{Code: CodeInvalidParams, Message: "ProjectID and Title are required"}
+		}
+		// Run IDs derive from the task's content and base commit, so resubmitting a failed
+		// task on its base commit restarts that run rather than starting a new one
+		if req.Method == MethodRetryTask && cmd.BaseCommitSHA == "" {
+			return nil, &Error{Code: CodeInvalidParams, Message: "BaseCommitSHA of the run to retry is required"}
+		}
+		cmd.Metadata = metadata
+		return s.call(ctx, key, cmd)
+
+	case MethodCancelTask:
+		var cmd protocol.CancelPipelineCommand
+		if rpcErr := decodeParams(req.Params, &cmd); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if cmd.RunID == "" {
+			return nil, &Error{Code: CodeInvalidParams, Message: "RunID is required"}
+		}
+		cmd.Metadata = metadata
+		return s.call(ctx, key, cmd)
+
+	case MethodCancelQueuedTask:
+		var cmd protocol.CancelQueuedTaskCommand
+		if rpcErr := decodeParams(req.Params, &cmd); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if cmd.ProjectID == "" || cmd.QueueID == "" {
+			return nil, &Error{Code: CodeInvalidParams, Message: "ProjectID and QueueID are required"}
+		}
+		cmd.Metadata = metadata
+		return s.call(ctx, key, cmd)
+
+	case MethodRebaseTask:
+		var cmd protocol.RebaseTaskCommand
+		if rpcErr := decodeParams(req.Params, &cmd); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if cmd.ProjectID == "" || cmd.TaskID == "" {
+			return nil, &Error{Code: CodeInvalidParams, Message: "ProjectID and TaskID are required"}
+		}
+		metadata.TaskID = cmd.TaskID
+		cmd.Metadata = metadata
+		return s.call(ctx, key, cmd)
+
+	case MethodAssembleBranch:
+		var cmd protocol.AssembleBranchCommand
+		if rpcErr := decodeParams(req.Params, &cmd); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if cmd.ProjectID == "" || cmd.TargetBranch == "" || len(cmd.SourceTaskIDs) == 0 {
+			return nil, &Error{Code: CodeInvalidParams, Message: "ProjectID, TargetBranch and SourceTaskIDs are required"}
+		}
+		cmd.Metadata = metadata
+		return s.call(ctx, key, cmd)
+
+	case MethodSubscribe:
+		return map[string]bool{"subscribed": true}, nil
+
+	default:
+		return nil, &Error{Code: CodeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+func decodeParams(params json.RawMessage, v any) *Error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: "invalid params", Data: err.Error()}
+	}
+	return nil
+}
+
+// call sends cmd and waits for the first event carrying its idempotency key, which the
+// orchestrator copies from the command's metadata onto its reply
+func (s *Server) call(ctx context.Context, key string, cmd protocol.Command) (any, *Error) {
+	ctx, cancel := context.WithTimeout(ctx, RequestTimeout)
+	defer cancel()
+
+	// Subscribe before sending so the reply cannot slip past
+	events, unsubscribe := s.bus.Subscribe()
+	defer unsubscribe()
+
+	select {
+	case s.cmdChan <- cmd:
+	case <-ctx.Done():
+		return nil, &Error{Code: CodeTimeout, Message: "orchestrator is not accepting commands"}
+	}
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil, &Error{Code: CodeInternalError, Message: "event stream closed"}
+			}
+			if event.GetMetadata().IdempotencyKey != key {
+				continue
+			}
+			if errEvent, isErr := event.(protocol.ErrorEvent); isErr {
+				return nil, &Error{Code: CodeCommandFailed, Message: errEvent.Message, Data: errEvent.Context}
+			}
+			msg, err := newEventMessage(event)
+			if err != nil {
+				return nil, &Error{Code: CodeInternalError, Message: err.Error()}
+			}
+			return msg, nil
+		case <-ctx.Done():
+			return nil, &Error{Code: CodeTimeout, Message: fmt.Sprintf("no reply from orchestrator within %s", RequestTimeout)}
+		}
+	}
+}
+
+// forwardEvents streams every bus event to a subscribed connection until it closes
+func (s *Server) forwardEvents(c *connection) {
+	events, unsubscribe := s.bus.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			msg, err := newEventMessage(event)
+			if err != nil {
+				getLog().Warn().Err(err).Msg("Skipping event that cannot be encoded")
+				continue
+			}
+			if err := c.send(Notification{JSONRPC: Version, Method: MethodEvent, Params: msg}); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -28,7 +28,7 @@ func getLog() *zerolog.Logger {
 	return log
 }
 
-// EventBroadcaster reads every event from the orchestrator's eventChan and
+// EventBroadcaster reads every event from its event bus subscription and
 // fans them out to all connected WebSocket clients.
 type EventBroadcaster struct {
 	eventChan <-chan protocol.Event
@@ -24,6 +24,7 @@ type dataReader interface {
 	LoadProjects(ctx context.Context) (map[string]*models.Project, error)
 	GetProject(ctx context.Context, projectID string) (*models.Project, error)
 	LoadTasks(ctx context.Context, projectID string) (map[string]*models.Task, error)
+	GetTask(ctx context.Context, taskID string) (*models.Task, error)
 	GetAIActivityByTask(ctx context.Context, taskID string) ([]*models.AIActivityRecord, error)
 	GetPipelineRunsByProject(ctx context.Context, projectID string) ([]*models.PipelineRun, error)
 	GetPipelineRun(ctx context.Context, runID string) (*models.PipelineRun, error)
@@ -36,10 +37,12 @@ type gitManager interface {
 }
 
 type pipelineMutator interface {
-	CreateProject(ctx context.Context, name, description, repoPath string) (*models.Project, error)
+	CreateProject(ctx context.Context, name, description, repoPath string, agentDefaults *protocol.AgentConfigInput) (*models.Project, error)
 	CreateTask(ctx context.Context, params services.CreateTaskParams) (*services.PipelineRunResult, error)
 	ToggleTask(ctx context.Context, projectID, taskID string) (models.TaskStatus, error)
-	DeleteTask(ctx context.Context, projectID, taskID string) error
+	DeleteTask(ctx context.Context, projectID, taskID string) (*services.TaskCleanupResult, error)
+	UpdateTask(ctx context.Context, projectID, taskID, title, description string, expectedVersion int) (*models.Task, error)
+	UpdateProject(ctx context.Context, projectID, name, description string, expectedVersion int) (*models.Project, error)
 	StartPipeline(ctx context.Context, params services.StartPipelineParams) (*services.PipelineRunResult, error)
 	CancelPipeline(ctx context.Context, runID, reason string) (*services.CancelResult, error)
 	PromotePipeline(ctx context.Context, params services.PromotePipelineParams) (*services.PipelineRunResult, error)
@@ -325,9 +328,10 @@ func (h *Handlers) GetConfig(w http.ResponseWriter, _ *http.Request) {
 
 // createProjectRequest is the JSON body for project creation.
 type createProjectRequest struct {
-	Name           string `json:"name"`
-	Description    string `json:"description"`
-	RepositoryPath string `json:"repository_path"`
+	Name               string                     `json:"name"`
+	Description        string                     `json:"description"`
+	RepositoryPath     string                     `json:"repository_path"`
+	DefaultAgentConfig *protocol.AgentConfigInput `json:"default_agent_config,omitempty"`
 }
 
 // CreateProject handles POST /api/v1/projects
@@ -352,7 +356,7 @@ func (h *Handlers) CreateProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	project, err := h.pipeline.CreateProject(r.Context(), body.Name, body.Description, body.RepositoryPath)
+	project, err := h.pipeline.CreateProject(r.Context(), body.Name, body.Description, body.RepositoryPath, body.DefaultAgentConfig)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Failed to create project", err)
 		return
@@ -360,12 +364,60 @@ func (h *Handlers) CreateProject(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, project)
 }
 
+// updateProjectRequest is the JSON body for project edits. Version is the version of the
+// project the edit is based on.
+type updateProjectRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Version     int    `json:"version"`
+}
+
+// UpdateProject handles PATCH /api/v1/projects/{id}. An edit based on a stale version is
+// rejected with 409 Conflict and the current project, for the client to refresh from.
+func (h *Handlers) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+	var body updateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid JSON body"})
+		return
+	}
+	body.Name = strings.TrimSpace(body.Name)
+	if body.Name == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "name is required"})
+		return
+	}
+	if body.Version <= 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "version is required"})
+		return
+	}
+
+	ctx := r.Context()
+	project, err := h.pipeline.UpdateProject(ctx, projectID, body.Name, body.Description, body.Version)
+	if errors.Is(err, services.ErrStaleWrite) {
+		current, getErr := h.data.GetProject(ctx, projectID)
+		if getErr != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to load project", getErr)
+			return
+		}
+		writeJSON(w, http.StatusConflict, map[string]any{"error": err.Error(), "project": current})
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to update project", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, project)
+}
+
 // createTaskRequest is the JSON body for task creation.
 type createTaskRequest struct {
 	Title         string                     `json:"title"`
 	Description   string                     `json:"description"`
 	BaseCommitSHA string                     `json:"base_commit_sha,omitempty"`
 	AgentConfig   *protocol.AgentConfigInput `json:"agent_config,omitempty"`
+	TokenBudget   int                        `json:"token_budget,omitempty"`
+	WorkingSubdir string                     `json:"working_subdir,omitempty"`
+	Labels        []string                   `json:"labels,omitempty"`
 }
 
 // CreateTask handles POST /api/v1/projects/{id}/tasks
@@ -381,6 +433,10 @@ func (h *Handlers) CreateTask(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "title is required"})
 		return
 	}
+	if body.TokenBudget < 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "token_budget must be >= 0"})
+		return
+	}
 
 	result, err := h.pipeline.CreateTask(r.Context(), services.CreateTaskParams{
 		ProjectID:     projectID,
@@ -388,6 +444,9 @@ func (h *Handlers) CreateTask(w http.ResponseWriter, r *http.Request) {
 		Description:   body.Description,
 		BaseCommitSHA: body.BaseCommitSHA,
 		AgentConfig:   body.AgentConfig,
+		TokenBudget:   body.TokenBudget,
+		WorkingSubdir: body.WorkingSubdir,
+		Labels:        body.Labels,
 	})
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Failed to create task", err)
@@ -409,16 +468,71 @@ func (h *Handlers) ToggleTask(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": newStatus.String()})
 }
 
+// updateTaskRequest is the JSON body for task edits. Version is the version of the task
+// the edit is based on.
+type updateTaskRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     int    `json:"version"`
+}
+
+// UpdateTask handles PATCH /api/v1/projects/{id}/tasks/{taskId}. An edit based on a stale
+// version is rejected with 409 Conflict and the current task, for the client to refresh from.
+func (h *Handlers) UpdateTask(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+	taskID := chi.URLParam(r, "taskId")
+	var body updateTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid JSON body"})
+		return
+	}
+	body.Title = strings.TrimSpace(body.Title)
+	if body.Title == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "title is required"})
+		return
+	}
+	if body.Version <= 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "version is required"})
+		return
+	}
+
+	ctx := r.Context()
+	task, err := h.pipeline.UpdateTask(ctx, projectID, taskID, body.Title, body.Description, body.Version)
+	if errors.Is(err, services.ErrStaleWrite) {
+		current, getErr := h.data.GetTask(ctx, taskID)
+		if getErr != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to load task", getErr)
+			return
+		}
+		writeJSON(w, http.StatusConflict, map[string]any{"error": err.Error(), "task": current})
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to update task", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
 // DeleteTask handles DELETE /api/v1/projects/{id}/tasks/{taskId}
 func (h *Handlers) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	projectID := chi.URLParam(r, "id")
 	taskID := chi.URLParam(r, "taskId")
 
-	if err := h.pipeline.DeleteTask(r.Context(), projectID, taskID); err != nil {
+	result, err := h.pipeline.DeleteTask(r.Context(), projectID, taskID)
+	if err != nil && !errors.Is(err, services.ErrTaskCleanup) {
 		writeError(w, http.StatusInternalServerError, "Failed to delete task", err)
 		return
 	}
-	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+	// The task is deleted even when cleaning up after it failed
+	response := map[string]any{"status": "deleted"}
+	if result != nil {
+		response["cleanup"] = result
+	}
+	if err != nil {
+		response["cleanup_error"] = err.Error()
+	}
+	writeJSON(w, http.StatusOK, response)
 }
 
 // startPipelineRequest is the JSON body for pipeline creation.
@@ -430,6 +544,7 @@ type startPipelineRequest struct {
 	ForkAfterStepID string                     `json:"fork_after_step_id,omitempty"`
 	NoAutoFork      bool                       `json:"no_auto_fork,omitempty"`
 	AutoPromote     bool                       `json:"auto_promote,omitempty"`
+	Labels          []string                   `json:"labels,omitempty"`
 }
 
 type startPipelineStepRequest struct {
@@ -484,6 +599,7 @@ func (h *Handlers) StartPipeline(w http.ResponseWriter, r *http.Request) {
 		ForkAfterStepID: body.ForkAfterStepID,
 		NoAutoFork:      body.NoAutoFork,
 		AutoPromote:     body.AutoPromote,
+		Labels:          body.Labels,
 	})
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Failed to start pipeline", err)
@@ -8,11 +8,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/noldarim/noldarim/internal/orchestrator/database"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 	"github.com/noldarim/noldarim/internal/orchestrator/services"
 	"github.com/noldarim/noldarim/internal/orchestrator/temporal/types"
@@ -22,6 +24,7 @@ import (
 type stubDataReader struct {
 	getPipelineRunFn       func(ctx context.Context, runID string) (*models.PipelineRun, error)
 	getAIActivityByRunIDFn func(ctx context.Context, runID string) ([]*models.AIActivityRecord, error)
+	getTaskFn              func(ctx context.Context, taskID string) (*models.Task, error)
 }
 
 func (s *stubDataReader) LoadProjects(ctx context.Context) (map[string]*models.Project, error) {
@@ -36,6 +39,13 @@ func (s *stubDataReader) LoadTasks(ctx context.Context, projectID string) (map[s
 	return map[string]*models.Task{}, nil
 }
 
+func (s *stubDataReader) GetTask(ctx context.Context, taskID string) (*models.Task, error) {
+	if s.getTaskFn != nil {
+		return s.getTaskFn(ctx, taskID)
+	}
+	return &models.Task{ID: taskID}, nil
+}
+
 func (s *stubDataReader) GetAIActivityByTask(ctx context.Context, taskID string) ([]*models.AIActivityRecord, error) {
 	return nil, nil
 }
@@ -66,9 +76,10 @@ type stubPipelineMutator struct {
 	startPipelineFn   func(ctx context.Context, params services.StartPipelineParams) (*services.PipelineRunResult, error)
 	promotePipelineFn func(ctx context.Context, params services.PromotePipelineParams) (*services.PipelineRunResult, error)
 	getMergeQueueFn   func(ctx context.Context, projectID string) (*types.MergeQueueState, error)
+	updateTaskFn      func(ctx context.Context, projectID, taskID, title, description string, expectedVersion int) (*models.Task, error)
 }
 
-func (s *stubPipelineMutator) CreateProject(ctx context.Context, name, description, repoPath string) (*models.Project, error) {
+func (s *stubPipelineMutator) CreateProject(ctx context.Context, name, description, repoPath string, agentDefaults *protocol.AgentConfigInput) (*models.Project, error) {
 	return nil, nil
 }
 
@@ -80,8 +91,19 @@ func (s *stubPipelineMutator) ToggleTask(ctx context.Context, projectID, taskID
 	return models.TaskStatusPending, nil
 }
 
-func (s *stubPipelineMutator) DeleteTask(ctx context.Context, projectID, taskID string) error {
-	return nil
+func (s *stubPipelineMutator) DeleteTask(ctx context.Context, projectID, taskID string) (*services.TaskCleanupResult, error) {
+	return nil, nil
+}
+
+func (s *stubPipelineMutator) UpdateTask(ctx context.Context, projectID, taskID, title, description string, expectedVersion int) (*models.Task, error) {
+	if s.updateTaskFn != nil {
+		return s.updateTaskFn(ctx, projectID, taskID, title, description, expectedVersion)
+	}
+	return &models.Task{ID: taskID, Title: title, Description: description, Version: expectedVersion + 1}, nil
+}
+
+func (s *stubPipelineMutator) UpdateProject(ctx context.Context, projectID, name, description string, expectedVersion int) (*models.Project, error) {
+	return &models.Project{ID: projectID, Name: name, Description: description, Version: expectedVersion + 1}, nil
 }
 
 func (s *stubPipelineMutator) StartPipeline(ctx context.Context, params services.StartPipelineParams) (*services.PipelineRunResult, error) {
@@ -510,3 +532,72 @@ func TestPromotePipeline(t *testing.T) {
 		}
 	})
 }
+
+func TestUpdateTask(t *testing.T) {
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/projects/project-1/tasks/task-1", bytes.NewBufferString(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "project-1")
+		rctx.URLParams.Add("taskId", "task-1")
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		var gotVersion int
+		h := NewHandlers(nil, &stubDataReader{}, &stubGitManager{}, &stubPipelineMutator{
+			updateTaskFn: func(ctx context.Context, projectID, taskID, title, description string, expectedVersion int) (*models.Task, error) {
+				gotVersion = expectedVersion
+				return &models.Task{ID: taskID, Title: title, Version: expectedVersion + 1}, nil
+			},
+		}, AgentDefaultsResponse{}, "")
+
+		rec := httptest.NewRecorder()
+		h.UpdateTask(rec, newRequest(`{"title":"Renamed","version":3}`))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
+		}
+		if gotVersion != 3 {
+			t.Fatalf("expected expected version 3 to be passed through, got %d", gotVersion)
+		}
+	})
+
+	t.Run("stale version (conflict)", func(t *testing.T) {
+		h := NewHandlers(nil, &stubDataReader{
+			getTaskFn: func(ctx context.Context, taskID string) (*models.Task, error) {
+				return &models.Task{ID: taskID, Title: "Edited elsewhere", Version: 5}, nil
+			},
+		}, &stubGitManager{}, &stubPipelineMutator{
+			updateTaskFn: func(ctx context.Context, projectID, taskID, title, description string, expectedVersion int) (*models.Task, error) {
+				return nil, fmt.Errorf("failed to update task: %w", &database.StaleWriteError{Table: "tasks", ID: taskID, ExpectedVersion: expectedVersion, CurrentVersion: 5})
+			},
+		}, AgentDefaultsResponse{}, "")
+
+		rec := httptest.NewRecorder()
+		h.UpdateTask(rec, newRequest(`{"title":"Renamed","version":3}`))
+
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("expected 409, got %d body=%s", rec.Code, rec.Body.String())
+		}
+		var response struct {
+			Task models.Task `json:"task"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if response.Task.Version != 5 || response.Task.Title != "Edited elsewhere" {
+			t.Fatalf("expected the current task in the conflict response, got %+v", response.Task)
+		}
+	})
+
+	t.Run("missing version (bad request)", func(t *testing.T) {
+		h := NewHandlers(nil, &stubDataReader{}, &stubGitManager{}, &stubPipelineMutator{}, AgentDefaultsResponse{}, "")
+
+		rec := httptest.NewRecorder()
+		h.UpdateTask(rec, newRequest(`{"title":"Renamed"}`))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d body=%s", rec.Code, rec.Body.String())
+		}
+	})
+}
@@ -51,6 +51,7 @@ func New(
 		// Projects
 		r.Get("/projects", handlers.GetProjects)
 		r.Post("/projects", handlers.CreateProject)
+		r.Patch("/projects/{id}", handlers.UpdateProject)
 		r.Get("/agent/defaults", handlers.GetAgentDefaults)
 		r.Get("/config", handlers.GetConfig)
 
@@ -65,6 +66,7 @@ func New(
 
 			// Task sub-resources
 			r.Post("/tasks/{taskId}/toggle", handlers.ToggleTask)
+			r.Patch("/tasks/{taskId}", handlers.UpdateTask)
 			r.Delete("/tasks/{taskId}", handlers.DeleteTask)
 			r.Get("/tasks/{taskId}/activity", handlers.GetAIActivity)
 		})
@@ -5,10 +5,17 @@ package activityfeed
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/noldarim/noldarim/internal/aiobs/types"
+	"github.com/noldarim/noldarim/internal/tui/keys"
+	"github.com/noldarim/noldarim/internal/tui/messages"
+	"github.com/noldarim/noldarim/internal/tui/toolstyle"
 )
 
 // EventType matches the AI event types
@@ -17,6 +24,7 @@ type EventType string
 const (
 	EventToolUse      EventType = "tool_use"
 	EventToolResult   EventType = "tool_result"
+	EventToolResultDelta EventType = "tool_result_delta"
 	EventThinking     EventType = "thinking"
 	EventAIOutput     EventType = "ai_output"
 	EventSubagentStart EventType = "subagent_start"
@@ -24,49 +32,394 @@ const (
 	EventError        EventType = "error"
 )
 
+// defaultMaxOutputLines caps the streamed output kept per tool result
+const defaultMaxOutputLines = 20
+
 // Activity represents a single activity item
 type Activity struct {
 	EventType      EventType
 	ToolName       string
+	ToolNamespace  string // MCP server of an MCP tool call, shown before ToolName
 	ContentPreview string
 	FilePath       string
 	ToolSuccess    *bool
 	ToolError      string
+	ToolUseID      string // Tool call a result (or result delta) belongs to
+
+	// ContentType is the sniffed type of a tool result's content; binary results are
+	// shown by their ContentLength instead of their preview
+	ContentType   types.ContentType
+	ContentLength int
+
+	// Attachments are the image and document blocks of the message, shown as
+	// "📎 image/png" after the preview
+	Attachments []types.Attachment
+
+	// Streaming marks a tool result still being built from deltas; OmittedLines
+	// counts the oldest output lines elided to stay under the output cap
+	Streaming    bool
+	OmittedLines int
 }
 
 // Model represents the activity feed component
 type Model struct {
-	activities []Activity
-	maxItems   int
+	activities     []Activity
+	maxItems       int
+	maxOutputLines int
+	focused        bool
+	selected       int // Index into activities, -1 when nothing is selected
+
+	// Filters narrow what is shown; activities stays complete so they can change freely
+	typeFilter []EventType
+	toolFilter []string
+
+	// Scrolling is enabled by SetSize; without a height View renders the last maxItems
+	width  int
+	height int
+	offset int // Position among the shown activities of the first rendered one, -1 while following the newest
+
+	upKey       key.Binding
+	downKey     key.Binding
+	pageUpKey   key.Binding
+	pageDownKey key.Binding
+	selectKey   key.Binding
 }
 
 // New creates a new activity feed model
 func New() Model {
 	return Model{
-		maxItems: 10,
+		maxItems:       10,
+		maxOutputLines: defaultMaxOutputLines,
+		selected:       -1,
+		offset:         -1,
+		upKey:          keys.Get(keys.Up),
+		downKey:        keys.Get(keys.Down),
+		pageUpKey:      keys.Get(keys.PageUp),
+		pageDownKey:    keys.Get(keys.PageDown),
+		selectKey:      keys.Get(keys.Select),
 	}
 }
 
 // SetActivities sets the activity list
 func (m Model) SetActivities(activities []Activity) Model {
 	m.activities = activities
+	if m.selected >= len(activities) {
+		m.selected = len(activities) - 1
+	}
+	return m
+}
+
+// AddActivity appends an activity. A tool_result_delta is appended to the streaming
+// result line of the same tool call (starting one if needed), and the final
+// tool_result replaces that line.
+func (m Model) AddActivity(a Activity) Model {
+	streaming := m.streamingResult(a.ToolUseID)
+
+	switch {
+	case a.EventType == EventToolResultDelta:
+		if streaming < 0 {
+			m.activities = append(m.activities, Activity{
+				EventType: EventToolResult,
+				ToolName:  a.ToolName,
+				ToolUseID: a.ToolUseID,
+				Streaming: true,
+			})
+			streaming = len(m.activities) - 1
+		}
+		result := &m.activities[streaming]
+		result.ContentPreview += a.ContentPreview
+		result.ContentPreview, result.OmittedLines = elideOutput(result.ContentPreview, result.OmittedLines, m.maxOutputLines)
+
+	case a.EventType == EventToolResult && streaming >= 0:
+		m.activities[streaming] = a
+
+	default:
+		// Keep the cursor on the newest activity while following it
+		if m.offset < 0 && m.selected >= 0 && m.selected == len(m.activities)-1 {
+			m.selected = len(m.activities)
+		}
+		m.activities = append(m.activities, a)
+	}
+	return m
+}
+
+// streamingResult returns the index of the streaming result for toolUseID, or -1
+func (m Model) streamingResult(toolUseID string) int {
+	if toolUseID == "" {
+		return -1
+	}
+	for i := len(m.activities) - 1; i >= 0; i-- {
+		if a := m.activities[i]; a.Streaming && a.ToolUseID == toolUseID {
+			return i
+		}
+	}
+	return -1
+}
+
+// elideOutput keeps the last maxLines lines of output, adding the dropped lines to omitted
+func elideOutput(output string, omitted, maxLines int) (string, int) {
+	if maxLines <= 0 {
+		return output, omitted
+	}
+	lines := strings.Split(output, "\n")
+	// A trailing newline leaves an empty, still-open last line that isn't counted
+	complete := len(lines)
+	if lines[complete-1] == "" {
+		complete--
+	}
+	if complete <= maxLines {
+		return output, omitted
+	}
+	drop := complete - maxLines
+	return strings.Join(lines[drop:], "\n"), omitted + drop
+}
+
+// SetMaxOutputLines sets how many lines of streamed tool output are kept per result
+func (m Model) SetMaxOutputLines(n int) Model {
+	m.maxOutputLines = n
 	return m
 }
 
-// SetMaxItems sets the maximum number of items to display
+// SetFocus sets the focus state; only a focused feed reacts to keys
+func (m Model) SetFocus(focused bool) Model {
+	m.focused = focused
+	return m
+}
+
+// Selected returns the selected activity, if any. An activity hidden by a filter is
+// not selected.
+func (m Model) Selected() (Activity, bool) {
+	if m.selected < 0 || m.selected >= len(m.activities) || !m.shows(m.activities[m.selected]) {
+		return Activity{}, false
+	}
+	return m.activities[m.selected], true
+}
+
+// SetFilter shows only activities of the given event types; no types shows them all
+func (m Model) SetFilter(eventTypes ...EventType) Model {
+	m.typeFilter = eventTypes
+	return m
+}
+
+// SetToolFilter shows only activities of the given tools, matched by tool name or by
+// MCP server (ToolNamespace); no names shows them all. Activities without a tool are
+// hidden while it is set.
+func (m Model) SetToolFilter(names ...string) Model {
+	m.toolFilter = names
+	return m
+}
+
+// filtering reports whether a filter is set
+func (m Model) filtering() bool {
+	return len(m.typeFilter) > 0 || len(m.toolFilter) > 0
+}
+
+// shows reports whether a passes the filters
+func (m Model) shows(a Activity) bool {
+	if len(m.typeFilter) > 0 && !slices.Contains(m.typeFilter, a.EventType) {
+		return false
+	}
+	if len(m.toolFilter) > 0 {
+		if a.ToolName == "" {
+			return false
+		}
+		if !slices.Contains(m.toolFilter, a.ToolName) && (a.ToolNamespace == "" || !slices.Contains(m.toolFilter, a.ToolNamespace)) {
+			return false
+		}
+	}
+	return true
+}
+
+// visible returns the indices of the activities that pass the filters, computed on
+// demand so changing filters costs nothing until the next render
+func (m Model) visible() []int {
+	indices := make([]int, 0, len(m.activities))
+	for i, a := range m.activities {
+		if m.shows(a) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// SetMaxItems sets the maximum number of items to display when no size is set
 func (m Model) SetMaxItems(n int) Model {
 	m.maxItems = n
 	return m
 }
 
+// SetSize sets the dimensions, turning on scrolling: View renders as many activities
+// as fit in height lines, and lines wider than width are truncated
+func (m Model) SetSize(width, height int) Model {
+	m.width = width
+	m.height = height
+	return m
+}
+
 func (m Model) Init() tea.Cmd {
 	return nil
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !m.focused {
+		return m, nil
+	}
+	visible := m.visible()
+	if len(visible) == 0 {
+		return m, nil
+	}
+	// Position of the selection among the shown activities; -1 selects the newest
+	pos := slices.Index(visible, m.selected)
+
+	if m.height > 0 {
+		return m.scroll(keyMsg, visible, pos)
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.upKey):
+		if pos < 0 {
+			m.selected = visible[len(visible)-1]
+		} else if pos > m.visibleStart(len(visible)) {
+			m.selected = visible[pos-1]
+		}
+	case key.Matches(keyMsg, m.downKey):
+		if pos < 0 {
+			m.selected = visible[len(visible)-1]
+		} else if pos < len(visible)-1 {
+			m.selected = visible[pos+1]
+		}
+	case key.Matches(keyMsg, m.selectKey):
+		return m, m.openSelected()
+	}
+	return m, nil
+}
+
+// scroll handles keys once a size is set, moving the cursor through every shown
+// activity and keeping it inside the window
+func (m Model) scroll(keyMsg tea.KeyMsg, visible []int, pos int) (Model, tea.Cmd) {
+	last := len(visible) - 1
+	start, end := m.window(visible)
+	page := max(end-start, 1)
+
+	switch {
+	case key.Matches(keyMsg, m.upKey):
+		pos = m.step(pos, -1, last)
+	case key.Matches(keyMsg, m.downKey):
+		pos = m.step(pos, 1, last)
+	case key.Matches(keyMsg, m.pageUpKey):
+		pos = m.step(pos, -page, last)
+	case key.Matches(keyMsg, m.pageDownKey):
+		pos = m.step(pos, page, last)
+	case key.Matches(keyMsg, m.selectKey):
+		return m, m.openSelected()
+	default:
+		return m, nil
+	}
+	m.selected = visible[pos]
+
+	switch {
+	case pos == last:
+		m.offset = -1
+	case pos < start:
+		m.offset = pos
+	case pos >= end:
+		m.offset = m.startEndingAt(visible, pos)
+	default:
+		m.offset = start
+	}
 	return m, nil
 }
 
+// step moves pos by delta within [0, last]; with no selection it starts at the newest
+func (m Model) step(pos, delta, last int) int {
+	if pos < 0 {
+		return last
+	}
+	return max(0, min(last, pos+delta))
+}
+
+// openSelected returns a command opening the selected activity's file, if it has one
+func (m Model) openSelected() tea.Cmd {
+	a, ok := m.Selected()
+	if !ok || a.FilePath == "" {
+		return nil
+	}
+	path := a.FilePath
+	return func() tea.Msg {
+		return messages.FileSelectedMsg{Path: path}
+	}
+}
+
+// visibleStart returns the position of the first rendered activity among the n shown
+// when no size is set
+func (m Model) visibleStart(n int) int {
+	if n > m.maxItems {
+		return n - m.maxItems
+	}
+	return 0
+}
+
+// window returns the positions [start, end) of the shown activities that are rendered.
+// Only these are rendered, so long feeds cost no more than a screenful.
+func (m Model) window(visible []int) (int, int) {
+	if m.height <= 0 {
+		return m.visibleStart(len(visible)), len(visible)
+	}
+	if len(visible) == 0 {
+		return 0, 0
+	}
+
+	// Never leave blank lines below the newest activity
+	start := m.startEndingAt(visible, len(visible)-1)
+	if m.offset >= 0 && m.offset < start {
+		start = m.offset
+	}
+
+	end, used := start, 0
+	for end < len(visible) {
+		used += activityLines(m.activities[visible[end]])
+		if used > m.lines() && end > start {
+			break
+		}
+		end++
+	}
+	return start, end
+}
+
+// startEndingAt returns the first position of the fullest window ending at pos
+func (m Model) startEndingAt(visible []int, pos int) int {
+	start, used := pos, activityLines(m.activities[visible[pos]])
+	for start > 0 {
+		used += activityLines(m.activities[visible[start-1]])
+		if used > m.lines() {
+			break
+		}
+		start--
+	}
+	return start
+}
+
+// lines returns the lines available for activities, less the filter header
+func (m Model) lines() int {
+	if m.filtering() {
+		return m.height - 1
+	}
+	return m.height
+}
+
+// activityLines returns how many lines renderActivity produces for a
+func activityLines(a Activity) int {
+	if a.EventType != EventToolResult || !a.Streaming {
+		return 1
+	}
+	n := 2 + strings.Count(strings.TrimRight(a.ContentPreview, "\n"), "\n")
+	if a.OmittedLines > 0 {
+		n++
+	}
+	return n
+}
+
 // View renders the activity feed
 func (m Model) View() string {
 	if len(m.activities) == 0 {
@@ -79,26 +432,64 @@ func (m Model) View() string {
 	success := lipgloss.NewStyle().Foreground(lipgloss.Color("35"))
 	fail := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
 	output := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	cursor := lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true)
 
 	var lines []string
-	start := 0
-	if len(m.activities) > m.maxItems {
-		start = len(m.activities) - m.maxItems
+	visible := m.visible()
+	if m.filtering() {
+		lines = append(lines, m.renderFilterHeader(len(m.activities)-len(visible), dim))
 	}
 
-	for _, a := range m.activities[start:] {
+	start, end := m.window(visible)
+	for _, idx := range visible[start:end] {
+		a := m.activities[idx]
 		line := renderActivity(a, dim, tool, thinking, success, fail, output)
+		for _, attachment := range a.Attachments {
+			line += " " + dim.Render(attachment.Label())
+		}
+		if m.selected >= 0 {
+			if idx == m.selected {
+				line = cursor.Render("›") + " " + line
+			} else {
+				line = "  " + line
+			}
+		}
 		lines = append(lines, line)
 	}
 
-	return strings.Join(lines, "\n")
+	view := strings.Join(lines, "\n")
+	if m.width > 0 {
+		view = truncateLines(view, m.width)
+	}
+	return view
+}
+
+// renderFilterHeader names the active filters and how many activities they hide,
+// e.g. "filter: error · tool: Bash · 42 hidden"
+func (m Model) renderFilterHeader(hidden int, dim lipgloss.Style) string {
+	var parts []string
+	if len(m.typeFilter) > 0 {
+		names := make([]string, len(m.typeFilter))
+		for i, t := range m.typeFilter {
+			names[i] = string(t)
+		}
+		parts = append(parts, "filter: "+strings.Join(names, ", "))
+	}
+	if len(m.toolFilter) > 0 {
+		parts = append(parts, "tool: "+strings.Join(m.toolFilter, ", "))
+	}
+	parts = append(parts, fmt.Sprintf("%d hidden", hidden))
+	return dim.Render(strings.Join(parts, " · "))
 }
 
 func renderActivity(a Activity, dim, tool, thinking, success, fail, output lipgloss.Style) string {
 	switch a.EventType {
 	case EventToolUse:
-		icon := tool.Render("▸")
-		name := tool.Render(a.ToolName)
+		icon := toolstyle.Icon(a.ToolName)
+		name := toolstyle.Name(a.ToolName)
+		if a.ToolNamespace != "" {
+			name = dim.Render(a.ToolNamespace+"/") + name
+		}
 		detail := ""
 		if a.FilePath != "" {
 			detail = dim.Render(" " + cleanString(a.FilePath))
@@ -108,6 +499,9 @@ func renderActivity(a Activity, dim, tool, thinking, success, fail, output lipgl
 		return fmt.Sprintf("%s %s%s", icon, name, detail)
 
 	case EventToolResult:
+		if a.Streaming {
+			return renderStreamingOutput(a, dim, output)
+		}
 		if a.ToolSuccess != nil && !*a.ToolSuccess {
 			icon := fail.Render("✗")
 			errMsg := cleanString(a.ToolError)
@@ -115,7 +509,9 @@ func renderActivity(a Activity, dim, tool, thinking, success, fail, output lipgl
 		}
 		icon := success.Render("✓")
 		detail := ""
-		if a.ContentPreview != "" {
+		if a.ContentType == types.ContentTypeBinary {
+			detail = dim.Render(fmt.Sprintf("[binary, %d bytes]", a.ContentLength))
+		} else if a.ContentPreview != "" {
 			detail = dim.Render(cleanString(a.ContentPreview))
 		}
 		return fmt.Sprintf("%s %s", icon, detail)
@@ -146,6 +542,28 @@ func renderActivity(a Activity, dim, tool, thinking, success, fail, output lipgl
 	}
 }
 
+// renderStreamingOutput renders the output of a running tool under a header line,
+// with an elision marker when older lines were dropped
+func renderStreamingOutput(a Activity, dim, output lipgloss.Style) string {
+	lines := []string{fmt.Sprintf("%s %s", dim.Render("⋯"), toolstyle.Render(a.ToolName))}
+	if a.OmittedLines > 0 {
+		lines = append(lines, dim.Render(fmt.Sprintf("  ... %d lines omitted ...", a.OmittedLines)))
+	}
+	for _, line := range strings.Split(strings.TrimRight(a.ContentPreview, "\n"), "\n") {
+		lines = append(lines, output.Render("  "+strings.TrimRight(line, "\r")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// truncateLines cuts every line of s to width cells
+func truncateLines(s string, width int) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, width, "…")
+	}
+	return strings.Join(lines, "\n")
+}
+
 func cleanString(s string) string {
 	s = strings.ReplaceAll(s, "\n", " ")
 	return strings.TrimSpace(s)
@@ -0,0 +1,245 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package activityfeed
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// feedActivities is a small feed of every kind of activity the filters distinguish
+func feedActivities() []Activity {
+	return []Activity{
+		{EventType: EventToolUse, ToolName: "Read", FilePath: "main.go"},             // 0
+		{EventType: EventToolResult, ToolName: "Read", ToolSuccess: boolPtr(true)},   // 1
+		{EventType: EventToolUse, ToolName: "Bash", ContentPreview: "go test ./..."}, // 2
+		{EventType: EventToolResult, ToolName: "Bash", ToolSuccess: boolPtr(false)},  // 3
+		{EventType: EventError, ContentPreview: "rate limited"},                      // 4
+		{EventType: EventToolUse, ToolName: "create_issue", ToolNamespace: "github"}, // 5
+		{EventType: EventAIOutput, ContentPreview: "Done, the tests pass"},           // 6
+	}
+}
+
+func TestModel_Filters(t *testing.T) {
+	tests := []struct {
+		name  string
+		types []EventType
+		tools []string
+		want  []int
+	}{
+		{"no filter", nil, nil, []int{0, 1, 2, 3, 4, 5, 6}},
+		{"one type", []EventType{EventError}, nil, []int{4}},
+		{"several types", []EventType{EventToolUse, EventAIOutput}, nil, []int{0, 2, 5, 6}},
+		{"tool name", nil, []string{"Bash"}, []int{2, 3}},
+		{"mcp server", nil, []string{"github"}, []int{5}},
+		{"type and tool", []EventType{EventToolResult}, []string{"Bash", "Read"}, []int{1, 3}},
+		{"tool filter hides activities without a tool", []EventType{EventError}, []string{"Bash"}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New().SetActivities(feedActivities()).SetFilter(tt.types...).SetToolFilter(tt.tools...)
+			assert.Equal(t, tt.want, m.visible())
+			assert.Equal(t, len(tt.types) > 0 || len(tt.tools) > 0, m.filtering())
+		})
+	}
+}
+
+func TestModel_FilterHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		types  []EventType
+		tools  []string
+		header string
+	}{
+		{"type filter", []EventType{EventError}, nil, "filter: error · 6 hidden"},
+		{"tool filter", nil, []string{"Bash"}, "tool: Bash · 5 hidden"},
+		{"both", []EventType{EventToolUse, EventToolResult}, []string{"Read"}, "filter: tool_use, tool_result · tool: Read · 5 hidden"},
+		{"everything hidden", []EventType{EventThinking}, nil, "filter: thinking · 7 hidden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New().SetActivities(feedActivities()).SetFilter(tt.types...).SetToolFilter(tt.tools...)
+			lines := ansiLines(m.View())
+			require.NotEmpty(t, lines)
+			assert.Equal(t, tt.header, lines[0])
+			assert.Len(t, lines, 1+len(m.visible()))
+		})
+	}
+
+	t.Run("no header without a filter", func(t *testing.T) {
+		m := New().SetActivities(feedActivities())
+		lines := ansiLines(m.View())
+		assert.Len(t, lines, len(feedActivities()))
+		assert.NotContains(t, lines[0], "hidden")
+	})
+}
+
+func TestModel_FilterSelection(t *testing.T) {
+	up := tea.KeyMsg{Type: tea.KeyUp}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	m := New().SetActivities(feedActivities()).SetFocus(true)
+	m, _ = m.Update(up)
+	selected, ok := m.Selected()
+	require.True(t, ok, "the first key selects the newest activity")
+	assert.Equal(t, EventAIOutput, selected.EventType)
+
+	// A hidden selection is not reported, and the next key picks the newest shown activity
+	m = m.SetFilter(EventToolUse)
+	_, ok = m.Selected()
+	assert.False(t, ok)
+	m, _ = m.Update(down)
+	assert.Equal(t, 5, m.selected)
+
+	// Moving skips the hidden activities
+	m, _ = m.Update(up)
+	assert.Equal(t, 2, m.selected)
+	m, _ = m.Update(up)
+	assert.Equal(t, 0, m.selected)
+	m, _ = m.Update(up)
+	assert.Equal(t, 0, m.selected, "stays on the oldest shown activity")
+
+	// Clearing the filter keeps the selection
+	m = m.SetFilter()
+	selected, ok = m.Selected()
+	require.True(t, ok)
+	assert.Equal(t, "main.go", selected.FilePath)
+
+	// Everything filtered out: keys do nothing
+	m = m.SetFilter(EventThinking)
+	m, _ = m.Update(down)
+	assert.Equal(t, 0, m.selected)
+	_, ok = m.Selected()
+	assert.False(t, ok)
+}
+
+// ansiLines splits a rendered view into lines without styling
+func ansiLines(view string) []string {
+	if view == "" {
+		return nil
+	}
+	return strings.Split(ansi.Strip(view), "\n")
+}
+
+// outputActivities returns n one-line activities, "line 0" to "line n-1"
+func outputActivities(n int) []Activity {
+	activities := make([]Activity, n)
+	for i := range activities {
+		activities[i] = Activity{EventType: EventAIOutput, ContentPreview: fmt.Sprintf("line %d", i)}
+	}
+	return activities
+}
+
+func TestModel_ScrollKeys(t *testing.T) {
+	m := New().SetActivities(outputActivities(30)).SetSize(80, 5).SetFocus(true)
+
+	steps := []struct {
+		key        tea.KeyType
+		selected   int
+		start, end int
+		following  bool
+	}{
+		{tea.KeyUp, 29, 25, 30, true}, // The first key selects the newest
+		{tea.KeyUp, 28, 25, 30, false},
+		{tea.KeyPgUp, 23, 23, 28, false}, // A page is the window's size
+		{tea.KeyPgUp, 18, 18, 23, false},
+		{tea.KeyDown, 19, 18, 23, false},
+		{tea.KeyPgDown, 24, 20, 25, false}, // The window ends at the cursor
+		{tea.KeyPgDown, 29, 25, 30, true},  // Reaching the newest follows it again
+		{tea.KeyPgDown, 29, 25, 30, true},
+	}
+	for i, step := range steps {
+		m, _ = m.Update(tea.KeyMsg{Type: step.key})
+		start, end := m.window(m.visible())
+		assert.Equal(t, step.selected, m.selected, "step %d: selected", i)
+		assert.Equal(t, step.start, start, "step %d: window start", i)
+		assert.Equal(t, step.end, end, "step %d: window end", i)
+		assert.Equal(t, step.following, m.offset < 0, "step %d: following", i)
+	}
+
+	// Paging up past the oldest stops on it
+	for range 10 {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	}
+	start, end := m.window(m.visible())
+	assert.Equal(t, 0, m.selected)
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 5, end)
+	assert.Equal(t, []string{"› line 0", "  line 1", "  line 2", "  line 3", "  line 4"}, ansiLines(m.View()))
+}
+
+func TestModel_FollowNewest(t *testing.T) {
+	m := New().SetActivities(outputActivities(30)).SetSize(80, 5).SetFocus(true)
+
+	// Following: new activities scroll into view, the cursor staying on the newest
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = m.AddActivity(Activity{EventType: EventAIOutput, ContentPreview: "line 30"})
+	start, end := m.window(m.visible())
+	assert.Equal(t, 30, m.selected)
+	assert.Equal(t, []int{26, 31}, []int{start, end})
+
+	// Scrolled back: the window stays put as activities arrive
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	before, _ := m.window(m.visible())
+	m = m.AddActivity(Activity{EventType: EventAIOutput, ContentPreview: "line 31"})
+	after, _ := m.window(m.visible())
+	assert.Equal(t, before, after)
+	assert.Equal(t, 25, m.selected)
+	assert.NotContains(t, m.View(), "line 31")
+}
+
+func TestModel_WindowFitsHeight(t *testing.T) {
+	streaming := Activity{
+		EventType:      EventToolResult,
+		ToolName:       "Bash",
+		ToolUseID:      "toolu_1",
+		Streaming:      true,
+		ContentPreview: "a\nb\nc\n",
+		OmittedLines:   4,
+	}
+	require.Equal(t, 5, activityLines(streaming), "header, elision marker and three output lines")
+
+	tests := []struct {
+		name       string
+		activities []Activity
+		filter     []EventType
+		height     int
+		start, end int
+	}{
+		{"fewer activities than lines", outputActivities(3), nil, 5, 0, 3},
+		{"newest window", outputActivities(10), nil, 4, 6, 10},
+		{"multi-line activity counts all its lines", append(outputActivities(10), streaming), nil, 7, 8, 11},
+		{"an activity taller than the window is still shown", append(outputActivities(2), streaming), nil, 3, 2, 3},
+		{"filter header takes a line", outputActivities(10), []EventType{EventAIOutput}, 4, 7, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New().SetActivities(tt.activities).SetFilter(tt.filter...).SetSize(80, tt.height)
+			start, end := m.window(m.visible())
+			assert.Equal(t, tt.start, start, "start")
+			assert.Equal(t, tt.end, end, "end")
+			assert.LessOrEqual(t, len(ansiLines(m.View())), max(tt.height, activityLines(streaming)))
+		})
+	}
+
+	t.Run("no size renders the last maxItems", func(t *testing.T) {
+		m := New().SetActivities(outputActivities(30)).SetMaxItems(4)
+		start, end := m.window(m.visible())
+		assert.Equal(t, []int{26, 30}, []int{start, end})
+		assert.Equal(t, []string{"line 26", "line 27", "line 28", "line 29"}, ansiLines(m.View()))
+	})
+}
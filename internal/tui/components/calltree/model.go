@@ -0,0 +1,243 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package calltree renders a session's AI activity as a collapsible tree: tool results under
+// the call that produced them, and subagent activity under the call that spawned it.
+package calltree
+
+import (
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+)
+
+// row is a node as laid out on screen
+type row struct {
+	node   *Node
+	guides []bool // Per ancestor level, whether a sibling follows further down
+	last   bool
+}
+
+// Model represents the call tree component
+type Model struct {
+	roots     []*Node
+	expanded  map[string]bool // Toggled nodes by ID, survives reloads of the records
+	focusedID string
+	viewport  viewport.Model
+	width     int
+	height    int
+
+	// Styling
+	styles Styles
+}
+
+// New creates a new call tree model
+func New(width, height int) Model {
+	vp := viewport.New(width, height)
+	vp.SetContent("Waiting for activity...")
+
+	return Model{
+		expanded: make(map[string]bool),
+		viewport: vp,
+		width:    width,
+		height:   height,
+		styles:   DefaultStyles(),
+	}
+}
+
+// Init initializes the model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		rows := m.rows()
+		idx := m.focusedIndex(rows)
+		switch msg.String() {
+		case "j", "down":
+			if idx < len(rows)-1 {
+				m.focus(rows, idx+1)
+			}
+		case "k", "up":
+			if idx > 0 {
+				m.focus(rows, idx-1)
+			}
+		case "g", "home":
+			m.focus(rows, 0)
+		case "G", "end":
+			m.focus(rows, len(rows)-1)
+		case "enter", " ":
+			if idx < len(rows) && len(rows[idx].node.Children) > 0 {
+				n := rows[idx].node
+				m.expanded[n.ID] = !m.isExpanded(n)
+				m.refreshContent()
+			}
+		case "l", "right":
+			if idx < len(rows) && len(rows[idx].node.Children) > 0 {
+				n := rows[idx].node
+				if m.isExpanded(n) {
+					m.focus(rows, idx+1)
+				} else {
+					m.expanded[n.ID] = true
+					m.refreshContent()
+				}
+			}
+		case "h", "left":
+			if idx < len(rows) {
+				n := rows[idx].node
+				if len(n.Children) > 0 && m.isExpanded(n) {
+					m.expanded[n.ID] = false
+					m.refreshContent()
+				} else if n.Parent() != nil {
+					m.focusedID = n.Parent().ID
+					m.refreshContent()
+				}
+			}
+		}
+		// Navigation keys are handled above, not by the viewport
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// SetRecords rebuilds the tree from the session's records. Expanded nodes stay expanded, and
+// focus stays on the same node, or on the last one when it was there, so a live tree follows
+// new activity.
+func (m Model) SetRecords(records []*models.AIActivityRecord) Model {
+	rows := m.rows()
+	following := len(rows) == 0 || m.focusedIndex(rows) == len(rows)-1
+
+	m.roots = Build(records)
+
+	rows = m.rows()
+	if following || m.indexOf(rows, m.focusedID) < 0 {
+		m.focusedID = ""
+		switch {
+		case len(rows) == 0:
+		case following:
+			m.focusedID = rows[len(rows)-1].node.ID
+		default:
+			m.focusedID = rows[0].node.ID
+		}
+	}
+	m.refreshContent()
+	return m
+}
+
+// Roots returns the top-level nodes of the tree
+func (m Model) Roots() []*Node {
+	return m.roots
+}
+
+// Focused returns the focused node, nil when the tree is empty
+func (m Model) Focused() *Node {
+	rows := m.rows()
+	if idx := m.indexOf(rows, m.focusedID); idx >= 0 {
+		return rows[idx].node
+	}
+	return nil
+}
+
+// SetSize updates component dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.viewport.Width = width
+	m.viewport.Height = height
+	m.refreshContent()
+}
+
+// isExpanded reports whether a node's children are shown. Until toggled, sessions and
+// subagents are open and tool calls closed, except calls that spawned a subagent.
+func (m Model) isExpanded(n *Node) bool {
+	if expanded, ok := m.expanded[n.ID]; ok {
+		return expanded
+	}
+	switch n.Kind {
+	case KindSession, KindSubagent:
+		return true
+	}
+	for _, child := range n.Children {
+		if child.Kind == KindSubagent {
+			return true
+		}
+	}
+	return false
+}
+
+// rows lays out the visible nodes, depth first
+func (m Model) rows() []row {
+	var rows []row
+	var walk func(nodes []*Node, guides []bool)
+	walk = func(nodes []*Node, guides []bool) {
+		for i, n := range nodes {
+			last := i == len(nodes)-1
+			rows = append(rows, row{node: n, guides: guides, last: last})
+			if len(n.Children) > 0 && m.isExpanded(n) {
+				childGuides := append(append([]bool(nil), guides...), !last)
+				walk(n.Children, childGuides)
+			}
+		}
+	}
+	walk(m.roots, nil)
+	return rows
+}
+
+func (m Model) indexOf(rows []row, id string) int {
+	for i, r := range rows {
+		if r.node.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// focusedIndex returns the focused row, the first one when the focused node is hidden
+func (m Model) focusedIndex(rows []row) int {
+	if idx := m.indexOf(rows, m.focusedID); idx >= 0 {
+		return idx
+	}
+	return 0
+}
+
+func (m *Model) focus(rows []row, idx int) {
+	if idx < 0 || idx >= len(rows) {
+		return
+	}
+	m.focusedID = rows[idx].node.ID
+	m.refreshContent()
+}
+
+// refreshContent renders the tree into the viewport and scrolls the focused row into view
+func (m *Model) refreshContent() {
+	rows := m.rows()
+	content := m.renderRows(rows)
+	if content == "" {
+		content = "Waiting for activity..."
+	}
+	m.viewport.SetContent(content)
+
+	idx := m.focusedIndex(rows)
+	switch {
+	case idx < m.viewport.YOffset:
+		m.viewport.SetYOffset(idx)
+	case m.viewport.Height > 0 && idx >= m.viewport.YOffset+m.viewport.Height:
+		m.viewport.SetYOffset(idx - m.viewport.Height + 1)
+	}
+}
+
+// RenderContent returns just the rendered tree without viewport wrapping.
+// Use this when embedding in another component that has its own viewport.
+func (m Model) RenderContent() string {
+	return m.renderRows(m.rows())
+}
@@ -0,0 +1,223 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package calltree
+
+import (
+	"strings"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+)
+
+// Kind tells what a tree node stands for
+type Kind int
+
+const (
+	KindEvent    Kind = iota // Any record that is not a tool call or its result
+	KindToolUse              // A tool call; its results are its children
+	KindResult               // A tool result or a blocked call
+	KindSession              // A top-level session, only used when there are several
+	KindSubagent             // A subagent's activity, nested under the call that spawned it
+)
+
+// Node is one line of the call tree
+type Node struct {
+	ID       string
+	Kind     Kind
+	Record   *models.AIActivityRecord // nil for session and subagent nodes
+	Label    string                   // Session or agent ID for session and subagent nodes
+	Children []*Node
+	parent   *Node
+}
+
+// Parent returns the node this one is nested under, nil for roots
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Result returns the latest result of a tool call, nil while it is still running
+func (n *Node) Result() *models.AIActivityRecord {
+	for i := len(n.Children) - 1; i >= 0; i-- {
+		if n.Children[i].Kind == KindResult {
+			return n.Children[i].Record
+		}
+	}
+	return nil
+}
+
+// spawnerTools are the tools whose calls start a subagent
+var spawnerTools = map[string]bool{"task": true, "agent": true}
+
+// thread collects the nodes of one session or one subagent
+type thread struct {
+	node      *Node
+	parentKey string
+	items     []*Node
+	spawners  []*Node
+	firstSeen int
+}
+
+// Build turns activity records, in the order they happened, into a call tree. Results are
+// nested under the call with the same ToolUseID, and a subagent's activity under the latest
+// Task or Agent call of its parent session. Records without correlation fields stay at the
+// level they were recorded at, so uncorrelated activity comes out as a flat list.
+// Streamed output deltas are left out; the final result carries the outcome.
+func Build(records []*models.AIActivityRecord) []*Node {
+	threads := make(map[string]*thread)
+	var order []string
+	calls := make(map[string]*Node)
+
+	for i, r := range records {
+		if r == nil || r.EventType == models.AIEventToolResultDelta {
+			continue
+		}
+
+		key, parentKey := threadKeys(r)
+		t, ok := threads[key]
+		if !ok {
+			t = &thread{parentKey: parentKey, firstSeen: i}
+			threads[key] = t
+			order = append(order, key)
+		}
+		if t.parentKey == "" {
+			t.parentKey = parentKey
+		}
+
+		node := &Node{ID: r.EventID, Kind: KindEvent, Record: r}
+		switch r.EventType {
+		case models.AIEventToolUse:
+			node.Kind = KindToolUse
+			if r.ToolUseID != "" {
+				calls[r.ToolUseID] = node
+			}
+			if spawnerTools[strings.ToLower(r.ToolName)] {
+				t.spawners = append(t.spawners, node)
+			}
+		case models.AIEventToolResult, models.AIEventToolBlocked:
+			node.Kind = KindResult
+			if call, ok := calls[r.ToolUseID]; ok {
+				call.Children = append(call.Children, node)
+				continue
+			}
+		}
+		t.items = append(t.items, node)
+	}
+
+	var roots []string
+	for _, key := range order {
+		t := threads[key]
+		parent, ok := threads[t.parentKey]
+		if !ok || t.parentKey == key || descendsFrom(threads, t.parentKey, key) {
+			roots = append(roots, key)
+			continue
+		}
+
+		t.node = &Node{ID: key, Kind: KindSubagent, Label: agentLabel(records[t.firstSeen])}
+		if spawner := latestSpawner(parent.spawners, records[t.firstSeen]); spawner != nil {
+			spawner.Children = insertAt(spawner.Children, t.node, records[t.firstSeen])
+		} else {
+			parent.items = insertAt(parent.items, t.node, records[t.firstSeen])
+		}
+	}
+
+	for _, t := range threads {
+		if t.node != nil {
+			t.node.Children = t.items
+		}
+	}
+
+	var result []*Node
+	if len(roots) == 1 {
+		result = threads[roots[0]].items
+	} else {
+		for _, key := range roots {
+			t := threads[key]
+			session := &Node{
+				ID:       key,
+				Kind:     KindSession,
+				Label:    agentLabel(records[t.firstSeen]),
+				Children: t.items,
+			}
+			result = append(result, session)
+		}
+	}
+	linkParents(nil, result)
+	return result
+}
+
+// threadKeys returns the key of the session or subagent a record belongs to, and the key
+// of the session it was spawned from. Subagents can share their parent's session ID, so they
+// are told apart by agent ID.
+func threadKeys(r *models.AIActivityRecord) (key, parentKey string) {
+	sidechain := r.IsSidechain != nil && *r.IsSidechain
+	if r.ParentSessionID == "" && !sidechain {
+		return "session:" + r.SessionID, ""
+	}
+	if r.AgentID != "" {
+		key = "agent:" + r.AgentID
+	} else {
+		key = "session:" + r.SessionID
+	}
+	if r.ParentSessionID != "" {
+		parentKey = "session:" + r.ParentSessionID
+	}
+	return key, parentKey
+}
+
+// descendsFrom reports whether the thread at key is nested, directly or not, under ancestor
+func descendsFrom(threads map[string]*thread, key, ancestor string) bool {
+	seen := make(map[string]bool)
+	for key != "" && !seen[key] {
+		if key == ancestor {
+			return true
+		}
+		seen[key] = true
+		t, ok := threads[key]
+		if !ok {
+			return false
+		}
+		key = t.parentKey
+	}
+	return false
+}
+
+// latestSpawner returns the last Task or Agent call made before the subagent's first record
+func latestSpawner(spawners []*Node, first *models.AIActivityRecord) *Node {
+	for i := len(spawners) - 1; i >= 0; i-- {
+		if first.Timestamp.IsZero() || !spawners[i].Record.Timestamp.After(first.Timestamp) {
+			return spawners[i]
+		}
+	}
+	return nil
+}
+
+// insertAt inserts node before the first item recorded after first
+func insertAt(items []*Node, node *Node, first *models.AIActivityRecord) []*Node {
+	idx := len(items)
+	if !first.Timestamp.IsZero() {
+		for i, item := range items {
+			if item.Record != nil && item.Record.Timestamp.After(first.Timestamp) {
+				idx = i
+				break
+			}
+		}
+	}
+	items = append(items, nil)
+	copy(items[idx+1:], items[idx:])
+	items[idx] = node
+	return items
+}
+
+func agentLabel(r *models.AIActivityRecord) string {
+	if r.AgentID != "" {
+		return r.AgentID
+	}
+	return r.SessionID
+}
+
+func linkParents(parent *Node, nodes []*Node) {
+	for _, n := range nodes {
+		n.parent = parent
+		linkParents(n, n.Children)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package calltree
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+var base = time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+
+func record(id string, eventType models.AIEventType, second int) *models.AIActivityRecord {
+	return &models.AIActivityRecord{
+		EventID:   id,
+		SessionID: "main",
+		EventType: eventType,
+		Timestamp: base.Add(time.Duration(second) * time.Second),
+	}
+}
+
+func toolUse(id, tool, toolUseID string, second int) *models.AIActivityRecord {
+	r := record(id, models.AIEventToolUse, second)
+	r.ToolName = tool
+	r.ToolUseID = toolUseID
+	return r
+}
+
+func toolResult(id, tool, toolUseID string, second int) *models.AIActivityRecord {
+	r := record(id, models.AIEventToolResult, second)
+	r.ToolName = tool
+	r.ToolUseID = toolUseID
+	r.ToolSuccess = boolPtr(true)
+	return r
+}
+
+func subagent(r *models.AIActivityRecord, agentID string) *models.AIActivityRecord {
+	r.AgentID = agentID
+	r.ParentSessionID = "main"
+	r.IsSidechain = boolPtr(true)
+	return r
+}
+
+// ids lists the IDs of nodes, with children in brackets
+func ids(nodes []*Node) string {
+	var parts []string
+	for _, n := range nodes {
+		part := n.ID
+		if len(n.Children) > 0 {
+			part += "[" + ids(n.Children) + "]"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestBuild_NestsResultsUnderTheirCall(t *testing.T) {
+	records := []*models.AIActivityRecord{
+		record("think", models.AIEventThinking, 0),
+		toolUse("read-a", "Read", "toolu_a", 1),
+		toolUse("read-b", "Read", "toolu_b", 2),
+		// Parallel calls complete out of order
+		toolResult("result-b", "Read", "toolu_b", 3),
+		toolResult("result-a", "Read", "toolu_a", 4),
+		record("out", models.AIEventAIOutput, 5),
+	}
+
+	roots := Build(records)
+
+	if got, want := ids(roots), "think read-a[result-a] read-b[result-b] out"; got != want {
+		t.Fatalf("tree = %q, want %q", got, want)
+	}
+	if roots[1].Result() != records[4] {
+		t.Errorf("Result() of read-a = %v, want result-a", roots[1].Result())
+	}
+	if roots[1].Children[0].Parent() != roots[1] {
+		t.Error("result-a is not linked to its call")
+	}
+}
+
+func TestBuild_NestsSubagentUnderSpawningCall(t *testing.T) {
+	records := []*models.AIActivityRecord{
+		toolUse("task-1", "Task", "toolu_task", 0),
+		subagent(toolUse("agent-grep", "Grep", "toolu_grep", 1), "agent-1"),
+		subagent(toolResult("agent-grep-result", "Grep", "toolu_grep", 2), "agent-1"),
+		subagent(record("agent-out", models.AIEventAIOutput, 3), "agent-1"),
+		toolResult("task-1-result", "Task", "toolu_task", 4),
+		toolUse("bash", "Bash", "toolu_bash", 5),
+	}
+
+	roots := Build(records)
+
+	want := "task-1[agent:agent-1[agent-grep[agent-grep-result] agent-out] task-1-result] bash"
+	if got := ids(roots); got != want {
+		t.Fatalf("tree = %q, want %q", got, want)
+	}
+	agent := roots[0].Children[0]
+	if agent.Kind != KindSubagent || agent.Label != "agent-1" {
+		t.Errorf("subagent node = %+v", agent)
+	}
+	if roots[0].Result() != records[4] {
+		t.Error("the subagent hides the Task call's result")
+	}
+}
+
+func TestBuild_SubagentWithoutSpawnerGoesInTimeOrder(t *testing.T) {
+	records := []*models.AIActivityRecord{
+		record("prompt", models.AIEventUserPrompt, 0),
+		record("out", models.AIEventAIOutput, 5),
+		subagent(record("agent-think", models.AIEventThinking, 2), "agent-1"),
+	}
+
+	if got, want := ids(Build(records)), "prompt agent:agent-1[agent-think] out"; got != want {
+		t.Errorf("tree = %q, want %q", got, want)
+	}
+}
+
+func TestBuild_FlatWithoutCorrelation(t *testing.T) {
+	records := []*models.AIActivityRecord{
+		toolUse("read", "Read", "", 0),
+		toolResult("read-result", "Read", "", 1),
+		record("delta", models.AIEventToolResultDelta, 2),
+		toolUse("bash", "Bash", "", 3),
+	}
+
+	if got, want := ids(Build(records)), "read read-result bash"; got != want {
+		t.Errorf("tree = %q, want %q", got, want)
+	}
+}
+
+func TestBuild_SeparatesTopLevelSessions(t *testing.T) {
+	other := record("other-out", models.AIEventAIOutput, 1)
+	other.SessionID = "second"
+	// The parent session is not among the records
+	orphan := subagent(record("orphan", models.AIEventThinking, 2), "agent-9")
+	orphan.ParentSessionID = "gone"
+
+	records := []*models.AIActivityRecord{record("main-out", models.AIEventAIOutput, 0), other, orphan}
+
+	want := "session:main[main-out] session:second[other-out] agent:agent-9[orphan]"
+	if got := ids(Build(records)); got != want {
+		t.Errorf("tree = %q, want %q", got, want)
+	}
+}
+
+func keys(m Model, keys ...string) Model {
+	for _, k := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "left":
+			msg = tea.KeyMsg{Type: tea.KeyLeft}
+		}
+		m, _ = m.Update(msg)
+	}
+	return m
+}
+
+func TestModel_Navigation(t *testing.T) {
+	records := []*models.AIActivityRecord{
+		toolUse("read", "Read", "toolu_read", 0),
+		toolResult("read-result", "Read", "toolu_read", 1),
+		toolUse("task", "Task", "toolu_task", 2),
+		subagent(toolUse("agent-bash", "Bash", "toolu_bash", 3), "agent-1"),
+	}
+
+	m := New(80, 3).SetRecords(records)
+
+	// Live trees follow the newest activity; Task opens to show its subagent
+	if got := m.Focused().ID; got != "agent-bash" {
+		t.Fatalf("focused = %q, want agent-bash", got)
+	}
+
+	m = keys(m, "g", "enter")
+	if got := len(m.rows()); got != 5 {
+		t.Fatalf("rows after expanding read = %d, want 5", got)
+	}
+	m = keys(m, "j")
+	if got := m.Focused().ID; got != "read-result" {
+		t.Errorf("focused = %q, want read-result", got)
+	}
+
+	// Left on a leaf goes to its parent, then collapses it
+	m = keys(m, "left")
+	if got := m.Focused().ID; got != "read" {
+		t.Errorf("focused = %q, want read", got)
+	}
+	m = keys(m, "left")
+	if got := len(m.rows()); got != 4 {
+		t.Errorf("rows after collapsing read = %d, want 4", got)
+	}
+
+	// Toggled state and focus survive new records
+	m = keys(m, "G", "k", "k", "enter")
+	records = append(records, subagent(toolResult("agent-bash-result", "Bash", "toolu_bash", 4), "agent-1"))
+	m = m.SetRecords(records)
+	if got := m.Focused().ID; got != "task" {
+		t.Errorf("focused after reload = %q, want task", got)
+	}
+	if got := len(m.rows()); got != 2 {
+		t.Errorf("rows after reload = %d, want 2", got)
+	}
+
+	// The focused row is scrolled into view
+	m = keys(m, "l", "j", "j", "l", "j")
+	if got := m.Focused().ID; got != "agent-bash-result" {
+		t.Fatalf("focused = %q, want agent-bash-result", got)
+	}
+	if m.viewport.YOffset == 0 {
+		t.Error("viewport did not scroll to the focused row")
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package calltree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/tui/toolstyle"
+)
+
+// maxPreviewRunes bounds the inline preview of a record's content
+const maxPreviewRunes = 80
+
+// Styles holds all the styling for the component
+type Styles struct {
+	Pending  lipgloss.Style
+	Success  lipgloss.Style
+	Failed   lipgloss.Style
+	Thinking lipgloss.Style
+	Output   lipgloss.Style
+	Subagent lipgloss.Style
+	FilePath lipgloss.Style
+	Guide    lipgloss.Style
+	Dim      lipgloss.Style
+	Focused  lipgloss.Style
+}
+
+// DefaultStyles returns the default color scheme
+func DefaultStyles() Styles {
+	return Styles{
+		Pending:  lipgloss.NewStyle().Foreground(lipgloss.Color("75")),
+		Success:  lipgloss.NewStyle().Foreground(lipgloss.Color("35")),
+		Failed:   lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		Thinking: lipgloss.NewStyle().Foreground(lipgloss.Color("141")),
+		Output:   lipgloss.NewStyle().Foreground(lipgloss.Color("252")),
+		Subagent: lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true),
+		FilePath: lipgloss.NewStyle().Foreground(lipgloss.Color("241")),
+		Guide:    lipgloss.NewStyle().Foreground(lipgloss.Color("239")),
+		Dim:      lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
+		Focused:  lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true),
+	}
+}
+
+// View renders the component
+func (m Model) View() string {
+	return m.viewport.View()
+}
+
+// renderRows renders one line per visible node
+func (m Model) renderRows(rows []row) string {
+	if len(rows) == 0 {
+		return ""
+	}
+
+	var lines []string
+	for _, r := range rows {
+		lines = append(lines, m.renderRow(r))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) renderRow(r row) string {
+	var b strings.Builder
+	if r.node.ID == m.focusedID {
+		b.WriteString(m.styles.Focused.Render("›") + " ")
+	} else {
+		b.WriteString("  ")
+	}
+
+	// Roots carry no connector, so the top level needs no guide column
+	var guide strings.Builder
+	if len(r.guides) > 0 {
+		for _, more := range r.guides[1:] {
+			if more {
+				guide.WriteString("│  ")
+			} else {
+				guide.WriteString("   ")
+			}
+		}
+		if r.last {
+			guide.WriteString("└─ ")
+		} else {
+			guide.WriteString("├─ ")
+		}
+	}
+	b.WriteString(m.styles.Guide.Render(guide.String()))
+
+	switch {
+	case len(r.node.Children) == 0:
+		b.WriteString("  ")
+	case m.isExpanded(r.node):
+		b.WriteString(m.styles.Dim.Render("▾") + " ")
+	default:
+		b.WriteString(m.styles.Dim.Render("▸") + " ")
+	}
+
+	b.WriteString(m.renderNode(r.node))
+	return b.String()
+}
+
+// renderNode renders the label of a node
+func (m Model) renderNode(n *Node) string {
+	switch n.Kind {
+	case KindSession:
+		return m.styles.Dim.Render("session " + shortID(n.Label))
+	case KindSubagent:
+		return fmt.Sprintf("%s %s", m.styles.Subagent.Render("↳ subagent "+shortID(n.Label)),
+			m.styles.Dim.Render(fmt.Sprintf("(%d)", len(n.Children))))
+	case KindToolUse:
+		return m.renderToolUse(n)
+	case KindResult:
+		return m.renderResult(n.Record)
+	}
+
+	r := n.Record
+	switch r.EventType {
+	case models.AIEventThinking:
+		return fmt.Sprintf("%s %s", m.styles.Thinking.Render("◦"), m.styles.Thinking.Render(preview(r.ContentPreview)))
+	case models.AIEventAIOutput:
+		return m.styles.Output.Render(preview(r.ContentPreview))
+	case models.AIEventError:
+		return fmt.Sprintf("%s %s", m.styles.Failed.Render("!"), m.styles.Failed.Render(preview(r.ContentPreview)))
+	default:
+		return m.styles.Dim.Render(strings.TrimSpace(string(r.EventType) + " " + preview(r.ContentPreview)))
+	}
+}
+
+// renderToolUse renders a call with the outcome of its latest result
+func (m Model) renderToolUse(n *Node) string {
+	r := n.Record
+	line := fmt.Sprintf("%s %s", toolstyle.Icon(r.ToolName), toolstyle.Name(r.ToolName))
+	if r.FilePath != "" {
+		line += m.styles.FilePath.Render(" " + r.FilePath)
+	} else if r.ToolInputSummary != "" {
+		line += m.styles.Dim.Render(" " + preview(r.ToolInputSummary))
+	}
+
+	result := n.Result()
+	switch {
+	case result == nil:
+		line += " " + m.styles.Pending.Render("⋯")
+	case result.EventType == models.AIEventToolBlocked:
+		line += " " + m.styles.Failed.Render("⊘")
+	case result.ToolSuccess != nil && !*result.ToolSuccess:
+		line += " " + m.styles.Failed.Render("✗")
+	default:
+		line += " " + m.styles.Success.Render("✓")
+	}
+	return line
+}
+
+func (m Model) renderResult(r *models.AIActivityRecord) string {
+	if r.EventType == models.AIEventToolBlocked {
+		return fmt.Sprintf("%s %s", m.styles.Failed.Render("⊘ blocked"), m.styles.Failed.Render(preview(r.ToolError)))
+	}
+	if r.ToolSuccess != nil && !*r.ToolSuccess {
+		return fmt.Sprintf("%s %s", m.styles.Failed.Render("✗"), m.styles.Failed.Render(preview(r.ToolError)))
+	}
+	line := m.styles.Success.Render("✓")
+	if r.ToolName != "" && r.ToolUseID == "" {
+		// Uncorrelated results sit next to the calls, so name the tool
+		line += " " + toolstyle.Name(r.ToolName)
+	}
+	if p := preview(r.ContentPreview); p != "" {
+		line += " " + m.styles.Dim.Render(p)
+	}
+	return line
+}
+
+// preview flattens content to one line of at most maxPreviewRunes
+func preview(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if runes := []rune(s); len(runes) > maxPreviewRunes {
+		return string(runes[:maxPreviewRunes-1]) + "…"
+	}
+	return s
+}
+
+// shortID keeps session and agent IDs to a readable length
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/noldarim/noldarim/internal/aiobs/types"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 )
 
@@ -261,6 +262,9 @@ func parseToolResult(record *models.AIActivityRecord, toolName string) ToolResul
 		Success: record.ToolSuccess != nil && *record.ToolSuccess,
 		Error:   record.ToolError,
 		Output:  record.ContentPreview,
+
+		ContentType:   types.ContentType(record.ResultContentType),
+		ContentLength: record.ContentLength,
 	}
 
 	// Parse tool-specific result data
@@ -6,6 +6,7 @@ package collapsiblefeed
 import (
 	"testing"
 
+	"github.com/noldarim/noldarim/internal/aiobs/types"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 )
 
@@ -125,6 +126,26 @@ func TestParseRecords_FailedGroup(t *testing.T) {
 	}
 }
 
+func TestParseRecords_ResultContentType(t *testing.T) {
+	records := []*models.AIActivityRecord{
+		{EventID: "1", EventType: models.AIEventToolUse, ToolName: "Bash"},
+		{EventID: "2", EventType: models.AIEventToolResult, ToolName: "Bash", ToolSuccess: boolPtr(true),
+			ContentPreview: "\x89PNG\x00", ContentLength: 2048, ResultContentType: "binary"},
+	}
+
+	groups := ParseRecords(records)
+
+	if len(groups) != 1 || groups[0].Result == nil {
+		t.Fatalf("expected 1 completed group, got %+v", groups)
+	}
+	if groups[0].Result.ContentType != types.ContentTypeBinary {
+		t.Errorf("expected binary content type, got %q", groups[0].Result.ContentType)
+	}
+	if summary := (Model{}).getResultSummary(groups[0]); summary != "[binary, 2048 bytes]" {
+		t.Errorf("expected binary summary, got %q", summary)
+	}
+}
+
 func TestParseRecords_TodoWrite(t *testing.T) {
 	records := []*models.AIActivityRecord{
 		{
@@ -3,6 +3,8 @@
 
 package collapsiblefeed
 
+import "github.com/noldarim/noldarim/internal/aiobs/types"
+
 // GroupState represents the state of an activity group
 type GroupState int
 
@@ -51,6 +53,9 @@ type ToolResult struct {
 	Error   string
 	Output  string // Result preview/summary
 
+	ContentType   types.ContentType // How Output is rendered when expanded; empty means text
+	ContentLength int               // Full output length, which Output may be truncated from
+
 	// Tool-specific result data
 	LineCount int // For Read
 	TodoCount int // For TodoWrite
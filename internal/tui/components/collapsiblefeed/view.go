@@ -4,10 +4,14 @@
 package collapsiblefeed
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/noldarim/noldarim/internal/aiobs/types"
 )
 
 // Styles holds all the styling for the component
@@ -178,6 +182,9 @@ func (m Model) getResultSummary(g ActivityGroup) string {
 	if !g.Result.Success {
 		return truncate(g.Result.Error, 60)
 	}
+	if g.Result.ContentType == types.ContentTypeBinary {
+		return binaryLabel(g.Result)
+	}
 
 	switch g.ToolName {
 	case "Read":
@@ -222,7 +229,7 @@ func (m Model) renderExpanded(g ActivityGroup) string {
 
 	// Show full result output
 	if g.Result != nil && g.Result.Output != "" {
-		b.WriteString(s.Render("Output: " + g.Result.Output + "\n"))
+		b.WriteString(m.renderOutput(g.Result))
 	}
 
 	// For TodoWrite, show the todo list
@@ -243,6 +250,60 @@ func (m Model) renderExpanded(g ActivityGroup) string {
 	return b.String()
 }
 
+// renderOutput renders result output by content type: JSON is pretty-printed, diff
+// lines are colored, and binary data is replaced by its size
+func (m Model) renderOutput(r *ToolResult) string {
+	s := m.styles.Expanded
+
+	switch r.ContentType {
+	case types.ContentTypeBinary:
+		return s.Render("Output: "+binaryLabel(r)) + "\n"
+
+	case types.ContentTypeJSON:
+		var pretty bytes.Buffer
+		// A truncated preview no longer parses; show it as-is
+		if err := json.Indent(&pretty, []byte(r.Output), "", "  "); err == nil {
+			return s.Render("Output:\n"+pretty.String()) + "\n"
+		}
+
+	case types.ContentTypeDiff:
+		var b strings.Builder
+		b.WriteString(s.Render("Output:") + "\n")
+		for _, line := range strings.Split(strings.TrimRight(r.Output, "\n"), "\n") {
+			b.WriteString(m.diffLineStyle(line).Render(line) + "\n")
+		}
+		return b.String()
+	}
+
+	return s.Render("Output: "+r.Output) + "\n"
+}
+
+// diffLineStyle colors added, removed and hunk header lines of a diff
+func (m Model) diffLineStyle(line string) lipgloss.Style {
+	s := m.styles.Expanded
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"), strings.HasPrefix(line, "diff --git"):
+		return s.Bold(true)
+	case strings.HasPrefix(line, "+"):
+		return s.Foreground(m.styles.Success.GetForeground())
+	case strings.HasPrefix(line, "-"):
+		return s.Foreground(m.styles.Failed.GetForeground())
+	case strings.HasPrefix(line, "@@"):
+		return s.Foreground(m.styles.Pending.GetForeground())
+	default:
+		return s
+	}
+}
+
+// binaryLabel describes binary output by its size
+func binaryLabel(r *ToolResult) string {
+	size := r.ContentLength
+	if size == 0 {
+		size = len(r.Output)
+	}
+	return fmt.Sprintf("[binary, %d bytes]", size)
+}
+
 // renderTodoPanel renders the todo progress sidebar
 func (m Model) renderTodoPanel() string {
 	if len(m.currentTodos) == 0 {
@@ -154,6 +154,20 @@ func (cell *Cell) setType(cellType cellType) *Cell {
 	return cell
 }
 
+// rotated returns the cell as drawn in a horizontal graph: the newer commit (up)
+// is to the right, the older one (down) to the left, and neighbouring lanes are
+// above and below
+func (cell *Cell) rotated() *Cell {
+	return &Cell{
+		up:       cell.left,
+		down:     cell.right,
+		left:     cell.down,
+		right:    cell.up,
+		cellType: cell.cellType,
+		style:    cell.style,
+	}
+}
+
 // getBoxDrawingChars returns the appropriate box drawing characters for the given connections
 func getBoxDrawingChars(up, down, left, right bool, symbols GraphSymbols) (string, string) {
 	if up && down && left && right {
@@ -0,0 +1,49 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commitgraph
+
+import "github.com/charmbracelet/lipgloss"
+
+// BranchPalette holds the colors AssignBranchColors hands out, in order
+var BranchPalette = []lipgloss.Color{
+	"1",  // Red
+	"2",  // Green
+	"3",  // Yellow
+	"4",  // Blue
+	"5",  // Magenta
+	"6",  // Cyan
+	"9",  // Bright Red
+	"10", // Bright Green
+	"11", // Bright Yellow
+	"12", // Bright Blue
+	"13", // Bright Magenta
+	"14", // Bright Cyan
+}
+
+// AssignBranchColors colors commits by branch lineage, keyed by hash pointer. Commits
+// are ordered newest first, as for RenderCommitGraph.
+//
+// Colors are handed out from the oldest commit up: a commit continues its first
+// parent's color unless an older child already did, otherwise it starts a new lineage
+// with the next palette color. Graph lanes are not used because they shift when
+// commits are prepended; this way prepending commits never recolors existing ones.
+func AssignBranchColors(commits []*Commit) map[*string]lipgloss.Color {
+	colors := make(map[*string]lipgloss.Color, len(commits))
+	continued := make(map[*string]bool) // Commits whose lineage a child already continues
+	next := 0
+
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		if parents := commit.ParentPtrs(); len(parents) > 0 {
+			if color, ok := colors[parents[0]]; ok && !continued[parents[0]] {
+				continued[parents[0]] = true
+				colors[commit.HashPtr()] = color
+				continue
+			}
+		}
+		colors[commit.HashPtr()] = BranchPalette[next%len(BranchPalette)]
+		next++
+	}
+	return colors
+}
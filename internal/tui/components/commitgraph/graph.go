@@ -101,6 +101,49 @@ func RenderCommitGraph(commits []*Commit, selectedCommitHashPtr *string, getStyl
 	return lines
 }
 
+// RenderCommitGraphHorizontal renders the commit graph with time flowing left to right:
+// one line per lane instead of one per commit, the oldest commit in the leftmost column.
+// Columns are two characters wide, so column i from the right belongs to commits[i].
+func RenderCommitGraphHorizontal(commits []*Commit, selectedCommitHashPtr *string, getStyle func(c *Commit) *lipgloss.Style) []string {
+	pipeSets := GetPipeSets(commits, getStyle)
+	if len(pipeSets) == 0 {
+		return nil
+	}
+
+	// Lay out every commit's cells as in the vertical graph, newest first
+	columns := make([][]*Cell, len(pipeSets))
+	lanes := 0
+	for i, pipeSet := range pipeSets {
+		var prevCommit *Commit
+		if i > 0 {
+			prevCommit = commits[i-1]
+		}
+		columns[i] = pipeSetCells(pipeSet, selectedCommitHashPtr, prevCommit)
+		lanes = max(lanes, len(columns[i]))
+	}
+
+	// Rotate: lanes become lines and the newest commit the rightmost column
+	symbols := DefaultSymbols()
+	lines := make([]string, lanes)
+	for lane := range lines {
+		writer := &strings.Builder{}
+		writer.Grow(len(columns) * 2)
+		for i := len(columns) - 1; i >= 0; i-- {
+			cell := NewCell()
+			if lane < len(columns[i]) {
+				cell = columns[i][lane].rotated()
+			}
+			if i == 0 {
+				// Nothing is newer than the first commit (its pipe comes from START)
+				cell.right = false
+			}
+			cell.render(writer, symbols)
+		}
+		lines[lane] = writer.String()
+	}
+	return lines
+}
+
 // buildCommitChildrenMap builds a map of commit hash to its children commits
 func buildCommitChildrenMap(commits []*Commit) map[*string][]*Commit {
 	childrenMap := make(map[*string][]*Commit)
@@ -439,6 +482,20 @@ func getNextAvailablePosNear(taken map[int]bool, preferred int16, allowLeft bool
 
 // renderPipeSet renders a single line of the commit graph
 func renderPipeSet(pipes []Pipe, selectedCommitHashPtr *string, prevCommit *Commit) string {
+	cells := pipeSetCells(pipes, selectedCommitHashPtr, prevCommit)
+	symbols := DefaultSymbols()
+
+	// Build final string
+	writer := &strings.Builder{}
+	writer.Grow(len(cells) * 2)
+	for _, cell := range cells {
+		cell.render(writer, symbols)
+	}
+	return writer.String()
+}
+
+// pipeSetCells lays out one line of the commit graph as cells, one per position
+func pipeSetCells(pipes []Pipe, selectedCommitHashPtr *string, prevCommit *Commit) []*Cell {
 	maxPos := int16(0)
 	commitPos := int16(0)
 	startCount := 0
@@ -457,7 +514,6 @@ func renderPipeSet(pipes []Pipe, selectedCommitHashPtr *string, prevCommit *Comm
 	}
 
 	isMerge := startCount > 1
-	symbols := DefaultSymbols()
 
 	// Create cells for the line
 	cells := make([]*Cell, int(maxPos)+1)
@@ -517,7 +573,12 @@ func renderPipeSet(pipes []Pipe, selectedCommitHashPtr *string, prevCommit *Comm
 	}
 
 	for _, pipe := range nonSelectedPipes {
-		if pipe.kind != PipeKindStarts && !(pipe.kind == PipeKindTerminates && pipe.fromPos == commitPos && pipe.toPos == commitPos) {
+		if pipe.kind == PipeKindTerminates && pipe.fromPos == commitPos && pipe.toPos == commitPos {
+			// Drawn by the commit symbol, but the horizontal graph still joins it to the newer commit
+			cells[commitPos].up = true
+			continue
+		}
+		if pipe.kind != PipeKindStarts {
 			renderPipe(&pipe, pipe.style, false)
 		}
 	}
@@ -542,13 +603,7 @@ func renderPipeSet(pipes []Pipe, selectedCommitHashPtr *string, prevCommit *Comm
 	}
 	cells[commitPos].setType(cType)
 
-	// Build final string
-	writer := &strings.Builder{}
-	writer.Grow(len(cells) * 2)
-	for _, cell := range cells {
-		cell.render(writer, symbols)
-	}
-	return writer.String()
+	return cells
 }
 
 // equalHashes compares hash pointers (like lazygit does for efficiency)
@@ -98,6 +98,96 @@ func TestRenderCommitGraph(t *testing.T) {
 	}
 }
 
+func TestRenderCommitGraphHorizontal(t *testing.T) {
+	tests := []struct {
+		name     string
+		commits  func(hashPool *StringPool) []*Commit
+		expected []string // One line per lane, oldest commit first
+	}{
+		{
+			name: "simple linear history",
+			commits: func(hashPool *StringPool) []*Commit {
+				return []*Commit{
+					NewCommit(hashPool, "1", "First commit", "Alice", []string{"2"}),
+					NewCommit(hashPool, "2", "Second commit", "Alice", []string{"3"}),
+					NewCommit(hashPool, "3", "Third commit", "Alice", []string{}),
+				}
+			},
+			expected: []string{"◯─◯─◯ "},
+		},
+		{
+			name: "single merge",
+			commits: func(hashPool *StringPool) []*Commit {
+				return []*Commit{
+					NewCommit(hashPool, "A", "Latest", "Alice", []string{"B"}),
+					NewCommit(hashPool, "B", "Feature merge", "Alice", []string{"C", "D"}),
+					NewCommit(hashPool, "C", "Main branch", "Alice", []string{"E"}),
+					NewCommit(hashPool, "D", "Feature branch", "Bob", []string{"E"}),
+					NewCommit(hashPool, "E", "Common base", "Alice", []string{}),
+				}
+			},
+			expected: []string{
+				"◯───◯─⏣─◯ ",
+				"╰─◯───╯   ",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			getStyle := func(c *Commit) *lipgloss.Style {
+				style := lipgloss.NewStyle()
+				return &style
+			}
+
+			hashPool := NewStringPool()
+			commits := test.commits(hashPool)
+			lines := RenderCommitGraphHorizontal(commits, hashPool.Add("nonexistent"), getStyle)
+
+			cleanActual := make([]string, len(lines))
+			for i, line := range lines {
+				cleanActual[i] = removeANSI(line)
+			}
+			assert.Equal(t, test.expected, cleanActual)
+		})
+	}
+
+	assert.Nil(t, RenderCommitGraphHorizontal(nil, nil, nil))
+}
+
+func TestAssignBranchColors(t *testing.T) {
+	hashPool := NewStringPool()
+	commits := []*Commit{
+		NewCommit(hashPool, "B", "Feature merge", "Alice", []string{"C", "D"}),
+		NewCommit(hashPool, "C", "Main branch", "Alice", []string{"E"}),
+		NewCommit(hashPool, "D", "Feature branch", "Bob", []string{"E"}),
+		NewCommit(hashPool, "E", "Common base", "Alice", []string{}),
+	}
+	hash := func(h string) *string { return hashPool.Add(h) }
+
+	colors := AssignBranchColors(commits)
+	assert.Len(t, colors, len(commits))
+	assert.Equal(t, BranchPalette[0], colors[hash("E")])
+	assert.Equal(t, BranchPalette[0], colors[hash("D")], "the oldest child continues the lineage")
+	assert.Equal(t, BranchPalette[1], colors[hash("C")], "a sibling starts a new lineage")
+	assert.Equal(t, BranchPalette[1], colors[hash("B")], "a merge continues its first parent")
+	assert.Equal(t, colors, AssignBranchColors(commits), "deterministic")
+
+	// Prepending commits keeps the existing colors
+	prepended := append([]*Commit{
+		NewCommit(hashPool, "F", "Off the base", "Carol", []string{"E"}),
+		NewCommit(hashPool, "A", "Latest", "Alice", []string{"B"}),
+	}, commits...)
+	updated := AssignBranchColors(prepended)
+	for _, commit := range commits {
+		assert.Equal(t, colors[commit.HashPtr()], updated[commit.HashPtr()], *commit.Hash)
+	}
+	assert.Equal(t, BranchPalette[1], updated[hash("A")])
+	assert.Equal(t, BranchPalette[2], updated[hash("F")])
+
+	assert.Empty(t, AssignBranchColors(nil))
+}
+
 func TestGetNextPipes(t *testing.T) {
 	hashPool := NewStringPool()
 
@@ -20,6 +20,11 @@ type Model struct {
 	elapsed   time.Duration
 	running   bool
 	style     lipgloss.Style
+
+	// Pausing freezes the running timer; time spent paused is left out of Elapsed
+	paused      bool
+	pausedAt    time.Time
+	pausedTotal time.Duration
 }
 
 // New creates a new elapsed timer model
@@ -33,6 +38,8 @@ func New() Model {
 func (m Model) Start() Model {
 	m.startTime = time.Now()
 	m.running = true
+	m.paused = false
+	m.pausedTotal = 0
 	return m
 }
 
@@ -40,17 +47,46 @@ func (m Model) Start() Model {
 func (m Model) StartFrom(t time.Time) Model {
 	m.startTime = t
 	m.running = true
+	m.paused = false
+	m.pausedTotal = 0
 	m.elapsed = time.Since(t)
 	return m
 }
 
 // Stop halts the timer
 func (m Model) Stop() Model {
-	m.elapsed = time.Since(m.startTime)
+	m.elapsed = m.Elapsed()
 	m.running = false
+	m.paused = false
 	return m
 }
 
+// Pause freezes a running timer at its current elapsed duration
+func (m Model) Pause() Model {
+	if !m.running || m.paused {
+		return m
+	}
+	m.pausedAt = time.Now()
+	m.paused = true
+	m.elapsed = m.Elapsed()
+	return m
+}
+
+// Resume continues a paused timer from the duration it was paused at
+func (m Model) Resume() Model {
+	if !m.paused {
+		return m
+	}
+	m.pausedTotal += time.Since(m.pausedAt)
+	m.paused = false
+	return m
+}
+
+// IsPaused returns whether the timer is paused
+func (m Model) IsPaused() bool {
+	return m.paused
+}
+
 // SetElapsed sets a specific elapsed duration (for display without ticking)
 func (m Model) SetElapsed(d time.Duration) Model {
 	m.elapsed = d
@@ -69,25 +105,33 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg.(type) {
 	case TickMsg:
 		if m.running {
-			m.elapsed = time.Since(m.startTime)
+			// Keep ticking while paused so Resume needs no command of its own
+			m.elapsed = m.Elapsed()
 			return m, tick()
 		}
 	}
 	return m, nil
 }
 
-// View renders: "⏱ 2m 34s"
+// View renders: "⏱ 2m 34s", or "⏸ 2m 34s" while paused
 func (m Model) View() string {
 	dim := m.style.Foreground(lipgloss.Color("239"))
 	accent := m.style.Foreground(lipgloss.Color("75"))
 
-	return dim.Render("⏱") + " " + accent.Render(formatDuration(m.Elapsed()))
+	icon := "⏱"
+	if m.paused {
+		icon = "⏸"
+	}
+	return dim.Render(icon) + " " + accent.Render(formatDuration(m.Elapsed()))
 }
 
-// Elapsed returns the current elapsed duration
+// Elapsed returns the current elapsed duration, not counting time spent paused
 func (m Model) Elapsed() time.Duration {
-	if m.running {
-		return time.Since(m.startTime)
+	switch {
+	case m.paused:
+		return m.pausedAt.Sub(m.startTime) - m.pausedTotal
+	case m.running:
+		return time.Since(m.startTime) - m.pausedTotal
 	}
 	return m.elapsed
 }
@@ -0,0 +1,81 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package elapsedtimer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pausedFor moves the start of the current pause back by d, as if it had lasted that long
+func pausedFor(m Model, d time.Duration) Model {
+	m.pausedAt = m.pausedAt.Add(-d)
+	return m
+}
+
+func TestModel_PauseResume(t *testing.T) {
+	tests := []struct {
+		name     string
+		timer    func(start time.Time) Model
+		expected time.Duration
+		paused   bool
+	}{
+		{
+			name:     "running",
+			timer:    func(start time.Time) Model { return New().StartFrom(start) },
+			expected: 10 * time.Minute,
+		},
+		{
+			name:     "paused freezes at the pause",
+			timer:    func(start time.Time) Model { return pausedFor(New().StartFrom(start).Pause(), 4*time.Minute) },
+			expected: 6 * time.Minute,
+			paused:   true,
+		},
+		{
+			name: "resumed leaves the pause out",
+			timer: func(start time.Time) Model {
+				return pausedFor(New().StartFrom(start).Pause(), 3*time.Minute).Resume()
+			},
+			expected: 7 * time.Minute,
+		},
+		{
+			name: "pauses add up",
+			timer: func(start time.Time) Model {
+				m := pausedFor(New().StartFrom(start).Pause(), 3*time.Minute).Resume()
+				return pausedFor(m.Pause(), 2*time.Minute).Resume()
+			},
+			expected: 5 * time.Minute,
+		},
+		{
+			name: "pausing twice keeps the first pause",
+			timer: func(start time.Time) Model {
+				return pausedFor(New().StartFrom(start).Pause(), 4*time.Minute).Pause()
+			},
+			expected: 6 * time.Minute,
+			paused:   true,
+		},
+		{
+			name: "stopping while paused keeps the paused duration",
+			timer: func(start time.Time) Model {
+				return pausedFor(New().StartFrom(start).Pause(), 4*time.Minute).Stop()
+			},
+			expected: 6 * time.Minute,
+		},
+		{
+			name:     "a stopped timer cannot be paused",
+			timer:    func(start time.Time) Model { return New().SetElapsed(time.Minute).Pause() },
+			expected: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.timer(time.Now().Add(-10 * time.Minute))
+			assert.Equal(t, tt.paused, m.IsPaused())
+			assert.InDelta(t, tt.expected, m.Elapsed(), float64(time.Second))
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package gitdiffviewer
+
+import "strings"
+
+// Conflict markers written by git into conflicted files
+const (
+	markerOurs   = "<<<<<<<"
+	markerBase   = "|||||||" // diff3 / zdiff3 conflict style only
+	markerSplit  = "======="
+	markerTheirs = ">>>>>>>"
+)
+
+// ConflictBlock is one conflicted region of a file, split into its sides
+type ConflictBlock struct {
+	File        string // Path of the conflicted file; empty when parsing bare file content
+	Line        int    // Zero-based line of the <<<<<<< marker in the input
+	OursLabel   string // Text after <<<<<<< (e.g. "HEAD")
+	TheirsLabel string // Text after >>>>>>> (e.g. the merged branch)
+	OurLines    []string
+	TheirLines  []string
+	BaseLines   []string // Common ancestor; nil unless the diff3 style was used
+}
+
+// conflictSection tracks which side of a conflict the parser is in
+type conflictSection int
+
+const (
+	sectionNone conflictSection = iota
+	sectionOurs
+	sectionBase
+	sectionTheirs
+)
+
+// ParseConflicts extracts the conflict blocks from a diff of conflicted files, or from
+// the content of a single conflicted file. Both the default two-way style and the diff3
+// style with a base section are recognised. Unterminated conflicts are dropped.
+func ParseConflicts(diff string) []ConflictBlock {
+	if diff == "" {
+		return nil
+	}
+
+	var (
+		blocks    []ConflictBlock
+		current   ConflictBlock
+		section   = sectionNone
+		file      string
+		maxPrefix int // Prefix columns of the current hunk; 0 outside any hunk
+		prefix    int // Prefix width of the conflict in progress
+	)
+
+	for i, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			_, file = parseDiffGitHeader(line)
+			section, maxPrefix = sectionNone, 0
+			continue
+		case strings.HasPrefix(line, "diff --cc "), strings.HasPrefix(line, "diff --combined "):
+			file = line[strings.LastIndex(line, " ")+1:]
+			section, maxPrefix = sectionNone, 0
+			continue
+		case strings.HasPrefix(line, "@@"):
+			// "@@" for a regular diff, "@@@" for a combined diff of a merge
+			maxPrefix = len(line) - len(strings.TrimLeft(line, "@")) - 1
+			continue
+		}
+
+		if section == sectionNone {
+			width := diffPrefixWidth(line, maxPrefix)
+			if label, ok := cutMarker(line[width:], markerOurs); ok {
+				current = ConflictBlock{File: file, Line: i, OursLabel: label}
+				section, prefix = sectionOurs, width
+			}
+			continue
+		}
+
+		width := diffPrefixWidth(line, prefix)
+		if strings.Contains(line[:width], "-") {
+			continue // Removed lines are not part of the conflicted file
+		}
+		content := line[width:]
+
+		switch {
+		case section == sectionOurs && isMarker(content, markerBase):
+			section = sectionBase
+			current.BaseLines = []string{}
+		case section != sectionTheirs && content == markerSplit:
+			section = sectionTheirs
+		case section == sectionTheirs && isMarker(content, markerTheirs):
+			current.TheirsLabel, _ = cutMarker(content, markerTheirs)
+			blocks = append(blocks, current)
+			section = sectionNone
+		case section == sectionOurs:
+			current.OurLines = append(current.OurLines, content)
+		case section == sectionBase:
+			current.BaseLines = append(current.BaseLines, content)
+		case section == sectionTheirs:
+			current.TheirLines = append(current.TheirLines, content)
+		}
+	}
+	return blocks
+}
+
+// diffPrefixWidth returns how many of the first max characters are diff line prefixes
+func diffPrefixWidth(line string, max int) int {
+	width := 0
+	for width < max && width < len(line) && strings.IndexByte("+- ", line[width]) >= 0 {
+		width++
+	}
+	return width
+}
+
+// isMarker reports whether content is the given conflict marker, with or without a label
+func isMarker(content, marker string) bool {
+	_, ok := cutMarker(content, marker)
+	return ok
+}
+
+// cutMarker reports whether content is the given conflict marker, returning its label
+func cutMarker(content, marker string) (string, bool) {
+	rest, ok := strings.CutPrefix(content, marker)
+	if !ok || (rest != "" && rest[0] != ' ') {
+		return "", false
+	}
+	return strings.TrimPrefix(rest, " "), true
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package gitdiffviewer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConflicts_TwoWay(t *testing.T) {
+	diff := `diff --cc config/app.yaml
+index abc1234,def5678..0000000
+--- a/config/app.yaml
++++ b/config/app.yaml
+@@@ -1,2 -1,2 +1,6 @@@
+  name: app
+++<<<<<<< HEAD
+ +version: 1.2.1
+++=======
++ version: 1.3.0
+++>>>>>>> feature-auth
+  port: 8080`
+
+	blocks := ParseConflicts(diff)
+	require.Len(t, blocks, 1)
+
+	block := blocks[0]
+	assert.Equal(t, "config/app.yaml", block.File)
+	assert.Equal(t, 6, block.Line)
+	assert.Equal(t, "HEAD", block.OursLabel)
+	assert.Equal(t, "feature-auth", block.TheirsLabel)
+	assert.Equal(t, []string{"version: 1.2.1"}, block.OurLines)
+	assert.Equal(t, []string{"version: 1.3.0"}, block.TheirLines)
+	assert.Nil(t, block.BaseLines)
+}
+
+func TestParseConflicts_Diff3(t *testing.T) {
+	content := `func handler() {
+<<<<<<< ours
+	return serveV2()
+||||||| base
+	return serve()
+=======
+	log.Println("serving")
+	return serve()
+>>>>>>> theirs
+}`
+
+	blocks := ParseConflicts(content)
+	require.Len(t, blocks, 1)
+
+	block := blocks[0]
+	assert.Empty(t, block.File)
+	assert.Equal(t, 1, block.Line)
+	assert.Equal(t, []string{"\treturn serveV2()"}, block.OurLines)
+	assert.Equal(t, []string{"\treturn serve()"}, block.BaseLines)
+	assert.Equal(t, []string{"\tlog.Println(\"serving\")", "\treturn serve()"}, block.TheirLines)
+}
+
+func TestParseConflicts_MultipleInOneFile(t *testing.T) {
+	diff := `diff --git a/routes.go b/routes.go
+--- a/routes.go
++++ b/routes.go
+@@ -1,3 +1,13 @@
+ func RegisterRoutes(r *mux.Router) {
++<<<<<<< HEAD
++	r.HandleFunc("/api/users", usersHandler)
++=======
++	r.HandleFunc("/api/login", loginHandler)
++>>>>>>> feature-auth
+ 	r.HandleFunc("/api/health", healthHandler)
+-	r.HandleFunc("/old", oldHandler)
++<<<<<<< HEAD
++=======
++	r.HandleFunc("/api/refresh", refreshHandler)
++>>>>>>> feature-auth
+ }`
+
+	blocks := ParseConflicts(diff)
+	require.Len(t, blocks, 2)
+
+	assert.Equal(t, "routes.go", blocks[0].File)
+	assert.Equal(t, []string{"\tr.HandleFunc(\"/api/users\", usersHandler)"}, blocks[0].OurLines)
+	assert.Equal(t, []string{"\tr.HandleFunc(\"/api/login\", loginHandler)"}, blocks[0].TheirLines)
+
+	assert.Equal(t, "routes.go", blocks[1].File)
+	assert.Equal(t, 12, blocks[1].Line)
+	assert.Empty(t, blocks[1].OurLines)
+	assert.Equal(t, []string{"\tr.HandleFunc(\"/api/refresh\", refreshHandler)"}, blocks[1].TheirLines)
+}
+
+func TestParseConflicts_SkipsRemovedAndUnterminated(t *testing.T) {
+	diff := `diff --git a/a.txt b/a.txt
+@@ -1,4 +1,4 @@
++<<<<<<< HEAD
++mine
+-stale
++=======
++yours
++>>>>>>> other
+diff --git a/b.txt b/b.txt
+@@ -1 +1,3 @@
++<<<<<<< HEAD
++truncated`
+
+	blocks := ParseConflicts(diff)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, "a.txt", blocks[0].File)
+	assert.Equal(t, []string{"mine"}, blocks[0].OurLines)
+	assert.Equal(t, []string{"yours"}, blocks[0].TheirLines)
+
+	assert.Nil(t, ParseConflicts(""))
+	assert.Empty(t, ParseConflicts("no conflicts here\n<<<<<<<<< not a marker"))
+}
@@ -8,8 +8,26 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
+// DefaultTabWidth is the tab stop width used when none is configured
+const DefaultTabWidth = 4
+
+// continuationMarker prefixes the soft-wrapped remainder of a long diff line
+const continuationMarker = "↪ "
+
+// Options controls width-aware rendering of a diff
+type Options struct {
+	Width    int  // Available content width in cells; 0 means unlimited
+	TabWidth int  // Columns per tab stop (default: DefaultTabWidth)
+	Wrap     bool // Soft-wrap lines wider than Width instead of leaving them for horizontal scrolling
+
+	// Highlight colors the code in hunks by the language of each file. Files in unknown
+	// languages, and diffs with merge conflict markers, keep the plain rendering.
+	Highlight bool
+}
+
 // Render displays a git diff with syntax highlighting
 func Render(diff string, maxHeight int) string {
 	if diff == "" {
@@ -23,7 +41,7 @@ func Render(diff string, maxHeight int) string {
 	var rendered []string
 
 	for _, line := range lines {
-		rendered = append(rendered, renderLine(line))
+		rendered = append(rendered, renderLine(expandTabs(line, DefaultTabWidth)))
 	}
 
 	content := strings.Join(rendered, "\n")
@@ -43,47 +61,151 @@ func Render(diff string, maxHeight int) string {
 	return content
 }
 
+// RenderWithOptions renders a diff to fit opts.Width. Because wrapping can turn one
+// diff line into several, it also returns the rendered line each raw diff line starts
+// on, so ParseDiff line numbers can be mapped to scroll positions.
+func RenderWithOptions(diff string, opts Options) (string, []int) {
+	if diff == "" {
+		return Render(diff, 0), nil
+	}
+	tabWidth := opts.TabWidth
+	if tabWidth <= 0 {
+		tabWidth = DefaultTabWidth
+	}
+
+	lines := strings.Split(diff, "\n")
+	rendered := make([]string, 0, len(lines))
+	lineStarts := make([]int, len(lines))
+	markerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var hl *highlighter
+	if opts.Highlight && !hasConflictMarkers(diff) {
+		hl = newHighlighter()
+	}
+
+	for i, line := range lines {
+		lineStarts[i] = len(rendered)
+		expanded := expandTabs(line, tabWidth)
+
+		// Highlighted lines carry their own styles; the rest are styled by prefix
+		paint := lineStyle(line).Render
+		if hl != nil {
+			if strings.HasPrefix(line, "diff --git ") {
+				hl.file(line)
+			}
+			if highlighted, ok := hl.render(expanded); ok {
+				expanded, paint = highlighted, asIs
+			}
+		}
+
+		if !opts.Wrap || opts.Width <= 0 {
+			if expanded != "" {
+				expanded = paint(expanded)
+			}
+			rendered = append(rendered, expanded)
+			continue
+		}
+
+		for j, segment := range wrapLine(expanded, opts.Width, ansi.StringWidth(continuationMarker)) {
+			if j == 0 {
+				rendered = append(rendered, paint(segment))
+				continue
+			}
+			rendered = append(rendered, markerStyle.Render(continuationMarker)+paint(segment))
+		}
+	}
+
+	return strings.Join(rendered, "\n"), lineStarts
+}
+
+// wrapLine splits line into segments of at most width cells, leaving room for the
+// continuation marker on every segment after the first
+func wrapLine(line string, width, markerWidth int) []string {
+	lineWidth := ansi.StringWidth(line)
+	if lineWidth <= width {
+		return []string{line}
+	}
+
+	continuationWidth := width - markerWidth
+	if continuationWidth < 1 {
+		continuationWidth = 1
+	}
+
+	segments := []string{ansi.Cut(line, 0, width)}
+	for start := width; start < lineWidth; start += continuationWidth {
+		segments = append(segments, ansi.Cut(line, start, start+continuationWidth))
+	}
+	return segments
+}
+
+// expandTabs replaces tabs with spaces up to the next tab stop. Stops are measured
+// from after the +/-/space prefix of hunk lines so source alignment is preserved.
+func expandTabs(line string, tabWidth int) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+
+	prefix := ""
+	if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") || strings.HasPrefix(line, " ") {
+		prefix, line = line[:1], line[1:]
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	col := 0
+	for _, r := range line {
+		if r == '\t' {
+			spaces := tabWidth - col%tabWidth
+			b.WriteString(strings.Repeat(" ", spaces))
+			col += spaces
+			continue
+		}
+		b.WriteRune(r)
+		col += ansi.StringWidth(string(r))
+	}
+	return b.String()
+}
+
 func renderLine(line string) string {
 	// Empty lines
 	if len(line) == 0 {
 		return ""
 	}
+	return lineStyle(line).Render(line)
+}
 
+// lineStyle picks the highlight for a diff line from its prefix
+func lineStyle(line string) lipgloss.Style {
 	// Diff headers (diff --git, index, +++, ---)
 	if strings.HasPrefix(line, "diff --git") ||
 		strings.HasPrefix(line, "index ") ||
 		strings.HasPrefix(line, "---") ||
 		strings.HasPrefix(line, "+++") {
-		style := lipgloss.NewStyle().
+		return lipgloss.NewStyle().
 			Foreground(lipgloss.Color("39")) // Cyan
-		return style.Render(line)
 	}
 
 	// Hunk headers (@@ -10,7 +10,8 @@)
 	if strings.HasPrefix(line, "@@") {
-		style := lipgloss.NewStyle().
+		return lipgloss.NewStyle().
 			Foreground(lipgloss.Color("140")) // Purple
-		return style.Render(line)
 	}
 
 	// Added lines
 	if strings.HasPrefix(line, "+") {
-		style := lipgloss.NewStyle().
+		return lipgloss.NewStyle().
 			Foreground(lipgloss.Color("82")) // Green
-		return style.Render(line)
 	}
 
 	// Removed lines
 	if strings.HasPrefix(line, "-") {
-		style := lipgloss.NewStyle().
+		return lipgloss.NewStyle().
 			Foreground(lipgloss.Color("196")) // Red
-		return style.Render(line)
 	}
 
 	// Context lines (no color, just regular)
-	style := lipgloss.NewStyle().
+	return lipgloss.NewStyle().
 		Foreground(lipgloss.Color("252"))
-	return style.Render(line)
 }
 
 // RenderCompact renders a compact view showing only file names and change summary
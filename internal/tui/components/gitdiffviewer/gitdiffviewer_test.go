@@ -0,0 +1,52 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package gitdiffviewer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderWithOptions_WrapsLongLine(t *testing.T) {
+	long := "+" + strings.Repeat("x", 49)
+	diff := "@@ -1 +1 @@\n" + long + "\n context"
+
+	content, lineStarts := RenderWithOptions(diff, Options{Width: 20, Wrap: true})
+	lines := strings.Split(ansi.Strip(content), "\n")
+
+	// 50 cells: 20 on the first line, then 18 per continuation after the marker
+	require.Len(t, lines, 5)
+	assert.Equal(t, long[:20], lines[1])
+	assert.Equal(t, continuationMarker+long[20:38], lines[2])
+	assert.Equal(t, continuationMarker+long[38:], lines[3])
+	for _, line := range lines {
+		assert.LessOrEqual(t, ansi.StringWidth(line), 20)
+	}
+
+	assert.Equal(t, []int{0, 1, 4}, lineStarts, "lines after a wrapped line shift down")
+}
+
+func TestRenderWithOptions_NoWrapKeepsLinesWhole(t *testing.T) {
+	long := "+" + strings.Repeat("x", 49)
+
+	content, lineStarts := RenderWithOptions(long, Options{Width: 20})
+
+	assert.Equal(t, long, ansi.Strip(content), "long lines are left for the viewport to scroll horizontally")
+	assert.Equal(t, []int{0}, lineStarts)
+}
+
+func TestRenderWithOptions_ExpandsTabs(t *testing.T) {
+	content, _ := RenderWithOptions("+\tfoo\n+ab\tbar\n\tplain", Options{TabWidth: 8})
+	lines := strings.Split(ansi.Strip(content), "\n")
+
+	require.Len(t, lines, 3)
+	assert.Equal(t, "+        foo", lines[0])
+	assert.Equal(t, "+ab      bar", lines[1], "tab stops are measured after the diff prefix")
+	assert.Equal(t, "        plain", lines[2])
+	assert.NotContains(t, content, "\t")
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package gitdiffviewer
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// highlightStyle is the chroma style used for code inside hunks
+const highlightStyle = "monokai"
+
+var (
+	addedMarkerStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("82"))  // Green
+	removedMarkerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196")) // Red
+	hunkHeaderStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("241")) // Dim
+
+	// Backgrounds tinting added and removed lines under the code colors
+	addedBackground   = lipgloss.Color("22")
+	removedBackground = lipgloss.Color("52")
+)
+
+// highlighter colors the code in hunk lines by language, detected from the file name
+// in each diff --git header. Not safe for concurrent use.
+type highlighter struct {
+	style *chroma.Style
+	lexer chroma.Lexer // Lexer for the current file; nil when its language is unknown
+}
+
+func newHighlighter() *highlighter {
+	return &highlighter{style: styles.Get(highlightStyle)}
+}
+
+// file switches to the language of the file named in a diff --git header
+func (h *highlighter) file(header string) {
+	_, path := parseDiffGitHeader(header)
+	h.lexer = nil
+	if lexer := lexers.Match(path); lexer != nil && lexer.Config().Name != "plaintext" {
+		h.lexer = chroma.Coalesce(lexer)
+	}
+}
+
+// render highlights a hunk line or hunk header, reporting false for lines that keep
+// the plain rendering: file headers, and every line of a file in an unknown language
+func (h *highlighter) render(line string) (string, bool) {
+	if strings.HasPrefix(line, "@@") {
+		return hunkHeaderStyle.Render(line), true
+	}
+	if h.lexer == nil || line == "" || strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---") {
+		return "", false
+	}
+
+	prefix, code := line[:1], line[1:]
+	var (
+		marker     lipgloss.Style
+		background lipgloss.TerminalColor
+	)
+	switch prefix {
+	case "+":
+		marker, background = addedMarkerStyle, addedBackground
+	case "-":
+		marker, background = removedMarkerStyle, removedBackground
+	case " ":
+		marker = lipgloss.NewStyle()
+	default:
+		return "", false // "\ No newline at end of file" and other non-hunk lines
+	}
+
+	tokens, err := h.lexer.Tokenise(nil, code)
+	if err != nil {
+		return "", false
+	}
+
+	var b strings.Builder
+	if background != nil {
+		marker = marker.Background(background)
+	}
+	b.WriteString(marker.Render(prefix))
+	for _, token := range tokens.Tokens() {
+		value := strings.TrimRight(token.Value, "\n") // Lexers may end the line with a newline
+		if value == "" {
+			continue
+		}
+		b.WriteString(h.tokenStyle(token.Type, background).Render(value))
+	}
+	return b.String(), true
+}
+
+// tokenStyle converts the chroma style of a token type to lipgloss
+func (h *highlighter) tokenStyle(tokenType chroma.TokenType, background lipgloss.TerminalColor) lipgloss.Style {
+	entry := h.style.Get(tokenType)
+	style := lipgloss.NewStyle()
+	if entry.Colour.IsSet() {
+		style = style.Foreground(lipgloss.Color(entry.Colour.String()))
+	}
+	if entry.Bold == chroma.Yes {
+		style = style.Bold(true)
+	}
+	if entry.Italic == chroma.Yes {
+		style = style.Italic(true)
+	}
+	if background != nil {
+		style = style.Background(background)
+	}
+	return style
+}
+
+// asIs stands in for a lipgloss Render on text that is already highlighted
+func asIs(strs ...string) string {
+	return strings.Join(strs, " ")
+}
+
+// hasConflictMarkers reports whether any line of diff, past its +/- prefixes, opens
+// a merge conflict. Such diffs are rendered plain so the markers stand out.
+func hasConflictMarkers(diff string) bool {
+	for _, line := range strings.Split(diff, "\n") {
+		if isMarker(line[diffPrefixWidth(line, 2):], markerOurs) {
+			return true
+		}
+	}
+	return false
+}
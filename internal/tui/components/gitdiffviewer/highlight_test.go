@@ -0,0 +1,75 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package gitdiffviewer
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+const highlightDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@ package main
+ func main() {
+-	fmt.Println("old")
++	fmt.Println("new")
+diff --git a/notes.unknownext b/notes.unknownext
+@@ -1 +1 @@
+-before
++after`
+
+func TestHighlighter_Render(t *testing.T) {
+	h := newHighlighter()
+
+	tests := []struct {
+		name   string
+		header string // diff --git header selecting the file; empty keeps the previous one
+		line   string
+		ok     bool
+	}{
+		{name: "added code", header: "diff --git a/main.go b/main.go", line: `+    fmt.Println("new")`, ok: true},
+		{name: "removed code", line: `-    x := 1`, ok: true},
+		{name: "context code", line: " }", ok: true},
+		{name: "hunk header", line: "@@ -1,3 +1,3 @@", ok: true},
+		{name: "file header", line: "+++ b/main.go"},
+		{name: "no newline marker", line: `\ No newline at end of file`},
+		{name: "unknown language", header: "diff --git a/notes.unknownext b/notes.unknownext", line: "+after"},
+		{name: "plain text", header: "diff --git a/notes.txt b/notes.txt", line: "+after"},
+		{name: "hunk header in unknown language", line: "@@ -1 +1 @@", ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.header != "" {
+				h.file(tt.header)
+			}
+			rendered, ok := h.render(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			if ok {
+				assert.Equal(t, tt.line, ansi.Strip(rendered), "highlighting only adds styles")
+			}
+		})
+	}
+}
+
+func TestRenderWithOptions_HighlightKeepsText(t *testing.T) {
+	for _, wrap := range []bool{false, true} {
+		plain, plainStarts := RenderWithOptions(highlightDiff, Options{Width: 20, Wrap: wrap})
+		highlighted, highlightedStarts := RenderWithOptions(highlightDiff, Options{Width: 20, Wrap: wrap, Highlight: true})
+
+		assert.Equal(t, ansi.Strip(plain), ansi.Strip(highlighted), "wrap %v", wrap)
+		assert.Equal(t, plainStarts, highlightedStarts, "wrap %v", wrap)
+	}
+}
+
+func TestHasConflictMarkers(t *testing.T) {
+	assert.False(t, hasConflictMarkers(highlightDiff))
+	assert.True(t, hasConflictMarkers("@@ -1 +1,5 @@\n+<<<<<<< HEAD\n+ours\n+=======\n+theirs\n+>>>>>>> branch"))
+	assert.True(t, hasConflictMarkers("@@@ -1,1 -1,1 +1,5 @@@\n++<<<<<<< HEAD"), "combined diffs have two prefix columns")
+	assert.False(t, hasConflictMarkers("+<<<<<<<<< not a marker"))
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package gitdiffviewer
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Hunk is a single @@ section of a file diff
+type Hunk struct {
+	Header string
+	Line   int // Zero-based line of the @@ header in the diff
+}
+
+// FileDiff is the part of a unified diff that belongs to one file
+type FileDiff struct {
+	Path    string // Path after the change (b/ side)
+	OldPath string // Path before the change; differs from Path for renames
+	Line    int    // Zero-based line of the "diff --git" header in the diff
+	Hunks   []Hunk
+}
+
+// ParseDiff splits a unified git diff into per-file sections. Line numbers refer to
+// lines of the raw diff, which Render keeps one-to-one in its output; with wrapping,
+// map them through the line starts returned by RenderWithOptions.
+func ParseDiff(diff string) []FileDiff {
+	if diff == "" {
+		return nil
+	}
+
+	var files []FileDiff
+	for i, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			oldPath, newPath := parseDiffGitHeader(line)
+			files = append(files, FileDiff{Path: newPath, OldPath: oldPath, Line: i})
+
+		case len(files) == 0:
+			continue
+
+		case strings.HasPrefix(line, "rename from "):
+			files[len(files)-1].OldPath = strings.TrimPrefix(line, "rename from ")
+
+		case strings.HasPrefix(line, "rename to "):
+			files[len(files)-1].Path = strings.TrimPrefix(line, "rename to ")
+
+		case strings.HasPrefix(line, "@@"):
+			current := &files[len(files)-1]
+			current.Hunks = append(current.Hunks, Hunk{Header: line, Line: i})
+		}
+	}
+	return files
+}
+
+// FindFile returns the file diff matching path. Absolute paths (as reported by agent
+// tools running inside a worktree) match a diff path that they end with.
+func FindFile(files []FileDiff, path string) (FileDiff, bool) {
+	path = filepath.ToSlash(filepath.Clean(path))
+	for _, f := range files {
+		if f.Path == path || f.OldPath == path {
+			return f, true
+		}
+	}
+	for _, f := range files {
+		if strings.HasSuffix(path, "/"+f.Path) || (f.OldPath != "" && strings.HasSuffix(path, "/"+f.OldPath)) {
+			return f, true
+		}
+	}
+	return FileDiff{}, false
+}
+
+// parseDiffGitHeader extracts both paths from "diff --git a/<old> b/<new>"
+func parseDiffGitHeader(line string) (oldPath, newPath string) {
+	rest := strings.TrimPrefix(line, "diff --git ")
+	// Paths without spaces split unambiguously; otherwise assume old and new are equal
+	if parts := strings.Split(rest, " "); len(parts) == 2 {
+		return strings.TrimPrefix(parts[0], "a/"), strings.TrimPrefix(parts[1], "b/")
+	}
+	if idx := strings.Index(rest, " b/"); idx >= 0 {
+		return strings.TrimPrefix(rest[:idx], "a/"), rest[idx+len(" b/"):]
+	}
+	return rest, rest
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package gitdiffviewer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@
+ package main
++import "fmt"
+@@ -10,2 +11,2 @@ func main() {
+-	println("hi")
++	fmt.Println("hi")
+diff --git a/old name.txt b/new name.txt
+similarity index 100%
+rename from old name.txt
+rename to new name.txt
+diff --git a/internal/util.go b/internal/util.go
+new file mode 100644
+--- /dev/null
++++ b/internal/util.go
+@@ -0,0 +1 @@
++package internal`
+
+func TestParseDiff(t *testing.T) {
+	files := ParseDiff(sampleDiff)
+	require.Len(t, files, 3)
+
+	assert.Equal(t, "main.go", files[0].Path)
+	assert.Equal(t, 0, files[0].Line)
+	require.Len(t, files[0].Hunks, 2)
+	assert.Equal(t, 4, files[0].Hunks[0].Line)
+	assert.Equal(t, 7, files[0].Hunks[1].Line)
+	assert.Equal(t, "@@ -10,2 +11,2 @@ func main() {", files[0].Hunks[1].Header)
+
+	assert.Equal(t, "new name.txt", files[1].Path)
+	assert.Equal(t, "old name.txt", files[1].OldPath)
+	assert.Equal(t, 10, files[1].Line)
+	assert.Empty(t, files[1].Hunks)
+
+	assert.Equal(t, "internal/util.go", files[2].Path)
+	require.Len(t, files[2].Hunks, 1)
+	assert.Equal(t, 18, files[2].Hunks[0].Line)
+}
+
+func TestParseDiff_Empty(t *testing.T) {
+	assert.Nil(t, ParseDiff(""))
+	assert.Empty(t, ParseDiff("not a diff"))
+}
+
+func TestFindFile(t *testing.T) {
+	files := ParseDiff(sampleDiff)
+
+	tests := []struct {
+		name     string
+		path     string
+		wantPath string
+		wantOK   bool
+	}{
+		{name: "relative path", path: "internal/util.go", wantPath: "internal/util.go", wantOK: true},
+		{name: "absolute worktree path", path: "/tmp/worktrees/task-1/internal/util.go", wantPath: "internal/util.go", wantOK: true},
+		{name: "renamed file by old path", path: "old name.txt", wantPath: "new name.txt", wantOK: true},
+		{name: "partial name does not match", path: "/repo/xmain.go", wantOK: false},
+		{name: "unchanged file", path: "README.md", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, ok := FindFile(files, tt.path)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantPath, f.Path)
+		})
+	}
+}
@@ -11,11 +11,11 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/tui/toolstyle"
 )
 
 // Event icons for different event types
 const (
-	iconToolCall   = ">"
 	iconToolResult = "<"
 	iconStop       = "X"
 	iconError      = "!"
@@ -28,8 +28,8 @@ var (
 	timestampStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("243"))
 
+	// Tool calls stand out in bold; the color comes from the tool's style
 	toolCallStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("86")). // Cyan
 			Bold(true)
 
 	toolResultOKStyle = lipgloss.NewStyle().
@@ -51,6 +51,10 @@ var (
 
 	inputStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("250")) // Light gray
+
+	cursorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("86")). // Cyan
+			Bold(true)
 )
 
 // RenderEventLog renders the chronological activity log
@@ -61,15 +65,32 @@ func RenderEventLog(events []*models.AIActivityRecord, width int) string {
 			Render("No activity yet...")
 	}
 
+	content, _ := renderEventLog(events, width, -1)
+	return content
+}
+
+// renderEventLog renders the log with a cursor on the selected event (-1 for none)
+// and returns the line the selected event ended up on, or -1 if it is not shown.
+func renderEventLog(events []*models.AIActivityRecord, width, selected int) (string, int) {
 	var lines []string
-	for _, record := range events {
+	selectedLine := -1
+	for i, record := range events {
 		line := renderEventLine(record, width)
-		if line != "" {
-			lines = append(lines, line)
+		if line == "" {
+			continue
 		}
+		if selected >= 0 {
+			if i == selected {
+				selectedLine = len(lines)
+				line = cursorStyle.Render("›") + " " + line
+			} else {
+				line = "  " + line
+			}
+		}
+		lines = append(lines, line)
 	}
 
-	return strings.Join(lines, "\n")
+	return strings.Join(lines, "\n"), selectedLine
 }
 
 // renderEventLine renders a single record as a log line
@@ -107,10 +128,13 @@ func renderEventLine(record *models.AIActivityRecord, width int) string {
 
 	case models.AIEventUserPrompt:
 		prompt := extractUserPrompt(record)
+		if prompt == "" && len(record.Attachments) > 0 {
+			prompt = record.ContentPreview // e.g. "[image attachment]"
+		}
 		if prompt != "" {
 			prompt = truncate(prompt, contentWidth-20)
-			return fmt.Sprintf("%s %s User: %s",
-				ts, sessionStyle.Render("💬"), inputStyle.Render(prompt))
+			return fmt.Sprintf("%s %s User: %s%s",
+				ts, sessionStyle.Render("💬"), inputStyle.Render(prompt), renderAttachments(record))
 		}
 		return fmt.Sprintf("%s %s User prompt submitted",
 			ts, sessionStyle.Render("💬"))
@@ -142,8 +166,8 @@ func renderToolCall(ts string, record *models.AIActivityRecord, maxWidth int) st
 		return ""
 	}
 
-	icon := toolCallStyle.Render(iconToolCall)
-	toolName := toolCallStyle.Render(record.ToolName)
+	icon := toolstyle.Icon(record.ToolName)
+	toolName := toolCallStyle.Render(toolstyle.Name(record.ToolName))
 
 	// Use ToolInputSummary for display
 	inputSummary := ""
@@ -165,15 +189,13 @@ func renderToolResult(ts string, record *models.AIActivityRecord, maxWidth int)
 		status = toolResultErrStyle.Render("[ERR]")
 	}
 
-	toolName := record.ToolName
-
 	// Add error message if present
 	extra := ""
 	if record.ToolError != "" {
-		extra = " " + truncate(record.ToolError, maxWidth-len(toolName)-10)
+		extra = " " + truncate(record.ToolError, maxWidth-len(record.ToolName)-10)
 	}
 
-	return fmt.Sprintf("%s %s %s %s%s", ts, icon, toolName, status, extra)
+	return fmt.Sprintf("%s %s %s %s%s", ts, icon, toolstyle.Name(record.ToolName), status, extra)
 }
 
 func renderStop(ts string, record *models.AIActivityRecord) string {
@@ -235,3 +257,12 @@ func extractUserPrompt(record *models.AIActivityRecord) string {
 
 	return ""
 }
+
+// renderAttachments returns the record's attachment labels, e.g. " 📎 image/png"
+func renderAttachments(record *models.AIActivityRecord) string {
+	var out string
+	for _, attachment := range record.Attachments {
+		out += " " + timestampStyle.Render(attachment.Label())
+	}
+	return out
+}
@@ -6,10 +6,14 @@ package hooksactivity
 import (
 	"time"
 
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/tui/keys"
+	"github.com/noldarim/noldarim/internal/tui/messages"
 )
 
 // Summary holds aggregated metrics from AI activity events
@@ -29,15 +33,39 @@ type Summary struct {
 type Model struct {
 	taskID      string
 	events      []*models.AIActivityRecord
+	selected    int // Index into events, -1 while following the newest event
 	streaming   bool
 	summary     Summary
 	logViewport viewport.Model
 	width       int
 	height      int
+	stacked     bool // Summary in one column, for vertical layouts
 	focused     bool
 	ready       bool
+
+	// Clipboard writer for copying events, and the transient status it leaves
+	clipboard   func(string) error
+	status      string
+	statusUntil time.Time
+
+	// Selection bindings, resolved from the keys registry
+	upKey     key.Binding
+	downKey   key.Binding
+	selectKey key.Binding
+	copyKey   key.Binding
+}
+
+// statusDuration is how long the status line after copying stays up
+const statusDuration = 2 * time.Second
+
+// copiedMsg reports the outcome of copying an event to the clipboard
+type copiedMsg struct {
+	err error
 }
 
+// clearStatusMsg is scheduled when a status is shown and removes it once expired
+type clearStatusMsg struct{}
+
 // New creates a new hooks activity model
 func New(taskID string, width, height int) Model {
 	vp := viewport.New(width, height-6) // Reserve space for summary
@@ -46,6 +74,7 @@ func New(taskID string, width, height int) Model {
 	return Model{
 		taskID:      taskID,
 		events:      make([]*models.AIActivityRecord, 0),
+		selected:    -1,
 		streaming:   false,
 		summary:     Summary{ToolsInvoked: make(map[string]int)},
 		logViewport: vp,
@@ -53,6 +82,11 @@ func New(taskID string, width, height int) Model {
 		height:      height,
 		focused:     false,
 		ready:       true,
+		clipboard:   clipboard.WriteAll,
+		upKey:       keys.Get(keys.Up),
+		downKey:     keys.Get(keys.Down),
+		selectKey:   keys.Get(keys.Select),
+		copyKey:     keys.Get(keys.Copy),
 	}
 }
 
@@ -63,15 +97,113 @@ func (m Model) Init() tea.Cmd {
 
 // Update handles messages for the hooks activity component
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	// Copy results arrive even if focus moved on meanwhile
+	switch msg := msg.(type) {
+	case copiedMsg:
+		m.status = "copied"
+		if msg.err != nil {
+			m.status = "copy failed: " + msg.err.Error()
+		}
+		m.statusUntil = time.Now().Add(statusDuration)
+		return m, tea.Tick(statusDuration, func(time.Time) tea.Msg {
+			return clearStatusMsg{}
+		})
+	case clearStatusMsg:
+		if !time.Now().Before(m.statusUntil) {
+			m.status = ""
+		}
+		return m, nil
+	}
+
 	if !m.focused {
 		return m, nil
 	}
 
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && len(m.events) > 0 {
+		switch {
+		case key.Matches(keyMsg, m.upKey):
+			m.moveSelection(-1)
+			return m, nil
+		case key.Matches(keyMsg, m.downKey):
+			m.moveSelection(1)
+			return m, nil
+		case key.Matches(keyMsg, m.selectKey):
+			if record := m.SelectedEvent(); record != nil && record.FilePath != "" {
+				path := record.FilePath
+				return m, func() tea.Msg {
+					return messages.FileSelectedMsg{Path: path}
+				}
+			}
+			return m, nil
+		case key.Matches(keyMsg, m.copyKey):
+			return m, m.copySelected()
+		}
+	}
+
 	var cmd tea.Cmd
 	m.logViewport, cmd = m.logViewport.Update(msg)
 	return m, cmd
 }
 
+// SelectedEvent returns the event under the cursor, or nil when nothing is selected
+func (m Model) SelectedEvent() *models.AIActivityRecord {
+	if m.selected < 0 || m.selected >= len(m.events) {
+		return nil
+	}
+	return m.events[m.selected]
+}
+
+// copySelected returns a command copying the selected event's raw payload, or its
+// content preview when there is no payload, to the clipboard
+func (m Model) copySelected() tea.Cmd {
+	record := m.SelectedEvent()
+	if record == nil {
+		return nil
+	}
+	text := record.RawPayload
+	if text == "" {
+		text = record.ContentPreview
+	}
+	write := m.clipboard
+	return func() tea.Msg {
+		return copiedMsg{err: write(text)}
+	}
+}
+
+// SetClipboard sets the function copying text to the system clipboard
+func (m *Model) SetClipboard(write func(string) error) {
+	m.clipboard = write
+}
+
+// Status returns the transient status line, empty when there is none
+func (m Model) Status() string {
+	if m.status == "" || !time.Now().Before(m.statusUntil) {
+		return ""
+	}
+	return m.status
+}
+
+// isFollowing reports whether the cursor sits on the newest event and should move with new ones
+func (m Model) isFollowing() bool {
+	return m.selected >= 0 && m.selected == len(m.events)-1
+}
+
+// moveSelection moves the cursor by delta events; the first move selects the newest event
+func (m *Model) moveSelection(delta int) {
+	if m.selected < 0 {
+		m.selected = len(m.events) - 1
+	} else {
+		m.selected += delta
+	}
+	if m.selected < 0 {
+		m.selected = 0
+	}
+	if m.selected >= len(m.events) {
+		m.selected = len(m.events) - 1
+	}
+	m.refreshLogContent()
+}
+
 // SetFocus sets the focus state
 func (m *Model) SetFocus(focused bool) {
 	m.focused = focused
@@ -82,13 +214,24 @@ func (m Model) IsFocused() bool {
 	return m.focused
 }
 
+// SetStacked puts the summary metrics in one column instead of two, for narrow
+// (vertical) layouts
+func (m *Model) SetStacked(stacked bool) {
+	m.stacked = stacked
+	m.SetSize(m.width, m.height)
+}
+
 // SetSize updates the component dimensions
 func (m *Model) SetSize(width, height int) {
 	m.width = width
 	m.height = height
 
-	// Reserve 6 lines for summary panel
-	logHeight := height - 6
+	// Reserve 6 lines for summary panel, 9 when its rows are stacked
+	summaryHeight := 6
+	if m.stacked {
+		summaryHeight = 9
+	}
+	logHeight := height - summaryHeight
 	if logHeight < 3 {
 		logHeight = 3
 	}
@@ -103,19 +246,27 @@ func (m *Model) AddEvent(record *models.AIActivityRecord) {
 		return
 	}
 
+	following := m.isFollowing()
 	m.events = append(m.events, record)
 	m.updateSummary(record)
+	if following {
+		m.selected = len(m.events) - 1
+	}
 	m.refreshLogContent()
 }
 
 // LoadBatch loads multiple records at once
 func (m *Model) LoadBatch(records []*models.AIActivityRecord) {
+	following := m.isFollowing()
 	for _, record := range records {
 		if record != nil {
 			m.events = append(m.events, record)
 			m.updateSummary(record)
 		}
 	}
+	if following {
+		m.selected = len(m.events) - 1
+	}
 	m.refreshLogContent()
 }
 
@@ -184,16 +335,33 @@ func (m *Model) updateSummary(record *models.AIActivityRecord) {
 
 // refreshLogContent updates the viewport content with the event log
 func (m *Model) refreshLogContent() {
-	content := RenderEventLog(m.events, m.width)
+	if len(m.events) == 0 {
+		m.logViewport.SetContent(RenderEventLog(m.events, m.width))
+		m.logViewport.GotoBottom()
+		return
+	}
+
+	content, selectedLine := renderEventLog(m.events, m.width, m.selected)
 	m.logViewport.SetContent(content)
 
-	// Auto-scroll to bottom for new events
-	m.logViewport.GotoBottom()
+	// Auto-scroll to bottom for new events unless the user is browsing older ones
+	if selectedLine < 0 || m.selected == len(m.events)-1 {
+		m.logViewport.GotoBottom()
+		return
+	}
+
+	// Keep the cursor on screen
+	if selectedLine < m.logViewport.YOffset {
+		m.logViewport.SetYOffset(selectedLine)
+	} else if bottom := m.logViewport.YOffset + m.logViewport.Height - 1; selectedLine > bottom {
+		m.logViewport.SetYOffset(selectedLine - m.logViewport.Height + 1)
+	}
 }
 
 // ClearEvents clears all events and resets summary
 func (m *Model) ClearEvents() {
 	m.events = make([]*models.AIActivityRecord, 0)
+	m.selected = -1
 	m.summary = Summary{ToolsInvoked: make(map[string]int)}
 	m.refreshLogContent()
 }
@@ -0,0 +1,93 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package hooksactivity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+)
+
+func TestModel_CopySelectedEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   *models.AIActivityRecord
+		writeErr error
+		copied   string
+		status   string
+	}{
+		{
+			name:   "raw payload",
+			record: &models.AIActivityRecord{RawPayload: `{"type":"tool_use"}`, ContentPreview: "ls"},
+			copied: `{"type":"tool_use"}`,
+			status: "copied",
+		},
+		{
+			name:   "preview without payload",
+			record: &models.AIActivityRecord{ContentPreview: "ls"},
+			copied: "ls",
+			status: "copied",
+		},
+		{
+			name:     "clipboard error",
+			record:   &models.AIActivityRecord{ContentPreview: "ls"},
+			writeErr: errors.New("no clipboard"),
+			copied:   "ls",
+			status:   "copy failed: no clipboard",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var copied string
+			m := New("task", 80, 24)
+			m.SetClipboard(func(s string) error {
+				copied = s
+				return tt.writeErr
+			})
+			m.SetFocus(true)
+			m.AddEvent(tt.record)
+
+			m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp}) // Select the event
+			m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+			require.NotNil(t, cmd)
+			m, _ = m.Update(cmd())
+
+			assert.Equal(t, tt.copied, copied)
+			assert.Equal(t, tt.status, m.Status())
+			assert.Contains(t, m.View(), tt.status)
+		})
+	}
+}
+
+func TestModel_CopyWithoutSelection(t *testing.T) {
+	m := New("task", 80, 24)
+	m.SetClipboard(func(string) error {
+		t.Fatal("nothing is selected, so nothing should be copied")
+		return nil
+	})
+	m.SetFocus(true)
+	m.AddEvent(&models.AIActivityRecord{ContentPreview: "ls"})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	assert.Nil(t, cmd)
+}
+
+func TestModel_SetStacked(t *testing.T) {
+	m := New("task-1", 60, 30)
+	m.SetSize(60, 30)
+	sideBySide := strings.Count(renderSummary(m.summary, false, 60, false), "\n")
+
+	m.SetStacked(true)
+	stacked := strings.Count(renderSummary(m.summary, false, 60, true), "\n")
+
+	assert.Equal(t, sideBySide+3, stacked, "each of the three rows becomes two lines")
+	assert.Equal(t, 30-9, m.logViewport.Height, "the log gives the taller summary room")
+}
@@ -49,24 +49,35 @@ var (
 
 // RenderSummary renders the summary panel
 func RenderSummary(s Summary, streaming bool, width int) string {
+	return renderSummary(s, streaming, width, false)
+}
+
+// renderSummary renders the summary panel in two columns, or in one when stacked
+func renderSummary(s Summary, streaming bool, width int, stacked bool) string {
 	// Build two-column layout
 	colWidth := (width - 4) / 2 // Account for separator and padding
+	row := func(left, right string) string {
+		if stacked {
+			return left + "\n" + right
+		}
+		return formatRow(left, right, colWidth)
+	}
 
 	// Row 1: Turns | Tokens
 	turns := formatMetric("Turns", fmt.Sprintf("%d", s.TurnCount), turnsStyle)
 	tokens := formatMetric("Tokens", formatNumber(s.TotalTokens), tokensStyle)
-	row1 := formatRow(turns, tokens, colWidth)
+	row1 := row(turns, tokens)
 
 	// Row 2: Tools | Duration
 	toolCount := countTotalTools(s.ToolsInvoked)
 	tools := formatMetric("Tools", fmt.Sprintf("%d calls", toolCount), toolsStyle)
 	duration := formatMetric("Duration", formatDuration(s.SessionDuration), durationStyle)
-	row2 := formatRow(tools, duration, colWidth)
+	row2 := row(tools, duration)
 
 	// Row 3: Top Tools | Status
 	topTools := formatMetric("Top", getTopTools(s.ToolsInvoked, 3), toolsStyle)
 	status := formatStatus(s, streaming)
-	row3 := formatRow(topTools, status, colWidth)
+	row3 := row(topTools, status)
 
 	// Combine rows
 	divider := dividerStyle.Render(strings.Repeat("─", width))
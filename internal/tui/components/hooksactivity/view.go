@@ -12,10 +12,19 @@ import (
 // View renders the hooks activity component
 func (m Model) View() string {
 	// Render summary panel at top
-	summaryContent := RenderSummary(m.summary, m.streaming, m.width)
+	summaryContent := renderSummary(m.summary, m.streaming, m.width, m.stacked)
 
-	// Render scrollable event log
+	// Render scrollable event log; a status line takes its last line while it is up
 	logContent := m.logViewport.View()
+	if status := m.Status(); status != "" {
+		vp := m.logViewport
+		atBottom := vp.AtBottom()
+		vp.Height--
+		if atBottom {
+			vp.GotoBottom()
+		}
+		logContent = lipgloss.JoinVertical(lipgloss.Left, vp.View(), labelStyle.Render(status))
+	}
 
 	// Combine summary and log
 	content := lipgloss.JoinVertical(
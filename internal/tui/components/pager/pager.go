@@ -0,0 +1,293 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package pager wraps bubbles/viewport with the navigation every scrolling screen shares:
+// line, half-page and page scrolling, top and bottom, and jumping to a line with ":N".
+// A status line shows the position and scroll percentage, or the jump prompt while typing.
+package pager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/noldarim/noldarim/internal/tui/keys"
+	"github.com/noldarim/noldarim/internal/tui/layout"
+)
+
+// KeyMap holds the pager's bindings, resolved from the keys registry
+type KeyMap struct {
+	Up           key.Binding
+	Down         key.Binding
+	HalfPageUp   key.Binding
+	HalfPageDown key.Binding
+	PageUp       key.Binding
+	PageDown     key.Binding
+	Top          key.Binding
+	Bottom       key.Binding
+	JumpToLine   key.Binding
+}
+
+// DefaultKeyMap returns the pager bindings from the keys registry
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:           keys.Bind(keys.Up, "scroll up"),
+		Down:         keys.Bind(keys.Down, "scroll down"),
+		HalfPageUp:   keys.Get(keys.HalfPageUp),
+		HalfPageDown: keys.Get(keys.HalfPageDown),
+		PageUp:       keys.Get(keys.PageUp),
+		PageDown:     keys.Get(keys.PageDown),
+		Top:          keys.Get(keys.Top),
+		Bottom:       keys.Get(keys.Bottom),
+		JumpToLine:   keys.Get(keys.JumpToLine),
+	}
+}
+
+// HelpItems returns the footer help for the pager's bindings
+func (k KeyMap) HelpItems() []layout.HelpItem {
+	return keys.HelpItems(k.Up, k.Down, k.HalfPageUp, k.HalfPageDown, k.PageUp, k.PageDown, k.Top, k.Bottom, k.JumpToLine)
+}
+
+// Styles holds all the styling for the component
+type Styles struct {
+	LineNumber lipgloss.Style
+	Status     lipgloss.Style
+	Prompt     lipgloss.Style
+}
+
+// DefaultStyles returns the default color scheme
+func DefaultStyles() Styles {
+	return Styles{
+		LineNumber: lipgloss.NewStyle().Foreground(lipgloss.Color("239")),
+		Status:     lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
+		Prompt:     lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true),
+	}
+}
+
+// Model represents the pager component
+type Model struct {
+	KeyMap KeyMap
+
+	viewport    viewport.Model
+	lines       []string
+	lineNumbers bool
+	statusLine  bool
+	width       int
+	height      int
+
+	// Jump-to-line prompt; prompting while the user types the line number after ":"
+	prompting bool
+	input     string
+
+	// Styling
+	styles Styles
+}
+
+// New creates a pager of the given size, status line included
+func New(width, height int) Model {
+	m := Model{
+		KeyMap:     DefaultKeyMap(),
+		viewport:   viewport.New(width, height),
+		statusLine: true,
+		styles:     DefaultStyles(),
+	}
+	// Vertical scrolling is handled by Update; the viewport keeps left/right, which move
+	// only once a horizontal step is set
+	defaults := viewport.DefaultKeyMap()
+	m.viewport.KeyMap = viewport.KeyMap{Left: defaults.Left, Right: defaults.Right}
+	m.SetSize(width, height)
+	return m
+}
+
+// Init initializes the model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.prompting {
+			m.updatePrompt(msg)
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, m.KeyMap.Up):
+			m.viewport.ScrollUp(1)
+		case key.Matches(msg, m.KeyMap.Down):
+			m.viewport.ScrollDown(1)
+		case key.Matches(msg, m.KeyMap.HalfPageUp):
+			m.viewport.HalfPageUp()
+		case key.Matches(msg, m.KeyMap.HalfPageDown):
+			m.viewport.HalfPageDown()
+		case key.Matches(msg, m.KeyMap.PageUp):
+			m.viewport.PageUp()
+		case key.Matches(msg, m.KeyMap.PageDown):
+			m.viewport.PageDown()
+		case key.Matches(msg, m.KeyMap.Top):
+			m.viewport.GotoTop()
+		case key.Matches(msg, m.KeyMap.Bottom):
+			m.viewport.GotoBottom()
+		case key.Matches(msg, m.KeyMap.JumpToLine):
+			m.prompting = true
+			m.input = ""
+		default:
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	// Mouse wheel scrolling stays with the viewport
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// updatePrompt edits the jump-to-line prompt: digits are typed, enter jumps, esc cancels
+func (m *Model) updatePrompt(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.prompting = false
+		if n, err := strconv.Atoi(m.input); err == nil {
+			m.GotoLine(n)
+		}
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.prompting = false
+	case tea.KeyBackspace:
+		if m.input == "" {
+			m.prompting = false
+		} else {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if r >= '0' && r <= '9' && len(m.input) < 9 {
+				m.input += string(r)
+			}
+		}
+	}
+}
+
+// Prompting reports whether the jump-to-line prompt takes the keyboard. Screens should
+// forward all keys to the pager meanwhile, so digits and esc are not taken as shortcuts.
+func (m Model) Prompting() bool {
+	return m.prompting
+}
+
+// View renders the component
+func (m Model) View() string {
+	if !m.statusLine {
+		return m.viewport.View()
+	}
+	return m.viewport.View() + "\n" + m.renderStatus()
+}
+
+// renderStatus renders the jump prompt, or the position in the content
+func (m Model) renderStatus() string {
+	if m.prompting {
+		return m.styles.Prompt.Render(":" + m.input)
+	}
+	total := len(m.lines)
+	if total <= m.viewport.Height {
+		return m.styles.Status.Render(fmt.Sprintf("%d lines", total))
+	}
+	last := min(m.viewport.YOffset+m.viewport.Height, total)
+	return m.styles.Status.Render(fmt.Sprintf("%d-%d/%d %3d%%",
+		m.viewport.YOffset+1, last, total, int(m.viewport.ScrollPercent()*100)))
+}
+
+// SetContent replaces the content, keeping the scroll position where possible
+func (m *Model) SetContent(content string) {
+	m.lines = strings.Split(content, "\n")
+	m.refreshContent()
+}
+
+// SetLineNumbers toggles the line number gutter
+func (m *Model) SetLineNumbers(on bool) {
+	m.lineNumbers = on
+	m.refreshContent()
+}
+
+// SetStatusLine toggles the status line. It takes the last row of the pager's height.
+func (m *Model) SetStatusLine(on bool) {
+	m.statusLine = on
+	m.SetSize(m.width, m.height)
+}
+
+// SetSize updates component dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.viewport.Width = width
+	m.viewport.Height = height
+	if m.statusLine {
+		m.viewport.Height = max(height-1, 0)
+	}
+	// Re-clamp the offset to the new height
+	m.viewport.SetYOffset(m.viewport.YOffset)
+}
+
+// refreshContent hands the lines to the viewport, with the gutter when line numbers are on
+func (m *Model) refreshContent() {
+	if !m.lineNumbers {
+		m.viewport.SetContent(strings.Join(m.lines, "\n"))
+		return
+	}
+	width := len(strconv.Itoa(len(m.lines)))
+	numbered := make([]string, len(m.lines))
+	for i, line := range m.lines {
+		numbered[i] = m.styles.LineNumber.Render(fmt.Sprintf("%*d │ ", width, i+1)) + line
+	}
+	m.viewport.SetContent(strings.Join(numbered, "\n"))
+}
+
+// GotoLine scrolls so that the given one-based line is at the top, clamped to the content
+func (m *Model) GotoLine(n int) {
+	m.viewport.SetYOffset(max(n-1, 0))
+}
+
+// ScrollToLine scrolls so that the given zero-based content line is at the top
+func (m *Model) ScrollToLine(line int) {
+	m.viewport.SetYOffset(line)
+}
+
+// YOffset returns the zero-based content line at the top of the viewport
+func (m Model) YOffset() int {
+	return m.viewport.YOffset
+}
+
+// SetHorizontalStep sets the columns moved per left/right key; 0 disables horizontal scrolling
+func (m *Model) SetHorizontalStep(n int) {
+	m.viewport.SetHorizontalStep(n)
+	if n == 0 {
+		m.viewport.SetXOffset(0)
+	}
+}
+
+// LineCount returns the number of content lines
+func (m Model) LineCount() int {
+	return len(m.lines)
+}
+
+// ScrollPercent returns the current scroll percentage (0.0 to 1.0)
+func (m Model) ScrollPercent() float64 {
+	return m.viewport.ScrollPercent()
+}
+
+// AtTop returns true if viewport is at the top
+func (m Model) AtTop() bool {
+	return m.viewport.AtTop()
+}
+
+// AtBottom returns true if viewport is at the bottom
+func (m Model) AtBottom() bool {
+	return m.viewport.AtBottom()
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pager
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func longContent(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func press(m Model, keys ...string) Model {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "ctrl+d":
+			msg = tea.KeyMsg{Type: tea.KeyCtrlD}
+		case "pgdown":
+			msg = tea.KeyMsg{Type: tea.KeyPgDown}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		m, _ = m.Update(msg)
+	}
+	return m
+}
+
+func TestModel_Navigation(t *testing.T) {
+	// 10 rows: 9 of content and the status line
+	m := New(40, 10)
+	m.SetContent(longContent(100))
+
+	tests := []struct {
+		keys []string
+		want int
+	}{
+		{[]string{"j", "j"}, 2},
+		{[]string{"k"}, 1},
+		{[]string{"ctrl+d"}, 5},
+		{[]string{"pgdown"}, 14},
+		{[]string{"G"}, 91},
+		{[]string{"g"}, 0},
+	}
+	for _, tt := range tests {
+		m = press(m, tt.keys...)
+		if got := m.YOffset(); got != tt.want {
+			t.Errorf("after %v: offset = %d, want %d", tt.keys, got, tt.want)
+		}
+	}
+}
+
+func TestModel_JumpToLine(t *testing.T) {
+	m := New(40, 10)
+	m.SetContent(longContent(100))
+
+	m = press(m, ":", "4", "2")
+	if !m.Prompting() {
+		t.Fatal("prompt did not open")
+	}
+	if got := m.View(); !strings.HasSuffix(got, ":42") {
+		t.Errorf("status line = %q, want the prompt", got[strings.LastIndex(got, "\n")+1:])
+	}
+	// Non-digits are ignored while typing, so screen shortcuts can't fire
+	m = press(m, "q", "enter")
+	if m.Prompting() || m.YOffset() != 41 {
+		t.Errorf("after :42 offset = %d prompting = %v, want line 42 at the top", m.YOffset(), m.Prompting())
+	}
+
+	// Past the end is clamped, esc cancels
+	m = press(m, ":", "9", "9", "9", "enter")
+	if got := m.YOffset(); got != 91 {
+		t.Errorf("after :999 offset = %d, want 91", got)
+	}
+	m = press(m, ":", "1", "esc")
+	if m.Prompting() || m.YOffset() != 91 {
+		t.Errorf("esc moved the pager to %d", m.YOffset())
+	}
+}
+
+func TestModel_StatusAndLineNumbers(t *testing.T) {
+	m := New(40, 10)
+	m.SetContent(longContent(100))
+	m.GotoLine(46)
+
+	lines := strings.Split(m.View(), "\n")
+	if len(lines) != 10 {
+		t.Fatalf("view has %d rows, want 10", len(lines))
+	}
+	if got := lines[9]; !strings.Contains(got, "46-54/100") || !strings.Contains(got, "49%") {
+		t.Errorf("status line = %q", got)
+	}
+
+	m.SetLineNumbers(true)
+	if got := strings.Split(m.View(), "\n")[0]; !strings.Contains(got, " 46 │ line 46") {
+		t.Errorf("first row = %q, want a line number gutter", got)
+	}
+
+	m.SetStatusLine(false)
+	if got := len(strings.Split(m.View(), "\n")); got != 10 {
+		t.Errorf("view without status line has %d rows, want 10", got)
+	}
+}
@@ -38,6 +38,9 @@ type SummaryData struct {
 	BaseCommitSHA  string
 	HeadCommitSHA  string
 	ErrorMessage   string
+
+	// Files changed excluding whitespace-only changes; 0 when unknown (older runs)
+	SignificantFilesChanged int
 }
 
 // Model represents the pipeline summary component
@@ -102,6 +105,9 @@ func (m Model) View() string {
 	// Diff stats
 	if m.data.FilesChanged > 0 {
 		diffLine := fmt.Sprintf("%s %d files", label.Render("Changes:"), m.data.FilesChanged)
+		if significant := m.data.SignificantFilesChanged; significant > 0 && significant < m.data.FilesChanged {
+			diffLine += dim.Render(fmt.Sprintf(" (%d significant)", significant))
+		}
 		diffLine += " " + success.Render(fmt.Sprintf("+%d", m.data.Insertions))
 		diffLine += " " + fail.Render(fmt.Sprintf("-%d", m.data.Deletions))
 		lines = append(lines, diffLine)
@@ -126,6 +132,76 @@ func (m Model) View() string {
 	return strings.Join(lines, "\n")
 }
 
+// Markdown renders the summary as Markdown for pasting into a PR description. Lines
+// for values that are zero or unknown are left out.
+func (m Model) Markdown() string {
+	d := m.data
+	lines := []string{"- **Status:** " + statusLabel(d.Status)}
+
+	if d.Duration > 0 {
+		lines = append(lines, "- **Duration:** "+formatDuration(d.Duration))
+	}
+
+	if d.TotalSteps > 0 {
+		steps := fmt.Sprintf("%d/%d completed", d.CompletedSteps, d.TotalSteps)
+		if d.FailedSteps > 0 {
+			steps += fmt.Sprintf(", %d failed", d.FailedSteps)
+		}
+		lines = append(lines, "- **Steps:** "+steps)
+	}
+
+	if d.TotalTokens > 0 {
+		tokens := formatNumber(d.TotalTokens)
+		if d.CacheHitTokens > 0 {
+			tokens += fmt.Sprintf(" (%s cache)", formatCompact(d.CacheHitTokens))
+		}
+		lines = append(lines, "- **Tokens:** "+tokens)
+	}
+
+	if d.FilesChanged > 0 {
+		changes := fmt.Sprintf("%d files", d.FilesChanged)
+		if significant := d.SignificantFilesChanged; significant > 0 && significant < d.FilesChanged {
+			changes += fmt.Sprintf(" (%d significant)", significant)
+		}
+		if d.Insertions > 0 {
+			changes += fmt.Sprintf(", +%d", d.Insertions)
+		}
+		if d.Deletions > 0 {
+			changes += fmt.Sprintf(", -%d", d.Deletions)
+		}
+		lines = append(lines, "- **Changes:** "+changes)
+	}
+
+	if d.BranchName != "" {
+		lines = append(lines, "- **Branch:** `"+d.BranchName+"`")
+	}
+	switch {
+	case d.BaseCommitSHA != "" && d.HeadCommitSHA != "":
+		lines = append(lines, fmt.Sprintf("- **Commits:** `%s` → `%s`", truncateSHA(d.BaseCommitSHA), truncateSHA(d.HeadCommitSHA)))
+	case d.HeadCommitSHA != "":
+		lines = append(lines, fmt.Sprintf("- **Head:** `%s`", truncateSHA(d.HeadCommitSHA)))
+	}
+
+	if d.ErrorMessage != "" && d.Status == StatusFailed {
+		lines = append(lines, "", "> **Error:** "+strings.ReplaceAll(d.ErrorMessage, "\n", "\n> "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func statusLabel(s Status) string {
+	switch s {
+	case StatusCompleted:
+		return "✓ Completed"
+	case StatusFailed:
+		return "✗ Failed"
+	case StatusRunning:
+		return "Running"
+	default:
+		return "Pending"
+	}
+}
+
 func renderStatus(s Status, success, fail, accent, label lipgloss.Style) string {
 	switch s {
 	case StatusCompleted:
@@ -0,0 +1,85 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipelinesummary
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModel_Markdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     SummaryData
+		expected string
+	}{
+		{
+			name:     "only the status when everything is zero",
+			data:     SummaryData{},
+			expected: "- **Status:** Pending",
+		},
+		{
+			name: "completed run",
+			data: SummaryData{
+				Status:                  StatusCompleted,
+				Duration:                2*time.Minute + 34*time.Second,
+				TotalSteps:              3,
+				CompletedSteps:          3,
+				TotalTokens:             12345,
+				CacheHitTokens:          4200,
+				FilesChanged:            4,
+				SignificantFilesChanged: 3,
+				Insertions:              120,
+				Deletions:               8,
+				BranchName:              "task/fix-login",
+				BaseCommitSHA:           "1234567890abcdef",
+				HeadCommitSHA:           "fedcba0987654321",
+			},
+			expected: "- **Status:** ✓ Completed\n" +
+				"- **Duration:** 2m 34s\n" +
+				"- **Steps:** 3/3 completed\n" +
+				"- **Tokens:** 12,345 (4.2k cache)\n" +
+				"- **Changes:** 4 files (3 significant), +120, -8\n" +
+				"- **Branch:** `task/fix-login`\n" +
+				"- **Commits:** `1234567` → `fedcba0`",
+		},
+		{
+			name:     "zero insertions and deletions are left out",
+			data:     SummaryData{Status: StatusCompleted, FilesChanged: 2, SignificantFilesChanged: 2},
+			expected: "- **Status:** ✓ Completed\n- **Changes:** 2 files",
+		},
+		{
+			name:     "head without base",
+			data:     SummaryData{Status: StatusRunning, HeadCommitSHA: "fedcba0987654321"},
+			expected: "- **Status:** Running\n- **Head:** `fedcba0`",
+		},
+		{
+			name: "failed run quotes the error",
+			data: SummaryData{
+				Status:         StatusFailed,
+				TotalSteps:     2,
+				CompletedSteps: 1,
+				FailedSteps:    1,
+				ErrorMessage:   "step test failed\nexit status 1",
+			},
+			expected: "- **Status:** ✗ Failed\n" +
+				"- **Steps:** 1/2 completed, 1 failed\n" +
+				"\n" +
+				"> **Error:** step test failed\n> exit status 1",
+		},
+		{
+			name:     "error is left out unless the run failed",
+			data:     SummaryData{Status: StatusCompleted, ErrorMessage: "retried"},
+			expected: "- **Status:** ✓ Completed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, New().SetData(tt.data).Markdown())
+		})
+	}
+}
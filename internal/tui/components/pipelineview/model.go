@@ -15,6 +15,7 @@ import (
 	"github.com/noldarim/noldarim/internal/tui/components/pipelinesummary"
 	"github.com/noldarim/noldarim/internal/tui/components/stepprogress"
 	"github.com/noldarim/noldarim/internal/tui/components/tokendisplay"
+	"github.com/noldarim/noldarim/internal/tui/layout"
 )
 
 // PollInterval is how often we check for new data
@@ -62,6 +63,7 @@ type Model struct {
 	viewport viewport.Model
 	width    int
 	height   int
+	vertical bool // Narrow terminal: status bar parts stacked, no room kept for the todo panel
 	ready    bool
 
 	// Sub-components
@@ -91,30 +93,20 @@ type Model struct {
 func New(width, height int, fetcher DataFetcher) Model {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	// Reserve space for status bar (2 lines: separator + status)
-	statusBarHeight := 2
-	vpHeight := height - statusBarHeight
-	if vpHeight < 3 {
-		vpHeight = 3
-	}
-
+	vertical := layout.OrientationAuto.Resolve(width) == layout.OrientationVertical
+	vpHeight := viewportHeight(height, vertical)
 	vp := viewport.New(width, vpHeight)
 	vp.SetContent("Waiting for activity...")
 
 	// Default to single step if not yet known
 	steps := []stepprogress.Step{{Name: "", Status: stepprogress.StatusRunning}}
 
-	// Calculate feed width (leave room for todo panel)
-	feedWidth := width
-	if width > 80 {
-		feedWidth = width - 40 // Reserve space for todo panel
-	}
-
 	return Model{
 		viewport: vp,
 		width:    width,
 		height:   height,
-		feed:     collapsiblefeed.New(feedWidth, vpHeight),
+		vertical: vertical,
+		feed:     collapsiblefeed.New(feedWidth(width, vertical), vpHeight),
 		timer:    elapsedtimer.New().Start(),
 		tokens:   tokendisplay.New(),
 		progress: stepprogress.New().SetSteps(steps).SetWidth(15),
@@ -238,6 +230,12 @@ func (m Model) applyData(data DataMsg) Model {
 	return m
 }
 
+// SetTokenBudget sets the token budget shown next to the token counts (0 = none)
+func (m Model) SetTokenBudget(budget int) Model {
+	m.tokens = m.tokens.SetBudget(budget)
+	return m
+}
+
 // refreshViewportContent renders activity groups into the viewport
 func (m *Model) refreshViewportContent() {
 	// Use RenderContent() to get raw content without nested viewport
@@ -251,13 +249,34 @@ func (m *Model) refreshViewportContent() {
 
 // updateViewportSize recalculates viewport dimensions
 func (m *Model) updateViewportSize() {
+	m.vertical = layout.OrientationAuto.Resolve(m.width) == layout.OrientationVertical
+	vpHeight := viewportHeight(m.height, m.vertical)
+	m.viewport.Width = m.width
+	m.viewport.Height = vpHeight
+	m.feed.SetSize(feedWidth(m.width, m.vertical), vpHeight)
+}
+
+// viewportHeight is the height left for the activity viewport above the status bar:
+// a separator and one status line, or one line per status part when stacked
+func viewportHeight(height int, vertical bool) int {
 	statusBarHeight := 2
-	vpHeight := m.height - statusBarHeight
+	if vertical {
+		statusBarHeight = 1 + maxStatusParts
+	}
+	vpHeight := height - statusBarHeight
 	if vpHeight < 3 {
 		vpHeight = 3
 	}
-	m.viewport.Width = m.width
-	m.viewport.Height = vpHeight
+	return vpHeight
+}
+
+// feedWidth leaves room beside the feed for the todo panel on wide terminals; stacked
+// layouts give the feed the full width
+func feedWidth(width int, vertical bool) int {
+	if width > 80 && !vertical {
+		return width - 40 // Reserve space for todo panel
+	}
+	return width
 }
 
 // SetSteps sets the initial step configuration
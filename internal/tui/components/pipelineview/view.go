@@ -17,6 +17,9 @@ var (
 			Foreground(lipgloss.Color("252"))
 )
 
+// maxStatusParts is the number of parts in a full status bar: progress, timer and tokens
+const maxStatusParts = 3
+
 // View renders the pipeline view with scrollable content and fixed status bar
 func (m Model) View() string {
 	// If done, return empty - summary will be printed to stdout after TUI exits
@@ -25,7 +28,7 @@ func (m Model) View() string {
 	}
 
 	// Build status bar: progress │ timer │ tokens
-	var statusParts []string
+	statusParts := make([]string, 0, maxStatusParts)
 	statusParts = append(statusParts, m.progress.View())
 	statusParts = append(statusParts, m.timer.View())
 
@@ -33,7 +36,11 @@ func (m Model) View() string {
 		statusParts = append(statusParts, m.tokens.View())
 	}
 
-	statusBar := statusBarStyle.Render(strings.Join(statusParts, " │ "))
+	separatorText := " │ "
+	if m.vertical {
+		separatorText = "\n" // Stacked, one part per line
+	}
+	statusBar := statusBarStyle.Render(strings.Join(statusParts, separatorText))
 
 	// Separator line
 	separator := separatorStyle.Render(strings.Repeat("─", m.width))
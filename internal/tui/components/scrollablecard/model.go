@@ -5,34 +5,34 @@ package scrollablecard
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/noldarim/noldarim/internal/tui/components/card"
+	"github.com/noldarim/noldarim/internal/tui/components/pager"
 )
 
 // Model represents a scrollable card with focus management
 type Model struct {
-	title    string
-	viewport viewport.Model
-	focused  bool
-	style    card.Style
-	ready    bool
+	title   string
+	pager   pager.Model
+	focused bool
+	style   card.Style
+	ready   bool
 }
 
 // New creates a new scrollable card
 func New(title, content string, width, height int) Model {
-	vp := viewport.New(width, height)
-	vp.SetContent(content)
+	p := pager.New(width, height)
+	p.SetContent(content)
 
 	style := card.DefaultStyle()
 	style.BorderColor = lipgloss.Color("240") // Start unfocused
 
 	return Model{
-		title:    title,
-		viewport: vp,
-		focused:  false,
-		style:    style,
-		ready:    true,
+		title:   title,
+		pager:   p,
+		focused: false,
+		style:   style,
+		ready:   true,
 	}
 }
 
@@ -48,27 +48,27 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Forward scroll commands to viewport
+	// Forward scroll commands to the pager
 	var cmd tea.Cmd
-	m.viewport, cmd = m.viewport.Update(msg)
+	m.pager, cmd = m.pager.Update(msg)
 	return m, cmd
 }
 
 // View renders the scrollable card
 func (m Model) View() string {
-	// Get viewport content
-	content := m.viewport.View()
+	// Get pager content
+	content := m.pager.View()
 
 	// Update style based on focus
 	if m.focused {
-		m.style.BorderColor = lipgloss.Color("86")  // Bright cyan when focused
+		m.style.BorderColor = lipgloss.Color("86")   // Bright cyan when focused
 		m.style.BorderStyle = lipgloss.ThickBorder() // Thicker border when focused
 	} else {
-		m.style.BorderColor = lipgloss.Color("240")      // Dim when not focused
-		m.style.BorderStyle = lipgloss.RoundedBorder()   // Normal border
+		m.style.BorderColor = lipgloss.Color("240")    // Dim when not focused
+		m.style.BorderStyle = lipgloss.RoundedBorder() // Normal border
 	}
 
-	// Render card with viewport content
+	// Render card with pager content
 	return card.Render(m.title, content, m.style)
 }
 
@@ -84,13 +84,12 @@ func (m Model) IsFocused() bool {
 
 // SetSize updates the card dimensions
 func (m *Model) SetSize(width, height int) {
-	m.viewport.Width = width
-	m.viewport.Height = height
+	m.pager.SetSize(width, height)
 }
 
 // SetContent updates the card content
 func (m *Model) SetContent(content string) {
-	m.viewport.SetContent(content)
+	m.pager.SetContent(content)
 }
 
 // SetTitle updates the card title
@@ -103,17 +102,37 @@ func (m Model) GetTitle() string {
 	return m.title
 }
 
+// ScrollToLine scrolls so that the given zero-based content line is at the top
+func (m *Model) ScrollToLine(line int) {
+	m.pager.ScrollToLine(line)
+}
+
+// YOffset returns the zero-based content line at the top of the viewport
+func (m Model) YOffset() int {
+	return m.pager.YOffset()
+}
+
+// Prompting reports whether the card's jump-to-line prompt takes the keyboard
+func (m Model) Prompting() bool {
+	return m.pager.Prompting()
+}
+
+// SetHorizontalStep sets the columns moved per left/right key; 0 disables horizontal scrolling
+func (m *Model) SetHorizontalStep(n int) {
+	m.pager.SetHorizontalStep(n)
+}
+
 // ScrollPercent returns the current scroll percentage (0.0 to 1.0)
 func (m Model) ScrollPercent() float64 {
-	return m.viewport.ScrollPercent()
+	return m.pager.ScrollPercent()
 }
 
 // AtTop returns true if viewport is at the top
 func (m Model) AtTop() bool {
-	return m.viewport.AtTop()
+	return m.pager.AtTop()
 }
 
 // AtBottom returns true if viewport is at the bottom
 func (m Model) AtBottom() bool {
-	return m.viewport.AtBottom()
+	return m.pager.AtBottom()
 }
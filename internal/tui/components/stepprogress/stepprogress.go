@@ -5,6 +5,8 @@ package stepprogress
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -25,18 +27,32 @@ const (
 type Step struct {
 	Name   string
 	Status StepStatus
+
+	// Optional timestamps; when set the view shows elapsed time and an ETA
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+// duration returns how long a finished step took, if its timestamps are known
+func (s Step) duration() (time.Duration, bool) {
+	if s.StartedAt == nil || s.CompletedAt == nil {
+		return 0, false
+	}
+	return s.CompletedAt.Sub(*s.StartedAt), true
 }
 
 // Model represents the step progress component
 type Model struct {
 	steps []Step
 	width int
+	now   func() time.Time
 }
 
 // New creates a new step progress model
 func New() Model {
 	return Model{
 		width: 20,
+		now:   time.Now,
 	}
 }
 
@@ -60,7 +76,8 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, nil
 }
 
-// View renders: [▓▓▓▓▓░░░░░] 2/4 Code Review
+// View renders: [▓▓▓▓▓░░░░░] 2/4 Code Review 1m 5s · ~1m left
+// followed, once steps have completed with known timestamps, by their durations
 func (m Model) View() string {
 	if len(m.steps) == 0 {
 		return ""
@@ -110,9 +127,98 @@ func (m Model) View() string {
 	label := ""
 	if currentName != "" {
 		label = accent.Render(currentName)
+		if timing := m.runningTiming(m.steps[currentIdx]); timing != "" {
+			label += " " + dim.Render(timing)
+		}
 	} else if completed == total {
 		label = success.Render("Complete ✓")
+		if took, ok := m.totalDuration(); ok {
+			label += " " + dim.Render(formatDuration(took))
+		}
+	}
+
+	view := fmt.Sprintf("[%s] %s %s", bar, dim.Render(fmt.Sprintf("%d/%d", displayStep, total)), label)
+	if durations := m.renderDurations(dim, success); durations != "" {
+		view += "\n" + durations
+	}
+	return view
+}
+
+// renderDurations lists how long each completed step took, for the steps whose
+// timestamps are known: "✓ Plan 12s  ✓ Implement 3m 4s"
+func (m Model) renderDurations(dim, success lipgloss.Style) string {
+	var parts []string
+	for _, s := range m.steps {
+		if d, ok := s.duration(); ok && s.Status == StatusCompleted {
+			parts = append(parts, fmt.Sprintf("%s %s %s", success.Render("✓"), s.Name, dim.Render(formatDuration(d))))
+		}
+	}
+	return strings.Join(parts, "  ")
+}
+
+// runningTiming renders the elapsed time of the running step and, once some steps
+// have completed with known durations, an ETA from their average: "1m 5s · ~1m left"
+func (m Model) runningTiming(running Step) string {
+	if running.StartedAt == nil {
+		return ""
 	}
+	elapsed := m.now().Sub(*running.StartedAt)
+	timing := formatDuration(elapsed)
+
+	var sum time.Duration
+	n := 0
+	for _, s := range m.steps {
+		if d, ok := s.duration(); ok && s.Status == StatusCompleted {
+			sum += d
+			n++
+		}
+	}
+	if n > 0 {
+		if left := sum/time.Duration(n) - elapsed; left > 0 {
+			timing += " · ~" + formatETA(left) + " left"
+		}
+	}
+	return timing
+}
+
+// totalDuration returns the summed durations of all steps, if every step's is known
+func (m Model) totalDuration() (time.Duration, bool) {
+	var total time.Duration
+	for _, s := range m.steps {
+		d, ok := s.duration()
+		if !ok {
+			return 0, false
+		}
+		total += d
+	}
+	return total, true
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+	s := d / time.Second
+
+	if h > 0 {
+		return fmt.Sprintf("%dh %dm %ds", h, m, s)
+	}
+	if m > 0 {
+		return fmt.Sprintf("%dm %ds", m, s)
+	}
+	return fmt.Sprintf("%ds", s)
+}
 
-	return fmt.Sprintf("[%s] %s %s", bar, dim.Render(fmt.Sprintf("%d/%d", displayStep, total)), label)
+// formatETA renders an estimate coarsely, to the minute once it is over a minute
+func formatETA(d time.Duration) string {
+	if d = d.Round(time.Second); d < time.Minute {
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+	d = d.Round(time.Minute)
+	if h := d / time.Hour; h > 0 {
+		return fmt.Sprintf("%dh %dm", h, (d-h*time.Hour)/time.Minute)
+	}
+	return fmt.Sprintf("%dm", d/time.Minute)
 }
@@ -0,0 +1,143 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package stepprogress
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+
+// at returns a pointer to the time offset from start
+func at(offset time.Duration) *time.Time {
+	t := start.Add(offset)
+	return &t
+}
+
+func TestModel_RunningTiming(t *testing.T) {
+	now := start.Add(10 * time.Minute)
+
+	tests := []struct {
+		name  string
+		steps []Step
+		want  string
+	}{
+		{
+			name: "eta from the average of completed steps",
+			steps: []Step{
+				{Name: "plan", Status: StatusCompleted, StartedAt: at(0), CompletedAt: at(2 * time.Minute)},
+				{Name: "build", Status: StatusCompleted, StartedAt: at(2 * time.Minute), CompletedAt: at(6 * time.Minute)},
+				{Name: "review", Status: StatusRunning, StartedAt: at(9 * time.Minute)},
+			},
+			want: "1m 0s · ~2m left", // Average 3m, 1m elapsed
+		},
+		{
+			name: "eta under a minute in seconds",
+			steps: []Step{
+				{Name: "plan", Status: StatusCompleted, StartedAt: at(0), CompletedAt: at(9*time.Minute + 40*time.Second)},
+				{Name: "review", Status: StatusRunning, StartedAt: at(time.Minute)},
+			},
+			want: "9m 0s · ~40s left",
+		},
+		{
+			name: "no eta once past the average",
+			steps: []Step{
+				{Name: "plan", Status: StatusCompleted, StartedAt: at(0), CompletedAt: at(time.Minute)},
+				{Name: "review", Status: StatusRunning, StartedAt: at(5 * time.Minute)},
+			},
+			want: "5m 0s",
+		},
+		{
+			name: "steps without timestamps and failed steps are not averaged",
+			steps: []Step{
+				{Name: "plan", Status: StatusCompleted},
+				{Name: "lint", Status: StatusFailed, StartedAt: at(0), CompletedAt: at(8 * time.Minute)},
+				{Name: "build", Status: StatusCompleted, StartedAt: at(0), CompletedAt: at(4 * time.Minute)},
+				{Name: "review", Status: StatusRunning, StartedAt: at(8 * time.Minute)},
+			},
+			want: "2m 0s · ~2m left",
+		},
+		{
+			name: "no completed steps",
+			steps: []Step{
+				{Name: "plan", Status: StatusRunning, StartedAt: at(8*time.Minute + 55*time.Second)},
+				{Name: "build", Status: StatusPending},
+			},
+			want: "1m 5s",
+		},
+		{
+			name: "no start time",
+			steps: []Step{
+				{Name: "plan", Status: StatusCompleted, StartedAt: at(0), CompletedAt: at(time.Minute)},
+				{Name: "review", Status: StatusRunning},
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New().SetSteps(tt.steps)
+			m.now = func() time.Time { return now }
+
+			var running Step
+			for _, s := range tt.steps {
+				if s.Status == StatusRunning {
+					running = s
+				}
+			}
+			assert.Equal(t, tt.want, m.runningTiming(running))
+		})
+	}
+}
+
+func TestFormatETA(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{45 * time.Second, "45s"},
+		{59*time.Second + 600*time.Millisecond, "1m"},
+		{90 * time.Second, "2m"},
+		{61*time.Minute + 20*time.Second, "1h 1m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, formatETA(tt.d))
+		})
+	}
+}
+
+func TestModel_ViewDurations(t *testing.T) {
+	t.Run("completed steps show how long they took", func(t *testing.T) {
+		m := New().SetSteps([]Step{
+			{Name: "plan", Status: StatusCompleted, StartedAt: at(0), CompletedAt: at(12 * time.Second)},
+			{Name: "build", Status: StatusCompleted, StartedAt: at(time.Minute), CompletedAt: at(4*time.Minute + 4*time.Second)},
+			{Name: "lint", Status: StatusCompleted},
+			{Name: "review", Status: StatusRunning, StartedAt: at(5 * time.Minute)},
+		})
+		m.now = func() time.Time { return start.Add(6 * time.Minute) }
+
+		lines := strings.Split(ansi.Strip(m.View()), "\n")
+		assert.Len(t, lines, 2)
+		assert.True(t, strings.HasSuffix(lines[0], "4/4 review 1m 0s · ~38s left"), lines[0])
+		assert.Equal(t, "✓ plan 12s  ✓ build 3m 4s", lines[1])
+	})
+
+	t.Run("unchanged without timestamps", func(t *testing.T) {
+		m := New().SetSteps([]Step{
+			{Name: "plan", Status: StatusCompleted},
+			{Name: "review", Status: StatusRunning},
+		})
+		view := ansi.Strip(m.View())
+		assert.NotContains(t, view, "\n")
+		assert.True(t, strings.HasSuffix(view, "2/2 review"), view)
+	})
+}
@@ -5,7 +5,9 @@ package taskinfocard
 
 import (
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
@@ -13,6 +15,11 @@ import (
 
 // Render creates a card displaying task information
 func Render(task *models.Task) string {
+	return RenderWithPrompt(task, "")
+}
+
+// RenderWithPrompt is Render plus the prompt the agent was started with, when known
+func RenderWithPrompt(task *models.Task, prompt string) string {
 	// Task Title
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -47,13 +54,26 @@ func Render(task *models.Task) string {
 		Foreground(lipgloss.Color("241"))
 	taskID := idStyle.Render("ID: " + task.ID)
 
-	// Combine all elements
-	content := lipgloss.JoinVertical(
-		lipgloss.Left,
+	sections := []string{
 		title,
 		"",
 		description,
 		"",
+	}
+
+	// Task prompt as sent to the agent (from the transcript)
+	if prompt != "" {
+		labelStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("252"))
+		promptStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245"))
+		const maxPromptLength = 500
+		prompt = truncateString(prompt, maxPromptLength)
+		sections = append(sections, labelStyle.Render("Task prompt:"), promptStyle.Render(prompt), "")
+	}
+
+	sections = append(sections,
 		status,
 		createdAt,
 		updatedAt,
@@ -61,7 +81,24 @@ func Render(task *models.Task) string {
 		taskID,
 	)
 
-	return content
+	// Combine all elements
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// truncateString shortens s to at most maxLen bytes without splitting a rune, replacing
+// invalid UTF-8
+func truncateString(s string, maxLen int) string {
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "\uFFFD")
+	}
+	if len(s) <= maxLen {
+		return s
+	}
+	cut := maxLen - 3
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
 }
 
 func getStatusDisplay(status models.TaskStatus) (icon, color, text string) {
@@ -0,0 +1,38 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package taskinfocard
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateString(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{"short", "fix the tests", 20, "fix the tests"},
+		{"ascii", "abcdefghij", 8, "abcde..."},
+		{"does not split a rune", "ab€€", 7, "ab..."},
+		{"invalid utf-8 is replaced", "ab\xffcd", 20, "ab�cd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateString(tt.in, tt.max)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, utf8.ValidString(got))
+			assert.LessOrEqual(t, len(got), max(tt.max, len(tt.want)))
+		})
+	}
+
+	long := strings.Repeat("é", 400)
+	assert.True(t, utf8.ValidString(truncateString(long, 500)))
+}
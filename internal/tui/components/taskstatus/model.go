@@ -25,14 +25,32 @@ const (
 	UIStatePending UIState = "pending" // Task creation pending
 	UIStateFailed  UIState = "failed"  // Task creation failed
 	UIStateCreated UIState = "created" // Task creation completed
+	UIStateQueued  UIState = "queued"  // Waiting for a free slot under the concurrent task limit
 )
 
+// StatusStyle is how a task status is displayed
+type StatusStyle struct {
+	Foreground lipgloss.TerminalColor
+	Symbol     string
+	Animated   bool // Show the spinner instead of Symbol
+}
+
+// DefaultTheme returns the status styles used unless WithTheme overrides them
+func DefaultTheme() map[models.TaskStatus]StatusStyle {
+	return map[models.TaskStatus]StatusStyle{
+		models.TaskStatusPending:    {Foreground: lipgloss.Color("241"), Symbol: "○"},
+		models.TaskStatusInProgress: {Foreground: lipgloss.Color("205"), Animated: true},
+		models.TaskStatusCompleted:  {Foreground: lipgloss.Color("76"), Symbol: "✓"},
+	}
+}
+
 type Model struct {
 	text    string
 	status  models.TaskStatus
 	uiState UIState // UI-specific state for visual feedback
 	spinner spinner.Model
 	width   int
+	theme   map[models.TaskStatus]StatusStyle
 }
 
 func New(text string, status models.TaskStatus) Model {
@@ -45,12 +63,13 @@ func New(text string, status models.TaskStatus) Model {
 		status:  status,
 		spinner: s,
 		width:   0,
+		theme:   DefaultTheme(),
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	// Start spinner for in-progress tasks or pending UI state
-	if m.status == models.TaskStatusInProgress || m.uiState == UIStatePending {
+	// Start spinner for animated statuses or pending UI state
+	if m.animated() {
 		return m.spinner.Tick
 	}
 	return nil
@@ -60,21 +79,21 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case TickMsg:
 		// Update spinner for in-progress tasks or pending UI state
-		if m.status == models.TaskStatusInProgress || m.uiState == UIStatePending {
+		if m.animated() {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(spinner.TickMsg{})
 			return m, cmd
 		}
 	case GlobalTickMsg:
 		// Handle global tick messages from main TUI
-		if m.status == models.TaskStatusInProgress || m.uiState == UIStatePending {
+		if m.animated() {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(spinner.TickMsg{})
 			return m, cmd
 		}
 	case spinner.TickMsg:
 		// Update spinner for in-progress tasks or pending UI state
-		if m.status == models.TaskStatusInProgress || m.uiState == UIStatePending {
+		if m.animated() {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
 			return m, cmd
@@ -83,6 +102,11 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// animated reports whether the spinner is shown
+func (m Model) animated() bool {
+	return m.uiState == UIStatePending || m.theme[m.status].Animated
+}
+
 func (m Model) View() string {
 	if m.width > 0 && m.width < 20 {
 		return m.renderCompact()
@@ -112,17 +136,18 @@ func (m Model) getIcon() string {
 		return "✗" // X mark for failed
 	case UIStateCreated:
 		return "✓" // Check mark for created
+	case UIStateQueued:
+		return "◷" // Clock for queued
 	default:
 		// Normal state: show task status
-		switch m.status {
-		case models.TaskStatusPending:
-			return "○"
-		case models.TaskStatusInProgress:
+		style, ok := m.theme[m.status]
+		switch {
+		case !ok:
+			return "?"
+		case style.Animated:
 			return m.spinner.View()
-		case models.TaskStatusCompleted:
-			return "✓"
 		default:
-			return "?"
+			return style.Symbol
 		}
 	}
 }
@@ -136,18 +161,15 @@ func (m Model) getStyle() lipgloss.Style {
 		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")) // Red for failed
 	case UIStateCreated:
 		return lipgloss.NewStyle().Foreground(lipgloss.Color("82")) // Green for created
+	case UIStateQueued:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("110")) // Blue for queued
 	default:
 		// Normal state: show task status colors
-		switch m.status {
-		case models.TaskStatusPending:
-			return lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		case models.TaskStatusInProgress:
-			return lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
-		case models.TaskStatusCompleted:
-			return lipgloss.NewStyle().Foreground(lipgloss.Color("76"))
-		default:
+		style, ok := m.theme[m.status]
+		if !ok || style.Foreground == nil {
 			return lipgloss.NewStyle().Foreground(lipgloss.Color("red"))
 		}
+		return lipgloss.NewStyle().Foreground(style.Foreground)
 	}
 }
 
@@ -180,6 +202,8 @@ func (m Model) getText() string {
 		return "FAILED"
 	case UIStateCreated:
 		return "CREATED"
+	case UIStateQueued:
+		return "QUEUED"
 	default:
 		// For normal state, return task status text
 		switch m.status {
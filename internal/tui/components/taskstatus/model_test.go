@@ -10,6 +10,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 )
 
@@ -284,6 +285,11 @@ func TestUIStates(t *testing.T) {
 			uiState:  UIStateCreated,
 			expected: "CREATED",
 		},
+		{
+			name:     "queued UI state",
+			uiState:  UIStateQueued,
+			expected: "QUEUED",
+		},
 		{
 			name:     "normal UI state shows task status",
 			uiState:  UIStateNormal,
@@ -313,3 +319,50 @@ func TestSetUIState(t *testing.T) {
 		t.Errorf("expected UI state %v, got %v", UIStateFailed, model.uiState)
 	}
 }
+
+func TestWithTheme(t *testing.T) {
+	theme := map[models.TaskStatus]StatusStyle{
+		models.TaskStatusPending:    {Foreground: lipgloss.Color("0"), Symbol: "·"},
+		models.TaskStatusInProgress: {Foreground: lipgloss.Color("4"), Symbol: "»"},
+		models.TaskStatusFailed:     {Foreground: lipgloss.Color("1"), Symbol: "✗", Animated: true},
+	}
+
+	tests := []struct {
+		name     string
+		status   models.TaskStatus
+		icon     string
+		animated bool
+	}{
+		{name: "themed symbol", status: models.TaskStatusPending, icon: "·"},
+		{name: "animation turned off", status: models.TaskStatusInProgress, icon: "»"},
+		{name: "animation turned on", status: models.TaskStatusFailed, animated: true},
+		{name: "missing from theme", status: models.TaskStatusCompleted, icon: "?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewWithOptions("test", tt.status, WithTheme(theme))
+
+			if got := model.Init() != nil; got != tt.animated {
+				t.Errorf("expected animated %v, got %v", tt.animated, got)
+			}
+			if tt.animated {
+				return
+			}
+			if icon := model.getIcon(); icon != tt.icon {
+				t.Errorf("expected icon %q, got %q", tt.icon, icon)
+			}
+		})
+	}
+}
+
+func TestDefaultTheme(t *testing.T) {
+	theme := DefaultTheme()
+
+	if !theme[models.TaskStatusInProgress].Animated {
+		t.Error("in progress should animate by default")
+	}
+	if theme[models.TaskStatusCompleted].Symbol != "✓" {
+		t.Errorf("expected completed symbol %q, got %q", "✓", theme[models.TaskStatusCompleted].Symbol)
+	}
+}
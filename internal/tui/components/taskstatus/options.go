@@ -29,6 +29,14 @@ func WithWidth(width int) Option {
 	}
 }
 
+// WithTheme sets how each task status is displayed; statuses missing from theme show
+// as unknown. Animated statuses use the spinner, styled by WithSpinnerStyle.
+func WithTheme(theme map[models.TaskStatus]StatusStyle) Option {
+	return func(m *Model) {
+		m.theme = theme
+	}
+}
+
 func NewWithOptions(text string, status models.TaskStatus, opts ...Option) Model {
 	m := New(text, status)
 	for _, opt := range opts {
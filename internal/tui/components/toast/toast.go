@@ -0,0 +1,290 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package toast
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/noldarim/noldarim/internal/tui/layout"
+)
+
+const (
+	// DefaultDuration is how long a toast stays visible when ShowMsg.Duration is zero
+	DefaultDuration = 4 * time.Second
+	// DefaultMaxVisible is the number of stacked toasts kept before the oldest is dropped
+	DefaultMaxVisible = 3
+
+	maxToastWidth = 48
+)
+
+// Level is the severity of a toast
+type Level int
+
+const (
+	LevelInfo Level = iota
+	LevelSuccess
+	LevelWarning
+	LevelError
+)
+
+// ShowMsg asks the toast model to display a notification
+type ShowMsg struct {
+	Level    Level
+	Message  string
+	Duration time.Duration // Zero uses DefaultDuration
+}
+
+// dismissMsg is scheduled when a toast is shown and removes it once its time is up
+type dismissMsg struct {
+	id int
+}
+
+// Show returns a command that displays a toast. Screens return this instead of
+// repurposing protocol events for transient feedback.
+func Show(level Level, message string) tea.Cmd {
+	return func() tea.Msg {
+		return ShowMsg{Level: level, Message: message}
+	}
+}
+
+// Success, Info, Warning and Error are shorthands for Show
+func Success(message string) tea.Cmd { return Show(LevelSuccess, message) }
+func Info(message string) tea.Cmd    { return Show(LevelInfo, message) }
+func Warning(message string) tea.Cmd { return Show(LevelWarning, message) }
+func Error(message string) tea.Cmd   { return Show(LevelError, message) }
+
+type toast struct {
+	id      int
+	level   Level
+	message string
+}
+
+// Model holds the stack of visible toasts, oldest first
+type Model struct {
+	toasts     []toast
+	nextID     int
+	maxVisible int
+	width      int
+}
+
+// New creates a new toast model
+func New() Model {
+	return Model{
+		maxVisible: DefaultMaxVisible,
+	}
+}
+
+// SetWidth sets the width of the area toasts are overlaid on
+func (m *Model) SetWidth(width int) {
+	m.width = width
+}
+
+// Len returns the number of visible toasts
+func (m Model) Len() int {
+	return len(m.toasts)
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case protocol.NotificationEvent:
+		return m.Update(ShowMsg{Level: levelFromProtocol(msg.Level), Message: msg.Message})
+
+	case protocol.BudgetExceededEvent:
+		return m.Update(budgetExceededToast(msg))
+
+	case protocol.AgentIdleEvent:
+		return m.Update(agentIdleToast(msg))
+
+	case protocol.TaskCleanedUpEvent:
+		return m.Update(taskCleanedUpToast(msg))
+
+	case ShowMsg:
+		if msg.Message == "" {
+			return m, nil
+		}
+		id := m.nextID
+		m.nextID++
+		m.toasts = append(m.toasts, toast{id: id, level: msg.Level, message: msg.Message})
+		// Dismiss oldest-first when the stack is full
+		if len(m.toasts) > m.maxVisible {
+			m.toasts = m.toasts[len(m.toasts)-m.maxVisible:]
+		}
+
+		duration := msg.Duration
+		if duration <= 0 {
+			duration = DefaultDuration
+		}
+		return m, tea.Tick(duration, func(time.Time) tea.Msg {
+			return dismissMsg{id: id}
+		})
+
+	case dismissMsg:
+		for i, t := range m.toasts {
+			if t.id == msg.id {
+				m.toasts = append(m.toasts[:i:i], m.toasts[i+1:]...)
+				break
+			}
+		}
+	}
+	return m, nil
+}
+
+// View renders the toast stack, newest at the bottom. Empty when there are no toasts.
+func (m Model) View() string {
+	if len(m.toasts) == 0 {
+		return ""
+	}
+
+	width := maxToastWidth
+	if m.width > 0 && m.width-2 < width {
+		width = m.width - 2
+	}
+
+	rendered := make([]string, 0, len(m.toasts))
+	for _, t := range m.toasts {
+		rendered = append(rendered, styleFor(t.level).Width(width).Render(iconFor(t.level)+" "+t.message))
+	}
+	return lipgloss.JoinVertical(lipgloss.Right, rendered...)
+}
+
+// Overlay draws the toast stack over the top-right corner of base
+func (m Model) Overlay(base string) string {
+	stack := m.View()
+	if stack == "" {
+		return base
+	}
+
+	baseLines := strings.Split(base, "\n")
+	stackLines := strings.Split(stack, "\n")
+	stackWidth := lipgloss.Width(stack)
+
+	width := m.width
+	if width <= 0 {
+		width = lipgloss.Width(base)
+	}
+	left := width - stackWidth
+	if left < 0 {
+		left = 0
+	}
+
+	// Start below the header line so the title stays readable
+	top := 1
+	for i, line := range stackLines {
+		row := top + i
+		for row >= len(baseLines) {
+			baseLines = append(baseLines, "")
+		}
+		prefix := ansi.Truncate(baseLines[row], left, "")
+		if pad := left - ansi.StringWidth(prefix); pad > 0 {
+			prefix += strings.Repeat(" ", pad)
+		}
+		baseLines[row] = prefix + line
+	}
+
+	return strings.Join(baseLines, "\n")
+}
+
+func levelFromProtocol(level protocol.NotificationLevel) Level {
+	switch level {
+	case protocol.NotificationSuccess:
+		return LevelSuccess
+	case protocol.NotificationWarning:
+		return LevelWarning
+	case protocol.NotificationError:
+		return LevelError
+	default:
+		return LevelInfo
+	}
+}
+
+// budgetExceededToast warns about a task over its token budget, as an error when it is being cancelled
+func budgetExceededToast(e protocol.BudgetExceededEvent) ShowMsg {
+	if e.Cancelled {
+		return ShowMsg{Level: LevelError, Message: fmt.Sprintf("Token budget exceeded (%d / %d), cancelling task", e.TokensUsed, e.Budget)}
+	}
+	return ShowMsg{Level: LevelWarning, Message: fmt.Sprintf("Token budget exceeded (%d / %d)", e.TokensUsed, e.Budget)}
+}
+
+// agentIdleToast warns about an agent that stopped writing to its transcript
+func agentIdleToast(e protocol.AgentIdleEvent) ShowMsg {
+	idleFor := e.IdleFor.Round(time.Second)
+	if e.Finalizing {
+		return ShowMsg{Level: LevelWarning, Message: fmt.Sprintf("Agent idle for %s, finalizing task with its changes so far", idleFor)}
+	}
+	return ShowMsg{Level: LevelWarning, Message: fmt.Sprintf("Agent idle for %s", idleFor)}
+}
+
+// taskCleanedUpToast sums up what was removed along with a deleted task, and what was kept
+func taskCleanedUpToast(e protocol.TaskCleanedUpEvent) ShowMsg {
+	var removed, kept []string
+	switch {
+	case e.WorktreeRemoved:
+		removed = append(removed, "worktree")
+	case e.WorktreeSkipped != "":
+		kept = append(kept, fmt.Sprintf("worktree (%s)", e.WorktreeSkipped))
+	}
+	switch {
+	case e.BranchDeleted:
+		removed = append(removed, "branch "+e.Branch)
+	case e.BranchSkipped != "":
+		kept = append(kept, fmt.Sprintf("branch %s (%s)", e.Branch, e.BranchSkipped))
+	}
+
+	var parts []string
+	if len(removed) > 0 {
+		parts = append(parts, "removed "+strings.Join(removed, " and "))
+	}
+	if len(kept) > 0 {
+		parts = append(parts, "kept "+strings.Join(kept, " and "))
+	}
+	if len(parts) == 0 {
+		return ShowMsg{}
+	}
+	level := LevelInfo
+	if len(kept) > 0 {
+		level = LevelWarning
+	}
+	return ShowMsg{Level: level, Message: "Task deleted: " + strings.Join(parts, ", ")}
+}
+
+func styleFor(level Level) lipgloss.Style {
+	color := layout.SecondaryColor
+	switch level {
+	case LevelSuccess:
+		color = layout.AccentColor
+	case LevelWarning:
+		color = layout.WarningColor
+	case LevelError:
+		color = layout.ErrorColor
+	}
+	return lipgloss.NewStyle().
+		Foreground(layout.TextColor).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(color).
+		PaddingLeft(1).
+		PaddingRight(1)
+}
+
+func iconFor(level Level) string {
+	switch level {
+	case LevelSuccess:
+		return "✓"
+	case LevelWarning:
+		return "!"
+	case LevelError:
+		return "✗"
+	default:
+		return "i"
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package toast
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdate_ShowAndDismiss(t *testing.T) {
+	m := New()
+
+	m, cmd := m.Update(ShowMsg{Level: LevelSuccess, Message: "saved"})
+	require.NotNil(t, cmd, "showing a toast should schedule its dismissal")
+	assert.Equal(t, 1, m.Len())
+	assert.Contains(t, m.View(), "saved")
+
+	m, _ = m.Update(dismissMsg{id: 0})
+	assert.Equal(t, 0, m.Len())
+	assert.Empty(t, m.View())
+}
+
+func TestUpdate_EmptyMessageIgnored(t *testing.T) {
+	m, cmd := New().Update(ShowMsg{Level: LevelInfo})
+	assert.Nil(t, cmd)
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestUpdate_StackDropsOldestFirst(t *testing.T) {
+	m := New()
+	for _, msg := range []string{"one", "two", "three", "four"} {
+		m, _ = m.Update(ShowMsg{Message: msg})
+	}
+
+	assert.Equal(t, DefaultMaxVisible, m.Len())
+	view := m.View()
+	assert.NotContains(t, view, "one")
+	assert.Contains(t, view, "four")
+	assert.Less(t, strings.Index(view, "two"), strings.Index(view, "four"), "newest toast renders at the bottom")
+
+	// Dismissing an already dropped toast is a no-op
+	m, _ = m.Update(dismissMsg{id: 0})
+	assert.Equal(t, DefaultMaxVisible, m.Len())
+}
+
+func TestUpdate_NotificationEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		level protocol.NotificationLevel
+		want  Level
+	}{
+		{name: "success", level: protocol.NotificationSuccess, want: LevelSuccess},
+		{name: "warning", level: protocol.NotificationWarning, want: LevelWarning},
+		{name: "error", level: protocol.NotificationError, want: LevelError},
+		{name: "unknown defaults to info", level: "", want: LevelInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, cmd := New().Update(protocol.NotificationEvent{Level: tt.level, Message: "hello"})
+			require.NotNil(t, cmd)
+			require.Equal(t, 1, m.Len())
+			assert.Equal(t, tt.want, m.toasts[0].level)
+		})
+	}
+}
+
+func TestUpdate_BudgetExceededEvent(t *testing.T) {
+	m, _ := New().Update(protocol.BudgetExceededEvent{Budget: 1000, TokensUsed: 1200})
+	require.Equal(t, 1, m.Len())
+	assert.Equal(t, LevelWarning, m.toasts[0].level)
+
+	m, _ = New().Update(protocol.BudgetExceededEvent{Budget: 1000, TokensUsed: 1200, Cancelled: true})
+	require.Equal(t, 1, m.Len())
+	assert.Equal(t, LevelError, m.toasts[0].level)
+	assert.Contains(t, m.toasts[0].message, "cancelling")
+}
+
+func TestUpdate_AgentIdleEvent(t *testing.T) {
+	m, _ := New().Update(protocol.AgentIdleEvent{IdleFor: 10*time.Minute + 300*time.Millisecond})
+	require.Equal(t, 1, m.Len())
+	assert.Equal(t, LevelWarning, m.toasts[0].level)
+	assert.Equal(t, "Agent idle for 10m0s", m.toasts[0].message)
+
+	m, _ = New().Update(protocol.AgentIdleEvent{IdleFor: time.Minute, Finalizing: true})
+	require.Equal(t, 1, m.Len())
+	assert.Contains(t, m.toasts[0].message, "finalizing")
+}
+
+func TestUpdate_TaskCleanedUpEvent(t *testing.T) {
+	m, _ := New().Update(protocol.TaskCleanedUpEvent{WorktreeRemoved: true, Branch: "task-1", BranchDeleted: true})
+	require.Equal(t, 1, m.Len())
+	assert.Equal(t, LevelInfo, m.toasts[0].level)
+	assert.Equal(t, "Task deleted: removed worktree and branch task-1", m.toasts[0].message)
+
+	m, _ = New().Update(protocol.TaskCleanedUpEvent{WorktreeRemoved: true, Branch: "task-1", BranchSkipped: "unmerged commits"})
+	require.Equal(t, 1, m.Len())
+	assert.Equal(t, LevelWarning, m.toasts[0].level)
+	assert.Equal(t, "Task deleted: removed worktree, kept branch task-1 (unmerged commits)", m.toasts[0].message)
+
+	m, _ = New().Update(protocol.TaskCleanedUpEvent{Branch: "task-1"})
+	assert.Equal(t, 0, m.Len(), "nothing to report")
+}
+
+func TestOverlay(t *testing.T) {
+	base := strings.Repeat(strings.Repeat(".", 80)+"\n", 9) + strings.Repeat(".", 80)
+
+	m := New()
+	m.SetWidth(80)
+	assert.Equal(t, base, m.Overlay(base), "no toasts leaves the view untouched")
+
+	m, _ = m.Update(ShowMsg{Message: "hello"})
+	out := m.Overlay(base)
+	lines := strings.Split(out, "\n")
+
+	require.Len(t, lines, 10)
+	assert.Equal(t, strings.Repeat(".", 80), lines[0], "header line is not covered")
+	assert.Contains(t, out, "hello")
+	for _, line := range lines {
+		assert.LessOrEqual(t, ansi.StringWidth(line), 80)
+	}
+}
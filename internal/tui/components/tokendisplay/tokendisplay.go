@@ -5,9 +5,29 @@ package tokendisplay
 
 import (
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/noldarim/noldarim/internal/aiobs/pricing"
+	"github.com/noldarim/noldarim/internal/tui/layout"
+)
+
+// WarnThreshold is the fraction of the budget at which the usage bar turns to the warning color
+const WarnThreshold = 0.8
+
+// defaultBarWidth is the number of cells in the budget usage bar
+const defaultBarWidth = 10
+
+// BudgetState classifies token usage against a budget
+type BudgetState int
+
+const (
+	BudgetNone     BudgetState = iota // No budget set
+	BudgetOK                          // Below WarnThreshold
+	BudgetWarning                     // At or above WarnThreshold
+	BudgetExceeded                    // Above the budget
 )
 
 // TokenData holds the token counts for display
@@ -16,18 +36,22 @@ type TokenData struct {
 	OutputTokens      int
 	CacheReadTokens   int
 	CacheCreateTokens int
+	Model             string // Optional; when its pricing is known the view shows the estimated cost
 }
 
 // Model represents the token display component
 type Model struct {
-	data  TokenData
-	style lipgloss.Style
+	data     TokenData
+	budget   int
+	barWidth int
+	style    lipgloss.Style
 }
 
 // New creates a new token display model
 func New() Model {
 	return Model{
-		style: lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
+		barWidth: defaultBarWidth,
+		style:    lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
 	}
 }
 
@@ -39,7 +63,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, nil
 }
 
-// View renders: In: 45,230 (12k cache) | Out: 8,120 (+5k cache)
+// View renders: In: 45,230 (12k cache) | Out: 8,120 (+5k cache) | $0.42
+// followed by a budget usage bar when a budget is set. The cost is shown only
+// for a model with known pricing.
 func (m Model) View() string {
 	bold := m.style.Bold(true).Foreground(lipgloss.Color("252"))
 	dim := m.style.Foreground(lipgloss.Color("239"))
@@ -54,7 +80,68 @@ func (m Model) View() string {
 		output += dim.Render(fmt.Sprintf(" (+%s cache)", formatCompact(m.data.CacheCreateTokens)))
 	}
 
-	return input + dim.Render(" | ") + output
+	view := input + dim.Render(" | ") + output
+	if cost, ok := m.Cost(); ok {
+		view += dim.Render(" | ") + bold.Render(formatCost(cost))
+	}
+	if m.budget > 0 {
+		view += dim.Render(" | ") + m.BudgetView()
+	}
+	return view
+}
+
+// BudgetView renders the usage bar: ███████░░░ 72% of 100k
+func (m Model) BudgetView() string {
+	if m.budget <= 0 {
+		return ""
+	}
+	ratio := float64(m.Used()) / float64(m.budget)
+
+	filled := int(ratio * float64(m.barWidth))
+	if filled > m.barWidth {
+		filled = m.barWidth
+	}
+	if filled == 0 && m.Used() > 0 {
+		filled = 1 // Any usage is visible
+	}
+
+	color := layout.AccentColor
+	switch m.State() {
+	case BudgetWarning:
+		color = layout.WarningColor
+	case BudgetExceeded:
+		color = layout.ErrorColor
+	}
+	bar := lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("239")).Render(strings.Repeat("░", m.barWidth-filled))
+	label := lipgloss.NewStyle().Foreground(color).Render(fmt.Sprintf(" %d%% of %s", int(ratio*100), formatCompact(m.budget)))
+	return bar + label
+}
+
+// State classifies current usage against the budget
+func (m Model) State() BudgetState {
+	if m.budget <= 0 {
+		return BudgetNone
+	}
+	used := m.Used()
+	switch {
+	case used > m.budget:
+		return BudgetExceeded
+	case float64(used) >= WarnThreshold*float64(m.budget):
+		return BudgetWarning
+	default:
+		return BudgetOK
+	}
+}
+
+// Cost returns the estimated USD cost of the tokens, false when the model is unset or unknown
+func (m Model) Cost() (float64, bool) {
+	return pricing.EstimateCost(m.data.Model, m.data.InputTokens, m.data.OutputTokens, m.data.CacheReadTokens, m.data.CacheCreateTokens)
+}
+
+// Used returns the input+output tokens counted against the budget
+func (m Model) Used() int {
+	return m.data.InputTokens + m.data.OutputTokens
 }
 
 // SetData updates the token data
@@ -63,6 +150,20 @@ func (m Model) SetData(data TokenData) Model {
 	return m
 }
 
+// SetBudget sets the input+output token budget (0 hides the usage bar)
+func (m Model) SetBudget(budget int) Model {
+	m.budget = budget
+	return m
+}
+
+// SetBarWidth sets the number of cells in the usage bar
+func (m Model) SetBarWidth(width int) Model {
+	if width > 0 {
+		m.barWidth = width
+	}
+	return m
+}
+
 func formatNumber(n int) string {
 	if n < 1000 {
 		return fmt.Sprintf("%d", n)
@@ -78,6 +179,14 @@ func formatNumber(n int) string {
 	return string(result)
 }
 
+// formatCost renders dollars with cents, or with more precision below a cent
+func formatCost(cost float64) string {
+	if cost > 0 && cost < 0.01 {
+		return fmt.Sprintf("$%.4f", cost)
+	}
+	return fmt.Sprintf("$%.2f", cost)
+}
+
 func formatCompact(n int) string {
 	if n < 1000 {
 		return fmt.Sprintf("%d", n)
@@ -0,0 +1,63 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package tokendisplay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget int
+		data   TokenData
+		want   BudgetState
+	}{
+		{name: "no budget", budget: 0, data: TokenData{InputTokens: 500}, want: BudgetNone},
+		{name: "below threshold", budget: 1000, data: TokenData{InputTokens: 500, OutputTokens: 200}, want: BudgetOK},
+		{name: "at threshold", budget: 1000, data: TokenData{InputTokens: 600, OutputTokens: 200}, want: BudgetWarning},
+		{name: "exactly at budget", budget: 1000, data: TokenData{InputTokens: 1000}, want: BudgetWarning},
+		{name: "over budget", budget: 1000, data: TokenData{InputTokens: 900, OutputTokens: 101}, want: BudgetExceeded},
+		{name: "cache tokens do not count", budget: 1000, data: TokenData{InputTokens: 100, CacheReadTokens: 5000}, want: BudgetOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New().SetBudget(tt.budget).SetData(tt.data)
+			assert.Equal(t, tt.want, m.State())
+		})
+	}
+}
+
+func TestBudgetView(t *testing.T) {
+	assert.Empty(t, New().BudgetView(), "no bar without a budget")
+
+	m := New().SetBudget(100000).SetData(TokenData{InputTokens: 150000})
+	assert.Contains(t, m.BudgetView(), "150% of 100k")
+	assert.Contains(t, m.View(), "150% of 100k")
+}
+
+func TestCost(t *testing.T) {
+	data := TokenData{InputTokens: 100000, OutputTokens: 8000, Model: "claude-sonnet-4-20250514"}
+	m := New().SetData(data)
+	cost, ok := m.Cost()
+	assert.True(t, ok)
+	assert.InDelta(t, 0.42, cost, 1e-9)
+	assert.Contains(t, m.View(), "$0.42")
+
+	// Unknown or unset models hide the cost instead of showing $0
+	data.Model = "some-local-model"
+	m = m.SetData(data)
+	_, ok = m.Cost()
+	assert.False(t, ok)
+	assert.NotContains(t, m.View(), "$")
+
+	data.Model = ""
+	assert.NotContains(t, m.SetData(data).View(), "$")
+
+	assert.Equal(t, "$0.0030", formatCost(0.003))
+	assert.Equal(t, "$12.50", formatCost(12.5))
+}
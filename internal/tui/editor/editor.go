@@ -0,0 +1,63 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package editor opens files in the user's editor from the TUI. The editor command
+// comes from config.Editor, falling back to $VISUAL and then $EDITOR.
+package editor
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ErrNoEditor is returned when neither config nor the environment names an editor
+var ErrNoEditor = errors.New("no editor configured: set editor in config, $VISUAL or $EDITOR")
+
+var (
+	configuredMu sync.RWMutex
+	configured   string
+)
+
+// Configure sets the editor command (e.g. "code --wait"); empty falls back to the environment
+func Configure(command string) {
+	configuredMu.Lock()
+	configured = strings.TrimSpace(command)
+	configuredMu.Unlock()
+}
+
+// Resolve returns the editor command split into program and arguments
+func Resolve() ([]string, error) {
+	configuredMu.RLock()
+	command := configured
+	configuredMu.RUnlock()
+
+	for _, candidate := range []string{command, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if args := strings.Fields(candidate); len(args) > 0 {
+			return args, nil
+		}
+	}
+	return nil, ErrNoEditor
+}
+
+// FinishedMsg is sent once the editor exits; Err is set when it could not run or failed
+type FinishedMsg struct {
+	Path string
+	Err  error
+}
+
+// Open suspends the TUI and runs the editor on path, resuming when it exits
+func Open(path string) (tea.Cmd, error) {
+	args, err := Resolve()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return FinishedMsg{Path: path, Err: err}
+	}), nil
+}
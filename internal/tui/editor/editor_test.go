@@ -0,0 +1,36 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package editor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve_Precedence(t *testing.T) {
+	t.Cleanup(func() { Configure("") })
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	Configure("")
+	_, err := Resolve()
+	assert.ErrorIs(t, err, ErrNoEditor)
+
+	t.Setenv("EDITOR", "vi")
+	args, err := Resolve()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vi"}, args)
+
+	t.Setenv("VISUAL", "emacs -nw")
+	args, err = Resolve()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"emacs", "-nw"}, args)
+
+	Configure("  code --wait ")
+	args, err = Resolve()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"code", "--wait"}, args)
+}
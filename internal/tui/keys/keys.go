@@ -0,0 +1,168 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package keys is the registry of TUI keybindings. Every bindable action has a default
+// binding that users can override through config.Keys; screens build their key maps
+// from the registry and generate their footer help from the same bindings.
+package keys
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/noldarim/noldarim/internal/tui/layout"
+)
+
+// Action names a bindable action. Config overrides refer to actions by this name.
+type Action string
+
+const (
+	Quit       Action = "quit"
+	Back       Action = "back"
+	NextTab    Action = "next_tab"
+	PrevTab    Action = "prev_tab"
+	Tab1       Action = "tab_1"
+	Tab2       Action = "tab_2"
+	Tab3       Action = "tab_3"
+	Up         Action = "up"
+	Down       Action = "down"
+	Select     Action = "select"
+	New        Action = "new"
+	Edit       Action = "edit"
+	Retry      Action = "retry"
+	Delete     Action = "delete"
+	ToggleWrap Action = "toggle_wrap"
+	OpenEditor Action = "open_editor"
+	History    Action = "history"
+	Copy       Action = "copy"
+
+	PageUp       Action = "page_up"
+	PageDown     Action = "page_down"
+	HalfPageUp   Action = "half_page_up"
+	HalfPageDown Action = "half_page_down"
+	Top          Action = "top"
+	Bottom       Action = "bottom"
+	JumpToLine   Action = "jump_to_line"
+)
+
+// defaults lists every action with its default keys and help text
+var defaults = map[Action]key.Binding{
+	Quit:       key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	Back:       key.NewBinding(key.WithKeys("esc", "backspace"), key.WithHelp("esc", "back")),
+	NextTab:    key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next tab")),
+	PrevTab:    key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "previous tab")),
+	Tab1:       key.NewBinding(key.WithKeys("1"), key.WithHelp("1", "tab 1")),
+	Tab2:       key.NewBinding(key.WithKeys("2"), key.WithHelp("2", "tab 2")),
+	Tab3:       key.NewBinding(key.WithKeys("3"), key.WithHelp("3", "tab 3")),
+	Up:         key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+	Down:       key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+	Select:     key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
+	New:        key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new")),
+	Edit:       key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "edit")),
+	Retry:      key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "retry")),
+	Delete:     key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
+	ToggleWrap: key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "toggle wrap")),
+	OpenEditor: key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "open in editor")),
+	History:    key.NewBinding(key.WithKeys("h"), key.WithHelp("h", "run history")),
+	Copy:       key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy")),
+
+	PageUp:       key.NewBinding(key.WithKeys("pgup", "ctrl+b"), key.WithHelp("pgup", "page up")),
+	PageDown:     key.NewBinding(key.WithKeys("pgdown", "ctrl+f"), key.WithHelp("pgdn", "page down")),
+	HalfPageUp:   key.NewBinding(key.WithKeys("ctrl+u"), key.WithHelp("ctrl+u", "half page up")),
+	HalfPageDown: key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "half page down")),
+	Top:          key.NewBinding(key.WithKeys("g", "home"), key.WithHelp("g", "top")),
+	Bottom:       key.NewBinding(key.WithKeys("G", "end"), key.WithHelp("G", "bottom")),
+	JumpToLine:   key.NewBinding(key.WithKeys(":"), key.WithHelp(":N", "go to line")),
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = copyBindings(defaults)
+)
+
+// Configure replaces the registry with the defaults plus overrides, given as
+// action → comma-separated keys (e.g. "quit": "q,ctrl+q"). An empty value disables
+// the action. Unknown actions are rejected so typos in config surface at startup.
+func Configure(overrides map[string]string) error {
+	bindings := copyBindings(defaults)
+
+	for name, value := range overrides {
+		action := Action(strings.ToLower(strings.TrimSpace(name)))
+		binding, ok := bindings[action]
+		if !ok {
+			return fmt.Errorf("unknown key action %q (known: %s)", name, strings.Join(actionNames(), ", "))
+		}
+
+		var keys []string
+		for _, k := range strings.Split(value, ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				keys = append(keys, k)
+			}
+		}
+		if len(keys) == 0 {
+			binding.SetEnabled(false)
+		} else {
+			binding.SetKeys(keys...)
+			binding.SetHelp(strings.Join(keys, "/"), binding.Help().Desc)
+		}
+		bindings[action] = binding
+	}
+
+	registryMu.Lock()
+	registry = bindings
+	registryMu.Unlock()
+	return nil
+}
+
+// Get returns the current binding for action
+func Get(action Action) key.Binding {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return registry[action]
+}
+
+// Bind returns the binding for action with a screen-specific help description
+func Bind(action Action, description string) key.Binding {
+	binding := Get(action)
+	binding.SetHelp(binding.Help().Key, description)
+	return binding
+}
+
+// HelpItems turns bindings into footer help, in order. Disabled bindings are left out
+// and adjacent bindings sharing a description are merged ("1/2/3 switch tab").
+func HelpItems(bindings ...key.Binding) []layout.HelpItem {
+	var items []layout.HelpItem
+	for _, binding := range bindings {
+		if !binding.Enabled() {
+			continue
+		}
+		help := binding.Help()
+		if n := len(items); n > 0 && items[n-1].Description == help.Desc {
+			items[n-1].Key += "/" + help.Key
+			continue
+		}
+		items = append(items, layout.HelpItem{Key: help.Key, Description: help.Desc})
+	}
+	return items
+}
+
+func copyBindings(src map[Action]key.Binding) map[Action]key.Binding {
+	dst := make(map[Action]key.Binding, len(src))
+	for action, binding := range src {
+		dst[action] = binding
+	}
+	return dst
+}
+
+func actionNames() []string {
+	names := make([]string, 0, len(defaults))
+	for action := range defaults {
+		names = append(names, string(action))
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package keys
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/tui/layout"
+)
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestConfigure_OverridesKeysAndHelp(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, Configure(nil)) })
+
+	require.NoError(t, Configure(map[string]string{"New": "a, ctrl+n"}))
+
+	binding := Get(New)
+	assert.True(t, key.Matches(runeKey('a'), binding))
+	assert.False(t, key.Matches(runeKey('n'), binding), "default key is replaced")
+	assert.Equal(t, "a/ctrl+n", binding.Help().Key)
+	assert.Equal(t, "new", binding.Help().Desc)
+
+	// Other actions keep their defaults
+	assert.True(t, key.Matches(runeKey('d'), Get(Delete)))
+}
+
+func TestConfigure_EmptyValueDisables(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, Configure(nil)) })
+
+	require.NoError(t, Configure(map[string]string{"delete": ""}))
+
+	assert.False(t, key.Matches(runeKey('d'), Get(Delete)))
+	assert.Empty(t, HelpItems(Get(Delete)))
+}
+
+func TestConfigure_UnknownAction(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, Configure(nil)) })
+
+	err := Configure(map[string]string{"launch_rockets": "x"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "launch_rockets")
+	assert.True(t, key.Matches(runeKey('q'), Get(Quit)), "registry is unchanged on error")
+}
+
+func TestHelpItems_MergesAdjacentDescriptions(t *testing.T) {
+	items := HelpItems(
+		Bind(Tab1, "switch tab"),
+		Bind(Tab2, "switch tab"),
+		Bind(Tab3, "switch tab"),
+		Get(Back),
+		Get(Quit),
+	)
+
+	assert.Equal(t, []layout.HelpItem{
+		{Key: "1/2/3", Description: "switch tab"},
+		{Key: "esc", Description: "back"},
+		{Key: "q", Description: "quit"},
+	}, items)
+}
@@ -6,6 +6,8 @@ package layout
 import (
 	"fmt"
 	"strings"
+
+	"github.com/charmbracelet/x/ansi"
 )
 
 // HelpItem represents a single help entry
@@ -40,6 +42,28 @@ func RenderHeader(title string, breadcrumbs []string, status string, width int)
 	return header.String()
 }
 
+// RenderCompactHeader creates a header for narrow terminals: only the last breadcrumb
+// is kept, and the title and status lines are cut to width instead of wrapping
+func RenderCompactHeader(title string, breadcrumbs []string, status string, width int) string {
+	var header strings.Builder
+
+	titleLine := TitleStyle.Render(title)
+	if len(breadcrumbs) > 1 {
+		titleLine += "  " + BreadcrumbStyle.Render("…"+BreadcrumbSeparator.String()+breadcrumbs[len(breadcrumbs)-1])
+	}
+	header.WriteString(ansi.Truncate(titleLine, width, "…"))
+
+	if status != "" {
+		header.WriteString("\n")
+		header.WriteString(ansi.Truncate(StatsStyle.Render(status), width, "…"))
+	}
+
+	header.WriteString("\n")
+	header.WriteString(GetDivider(width))
+
+	return header.String()
+}
+
 // RenderFooter creates a footer with help items
 func RenderFooter(helpItems []HelpItem, width int) string {
 	if len(helpItems) == 0 {
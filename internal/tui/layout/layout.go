@@ -15,14 +15,40 @@ const (
 	MinimumWidth = 40
 	// MinimumHeight is the minimum terminal height required (header + footer + some space)
 	MinimumHeight = 10
+	// VerticalBreakpoint is the width below which OrientationAuto stacks vertically
+	VerticalBreakpoint = 80
 )
 
+// Orientation is how a screen arranges its panels
+type Orientation int
+
+const (
+	// OrientationAuto is vertical below VerticalBreakpoint columns, horizontal otherwise
+	OrientationAuto Orientation = iota
+	// OrientationHorizontal places panels side by side under a full header
+	OrientationHorizontal
+	// OrientationVertical stacks panels under a compact header with less breadcrumb detail
+	OrientationVertical
+)
+
+// Resolve returns the orientation to use at width; it is never OrientationAuto
+func (o Orientation) Resolve(width int) Orientation {
+	if o != OrientationAuto {
+		return o
+	}
+	if width < VerticalBreakpoint {
+		return OrientationVertical
+	}
+	return OrientationHorizontal
+}
+
 // LayoutInfo contains all the information needed to render a layout
 type LayoutInfo struct {
 	Title       string
 	Breadcrumbs []string
 	Status      string
 	HelpItems   []HelpItem
+	Orientation Orientation
 }
 
 // Dimensions represents the available space for content
@@ -31,6 +57,9 @@ type Dimensions struct {
 	Height int
 	Valid  bool
 	Error  string
+
+	// Orientation is the resolved orientation; panels should stack when it is vertical
+	Orientation Orientation
 }
 
 // ValidateSpace checks if the terminal has enough space to render properly
@@ -70,7 +99,7 @@ func RenderLayout(content string, info LayoutInfo, width, height int) string {
 	}
 
 	// Render header
-	header := RenderHeader(info.Title, info.Breadcrumbs, info.Status, width)
+	header := renderHeader(info, width)
 
 	// Render footer if help items exist
 	var footer string
@@ -112,7 +141,7 @@ func GetContentArea(info LayoutInfo, totalWidth, totalHeight int) Dimensions {
 	}
 
 	// Calculate header height
-	header := RenderHeader(info.Title, info.Breadcrumbs, info.Status, totalWidth)
+	header := renderHeader(info, totalWidth)
 	headerHeight := lipgloss.Height(header)
 
 	// Calculate footer height
@@ -129,10 +158,19 @@ func GetContentArea(info LayoutInfo, totalWidth, totalHeight int) Dimensions {
 	}
 
 	return Dimensions{
-		Width:  totalWidth,
-		Height: contentHeight,
-		Valid:  true,
+		Width:       totalWidth,
+		Height:      contentHeight,
+		Valid:       true,
+		Orientation: info.Orientation.Resolve(totalWidth),
+	}
+}
+
+// renderHeader renders the header for info's orientation at width
+func renderHeader(info LayoutInfo, width int) string {
+	if info.Orientation.Resolve(width) == OrientationVertical {
+		return RenderCompactHeader(info.Title, info.Breadcrumbs, info.Status, width)
 	}
+	return RenderHeader(info.Title, info.Breadcrumbs, info.Status, width)
 }
 
 // renderSpaceError renders an error message when terminal is too small
@@ -0,0 +1,81 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrientation_Resolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		orientation Orientation
+		width       int
+		want        Orientation
+	}{
+		{"auto below breakpoint", OrientationAuto, VerticalBreakpoint - 1, OrientationVertical},
+		{"auto at breakpoint", OrientationAuto, VerticalBreakpoint, OrientationHorizontal},
+		{"auto wide", OrientationAuto, 200, OrientationHorizontal},
+		{"horizontal stays horizontal when narrow", OrientationHorizontal, MinimumWidth, OrientationHorizontal},
+		{"vertical stays vertical when wide", OrientationVertical, 200, OrientationVertical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.orientation.Resolve(tt.width))
+		})
+	}
+}
+
+func TestGetContentArea(t *testing.T) {
+	info := LayoutInfo{
+		Title:       "Task Details",
+		Breadcrumbs: []string{"Projects", "project", "Tasks", "task"},
+		Status:      "Repository: /some/long/path | Tasks: 12 (3 completed) | Commits: 40",
+		HelpItems:   []HelpItem{{Key: "q", Description: "quit"}, {Key: "esc", Description: "back"}},
+	}
+
+	tests := []struct {
+		name            string
+		width           int
+		height          int
+		wantOrientation Orientation
+	}{
+		{"wide terminal", 120, 40, OrientationHorizontal},
+		{"narrow terminal", 60, 30, OrientationVertical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dims := GetContentArea(info, tt.width, tt.height)
+			assert.True(t, dims.Valid)
+			assert.Equal(t, tt.width, dims.Width)
+			assert.Equal(t, tt.wantOrientation, dims.Orientation)
+
+			// The content gets what the header and footer leave
+			header := renderHeader(info, tt.width)
+			footer := RenderFooter(info.HelpItems, tt.width)
+			assert.Equal(t, tt.height-lipgloss.Height(header)-lipgloss.Height(footer), dims.Height)
+
+			// and the rendered screen fills the terminal exactly
+			content := strings.Repeat("line\n", dims.Height*2)
+			assert.Equal(t, tt.height, lipgloss.Height(RenderLayout(content, info, tt.width, tt.height)))
+		})
+	}
+
+	t.Run("compact header keeps the status on one line", func(t *testing.T) {
+		header := renderHeader(info, 50)
+		assert.Equal(t, 3, lipgloss.Height(header), "title, status and divider")
+	})
+
+	t.Run("too small", func(t *testing.T) {
+		dims := GetContentArea(info, MinimumWidth-1, 30)
+		assert.False(t, dims.Valid)
+		assert.NotEmpty(t, dims.Error)
+	})
+}
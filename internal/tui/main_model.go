@@ -4,13 +4,16 @@
 package tui
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/charmbracelet/bubbletea"
 	"github.com/noldarim/noldarim/internal/logger"
 	"github.com/noldarim/noldarim/internal/protocol"
 	"github.com/noldarim/noldarim/internal/tui/components/taskstatus"
+	"github.com/noldarim/noldarim/internal/tui/components/toast"
 	"github.com/noldarim/noldarim/internal/tui/messages"
+	"github.com/noldarim/noldarim/internal/tui/screens/pipelinehistory"
 	"github.com/noldarim/noldarim/internal/tui/screens/projectcreation"
 	"github.com/noldarim/noldarim/internal/tui/screens/projectlist"
 	"github.com/noldarim/noldarim/internal/tui/screens/settings"
@@ -27,6 +30,7 @@ const (
 	TaskDetailsScreen
 	SettingsScreen
 	ProjectCreationScreen
+	PipelineHistoryScreen
 )
 
 type MainModel struct {
@@ -41,6 +45,14 @@ type MainModel struct {
 	taskDetails     taskdetails.Model
 	settings        settings.Model
 	projectCreation projectcreation.Model
+	pipelineHistory pipelinehistory.Model
+
+	// Transient notifications drawn over the current screen
+	toasts toast.Model
+
+	// Tasks the orchestrator reported as still running, keyed by task ID.
+	// Used to reattach the activity feed after a TUI restart.
+	runningTasks map[string]protocol.RunningTaskInfo
 
 	// Global state
 	width, height int
@@ -56,12 +68,19 @@ func NewMainModel(cmdChan chan<- protocol.Command, eventChan <-chan protocol.Eve
 		projectList:   projectlist.NewModel(cmdChan),
 		taskView:      taskview.Model{}, // Will be initialized when needed
 		settings:      settings.NewModel(),
+		toasts:        toast.New(),
+		runningTasks:  make(map[string]protocol.RunningTaskInfo),
 		cmdChan:       cmdChan,
 		eventChan:     eventChan,
 	}
 }
 
 func (m MainModel) Init() tea.Cmd {
+	// Find tasks that kept running while the TUI was not
+	go func() {
+		m.cmdChan <- protocol.ListRunningTasksCommand{}
+	}()
+
 	return tea.Batch(
 		m.projectList.Init(),
 		tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
@@ -70,6 +89,24 @@ func (m MainModel) Init() tea.Cmd {
 	)
 }
 
+// handleRunningTasks records the tasks still running in the orchestrator
+func (m *MainModel) handleRunningTasks(event protocol.RunningTasksEvent) tea.Cmd {
+	if event.Unavailable {
+		log := logger.GetTUILogger().With().Str("component", "main_model").Logger()
+		log.Warn().Str("error", event.Error).Msg("Running tasks unavailable")
+		return nil
+	}
+
+	m.runningTasks = make(map[string]protocol.RunningTaskInfo, len(event.Tasks))
+	for _, task := range event.Tasks {
+		m.runningTasks[task.TaskID] = task
+	}
+	if len(event.Tasks) == 0 {
+		return nil
+	}
+	return toast.Info(fmt.Sprintf("%d task(s) still running", len(event.Tasks)))
+}
+
 // tickMsg is used for global animation timing
 type tickMsg time.Time
 
@@ -77,6 +114,7 @@ type tickMsg time.Time
 func (m *MainModel) setSize(width, height int) {
 	m.width = width
 	m.height = height
+	m.toasts.SetWidth(width)
 	switch m.currentScreen {
 	case ProjectListScreen:
 		m.projectList.SetSize(width, height)
@@ -88,6 +126,8 @@ func (m *MainModel) setSize(width, height int) {
 		m.settings.SetSize(width, height)
 	case ProjectCreationScreen:
 		m.projectCreation.SetSize(width, height)
+	case PipelineHistoryScreen:
+		m.pipelineHistory.SetSize(width, height)
 	}
 }
 
@@ -109,6 +149,27 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.setSize(windowSize.Width, windowSize.Height)
 	}
 
+	// Toasts are global and never delegated to screens
+	switch msg.(type) {
+	case toast.ShowMsg, protocol.NotificationEvent, protocol.BudgetExceededEvent, protocol.AgentIdleEvent,
+		protocol.TaskCleanedUpEvent:
+		var toastCmd tea.Cmd
+		m.toasts, toastCmd = m.toasts.Update(msg)
+		return m, toastCmd
+	}
+	var toastCmd tea.Cmd
+	m.toasts, toastCmd = m.toasts.Update(msg)
+	if toastCmd != nil {
+		cmds = append(cmds, toastCmd)
+	}
+
+	switch msg := msg.(type) {
+	case protocol.RunningTasksEvent:
+		return m, m.handleRunningTasks(msg)
+	case protocol.AIStreamEndEvent:
+		delete(m.runningTasks, msg.TaskID)
+	}
+
 	// Handle Navigation Messages First (these return early to avoid screen delegation)
 	switch msg := msg.(type) {
 	case messages.GoToTasksScreenMsg:
@@ -132,6 +193,10 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.taskDetails.SetSize(m.width, m.height)
 		m.currentScreen = TaskDetailsScreen
 		navCmd := m.taskDetails.Init()
+		if _, running := m.runningTasks[msg.Task.ID]; running {
+			// Live activity for this task will keep arriving; show the feed as streaming
+			m.taskDetails.StartAIStream()
+		}
 
 		// Request historical AI activity events for this task
 		taskID := msg.Task.ID
@@ -184,6 +249,18 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, navCmd
 
+	case messages.GoToPipelineHistoryMsg:
+		// Push current screen to history
+		m.screenHistory = append(m.screenHistory, m.currentScreen)
+		m.pipelineHistory = pipelinehistory.NewModel(msg.ProjectID, m.cmdChan)
+		m.pipelineHistory.SetSize(m.width, m.height)
+		m.currentScreen = PipelineHistoryScreen
+		navCmd := m.pipelineHistory.Init()
+		if len(cmds) > 0 {
+			return m, tea.Batch(append(cmds, navCmd)...)
+		}
+		return m, navCmd
+
 	case messages.GoToProjectListMsg:
 		// Clear history and go back to project list
 		m.currentScreen = ProjectListScreen
@@ -229,6 +306,10 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var model tea.Model
 		model, screenCmd = m.projectCreation.Update(msg)
 		m.projectCreation = model.(projectcreation.Model)
+	case PipelineHistoryScreen:
+		var model tea.Model
+		model, screenCmd = m.pipelineHistory.Update(msg)
+		m.pipelineHistory = model.(pipelinehistory.Model)
 	}
 
 	// Add screen command to batch if it exists
@@ -244,6 +325,11 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m MainModel) View() string {
+	return m.toasts.Overlay(m.screenView())
+}
+
+// screenView renders the current screen without overlays
+func (m MainModel) screenView() string {
 	switch m.currentScreen {
 	case ProjectListScreen:
 		return m.projectList.View()
@@ -255,6 +341,8 @@ func (m MainModel) View() string {
 		return m.settings.View()
 	case ProjectCreationScreen:
 		return m.projectCreation.View()
+	case PipelineHistoryScreen:
+		return m.pipelineHistory.View()
 	default:
 		return "Unknown screen"
 	}
@@ -273,6 +361,8 @@ func screenName(s ScreenType) string {
 		return "Settings"
 	case ProjectCreationScreen:
 		return "ProjectCreation"
+	case PipelineHistoryScreen:
+		return "PipelineHistory"
 	default:
 		return "Unknown"
 	}
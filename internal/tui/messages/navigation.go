@@ -22,3 +22,7 @@ type GoToSettingsMsg struct{}
 type GoToProjectListMsg struct{}
 
 type GoToProjectCreationMsg struct{}
+
+type GoToPipelineHistoryMsg struct {
+	ProjectID string
+}
@@ -0,0 +1,9 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package messages
+
+// FileSelectedMsg is emitted when the user picks a file in an activity list
+type FileSelectedMsg struct {
+	Path string
+}
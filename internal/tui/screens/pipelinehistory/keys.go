@@ -0,0 +1,36 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipelinehistory
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/noldarim/noldarim/internal/tui/keys"
+	"github.com/noldarim/noldarim/internal/tui/layout"
+)
+
+// keyMap holds the history screen's bindings, resolved from the keys registry
+type keyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+	Retry  key.Binding
+	Back   key.Binding
+	Quit   key.Binding
+}
+
+func newKeyMap() keyMap {
+	return keyMap{
+		Up:     keys.Get(keys.Up),
+		Down:   keys.Get(keys.Down),
+		Select: keys.Bind(keys.Select, "summary"),
+		Retry:  keys.Bind(keys.Retry, "retry (failed)"),
+		Back:   keys.Get(keys.Back),
+		Quit:   keys.Get(keys.Quit),
+	}
+}
+
+func (k keyMap) helpItems() []layout.HelpItem {
+	return keys.HelpItems(k.Up, k.Down, k.Select, k.Retry, k.Back, k.Quit)
+}
@@ -0,0 +1,263 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package pipelinehistory is the screen listing a project's past pipeline runs, with
+// a summary of the selected run and a retry action for failed ones.
+package pipelinehistory
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/noldarim/noldarim/internal/tui/components/pipelinesummary"
+	"github.com/noldarim/noldarim/internal/tui/components/stepprogress"
+	"github.com/noldarim/noldarim/internal/tui/layout"
+)
+
+// Model is the model for the pipeline history screen.
+type Model struct {
+	projectID   string
+	projectName string
+	cmdChan     chan<- protocol.Command
+
+	runs     []*models.PipelineRun // Newest first
+	loaded   bool                  // False until the first PipelineRunsLoadedEvent
+	selected int
+	showRun  bool // Summary of the selected run is open
+
+	width  int
+	height int
+	keys   keyMap
+}
+
+// NewModel creates a new pipeline history model
+func NewModel(projectID string, cmdChan chan<- protocol.Command) Model {
+	return Model{
+		projectID: projectID,
+		cmdChan:   cmdChan,
+		width:     80,
+		height:    24,
+		keys:      newKeyMap(),
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	go func() {
+		m.cmdChan <- protocol.LoadPipelineRunsCommand{ProjectID: m.projectID}
+	}()
+	return nil
+}
+
+// GetLayoutInfo returns layout information for the pipeline history screen
+func (m Model) GetLayoutInfo() layout.LayoutInfo {
+	projectDisplayName := m.projectName
+	if projectDisplayName == "" {
+		projectDisplayName = m.projectID
+	}
+
+	failed := 0
+	for _, run := range m.runs {
+		if run.Status == models.PipelineRunStatusFailed {
+			failed++
+		}
+	}
+
+	return layout.LayoutInfo{
+		Title:       "Run History",
+		Breadcrumbs: []string{"Projects", projectDisplayName, "History"},
+		Status:      fmt.Sprintf("Runs: %d (%d failed)", len(m.runs), failed),
+		HelpItems:   m.keys.helpItems(),
+	}
+}
+
+// SetSize updates the model's dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// setRuns replaces the listed runs, keeping the cursor on the same run when it is still there
+func (m *Model) setRuns(runs map[string]*models.PipelineRun) {
+	selectedID := ""
+	if run := m.selectedRun(); run != nil {
+		selectedID = run.ID
+	}
+
+	m.runs = make([]*models.PipelineRun, 0, len(runs))
+	for _, run := range runs {
+		m.runs = append(m.runs, run)
+	}
+	sort.Slice(m.runs, func(i, j int) bool {
+		if !m.runs[i].CreatedAt.Equal(m.runs[j].CreatedAt) {
+			return m.runs[i].CreatedAt.After(m.runs[j].CreatedAt)
+		}
+		return m.runs[i].ID < m.runs[j].ID
+	})
+
+	m.selected = 0
+	for i, run := range m.runs {
+		if run.ID == selectedID {
+			m.selected = i
+			break
+		}
+	}
+	if len(m.runs) == 0 {
+		m.showRun = false
+	}
+}
+
+// selectedRun returns the run under the cursor, or nil when there are none
+func (m Model) selectedRun() *models.PipelineRun {
+	if m.selected < 0 || m.selected >= len(m.runs) {
+		return nil
+	}
+	return m.runs[m.selected]
+}
+
+// retryCommand rebuilds the StartPipelineCommand of a run from its step snapshots, on the
+// same base commit and in the same working subdirectory. For a run started as a pipeline
+// this resolves to the same run ID, so the orchestrator restarts the failed run.
+func retryCommand(run *models.PipelineRun) (protocol.StartPipelineCommand, error) {
+	if len(run.StepSnapshots) == 0 {
+		return protocol.StartPipelineCommand{}, fmt.Errorf("run %s has no step definitions to retry", run.ID)
+	}
+
+	snapshots := sortedSnapshots(run)
+	steps := make([]protocol.StepInput, 0, len(snapshots))
+	for _, snap := range snapshots {
+		step := protocol.StepInput{StepID: snap.StepID, Name: snap.StepName}
+		if snap.AgentConfigJSON != "" {
+			var agentConfig protocol.AgentConfigInput
+			if err := json.Unmarshal([]byte(snap.AgentConfigJSON), &agentConfig); err != nil {
+				return protocol.StartPipelineCommand{}, fmt.Errorf("failed to decode agent config of step %s: %w", snap.StepID, err)
+			}
+			step.AgentConfig = &agentConfig
+		}
+		steps = append(steps, step)
+	}
+
+	return protocol.StartPipelineCommand{
+		Metadata:      protocol.Metadata{Version: protocol.CurrentProtocolVersion},
+		ProjectID:     run.ProjectID,
+		Name:          run.Name,
+		Steps:         steps,
+		BaseCommitSHA: run.BaseCommitSHA,
+		WorkingSubdir: run.WorkingSubdir,
+	}, nil
+}
+
+// sortedSnapshots returns the run's step definitions in pipeline order
+func sortedSnapshots(run *models.PipelineRun) []models.RunStepSnapshot {
+	snapshots := append([]models.RunStepSnapshot(nil), run.StepSnapshots...)
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].StepIndex < snapshots[j].StepIndex })
+	return snapshots
+}
+
+// runDuration is how long the run took, or has been running; zero if it never started
+func runDuration(run *models.PipelineRun, now time.Time) time.Duration {
+	if run.StartedAt == nil {
+		return 0
+	}
+	if run.CompletedAt != nil {
+		return run.CompletedAt.Sub(*run.StartedAt)
+	}
+	return now.Sub(*run.StartedAt)
+}
+
+// runTokens totals input and output tokens over the run's steps
+func runTokens(run *models.PipelineRun) int {
+	total := 0
+	for _, step := range run.StepResults {
+		total += step.InputTokens + step.OutputTokens
+	}
+	return total
+}
+
+// summaryData converts a run to the pipelinesummary component's data
+func summaryData(run *models.PipelineRun, now time.Time) pipelinesummary.SummaryData {
+	data := pipelinesummary.SummaryData{
+		Status:        summaryStatus(run.Status),
+		Duration:      runDuration(run, now),
+		TotalSteps:    len(run.StepSnapshots),
+		BranchName:    run.BranchName,
+		BaseCommitSHA: run.BaseCommitSHA,
+		HeadCommitSHA: run.HeadCommitSHA,
+		ErrorMessage:  run.ErrorMessage,
+	}
+	if data.TotalSteps == 0 {
+		data.TotalSteps = len(run.StepResults)
+	}
+
+	for _, step := range run.StepResults {
+		switch step.Status {
+		case models.StepStatusCompleted:
+			data.CompletedSteps++
+		case models.StepStatusFailed:
+			data.FailedSteps++
+		}
+		data.TotalTokens += step.InputTokens + step.OutputTokens
+		data.CacheHitTokens += step.CacheReadTokens
+		data.FilesChanged += step.FilesChanged
+		data.SignificantFilesChanged += step.SignificantFilesChanged
+		data.Insertions += step.Insertions
+		data.Deletions += step.Deletions
+	}
+	return data
+}
+
+// progressSteps lists every defined step of a run with its result status; steps
+// without a result yet are pending
+func progressSteps(run *models.PipelineRun) []stepprogress.Step {
+	results := make(map[string]models.StepResult, len(run.StepResults))
+	for _, result := range run.StepResults {
+		results[result.StepID] = result
+	}
+
+	var steps []stepprogress.Step
+	for _, snap := range sortedSnapshots(run) {
+		step := stepprogress.Step{Name: snap.StepName, Status: stepprogress.StatusPending}
+		if result, ok := results[snap.StepID]; ok {
+			step.Status = progressStatus(result.Status)
+		}
+		steps = append(steps, step)
+	}
+	if len(steps) == 0 {
+		for _, result := range run.StepResults {
+			steps = append(steps, stepprogress.Step{Name: result.StepName, Status: progressStatus(result.Status)})
+		}
+	}
+	return steps
+}
+
+func summaryStatus(s models.PipelineRunStatus) pipelinesummary.Status {
+	switch s {
+	case models.PipelineRunStatusRunning:
+		return pipelinesummary.StatusRunning
+	case models.PipelineRunStatusCompleted:
+		return pipelinesummary.StatusCompleted
+	case models.PipelineRunStatusFailed:
+		return pipelinesummary.StatusFailed
+	default:
+		return pipelinesummary.StatusPending
+	}
+}
+
+func progressStatus(s models.StepStatus) stepprogress.StepStatus {
+	switch s {
+	case models.StepStatusRunning:
+		return stepprogress.StatusRunning
+	case models.StepStatusCompleted:
+		return stepprogress.StatusCompleted
+	case models.StepStatusFailed:
+		return stepprogress.StatusFailed
+	case models.StepStatusSkipped:
+		return stepprogress.StatusSkipped
+	default:
+		return stepprogress.StatusPending
+	}
+}
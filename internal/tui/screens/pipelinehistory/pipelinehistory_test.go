@@ -0,0 +1,143 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipelinehistory
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/noldarim/noldarim/internal/tui/components/stepprogress"
+	"github.com/noldarim/noldarim/internal/tui/messages"
+	"github.com/noldarim/noldarim/test/testutil"
+)
+
+func testRuns() map[string]*models.PipelineRun {
+	base := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	started := base.Add(time.Second)
+	finished := started.Add(90 * time.Second)
+	return map[string]*models.PipelineRun{
+		"run-old": {
+			ID: "run-old", ProjectID: "proj-1", Name: "Add login", CreatedAt: base,
+			Status: models.PipelineRunStatusCompleted, StartedAt: &started, CompletedAt: &finished,
+			StepResults: []models.StepResult{{StepID: "main", Status: models.StepStatusCompleted, InputTokens: 1000, OutputTokens: 500}},
+		},
+		"run-new": {
+			ID: "run-new", ProjectID: "proj-1", Name: "Fix parser", CreatedAt: base.Add(time.Hour),
+			Status: models.PipelineRunStatusFailed, BaseCommitSHA: "abc123", WorkingSubdir: "services/api",
+			StepSnapshots: []models.RunStepSnapshot{
+				{StepID: "review", StepIndex: 1, StepName: "Review", AgentConfigJSON: `{"tool_name":"claude","prompt_template":"review"}`},
+				{StepID: "main", StepIndex: 0, StepName: "Implement", AgentConfigJSON: `{"tool_name":"claude","prompt_template":"{{.task}}","variables":{"task":"fix"},"flag_format":"equals"}`},
+			},
+			StepResults: []models.StepResult{{StepID: "main", StepName: "Implement", Status: models.StepStatusFailed}},
+		},
+	}
+}
+
+func loadedModel(cmdChan chan<- protocol.Command) Model {
+	m := NewModel("proj-1", cmdChan)
+	updated, _ := m.Update(protocol.PipelineRunsLoadedEvent{ProjectID: "proj-1", ProjectName: "Demo", Runs: testRuns()})
+	return updated.(Model)
+}
+
+func TestModelUpdate_RunsSortedNewestFirst(t *testing.T) {
+	m := loadedModel(make(chan protocol.Command, 1))
+
+	require.Len(t, m.runs, 2)
+	assert.Equal(t, "run-new", m.runs[0].ID)
+	assert.Equal(t, "run-old", m.runs[1].ID)
+	assert.Contains(t, m.View(), "Fix parser")
+	assert.Contains(t, m.View(), "1m30s")
+	assert.Contains(t, m.View(), "1.5k")
+}
+
+func TestModelUpdate_IgnoresOtherProjects(t *testing.T) {
+	m := NewModel("proj-1", make(chan protocol.Command, 1))
+	updated, _ := m.Update(protocol.PipelineRunsLoadedEvent{ProjectID: "proj-2", Runs: testRuns()})
+
+	assert.Empty(t, updated.(Model).runs)
+	assert.Contains(t, updated.View(), "Loading run history")
+}
+
+func TestModelView_EmptyHistory(t *testing.T) {
+	m := NewModel("proj-1", make(chan protocol.Command, 1))
+	updated, _ := m.Update(protocol.PipelineRunsLoadedEvent{ProjectID: "proj-1", Runs: map[string]*models.PipelineRun{}})
+
+	assert.Contains(t, updated.View(), "No pipeline runs yet")
+
+	// Enter and retry do nothing without runs
+	updated, cmd := testutil.SendMessage(updated, testutil.SpecialKey(tea.KeyEnter))
+	assert.False(t, updated.(Model).showRun)
+	testutil.AssertNoCommand(t, cmd)
+	_, cmd = testutil.SendMessage(updated, testutil.KeyPress("r"))
+	testutil.AssertNoCommand(t, cmd)
+}
+
+func TestModelUpdate_EnterOpensSummaryAndEscClosesIt(t *testing.T) {
+	m := loadedModel(make(chan protocol.Command, 1))
+
+	updated, _ := testutil.SendMessage(m, testutil.SpecialKey(tea.KeyEnter))
+	require.True(t, updated.(Model).showRun)
+	assert.Contains(t, updated.View(), "run-new")
+
+	// First esc closes the summary, the second leaves the screen
+	updated, cmd := testutil.SendMessage(updated, testutil.SpecialKey(tea.KeyEsc))
+	assert.False(t, updated.(Model).showRun)
+	testutil.AssertNoCommand(t, cmd)
+
+	_, cmd = testutil.SendMessage(updated, testutil.SpecialKey(tea.KeyEsc))
+	require.NotNil(t, cmd)
+	assert.IsType(t, messages.GoBackMsg{}, testutil.ExecuteCommand(cmd))
+}
+
+func TestModelUpdate_RetryFailedRun(t *testing.T) {
+	cmdChan := make(chan protocol.Command, 1)
+	m := loadedModel(cmdChan)
+
+	updated, cmd := testutil.SendMessage(m, testutil.KeyPress("r"))
+	require.NotNil(t, cmd)
+	assert.Equal(t, models.PipelineRunStatusPending, updated.(Model).runs[0].Status)
+
+	select {
+	case sent := <-cmdChan:
+		start, ok := sent.(protocol.StartPipelineCommand)
+		require.True(t, ok, "expected StartPipelineCommand, got %T", sent)
+		assert.Equal(t, "proj-1", start.ProjectID)
+		assert.Equal(t, "Fix parser", start.Name)
+		assert.Equal(t, "abc123", start.BaseCommitSHA)
+		assert.Equal(t, "services/api", start.WorkingSubdir)
+		require.Len(t, start.Steps, 2)
+		assert.Equal(t, "main", start.Steps[0].StepID)
+		assert.Equal(t, "fix", start.Steps[0].AgentConfig.Variables["task"])
+		assert.Equal(t, "equals", start.Steps[0].AgentConfig.FlagFormat)
+		assert.Equal(t, "review", start.Steps[1].StepID)
+	case <-time.After(time.Second):
+		t.Fatal("retry command was not sent")
+	}
+}
+
+func TestModelUpdate_RetryIgnoresCompletedRun(t *testing.T) {
+	cmdChan := make(chan protocol.Command, 1)
+	m := loadedModel(cmdChan)
+
+	updated, _ := testutil.SendMessage(m, testutil.KeyPress("j"))
+	require.Equal(t, 1, updated.(Model).selected)
+
+	_, cmd := testutil.SendMessage(updated, testutil.KeyPress("r"))
+	testutil.AssertNoCommand(t, cmd)
+	assert.Empty(t, cmdChan)
+}
+
+func TestProgressSteps_PendingWithoutResult(t *testing.T) {
+	steps := progressSteps(testRuns()["run-new"])
+
+	require.Len(t, steps, 2)
+	assert.Equal(t, stepprogress.Step{Name: "Implement", Status: stepprogress.StatusFailed}, steps[0])
+	assert.Equal(t, stepprogress.Step{Name: "Review", Status: stepprogress.StatusPending}, steps[1])
+}
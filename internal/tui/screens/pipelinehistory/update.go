@@ -0,0 +1,89 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipelinehistory
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/noldarim/noldarim/internal/tui/components/toast"
+	"github.com/noldarim/noldarim/internal/tui/messages"
+)
+
+// Update handles messages and updates the model state
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Up):
+			if !m.showRun && m.selected > 0 {
+				m.selected--
+			}
+		case key.Matches(msg, m.keys.Down):
+			if !m.showRun && m.selected < len(m.runs)-1 {
+				m.selected++
+			}
+		case key.Matches(msg, m.keys.Select):
+			if m.selectedRun() != nil {
+				m.showRun = !m.showRun
+			}
+		case key.Matches(msg, m.keys.Retry):
+			return m, m.retrySelected()
+		case key.Matches(msg, m.keys.Back):
+			if m.showRun {
+				m.showRun = false
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return messages.GoBackMsg{}
+			}
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		}
+
+	case protocol.PipelineRunsLoadedEvent:
+		if msg.ProjectID == m.projectID {
+			m.projectName = msg.ProjectName
+			m.loaded = true
+			m.setRuns(msg.Runs)
+		}
+
+	case protocol.PipelineRunStartedEvent:
+		if msg.ProjectID == m.projectID {
+			// Reload so a retried run shows its new status and step results
+			go func() {
+				m.cmdChan <- protocol.LoadPipelineRunsCommand{ProjectID: m.projectID}
+			}()
+		}
+
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	}
+
+	return m, nil
+}
+
+// retrySelected starts the selected run again if it failed; see retryCommand for when
+// that restarts the same run
+func (m *Model) retrySelected() tea.Cmd {
+	run := m.selectedRun()
+	if run == nil || run.Status != models.PipelineRunStatusFailed {
+		return nil
+	}
+
+	cmd, err := retryCommand(run)
+	if err != nil {
+		return toast.Error(fmt.Sprintf("Cannot retry: %v", err))
+	}
+
+	// Optimistic update until the reload arrives
+	run.Status = models.PipelineRunStatusPending
+	go func() {
+		m.cmdChan <- cmd
+	}()
+	return toast.Info(fmt.Sprintf("Retrying %s", run.Name))
+}
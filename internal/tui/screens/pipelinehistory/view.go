@@ -0,0 +1,129 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipelinehistory
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+	"github.com/noldarim/noldarim/internal/tui/components/pipelinesummary"
+	"github.com/noldarim/noldarim/internal/tui/components/stepprogress"
+	"github.com/noldarim/noldarim/internal/tui/layout"
+)
+
+const timestampFormat = "2006-01-02 15:04"
+
+var (
+	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("239"))
+	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("75")).Bold(true)
+)
+
+// View renders the pipeline history screen
+func (m Model) View() string {
+	var content string
+	switch {
+	case !m.loaded:
+		content = dimStyle.Render("Loading run history...")
+	case len(m.runs) == 0:
+		content = dimStyle.Render("No pipeline runs yet. Create a task to start one.")
+	case m.showRun:
+		content = m.renderRun(m.selectedRun())
+	default:
+		content = m.renderList()
+	}
+
+	return layout.RenderLayout(content, m.GetLayoutInfo(), m.width, m.height)
+}
+
+// renderList renders one line per run: status, name, start time, duration and tokens
+func (m Model) renderList() string {
+	now := time.Now()
+	var b strings.Builder
+	for i, run := range m.runs {
+		line := fmt.Sprintf("%s %-9s %-40s %s  %8s  %8s tok",
+			statusIcon(run.Status),
+			run.Status,
+			truncate(run.Name, 40),
+			startedAt(run),
+			formatDuration(runDuration(run, now)),
+			formatTokens(runTokens(run)))
+		if i == m.selected {
+			b.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderRun renders the selected run with the pipelinesummary and stepprogress components
+func (m Model) renderRun(run *models.PipelineRun) string {
+	var b strings.Builder
+	b.WriteString(selectedStyle.Render(run.Name))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(fmt.Sprintf("%s · started %s", run.ID, startedAt(run))))
+	b.WriteString("\n\n")
+
+	if steps := progressSteps(run); len(steps) > 0 {
+		b.WriteString(stepprogress.New().SetSteps(steps).SetWidth(m.width / 3).View())
+		b.WriteString("\n\n")
+	}
+	b.WriteString(pipelinesummary.New().SetData(summaryData(run, time.Now())).View())
+	return b.String()
+}
+
+func statusIcon(s models.PipelineRunStatus) string {
+	switch s {
+	case models.PipelineRunStatusRunning:
+		return "●"
+	case models.PipelineRunStatusCompleted:
+		return "✓"
+	case models.PipelineRunStatusFailed:
+		return "✗"
+	default:
+		return "○"
+	}
+}
+
+func startedAt(run *models.PipelineRun) string {
+	if run.StartedAt != nil {
+		return run.StartedAt.Local().Format(timestampFormat)
+	}
+	return run.CreatedAt.Local().Format(timestampFormat)
+}
+
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func formatTokens(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+func truncate(s string, max int) string {
+	if len([]rune(s)) <= max {
+		return s
+	}
+	return string([]rune(s)[:max-1]) + "…"
+}
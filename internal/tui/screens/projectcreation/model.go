@@ -6,6 +6,8 @@ package projectcreation
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/filepicker"
 	tea "github.com/charmbracelet/bubbletea"
@@ -31,8 +33,14 @@ type Model struct {
 	formTitle    string
 	formDesc     string
 	cmdChan      chan<- protocol.Command
-	width        int
-	height       int
+
+	// Optional default agent config for the project's tasks
+	formAgentTool       string
+	formAgentOptions    string
+	formAgentFlagFormat string
+	formAgentPrompt     string
+	width               int
+	height              int
 }
 
 // NewModel creates a new project creation model
@@ -84,9 +92,95 @@ func (m *Model) initForm() {
 				Placeholder("Enter project description...").
 				Value(&m.formDesc),
 		),
+		huh.NewGroup(
+			huh.NewInput().
+				Key("agent_tool").
+				Title("Default Agent Tool").
+				Description("Used by tasks that don't specify their own; empty uses the app default").
+				Placeholder("claude").
+				Value(&m.formAgentTool),
+
+			huh.NewInput().
+				Key("agent_options").
+				Title("Default Tool Options").
+				Placeholder("model=claude-sonnet-4-5, max-turns=10").
+				Value(&m.formAgentOptions).
+				Validate(func(s string) error {
+					_, err := parseToolOptions(s)
+					return err
+				}),
+
+			huh.NewSelect[string]().
+				Key("agent_flag_format").
+				Title("Flag Format").
+				Options(
+					huh.NewOption("App default", ""),
+					huh.NewOption("--flag value", "space"),
+					huh.NewOption("--flag=value", "equals"),
+				).
+				Value(&m.formAgentFlagFormat),
+
+			huh.NewText().
+				Key("agent_prompt").
+				Title("Default Prompt Template").
+				Placeholder("Empty uses the app default, e.g. Task: {{.title}}").
+				Value(&m.formAgentPrompt),
+		).Title("Default Agent (optional)"),
 	).WithTheme(huh.ThemeCharm())
 }
 
+// agentDefaults builds the project's default agent config from the form, or nil if left empty
+func (m Model) agentDefaults() *protocol.AgentConfigInput {
+	options, err := parseToolOptions(m.formAgentOptions)
+	if err != nil {
+		options = nil // Rejected by the form's validation
+	}
+	cfg := &protocol.AgentConfigInput{
+		ToolName:       strings.TrimSpace(m.formAgentTool),
+		PromptTemplate: strings.TrimSpace(m.formAgentPrompt),
+		ToolOptions:    options,
+		FlagFormat:     m.formAgentFlagFormat,
+	}
+	if cfg.ToolName == "" && cfg.PromptTemplate == "" && len(cfg.ToolOptions) == 0 && cfg.FlagFormat == "" {
+		return nil
+	}
+	return cfg
+}
+
+// parseToolOptions parses "key=value, key=value". Numbers and booleans keep their type;
+// a key without a value is a boolean flag.
+func parseToolOptions(s string) (map[string]interface{}, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	options := make(map[string]interface{})
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, hasValue := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("option %q has no name", pair)
+		}
+		if !hasValue {
+			options[key] = true
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if n, err := strconv.Atoi(value); err == nil {
+			options[key] = n
+		} else if b, err := strconv.ParseBool(value); err == nil {
+			options[key] = b
+		} else {
+			options[key] = value
+		}
+	}
+	return options, nil
+}
+
 func (m Model) Init() tea.Cmd {
 	return m.filePicker.Init()
 }
@@ -122,11 +122,55 @@ func TestFormSubmissionSendsCommand(t *testing.T) {
 		assert.Equal(t, "Test Project", createCmd.Name)
 		assert.Equal(t, "Test Description", createCmd.Description)
 		assert.Equal(t, "/test/project", createCmd.RepositoryPath)
+		assert.Nil(t, createCmd.DefaultAgentConfig, "empty agent fields send no project default")
 	case <-time.After(100 * time.Millisecond):
 		t.Fatal("No command received")
 	}
 }
 
+func TestFormSubmissionSendsAgentDefaults(t *testing.T) {
+	cmdChan := make(chan protocol.Command, 1)
+	model := NewModel(cmdChan)
+
+	model.stage = FormInput
+	model.selectedPath = "/test/project"
+	model.formTitle = "Test Project"
+	model.formAgentTool = "claude"
+	model.formAgentOptions = "model=claude-sonnet-4-5, max-turns=10, verbose"
+	model.formAgentFlagFormat = "equals"
+	model.initForm()
+	model.form.State = huh.StateCompleted
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+
+	select {
+	case sentCmd := <-cmdChan:
+		createCmd, ok := sentCmd.(protocol.CreateProjectCommand)
+		assert.True(t, ok, "Expected CreateProjectCommand")
+		assert.Equal(t, &protocol.AgentConfigInput{
+			ToolName:    "claude",
+			ToolOptions: map[string]interface{}{"model": "claude-sonnet-4-5", "max-turns": 10, "verbose": true},
+			FlagFormat:  "equals",
+		}, createCmd.DefaultAgentConfig)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("No command received")
+	}
+}
+
+func TestParseToolOptions(t *testing.T) {
+	options, err := parseToolOptions("")
+	assert.NoError(t, err)
+	assert.Nil(t, options)
+
+	options, err = parseToolOptions(" model = opus ,dangerously-skip-permissions=false,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"model": "opus", "dangerously-skip-permissions": false}, options)
+
+	_, err = parseToolOptions("=value")
+	assert.Error(t, err)
+}
+
 func TestWindowResize(t *testing.T) {
 	cmdChan := make(chan protocol.Command, 1)
 	model := NewModel(cmdChan)
@@ -58,6 +58,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Reset form values
 				m.formTitle = ""
 				m.formDesc = ""
+				m.formAgentTool = ""
+				m.formAgentOptions = ""
+				m.formAgentFlagFormat = ""
+				m.formAgentPrompt = ""
 				m.initForm()
 				return m, nil
 
@@ -89,11 +93,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			log.Info().Str("title", title).Str("description", description).Str("repository_path", m.selectedPath).Msg("Sending CreateProjectCommand")
 
 			// Send CreateProjectCommand
+			agentDefaults := m.agentDefaults()
 			go func() {
 				cmd := protocol.CreateProjectCommand{
-					Name:           title,
-					Description:    description,
-					RepositoryPath: m.selectedPath,
+					Name:               title,
+					Description:        description,
+					RepositoryPath:     m.selectedPath,
+					DefaultAgentConfig: agentDefaults,
 				}
 				m.cmdChan <- cmd
 			}()
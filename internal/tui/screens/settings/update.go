@@ -5,6 +5,7 @@ package settings
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/noldarim/noldarim/internal/tui/components/toast"
 	"github.com/noldarim/noldarim/internal/tui/messages"
 )
 
@@ -42,7 +43,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					m.options[3] = "Debug Mode: Disabled"
 				}
+			default:
+				return m, nil
 			}
+			return m, toast.Info(m.options[m.selectedIndex])
 		case "esc", "backspace":
 			// Go back to previous screen
 			return m, func() tea.Msg {
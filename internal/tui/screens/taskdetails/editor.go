@@ -0,0 +1,132 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package taskdetails
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/noldarim/noldarim/internal/tui/components/gitdiffviewer"
+	"github.com/noldarim/noldarim/internal/tui/components/toast"
+	"github.com/noldarim/noldarim/internal/tui/editor"
+)
+
+// selectedFilePath returns the file under the cursor on the active tab: the selected
+// event on the hooks tab, or the file at the top of the git diff
+func (m *Model) selectedFilePath() string {
+	switch m.tabBar.GetActiveTab() {
+	case 1:
+		if m.task == nil || len(m.cards) < 2 {
+			return ""
+		}
+		top := m.cards[1].YOffset()
+		path := ""
+		for _, file := range gitdiffviewer.ParseDiff(m.gitDiff()) {
+			if m.renderedDiffLine(file.Line) > top {
+				break
+			}
+			path = file.Path
+		}
+		return path
+	case 2:
+		if record := m.hooksActivity.SelectedEvent(); record != nil {
+			return record.FilePath
+		}
+	}
+	return ""
+}
+
+// openInEditor opens path from the task's worktree in the user's editor, asking the
+// orchestrator for the worktree first if it is not known yet
+func (m *Model) openInEditor(path string) tea.Cmd {
+	if m.task == nil {
+		return nil
+	}
+	if path == "" {
+		return toast.Info("No file selected")
+	}
+	if _, err := editor.Resolve(); err != nil {
+		return toast.Warning("No editor configured: set $EDITOR or editor in config")
+	}
+
+	if !m.worktreeResolved {
+		m.pendingEditPath = path
+		cmdChan, projectID, taskID := m.cmdChan, m.projectID, m.task.ID
+		go func() {
+			cmdChan <- protocol.ResolveWorktreeCommand{ProjectID: projectID, TaskID: taskID}
+		}()
+		return nil
+	}
+	if m.worktreePath == "" {
+		return toast.Warning("The task worktree no longer exists")
+	}
+
+	fullPath, ok := m.worktreeFilePath(path)
+	if !ok {
+		return toast.Warning(fmt.Sprintf("%s is not in the task worktree", path))
+	}
+	if _, err := os.Stat(fullPath); err != nil {
+		return toast.Warning(fmt.Sprintf("%s does not exist in the worktree", path))
+	}
+
+	cmd, err := editor.Open(fullPath)
+	if err != nil {
+		return toast.Warning(err.Error())
+	}
+	return cmd
+}
+
+// worktreeFilePath maps a path reported by the agent (relative, absolute inside the
+// container, or absolute in the worktree) to a file in the task worktree
+func (m *Model) worktreeFilePath(path string) (string, bool) {
+	if m.task != nil {
+		if file, ok := gitdiffviewer.FindFile(gitdiffviewer.ParseDiff(m.gitDiff()), path); ok {
+			path = file.Path
+		}
+	}
+	if !filepath.IsAbs(path) {
+		full := filepath.Join(m.worktreePath, path)
+		return full, isWithin(m.worktreePath, full)
+	}
+	return path, isWithin(m.worktreePath, path)
+}
+
+// isWithin reports whether path is root or lies below it
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// handleWorktreeResolved records the task's worktree and opens the file that was waiting for it
+func (m *Model) handleWorktreeResolved(event protocol.WorktreeResolvedEvent) tea.Cmd {
+	m.worktreeResolved = true
+	m.worktreePath = ""
+	if event.Exists {
+		m.worktreePath = event.WorktreePath
+	}
+
+	path := m.pendingEditPath
+	m.pendingEditPath = ""
+	if path == "" {
+		return nil
+	}
+	return m.openInEditor(path)
+}
+
+// editorFinished reports editor failures once the TUI resumes
+func editorFinished(msg editor.FinishedMsg) tea.Cmd {
+	if msg.Err == nil {
+		return nil
+	}
+	var exitErr interface{ ExitCode() int }
+	if errors.As(msg.Err, &exitErr) {
+		return toast.Error(fmt.Sprintf("Editor exited with status %d", exitErr.ExitCode()))
+	}
+	return toast.Error(fmt.Sprintf("Failed to open editor: %v", msg.Err))
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package taskdetails
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/noldarim/noldarim/internal/tui/keys"
+	"github.com/noldarim/noldarim/internal/tui/layout"
+)
+
+// keyMap holds the task details screen's bindings, resolved from the keys registry.
+// Scrolling, jump-to-line, jump-to-diff and copy are handled by the focused card; they are listed so the
+// footer reflects any overrides.
+type keyMap struct {
+	NextTab    key.Binding
+	PrevTab    key.Binding
+	Tab1       key.Binding
+	Tab2       key.Binding
+	Tab3       key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	JumpToLine key.Binding
+	JumpToDiff key.Binding
+	ToggleWrap key.Binding
+	OpenEditor key.Binding
+	CopyEvent  key.Binding
+	Back       key.Binding
+	Quit       key.Binding
+}
+
+func newKeyMap() keyMap {
+	return keyMap{
+		NextTab:    keys.Get(keys.NextTab),
+		PrevTab:    keys.Get(keys.PrevTab),
+		Tab1:       keys.Bind(keys.Tab1, "switch tab"),
+		Tab2:       keys.Bind(keys.Tab2, "switch tab"),
+		Tab3:       keys.Bind(keys.Tab3, "switch tab"),
+		Up:         keys.Bind(keys.Up, "scroll up"),
+		Down:       keys.Bind(keys.Down, "scroll down"),
+		JumpToLine: keys.Get(keys.JumpToLine),
+		JumpToDiff: keys.Bind(keys.Select, "jump to diff"),
+		ToggleWrap: keys.Bind(keys.ToggleWrap, "wrap/scroll diff"),
+		OpenEditor: keys.Get(keys.OpenEditor),
+		CopyEvent:  keys.Bind(keys.Copy, "copy event"),
+		Back:       keys.Get(keys.Back),
+		Quit:       keys.Get(keys.Quit),
+	}
+}
+
+func (k keyMap) helpItems() []layout.HelpItem {
+	return keys.HelpItems(k.Tab1, k.Tab2, k.Tab3, k.Up, k.Down, k.JumpToLine, k.JumpToDiff, k.ToggleWrap, k.OpenEditor, k.CopyEvent, k.Back, k.Quit)
+}
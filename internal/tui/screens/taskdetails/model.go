@@ -5,6 +5,7 @@ package taskdetails
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/noldarim/noldarim/internal/aiobs/adapters"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 	"github.com/noldarim/noldarim/internal/protocol"
 	"github.com/noldarim/noldarim/internal/tui/components/gitdiffviewer"
@@ -33,14 +34,38 @@ type Model struct {
 	// Tab navigation
 	tabBar tabbar.Model
 
+	// Keybindings, resolved from the keys registry
+	keys keyMap
+
 	// Content for each tab
 	cards         []scrollablecard.Model // Task info (0) and Git diff (1)
 	hooksActivity hooksactivity.Model    // Hooks activity tab
 
 	focusedCard int
 	ready       bool
+
+	// taskPrompt is the first human prompt of the transcript, shown on the task info tab
+	taskPrompt string
+
+	// Git diff rendering: soft-wrapped to the card width, or scrolled horizontally
+	diffWidth      int
+	diffWrap       bool
+	diffTabWidth   int
+	diffHighlight  bool  // Color code in hunks by language
+	diffLineStarts []int // Rendered line of each raw diff line
+
+	// Latest diff streamed while the task runs; replaces the task's stored diff once set
+	liveDiff *protocol.DiffUpdatedEvent
+
+	// Task worktree, resolved by the orchestrator the first time a file is opened in the editor
+	worktreePath     string // Empty when the worktree no longer exists
+	worktreeResolved bool
+	pendingEditPath  string // File to open once the worktree is resolved
 }
 
+// diffHorizontalStep is the columns scrolled per left/right key when the diff is not wrapped
+const diffHorizontalStep = 8
+
 // NewModel creates a new task details model
 func NewModel(task *models.Task, projectID string, cmdChan chan<- protocol.Command) Model {
 	// Create tab bar
@@ -62,7 +87,7 @@ func NewModel(task *models.Task, projectID string, cmdChan chan<- protocol.Comma
 
 	gitDiffCard := scrollablecard.New(
 		"Git Diff",
-		gitdiffviewer.Render(task.GitDiff, 0), // No max height, viewport handles it
+		"", // Rendered once the width is known
 		40, // Initial width
 		15, // Initial height
 	)
@@ -74,18 +99,25 @@ func NewModel(task *models.Task, projectID string, cmdChan chan<- protocol.Comma
 	}
 	hooks := hooksactivity.New(taskID, 40, 15)
 
-	return Model{
+	m := Model{
 		task:          task,
 		projectID:     projectID,
 		cmdChan:       cmdChan,
 		width:         50,
 		height:        10,
 		tabBar:        tb,
+		keys:          newKeyMap(),
 		cards:         []scrollablecard.Model{taskInfoCard, gitDiffCard},
 		hooksActivity: hooks,
 		focusedCard:   0, // Task info focused by default
 		ready:         false,
+		diffWidth:     40,
+		diffWrap:      true,
+		diffTabWidth:  gitdiffviewer.DefaultTabWidth,
+		diffHighlight: true,
 	}
+	m.renderDiff()
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
@@ -94,14 +126,6 @@ func (m Model) Init() tea.Cmd {
 
 // GetLayoutInfo returns layout information for the task details screen
 func (m Model) GetLayoutInfo() layout.LayoutInfo {
-	helpItems := []layout.HelpItem{
-		{Key: "1/2/3", Description: "switch tab"},
-		{Key: "↑/k", Description: "scroll up"},
-		{Key: "↓/j", Description: "scroll down"},
-		{Key: "esc", Description: "back"},
-		{Key: "q", Description: "quit"},
-	}
-
 	// Build breadcrumbs with task title
 	taskTitle := m.task.Title
 	if len(taskTitle) > 30 {
@@ -112,7 +136,7 @@ func (m Model) GetLayoutInfo() layout.LayoutInfo {
 		Title:       "Task Details",
 		Breadcrumbs: []string{"Projects", m.projectID, "Tasks", taskTitle},
 		Status:      "",
-		HelpItems:   helpItems,
+		HelpItems:   m.keys.helpItems(),
 	}
 }
 
@@ -146,9 +170,10 @@ func (m *Model) SetSize(width, height int) {
 		contentHeight = 3
 	}
 
-	// Card width (account for borders and padding)
+	// Card width (account for borders and padding); stacked layouts drop the margin
+	vertical := dims.Orientation == layout.OrientationVertical
 	cardWidth := dims.Width - 10
-	if cardWidth < 20 {
+	if cardWidth < 20 || vertical {
 		cardWidth = dims.Width - 4
 	}
 
@@ -157,14 +182,91 @@ func (m *Model) SetSize(width, height int) {
 	if len(m.cards) >= 2 {
 		m.cards[0].SetSize(cardWidth, contentHeight)
 		m.cards[1].SetSize(cardWidth, contentHeight)
+		if cardWidth != m.diffWidth {
+			m.diffWidth = cardWidth
+			m.renderDiff()
+		}
 	}
 
-	// Size hooks activity component
+	// Size hooks activity component, stacking its summary columns when vertical
+	m.hooksActivity.SetStacked(vertical)
 	m.hooksActivity.SetSize(cardWidth, contentHeight)
 
 	m.ready = true
 }
 
+// SetDiffTabWidth sets the tab stop width used when rendering the git diff
+func (m *Model) SetDiffTabWidth(width int) {
+	if width <= 0 {
+		width = gitdiffviewer.DefaultTabWidth
+	}
+	m.diffTabWidth = width
+	m.renderDiff()
+}
+
+// SetDiffHighlighting turns language highlighting of the git diff on or off
+func (m *Model) SetDiffHighlighting(enabled bool) {
+	m.diffHighlight = enabled
+	m.renderDiff()
+}
+
+// toggleDiffWrap switches the git diff between soft-wrapping and horizontal scrolling
+func (m *Model) toggleDiffWrap() {
+	m.diffWrap = !m.diffWrap
+	if m.diffWrap {
+		m.cards[1].SetHorizontalStep(0)
+	} else {
+		m.cards[1].SetHorizontalStep(diffHorizontalStep)
+	}
+	m.renderDiff()
+}
+
+// renderDiff re-renders the git diff card for the current width and wrap mode
+func (m *Model) renderDiff() {
+	if len(m.cards) < 2 {
+		return
+	}
+	if m.liveDiff != nil && m.liveDiff.DiffOmitted {
+		m.diffLineStarts = nil
+		m.cards[1].SetContent(m.liveDiff.DiffStat + "\nDiff too large to show while the task runs; it appears once the step finishes.")
+		return
+	}
+	content, lineStarts := gitdiffviewer.RenderWithOptions(m.gitDiff(), gitdiffviewer.Options{
+		Width:     m.diffWidth,
+		TabWidth:  m.diffTabWidth,
+		Wrap:      m.diffWrap,
+		Highlight: m.diffHighlight,
+	})
+	m.diffLineStarts = lineStarts
+	m.cards[1].SetContent(content)
+}
+
+// gitDiff returns the diff shown on the Git Diff tab: the latest streamed one while the
+// task runs, otherwise the task's stored diff
+func (m *Model) gitDiff() string {
+	if m.liveDiff != nil {
+		return m.liveDiff.Diff
+	}
+	if m.task != nil {
+		return m.task.GitDiff
+	}
+	return ""
+}
+
+// SetLiveDiff shows a diff streamed from the running task, keeping the scroll position
+func (m *Model) SetLiveDiff(event protocol.DiffUpdatedEvent) {
+	m.liveDiff = &event
+	m.renderDiff()
+}
+
+// renderedDiffLine maps a raw diff line number to its line in the rendered diff
+func (m *Model) renderedDiffLine(line int) int {
+	if line >= 0 && line < len(m.diffLineStarts) {
+		return m.diffLineStarts[line]
+	}
+	return line
+}
+
 // updateFocus updates the focus state based on active tab
 func (m *Model) updateFocus() {
 	activeTab := m.tabBar.GetActiveTab()
@@ -193,6 +295,12 @@ func (m *Model) updateFocus() {
 // AddAIActivityRecord adds an AI activity record to the hooks activity component
 func (m *Model) AddAIActivityRecord(record *models.AIActivityRecord) {
 	if record != nil {
+		if m.taskPrompt == "" {
+			if prompt, ok := taskPromptFromRecord(record); ok {
+				m.taskPrompt = prompt
+				m.cards[0].SetContent(taskinfocard.RenderWithPrompt(m.task, prompt))
+			}
+		}
 		m.hooksActivity.AddEvent(record)
 		// Update badge on hooks tab with event count
 		count := m.hooksActivity.GetEventCount()
@@ -202,6 +310,19 @@ func (m *Model) AddAIActivityRecord(record *models.AIActivityRecord) {
 	}
 }
 
+// taskPromptFromRecord returns the prompt carried by a human prompt record, if any
+func taskPromptFromRecord(record *models.AIActivityRecord) (string, bool) {
+	if record.EventType != models.AIEventUserPrompt || record.RawPayload == "" {
+		return "", false
+	}
+	entry := adapters.RawEntry{Data: record.GetRawPayloadJSON()}
+	adapter, ok := adapters.Detect(entry) // The record does not keep its source; the payload's shape names it
+	if !ok {
+		return "", false
+	}
+	return adapter.ExtractTaskPrompt([]adapters.RawEntry{entry})
+}
+
 // StartAIStream marks the start of AI activity streaming
 func (m *Model) StartAIStream() {
 	m.hooksActivity.StartStream()
@@ -4,10 +4,16 @@
 package taskdetails
 
 import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/noldarim/noldarim/internal/logger"
 	"github.com/noldarim/noldarim/internal/orchestrator/models"
 	"github.com/noldarim/noldarim/internal/protocol"
+	"github.com/noldarim/noldarim/internal/tui/components/gitdiffviewer"
+	"github.com/noldarim/noldarim/internal/tui/components/toast"
+	"github.com/noldarim/noldarim/internal/tui/editor"
 	"github.com/noldarim/noldarim/internal/tui/messages"
 )
 
@@ -18,49 +24,79 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc", "backspace":
+		switch {
+		case m.promptingCard() >= 0:
+			// A card's jump-to-line prompt takes every key until it closes
+			i := m.promptingCard()
+			m.cards[i], cmd = m.cards[i].Update(msg)
+			return m, cmd
+
+		case key.Matches(msg, m.keys.Back):
 			// Go back to task view
 			return m, func() tea.Msg {
 				return messages.GoBackMsg{}
 			}
-		case "q", "ctrl+c":
+		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 
-		case "tab":
+		case key.Matches(msg, m.keys.NextTab):
 			// Switch to next tab
 			m.tabBar.NextTab()
 			m.updateFocus()
 			return m, nil
 
-		case "shift+tab":
+		case key.Matches(msg, m.keys.PrevTab):
 			// Switch to previous tab
 			m.tabBar.PrevTab()
 			m.updateFocus()
 			return m, nil
 
-		case "1":
+		case key.Matches(msg, m.keys.Tab1):
 			// Switch to Task Info tab
 			m.tabBar.SetActiveTab(0)
 			m.updateFocus()
 			return m, nil
 
-		case "2":
+		case key.Matches(msg, m.keys.Tab2):
 			// Switch to Git Diff tab
 			m.tabBar.SetActiveTab(1)
 			m.updateFocus()
 			return m, nil
 
-		case "3":
+		case key.Matches(msg, m.keys.Tab3):
 			// Switch to Hooks Activity tab
 			m.tabBar.SetActiveTab(2)
 			m.updateFocus()
 			return m, nil
+
+		case key.Matches(msg, m.keys.ToggleWrap) && m.tabBar.GetActiveTab() == 1:
+			// Toggle soft-wrap of the Git Diff tab
+			m.toggleDiffWrap()
+			return m, nil
+
+		case key.Matches(msg, m.keys.OpenEditor) && m.tabBar.GetActiveTab() != 0:
+			// Open the selected file from the task worktree in $EDITOR
+			cmd := m.openInEditor(m.selectedFilePath())
+			return m, cmd
 		}
 
 	case tea.WindowSizeMsg:
 		m.SetSize(msg.Width, msg.Height)
 
+	case messages.FileSelectedMsg:
+		cmd := m.jumpToFileDiff(msg.Path)
+		return m, cmd
+
+	case protocol.WorktreeResolvedEvent:
+		if m.task != nil && msg.TaskID == m.task.ID {
+			cmd := m.handleWorktreeResolved(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case editor.FinishedMsg:
+		return m, editorFinished(msg)
+
 	// Handle AI Activity events
 	// AIActivityRecord implements common.Event directly (no protocol wrapper)
 	case *models.AIActivityRecord:
@@ -99,6 +135,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.EndAIStream(msg.FinalStatus)
 		}
 		return m, nil
+
+	case protocol.DiffUpdatedEvent:
+		if m.task != nil && msg.TaskID == m.task.ID {
+			m.SetLiveDiff(msg)
+		}
+		return m, nil
 	}
 
 	// Forward message to the focused component based on active tab
@@ -121,3 +163,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	return m, tea.Batch(cmds...)
 }
+
+// promptingCard returns the active tab's card while its jump-to-line prompt is open, -1 otherwise
+func (m Model) promptingCard() int {
+	if i := m.tabBar.GetActiveTab(); i < len(m.cards) && m.cards[i].Prompting() {
+		return i
+	}
+	return -1
+}
+
+// jumpToFileDiff switches to the Git Diff tab scrolled to the first hunk of path.
+// Files touched by the agent without resulting changes get a toast instead.
+func (m *Model) jumpToFileDiff(path string) tea.Cmd {
+	if m.task == nil || len(m.cards) < 2 {
+		return nil
+	}
+
+	file, ok := gitdiffviewer.FindFile(gitdiffviewer.ParseDiff(m.gitDiff()), path)
+	if !ok {
+		return toast.Info(fmt.Sprintf("No changes to %s", path))
+	}
+
+	line := file.Line
+	if len(file.Hunks) > 0 {
+		line = file.Hunks[0].Line
+	}
+
+	m.tabBar.SetActiveTab(1)
+	m.updateFocus()
+	m.cards[1].ScrollToLine(m.renderedDiffLine(line))
+	return nil
+}
@@ -58,8 +58,11 @@ func (d TaskDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 
 // renderTaskWithStatus renders a task with its status component on the right
 func (d TaskDelegate) renderTaskWithStatus(m list.Model, _ int, task TaskItem) string {
-	// Get the task title
+	// Get the task title, followed by its labels
 	title := task.TaskTitle
+	if len(task.Labels) > 0 {
+		title += " " + labelPrefix + strings.Join(task.Labels, " "+labelPrefix)
+	}
 
 	// Get the task status component
 	var statusComponent string
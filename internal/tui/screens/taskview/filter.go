@@ -0,0 +1,74 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package taskview
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/list"
+
+	"github.com/noldarim/noldarim/internal/orchestrator/models"
+)
+
+// labelPrefix marks a label in the filter input: "#bug #ui crash" shows tasks labelled
+// both bug AND ui whose title fuzzy-matches "crash"
+const labelPrefix = "#"
+
+// labelSeparator splits the title from the labels in a TaskItem's FilterValue
+const labelSeparator = "\x1f"
+
+// filterTasks is the list's FilterFunc. Label terms must all be present on a task; the
+// remaining text is fuzzy-matched against titles as the default filter does.
+func filterTasks(term string, targets []string) []list.Rank {
+	var labels, words []string
+	for _, field := range strings.Fields(term) {
+		if label, ok := strings.CutPrefix(field, labelPrefix); ok {
+			if label != "" {
+				labels = append(labels, label)
+			}
+			continue
+		}
+		words = append(words, field)
+	}
+	labels = models.NormalizeLabels(labels)
+
+	titles := make([]string, 0, len(targets))
+	indexes := make([]int, 0, len(targets))
+	for i, target := range targets {
+		title, labelText, _ := strings.Cut(target, labelSeparator)
+		task := models.Task{Labels: strings.Fields(labelText)}
+		if !task.HasLabels(labels...) {
+			continue
+		}
+		titles = append(titles, title)
+		indexes = append(indexes, i)
+	}
+
+	if len(words) == 0 {
+		ranks := make([]list.Rank, len(indexes))
+		for i, index := range indexes {
+			ranks[i] = list.Rank{Index: index}
+		}
+		return ranks
+	}
+
+	ranks := list.DefaultFilter(strings.Join(words, " "), titles)
+	for i := range ranks {
+		ranks[i].Index = indexes[ranks[i].Index]
+	}
+	return ranks
+}
+
+// parseLabels reads the labels typed in the task form, separated by spaces or commas. A
+// leading # is accepted, as in the filter.
+func parseLabels(text string) []string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	for i, field := range fields {
+		fields[i] = strings.TrimPrefix(field, labelPrefix)
+	}
+	return models.NormalizeLabels(fields)
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package taskview
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/noldarim/noldarim/internal/tui/keys"
+	"github.com/noldarim/noldarim/internal/tui/layout"
+)
+
+// keyMap holds the task view's bindings, resolved from the keys registry
+type keyMap struct {
+	NextTab key.Binding
+	Tab1    key.Binding
+	Tab2    key.Binding
+	Select  key.Binding
+	New     key.Binding
+	Edit    key.Binding
+	Retry   key.Binding
+	Delete  key.Binding
+	History key.Binding
+	Up      key.Binding
+	Down    key.Binding
+	Back    key.Binding
+	Quit    key.Binding
+}
+
+func newKeyMap() keyMap {
+	return keyMap{
+		NextTab: keys.Bind(keys.NextTab, "switch tabs"),
+		Tab1:    keys.Bind(keys.Tab1, "switch tabs"),
+		Tab2:    keys.Bind(keys.Tab2, "switch tabs"),
+		Select:  keys.Bind(keys.Select, "details"),
+		New:     keys.Get(keys.New),
+		Edit:    keys.Get(keys.Edit),
+		Retry:   keys.Bind(keys.Retry, "retry (failed)"),
+		Delete:  keys.Get(keys.Delete),
+		History: keys.Get(keys.History),
+		Up:      keys.Bind(keys.Up, "previous commit"),
+		Down:    keys.Bind(keys.Down, "next commit"),
+		Back:    keys.Get(keys.Back),
+		Quit:    keys.Get(keys.Quit),
+	}
+}
+
+// helpItems is the footer help shared by both tabs
+func (k keyMap) helpItems() []layout.HelpItem {
+	return keys.HelpItems(k.NextTab, k.Tab1, k.Tab2, k.Select, k.New, k.Edit, k.Retry, k.Delete, k.History, k.Back, k.Quit)
+}
@@ -6,6 +6,7 @@ package taskview
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -24,11 +25,15 @@ type TaskItem struct {
 	TaskTitle string
 	Desc      string
 	Status    models.TaskStatus
+	Labels    []string
 }
 
-// FilterValue returns the value to filter against
+// FilterValue returns the value to filter against: the title, then any labels (see filterTasks)
 func (t TaskItem) FilterValue() string {
-	return t.TaskTitle
+	if len(t.Labels) == 0 {
+		return t.TaskTitle
+	}
+	return t.TaskTitle + labelSeparator + strings.Join(t.Labels, " ")
 }
 
 // Title returns the task title without status indicator (status will be shown separately)
@@ -59,17 +64,25 @@ type Model struct {
 	taskStatuses   map[string]taskstatus.Model    // Task status components (works for both)
 	pendingTasks   map[string]bool                // Track tasks/runs that are pending creation
 	failedTasks    map[string]time.Time           // Track failed tasks/runs with timestamp for cleanup
+	queuedTasks    map[string]*queuedTask         // Tasks waiting under the concurrent task limit, by queue ID
 	showForm       bool
 	form           *huh.Form
 	formTitle      string
 	formDesc       string
-	width          int // Terminal width for layout
-	height         int // Terminal height for layout
+	formLabels     string
+	editingTaskID  string // Task being edited in the form; empty when the form creates a task
+	editingVersion int    // Version of the task being edited, sent so stale edits are rejected
+	statusMessage  string // Error to show in place of the status line, cleared by the next key press
+	width          int    // Terminal width for layout
+	height         int    // Terminal height for layout
 
 	// Tab navigation
 	tabs      []string
 	activeTab int
 
+	// Keybindings, resolved from the keys registry
+	keys keyMap
+
 	// Commit graph related fields
 	commits        []*commitgraph.Commit
 	commitsLoaded  bool
@@ -87,6 +100,7 @@ func NewModel(projectID string, cmdChan chan<- protocol.Command) Model {
 	l.SetShowStatusBar(false)
 	l.SetShowHelp(false)
 	l.SetFilteringEnabled(true)
+	l.Filter = filterTasks
 	l.Title = ""
 
 	m := Model{
@@ -99,6 +113,7 @@ func NewModel(projectID string, cmdChan chan<- protocol.Command) Model {
 		taskStatuses:   make(map[string]taskstatus.Model),
 		pendingTasks:   make(map[string]bool),
 		failedTasks:    make(map[string]time.Time),
+		queuedTasks:    make(map[string]*queuedTask),
 		showForm:       false,
 		width:          80, // Default width
 		height:         24, // Default height
@@ -110,29 +125,48 @@ func NewModel(projectID string, cmdChan chan<- protocol.Command) Model {
 		commitLanes:    make(map[int]int16),
 		currentLane:    0,
 		hashPool:       commitgraph.NewStringPool(),
+		keys:           newKeyMap(),
 	}
 
 	m.initForm()
 	return m
 }
 
-// initForm initializes the huh form for creating new tasks
+// initForm initializes the huh form for creating new tasks, or for editing the title and
+// description of editingTaskID
 func (m *Model) initForm() {
-	m.form = huh.NewForm(
-		huh.NewGroup(
-			huh.NewInput().
-				Key("title").
-				Title("Task Title").
-				Placeholder("Enter task title...").
-				Value(&m.formTitle),
-
-			huh.NewText().
-				Key("description").
-				Title("Task Description").
-				Placeholder("Enter task description...").
-				Value(&m.formDesc),
-		),
-	).WithTheme(huh.ThemeCharm())
+	fields := []huh.Field{
+		huh.NewInput().
+			Key("title").
+			Title("Task Title").
+			Placeholder("Enter task title...").
+			Value(&m.formTitle),
+
+		huh.NewText().
+			Key("description").
+			Title("Task Description").
+			Placeholder("Enter task description...").
+			Value(&m.formDesc),
+	}
+	if m.editingTaskID == "" {
+		fields = append(fields, huh.NewInput().
+			Key("labels").
+			Title("Labels").
+			Placeholder("Optional, e.g. backend urgent").
+			Value(&m.formLabels))
+	}
+	m.form = huh.NewForm(huh.NewGroup(fields...)).WithTheme(huh.ThemeCharm())
+}
+
+// resetForm closes the form and clears its values
+func (m *Model) resetForm() {
+	m.showForm = false
+	m.formTitle = ""
+	m.formDesc = ""
+	m.formLabels = ""
+	m.editingTaskID = ""
+	m.editingVersion = 0
+	m.initForm()
 }
 
 func (m Model) Init() tea.Cmd {
@@ -190,22 +224,15 @@ func (m Model) GetLayoutInfo() layout.LayoutInfo {
 			len(m.tasks), completedCount, commitCount)
 	}
 
-	// Provide help items that work for both tabs
-	helpItems := []layout.HelpItem{
-		{Key: "tab/1/2", Description: "switch tabs"},
-		{Key: "enter", Description: "details"},
-		{Key: "n", Description: "new"},
-		{Key: "r", Description: "retry (failed)"},
-		{Key: "d", Description: "delete"},
-		{Key: "esc", Description: "back"},
-		{Key: "q", Description: "quit"},
+	if m.statusMessage != "" {
+		statusText = m.statusMessage
 	}
 
 	return layout.LayoutInfo{
 		Title:       title,
 		Breadcrumbs: []string{"Projects", projectDisplayName},
 		Status:      statusText,
-		HelpItems:   helpItems,
+		HelpItems:   m.keys.helpItems(),
 	}
 }
 
@@ -237,13 +264,22 @@ func pipelineRunStatusToTaskStatus(status models.PipelineRunStatus) models.TaskS
 	}
 }
 
-// displayItem represents a unified display item (either Task or PipelineRun)
+// queuedTask is a task the orchestrator is holding until a slot frees up
+type queuedTask struct {
+	title    string
+	position int
+	queuedAt time.Time
+}
+
+// displayItem represents a unified display item (a Task, PipelineRun or queued task)
 type displayItem struct {
 	ID        string
 	Title     string
 	Desc      string
 	Status    models.TaskStatus
 	CreatedAt time.Time
+	Queued    bool
+	Labels    []string
 }
 
 // refreshTaskList updates the list items with current tasks/runs and creates/updates taskstatus components
@@ -259,6 +295,7 @@ func (m *Model) refreshTaskList() {
 			Desc:      task.Description,
 			Status:    task.Status,
 			CreatedAt: task.CreatedAt,
+			Labels:    task.Labels,
 		})
 	}
 
@@ -270,6 +307,18 @@ func (m *Model) refreshTaskList() {
 			Desc:      "", // PipelineRun doesn't have description
 			Status:    pipelineRunStatusToTaskStatus(run.Status),
 			CreatedAt: run.CreatedAt,
+			Labels:    run.Labels,
+		})
+	}
+
+	// Add tasks waiting for a slot
+	for id, task := range m.queuedTasks {
+		items = append(items, displayItem{
+			ID:        id,
+			Title:     fmt.Sprintf("%s (#%d in queue)", task.title, task.position),
+			Status:    models.TaskStatusPending,
+			CreatedAt: task.queuedAt,
+			Queued:    true,
 		})
 	}
 
@@ -285,6 +334,7 @@ func (m *Model) refreshTaskList() {
 			TaskTitle: item.Title,
 			Desc:      item.Desc,
 			Status:    item.Status,
+			Labels:    item.Labels,
 		}
 
 		// Create or update task status component
@@ -292,8 +342,10 @@ func (m *Model) refreshTaskList() {
 			// Update existing status component
 			statusModel = statusModel.SetStatus(item.Status)
 
-			// Apply UI state based on pending/failed status
-			if m.pendingTasks[item.ID] {
+			// Apply UI state based on queued/pending/failed status
+			if item.Queued {
+				statusModel = statusModel.SetUIState(taskstatus.UIStateQueued)
+			} else if m.pendingTasks[item.ID] {
 				statusModel = statusModel.SetUIState(taskstatus.UIStatePending)
 			} else if _, isFailed := m.failedTasks[item.ID]; isFailed || item.Status == models.TaskStatusFailed {
 				statusModel = statusModel.SetUIState(taskstatus.UIStateFailed)
@@ -306,8 +358,10 @@ func (m *Model) refreshTaskList() {
 			// Create new status component
 			statusModel := taskstatus.New(item.Title, item.Status)
 
-			// Apply UI state based on pending/failed status
-			if m.pendingTasks[item.ID] {
+			// Apply UI state based on queued/pending/failed status
+			if item.Queued {
+				statusModel = statusModel.SetUIState(taskstatus.UIStateQueued)
+			} else if m.pendingTasks[item.ID] {
 				statusModel = statusModel.SetUIState(taskstatus.UIStatePending)
 			} else if _, isFailed := m.failedTasks[item.ID]; isFailed || item.Status == models.TaskStatusFailed {
 				statusModel = statusModel.SetUIState(taskstatus.UIStateFailed)
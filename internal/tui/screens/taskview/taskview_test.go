@@ -461,6 +461,7 @@ func TestTaskCreationIntegration(t *testing.T) {
 		// Set form values and mark as completed
 		model.formTitle = "New Test Task"
 		model.formDesc = "Test task description"
+		model.formLabels = "#Backend, urgent"
 		model.showForm = true
 		model.initForm()
 		model.form.State = huh.StateCompleted
@@ -492,6 +493,7 @@ func TestTaskCreationIntegration(t *testing.T) {
 		assert.Equal(t, "Test task description", pendingTask.Description)
 		assert.Equal(t, models.TaskStatusPending, pendingTask.Status)
 		assert.Equal(t, projectID, pendingTask.ProjectID)
+		assert.Equal(t, []string{"backend", "urgent"}, pendingTask.Labels)
 		assert.True(t, updatedModel.pendingTasks[tempID], "Task should be marked as pending")
 
 		// Verify CreateTaskCommand was sent
@@ -500,12 +502,14 @@ func TestTaskCreationIntegration(t *testing.T) {
 		assert.Equal(t, projectID, lastCmd.ProjectID)
 		assert.Equal(t, "New Test Task", lastCmd.Title)
 		assert.Equal(t, "Test task description", lastCmd.Description)
+		assert.Equal(t, []string{"backend", "urgent"}, lastCmd.Labels)
 		assert.Empty(t, lastCmd.Metadata.TaskID, "TaskID should be empty - orchestrator computes content-based ID")
 
 		// Form should be hidden after completion
 		assert.False(t, updatedModel.showForm, "Form should be hidden after completion")
 		assert.Empty(t, updatedModel.formTitle, "Form title should be cleared")
 		assert.Empty(t, updatedModel.formDesc, "Form description should be cleared")
+		assert.Empty(t, updatedModel.formLabels, "Form labels should be cleared")
 	})
 
 	t.Run("handling TaskCreatedEvent success case", func(t *testing.T) {
@@ -729,6 +733,54 @@ func TestTaskCreationIntegration(t *testing.T) {
 }
 
 // TestUIStateBadgeRendering tests the UI badge rendering for different states
+func TestTaskEdit(t *testing.T) {
+	capture := testutil.NewCommandCapture()
+	defer capture.Close()
+
+	projectID := "test-project"
+	model := NewModel(projectID, capture.Channel())
+	newModel, _ := testutil.SendMessage(model, protocol.TasksLoadedEvent{
+		ProjectID: projectID,
+		Tasks: map[string]*models.Task{
+			"task-1": {ID: "task-1", Title: "Old title", Description: "Old description", Status: models.TaskStatusCompleted, Version: 4},
+		},
+	})
+	model = newModel.(Model)
+
+	// Edit opens the form pre-filled with the selected task, noting its version
+	newModel, cmd := testutil.SendMessage(model, testutil.KeyPress("E"))
+	model = newModel.(Model)
+	assert.NotNil(t, cmd, "Form init should return a command")
+	assert.True(t, model.showForm)
+	assert.Equal(t, "task-1", model.editingTaskID)
+	assert.Equal(t, 4, model.editingVersion)
+	assert.Equal(t, "Old title", model.formTitle)
+	assert.Equal(t, "Old description", model.formDesc)
+
+	// Completing the form sends an update based on the loaded version
+	model.formTitle = "New title"
+	model.initForm()
+	model.form.State = huh.StateCompleted
+	newModel, _ = testutil.SendMessage(model, tea.KeyMsg{Type: tea.KeyEnter})
+	model = newModel.(Model)
+
+	capture.WaitForCommands(1)
+	update, ok := capture.LastCommand().(protocol.UpdateTaskCommand)
+	assert.True(t, ok, "expected an UpdateTaskCommand, got %T", capture.LastCommand())
+	assert.Equal(t, "task-1", update.TaskID)
+	assert.Equal(t, "New title", update.Title)
+	assert.Equal(t, "Old description", update.Description)
+	assert.Equal(t, 4, update.ExpectedVersion)
+	assert.False(t, model.showForm)
+	assert.Empty(t, model.editingTaskID)
+
+	// A stale edit is reported in the status line without failing the task
+	newModel, _ = testutil.SendMessage(model, protocol.ErrorEvent{Message: "Task was changed elsewhere, reloaded the latest version"})
+	model = newModel.(Model)
+	assert.Contains(t, model.GetLayoutInfo().Status, "changed elsewhere")
+	assert.Equal(t, models.TaskStatusCompleted, model.tasks["task-1"].Status)
+}
+
 func TestUIStateBadgeRendering(t *testing.T) {
 	t.Run("pending task shows PENDING in status component", func(t *testing.T) {
 		capture := testutil.NewCommandCapture()
@@ -1020,3 +1072,75 @@ func TestTaskDelegate(t *testing.T) {
 		assert.Equal(t, "🚀 T", result)
 	})
 }
+
+func TestQueuedTasks(t *testing.T) {
+	projectID := "test-project"
+
+	t.Run("queued task replaces the pending entry and shows its position", func(t *testing.T) {
+		capture := testutil.NewCommandCapture()
+		defer capture.Close()
+		model := NewModel(projectID, capture.Channel())
+		model.tasks["pending-1"] = &models.Task{ID: "pending-1", Title: "Queued task", Status: models.TaskStatusPending}
+		model.pendingTasks["pending-1"] = true
+
+		newModel, _ := model.Update(protocol.TaskQueuedEvent{ProjectID: projectID, QueueID: "queued-1", Title: "Queued task", Position: 2})
+		model = newModel.(Model)
+
+		assert.NotContains(t, model.tasks, "pending-1")
+		items := model.list.Items()
+		assert.Len(t, items, 1)
+		assert.Equal(t, "Queued task (#2 in queue)", items[0].(TaskItem).TaskTitle)
+		assert.Contains(t, model.taskStatuses["queued-1"].View(), "QUEUED")
+
+		newModel, _ = model.Update(protocol.TaskQueuedEvent{ProjectID: projectID, QueueID: "queued-1", Title: "Queued task", Position: 1})
+		model = newModel.(Model)
+		assert.Equal(t, "Queued task (#1 in queue)", model.list.Items()[0].(TaskItem).TaskTitle)
+
+		newModel, _ = model.Update(protocol.TaskDequeuedEvent{ProjectID: projectID, QueueID: "queued-1"})
+		model = newModel.(Model)
+		assert.Empty(t, model.list.Items())
+		assert.NotContains(t, model.taskStatuses, "queued-1")
+	})
+
+	t.Run("d on a queued task cancels it instead of deleting", func(t *testing.T) {
+		capture := testutil.NewCommandCapture()
+		defer capture.Close()
+		model := NewModel(projectID, capture.Channel())
+
+		newModel, _ := model.Update(protocol.TaskQueuedEvent{ProjectID: projectID, QueueID: "queued-7", Title: "Waiting", Position: 1})
+		_, _ = testutil.SendMessage(newModel, testutil.KeyPress("d"))
+
+		assert.Eventually(t, func() bool { return capture.CommandCount() > 0 }, time.Second, 10*time.Millisecond)
+		cmd, ok := capture.LastCommand().(protocol.CancelQueuedTaskCommand)
+		assert.True(t, ok)
+		assert.Equal(t, projectID, cmd.ProjectID)
+		assert.Equal(t, "queued-7", cmd.QueueID)
+	})
+}
+
+func TestFilterTasks(t *testing.T) {
+	items := []TaskItem{
+		{ID: "t1", TaskTitle: "Fix crash on start", Labels: []string{"bug", "ui"}},
+		{ID: "t2", TaskTitle: "Add export", Labels: []string{"feature", "ui"}},
+		{ID: "t3", TaskTitle: "Fix flaky test"},
+	}
+	targets := make([]string, len(items))
+	for i, item := range items {
+		targets[i] = item.FilterValue()
+	}
+	matched := func(term string) []string {
+		var ids []string
+		for _, rank := range filterTasks(term, targets) {
+			ids = append(ids, items[rank.Index].ID)
+		}
+		return ids
+	}
+
+	assert.ElementsMatch(t, []string{"t1", "t2"}, matched("#ui"))
+	assert.ElementsMatch(t, []string{"t1"}, matched("#ui #BUG"), "labels combine with AND")
+	assert.Empty(t, matched("#bug #feature"))
+	assert.ElementsMatch(t, []string{"t1", "t3"}, matched("fix"))
+	assert.ElementsMatch(t, []string{"t1"}, matched("fix #ui"))
+	assert.Empty(t, matched("export #bug"))
+	assert.Equal(t, "Fix crash on start\x1fbug ui", items[0].FilterValue())
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"github.com/rs/zerolog"
@@ -34,10 +35,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch msg.String() {
 			case "esc":
 				// Close form without saving
-				m.showForm = false
-				m.formTitle = ""
-				m.formDesc = ""
-				m.initForm()
+				m.resetForm()
 				return m, nil
 			case "ctrl+c":
 				return m, tea.Quit
@@ -63,6 +61,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				description = m.formDesc
 			}
 
+			if m.editingTaskID != "" {
+				update := protocol.UpdateTaskCommand{
+					Metadata:        protocol.Metadata{TaskID: m.editingTaskID, Version: protocol.CurrentProtocolVersion},
+					ProjectID:       m.projectID,
+					TaskID:          m.editingTaskID,
+					Title:           title,
+					Description:     description,
+					ExpectedVersion: m.editingVersion,
+				}
+				go func() { m.cmdChan <- update }()
+				getTUILog().Info().Str("task_id", update.TaskID).Int("expected_version", update.ExpectedVersion).Msg("Task update requested")
+				m.resetForm()
+				return m, cmd
+			}
+
+			labelText := m.form.GetString("labels")
+			if labelText == "" {
+				labelText = m.formLabels
+			}
+			labels := parseLabels(labelText)
+
 			// Use a temporary display ID for optimistic UI
 			// Real task ID will be computed by orchestrator from content hash (including current commit)
 			tempDisplayID := fmt.Sprintf("pending-%d", time.Now().UnixNano())
@@ -74,6 +93,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				Description: description,
 				Status:      models.TaskStatusPending,
 				ProjectID:   m.projectID,
+				Labels:      labels,
 			}
 
 			// Add to tasks and mark as pending
@@ -90,16 +110,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					ProjectID:   m.projectID,
 					Title:       title,
 					Description: description,
+					Labels:      labels,
 					// BaseCommitSHA is empty - orchestrator will get current HEAD from git service
 				}
 				m.cmdChan <- cmd
 			}()
 			getTUILog().Info().Str("title", title).Str("description", description).Msg("Task creation requested")
-			// Reset form state
-			m.showForm = false
-			m.formTitle = ""
-			m.formDesc = ""
-			m.initForm()
+			m.resetForm()
 		}
 
 		return m, cmd
@@ -108,9 +125,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Normal list handling when form is not shown
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		m.statusMessage = ""
+
 		// Tab switching
-		switch msg.String() {
-		case "tab":
+		switch {
+		case key.Matches(msg, m.keys.NextTab):
 			// Cycle through tabs
 			m.activeTab = (m.activeTab + 1) % len(m.tabs)
 			// Load commits when switching to commits tab for the first time
@@ -123,11 +142,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}()
 			}
 			return m, nil
-		case "1":
+		case key.Matches(msg, m.keys.Tab1):
 			// Switch to Tasks tab
 			m.activeTab = 0
 			return m, nil
-		case "2":
+		case key.Matches(msg, m.keys.Tab2):
 			// Switch to Commits tab
 			m.activeTab = 1
 			// Load commits when switching to commits tab for the first time
@@ -140,13 +159,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}()
 			}
 			return m, nil
+		case key.Matches(msg, m.keys.History):
+			return m, func() tea.Msg {
+				return messages.GoToPipelineHistoryMsg{ProjectID: m.projectID}
+			}
 		}
 
 		// Tab-specific navigation
 		if m.activeTab == 0 {
 			// Tasks tab navigation
-			switch msg.String() {
-			case "enter":
+			switch {
+			case key.Matches(msg, m.keys.Select):
 				// Handle task selection - go to task details
 				if selectedItem := m.list.SelectedItem(); selectedItem != nil {
 					if taskItem, ok := selectedItem.(TaskItem); ok {
@@ -161,11 +184,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 					}
 				}
-			case "n":
+			case key.Matches(msg, m.keys.New):
 				// Show form to create new task
 				m.showForm = true
 				return m, m.form.Init()
-			case "r":
+			case key.Matches(msg, m.keys.Edit):
+				// Edit the selected task, based on the version loaded with it
+				if selectedItem := m.list.SelectedItem(); selectedItem != nil {
+					if taskItem, ok := selectedItem.(TaskItem); ok {
+						if task, exists := m.tasks[taskItem.ID]; exists && !m.pendingTasks[task.ID] {
+							m.editingTaskID = task.ID
+							m.editingVersion = task.Version
+							m.formTitle = task.Title
+							m.formDesc = task.Description
+							m.initForm()
+							m.showForm = true
+							return m, m.form.Init()
+						}
+					}
+				}
+			case key.Matches(msg, m.keys.Retry):
 				// Retry selected failed task by re-sending CreateTaskCommand with same taskID
 				// CreateTaskWorkflow is idempotent and will resume from where it failed
 				if selectedItem := m.list.SelectedItem(); selectedItem != nil {
@@ -200,10 +238,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 					}
 				}
-			case "d":
-				// Delete selected task
+			case key.Matches(msg, m.keys.Delete):
+				// Delete selected task; a queued task is cancelled instead, as it has nothing to delete yet
 				if selectedItem := m.list.SelectedItem(); selectedItem != nil {
 					if taskItem, ok := selectedItem.(TaskItem); ok {
+						if _, queued := m.queuedTasks[taskItem.ID]; queued {
+							go func() {
+								m.cmdChan <- protocol.CancelQueuedTaskCommand{
+									ProjectID: m.projectID,
+									QueueID:   taskItem.ID,
+								}
+							}()
+							break
+						}
 						go func() {
 							m.cmdChan <- protocol.DeleteTaskCommand{
 								ProjectID: m.projectID,
@@ -212,33 +259,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}()
 					}
 				}
-			case "esc", "backspace":
+			case key.Matches(msg, m.keys.Back):
 				// Go back to project list
 				return m, func() tea.Msg {
 					return messages.GoBackMsg{}
 				}
-			case "q", "ctrl+c":
+			case key.Matches(msg, m.keys.Quit):
 				return m, tea.Quit
 			}
 		} else if m.activeTab == 1 {
 			// Commits tab navigation
-			switch msg.String() {
-			case "up", "k":
+			switch {
+			case key.Matches(msg, m.keys.Up):
 				// Move to previous commit
 				if m.selectedCommit > 0 {
 					m.selectedCommit--
 				}
-			case "down", "j":
+			case key.Matches(msg, m.keys.Down):
 				// Move to next commit
 				if m.selectedCommit < len(m.commits)-1 {
 					m.selectedCommit++
 				}
-			case "esc", "backspace":
+			case key.Matches(msg, m.keys.Back):
 				// Go back to project list
 				return m, func() tea.Msg {
 					return messages.GoBackMsg{}
 				}
-			case "q", "ctrl+c":
+			case key.Matches(msg, m.keys.Quit):
 				return m, tea.Quit
 			}
 		}
@@ -404,6 +451,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.taskStatuses[msg.TaskID] = statusModel
 			}
 
+			m.refreshTaskList()
+		} else {
+			// Not tied to a task, e.g. an edit rejected as stale; the task reload that
+			// follows it brings in the current version
+			m.statusMessage = "Error: " + msg.Message
+		}
+
+	case protocol.TaskQueuedEvent:
+		if msg.ProjectID == m.projectID {
+			if task, exists := m.queuedTasks[msg.QueueID]; exists {
+				// Moved up the queue
+				task.position = msg.Position
+			} else {
+				// The optimistic pending entry is replaced by the queued one
+				for id, task := range m.tasks {
+					if strings.HasPrefix(id, "pending-") && task.Title == msg.Title {
+						delete(m.tasks, id)
+						delete(m.pendingTasks, id)
+						delete(m.taskStatuses, id)
+						break
+					}
+				}
+				m.queuedTasks[msg.QueueID] = &queuedTask{title: msg.Title, position: msg.Position, queuedAt: time.Now()}
+			}
+			m.refreshTaskList()
+		}
+
+	case protocol.TaskDequeuedEvent:
+		if msg.ProjectID == m.projectID {
+			// A started task comes back as a pipeline run via PipelineRunStartedEvent
+			delete(m.queuedTasks, msg.QueueID)
+			delete(m.taskStatuses, msg.QueueID)
 			m.refreshTaskList()
 		}
 
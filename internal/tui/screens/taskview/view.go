@@ -117,30 +117,15 @@ func (m Model) renderCommitsTabContent() string {
 		selectedHash = m.commits[m.selectedCommit].HashPtr()
 	}
 
-	// Create style function for coloring branches
-	branchColors := []string{"1", "2", "3", "4", "5", "6", "9", "10", "11", "12", "13", "14"}
-	colorMap := make(map[*string]string)
-
-	// Assign colors based on lane positions
-	positionColors := make(map[int16]string)
-	nextColorIndex := 0
-
-	for i, commit := range m.commits {
-		if lane, exists := m.commitLanes[i]; exists {
-			if _, hasColor := positionColors[lane]; !hasColor {
-				positionColors[lane] = branchColors[nextColorIndex%len(branchColors)]
-				nextColorIndex++
-			}
-			colorMap[commit.HashPtr()] = positionColors[lane]
-		}
-	}
+	// Create style function for coloring branches by lineage
+	colorMap := commitgraph.AssignBranchColors(m.commits)
 
 	getStyle := func(c *commitgraph.Commit) *lipgloss.Style {
-		color := colorMap[c.HashPtr()]
-		if color == "" {
+		color, ok := colorMap[c.HashPtr()]
+		if !ok {
 			color = "7" // Default gray
 		}
-		s := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+		s := lipgloss.NewStyle().Foreground(color)
 		return &s
 	}
 
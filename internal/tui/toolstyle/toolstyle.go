@@ -0,0 +1,140 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package toolstyle is the registry of per-tool icons and colors shared by the activity
+// feeds. Built-in styles cover the common agent tools; config.TUI.ToolStyles overrides
+// them. Colors are dropped when NO_COLOR is set.
+package toolstyle
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultKey names the style used for tools without one of their own
+const DefaultKey = "default"
+
+// Style is the icon and color shown next to a tool's name
+type Style struct {
+	Icon  string
+	Color string
+}
+
+// defaults lists the built-in styles, keyed by lowercase tool name
+var defaults = map[string]Style{
+	DefaultKey: {Icon: "▸", Color: "75"},
+	"bash":     {Icon: "$", Color: "214"},
+	"read":     {Icon: "◎", Color: "75"},
+	"edit":     {Icon: "±", Color: "178"},
+	"write":    {Icon: "✎", Color: "178"},
+	"grep":     {Icon: "⌕", Color: "141"},
+	"glob":     {Icon: "⌕", Color: "141"},
+	"task":     {Icon: "↳", Color: "86"},
+}
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+var (
+	registryMu sync.RWMutex
+	registry   = copyStyles(defaults)
+)
+
+// Configure replaces the registry with the defaults plus overrides, keyed by tool name.
+// Empty fields keep the built-in (or default) icon or color; invalid colors are rejected
+// so typos in config surface at startup.
+func Configure(overrides map[string]Style) error {
+	styles := copyStyles(defaults)
+
+	for name, override := range overrides {
+		key := normalize(name)
+		if key == "" {
+			return fmt.Errorf("tool style with an empty tool name")
+		}
+		if override.Color != "" && !validColor(override.Color) {
+			return fmt.Errorf("tool style %q: invalid color %q (use an ANSI number 0-255 or #rrggbb)", name, override.Color)
+		}
+
+		style := styles[key]
+		if override.Icon != "" {
+			style.Icon = override.Icon
+		}
+		if override.Color != "" {
+			style.Color = override.Color
+		}
+		styles[key] = style
+	}
+
+	registryMu.Lock()
+	registry = styles
+	registryMu.Unlock()
+	return nil
+}
+
+// Lookup returns the style for toolName. Unknown tools, and fields a configured tool
+// leaves empty, use the default style.
+func Lookup(toolName string) Style {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	fallback := registry[DefaultKey]
+	style, ok := registry[normalize(toolName)]
+	if !ok {
+		return fallback
+	}
+	if style.Icon == "" {
+		style.Icon = fallback.Icon
+	}
+	if style.Color == "" {
+		style.Color = fallback.Color
+	}
+	return style
+}
+
+// Icon renders toolName's icon in its color
+func Icon(toolName string) string {
+	style := Lookup(toolName)
+	return colorize(style.Color, style.Icon)
+}
+
+// Name renders toolName in its color
+func Name(toolName string) string {
+	return colorize(Lookup(toolName).Color, toolName)
+}
+
+// Render renders toolName's icon followed by its name
+func Render(toolName string) string {
+	return Icon(toolName) + " " + Name(toolName)
+}
+
+// colorize renders text in color unless NO_COLOR is set (see https://no-color.org)
+func colorize(color, text string) string {
+	if color == "" || os.Getenv("NO_COLOR") != "" {
+		return text
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(text)
+}
+
+func validColor(color string) bool {
+	if hexColorPattern.MatchString(color) {
+		return true
+	}
+	n, err := strconv.Atoi(color)
+	return err == nil && n >= 0 && n <= 255
+}
+
+func normalize(toolName string) string {
+	return strings.ToLower(strings.TrimSpace(toolName))
+}
+
+func copyStyles(src map[string]Style) map[string]Style {
+	dst := make(map[string]Style, len(src))
+	for name, style := range src {
+		dst[name] = style
+	}
+	return dst
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2025-2026 Noldarim
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package toolstyle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup_BuiltInAndUnknownTools(t *testing.T) {
+	assert.Equal(t, Style{Icon: "$", Color: "214"}, Lookup("Bash"))
+	assert.Equal(t, Lookup("Bash"), Lookup("bash"), "lookup ignores case")
+	assert.Equal(t, defaults[DefaultKey], Lookup("mcp__github__create_issue"))
+}
+
+func TestConfigure_Overrides(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, Configure(nil)) })
+
+	require.NoError(t, Configure(map[string]Style{
+		"bash":       {Color: "#ff8800"},
+		"WebFetch":   {Icon: "⇣"},
+		DefaultKey:   {Icon: "•"},
+		"NotebookRW": {Color: "33"},
+	}))
+
+	assert.Equal(t, Style{Icon: "$", Color: "#ff8800"}, Lookup("Bash"), "unset icon keeps the built-in one")
+	assert.Equal(t, Style{Icon: "⇣", Color: "75"}, Lookup("WebFetch"), "unset color falls back to the default")
+	assert.Equal(t, Style{Icon: "•", Color: "33"}, Lookup("NotebookRW"))
+	assert.Equal(t, Style{Icon: "•", Color: "75"}, Lookup("Unknown"))
+}
+
+func TestConfigure_RejectsInvalidColor(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, Configure(nil)) })
+
+	require.NoError(t, Configure(map[string]Style{"Read": {Color: "33"}}))
+	err := Configure(map[string]Style{"Read": {Color: "blue"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Read")
+	assert.Equal(t, "33", Lookup("Read").Color, "a rejected configuration leaves the registry unchanged")
+
+	assert.Error(t, Configure(map[string]Style{"Read": {Color: "256"}}))
+	assert.Error(t, Configure(map[string]Style{" ": {Icon: "x"}}))
+}
+
+func TestRender_NoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	assert.Equal(t, "$ Bash", Render("Bash"))
+	assert.Equal(t, "▸ Unknown", Render("Unknown"))
+}